@@ -0,0 +1,333 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// +kubebuilder:validation:Enum=">";">=";"<";"<=";"="
+type AlarmComparisonOperator string
+
+// +kubebuilder:validation:Enum=average;max;min;sum;variance
+type AlarmFilter string
+
+const (
+	AlarmFilterAverage  AlarmFilter = "average"
+	AlarmFilterMax      AlarmFilter = "max"
+	AlarmFilterMin      AlarmFilter = "min"
+	AlarmFilterSum      AlarmFilter = "sum"
+	AlarmFilterVariance AlarmFilter = "variance"
+)
+
+// AlarmLevel is the severity of an alarm: 1 (critical), 2 (major), 3 (minor)
+// or 4 (informational).
+// +kubebuilder:validation:Minimum=1
+// +kubebuilder:validation:Maximum=4
+type AlarmLevel int
+
+// AlarmRuleMetric identifies the Cloud Eye metric an alarm rule watches
+type AlarmRuleMetric struct {
+	// Namespace is the namespace of the metric (e.g. SYS.ECS, SYS.NAT or
+	// SYS.VPC). It must match the kind of the watched resource.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Pattern=`^[A-Za-z][A-Za-z0-9_]*\.[A-Za-z][A-Za-z0-9_]*$`
+	Namespace string `json:"namespace"`
+
+	// Name is the name of the metric (e.g. cpu_util, snat_connection or
+	// upstream_bandwidth_usage)
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=64
+	Name string `json:"name"`
+}
+
+// AlarmRuleResource defines the resource an alarm rule watches. The metric
+// dimension is derived from the kind of the resource. Exactly one of
+// NATGateway, PublicIP or Instance must be specified.
+// +kubebuilder:validation:XValidation:rule="(has(self.natGateway)?1:0)+(has(self.publicIP)?1:0)+(has(self.instance)?1:0)==1",message="exactly one of natGateway, publicIP or instance must be set"
+type AlarmRuleResource struct {
+	// NATGateway is the NAT gateway to watch, using the nat_gateway_id
+	// dimension
+	// +optional
+	NATGateway *NATGatewayDependency `json:"natGateway,omitempty"`
+
+	// PublicIP is the public IP to watch, using the publicip_id dimension
+	// +optional
+	PublicIP *PublicIPDependency `json:"publicIP,omitempty"`
+
+	// Instance is the instance to watch, using the instance_id dimension
+	// +optional
+	Instance *InstanceDependency `json:"instance,omitempty"`
+}
+
+// AlarmRuleCondition defines when an alarm is triggered
+type AlarmRuleCondition struct {
+	// ComparisonOperator compares the aggregated metric against the value
+	// +kubebuilder:validation:Required
+	ComparisonOperator AlarmComparisonOperator `json:"comparisonOperator"`
+
+	// Value is the threshold the metric is compared against
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Pattern=`^-?[0-9]+(\.[0-9]+)?$`
+	Value string `json:"value"`
+
+	// Unit is the unit of the value (e.g. %, count or bit/s)
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxLength=32
+	Unit string `json:"unit,omitempty"`
+
+	// Filter is the aggregation applied to the metric within a period
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=average
+	Filter AlarmFilter `json:"filter,omitempty"`
+
+	// Period is the aggregation period in seconds. A period of 1 uses the
+	// raw data.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=1;300;1200;3600;14400;86400
+	// +kubebuilder:default=300
+	Period int `json:"period,omitempty"`
+
+	// Count is the number of consecutive periods the condition has to be met
+	// to trigger the alarm
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=5
+	// +kubebuilder:default=3
+	Count int `json:"count,omitempty"`
+
+	// SuppressDuration is the interval in seconds in which a triggered alarm
+	// is not sent again. A value of 0 sends the alarm only once.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=0;300;600;900;1800;3600;10800;21600;43200;86400
+	SuppressDuration int `json:"suppressDuration,omitempty"`
+}
+
+// AlarmRuleSpec defines the desired state of AlarmRule. The Cloud Eye API
+// can not update an alarm rule, so everything except Enabled is immutable.
+type AlarmRuleSpec struct {
+	// ProviderConfigRef references the ProviderConfig to use for authentication
+	// +kubebuilder:validation:Required
+	ProviderConfigRef ProviderConfigReference `json:"providerConfigRef"`
+
+	// Description is the description of the alarm rule
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxLength=256
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="description is immutable"
+	Description string `json:"description,omitempty"`
+
+	// Metric is the metric the alarm rule watches
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="metric is immutable"
+	Metric AlarmRuleMetric `json:"metric"`
+
+	// Resource is the resource the metric is watched for
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="resource is immutable"
+	Resource AlarmRuleResource `json:"resource"`
+
+	// Condition defines when the alarm is triggered
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="condition is immutable"
+	Condition AlarmRuleCondition `json:"condition"`
+
+	// Level is the severity of the alarm
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=2
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="level is immutable"
+	Level AlarmLevel `json:"level,omitempty"`
+
+	// Enabled defines whether the alarm rule is evaluated
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=true
+	Enabled bool `json:"enabled"`
+
+	// AlarmActions are the SMN topics notified when the alarm is triggered
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxItems=5
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="alarmActions is immutable"
+	AlarmActions []TopicDependency `json:"alarmActions,omitempty"`
+
+	// OKActions are the SMN topics notified when the alarm is cleared
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxItems=5
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="okActions is immutable"
+	OKActions []TopicDependency `json:"okActions,omitempty"`
+
+	// WriteConnectionDetailsToRef specifies the Secret or ConfigMap the outputs
+	// of the resource are written to
+	// +kubebuilder:validation:Optional
+	WriteConnectionDetailsToRef *ConnectionDetailsReference `json:"writeConnectionDetailsToRef,omitempty"`
+
+	// OrphanOnDelete prevents deletion of the external resource when the CR is deleted
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=false
+	OrphanOnDelete bool `json:"orphanOnDelete,omitempty"`
+
+	// NotFoundPolicy defines how the resource reacts when the external
+	// resource was deleted out-of-band
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=Recreate
+	NotFoundPolicy NotFoundPolicy `json:"notFoundPolicy,omitempty"`
+}
+
+// AlarmRuleDependenciesResolved contains the resolved IDs for alarm rule
+// dependencies
+type AlarmRuleDependenciesResolved struct {
+	// NATGatewayID is the resolved NATGateway ID the alarm rule watches
+	NATGatewayID string `json:"natGatewayID,omitempty"`
+	// PublicIPID is the resolved PublicIP ID the alarm rule watches
+	PublicIPID string `json:"publicIPID,omitempty"`
+	// InstanceID is the resolved Instance ID the alarm rule watches
+	InstanceID string `json:"instanceID,omitempty"`
+	// AlarmTopicURNs are the resolved Topic URNs notified when the alarm is
+	// triggered
+	AlarmTopicURNs []string `json:"alarmTopicURNs,omitempty"`
+	// OKTopicURNs are the resolved Topic URNs notified when the alarm is
+	// cleared
+	OKTopicURNs []string `json:"okTopicURNs,omitempty"`
+}
+
+// AlarmRuleStatus defines the observed state of AlarmRule.
+type AlarmRuleStatus struct {
+	// Conditions represent the latest available observations of the AlarmRule's state
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// BlockedBy lists the chain of unready resources the resource is waiting
+	// for, ending with the root cause
+	// +optional
+	BlockedBy []BlockingResource `json:"blockedBy,omitempty"`
+
+	// ExternalID is the provider's ID for this AlarmRule
+	// +optional
+	ExternalID string `json:"externalID,omitempty"`
+
+	// ResolvedDependencies contains the resolved IDs for alarm rule dependencies
+	// +optional
+	ResolvedDependencies AlarmRuleDependenciesResolved `json:"resolvedDependencies"`
+
+	// AlarmState is the observed state of the alarm (ok, alarm or
+	// insufficient_data)
+	// +optional
+	AlarmState string `json:"alarmState,omitempty"`
+
+	// ObservedGeneration reflects the generation of the most recently observed AlarmRule spec
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// LastSyncTime is the timestamp of the last successful sync with the provider
+	// +optional
+	LastSyncTime *metav1.Time `json:"lastSyncTime,omitempty"`
+
+	// LastAppliedSpec caches the spec that was successfully applied to the
+	// external resource. It is used to detect changes to immutable fields.
+	// +optional
+	LastAppliedSpec *AlarmRuleSpec `json:"lastAppliedSpec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Namespaced,categories=monitoring
+// +kubebuilder:printcolumn:name="Namespace",type=string,JSONPath=`.spec.metric.namespace`
+// +kubebuilder:printcolumn:name="Metric",type=string,JSONPath=`.spec.metric.name`
+// +kubebuilder:printcolumn:name="State",type=string,JSONPath=`.status.alarmState`
+// +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
+// +kubebuilder:printcolumn:name="ExternalID",type=string,JSONPath=`.status.externalID`,priority=1
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// AlarmRule is the Schema for the alarmrules API
+type AlarmRule struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty,omitzero"`
+
+	Spec   AlarmRuleSpec   `json:"spec"`
+	Status AlarmRuleStatus `json:"status,omitempty"`
+}
+
+// GetProviderConfigRef returns the ProviderConfig used for authentication.
+func (ar *AlarmRule) GetProviderConfigRef() ProviderConfigReference {
+	return ar.Spec.ProviderConfigRef
+}
+
+// GetNotFoundPolicy returns how a missing external resource is handled.
+func (ar *AlarmRule) GetNotFoundPolicy() NotFoundPolicy {
+	return ar.Spec.NotFoundPolicy
+}
+
+// GetOrphanOnDelete returns whether the external resource is kept on deletion.
+func (ar *AlarmRule) GetOrphanOnDelete() bool {
+	return ar.Spec.OrphanOnDelete
+}
+
+// GetConnectionDetailsRef returns the Secret or ConfigMap the outputs are written to.
+func (ar *AlarmRule) GetConnectionDetailsRef() *ConnectionDetailsReference {
+	return ar.Spec.WriteConnectionDetailsToRef
+}
+
+// GetConditions returns the conditions of the status.
+func (ar *AlarmRule) GetConditions() *[]metav1.Condition {
+	return &ar.Status.Conditions
+}
+
+// GetExternalID returns the provider's ID.
+func (ar *AlarmRule) GetExternalID() string {
+	return ar.Status.ExternalID
+}
+
+// SetExternalID sets the provider's ID.
+func (ar *AlarmRule) SetExternalID(id string) {
+	ar.Status.ExternalID = id
+}
+
+// GetLastSyncTime returns the time of the last successful sync.
+func (ar *AlarmRule) GetLastSyncTime() *metav1.Time {
+	return ar.Status.LastSyncTime
+}
+
+// SetLastSyncTime sets the time of the last successful sync.
+func (ar *AlarmRule) SetLastSyncTime(t *metav1.Time) {
+	ar.Status.LastSyncTime = t
+}
+
+// HasLastAppliedSpec returns whether a spec was applied before.
+func (ar *AlarmRule) HasLastAppliedSpec() bool {
+	return ar.Status.LastAppliedSpec != nil
+}
+
+// SetLastAppliedSpec records the current spec as applied.
+func (ar *AlarmRule) SetLastAppliedSpec() {
+	ar.Status.LastAppliedSpec = ar.Spec.DeepCopy()
+}
+
+// ResetLastAppliedSpec clears the applied spec.
+func (ar *AlarmRule) ResetLastAppliedSpec() {
+	ar.Status.LastAppliedSpec = nil
+}
+
+// SetBlockedBy sets the chain of unready resources the resource waits for.
+func (ar *AlarmRule) SetBlockedBy(blockers []BlockingResource) {
+	ar.Status.BlockedBy = blockers
+}
+
+// +kubebuilder:object:root=true
+
+// AlarmRuleList contains a list of AlarmRule
+type AlarmRuleList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []AlarmRule `json:"items"`
+}
+
+// GetItems returns the list of items as a slice of client.Object.
+func (al *AlarmRuleList) GetItems() []client.Object {
+	items := make([]client.Object, len(al.Items))
+	for i := range al.Items {
+		items[i] = &al.Items[i]
+	}
+	return items
+}
+
+func init() {
+	SchemeBuilder.Register(&AlarmRule{}, &AlarmRuleList{})
+}
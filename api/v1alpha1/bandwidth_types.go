@@ -0,0 +1,186 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// +kubebuilder:validation:Enum=Bandwidth;Traffic
+type BandwidthChargeMode string
+
+const (
+	BandwidthChargeModeBandwidth BandwidthChargeMode = "Bandwidth"
+	BandwidthChargeModeTraffic   BandwidthChargeMode = "Traffic"
+)
+
+// BandwidthSpec defines the desired state of Bandwidth
+type BandwidthSpec struct {
+	// ProviderConfigRef references the ProviderConfig to use for authentication
+	// +kubebuilder:validation:Required
+	ProviderConfigRef ProviderConfigReference `json:"providerConfigRef"`
+
+	// Size is the bandwidth size in Mbit/s
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Minimum=1
+	Size int `json:"size"`
+
+	// ChargeMode is the billing mode of the bandwidth (Bandwidth or Traffic)
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=Bandwidth
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="chargeMode is immutable"
+	ChargeMode BandwidthChargeMode `json:"chargeMode,omitempty"`
+
+	// WriteConnectionDetailsToRef specifies the Secret or ConfigMap the outputs
+	// of the resource are written to
+	// +kubebuilder:validation:Optional
+	WriteConnectionDetailsToRef *ConnectionDetailsReference `json:"writeConnectionDetailsToRef,omitempty"`
+
+	// OrphanOnDelete prevents deletion of the external resource when the CR is deleted
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=false
+	OrphanOnDelete bool `json:"orphanOnDelete,omitempty"`
+
+	// NotFoundPolicy defines how the resource reacts when the external
+	// resource was deleted out-of-band
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=Recreate
+	NotFoundPolicy NotFoundPolicy `json:"notFoundPolicy,omitempty"`
+}
+
+// BandwidthStatus defines the observed state of Bandwidth.
+type BandwidthStatus struct {
+	// Conditions represent the latest available observations of the Bandwidth's state
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// BlockedBy lists the chain of unready resources the resource is waiting
+	// for, ending with the root cause
+	// +optional
+	BlockedBy []BlockingResource `json:"blockedBy,omitempty"`
+
+	// ExternalID is the provider's ID for this Bandwidth
+	// +optional
+	ExternalID string `json:"externalID,omitempty"`
+
+	// PublicIPIDs are the IDs of the public IPs using this bandwidth
+	// +optional
+	PublicIPIDs []string `json:"publicIPIDs,omitempty"`
+
+	// ObservedGeneration reflects the generation of the most recently observed Bandwidth spec
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// LastSyncTime is the timestamp of the last successful sync with the provider
+	// +optional
+	LastSyncTime *metav1.Time `json:"lastSyncTime,omitempty"`
+
+	// LastAppliedSpec caches the spec that was successfully applied to the
+	// external resource. It is used to detect changes to immutable fields.
+	// +optional
+	LastAppliedSpec *BandwidthSpec `json:"lastAppliedSpec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Namespaced,categories=networking
+// +kubebuilder:printcolumn:name="Size",type=integer,JSONPath=`.spec.size`
+// +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
+// +kubebuilder:printcolumn:name="ExternalID",type=string,JSONPath=`.status.externalID`,priority=1
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// Bandwidth is the Schema for the bandwidths API. It represents a shared
+// bandwidth, which can be used by multiple public IPs.
+type Bandwidth struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty,omitzero"`
+
+	Spec   BandwidthSpec   `json:"spec"`
+	Status BandwidthStatus `json:"status,omitempty"`
+}
+
+// GetProviderConfigRef returns the ProviderConfig used for authentication.
+func (b *Bandwidth) GetProviderConfigRef() ProviderConfigReference {
+	return b.Spec.ProviderConfigRef
+}
+
+// GetNotFoundPolicy returns how a missing external resource is handled.
+func (b *Bandwidth) GetNotFoundPolicy() NotFoundPolicy {
+	return b.Spec.NotFoundPolicy
+}
+
+// GetOrphanOnDelete returns whether the external resource is kept on deletion.
+func (b *Bandwidth) GetOrphanOnDelete() bool {
+	return b.Spec.OrphanOnDelete
+}
+
+// GetConnectionDetailsRef returns the Secret or ConfigMap the outputs are written to.
+func (b *Bandwidth) GetConnectionDetailsRef() *ConnectionDetailsReference {
+	return b.Spec.WriteConnectionDetailsToRef
+}
+
+// GetConditions returns the conditions of the status.
+func (b *Bandwidth) GetConditions() *[]metav1.Condition {
+	return &b.Status.Conditions
+}
+
+// GetExternalID returns the provider's ID.
+func (b *Bandwidth) GetExternalID() string {
+	return b.Status.ExternalID
+}
+
+// SetExternalID sets the provider's ID.
+func (b *Bandwidth) SetExternalID(id string) {
+	b.Status.ExternalID = id
+}
+
+// GetLastSyncTime returns the time of the last successful sync.
+func (b *Bandwidth) GetLastSyncTime() *metav1.Time {
+	return b.Status.LastSyncTime
+}
+
+// SetLastSyncTime sets the time of the last successful sync.
+func (b *Bandwidth) SetLastSyncTime(t *metav1.Time) {
+	b.Status.LastSyncTime = t
+}
+
+// HasLastAppliedSpec returns whether a spec was applied before.
+func (b *Bandwidth) HasLastAppliedSpec() bool {
+	return b.Status.LastAppliedSpec != nil
+}
+
+// SetLastAppliedSpec records the current spec as applied.
+func (b *Bandwidth) SetLastAppliedSpec() {
+	b.Status.LastAppliedSpec = b.Spec.DeepCopy()
+}
+
+// ResetLastAppliedSpec clears the applied spec.
+func (b *Bandwidth) ResetLastAppliedSpec() {
+	b.Status.LastAppliedSpec = nil
+}
+
+// SetBlockedBy sets the chain of unready resources the resource waits for.
+func (b *Bandwidth) SetBlockedBy(blockers []BlockingResource) {
+	b.Status.BlockedBy = blockers
+}
+
+// +kubebuilder:object:root=true
+
+// BandwidthList contains a list of Bandwidth
+type BandwidthList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Bandwidth `json:"items"`
+}
+
+// GetItems returns the list of items as a slice of client.Object.
+func (bl *BandwidthList) GetItems() []client.Object {
+	items := make([]client.Object, len(bl.Items))
+	for i := range bl.Items {
+		items[i] = &bl.Items[i]
+	}
+	return items
+}
+
+func init() {
+	SchemeBuilder.Register(&Bandwidth{}, &BandwidthList{})
+}
@@ -0,0 +1,329 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// +kubebuilder:validation:Enum=WARM;COLD
+type BucketStorageClass string
+
+const (
+	BucketStorageClassWarm BucketStorageClass = "WARM"
+	BucketStorageClassCold BucketStorageClass = "COLD"
+)
+
+// +kubebuilder:validation:Enum=kms;AES256
+type BucketEncryptionAlgorithm string
+
+const (
+	BucketEncryptionKMS    BucketEncryptionAlgorithm = "kms"
+	BucketEncryptionAES256 BucketEncryptionAlgorithm = "AES256"
+)
+
+// +kubebuilder:validation:Enum=GET;PUT;POST;DELETE;HEAD
+type BucketCORSMethod string
+
+// BucketTransition moves objects to a colder storage class after a number of
+// days
+type BucketTransition struct {
+	// Days is the number of days after which the objects are moved
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Minimum=1
+	Days int `json:"days"`
+
+	// StorageClass is the storage class the objects are moved to
+	// +kubebuilder:validation:Required
+	StorageClass BucketStorageClass `json:"storageClass"`
+}
+
+// BucketLifecycleRule defines how the objects matching a prefix are
+// transitioned and expired
+type BucketLifecycleRule struct {
+	// ID is the unique identifier of the rule
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=255
+	ID string `json:"id"`
+
+	// Prefix is the prefix of the objects the rule applies to. If omitted,
+	// the rule applies to all objects.
+	// +kubebuilder:validation:Optional
+	Prefix string `json:"prefix,omitempty"`
+
+	// Enabled defines whether the rule is applied
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=true
+	Enabled bool `json:"enabled"`
+
+	// ExpirationDays is the number of days after which the objects are
+	// deleted
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	ExpirationDays int `json:"expirationDays,omitempty"`
+
+	// Transitions move the objects to colder storage classes
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxItems=2
+	Transitions []BucketTransition `json:"transitions,omitempty"`
+
+	// NoncurrentVersionExpirationDays is the number of days after which
+	// noncurrent object versions are deleted
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	NoncurrentVersionExpirationDays int `json:"noncurrentVersionExpirationDays,omitempty"`
+}
+
+// +kubebuilder:validation:XValidation:rule="self.algorithm == 'kms' || !has(self.kmsKey)",message="kmsKey can only be set for the kms algorithm"
+
+// BucketEncryption defines the default server-side encryption of a bucket
+type BucketEncryption struct {
+	// Algorithm is the encryption algorithm. kms encrypts with a key of the
+	// Key Management Service, AES256 with a key managed by OBS.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=kms
+	Algorithm BucketEncryptionAlgorithm `json:"algorithm,omitempty"`
+
+	// KMSKey is the KMS key the objects are encrypted with. If omitted, the
+	// default key of OBS is used.
+	// +kubebuilder:validation:Optional
+	KMSKey *KMSKeyDependency `json:"kmsKey,omitempty"`
+}
+
+// BucketCORSRule defines which cross-origin requests are allowed
+type BucketCORSRule struct {
+	// AllowedOrigins are the origins allowed to access the bucket. An origin
+	// may contain one wildcard (*).
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinItems=1
+	AllowedOrigins []string `json:"allowedOrigins"`
+
+	// AllowedMethods are the HTTP methods allowed
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinItems=1
+	AllowedMethods []BucketCORSMethod `json:"allowedMethods"`
+
+	// AllowedHeaders are the request headers allowed in a preflight request
+	// +kubebuilder:validation:Optional
+	AllowedHeaders []string `json:"allowedHeaders,omitempty"`
+
+	// ExposeHeaders are the response headers exposed to the client
+	// +kubebuilder:validation:Optional
+	ExposeHeaders []string `json:"exposeHeaders,omitempty"`
+
+	// MaxAgeSeconds is the time the client may cache the preflight response
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=0
+	MaxAgeSeconds int `json:"maxAgeSeconds,omitempty"`
+}
+
+// BucketSpec defines the desired state of Bucket. The name of the resource is
+// the name of the bucket, which has to be globally unique. Buckets require a
+// ProviderConfig with access key credentials.
+type BucketSpec struct {
+	// ProviderConfigRef references the ProviderConfig to use for authentication
+	// +kubebuilder:validation:Required
+	ProviderConfigRef ProviderConfigReference `json:"providerConfigRef"`
+
+	// Versioning keeps all versions of the objects. Once enabled, versioning
+	// can only be suspended, which keeps the existing versions.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=false
+	Versioning bool `json:"versioning,omitempty"`
+
+	// LifecycleRules define how objects are transitioned and expired
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxItems=1000
+	// +listType=map
+	// +listMapKey=id
+	LifecycleRules []BucketLifecycleRule `json:"lifecycleRules,omitempty"`
+
+	// Encryption defines the default server-side encryption of the objects
+	// +kubebuilder:validation:Optional
+	Encryption *BucketEncryption `json:"encryption,omitempty"`
+
+	// CORSRules define which cross-origin requests are allowed
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxItems=100
+	CORSRules []BucketCORSRule `json:"corsRules,omitempty"`
+
+	// Policy is the bucket policy as a JSON document
+	// +kubebuilder:validation:Optional
+	Policy string `json:"policy,omitempty"`
+
+	// ForceDestroy deletes all objects, object versions and incomplete
+	// uploads of the bucket before the bucket is deleted. Otherwise, the
+	// deletion of a non-empty bucket fails.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=false
+	ForceDestroy bool `json:"forceDestroy,omitempty"`
+
+	// WriteConnectionDetailsToRef specifies the Secret or ConfigMap the outputs
+	// of the resource are written to. These are the name, region and endpoint
+	// of the bucket.
+	// +kubebuilder:validation:Optional
+	WriteConnectionDetailsToRef *ConnectionDetailsReference `json:"writeConnectionDetailsToRef,omitempty"`
+
+	// OrphanOnDelete prevents deletion of the external resource when the CR is deleted
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=false
+	OrphanOnDelete bool `json:"orphanOnDelete,omitempty"`
+
+	// NotFoundPolicy defines how the resource reacts when the external
+	// resource was deleted out-of-band
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=Recreate
+	NotFoundPolicy NotFoundPolicy `json:"notFoundPolicy,omitempty"`
+}
+
+// BucketDependenciesResolved contains the resolved IDs for bucket dependencies
+type BucketDependenciesResolved struct {
+	// KMSKeyID is the resolved KMSKey ID the objects are encrypted with
+	KMSKeyID string `json:"kmsKeyID,omitempty"`
+}
+
+// BucketStatus defines the observed state of Bucket.
+type BucketStatus struct {
+	// Conditions represent the latest available observations of the Bucket's state
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// BlockedBy lists the chain of unready resources the resource is waiting
+	// for, ending with the root cause
+	// +optional
+	BlockedBy []BlockingResource `json:"blockedBy,omitempty"`
+
+	// ExternalID is the provider's ID for this Bucket, which is its name
+	// +optional
+	ExternalID string `json:"externalID,omitempty"`
+
+	// ResolvedDependencies contains the resolved IDs for bucket dependencies
+	// +optional
+	ResolvedDependencies BucketDependenciesResolved `json:"resolvedDependencies"`
+
+	// Region is the region the bucket is located in
+	// +optional
+	Region string `json:"region,omitempty"`
+
+	// Endpoint is the virtual-hosted-style endpoint of the bucket
+	// +optional
+	Endpoint string `json:"endpoint,omitempty"`
+
+	// ObservedGeneration reflects the generation of the most recently observed Bucket spec
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// LastSyncTime is the timestamp of the last successful sync with the provider
+	// +optional
+	LastSyncTime *metav1.Time `json:"lastSyncTime,omitempty"`
+
+	// LastAppliedSpec caches the spec that was successfully applied to the
+	// external resource. It is used to detect changes to immutable fields.
+	// +optional
+	LastAppliedSpec *BucketSpec `json:"lastAppliedSpec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Namespaced,categories=storage
+// +kubebuilder:printcolumn:name="Region",type=string,JSONPath=`.status.region`
+// +kubebuilder:printcolumn:name="Versioning",type=boolean,JSONPath=`.spec.versioning`
+// +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
+// +kubebuilder:printcolumn:name="Endpoint",type=string,JSONPath=`.status.endpoint`,priority=1
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// Bucket is the Schema for the buckets API
+type Bucket struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty,omitzero"`
+
+	Spec   BucketSpec   `json:"spec"`
+	Status BucketStatus `json:"status,omitempty"`
+}
+
+// GetProviderConfigRef returns the ProviderConfig used for authentication.
+func (b *Bucket) GetProviderConfigRef() ProviderConfigReference {
+	return b.Spec.ProviderConfigRef
+}
+
+// GetNotFoundPolicy returns how a missing external resource is handled.
+func (b *Bucket) GetNotFoundPolicy() NotFoundPolicy {
+	return b.Spec.NotFoundPolicy
+}
+
+// GetOrphanOnDelete returns whether the external resource is kept on deletion.
+func (b *Bucket) GetOrphanOnDelete() bool {
+	return b.Spec.OrphanOnDelete
+}
+
+// GetConnectionDetailsRef returns the Secret or ConfigMap the outputs are written to.
+func (b *Bucket) GetConnectionDetailsRef() *ConnectionDetailsReference {
+	return b.Spec.WriteConnectionDetailsToRef
+}
+
+// GetConditions returns the conditions of the status.
+func (b *Bucket) GetConditions() *[]metav1.Condition {
+	return &b.Status.Conditions
+}
+
+// GetExternalID returns the provider's ID.
+func (b *Bucket) GetExternalID() string {
+	return b.Status.ExternalID
+}
+
+// SetExternalID sets the provider's ID.
+func (b *Bucket) SetExternalID(id string) {
+	b.Status.ExternalID = id
+}
+
+// GetLastSyncTime returns the time of the last successful sync.
+func (b *Bucket) GetLastSyncTime() *metav1.Time {
+	return b.Status.LastSyncTime
+}
+
+// SetLastSyncTime sets the time of the last successful sync.
+func (b *Bucket) SetLastSyncTime(t *metav1.Time) {
+	b.Status.LastSyncTime = t
+}
+
+// HasLastAppliedSpec returns whether a spec was applied before.
+func (b *Bucket) HasLastAppliedSpec() bool {
+	return b.Status.LastAppliedSpec != nil
+}
+
+// SetLastAppliedSpec records the current spec as applied.
+func (b *Bucket) SetLastAppliedSpec() {
+	b.Status.LastAppliedSpec = b.Spec.DeepCopy()
+}
+
+// ResetLastAppliedSpec clears the applied spec.
+func (b *Bucket) ResetLastAppliedSpec() {
+	b.Status.LastAppliedSpec = nil
+}
+
+// SetBlockedBy sets the chain of unready resources the resource waits for.
+func (b *Bucket) SetBlockedBy(blockers []BlockingResource) {
+	b.Status.BlockedBy = blockers
+}
+
+// +kubebuilder:object:root=true
+
+// BucketList contains a list of Bucket
+type BucketList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Bucket `json:"items"`
+}
+
+// GetItems returns the list of items as a slice of client.Object.
+func (bl *BucketList) GetItems() []client.Object {
+	items := make([]client.Object, len(bl.Items))
+	for i := range bl.Items {
+		items[i] = &bl.Items[i]
+	}
+	return items
+}
+
+func init() {
+	SchemeBuilder.Register(&Bucket{}, &BucketList{})
+}
@@ -0,0 +1,263 @@
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// +kubebuilder:validation:Enum=overlay_l2;vpc-router
+type ClusterContainerNetworkMode string
+
+const (
+	ClusterContainerNetworkOverlayL2 ClusterContainerNetworkMode = "overlay_l2"
+	ClusterContainerNetworkVPCRouter ClusterContainerNetworkMode = "vpc-router"
+)
+
+// ClusterContainerNetwork defines the network of the pods of a cluster
+type ClusterContainerNetwork struct {
+	// Mode is the container network type
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=overlay_l2
+	Mode ClusterContainerNetworkMode `json:"mode,omitempty"`
+
+	// CIDR is the CIDR of the container network. If omitted, it is chosen by
+	// the provider.
+	// +kubebuilder:validation:Optional
+	CIDR string `json:"cidr,omitempty"`
+}
+
+// ClusterSpec defines the desired state of Cluster
+type ClusterSpec struct {
+	// ProviderConfigRef references the ProviderConfig to use for authentication
+	// +kubebuilder:validation:Required
+	ProviderConfigRef ProviderConfigReference `json:"providerConfigRef"`
+
+	// Flavor is the flavor of the control plane (e.g. cce.s1.small)
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="flavor is immutable"
+	Flavor string `json:"flavor"`
+
+	// Version is the Kubernetes version of the cluster (e.g. v1.29). If
+	// omitted, the latest version is used.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="version is immutable"
+	Version string `json:"version,omitempty"`
+
+	// Description is the description of the cluster
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxLength=200
+	Description string `json:"description,omitempty"`
+
+	// Network defines the network the cluster is created in
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="network is immutable"
+	Network NetworkDependency `json:"network"`
+
+	// Subnet defines the subnet the control plane and the nodes are attached
+	// to by default
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="subnet is immutable"
+	Subnet SubnetDependency `json:"subnet"`
+
+	// SecurityGroup defines the security group of the nodes. If omitted, a
+	// security group is created by the provider.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="securityGroup is immutable"
+	SecurityGroup *SecurityGroupDependency `json:"securityGroup,omitempty"`
+
+	// ContainerNetwork defines the network of the pods
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default={}
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="containerNetwork is immutable"
+	ContainerNetwork ClusterContainerNetwork `json:"containerNetwork,omitempty"`
+
+	// ServiceCIDR is the CIDR of the Kubernetes services. If omitted, it is
+	// chosen by the provider.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="serviceCIDR is immutable"
+	ServiceCIDR string `json:"serviceCIDR,omitempty"`
+
+	// WriteKubeconfigToRef references the Secret the kubeconfig of the cluster
+	// is written to. The kubeconfig is stored under the key 'kubeconfig'.
+	// +kubebuilder:validation:Optional
+	WriteKubeconfigToRef *corev1.LocalObjectReference `json:"writeKubeconfigToRef,omitempty"`
+
+	// WriteConnectionDetailsToRef specifies the Secret or ConfigMap the outputs
+	// of the resource are written to
+	// +kubebuilder:validation:Optional
+	WriteConnectionDetailsToRef *ConnectionDetailsReference `json:"writeConnectionDetailsToRef,omitempty"`
+
+	// OrphanOnDelete prevents deletion of the external resource when the CR is deleted
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=false
+	OrphanOnDelete bool `json:"orphanOnDelete,omitempty"`
+
+	// NotFoundPolicy defines how the resource reacts when the external
+	// resource was deleted out-of-band
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=Recreate
+	NotFoundPolicy NotFoundPolicy `json:"notFoundPolicy,omitempty"`
+}
+
+// ClusterDependenciesResolved contains the resolved IDs for cluster dependencies
+type ClusterDependenciesResolved struct {
+	// NetworkID is the resolved Network ID
+	NetworkID string `json:"networkID,omitempty"`
+	// SubnetID is the resolved Subnet ID
+	SubnetID string `json:"subnetID,omitempty"`
+	// SecurityGroupID is the resolved SecurityGroup ID
+	SecurityGroupID string `json:"securityGroupID,omitempty"`
+}
+
+// ClusterStatus defines the observed state of Cluster.
+type ClusterStatus struct {
+	// Conditions represent the latest available observations of the Cluster's state
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// BlockedBy lists the chain of unready resources the resource is waiting
+	// for, ending with the root cause
+	// +optional
+	BlockedBy []BlockingResource `json:"blockedBy,omitempty"`
+
+	// ExternalID is the provider's ID for this Cluster
+	// +optional
+	ExternalID string `json:"externalID,omitempty"`
+
+	// ResolvedDependencies contains the resolved IDs for cluster dependencies
+	// +optional
+	ResolvedDependencies ClusterDependenciesResolved `json:"resolvedDependencies"`
+
+	// Phase is the phase of the cluster reported by the provider
+	// +optional
+	Phase string `json:"phase,omitempty"`
+
+	// Version is the observed Kubernetes version of the cluster
+	// +optional
+	Version string `json:"version,omitempty"`
+
+	// Endpoint is the internal address of the Kubernetes API server
+	// +optional
+	Endpoint string `json:"endpoint,omitempty"`
+
+	// ObservedGeneration reflects the generation of the most recently observed Cluster spec
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// LastSyncTime is the timestamp of the last successful sync with the provider
+	// +optional
+	LastSyncTime *metav1.Time `json:"lastSyncTime,omitempty"`
+
+	// LastAppliedSpec caches the spec that was successfully applied to the
+	// external resource. It is used to detect changes to immutable fields.
+	// +optional
+	LastAppliedSpec *ClusterSpec `json:"lastAppliedSpec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Namespaced,categories=containers
+// +kubebuilder:printcolumn:name="Flavor",type=string,JSONPath=`.spec.flavor`
+// +kubebuilder:printcolumn:name="Version",type=string,JSONPath=`.status.version`
+// +kubebuilder:printcolumn:name="Phase",type=string,JSONPath=`.status.phase`
+// +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
+// +kubebuilder:printcolumn:name="ExternalID",type=string,JSONPath=`.status.externalID`,priority=1
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// Cluster is the Schema for the clusters API
+type Cluster struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty,omitzero"`
+
+	Spec   ClusterSpec   `json:"spec"`
+	Status ClusterStatus `json:"status,omitempty"`
+}
+
+// GetProviderConfigRef returns the ProviderConfig used for authentication.
+func (c *Cluster) GetProviderConfigRef() ProviderConfigReference {
+	return c.Spec.ProviderConfigRef
+}
+
+// GetNotFoundPolicy returns how a missing external resource is handled.
+func (c *Cluster) GetNotFoundPolicy() NotFoundPolicy {
+	return c.Spec.NotFoundPolicy
+}
+
+// GetOrphanOnDelete returns whether the external resource is kept on deletion.
+func (c *Cluster) GetOrphanOnDelete() bool {
+	return c.Spec.OrphanOnDelete
+}
+
+// GetConnectionDetailsRef returns the Secret or ConfigMap the outputs are written to.
+func (c *Cluster) GetConnectionDetailsRef() *ConnectionDetailsReference {
+	return c.Spec.WriteConnectionDetailsToRef
+}
+
+// GetConditions returns the conditions of the status.
+func (c *Cluster) GetConditions() *[]metav1.Condition {
+	return &c.Status.Conditions
+}
+
+// GetExternalID returns the provider's ID.
+func (c *Cluster) GetExternalID() string {
+	return c.Status.ExternalID
+}
+
+// SetExternalID sets the provider's ID.
+func (c *Cluster) SetExternalID(id string) {
+	c.Status.ExternalID = id
+}
+
+// GetLastSyncTime returns the time of the last successful sync.
+func (c *Cluster) GetLastSyncTime() *metav1.Time {
+	return c.Status.LastSyncTime
+}
+
+// SetLastSyncTime sets the time of the last successful sync.
+func (c *Cluster) SetLastSyncTime(t *metav1.Time) {
+	c.Status.LastSyncTime = t
+}
+
+// HasLastAppliedSpec returns whether a spec was applied before.
+func (c *Cluster) HasLastAppliedSpec() bool {
+	return c.Status.LastAppliedSpec != nil
+}
+
+// SetLastAppliedSpec records the current spec as applied.
+func (c *Cluster) SetLastAppliedSpec() {
+	c.Status.LastAppliedSpec = c.Spec.DeepCopy()
+}
+
+// ResetLastAppliedSpec clears the applied spec.
+func (c *Cluster) ResetLastAppliedSpec() {
+	c.Status.LastAppliedSpec = nil
+}
+
+// SetBlockedBy sets the chain of unready resources the resource waits for.
+func (c *Cluster) SetBlockedBy(blockers []BlockingResource) {
+	c.Status.BlockedBy = blockers
+}
+
+// +kubebuilder:object:root=true
+
+// ClusterList contains a list of Cluster
+type ClusterList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Cluster `json:"items"`
+}
+
+// GetItems returns the list of items as a slice of client.Object.
+func (cl *ClusterList) GetItems() []client.Object {
+	items := make([]client.Object, len(cl.Items))
+	for i := range cl.Items {
+		items[i] = &cl.Items[i]
+	}
+	return items
+}
+
+func init() {
+	SchemeBuilder.Register(&Cluster{}, &ClusterList{})
+}
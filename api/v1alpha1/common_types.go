@@ -5,6 +5,72 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
+// NamespacedObjectReference references a resource in the namespace of the
+// referencing resource, or in another namespace if a ReferenceGrant in that
+// namespace allows it.
+type NamespacedObjectReference struct {
+	// Name of the referenced resource
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// Namespace of the referenced resource. Defaults to the namespace of the
+	// referencing resource.
+	// +kubebuilder:validation:Optional
+	Namespace string `json:"namespace,omitempty"`
+}
+
+// BlockingResource is an unready resource another resource is waiting for.
+type BlockingResource struct {
+	// Kind of the resource
+	Kind string `json:"kind"`
+
+	// Namespace of the resource
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+
+	// Name of the resource
+	Name string `json:"name"`
+
+	// Reason of the Ready condition of the resource
+	// +optional
+	Reason string `json:"reason,omitempty"`
+
+	// Message of the Ready condition of the resource
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// SelectionPolicy defines which resource a selector resolves to if multiple
+// resources match.
+// +kubebuilder:validation:Enum=Unique;Oldest;Newest
+type SelectionPolicy string
+
+const (
+	// SelectionPolicyUnique requires exactly one resource to match.
+	SelectionPolicyUnique SelectionPolicy = "Unique"
+	// SelectionPolicyOldest selects the resource created first.
+	SelectionPolicyOldest SelectionPolicy = "Oldest"
+	// SelectionPolicyNewest selects the resource created last.
+	SelectionPolicyNewest SelectionPolicy = "Newest"
+)
+
+// ResourceSelector selects a resource by labels. If multiple resources match,
+// the Policy decides which one is selected.
+type ResourceSelector struct {
+	metav1.LabelSelector `json:",inline"`
+
+	// Policy defines which resource is selected if multiple resources match.
+	// A previously selected resource is kept as long as it still matches.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=Unique
+	Policy SelectionPolicy `json:"policy,omitempty"`
+
+	// ReadyOnly ignores resources which are not ready
+	// +kubebuilder:validation:Optional
+	ReadyOnly bool `json:"readyOnly,omitempty"`
+}
+
 // +kubebuilder:validation:XValidation:rule="(has(self.networkID)?1:0)+(has(self.networkRef)?1:0)+(has(self.networkSelector)?1:0)==1",message="exactly one of networkID, networkRef or networkSelector must be set"
 
 // NetworkDependency specifies a dependency on a Network resource. Exactly one
@@ -13,12 +79,13 @@ type NetworkDependency struct {
 	// NetworkID is the external provider ID of the Network
 	// +optional
 	NetworkID *string `json:"networkID,omitempty"`
-	// NetworkRef is a reference to a Network resource
+	// NetworkRef is a reference to a Network resource. It can reference a resource in
+	// another namespace if a ReferenceGrant allows it.
 	// +optional
-	NetworkRef *corev1.LocalObjectReference `json:"networkRef,omitempty"`
+	NetworkRef *NamespacedObjectReference `json:"networkRef,omitempty"`
 	// NetworkSelector selects a Network by labels
 	// +optional
-	NetworkSelector *metav1.LabelSelector `json:"networkSelector,omitempty"`
+	NetworkSelector *ResourceSelector `json:"networkSelector,omitempty"`
 }
 
 // +kubebuilder:validation:XValidation:rule="(has(self.subnetID)?1:0)+(has(self.subnetRef)?1:0)+(has(self.subnetSelector)?1:0)==1",message="exactly one of subnetID, subnetRef or subnetSelector must be set"
@@ -29,12 +96,13 @@ type SubnetDependency struct {
 	// SubnetID is the external provider ID of the subnet
 	// +optional
 	SubnetID *string `json:"subnetID,omitempty"`
-	// SubnetRef is a reference to a Subnet resource
+	// SubnetRef is a reference to a Subnet resource. It can reference a resource in
+	// another namespace if a ReferenceGrant allows it.
 	// +optional
-	SubnetRef *corev1.LocalObjectReference `json:"subnetRef,omitempty"`
+	SubnetRef *NamespacedObjectReference `json:"subnetRef,omitempty"`
 	// SubnetSelector selects a Subnet by labels
 	// +optional
-	SubnetSelector *metav1.LabelSelector `json:"subnetSelector,omitempty"`
+	SubnetSelector *ResourceSelector `json:"subnetSelector,omitempty"`
 }
 
 // +kubebuilder:validation:XValidation:rule="(has(self.securityGroupID)?1:0)+(has(self.securityGroupRef)?1:0)+(has(self.securityGroupSelector)?1:0)==1",message="exactly one of securityGroupID, securityGroupRef or securityGroupSelector must be set"
@@ -46,12 +114,13 @@ type SecurityGroupDependency struct {
 	// SecurityGroupID is the external provider ID of the security group
 	// +optional
 	SecurityGroupID *string `json:"securityGroupID,omitempty"`
-	// SecurityGroupRef is a reference to a SecurityGroup custom resource
+	// SecurityGroupRef is a reference to a SecurityGroup custom resource. It can reference a resource in
+	// another namespace if a ReferenceGrant allows it.
 	// +optional
-	SecurityGroupRef *corev1.LocalObjectReference `json:"securityGroupRef,omitempty"`
+	SecurityGroupRef *NamespacedObjectReference `json:"securityGroupRef,omitempty"`
 	// SecurityGroupSelector selects a SecurityGroup by labels
 	// +optional
-	SecurityGroupSelector *metav1.LabelSelector `json:"securityGroupSelector,omitempty"`
+	SecurityGroupSelector *ResourceSelector `json:"securityGroupSelector,omitempty"`
 }
 
 // NATGatewayDependency specifies a dependency on a NATGateway resource. Exactly one of
@@ -65,7 +134,7 @@ type NATGatewayDependency struct {
 	NATGatewayRef *corev1.LocalObjectReference `json:"natGatewayRef,omitempty"`
 	// NATGatewaySelector selects a NAT gateway by labels
 	// +optional
-	NATGatewaySelector *metav1.LabelSelector `json:"natGatewaySelector,omitempty"`
+	NATGatewaySelector *ResourceSelector `json:"natGatewaySelector,omitempty"`
 }
 
 // PublicIPDependency specifies a dependency on a Public IP resource. Exactly one of
@@ -79,5 +148,212 @@ type PublicIPDependency struct {
 	PublicIPRef *corev1.LocalObjectReference `json:"publicIPRef,omitempty"`
 	// PublicIPSelector selects a public IP by labels
 	// +optional
-	PublicIPSelector *metav1.LabelSelector `json:"publicIPSelector,omitempty"`
+	PublicIPSelector *ResourceSelector `json:"publicIPSelector,omitempty"`
+}
+
+// +kubebuilder:validation:XValidation:rule="(has(self.bandwidthID)?1:0)+(has(self.bandwidthRef)?1:0)+(has(self.bandwidthSelector)?1:0)==1",message="exactly one of bandwidthID, bandwidthRef or bandwidthSelector must be set"
+
+// BandwidthDependency specifies a dependency on a shared Bandwidth resource.
+// Exactly one of BandwidthID, BandwidthRef or BandwidthSelector must be
+// specified.
+type BandwidthDependency struct {
+	// BandwidthID is the external provider ID of the shared bandwidth
+	// +optional
+	BandwidthID *string `json:"bandwidthID,omitempty"`
+	// BandwidthRef is a reference to a Bandwidth resource
+	// +optional
+	BandwidthRef *corev1.LocalObjectReference `json:"bandwidthRef,omitempty"`
+	// BandwidthSelector selects a Bandwidth by labels
+	// +optional
+	BandwidthSelector *ResourceSelector `json:"bandwidthSelector,omitempty"`
+}
+
+// +kubebuilder:validation:XValidation:rule="(has(self.instanceID)?1:0)+(has(self.instanceRef)?1:0)+(has(self.instanceSelector)?1:0)==1",message="exactly one of instanceID, instanceRef or instanceSelector must be set"
+
+// InstanceDependency specifies a dependency on an Instance resource. Exactly
+// one of InstanceID, InstanceRef or InstanceSelector must be specified.
+type InstanceDependency struct {
+	// InstanceID is the external provider ID of the instance
+	// +optional
+	InstanceID *string `json:"instanceID,omitempty"`
+	// InstanceRef is a reference to an Instance resource
+	// +optional
+	InstanceRef *corev1.LocalObjectReference `json:"instanceRef,omitempty"`
+	// InstanceSelector selects an Instance by labels
+	// +optional
+	InstanceSelector *ResourceSelector `json:"instanceSelector,omitempty"`
+}
+
+// +kubebuilder:validation:XValidation:rule="(has(self.keyPairName)?1:0)+(has(self.keyPairRef)?1:0)+(has(self.keyPairSelector)?1:0)==1",message="exactly one of keyPairName, keyPairRef or keyPairSelector must be set"
+
+// KeyPairDependency specifies a dependency on a KeyPair resource. Exactly one
+// of KeyPairName, KeyPairRef or KeyPairSelector must be specified.
+type KeyPairDependency struct {
+	// KeyPairName is the name of the key pair at the provider
+	// +optional
+	KeyPairName *string `json:"keyPairName,omitempty"`
+	// KeyPairRef is a reference to a KeyPair resource
+	// +optional
+	KeyPairRef *corev1.LocalObjectReference `json:"keyPairRef,omitempty"`
+	// KeyPairSelector selects a KeyPair by labels
+	// +optional
+	KeyPairSelector *ResourceSelector `json:"keyPairSelector,omitempty"`
 }
+
+// +kubebuilder:validation:XValidation:rule="(has(self.dnsZoneID)?1:0)+(has(self.dnsZoneRef)?1:0)+(has(self.dnsZoneSelector)?1:0)==1",message="exactly one of dnsZoneID, dnsZoneRef or dnsZoneSelector must be set"
+
+// DNSZoneDependency specifies a dependency on a DNSZone resource. Exactly one
+// of DNSZoneID, DNSZoneRef or DNSZoneSelector must be specified.
+type DNSZoneDependency struct {
+	// DNSZoneID is the external provider ID of the DNS zone
+	// +optional
+	DNSZoneID *string `json:"dnsZoneID,omitempty"`
+	// DNSZoneRef is a reference to a DNSZone resource
+	// +optional
+	DNSZoneRef *corev1.LocalObjectReference `json:"dnsZoneRef,omitempty"`
+	// DNSZoneSelector selects a DNSZone by labels
+	// +optional
+	DNSZoneSelector *ResourceSelector `json:"dnsZoneSelector,omitempty"`
+}
+
+// +kubebuilder:validation:XValidation:rule="(has(self.vpnGatewayID)?1:0)+(has(self.vpnGatewayRef)?1:0)+(has(self.vpnGatewaySelector)?1:0)==1",message="exactly one of vpnGatewayID, vpnGatewayRef or vpnGatewaySelector must be set"
+
+// VPNGatewayDependency specifies a dependency on a VPNGateway resource. Exactly
+// one of VPNGatewayID, VPNGatewayRef or VPNGatewaySelector must be specified.
+type VPNGatewayDependency struct {
+	// VPNGatewayID is the external provider ID of the VPN gateway
+	// +optional
+	VPNGatewayID *string `json:"vpnGatewayID,omitempty"`
+	// VPNGatewayRef is a reference to a VPNGateway resource
+	// +optional
+	VPNGatewayRef *corev1.LocalObjectReference `json:"vpnGatewayRef,omitempty"`
+	// VPNGatewaySelector selects a VPNGateway by labels
+	// +optional
+	VPNGatewaySelector *ResourceSelector `json:"vpnGatewaySelector,omitempty"`
+}
+
+// +kubebuilder:validation:XValidation:rule="(has(self.customerGatewayID)?1:0)+(has(self.customerGatewayRef)?1:0)+(has(self.customerGatewaySelector)?1:0)==1",message="exactly one of customerGatewayID, customerGatewayRef or customerGatewaySelector must be set"
+
+// CustomerGatewayDependency specifies a dependency on a CustomerGateway resource. Exactly
+// one of CustomerGatewayID, CustomerGatewayRef or CustomerGatewaySelector must be specified.
+type CustomerGatewayDependency struct {
+	// CustomerGatewayID is the external provider ID of the customer gateway
+	// +optional
+	CustomerGatewayID *string `json:"customerGatewayID,omitempty"`
+	// CustomerGatewayRef is a reference to a CustomerGateway resource
+	// +optional
+	CustomerGatewayRef *corev1.LocalObjectReference `json:"customerGatewayRef,omitempty"`
+	// CustomerGatewaySelector selects a CustomerGateway by labels
+	// +optional
+	CustomerGatewaySelector *ResourceSelector `json:"customerGatewaySelector,omitempty"`
+}
+
+// +kubebuilder:validation:XValidation:rule="(has(self.clusterID)?1:0)+(has(self.clusterRef)?1:0)+(has(self.clusterSelector)?1:0)==1",message="exactly one of clusterID, clusterRef or clusterSelector must be set"
+
+// ClusterDependency specifies a dependency on a Cluster resource. Exactly
+// one of ClusterID, ClusterRef or ClusterSelector must be specified.
+type ClusterDependency struct {
+	// ClusterID is the external provider ID of the cluster
+	// +optional
+	ClusterID *string `json:"clusterID,omitempty"`
+	// ClusterRef is a reference to a Cluster resource
+	// +optional
+	ClusterRef *corev1.LocalObjectReference `json:"clusterRef,omitempty"`
+	// ClusterSelector selects a Cluster by labels
+	// +optional
+	ClusterSelector *ResourceSelector `json:"clusterSelector,omitempty"`
+}
+
+// +kubebuilder:validation:XValidation:rule="(has(self.kmsKeyID)?1:0)+(has(self.kmsKeyRef)?1:0)+(has(self.kmsKeySelector)?1:0)==1",message="exactly one of kmsKeyID, kmsKeyRef or kmsKeySelector must be set"
+
+// KMSKeyDependency specifies a dependency on a KMSKey resource. Exactly one
+// of KMSKeyID, KMSKeyRef or KMSKeySelector must be specified.
+type KMSKeyDependency struct {
+	// KMSKeyID is the external provider ID of the KMS key
+	// +optional
+	KMSKeyID *string `json:"kmsKeyID,omitempty"`
+	// KMSKeyRef is a reference to a KMSKey resource
+	// +optional
+	KMSKeyRef *corev1.LocalObjectReference `json:"kmsKeyRef,omitempty"`
+	// KMSKeySelector selects a KMSKey by labels
+	// +optional
+	KMSKeySelector *ResourceSelector `json:"kmsKeySelector,omitempty"`
+}
+
+// +kubebuilder:validation:XValidation:rule="(has(self.topicURN)?1:0)+(has(self.topicRef)?1:0)+(has(self.topicSelector)?1:0)==1",message="exactly one of topicURN, topicRef or topicSelector must be set"
+
+// TopicDependency specifies a dependency on a Topic resource. Exactly one of
+// TopicURN, TopicRef or TopicSelector must be specified.
+type TopicDependency struct {
+	// TopicURN is the external provider URN of the SMN topic
+	// +optional
+	TopicURN *string `json:"topicURN,omitempty"`
+	// TopicRef is a reference to a Topic resource
+	// +optional
+	TopicRef *corev1.LocalObjectReference `json:"topicRef,omitempty"`
+	// TopicSelector selects a Topic by labels
+	// +optional
+	TopicSelector *ResourceSelector `json:"topicSelector,omitempty"`
+}
+
+// +kubebuilder:validation:Enum=Secret;ConfigMap
+type ConnectionDetailsKind string
+
+const (
+	ConnectionDetailsSecret    ConnectionDetailsKind = "Secret"
+	ConnectionDetailsConfigMap ConnectionDetailsKind = "ConfigMap"
+)
+
+// ConnectionDetailsReference references a Secret or ConfigMap in the namespace
+// of the resource. The outputs of the resource, like its external ID or
+// addresses, are written to it.
+type ConnectionDetailsReference struct {
+	// Kind is the kind of the referenced object (Secret or ConfigMap)
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=Secret
+	Kind ConnectionDetailsKind `json:"kind,omitempty"`
+	// Name is the name of the referenced object
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+}
+
+// NotFoundPolicy defines how a resource reacts when its external resource was
+// deleted out-of-band.
+// +kubebuilder:validation:Enum=Recreate;MarkDeleted;Fail
+type NotFoundPolicy string
+
+const (
+	// NotFoundPolicyRecreate creates the external resource again.
+	NotFoundPolicyRecreate NotFoundPolicy = "Recreate"
+	// NotFoundPolicyMarkDeleted marks the resource as deleted and stops
+	// reconciling it.
+	NotFoundPolicyMarkDeleted NotFoundPolicy = "MarkDeleted"
+	// NotFoundPolicyFail reports the missing external resource as a failure
+	// until it is resolved manually.
+	NotFoundPolicyFail NotFoundPolicy = "Fail"
+)
+
+// DeletionPolicy defines how a resource is tied to the resources it depends
+// on.
+// +kubebuilder:validation:Enum=Block;Cascade
+type DeletionPolicy string
+
+const (
+	// DeletionPolicyBlock blocks the deletion of a dependency until the
+	// resource is deleted.
+	DeletionPolicyBlock DeletionPolicy = "Block"
+	// DeletionPolicyCascade sets owner references to the dependencies, so the
+	// resource is deleted together with them.
+	DeletionPolicyCascade DeletionPolicy = "Cascade"
+)
+
+const (
+	// PausedAnnotation pauses the reconciliation of a resource when set to
+	// "true". Set on a ProviderConfig, it pauses all resources using it.
+	PausedAnnotation = "otc.peertech.de/paused"
+
+	// PauseDeletionAnnotation also pauses the deletion of a paused resource
+	// when set to "true". Without it, paused resources are still deleted.
+	PauseDeletionAnnotation = "otc.peertech.de/pause-deletion"
+)
@@ -0,0 +1,176 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// CustomerGatewaySpec defines the desired state of CustomerGateway
+type CustomerGatewaySpec struct {
+	// ProviderConfigRef references the ProviderConfig to use for authentication
+	// +kubebuilder:validation:Required
+	ProviderConfigRef ProviderConfigReference `json:"providerConfigRef"`
+
+	// IPAddress is the public IPv4 address of the on-premises gateway
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="ipAddress is immutable"
+	IPAddress string `json:"ipAddress"`
+
+	// BGPASN is the BGP autonomous system number of the on-premises gateway
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=65000
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=4294967295
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="bgpASN is immutable"
+	BGPASN int64 `json:"bgpASN,omitempty"`
+
+	// WriteConnectionDetailsToRef specifies the Secret or ConfigMap the outputs
+	// of the resource are written to
+	// +kubebuilder:validation:Optional
+	WriteConnectionDetailsToRef *ConnectionDetailsReference `json:"writeConnectionDetailsToRef,omitempty"`
+
+	// OrphanOnDelete prevents deletion of the external resource when the CR is deleted
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=false
+	OrphanOnDelete bool `json:"orphanOnDelete,omitempty"`
+
+	// NotFoundPolicy defines how the resource reacts when the external
+	// resource was deleted out-of-band
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=Recreate
+	NotFoundPolicy NotFoundPolicy `json:"notFoundPolicy,omitempty"`
+}
+
+// CustomerGatewayStatus defines the observed state of CustomerGateway.
+type CustomerGatewayStatus struct {
+	// Conditions represent the latest available observations of the CustomerGateway's state
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// BlockedBy lists the chain of unready resources the resource is waiting
+	// for, ending with the root cause
+	// +optional
+	BlockedBy []BlockingResource `json:"blockedBy,omitempty"`
+
+	// ExternalID is the provider's ID for this CustomerGateway
+	// +optional
+	ExternalID string `json:"externalID,omitempty"`
+
+	// ObservedGeneration reflects the generation of the most recently observed CustomerGateway spec
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// LastSyncTime is the timestamp of the last successful sync with the provider
+	// +optional
+	LastSyncTime *metav1.Time `json:"lastSyncTime,omitempty"`
+
+	// LastAppliedSpec caches the spec that was successfully applied to the
+	// external resource. It is used to detect changes to immutable fields.
+	// +optional
+	LastAppliedSpec *CustomerGatewaySpec `json:"lastAppliedSpec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Namespaced,categories=networking
+// +kubebuilder:printcolumn:name="IP",type=string,JSONPath=`.spec.ipAddress`
+// +kubebuilder:printcolumn:name="ASN",type=integer,JSONPath=`.spec.bgpASN`
+// +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
+// +kubebuilder:printcolumn:name="ExternalID",type=string,JSONPath=`.status.externalID`,priority=1
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// CustomerGateway is the Schema for the customergateways API
+type CustomerGateway struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty,omitzero"`
+
+	Spec   CustomerGatewaySpec   `json:"spec"`
+	Status CustomerGatewayStatus `json:"status,omitempty"`
+}
+
+// GetProviderConfigRef returns the ProviderConfig used for authentication.
+func (cg *CustomerGateway) GetProviderConfigRef() ProviderConfigReference {
+	return cg.Spec.ProviderConfigRef
+}
+
+// GetNotFoundPolicy returns how a missing external resource is handled.
+func (cg *CustomerGateway) GetNotFoundPolicy() NotFoundPolicy {
+	return cg.Spec.NotFoundPolicy
+}
+
+// GetOrphanOnDelete returns whether the external resource is kept on deletion.
+func (cg *CustomerGateway) GetOrphanOnDelete() bool {
+	return cg.Spec.OrphanOnDelete
+}
+
+// GetConnectionDetailsRef returns the Secret or ConfigMap the outputs are written to.
+func (cg *CustomerGateway) GetConnectionDetailsRef() *ConnectionDetailsReference {
+	return cg.Spec.WriteConnectionDetailsToRef
+}
+
+// GetConditions returns the conditions of the status.
+func (cg *CustomerGateway) GetConditions() *[]metav1.Condition {
+	return &cg.Status.Conditions
+}
+
+// GetExternalID returns the provider's ID.
+func (cg *CustomerGateway) GetExternalID() string {
+	return cg.Status.ExternalID
+}
+
+// SetExternalID sets the provider's ID.
+func (cg *CustomerGateway) SetExternalID(id string) {
+	cg.Status.ExternalID = id
+}
+
+// GetLastSyncTime returns the time of the last successful sync.
+func (cg *CustomerGateway) GetLastSyncTime() *metav1.Time {
+	return cg.Status.LastSyncTime
+}
+
+// SetLastSyncTime sets the time of the last successful sync.
+func (cg *CustomerGateway) SetLastSyncTime(t *metav1.Time) {
+	cg.Status.LastSyncTime = t
+}
+
+// HasLastAppliedSpec returns whether a spec was applied before.
+func (cg *CustomerGateway) HasLastAppliedSpec() bool {
+	return cg.Status.LastAppliedSpec != nil
+}
+
+// SetLastAppliedSpec records the current spec as applied.
+func (cg *CustomerGateway) SetLastAppliedSpec() {
+	cg.Status.LastAppliedSpec = cg.Spec.DeepCopy()
+}
+
+// ResetLastAppliedSpec clears the applied spec.
+func (cg *CustomerGateway) ResetLastAppliedSpec() {
+	cg.Status.LastAppliedSpec = nil
+}
+
+// SetBlockedBy sets the chain of unready resources the resource waits for.
+func (cg *CustomerGateway) SetBlockedBy(blockers []BlockingResource) {
+	cg.Status.BlockedBy = blockers
+}
+
+// +kubebuilder:object:root=true
+
+// CustomerGatewayList contains a list of CustomerGateway
+type CustomerGatewayList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []CustomerGateway `json:"items"`
+}
+
+// GetItems returns the list of items as a slice of client.Object.
+func (cl *CustomerGatewayList) GetItems() []client.Object {
+	items := make([]client.Object, len(cl.Items))
+	for i := range cl.Items {
+		items[i] = &cl.Items[i]
+	}
+	return items
+}
+
+func init() {
+	SchemeBuilder.Register(&CustomerGateway{}, &CustomerGatewayList{})
+}
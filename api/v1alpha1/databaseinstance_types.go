@@ -0,0 +1,353 @@
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// +kubebuilder:validation:Enum=MySQL;PostgreSQL
+type DatabaseEngine string
+
+const (
+	DatabaseEngineMySQL      DatabaseEngine = "MySQL"
+	DatabaseEnginePostgreSQL DatabaseEngine = "PostgreSQL"
+)
+
+// +kubebuilder:validation:Enum=Single;HA
+type DatabaseHAMode string
+
+const (
+	DatabaseHAModeSingle DatabaseHAMode = "Single"
+	DatabaseHAModeHA     DatabaseHAMode = "HA"
+)
+
+// +kubebuilder:validation:Enum=async;semisync;sync
+type DatabaseReplicationMode string
+
+const (
+	DatabaseReplicationAsync    DatabaseReplicationMode = "async"
+	DatabaseReplicationSemiSync DatabaseReplicationMode = "semisync"
+	DatabaseReplicationSync     DatabaseReplicationMode = "sync"
+)
+
+// +kubebuilder:validation:Enum=COMMON;ULTRAHIGH;CLOUDSSD;ESSD
+type DatabaseVolumeType string
+
+const (
+	DatabaseVolumeTypeCommon    DatabaseVolumeType = "COMMON"
+	DatabaseVolumeTypeUltraHigh DatabaseVolumeType = "ULTRAHIGH"
+	DatabaseVolumeTypeCloudSSD  DatabaseVolumeType = "CLOUDSSD"
+	DatabaseVolumeTypeESSD      DatabaseVolumeType = "ESSD"
+)
+
+// +kubebuilder:validation:XValidation:rule="self.size >= oldSelf.size",message="volume size cannot be decreased"
+
+// DatabaseVolume defines the storage of a database instance
+type DatabaseVolume struct {
+	// Type is the storage type
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=ULTRAHIGH
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="volume type is immutable"
+	Type DatabaseVolumeType `json:"type,omitempty"`
+
+	// Size is the size of the storage in GB. It can only be increased, in
+	// steps of 10 GB.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Minimum=40
+	// +kubebuilder:validation:Maximum=4000
+	// +kubebuilder:validation:MultipleOf=10
+	Size int `json:"size"`
+
+	// EncryptionKey is the KMS key the storage is encrypted with. If omitted,
+	// the storage is not encrypted.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="encryptionKey is immutable"
+	EncryptionKey *KMSKeyDependency `json:"encryptionKey,omitempty"`
+}
+
+// DatabaseBackupPolicy defines the automated backups of a database instance
+type DatabaseBackupPolicy struct {
+	// StartTime is the UTC time window the backups are started in, in the
+	// format hh:mm-HH:MM (e.g. 01:00-02:00). The window is one hour long.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Pattern=`^([01][0-9]|2[0-3]):(00|15|30|45)-([01][0-9]|2[0-3]):(00|15|30|45)$`
+	StartTime string `json:"startTime"`
+
+	// KeepDays is the number of days the backups are retained. Zero disables
+	// the automated backups.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=732
+	KeepDays int `json:"keepDays,omitempty"`
+}
+
+// +kubebuilder:validation:XValidation:rule="self.haMode == 'HA' ? size(self.availabilityZones) == 2 : size(self.availabilityZones) == 1",message="HA instances require two availability zones, single instances one"
+// +kubebuilder:validation:XValidation:rule="!has(self.replicationMode) || self.haMode == 'HA'",message="replicationMode can only be set for HA instances"
+
+// DatabaseInstanceSpec defines the desired state of DatabaseInstance
+type DatabaseInstanceSpec struct {
+	// ProviderConfigRef references the ProviderConfig to use for authentication
+	// +kubebuilder:validation:Required
+	ProviderConfigRef ProviderConfigReference `json:"providerConfigRef"`
+
+	// Engine is the database engine
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="engine is immutable"
+	Engine DatabaseEngine `json:"engine"`
+
+	// Version is the major version of the database engine (e.g. 8.0 or 15)
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="version is immutable"
+	Version string `json:"version"`
+
+	// Flavor is the flavor of the instance (e.g. rds.mysql.c2.large). The
+	// flavor has to match the HA mode. Changing it resizes the instance.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	Flavor string `json:"flavor"`
+
+	// Volume defines the storage of the instance
+	// +kubebuilder:validation:Required
+	Volume DatabaseVolume `json:"volume"`
+
+	// HAMode is the deployment mode of the instance. HA instances run a
+	// primary and a standby node.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=Single
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="haMode is immutable"
+	HAMode DatabaseHAMode `json:"haMode,omitempty"`
+
+	// ReplicationMode is the replication mode between the primary and the
+	// standby node of an HA instance. MySQL supports async and semisync,
+	// PostgreSQL async and sync. If omitted, async is used.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="replicationMode is immutable"
+	ReplicationMode DatabaseReplicationMode `json:"replicationMode,omitempty"`
+
+	// AvailabilityZones are the availability zones of the nodes. Single
+	// instances take one zone, HA instances two, which may be equal.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=2
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="availabilityZones is immutable"
+	AvailabilityZones []string `json:"availabilityZones"`
+
+	// Port is the port the database listens on. If omitted, the default port
+	// of the engine is used.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="port is immutable"
+	Port *int32 `json:"port,omitempty"`
+
+	// BackupPolicy defines the automated backups of the instance
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default={startTime: "01:00-02:00", keepDays: 7}
+	BackupPolicy DatabaseBackupPolicy `json:"backupPolicy,omitempty"`
+
+	// AdminPasswordSecretRef references a key of a Secret holding the password
+	// of the administrator account. If the key does not exist, a password is
+	// generated and written to it. The Secret is created if it does not exist.
+	// The password is only used when the instance is created.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="adminPasswordSecretRef is immutable"
+	AdminPasswordSecretRef corev1.SecretKeySelector `json:"adminPasswordSecretRef"`
+
+	// Network defines the network the instance is created in
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="network is immutable"
+	Network NetworkDependency `json:"network"`
+
+	// Subnet defines the subnet the instance is attached to
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="subnet is immutable"
+	Subnet SubnetDependency `json:"subnet"`
+
+	// SecurityGroup defines the security group of the instance
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="securityGroup is immutable"
+	SecurityGroup SecurityGroupDependency `json:"securityGroup"`
+
+	// WriteConnectionDetailsToRef specifies the Secret or ConfigMap the outputs
+	// of the resource are written to. These are the host, port and username
+	// of the instance.
+	// +kubebuilder:validation:Optional
+	WriteConnectionDetailsToRef *ConnectionDetailsReference `json:"writeConnectionDetailsToRef,omitempty"`
+
+	// OrphanOnDelete prevents deletion of the external resource when the CR is deleted
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=false
+	OrphanOnDelete bool `json:"orphanOnDelete,omitempty"`
+
+	// NotFoundPolicy defines how the resource reacts when the external
+	// resource was deleted out-of-band
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=Recreate
+	NotFoundPolicy NotFoundPolicy `json:"notFoundPolicy,omitempty"`
+}
+
+// DatabaseInstanceDependenciesResolved contains the resolved IDs for database instance dependencies
+type DatabaseInstanceDependenciesResolved struct {
+	// NetworkID is the resolved Network ID
+	NetworkID string `json:"networkID,omitempty"`
+	// SubnetID is the resolved Subnet ID
+	SubnetID string `json:"subnetID,omitempty"`
+	// SecurityGroupID is the resolved SecurityGroup ID
+	SecurityGroupID string `json:"securityGroupID,omitempty"`
+	// KMSKeyID is the resolved KMSKey ID the storage is encrypted with
+	KMSKeyID string `json:"kmsKeyID,omitempty"`
+}
+
+// DatabaseInstanceStatus defines the observed state of DatabaseInstance.
+type DatabaseInstanceStatus struct {
+	// Conditions represent the latest available observations of the DatabaseInstance's state
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// BlockedBy lists the chain of unready resources the resource is waiting
+	// for, ending with the root cause
+	// +optional
+	BlockedBy []BlockingResource `json:"blockedBy,omitempty"`
+
+	// ExternalID is the provider's ID for this DatabaseInstance
+	// +optional
+	ExternalID string `json:"externalID,omitempty"`
+
+	// ResolvedDependencies contains the resolved IDs for database instance dependencies
+	// +optional
+	ResolvedDependencies DatabaseInstanceDependenciesResolved `json:"resolvedDependencies"`
+
+	// Status is the status of the instance reported by the provider
+	// +optional
+	Status string `json:"status,omitempty"`
+
+	// Host is the private IP address of the instance
+	// +optional
+	Host string `json:"host,omitempty"`
+
+	// Port is the port the database listens on
+	// +optional
+	Port int32 `json:"port,omitempty"`
+
+	// ObservedGeneration reflects the generation of the most recently observed DatabaseInstance spec
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// LastSyncTime is the timestamp of the last successful sync with the provider
+	// +optional
+	LastSyncTime *metav1.Time `json:"lastSyncTime,omitempty"`
+
+	// LastAppliedSpec caches the spec that was successfully applied to the
+	// external resource. It is used to detect changes to immutable fields.
+	// +optional
+	LastAppliedSpec *DatabaseInstanceSpec `json:"lastAppliedSpec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Namespaced,categories=database
+// +kubebuilder:printcolumn:name="Engine",type=string,JSONPath=`.spec.engine`
+// +kubebuilder:printcolumn:name="Version",type=string,JSONPath=`.spec.version`
+// +kubebuilder:printcolumn:name="Host",type=string,JSONPath=`.status.host`
+// +kubebuilder:printcolumn:name="Status",type=string,JSONPath=`.status.status`
+// +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
+// +kubebuilder:printcolumn:name="ExternalID",type=string,JSONPath=`.status.externalID`,priority=1
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// DatabaseInstance is the Schema for the databaseinstances API
+type DatabaseInstance struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty,omitzero"`
+
+	Spec   DatabaseInstanceSpec   `json:"spec"`
+	Status DatabaseInstanceStatus `json:"status,omitempty"`
+}
+
+// GetProviderConfigRef returns the ProviderConfig used for authentication.
+func (di *DatabaseInstance) GetProviderConfigRef() ProviderConfigReference {
+	return di.Spec.ProviderConfigRef
+}
+
+// GetNotFoundPolicy returns how a missing external resource is handled.
+func (di *DatabaseInstance) GetNotFoundPolicy() NotFoundPolicy {
+	return di.Spec.NotFoundPolicy
+}
+
+// GetOrphanOnDelete returns whether the external resource is kept on deletion.
+func (di *DatabaseInstance) GetOrphanOnDelete() bool {
+	return di.Spec.OrphanOnDelete
+}
+
+// GetConnectionDetailsRef returns the Secret or ConfigMap the outputs are written to.
+func (di *DatabaseInstance) GetConnectionDetailsRef() *ConnectionDetailsReference {
+	return di.Spec.WriteConnectionDetailsToRef
+}
+
+// GetConditions returns the conditions of the status.
+func (di *DatabaseInstance) GetConditions() *[]metav1.Condition {
+	return &di.Status.Conditions
+}
+
+// GetExternalID returns the provider's ID.
+func (di *DatabaseInstance) GetExternalID() string {
+	return di.Status.ExternalID
+}
+
+// SetExternalID sets the provider's ID.
+func (di *DatabaseInstance) SetExternalID(id string) {
+	di.Status.ExternalID = id
+}
+
+// GetLastSyncTime returns the time of the last successful sync.
+func (di *DatabaseInstance) GetLastSyncTime() *metav1.Time {
+	return di.Status.LastSyncTime
+}
+
+// SetLastSyncTime sets the time of the last successful sync.
+func (di *DatabaseInstance) SetLastSyncTime(t *metav1.Time) {
+	di.Status.LastSyncTime = t
+}
+
+// HasLastAppliedSpec returns whether a spec was applied before.
+func (di *DatabaseInstance) HasLastAppliedSpec() bool {
+	return di.Status.LastAppliedSpec != nil
+}
+
+// SetLastAppliedSpec records the current spec as applied.
+func (di *DatabaseInstance) SetLastAppliedSpec() {
+	di.Status.LastAppliedSpec = di.Spec.DeepCopy()
+}
+
+// ResetLastAppliedSpec clears the applied spec.
+func (di *DatabaseInstance) ResetLastAppliedSpec() {
+	di.Status.LastAppliedSpec = nil
+}
+
+// SetBlockedBy sets the chain of unready resources the resource waits for.
+func (di *DatabaseInstance) SetBlockedBy(blockers []BlockingResource) {
+	di.Status.BlockedBy = blockers
+}
+
+// +kubebuilder:object:root=true
+
+// DatabaseInstanceList contains a list of DatabaseInstance
+type DatabaseInstanceList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []DatabaseInstance `json:"items"`
+}
+
+// GetItems returns the list of items as a slice of client.Object.
+func (dl *DatabaseInstanceList) GetItems() []client.Object {
+	items := make([]client.Object, len(dl.Items))
+	for i := range dl.Items {
+		items[i] = &dl.Items[i]
+	}
+	return items
+}
+
+func init() {
+	SchemeBuilder.Register(&DatabaseInstance{}, &DatabaseInstanceList{})
+}
@@ -0,0 +1,222 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// +kubebuilder:validation:Enum=public;private
+type DNSZoneType string
+
+const (
+	DNSZonePublic  DNSZoneType = "public"
+	DNSZonePrivate DNSZoneType = "private"
+)
+
+// DNSZoneSpec defines the desired state of DNSZone
+// +kubebuilder:validation:XValidation:rule="self.type == 'private' || !has(self.networks)",message="networks can only be set for private zones"
+// +kubebuilder:validation:XValidation:rule="self.type != 'private' || (has(self.networks) && size(self.networks) > 0)",message="private zones require at least one network"
+type DNSZoneSpec struct {
+	// ProviderConfigRef references the ProviderConfig to use for authentication
+	// +kubebuilder:validation:Required
+	ProviderConfigRef ProviderConfigReference `json:"providerConfigRef"`
+
+	// DomainName is the domain name of the zone (e.g. example.com.)
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=254
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="domainName is immutable"
+	DomainName string `json:"domainName"`
+
+	// Type is the zone type. Public zones are resolvable from the internet,
+	// private zones only from within the associated networks.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=public
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="type is immutable"
+	Type DNSZoneType `json:"type,omitempty"`
+
+	// Email is the email address of the zone administrator
+	// +kubebuilder:validation:Optional
+	Email string `json:"email,omitempty"`
+
+	// TTL is the time to live of the SOA record in seconds
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=300
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=2147483647
+	TTL int `json:"ttl,omitempty"`
+
+	// Description is a human-readable description for the zone
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxLength=255
+	Description string `json:"description,omitempty"`
+
+	// Networks defines the networks a private zone is associated with
+	// +kubebuilder:validation:Optional
+	Networks []NetworkDependency `json:"networks,omitempty"`
+
+	// WriteConnectionDetailsToRef specifies the Secret or ConfigMap the outputs
+	// of the resource are written to
+	// +kubebuilder:validation:Optional
+	WriteConnectionDetailsToRef *ConnectionDetailsReference `json:"writeConnectionDetailsToRef,omitempty"`
+
+	// OrphanOnDelete prevents deletion of the external resource when the CR is deleted
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=false
+	OrphanOnDelete bool `json:"orphanOnDelete,omitempty"`
+
+	// NotFoundPolicy defines how the resource reacts when the external
+	// resource was deleted out-of-band
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=Recreate
+	NotFoundPolicy NotFoundPolicy `json:"notFoundPolicy,omitempty"`
+}
+
+// DNSZoneDependenciesResolved contains the resolved IDs for DNS zone dependencies
+type DNSZoneDependenciesResolved struct {
+	// NetworkIDs are the resolved Network IDs in the order of the networks
+	NetworkIDs []string `json:"networkIDs,omitempty"`
+}
+
+// DNSZoneStatus defines the observed state of DNSZone.
+type DNSZoneStatus struct {
+	// Conditions represent the latest available observations of the DNSZone's state
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// BlockedBy lists the chain of unready resources the resource is waiting
+	// for, ending with the root cause
+	// +optional
+	BlockedBy []BlockingResource `json:"blockedBy,omitempty"`
+
+	// ExternalID is the provider's ID for this DNSZone
+	// +optional
+	ExternalID string `json:"externalID,omitempty"`
+
+	// ResolvedDependencies contains the resolved IDs for DNS zone dependencies
+	// +optional
+	ResolvedDependencies DNSZoneDependenciesResolved `json:"resolvedDependencies"`
+
+	// AssociatedNetworkIDs are the IDs of the networks a private zone is
+	// associated with
+	// +optional
+	AssociatedNetworkIDs []string `json:"associatedNetworkIDs,omitempty"`
+
+	// ObservedGeneration reflects the generation of the most recently observed DNSZone spec
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// LastSyncTime is the timestamp of the last successful sync with the provider
+	// +optional
+	LastSyncTime *metav1.Time `json:"lastSyncTime,omitempty"`
+
+	// LastAppliedSpec caches the spec that was successfully applied to the
+	// external resource. It is used to detect changes to immutable fields.
+	// +optional
+	LastAppliedSpec *DNSZoneSpec `json:"lastAppliedSpec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Namespaced,categories=networking
+// +kubebuilder:printcolumn:name="Domain",type=string,JSONPath=`.spec.domainName`
+// +kubebuilder:printcolumn:name="Type",type=string,JSONPath=`.spec.type`
+// +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
+// +kubebuilder:printcolumn:name="ExternalID",type=string,JSONPath=`.status.externalID`,priority=1
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// DNSZone is the Schema for the dnszones API
+type DNSZone struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty,omitzero"`
+
+	Spec   DNSZoneSpec   `json:"spec"`
+	Status DNSZoneStatus `json:"status,omitempty"`
+}
+
+// GetProviderConfigRef returns the ProviderConfig used for authentication.
+func (z *DNSZone) GetProviderConfigRef() ProviderConfigReference {
+	return z.Spec.ProviderConfigRef
+}
+
+// GetNotFoundPolicy returns how a missing external resource is handled.
+func (z *DNSZone) GetNotFoundPolicy() NotFoundPolicy {
+	return z.Spec.NotFoundPolicy
+}
+
+// GetOrphanOnDelete returns whether the external resource is kept on deletion.
+func (z *DNSZone) GetOrphanOnDelete() bool {
+	return z.Spec.OrphanOnDelete
+}
+
+// GetConnectionDetailsRef returns the Secret or ConfigMap the outputs are written to.
+func (z *DNSZone) GetConnectionDetailsRef() *ConnectionDetailsReference {
+	return z.Spec.WriteConnectionDetailsToRef
+}
+
+// GetConditions returns the conditions of the status.
+func (z *DNSZone) GetConditions() *[]metav1.Condition {
+	return &z.Status.Conditions
+}
+
+// GetExternalID returns the provider's ID.
+func (z *DNSZone) GetExternalID() string {
+	return z.Status.ExternalID
+}
+
+// SetExternalID sets the provider's ID.
+func (z *DNSZone) SetExternalID(id string) {
+	z.Status.ExternalID = id
+}
+
+// GetLastSyncTime returns the time of the last successful sync.
+func (z *DNSZone) GetLastSyncTime() *metav1.Time {
+	return z.Status.LastSyncTime
+}
+
+// SetLastSyncTime sets the time of the last successful sync.
+func (z *DNSZone) SetLastSyncTime(t *metav1.Time) {
+	z.Status.LastSyncTime = t
+}
+
+// HasLastAppliedSpec returns whether a spec was applied before.
+func (z *DNSZone) HasLastAppliedSpec() bool {
+	return z.Status.LastAppliedSpec != nil
+}
+
+// SetLastAppliedSpec records the current spec as applied.
+func (z *DNSZone) SetLastAppliedSpec() {
+	z.Status.LastAppliedSpec = z.Spec.DeepCopy()
+}
+
+// ResetLastAppliedSpec clears the applied spec.
+func (z *DNSZone) ResetLastAppliedSpec() {
+	z.Status.LastAppliedSpec = nil
+}
+
+// SetBlockedBy sets the chain of unready resources the resource waits for.
+func (z *DNSZone) SetBlockedBy(blockers []BlockingResource) {
+	z.Status.BlockedBy = blockers
+}
+
+// +kubebuilder:object:root=true
+
+// DNSZoneList contains a list of DNSZone
+type DNSZoneList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []DNSZone `json:"items"`
+}
+
+// GetItems returns the list of items as a slice of client.Object.
+func (dl *DNSZoneList) GetItems() []client.Object {
+	items := make([]client.Object, len(dl.Items))
+	for i := range dl.Items {
+		items[i] = &dl.Items[i]
+	}
+	return items
+}
+
+func init() {
+	SchemeBuilder.Register(&DNSZone{}, &DNSZoneList{})
+}
@@ -0,0 +1,339 @@
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// +kubebuilder:validation:Enum=SATA;SAS;SSD;GPSSD;ESSD
+type VolumeType string
+
+const (
+	VolumeTypeSATA  VolumeType = "SATA"
+	VolumeTypeSAS   VolumeType = "SAS"
+	VolumeTypeSSD   VolumeType = "SSD"
+	VolumeTypeGPSSD VolumeType = "GPSSD"
+	VolumeTypeESSD  VolumeType = "ESSD"
+)
+
+// +kubebuilder:validation:Enum=Running;Stopped
+type InstancePowerState string
+
+const (
+	InstancePowerStateRunning InstancePowerState = "Running"
+	InstancePowerStateStopped InstancePowerState = "Stopped"
+)
+
+// InstanceRootVolume defines the system disk of an instance
+type InstanceRootVolume struct {
+	// Type is the disk type of the system disk
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=SSD
+	Type VolumeType `json:"type,omitempty"`
+
+	// Size is the size of the system disk in GB. If omitted, the size of the
+	// image is used.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	Size int `json:"size,omitempty"`
+}
+
+// InstanceDataVolume defines a data disk which is created and attached
+// together with the instance
+type InstanceDataVolume struct {
+	// Type is the disk type of the data disk
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=SSD
+	Type VolumeType `json:"type,omitempty"`
+
+	// Size is the size of the data disk in GB
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Minimum=10
+	Size int `json:"size"`
+}
+
+// InstanceNIC defines a network interface of an instance. The first NIC is
+// the primary network interface.
+type InstanceNIC struct {
+	// Subnet defines the subnet the network interface is attached to
+	// +kubebuilder:validation:Required
+	Subnet SubnetDependency `json:"subnet"`
+
+	// IPAddress is the fixed IP address of the network interface. If omitted,
+	// an address is allocated from the subnet.
+	// +kubebuilder:validation:Optional
+	IPAddress string `json:"ipAddress,omitempty"`
+}
+
+// InstanceSpec defines the desired state of Instance
+type InstanceSpec struct {
+	// ProviderConfigRef references the ProviderConfig to use for authentication
+	// +kubebuilder:validation:Required
+	ProviderConfigRef ProviderConfigReference `json:"providerConfigRef"`
+
+	// Flavor is the ID of the flavor (e.g. s3.medium.2)
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="flavor is immutable"
+	Flavor string `json:"flavor"`
+
+	// ImageID is the ID of the image the instance is created from
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="imageID is immutable"
+	ImageID string `json:"imageID"`
+
+	// AvailabilityZone is the availability zone the instance is created in. If
+	// omitted, the availability zone is chosen by the provider.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="availabilityZone is immutable"
+	AvailabilityZone string `json:"availabilityZone,omitempty"`
+
+	// KeyPair defines the SSH key pair used to log in to the instance
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="keyPair is immutable"
+	KeyPair *KeyPairDependency `json:"keyPair,omitempty"`
+
+	// RootVolume defines the system disk of the instance
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="rootVolume is immutable"
+	RootVolume InstanceRootVolume `json:"rootVolume,omitempty"`
+
+	// DataVolumes defines the data disks created together with the instance.
+	// They are deleted together with the instance.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxItems=23
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="dataVolumes is immutable"
+	DataVolumes []InstanceDataVolume `json:"dataVolumes,omitempty"`
+
+	// UserDataSecretRef references a key of a Secret containing the user data
+	// (e.g. cloud-init) passed to the instance
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="userDataSecretRef is immutable"
+	UserDataSecretRef *corev1.SecretKeySelector `json:"userDataSecretRef,omitempty"`
+
+	// NICs defines the network interfaces of the instance. All subnets must
+	// belong to the same network.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=12
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="nics is immutable"
+	NICs []InstanceNIC `json:"nics"`
+
+	// SecurityGroups defines the security groups of the instance. If omitted,
+	// the default security group is used.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="securityGroups is immutable"
+	SecurityGroups []SecurityGroupDependency `json:"securityGroups,omitempty"`
+
+	// PublicIP defines the public IP bound to the primary network interface
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="publicIP is immutable"
+	PublicIP *PublicIPDependency `json:"publicIP,omitempty"`
+
+	// PowerState is the desired power state of the instance
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=Running
+	PowerState InstancePowerState `json:"powerState,omitempty"`
+
+	// WriteConnectionDetailsToRef specifies the Secret or ConfigMap the outputs
+	// of the resource are written to
+	// +kubebuilder:validation:Optional
+	WriteConnectionDetailsToRef *ConnectionDetailsReference `json:"writeConnectionDetailsToRef,omitempty"`
+
+	// OrphanOnDelete prevents deletion of the external resource when the CR is deleted
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=false
+	OrphanOnDelete bool `json:"orphanOnDelete,omitempty"`
+
+	// NotFoundPolicy defines how the resource reacts when the external
+	// resource was deleted out-of-band
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=Recreate
+	NotFoundPolicy NotFoundPolicy `json:"notFoundPolicy,omitempty"`
+}
+
+// InstanceDependenciesResolved contains the resolved IDs for instance dependencies
+type InstanceDependenciesResolved struct {
+	// SubnetIDs are the resolved Subnet IDs in the order of the NICs
+	SubnetIDs []string `json:"subnetIDs,omitempty"`
+	// SecurityGroupIDs are the resolved SecurityGroup IDs
+	SecurityGroupIDs []string `json:"securityGroupIDs,omitempty"`
+	// PublicIPID is the resolved PublicIP ID
+	PublicIPID string `json:"publicIPID,omitempty"`
+	// KeyPairName is the resolved KeyPair name
+	KeyPairName string `json:"keyPairName,omitempty"`
+}
+
+// InstanceInterface describes an observed network interface of an instance
+type InstanceInterface struct {
+	// PortID is the provider's ID of the port backing the network interface
+	PortID string `json:"portID"`
+	// SubnetID is the ID of the subnet the network interface is attached to
+	SubnetID string `json:"subnetID,omitempty"`
+	// IPAddress is the fixed IP address of the network interface
+	IPAddress string `json:"ipAddress,omitempty"`
+}
+
+// InstanceStatus defines the observed state of Instance.
+type InstanceStatus struct {
+	// Conditions represent the latest available observations of the Instance's state
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// BlockedBy lists the chain of unready resources the resource is waiting
+	// for, ending with the root cause
+	// +optional
+	BlockedBy []BlockingResource `json:"blockedBy,omitempty"`
+
+	// ExternalID is the provider's ID for this Instance
+	// +optional
+	ExternalID string `json:"externalID,omitempty"`
+
+	// ResolvedDependencies contains the resolved IDs for instance dependencies
+	// +optional
+	ResolvedDependencies InstanceDependenciesResolved `json:"resolvedDependencies"`
+
+	// PowerState is the observed power state of the instance
+	// +optional
+	PowerState string `json:"powerState,omitempty"`
+
+	// Interfaces are the observed network interfaces of the instance. The
+	// primary network interface comes first.
+	// +optional
+	Interfaces []InstanceInterface `json:"interfaces,omitempty"`
+
+	// PrivateAddress is the fixed IP address of the primary network interface
+	// +optional
+	PrivateAddress string `json:"privateAddress,omitempty"`
+
+	// PublicAddress is the public IP address bound to the instance
+	// +optional
+	PublicAddress string `json:"publicAddress,omitempty"`
+
+	// DataVolumeIDs are the IDs of the data volumes created together with
+	// the instance, which are deleted with it
+	// +optional
+	DataVolumeIDs []string `json:"dataVolumeIDs,omitempty"`
+
+	// ObservedGeneration reflects the generation of the most recently observed Instance spec
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// LastSyncTime is the timestamp of the last successful sync with the provider
+	// +optional
+	LastSyncTime *metav1.Time `json:"lastSyncTime,omitempty"`
+
+	// LastAppliedSpec caches the spec that was successfully applied to the
+	// external resource. It is used to detect changes to immutable fields.
+	// +optional
+	LastAppliedSpec *InstanceSpec `json:"lastAppliedSpec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Namespaced,categories=compute
+// +kubebuilder:printcolumn:name="Flavor",type=string,JSONPath=`.spec.flavor`
+// +kubebuilder:printcolumn:name="Power",type=string,JSONPath=`.status.powerState`
+// +kubebuilder:printcolumn:name="Private",type=string,JSONPath=`.status.privateAddress`
+// +kubebuilder:printcolumn:name="Public",type=string,JSONPath=`.status.publicAddress`
+// +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
+// +kubebuilder:printcolumn:name="ExternalID",type=string,JSONPath=`.status.externalID`,priority=1
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// Instance is the Schema for the instances API
+type Instance struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty,omitzero"`
+
+	Spec   InstanceSpec   `json:"spec"`
+	Status InstanceStatus `json:"status,omitempty"`
+}
+
+// GetProviderConfigRef returns the ProviderConfig used for authentication.
+func (i *Instance) GetProviderConfigRef() ProviderConfigReference {
+	return i.Spec.ProviderConfigRef
+}
+
+// GetNotFoundPolicy returns how a missing external resource is handled.
+func (i *Instance) GetNotFoundPolicy() NotFoundPolicy {
+	return i.Spec.NotFoundPolicy
+}
+
+// GetOrphanOnDelete returns whether the external resource is kept on deletion.
+func (i *Instance) GetOrphanOnDelete() bool {
+	return i.Spec.OrphanOnDelete
+}
+
+// GetConnectionDetailsRef returns the Secret or ConfigMap the outputs are written to.
+func (i *Instance) GetConnectionDetailsRef() *ConnectionDetailsReference {
+	return i.Spec.WriteConnectionDetailsToRef
+}
+
+// GetConditions returns the conditions of the status.
+func (i *Instance) GetConditions() *[]metav1.Condition {
+	return &i.Status.Conditions
+}
+
+// GetExternalID returns the provider's ID.
+func (i *Instance) GetExternalID() string {
+	return i.Status.ExternalID
+}
+
+// SetExternalID sets the provider's ID.
+func (i *Instance) SetExternalID(id string) {
+	i.Status.ExternalID = id
+}
+
+// GetLastSyncTime returns the time of the last successful sync.
+func (i *Instance) GetLastSyncTime() *metav1.Time {
+	return i.Status.LastSyncTime
+}
+
+// SetLastSyncTime sets the time of the last successful sync.
+func (i *Instance) SetLastSyncTime(t *metav1.Time) {
+	i.Status.LastSyncTime = t
+}
+
+// HasLastAppliedSpec returns whether a spec was applied before.
+func (i *Instance) HasLastAppliedSpec() bool {
+	return i.Status.LastAppliedSpec != nil
+}
+
+// SetLastAppliedSpec records the current spec as applied.
+func (i *Instance) SetLastAppliedSpec() {
+	i.Status.LastAppliedSpec = i.Spec.DeepCopy()
+}
+
+// ResetLastAppliedSpec clears the applied spec.
+func (i *Instance) ResetLastAppliedSpec() {
+	i.Status.LastAppliedSpec = nil
+}
+
+// SetBlockedBy sets the chain of unready resources the resource waits for.
+func (i *Instance) SetBlockedBy(blockers []BlockingResource) {
+	i.Status.BlockedBy = blockers
+}
+
+// +kubebuilder:object:root=true
+
+// InstanceList contains a list of Instance
+type InstanceList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Instance `json:"items"`
+}
+
+// GetItems returns the list of items as a slice of client.Object.
+func (il *InstanceList) GetItems() []client.Object {
+	items := make([]client.Object, len(il.Items))
+	for i := range il.Items {
+		items[i] = &il.Items[i]
+	}
+	return items
+}
+
+func init() {
+	SchemeBuilder.Register(&Instance{}, &InstanceList{})
+}
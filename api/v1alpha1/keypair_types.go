@@ -0,0 +1,189 @@
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// KeyPairSpec defines the desired state of KeyPair. Either an existing public
+// key is imported from a Secret, or a new key pair is generated and its
+// private key is written to a Secret.
+// +kubebuilder:validation:XValidation:rule="has(self.publicKeySecretRef) != has(self.privateKeySecretRef)",message="exactly one of publicKeySecretRef or privateKeySecretRef must be set"
+type KeyPairSpec struct {
+	// ProviderConfigRef references the ProviderConfig to use for authentication
+	// +kubebuilder:validation:Required
+	ProviderConfigRef ProviderConfigReference `json:"providerConfigRef"`
+
+	// PublicKeySecretRef references a key of a Secret containing the OpenSSH
+	// public key to import
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="publicKeySecretRef is immutable"
+	PublicKeySecretRef *corev1.SecretKeySelector `json:"publicKeySecretRef,omitempty"`
+
+	// PrivateKeySecretRef references a key of a Secret the private key of a
+	// generated key pair is written to. The Secret is created if it does not
+	// exist and is deleted together with the KeyPair.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="privateKeySecretRef is immutable"
+	PrivateKeySecretRef *corev1.SecretKeySelector `json:"privateKeySecretRef,omitempty"`
+
+	// WriteConnectionDetailsToRef specifies the Secret or ConfigMap the outputs
+	// of the resource are written to
+	// +kubebuilder:validation:Optional
+	WriteConnectionDetailsToRef *ConnectionDetailsReference `json:"writeConnectionDetailsToRef,omitempty"`
+
+	// OrphanOnDelete prevents deletion of the external resource when the CR is deleted
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=false
+	OrphanOnDelete bool `json:"orphanOnDelete,omitempty"`
+
+	// NotFoundPolicy defines how the resource reacts when the external
+	// resource was deleted out-of-band
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=Recreate
+	NotFoundPolicy NotFoundPolicy `json:"notFoundPolicy,omitempty"`
+}
+
+// KeyPairStatus defines the observed state of KeyPair.
+type KeyPairStatus struct {
+	// Conditions represent the latest available observations of the KeyPair's state
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// BlockedBy lists the chain of unready resources the resource is waiting
+	// for, ending with the root cause
+	// +optional
+	BlockedBy []BlockingResource `json:"blockedBy,omitempty"`
+
+	// ExternalID is the provider's ID for this KeyPair, which is its name
+	// qualified with the namespace and a hash suffix,
+	// <namespace>-<name>-<hash>
+	// +optional
+	ExternalID string `json:"externalID,omitempty"`
+
+	// Fingerprint is the fingerprint of the public key
+	// +optional
+	Fingerprint string `json:"fingerprint,omitempty"`
+
+	// PublicKey is the OpenSSH public key of the key pair
+	// +optional
+	PublicKey string `json:"publicKey,omitempty"`
+
+	// ObservedGeneration reflects the generation of the most recently observed KeyPair spec
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// LastSyncTime is the timestamp of the last successful sync with the provider
+	// +optional
+	LastSyncTime *metav1.Time `json:"lastSyncTime,omitempty"`
+
+	// LastAppliedSpec caches the spec that was successfully applied to the
+	// external resource. It is used to detect changes to immutable fields.
+	// +optional
+	LastAppliedSpec *KeyPairSpec `json:"lastAppliedSpec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Namespaced,categories=compute
+// +kubebuilder:printcolumn:name="Fingerprint",type=string,JSONPath=`.status.fingerprint`
+// +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
+// +kubebuilder:printcolumn:name="ExternalID",type=string,JSONPath=`.status.externalID`,priority=1
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// KeyPair is the Schema for the keypairs API
+type KeyPair struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty,omitzero"`
+
+	Spec   KeyPairSpec   `json:"spec"`
+	Status KeyPairStatus `json:"status,omitempty"`
+}
+
+// GetProviderConfigRef returns the ProviderConfig used for authentication.
+func (kp *KeyPair) GetProviderConfigRef() ProviderConfigReference {
+	return kp.Spec.ProviderConfigRef
+}
+
+// GetNotFoundPolicy returns how a missing external resource is handled.
+func (kp *KeyPair) GetNotFoundPolicy() NotFoundPolicy {
+	return kp.Spec.NotFoundPolicy
+}
+
+// GetOrphanOnDelete returns whether the external resource is kept on deletion.
+func (kp *KeyPair) GetOrphanOnDelete() bool {
+	return kp.Spec.OrphanOnDelete
+}
+
+// GetConnectionDetailsRef returns the Secret or ConfigMap the outputs are written to.
+func (kp *KeyPair) GetConnectionDetailsRef() *ConnectionDetailsReference {
+	return kp.Spec.WriteConnectionDetailsToRef
+}
+
+// GetConditions returns the conditions of the status.
+func (kp *KeyPair) GetConditions() *[]metav1.Condition {
+	return &kp.Status.Conditions
+}
+
+// GetExternalID returns the provider's ID.
+func (kp *KeyPair) GetExternalID() string {
+	return kp.Status.ExternalID
+}
+
+// SetExternalID sets the provider's ID.
+func (kp *KeyPair) SetExternalID(id string) {
+	kp.Status.ExternalID = id
+}
+
+// GetLastSyncTime returns the time of the last successful sync.
+func (kp *KeyPair) GetLastSyncTime() *metav1.Time {
+	return kp.Status.LastSyncTime
+}
+
+// SetLastSyncTime sets the time of the last successful sync.
+func (kp *KeyPair) SetLastSyncTime(t *metav1.Time) {
+	kp.Status.LastSyncTime = t
+}
+
+// HasLastAppliedSpec returns whether a spec was applied before.
+func (kp *KeyPair) HasLastAppliedSpec() bool {
+	return kp.Status.LastAppliedSpec != nil
+}
+
+// SetLastAppliedSpec records the current spec as applied.
+func (kp *KeyPair) SetLastAppliedSpec() {
+	kp.Status.LastAppliedSpec = kp.Spec.DeepCopy()
+}
+
+// ResetLastAppliedSpec clears the applied spec.
+func (kp *KeyPair) ResetLastAppliedSpec() {
+	kp.Status.LastAppliedSpec = nil
+}
+
+// SetBlockedBy sets the chain of unready resources the resource waits for.
+func (kp *KeyPair) SetBlockedBy(blockers []BlockingResource) {
+	kp.Status.BlockedBy = blockers
+}
+
+// +kubebuilder:object:root=true
+
+// KeyPairList contains a list of KeyPair
+type KeyPairList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []KeyPair `json:"items"`
+}
+
+// GetItems returns the list of items as a slice of client.Object.
+func (kl *KeyPairList) GetItems() []client.Object {
+	items := make([]client.Object, len(kl.Items))
+	for i := range kl.Items {
+		items[i] = &kl.Items[i]
+	}
+	return items
+}
+
+func init() {
+	SchemeBuilder.Register(&KeyPair{}, &KeyPairList{})
+}
@@ -0,0 +1,203 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// KMSKeySpec defines the desired state of KMSKey. The key is a customer
+// master key (CMK) of the Key Management Service, which can be used to
+// encrypt volumes, buckets and database instances.
+type KMSKeySpec struct {
+	// ProviderConfigRef references the ProviderConfig to use for authentication
+	// +kubebuilder:validation:Required
+	ProviderConfigRef ProviderConfigReference `json:"providerConfigRef"`
+
+	// Alias is the alias of the key, which has to be unique within the region
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=255
+	// +kubebuilder:validation:Pattern=`^[a-zA-Z0-9:/_-]+$`
+	Alias string `json:"alias"`
+
+	// Description is the description of the key
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxLength=255
+	Description string `json:"description,omitempty"`
+
+	// RotationEnabled enables the automatic rotation of the key material
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=false
+	RotationEnabled bool `json:"rotationEnabled"`
+
+	// RotationIntervalDays is the number of days between two rotations of the
+	// key material
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=30
+	// +kubebuilder:validation:Maximum=365
+	// +kubebuilder:default=365
+	RotationIntervalDays int `json:"rotationIntervalDays,omitempty"`
+
+	// PendingDeletionWindowDays is the number of days after which the key is
+	// deleted once this resource is deleted. Until then, the deletion can be
+	// cancelled at the provider.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=7
+	// +kubebuilder:validation:Maximum=1096
+	// +kubebuilder:default=7
+	PendingDeletionWindowDays int `json:"pendingDeletionWindowDays,omitempty"`
+
+	// WriteConnectionDetailsToRef specifies the Secret or ConfigMap the outputs
+	// of the resource are written to
+	// +kubebuilder:validation:Optional
+	WriteConnectionDetailsToRef *ConnectionDetailsReference `json:"writeConnectionDetailsToRef,omitempty"`
+
+	// OrphanOnDelete prevents deletion of the external resource when the CR is deleted
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=false
+	OrphanOnDelete bool `json:"orphanOnDelete,omitempty"`
+
+	// NotFoundPolicy defines how the resource reacts when the external
+	// resource was deleted out-of-band
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=Recreate
+	NotFoundPolicy NotFoundPolicy `json:"notFoundPolicy,omitempty"`
+}
+
+// KMSKeyStatus defines the observed state of KMSKey.
+type KMSKeyStatus struct {
+	// Conditions represent the latest available observations of the KMSKey's state
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// BlockedBy lists the chain of unready resources the resource is waiting
+	// for, ending with the root cause
+	// +optional
+	BlockedBy []BlockingResource `json:"blockedBy,omitempty"`
+
+	// ExternalID is the provider's ID for this KMSKey
+	// +optional
+	ExternalID string `json:"externalID,omitempty"`
+
+	// KeyState is the observed state of the key
+	// +optional
+	KeyState string `json:"keyState,omitempty"`
+
+	// ObservedGeneration reflects the generation of the most recently observed KMSKey spec
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// LastSyncTime is the timestamp of the last successful sync with the provider
+	// +optional
+	LastSyncTime *metav1.Time `json:"lastSyncTime,omitempty"`
+
+	// LastAppliedSpec caches the spec that was successfully applied to the
+	// external resource. It is used to detect changes to immutable fields.
+	// +optional
+	LastAppliedSpec *KMSKeySpec `json:"lastAppliedSpec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Namespaced,categories=security
+// +kubebuilder:printcolumn:name="Alias",type=string,JSONPath=`.spec.alias`
+// +kubebuilder:printcolumn:name="State",type=string,JSONPath=`.status.keyState`
+// +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
+// +kubebuilder:printcolumn:name="ExternalID",type=string,JSONPath=`.status.externalID`,priority=1
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// KMSKey is the Schema for the kmskeys API
+type KMSKey struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty,omitzero"`
+
+	Spec   KMSKeySpec   `json:"spec"`
+	Status KMSKeyStatus `json:"status,omitempty"`
+}
+
+// GetProviderConfigRef returns the ProviderConfig used for authentication.
+func (k *KMSKey) GetProviderConfigRef() ProviderConfigReference {
+	return k.Spec.ProviderConfigRef
+}
+
+// GetNotFoundPolicy returns how a missing external resource is handled.
+func (k *KMSKey) GetNotFoundPolicy() NotFoundPolicy {
+	return k.Spec.NotFoundPolicy
+}
+
+// GetOrphanOnDelete returns whether the external resource is kept on deletion.
+func (k *KMSKey) GetOrphanOnDelete() bool {
+	return k.Spec.OrphanOnDelete
+}
+
+// GetConnectionDetailsRef returns the Secret or ConfigMap the outputs are written to.
+func (k *KMSKey) GetConnectionDetailsRef() *ConnectionDetailsReference {
+	return k.Spec.WriteConnectionDetailsToRef
+}
+
+// GetConditions returns the conditions of the status.
+func (k *KMSKey) GetConditions() *[]metav1.Condition {
+	return &k.Status.Conditions
+}
+
+// GetExternalID returns the provider's ID.
+func (k *KMSKey) GetExternalID() string {
+	return k.Status.ExternalID
+}
+
+// SetExternalID sets the provider's ID.
+func (k *KMSKey) SetExternalID(id string) {
+	k.Status.ExternalID = id
+}
+
+// GetLastSyncTime returns the time of the last successful sync.
+func (k *KMSKey) GetLastSyncTime() *metav1.Time {
+	return k.Status.LastSyncTime
+}
+
+// SetLastSyncTime sets the time of the last successful sync.
+func (k *KMSKey) SetLastSyncTime(t *metav1.Time) {
+	k.Status.LastSyncTime = t
+}
+
+// HasLastAppliedSpec returns whether a spec was applied before.
+func (k *KMSKey) HasLastAppliedSpec() bool {
+	return k.Status.LastAppliedSpec != nil
+}
+
+// SetLastAppliedSpec records the current spec as applied.
+func (k *KMSKey) SetLastAppliedSpec() {
+	k.Status.LastAppliedSpec = k.Spec.DeepCopy()
+}
+
+// ResetLastAppliedSpec clears the applied spec.
+func (k *KMSKey) ResetLastAppliedSpec() {
+	k.Status.LastAppliedSpec = nil
+}
+
+// SetBlockedBy sets the chain of unready resources the resource waits for.
+func (k *KMSKey) SetBlockedBy(blockers []BlockingResource) {
+	k.Status.BlockedBy = blockers
+}
+
+// +kubebuilder:object:root=true
+
+// KMSKeyList contains a list of KMSKey
+type KMSKeyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []KMSKey `json:"items"`
+}
+
+// GetItems returns the list of items as a slice of client.Object.
+func (kl *KMSKeyList) GetItems() []client.Object {
+	items := make([]client.Object, len(kl.Items))
+	for i := range kl.Items {
+		items[i] = &kl.Items[i]
+	}
+	return items
+}
+
+func init() {
+	SchemeBuilder.Register(&KMSKey{}, &KMSKeyList{})
+}
@@ -41,10 +41,27 @@ type NATGatewaySpec struct {
 	// +kubebuilder:validation:Required
 	Type NATGatewayType `json:"type"`
 
+	// WriteConnectionDetailsToRef specifies the Secret or ConfigMap the outputs
+	// of the resource are written to
+	// +kubebuilder:validation:Optional
+	WriteConnectionDetailsToRef *ConnectionDetailsReference `json:"writeConnectionDetailsToRef,omitempty"`
+
 	// OrphanOnDelete prevents deletion of the external resource when the CR is deleted
 	// +kubebuilder:validation:Optional
 	// +kubebuilder:default=false
 	OrphanOnDelete bool `json:"orphanOnDelete,omitempty"`
+
+	// NotFoundPolicy defines how the resource reacts when the external
+	// resource was deleted out-of-band
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=Recreate
+	NotFoundPolicy NotFoundPolicy `json:"notFoundPolicy,omitempty"`
+
+	// DeletionPolicy defines whether the resource is deleted together with
+	// the resources it references in the same namespace
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=Block
+	DeletionPolicy DeletionPolicy `json:"deletionPolicy,omitempty"`
 }
 
 // NATGatewayNetworkResolved contains the resolved IDs for network dependencies
@@ -61,6 +78,11 @@ type NATGatewayStatus struct {
 	// +optional
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
 
+	// BlockedBy lists the chain of unready resources the resource is waiting
+	// for, ending with the root cause
+	// +optional
+	BlockedBy []BlockingResource `json:"blockedBy,omitempty"`
+
 	// ExternalID is the provider's ID for this NAT gateway
 	// +optional
 	ExternalID string `json:"externalID,omitempty"`
@@ -100,6 +122,77 @@ type NATGateway struct {
 	Status NATGatewayStatus `json:"status,omitempty"`
 }
 
+// GetProviderConfigRef returns the ProviderConfig used for authentication.
+func (ng *NATGateway) GetProviderConfigRef() ProviderConfigReference {
+	return ng.Spec.ProviderConfigRef
+}
+
+// GetNotFoundPolicy returns how a missing external resource is handled.
+func (ng *NATGateway) GetNotFoundPolicy() NotFoundPolicy {
+	return ng.Spec.NotFoundPolicy
+}
+
+// GetOrphanOnDelete returns whether the external resource is kept on deletion.
+func (ng *NATGateway) GetOrphanOnDelete() bool {
+	return ng.Spec.OrphanOnDelete
+}
+
+// GetDeletionPolicy returns whether the resource is deleted together with its
+// dependencies.
+func (ng *NATGateway) GetDeletionPolicy() DeletionPolicy {
+	return ng.Spec.DeletionPolicy
+}
+
+// GetConnectionDetailsRef returns the Secret or ConfigMap the outputs are written to.
+func (ng *NATGateway) GetConnectionDetailsRef() *ConnectionDetailsReference {
+	return ng.Spec.WriteConnectionDetailsToRef
+}
+
+// GetConditions returns the conditions of the status.
+func (ng *NATGateway) GetConditions() *[]metav1.Condition {
+	return &ng.Status.Conditions
+}
+
+// GetExternalID returns the provider's ID.
+func (ng *NATGateway) GetExternalID() string {
+	return ng.Status.ExternalID
+}
+
+// SetExternalID sets the provider's ID.
+func (ng *NATGateway) SetExternalID(id string) {
+	ng.Status.ExternalID = id
+}
+
+// GetLastSyncTime returns the time of the last successful sync.
+func (ng *NATGateway) GetLastSyncTime() *metav1.Time {
+	return ng.Status.LastSyncTime
+}
+
+// SetLastSyncTime sets the time of the last successful sync.
+func (ng *NATGateway) SetLastSyncTime(t *metav1.Time) {
+	ng.Status.LastSyncTime = t
+}
+
+// HasLastAppliedSpec returns whether a spec was applied before.
+func (ng *NATGateway) HasLastAppliedSpec() bool {
+	return ng.Status.LastAppliedSpec != nil
+}
+
+// SetLastAppliedSpec records the current spec as applied.
+func (ng *NATGateway) SetLastAppliedSpec() {
+	ng.Status.LastAppliedSpec = ng.Spec.DeepCopy()
+}
+
+// ResetLastAppliedSpec clears the applied spec.
+func (ng *NATGateway) ResetLastAppliedSpec() {
+	ng.Status.LastAppliedSpec = nil
+}
+
+// SetBlockedBy sets the chain of unready resources the resource waits for.
+func (ng *NATGateway) SetBlockedBy(blockers []BlockingResource) {
+	ng.Status.BlockedBy = blockers
+}
+
 // +kubebuilder:object:root=true
 
 // NATGatewayList contains a list of NATGateway
@@ -20,10 +20,21 @@ type NetworkSpec struct {
 	// +kubebuilder:validation:Required
 	Cidr string `json:"cidr"`
 
+	// WriteConnectionDetailsToRef specifies the Secret or ConfigMap the outputs
+	// of the resource are written to
+	// +kubebuilder:validation:Optional
+	WriteConnectionDetailsToRef *ConnectionDetailsReference `json:"writeConnectionDetailsToRef,omitempty"`
+
 	// OrphanOnDelete prevents deletion of the external resource when the CR is deleted
 	// +kubebuilder:validation:Optional
 	// +kubebuilder:default=false
 	OrphanOnDelete bool `json:"orphanOnDelete,omitempty"`
+
+	// NotFoundPolicy defines how the resource reacts when the external
+	// resource was deleted out-of-band
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=Recreate
+	NotFoundPolicy NotFoundPolicy `json:"notFoundPolicy,omitempty"`
 }
 
 // NetworkStatus defines the observed state of Network.
@@ -32,6 +43,11 @@ type NetworkStatus struct {
 	// +optional
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
 
+	// BlockedBy lists the chain of unready resources the resource is waiting
+	// for, ending with the root cause
+	// +optional
+	BlockedBy []BlockingResource `json:"blockedBy,omitempty"`
+
 	// ExternalID is the provider's ID for this Network
 	// +optional
 	ExternalID string `json:"externalID,omitempty"`
@@ -67,6 +83,71 @@ type Network struct {
 	Status NetworkStatus `json:"status,omitempty"`
 }
 
+// GetProviderConfigRef returns the ProviderConfig used for authentication.
+func (n *Network) GetProviderConfigRef() ProviderConfigReference {
+	return n.Spec.ProviderConfigRef
+}
+
+// GetNotFoundPolicy returns how a missing external resource is handled.
+func (n *Network) GetNotFoundPolicy() NotFoundPolicy {
+	return n.Spec.NotFoundPolicy
+}
+
+// GetOrphanOnDelete returns whether the external resource is kept on deletion.
+func (n *Network) GetOrphanOnDelete() bool {
+	return n.Spec.OrphanOnDelete
+}
+
+// GetConnectionDetailsRef returns the Secret or ConfigMap the outputs are written to.
+func (n *Network) GetConnectionDetailsRef() *ConnectionDetailsReference {
+	return n.Spec.WriteConnectionDetailsToRef
+}
+
+// GetConditions returns the conditions of the status.
+func (n *Network) GetConditions() *[]metav1.Condition {
+	return &n.Status.Conditions
+}
+
+// GetExternalID returns the provider's ID.
+func (n *Network) GetExternalID() string {
+	return n.Status.ExternalID
+}
+
+// SetExternalID sets the provider's ID.
+func (n *Network) SetExternalID(id string) {
+	n.Status.ExternalID = id
+}
+
+// GetLastSyncTime returns the time of the last successful sync.
+func (n *Network) GetLastSyncTime() *metav1.Time {
+	return n.Status.LastSyncTime
+}
+
+// SetLastSyncTime sets the time of the last successful sync.
+func (n *Network) SetLastSyncTime(t *metav1.Time) {
+	n.Status.LastSyncTime = t
+}
+
+// HasLastAppliedSpec returns whether a spec was applied before.
+func (n *Network) HasLastAppliedSpec() bool {
+	return n.Status.LastAppliedSpec != nil
+}
+
+// SetLastAppliedSpec records the current spec as applied.
+func (n *Network) SetLastAppliedSpec() {
+	n.Status.LastAppliedSpec = n.Spec.DeepCopy()
+}
+
+// ResetLastAppliedSpec clears the applied spec.
+func (n *Network) ResetLastAppliedSpec() {
+	n.Status.LastAppliedSpec = nil
+}
+
+// SetBlockedBy sets the chain of unready resources the resource waits for.
+func (n *Network) SetBlockedBy(blockers []BlockingResource) {
+	n.Status.BlockedBy = blockers
+}
+
 // +kubebuilder:object:root=true
 
 // NetworkList contains a list of Network
@@ -0,0 +1,154 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// NetworkStackLabel is set on the resources created by a NetworkStack to the
+// name of the stack.
+const NetworkStackLabel = "otc.peertech.de/network-stack"
+
+// NetworkStackSubnet defines a subnet of the stack.
+type NetworkStackSubnet struct {
+	// Name of the subnet. The Subnet is named after the stack and this name.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// Description is an optional human-readable description of the subnet
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxLength=255
+	Description string `json:"description,omitempty"`
+
+	// Cidr is the IPv4 CIDR block for the subnet (e.g. "192.168.0.0/24")
+	// +kubebuilder:validation:Required
+	Cidr string `json:"cidr"`
+
+	// GatewayIP is the IPv4 gateway IP for the subnet (e.g. "192.168.0.1")
+	// +kubebuilder:validation:Required
+	GatewayIP string `json:"gatewayIP"`
+
+	// SNAT creates a SNAT rule for the subnet, so it reaches the internet
+	// through the NAT gateway of the stack
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=false
+	SNAT bool `json:"snat,omitempty"`
+}
+
+// NetworkStackNATGateway defines the NAT gateway of the stack and its public
+// IP.
+type NetworkStackNATGateway struct {
+	// Subnet is the name of the subnet of the stack the NAT gateway is placed in
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	Subnet string `json:"subnet"`
+
+	// Type is the NAT gateway type (micro, small, medium, large, extra-large)
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=small
+	Type NATGatewayType `json:"type,omitempty"`
+
+	// PublicIPType is the type of the public IP used by the SNAT rules
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=BGP
+	PublicIPType PublicIPType `json:"publicIPType,omitempty"`
+
+	// BandwidthSize is the size of the bandwidth of the public IP in Mbit/s
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:default=10
+	BandwidthSize int `json:"bandwidthSize,omitempty"`
+}
+
+// NetworkStackSpec defines the desired state of NetworkStack
+type NetworkStackSpec struct {
+	// ProviderConfigRef references the ProviderConfig to use for authentication
+	// +kubebuilder:validation:Required
+	ProviderConfigRef ProviderConfigReference `json:"providerConfigRef"`
+
+	// Description is an optional human-readable description of the resources
+	// of the stack
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxLength=255
+	Description string `json:"description,omitempty"`
+
+	// Cidr is the IPv4 CIDR block for the network (e.g. "192.168.0.0/16")
+	// +kubebuilder:validation:Required
+	Cidr string `json:"cidr"`
+
+	// Subnets of the network
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinItems=1
+	Subnets []NetworkStackSubnet `json:"subnets"`
+
+	// NATGateway defines the NAT gateway of the network. If omitted, no NAT
+	// gateway, public IP and SNAT rules are created.
+	// +kubebuilder:validation:Optional
+	NATGateway *NetworkStackNATGateway `json:"natGateway,omitempty"`
+
+	// OrphanOnDelete prevents deletion of the external resources when the CR is deleted
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=false
+	OrphanOnDelete bool `json:"orphanOnDelete,omitempty"`
+
+	// NotFoundPolicy defines how the resources of the stack react when their
+	// external resource was deleted out-of-band
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=Recreate
+	NotFoundPolicy NotFoundPolicy `json:"notFoundPolicy,omitempty"`
+}
+
+// NetworkStackResource describes a resource created by the stack.
+type NetworkStackResource struct {
+	// Kind of the resource
+	Kind string `json:"kind"`
+
+	// Name of the resource
+	Name string `json:"name"`
+
+	// Ready reports whether the resource is ready
+	Ready bool `json:"ready"`
+}
+
+// NetworkStackStatus defines the observed state of NetworkStack.
+type NetworkStackStatus struct {
+	// Conditions represent the latest available observations of the stack's state
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// Resources lists the resources created by the stack
+	// +optional
+	Resources []NetworkStackResource `json:"resources,omitempty"`
+
+	// NetworkID is the provider's ID of the network
+	// +optional
+	NetworkID string `json:"networkID,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Cidr",type=string,JSONPath=`.spec.cidr`
+// +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
+
+// NetworkStack is the Schema for the networkstacks API. It creates a Network
+// with its Subnets and optionally a NATGateway with a PublicIP and SNATRules.
+type NetworkStack struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty,omitzero"`
+
+	Spec   NetworkStackSpec   `json:"spec"`
+	Status NetworkStackStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// NetworkStackList contains a list of NetworkStack
+type NetworkStackList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []NetworkStack `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&NetworkStack{}, &NetworkStackList{})
+}
@@ -0,0 +1,330 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// +kubebuilder:validation:Enum=NoSchedule;PreferNoSchedule;NoExecute
+type NodePoolTaintEffect string
+
+const (
+	NodePoolTaintNoSchedule       NodePoolTaintEffect = "NoSchedule"
+	NodePoolTaintPreferNoSchedule NodePoolTaintEffect = "PreferNoSchedule"
+	NodePoolTaintNoExecute        NodePoolTaintEffect = "NoExecute"
+)
+
+// NodePoolVolume defines a disk of the nodes of a node pool
+type NodePoolVolume struct {
+	// Type is the disk type
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=SSD
+	Type VolumeType `json:"type,omitempty"`
+
+	// Size is the size of the disk in GB
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Minimum=40
+	Size int `json:"size"`
+}
+
+// NodePoolTaint defines a taint applied to the nodes of a node pool
+type NodePoolTaint struct {
+	// Key is the key of the taint
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	Key string `json:"key"`
+
+	// Value is the value of the taint
+	// +kubebuilder:validation:Optional
+	Value string `json:"value,omitempty"`
+
+	// Effect is the effect of the taint
+	// +kubebuilder:validation:Required
+	Effect NodePoolTaintEffect `json:"effect"`
+}
+
+// +kubebuilder:validation:XValidation:rule="self.minNodeCount <= self.maxNodeCount",message="minNodeCount must not be greater than maxNodeCount"
+
+// NodePoolAutoscaling defines the autoscaling of a node pool. It requires the
+// autoscaler addon to be installed in the cluster.
+type NodePoolAutoscaling struct {
+	// MinNodeCount is the minimum number of nodes
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=0
+	MinNodeCount int `json:"minNodeCount,omitempty"`
+
+	// MaxNodeCount is the maximum number of nodes
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Minimum=1
+	MaxNodeCount int `json:"maxNodeCount"`
+
+	// ScaleDownCooldownMinutes is the time to wait after a scale-up before
+	// nodes are scaled down again
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=10
+	// +kubebuilder:validation:Minimum=0
+	ScaleDownCooldownMinutes int `json:"scaleDownCooldownMinutes,omitempty"`
+
+	// Priority is the priority of the node pool when scaling up. Node pools
+	// with a higher priority are scaled up first.
+	// +kubebuilder:validation:Optional
+	Priority int `json:"priority,omitempty"`
+}
+
+// NodePoolSpec defines the desired state of NodePool
+type NodePoolSpec struct {
+	// ProviderConfigRef references the ProviderConfig to use for authentication
+	// +kubebuilder:validation:Required
+	ProviderConfigRef ProviderConfigReference `json:"providerConfigRef"`
+
+	// Cluster defines the cluster the node pool belongs to
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="cluster is immutable"
+	Cluster ClusterDependency `json:"cluster"`
+
+	// Flavor is the flavor of the nodes (e.g. s3.large.2). The flavor of an
+	// existing node pool cannot be changed, so a new node pool is needed.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="flavor is immutable"
+	Flavor string `json:"flavor"`
+
+	// AvailabilityZone is the availability zone of the nodes. If omitted, the
+	// nodes are spread randomly.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=random
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="availabilityZone is immutable"
+	AvailabilityZone string `json:"availabilityZone,omitempty"`
+
+	// OS is the operating system of the nodes (e.g. EulerOS 2.9). If omitted,
+	// the default of the cluster version is used.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="os is immutable"
+	OS string `json:"os,omitempty"`
+
+	// KeyPair defines the SSH key pair used to log in to the nodes
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="keyPair is immutable"
+	KeyPair KeyPairDependency `json:"keyPair"`
+
+	// Subnet defines the subnet the nodes are attached to. If omitted, the
+	// subnet of the cluster is used.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="subnet is immutable"
+	Subnet *SubnetDependency `json:"subnet,omitempty"`
+
+	// SecurityGroups defines additional security groups of the nodes
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxItems=5
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="securityGroups is immutable"
+	SecurityGroups []SecurityGroupDependency `json:"securityGroups,omitempty"`
+
+	// RootVolume defines the system disk of the nodes
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default={size: 50}
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="rootVolume is immutable"
+	RootVolume NodePoolVolume `json:"rootVolume,omitempty"`
+
+	// DataVolumes defines the data disks of the nodes. The first data disk is
+	// used by the container runtime and must be at least 100 GB.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default={{size: 100}}
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="dataVolumes is immutable"
+	// +kubebuilder:validation:XValidation:rule="self[0].size >= 100",message="the first data volume must be at least 100 GB"
+	DataVolumes []NodePoolVolume `json:"dataVolumes,omitempty"`
+
+	// NodeCount is the desired number of nodes. It is ignored while
+	// autoscaling is enabled.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=1
+	// +kubebuilder:validation:Minimum=0
+	NodeCount int `json:"nodeCount"`
+
+	// Autoscaling enables the autoscaling of the node pool
+	// +kubebuilder:validation:Optional
+	Autoscaling *NodePoolAutoscaling `json:"autoscaling,omitempty"`
+
+	// Labels are the Kubernetes labels applied to the nodes
+	// +kubebuilder:validation:Optional
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// Taints are the Kubernetes taints applied to the nodes
+	// +kubebuilder:validation:Optional
+	Taints []NodePoolTaint `json:"taints,omitempty"`
+
+	// WriteConnectionDetailsToRef specifies the Secret or ConfigMap the outputs
+	// of the resource are written to
+	// +kubebuilder:validation:Optional
+	WriteConnectionDetailsToRef *ConnectionDetailsReference `json:"writeConnectionDetailsToRef,omitempty"`
+
+	// OrphanOnDelete prevents deletion of the external resource when the CR is deleted
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=false
+	OrphanOnDelete bool `json:"orphanOnDelete,omitempty"`
+
+	// NotFoundPolicy defines how the resource reacts when the external
+	// resource was deleted out-of-band
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=Recreate
+	NotFoundPolicy NotFoundPolicy `json:"notFoundPolicy,omitempty"`
+}
+
+// NodePoolDependenciesResolved contains the resolved IDs for node pool dependencies
+type NodePoolDependenciesResolved struct {
+	// ClusterID is the resolved Cluster ID
+	ClusterID string `json:"clusterID,omitempty"`
+	// SubnetID is the resolved Subnet ID
+	SubnetID string `json:"subnetID,omitempty"`
+	// SecurityGroupIDs are the resolved SecurityGroup IDs
+	SecurityGroupIDs []string `json:"securityGroupIDs,omitempty"`
+	// KeyPairName is the resolved KeyPair name
+	KeyPairName string `json:"keyPairName,omitempty"`
+}
+
+// NodePoolStatus defines the observed state of NodePool.
+type NodePoolStatus struct {
+	// Conditions represent the latest available observations of the NodePool's state
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// BlockedBy lists the chain of unready resources the resource is waiting
+	// for, ending with the root cause
+	// +optional
+	BlockedBy []BlockingResource `json:"blockedBy,omitempty"`
+
+	// ExternalID is the provider's ID for this NodePool
+	// +optional
+	ExternalID string `json:"externalID,omitempty"`
+
+	// ResolvedDependencies contains the resolved IDs for node pool dependencies
+	// +optional
+	ResolvedDependencies NodePoolDependenciesResolved `json:"resolvedDependencies"`
+
+	// Phase is the phase of the node pool reported by the provider
+	// +optional
+	Phase string `json:"phase,omitempty"`
+
+	// CurrentNodeCount is the observed number of nodes
+	// +optional
+	CurrentNodeCount int `json:"currentNodeCount,omitempty"`
+
+	// ObservedGeneration reflects the generation of the most recently observed NodePool spec
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// LastSyncTime is the timestamp of the last successful sync with the provider
+	// +optional
+	LastSyncTime *metav1.Time `json:"lastSyncTime,omitempty"`
+
+	// LastAppliedSpec caches the spec that was successfully applied to the
+	// external resource. It is used to detect changes to immutable fields.
+	// +optional
+	LastAppliedSpec *NodePoolSpec `json:"lastAppliedSpec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Namespaced,categories=containers
+// +kubebuilder:printcolumn:name="Flavor",type=string,JSONPath=`.spec.flavor`
+// +kubebuilder:printcolumn:name="Desired",type=integer,JSONPath=`.spec.nodeCount`
+// +kubebuilder:printcolumn:name="Current",type=integer,JSONPath=`.status.currentNodeCount`
+// +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
+// +kubebuilder:printcolumn:name="ExternalID",type=string,JSONPath=`.status.externalID`,priority=1
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// NodePool is the Schema for the nodepools API
+type NodePool struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty,omitzero"`
+
+	Spec   NodePoolSpec   `json:"spec"`
+	Status NodePoolStatus `json:"status,omitempty"`
+}
+
+// GetProviderConfigRef returns the ProviderConfig used for authentication.
+func (np *NodePool) GetProviderConfigRef() ProviderConfigReference {
+	return np.Spec.ProviderConfigRef
+}
+
+// GetNotFoundPolicy returns how a missing external resource is handled.
+func (np *NodePool) GetNotFoundPolicy() NotFoundPolicy {
+	return np.Spec.NotFoundPolicy
+}
+
+// GetOrphanOnDelete returns whether the external resource is kept on deletion.
+func (np *NodePool) GetOrphanOnDelete() bool {
+	return np.Spec.OrphanOnDelete
+}
+
+// GetConnectionDetailsRef returns the Secret or ConfigMap the outputs are written to.
+func (np *NodePool) GetConnectionDetailsRef() *ConnectionDetailsReference {
+	return np.Spec.WriteConnectionDetailsToRef
+}
+
+// GetConditions returns the conditions of the status.
+func (np *NodePool) GetConditions() *[]metav1.Condition {
+	return &np.Status.Conditions
+}
+
+// GetExternalID returns the provider's ID.
+func (np *NodePool) GetExternalID() string {
+	return np.Status.ExternalID
+}
+
+// SetExternalID sets the provider's ID.
+func (np *NodePool) SetExternalID(id string) {
+	np.Status.ExternalID = id
+}
+
+// GetLastSyncTime returns the time of the last successful sync.
+func (np *NodePool) GetLastSyncTime() *metav1.Time {
+	return np.Status.LastSyncTime
+}
+
+// SetLastSyncTime sets the time of the last successful sync.
+func (np *NodePool) SetLastSyncTime(t *metav1.Time) {
+	np.Status.LastSyncTime = t
+}
+
+// HasLastAppliedSpec returns whether a spec was applied before.
+func (np *NodePool) HasLastAppliedSpec() bool {
+	return np.Status.LastAppliedSpec != nil
+}
+
+// SetLastAppliedSpec records the current spec as applied.
+func (np *NodePool) SetLastAppliedSpec() {
+	np.Status.LastAppliedSpec = np.Spec.DeepCopy()
+}
+
+// ResetLastAppliedSpec clears the applied spec.
+func (np *NodePool) ResetLastAppliedSpec() {
+	np.Status.LastAppliedSpec = nil
+}
+
+// SetBlockedBy sets the chain of unready resources the resource waits for.
+func (np *NodePool) SetBlockedBy(blockers []BlockingResource) {
+	np.Status.BlockedBy = blockers
+}
+
+// +kubebuilder:object:root=true
+
+// NodePoolList contains a list of NodePool
+type NodePoolList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []NodePool `json:"items"`
+}
+
+// GetItems returns the list of items as a slice of client.Object.
+func (nl *NodePoolList) GetItems() []client.Object {
+	items := make([]client.Object, len(nl.Items))
+	for i := range nl.Items {
+		items[i] = &nl.Items[i]
+	}
+	return items
+}
+
+func init() {
+	SchemeBuilder.Register(&NodePool{}, &NodePoolList{})
+}
@@ -1,6 +1,7 @@
 package v1alpha1
 
 import (
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -21,6 +22,23 @@ const (
 	PublicIPBandwidthShared    PublicIPBandwidthShareType = "Shared"
 )
 
+// +kubebuilder:validation:XValidation:rule="(has(self.portID)?1:0)+(has(self.virtualIPRef)?1:0)+(has(self.instanceRef)?1:0)==1",message="exactly one of portID, virtualIPRef or instanceRef must be set"
+
+// PublicIPBinding specifies the port a public IP is bound to. Exactly one of
+// PortID, VirtualIPRef or InstanceRef must be specified.
+type PublicIPBinding struct {
+	// PortID is the external provider ID of the port
+	// +optional
+	PortID *string `json:"portID,omitempty"`
+	// VirtualIPRef is a reference to a VirtualIP resource
+	// +optional
+	VirtualIPRef *corev1.LocalObjectReference `json:"virtualIPRef,omitempty"`
+	// InstanceRef is a reference to an Instance resource. The public IP is
+	// bound to its primary network interface.
+	// +optional
+	InstanceRef *corev1.LocalObjectReference `json:"instanceRef,omitempty"`
+}
+
 // PublicIPSpec defines the desired state of PublicIP
 type PublicIPSpec struct {
 	// ProviderConfigRef references the ProviderConfig to use for authentication
@@ -31,16 +49,52 @@ type PublicIPSpec struct {
 	// +kubebuilder:validation:Required
 	Type PublicIPType `json:"type"`
 
-	// +kubebuilder:validation:Required
-	BandwidthSize int `json:"bandwidthSize"`
+	// BandwidthSize is the size of the bandwidth created for the public IP in
+	// Mbit/s. It is required unless the public IP joins a shared bandwidth.
+	// Changes are applied to the existing bandwidth in place.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	BandwidthSize int `json:"bandwidthSize,omitempty"`
 
 	// +kubebuilder:validation:Required
 	BandwidthShareType PublicIPBandwidthShareType `json:"bandwidthShareType"`
 
+	// Bandwidth defines the shared bandwidth the public IP joins. It requires
+	// the Shared bandwidth share type.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="bandwidth is immutable"
+	Bandwidth *BandwidthDependency `json:"bandwidth,omitempty"`
+
+	// Binding defines the port the public IP is bound to. If omitted, the
+	// public IP stays unbound.
+	// +kubebuilder:validation:Optional
+	Binding *PublicIPBinding `json:"binding,omitempty"`
+
+	// WriteConnectionDetailsToRef specifies the Secret or ConfigMap the outputs
+	// of the resource are written to
+	// +kubebuilder:validation:Optional
+	WriteConnectionDetailsToRef *ConnectionDetailsReference `json:"writeConnectionDetailsToRef,omitempty"`
+
 	// OrphanOnDelete prevents deletion of the external resource when the CR is deleted
 	// +kubebuilder:validation:Optional
 	// +kubebuilder:default=false
 	OrphanOnDelete bool `json:"orphanOnDelete,omitempty"`
+
+	// NotFoundPolicy defines how the resource reacts when the external
+	// resource was deleted out-of-band
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=Recreate
+	NotFoundPolicy NotFoundPolicy `json:"notFoundPolicy,omitempty"`
+}
+
+// PublicIPDependenciesResolved contains the resolved IDs for public IP dependencies
+type PublicIPDependenciesResolved struct {
+	// PortID is the resolved Port ID of the binding
+	PortID string `json:"portID,omitempty"`
+	// InstanceID is the resolved Instance ID of the binding
+	InstanceID string `json:"instanceID,omitempty"`
+	// BandwidthID is the resolved shared Bandwidth ID
+	BandwidthID string `json:"bandwidthID,omitempty"`
 }
 
 // PublicIPStatus defines the observed state of PublicIP.
@@ -49,10 +103,31 @@ type PublicIPStatus struct {
 	// +optional
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
 
+	// BlockedBy lists the chain of unready resources the resource is waiting
+	// for, ending with the root cause
+	// +optional
+	BlockedBy []BlockingResource `json:"blockedBy,omitempty"`
+
 	// ExternalID is the provider's ID for this Network
 	// +optional
 	ExternalID string `json:"externalID,omitempty"`
 
+	// ResolvedDependencies contains the resolved IDs for public IP dependencies
+	// +optional
+	ResolvedDependencies PublicIPDependenciesResolved `json:"resolvedDependencies"`
+
+	// PublicAddress is the allocated public IP address
+	// +optional
+	PublicAddress string `json:"publicAddress,omitempty"`
+
+	// BoundPortID is the ID of the port the public IP is currently bound to
+	// +optional
+	BoundPortID string `json:"boundPortID,omitempty"`
+
+	// PrivateAddress is the private IP address of the bound port
+	// +optional
+	PrivateAddress string `json:"privateAddress,omitempty"`
+
 	// ObservedGeneration reflects the generation of the most recently observed Network spec
 	// +optional
 	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
@@ -79,6 +154,71 @@ type PublicIP struct {
 	Status PublicIPStatus `json:"status,omitempty"`
 }
 
+// GetProviderConfigRef returns the ProviderConfig used for authentication.
+func (p *PublicIP) GetProviderConfigRef() ProviderConfigReference {
+	return p.Spec.ProviderConfigRef
+}
+
+// GetNotFoundPolicy returns how a missing external resource is handled.
+func (p *PublicIP) GetNotFoundPolicy() NotFoundPolicy {
+	return p.Spec.NotFoundPolicy
+}
+
+// GetOrphanOnDelete returns whether the external resource is kept on deletion.
+func (p *PublicIP) GetOrphanOnDelete() bool {
+	return p.Spec.OrphanOnDelete
+}
+
+// GetConnectionDetailsRef returns the Secret or ConfigMap the outputs are written to.
+func (p *PublicIP) GetConnectionDetailsRef() *ConnectionDetailsReference {
+	return p.Spec.WriteConnectionDetailsToRef
+}
+
+// GetConditions returns the conditions of the status.
+func (p *PublicIP) GetConditions() *[]metav1.Condition {
+	return &p.Status.Conditions
+}
+
+// GetExternalID returns the provider's ID.
+func (p *PublicIP) GetExternalID() string {
+	return p.Status.ExternalID
+}
+
+// SetExternalID sets the provider's ID.
+func (p *PublicIP) SetExternalID(id string) {
+	p.Status.ExternalID = id
+}
+
+// GetLastSyncTime returns the time of the last successful sync.
+func (p *PublicIP) GetLastSyncTime() *metav1.Time {
+	return p.Status.LastSyncTime
+}
+
+// SetLastSyncTime sets the time of the last successful sync.
+func (p *PublicIP) SetLastSyncTime(t *metav1.Time) {
+	p.Status.LastSyncTime = t
+}
+
+// HasLastAppliedSpec returns whether a spec was applied before.
+func (p *PublicIP) HasLastAppliedSpec() bool {
+	return p.Status.LastAppliedSpec != nil
+}
+
+// SetLastAppliedSpec records the current spec as applied.
+func (p *PublicIP) SetLastAppliedSpec() {
+	p.Status.LastAppliedSpec = p.Spec.DeepCopy()
+}
+
+// ResetLastAppliedSpec clears the applied spec.
+func (p *PublicIP) ResetLastAppliedSpec() {
+	p.Status.LastAppliedSpec = nil
+}
+
+// SetBlockedBy sets the chain of unready resources the resource waits for.
+func (p *PublicIP) SetBlockedBy(blockers []BlockingResource) {
+	p.Status.BlockedBy = blockers
+}
+
 // +kubebuilder:object:root=true
 
 // PublicIPList contains a list of PublicIP
@@ -0,0 +1,254 @@
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// +kubebuilder:validation:Enum=A;AAAA;CNAME;MX;TXT;SRV;NS;CAA;PTR
+type RecordSetType string
+
+const (
+	RecordSetA     RecordSetType = "A"
+	RecordSetAAAA  RecordSetType = "AAAA"
+	RecordSetCNAME RecordSetType = "CNAME"
+	RecordSetMX    RecordSetType = "MX"
+	RecordSetTXT   RecordSetType = "TXT"
+	RecordSetSRV   RecordSetType = "SRV"
+	RecordSetNS    RecordSetType = "NS"
+	RecordSetCAA   RecordSetType = "CAA"
+	RecordSetPTR   RecordSetType = "PTR"
+)
+
+// +kubebuilder:validation:XValidation:rule="(has(self.publicIPRef)?1:0)+(has(self.virtualIPRef)?1:0)==1",message="exactly one of publicIPRef or virtualIPRef must be set"
+
+// RecordSetValueSource specifies a resource whose address is used as a record
+// value. Exactly one of PublicIPRef or VirtualIPRef must be specified.
+type RecordSetValueSource struct {
+	// PublicIPRef is a reference to a PublicIP resource. Its public address
+	// is used.
+	// +optional
+	PublicIPRef *corev1.LocalObjectReference `json:"publicIPRef,omitempty"`
+	// VirtualIPRef is a reference to a VirtualIP resource. Its fixed IP
+	// address is used.
+	// +optional
+	VirtualIPRef *corev1.LocalObjectReference `json:"virtualIPRef,omitempty"`
+}
+
+// RecordSetSpec defines the desired state of RecordSet
+// +kubebuilder:validation:XValidation:rule="has(self.records) || has(self.recordsFrom)",message="at least one of records or recordsFrom must be set"
+// +kubebuilder:validation:XValidation:rule="!has(self.recordsFrom) || self.type == 'A'",message="recordsFrom can only be used with A records"
+type RecordSetSpec struct {
+	// ProviderConfigRef references the ProviderConfig to use for authentication
+	// +kubebuilder:validation:Required
+	ProviderConfigRef ProviderConfigReference `json:"providerConfigRef"`
+
+	// Zone defines the DNS zone the record set belongs to
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="zone is immutable"
+	Zone DNSZoneDependency `json:"zone"`
+
+	// Name is the fully qualified domain name of the record set (e.g.
+	// www.example.com.). It must be within the domain of the zone.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=254
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="name is immutable"
+	Name string `json:"name"`
+
+	// Type is the record type
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="type is immutable"
+	Type RecordSetType `json:"type"`
+
+	// TTL is the time to live of the records in seconds
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=300
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=2147483647
+	TTL int `json:"ttl,omitempty"`
+
+	// Description is a human-readable description for the record set
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxLength=255
+	Description string `json:"description,omitempty"`
+
+	// Records are the literal values of the record set
+	// +kubebuilder:validation:Optional
+	Records []string `json:"records,omitempty"`
+
+	// RecordsFrom defines resources whose addresses are added to the values
+	// of the record set. The records are updated when the addresses change.
+	// +kubebuilder:validation:Optional
+	RecordsFrom []RecordSetValueSource `json:"recordsFrom,omitempty"`
+
+	// WriteConnectionDetailsToRef specifies the Secret or ConfigMap the outputs
+	// of the resource are written to
+	// +kubebuilder:validation:Optional
+	WriteConnectionDetailsToRef *ConnectionDetailsReference `json:"writeConnectionDetailsToRef,omitempty"`
+
+	// OrphanOnDelete prevents deletion of the external resource when the CR is deleted
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=false
+	OrphanOnDelete bool `json:"orphanOnDelete,omitempty"`
+
+	// NotFoundPolicy defines how the resource reacts when the external
+	// resource was deleted out-of-band
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=Recreate
+	NotFoundPolicy NotFoundPolicy `json:"notFoundPolicy,omitempty"`
+}
+
+// RecordSetDependenciesResolved contains the resolved IDs for record set dependencies
+type RecordSetDependenciesResolved struct {
+	// DNSZoneID is the resolved DNSZone ID
+	DNSZoneID string `json:"dnsZoneID,omitempty"`
+
+	// Records are the resolved values of the record set, including the
+	// addresses of the referenced resources
+	Records []string `json:"records,omitempty"`
+}
+
+// RecordSetStatus defines the observed state of RecordSet.
+type RecordSetStatus struct {
+	// Conditions represent the latest available observations of the RecordSet's state
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// BlockedBy lists the chain of unready resources the resource is waiting
+	// for, ending with the root cause
+	// +optional
+	BlockedBy []BlockingResource `json:"blockedBy,omitempty"`
+
+	// ExternalID is the provider's ID for this RecordSet
+	// +optional
+	ExternalID string `json:"externalID,omitempty"`
+
+	// ResolvedDependencies contains the resolved IDs for record set dependencies
+	// +optional
+	ResolvedDependencies RecordSetDependenciesResolved `json:"resolvedDependencies"`
+
+	// Records are the observed values of the record set
+	// +optional
+	Records []string `json:"records,omitempty"`
+
+	// ObservedGeneration reflects the generation of the most recently observed RecordSet spec
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// LastSyncTime is the timestamp of the last successful sync with the provider
+	// +optional
+	LastSyncTime *metav1.Time `json:"lastSyncTime,omitempty"`
+
+	// LastAppliedSpec caches the spec that was successfully applied to the
+	// external resource. It is used to detect changes to immutable fields.
+	// +optional
+	LastAppliedSpec *RecordSetSpec `json:"lastAppliedSpec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Namespaced,categories=networking
+// +kubebuilder:printcolumn:name="Name",type=string,JSONPath=`.spec.name`
+// +kubebuilder:printcolumn:name="Type",type=string,JSONPath=`.spec.type`
+// +kubebuilder:printcolumn:name="Records",type=string,JSONPath=`.status.records`
+// +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
+// +kubebuilder:printcolumn:name="ExternalID",type=string,JSONPath=`.status.externalID`,priority=1
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// RecordSet is the Schema for the recordsets API
+type RecordSet struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty,omitzero"`
+
+	Spec   RecordSetSpec   `json:"spec"`
+	Status RecordSetStatus `json:"status,omitempty"`
+}
+
+// GetProviderConfigRef returns the ProviderConfig used for authentication.
+func (rs *RecordSet) GetProviderConfigRef() ProviderConfigReference {
+	return rs.Spec.ProviderConfigRef
+}
+
+// GetNotFoundPolicy returns how a missing external resource is handled.
+func (rs *RecordSet) GetNotFoundPolicy() NotFoundPolicy {
+	return rs.Spec.NotFoundPolicy
+}
+
+// GetOrphanOnDelete returns whether the external resource is kept on deletion.
+func (rs *RecordSet) GetOrphanOnDelete() bool {
+	return rs.Spec.OrphanOnDelete
+}
+
+// GetConnectionDetailsRef returns the Secret or ConfigMap the outputs are written to.
+func (rs *RecordSet) GetConnectionDetailsRef() *ConnectionDetailsReference {
+	return rs.Spec.WriteConnectionDetailsToRef
+}
+
+// GetConditions returns the conditions of the status.
+func (rs *RecordSet) GetConditions() *[]metav1.Condition {
+	return &rs.Status.Conditions
+}
+
+// GetExternalID returns the provider's ID.
+func (rs *RecordSet) GetExternalID() string {
+	return rs.Status.ExternalID
+}
+
+// SetExternalID sets the provider's ID.
+func (rs *RecordSet) SetExternalID(id string) {
+	rs.Status.ExternalID = id
+}
+
+// GetLastSyncTime returns the time of the last successful sync.
+func (rs *RecordSet) GetLastSyncTime() *metav1.Time {
+	return rs.Status.LastSyncTime
+}
+
+// SetLastSyncTime sets the time of the last successful sync.
+func (rs *RecordSet) SetLastSyncTime(t *metav1.Time) {
+	rs.Status.LastSyncTime = t
+}
+
+// HasLastAppliedSpec returns whether a spec was applied before.
+func (rs *RecordSet) HasLastAppliedSpec() bool {
+	return rs.Status.LastAppliedSpec != nil
+}
+
+// SetLastAppliedSpec records the current spec as applied.
+func (rs *RecordSet) SetLastAppliedSpec() {
+	rs.Status.LastAppliedSpec = rs.Spec.DeepCopy()
+}
+
+// ResetLastAppliedSpec clears the applied spec.
+func (rs *RecordSet) ResetLastAppliedSpec() {
+	rs.Status.LastAppliedSpec = nil
+}
+
+// SetBlockedBy sets the chain of unready resources the resource waits for.
+func (rs *RecordSet) SetBlockedBy(blockers []BlockingResource) {
+	rs.Status.BlockedBy = blockers
+}
+
+// +kubebuilder:object:root=true
+
+// RecordSetList contains a list of RecordSet
+type RecordSetList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []RecordSet `json:"items"`
+}
+
+// GetItems returns the list of items as a slice of client.Object.
+func (rl *RecordSetList) GetItems() []client.Object {
+	items := make([]client.Object, len(rl.Items))
+	for i := range rl.Items {
+		items[i] = &rl.Items[i]
+	}
+	return items
+}
+
+func init() {
+	SchemeBuilder.Register(&RecordSet{}, &RecordSetList{})
+}
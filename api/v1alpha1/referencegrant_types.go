@@ -0,0 +1,97 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ReferenceGrantKind is a kind of resource which can be referenced from
+// other namespaces.
+// +kubebuilder:validation:Enum=Network;Subnet;SecurityGroup
+type ReferenceGrantKind string
+
+const (
+	ReferenceGrantKindNetwork       ReferenceGrantKind = "Network"
+	ReferenceGrantKindSubnet        ReferenceGrantKind = "Subnet"
+	ReferenceGrantKindSecurityGroup ReferenceGrantKind = "SecurityGroup"
+)
+
+// ReferenceGrantFrom describes a namespace which is allowed to reference
+// resources in the namespace of the ReferenceGrant.
+type ReferenceGrantFrom struct {
+	// Namespace of the referencing resources
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	Namespace string `json:"namespace"`
+}
+
+// ReferenceGrantTo describes the resources which can be referenced.
+type ReferenceGrantTo struct {
+	// Kind of the referenced resources
+	// +kubebuilder:validation:Required
+	Kind ReferenceGrantKind `json:"kind"`
+
+	// Name restricts the grant to a single resource. If it is not set, all
+	// resources of the kind can be referenced.
+	// +kubebuilder:validation:Optional
+	Name string `json:"name,omitempty"`
+}
+
+// ReferenceGrantSpec defines the desired state of ReferenceGrant
+type ReferenceGrantSpec struct {
+	// From lists the namespaces which are allowed to reference the resources
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinItems=1
+	From []ReferenceGrantFrom `json:"from"`
+
+	// To lists the resources which can be referenced
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinItems=1
+	To []ReferenceGrantTo `json:"to"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Namespaced,categories=provider
+
+// ReferenceGrant allows resources in other namespaces to reference resources
+// in its namespace. It is modelled on the Gateway API ReferenceGrant.
+type ReferenceGrant struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty,omitzero"`
+
+	Spec ReferenceGrantSpec `json:"spec"`
+}
+
+// Allows reports whether the grant allows resources in namespace from to
+// reference the resource of the given kind and name.
+func (g *ReferenceGrant) Allows(from string, kind ReferenceGrantKind, name string) bool {
+	fromAllowed := false
+	for _, f := range g.Spec.From {
+		if f.Namespace == from {
+			fromAllowed = true
+			break
+		}
+	}
+	if !fromAllowed {
+		return false
+	}
+
+	for _, t := range g.Spec.To {
+		if t.Kind == kind && (t.Name == "" || t.Name == name) {
+			return true
+		}
+	}
+	return false
+}
+
+// +kubebuilder:object:root=true
+
+// ReferenceGrantList contains a list of ReferenceGrant
+type ReferenceGrantList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ReferenceGrant `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ReferenceGrant{}, &ReferenceGrantList{})
+}
@@ -16,10 +16,21 @@ type SecurityGroupSpec struct {
 	// +kubebuilder:validation:MaxLength=255
 	Description string `json:"description,omitempty"`
 
+	// WriteConnectionDetailsToRef specifies the Secret or ConfigMap the outputs
+	// of the resource are written to
+	// +kubebuilder:validation:Optional
+	WriteConnectionDetailsToRef *ConnectionDetailsReference `json:"writeConnectionDetailsToRef,omitempty"`
+
 	// OrphanOnDelete prevents deletion of the external resource when the CR is deleted
 	// +kubebuilder:validation:Optional
 	// +kubebuilder:default=false
 	OrphanOnDelete bool `json:"orphanOnDelete,omitempty"`
+
+	// NotFoundPolicy defines how the resource reacts when the external
+	// resource was deleted out-of-band
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=Recreate
+	NotFoundPolicy NotFoundPolicy `json:"notFoundPolicy,omitempty"`
 }
 
 // SecurityGroupStatus defines the observed state of SecurityGroup.
@@ -28,6 +39,11 @@ type SecurityGroupStatus struct {
 	// +optional
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
 
+	// BlockedBy lists the chain of unready resources the resource is waiting
+	// for, ending with the root cause
+	// +optional
+	BlockedBy []BlockingResource `json:"blockedBy,omitempty"`
+
 	// ExternalID is the provider's ID for this NAT gateway
 	// +optional
 	ExternalID string `json:"externalID,omitempty"`
@@ -62,6 +78,71 @@ type SecurityGroup struct {
 	Status SecurityGroupStatus `json:"status,omitempty"`
 }
 
+// GetProviderConfigRef returns the ProviderConfig used for authentication.
+func (sg *SecurityGroup) GetProviderConfigRef() ProviderConfigReference {
+	return sg.Spec.ProviderConfigRef
+}
+
+// GetNotFoundPolicy returns how a missing external resource is handled.
+func (sg *SecurityGroup) GetNotFoundPolicy() NotFoundPolicy {
+	return sg.Spec.NotFoundPolicy
+}
+
+// GetOrphanOnDelete returns whether the external resource is kept on deletion.
+func (sg *SecurityGroup) GetOrphanOnDelete() bool {
+	return sg.Spec.OrphanOnDelete
+}
+
+// GetConnectionDetailsRef returns the Secret or ConfigMap the outputs are written to.
+func (sg *SecurityGroup) GetConnectionDetailsRef() *ConnectionDetailsReference {
+	return sg.Spec.WriteConnectionDetailsToRef
+}
+
+// GetConditions returns the conditions of the status.
+func (sg *SecurityGroup) GetConditions() *[]metav1.Condition {
+	return &sg.Status.Conditions
+}
+
+// GetExternalID returns the provider's ID.
+func (sg *SecurityGroup) GetExternalID() string {
+	return sg.Status.ExternalID
+}
+
+// SetExternalID sets the provider's ID.
+func (sg *SecurityGroup) SetExternalID(id string) {
+	sg.Status.ExternalID = id
+}
+
+// GetLastSyncTime returns the time of the last successful sync.
+func (sg *SecurityGroup) GetLastSyncTime() *metav1.Time {
+	return sg.Status.LastSyncTime
+}
+
+// SetLastSyncTime sets the time of the last successful sync.
+func (sg *SecurityGroup) SetLastSyncTime(t *metav1.Time) {
+	sg.Status.LastSyncTime = t
+}
+
+// HasLastAppliedSpec returns whether a spec was applied before.
+func (sg *SecurityGroup) HasLastAppliedSpec() bool {
+	return sg.Status.LastAppliedSpec != nil
+}
+
+// SetLastAppliedSpec records the current spec as applied.
+func (sg *SecurityGroup) SetLastAppliedSpec() {
+	sg.Status.LastAppliedSpec = sg.Spec.DeepCopy()
+}
+
+// ResetLastAppliedSpec clears the applied spec.
+func (sg *SecurityGroup) ResetLastAppliedSpec() {
+	sg.Status.LastAppliedSpec = nil
+}
+
+// SetBlockedBy sets the chain of unready resources the resource waits for.
+func (sg *SecurityGroup) SetBlockedBy(blockers []BlockingResource) {
+	sg.Status.BlockedBy = blockers
+}
+
 // +kubebuilder:object:root=true
 
 // SecurityGroupList contains a list of SecurityGroup
@@ -84,10 +84,21 @@ type SecurityGroupRuleSpec struct {
 	// +kubebuilder:validation:Maximum=100
 	Priority *int `json:"priority,omitempty"`
 
+	// WriteConnectionDetailsToRef specifies the Secret or ConfigMap the outputs
+	// of the resource are written to
+	// +kubebuilder:validation:Optional
+	WriteConnectionDetailsToRef *ConnectionDetailsReference `json:"writeConnectionDetailsToRef,omitempty"`
+
 	// OrphanOnDelete prevents deletion of the external resource when the CR is deleted
 	// +kubebuilder:validation:Optional
 	// +kubebuilder:default=false
 	OrphanOnDelete bool `json:"orphanOnDelete,omitempty"`
+
+	// NotFoundPolicy defines how the resource reacts when the external
+	// resource was deleted out-of-band
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=Recreate
+	NotFoundPolicy NotFoundPolicy `json:"notFoundPolicy,omitempty"`
 }
 
 // SecurityGroupResolved contains the resolved ID for security group dependency
@@ -103,6 +114,11 @@ type SecurityGroupRuleStatus struct {
 	// +optional
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
 
+	// BlockedBy lists the chain of unready resources the resource is waiting
+	// for, ending with the root cause
+	// +optional
+	BlockedBy []BlockingResource `json:"blockedBy,omitempty"`
+
 	// ExternalID is the provider's ID for this Subnet
 	// +optional
 	ExternalID string `json:"externalID,omitempty"`
@@ -143,6 +159,71 @@ type SecurityGroupRule struct {
 	Status SecurityGroupRuleStatus `json:"status,omitempty"`
 }
 
+// GetProviderConfigRef returns the ProviderConfig used for authentication.
+func (sgr *SecurityGroupRule) GetProviderConfigRef() ProviderConfigReference {
+	return sgr.Spec.ProviderConfigRef
+}
+
+// GetNotFoundPolicy returns how a missing external resource is handled.
+func (sgr *SecurityGroupRule) GetNotFoundPolicy() NotFoundPolicy {
+	return sgr.Spec.NotFoundPolicy
+}
+
+// GetOrphanOnDelete returns whether the external resource is kept on deletion.
+func (sgr *SecurityGroupRule) GetOrphanOnDelete() bool {
+	return sgr.Spec.OrphanOnDelete
+}
+
+// GetConnectionDetailsRef returns the Secret or ConfigMap the outputs are written to.
+func (sgr *SecurityGroupRule) GetConnectionDetailsRef() *ConnectionDetailsReference {
+	return sgr.Spec.WriteConnectionDetailsToRef
+}
+
+// GetConditions returns the conditions of the status.
+func (sgr *SecurityGroupRule) GetConditions() *[]metav1.Condition {
+	return &sgr.Status.Conditions
+}
+
+// GetExternalID returns the provider's ID.
+func (sgr *SecurityGroupRule) GetExternalID() string {
+	return sgr.Status.ExternalID
+}
+
+// SetExternalID sets the provider's ID.
+func (sgr *SecurityGroupRule) SetExternalID(id string) {
+	sgr.Status.ExternalID = id
+}
+
+// GetLastSyncTime returns the time of the last successful sync.
+func (sgr *SecurityGroupRule) GetLastSyncTime() *metav1.Time {
+	return sgr.Status.LastSyncTime
+}
+
+// SetLastSyncTime sets the time of the last successful sync.
+func (sgr *SecurityGroupRule) SetLastSyncTime(t *metav1.Time) {
+	sgr.Status.LastSyncTime = t
+}
+
+// HasLastAppliedSpec returns whether a spec was applied before.
+func (sgr *SecurityGroupRule) HasLastAppliedSpec() bool {
+	return sgr.Status.LastAppliedSpec != nil
+}
+
+// SetLastAppliedSpec records the current spec as applied.
+func (sgr *SecurityGroupRule) SetLastAppliedSpec() {
+	sgr.Status.LastAppliedSpec = sgr.Spec.DeepCopy()
+}
+
+// ResetLastAppliedSpec clears the applied spec.
+func (sgr *SecurityGroupRule) ResetLastAppliedSpec() {
+	sgr.Status.LastAppliedSpec = nil
+}
+
+// SetBlockedBy sets the chain of unready resources the resource waits for.
+func (sgr *SecurityGroupRule) SetBlockedBy(blockers []BlockingResource) {
+	sgr.Status.BlockedBy = blockers
+}
+
 // +kubebuilder:object:root=true
 
 // SecurityGroupRuleList contains a list of SecurityGroupRule
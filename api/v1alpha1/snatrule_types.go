@@ -30,10 +30,27 @@ type SNATRuleSpec struct {
 	// +kubebuilder:validation:MaxLength=255
 	Description string `json:"description,omitempty"`
 
+	// WriteConnectionDetailsToRef specifies the Secret or ConfigMap the outputs
+	// of the resource are written to
+	// +kubebuilder:validation:Optional
+	WriteConnectionDetailsToRef *ConnectionDetailsReference `json:"writeConnectionDetailsToRef,omitempty"`
+
 	// OrphanOnDelete prevents deletion of the external resource when the CR is deleted
 	// +kubebuilder:validation:Optional
 	// +kubebuilder:default=false
 	OrphanOnDelete bool `json:"orphanOnDelete,omitempty"`
+
+	// NotFoundPolicy defines how the resource reacts when the external
+	// resource was deleted out-of-band
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=Recreate
+	NotFoundPolicy NotFoundPolicy `json:"notFoundPolicy,omitempty"`
+
+	// DeletionPolicy defines whether the resource is deleted together with
+	// the resources it references in the same namespace
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=Block
+	DeletionPolicy DeletionPolicy `json:"deletionPolicy,omitempty"`
 }
 
 // NATGatewayNetworkResolved contains the resolved IDs for network dependencies
@@ -54,6 +71,11 @@ type SNATRuleStatus struct {
 	// +optional
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
 
+	// BlockedBy lists the chain of unready resources the resource is waiting
+	// for, ending with the root cause
+	// +optional
+	BlockedBy []BlockingResource `json:"blockedBy,omitempty"`
+
 	// ExternalID is the provider's ID for this NAT gateway
 	// +optional
 	ExternalID string `json:"externalID,omitempty"`
@@ -88,6 +110,77 @@ type SNATRule struct {
 	Status SNATRuleStatus `json:"status,omitempty"`
 }
 
+// GetProviderConfigRef returns the ProviderConfig used for authentication.
+func (sr *SNATRule) GetProviderConfigRef() ProviderConfigReference {
+	return sr.Spec.ProviderConfigRef
+}
+
+// GetNotFoundPolicy returns how a missing external resource is handled.
+func (sr *SNATRule) GetNotFoundPolicy() NotFoundPolicy {
+	return sr.Spec.NotFoundPolicy
+}
+
+// GetOrphanOnDelete returns whether the external resource is kept on deletion.
+func (sr *SNATRule) GetOrphanOnDelete() bool {
+	return sr.Spec.OrphanOnDelete
+}
+
+// GetDeletionPolicy returns whether the resource is deleted together with its
+// dependencies.
+func (sr *SNATRule) GetDeletionPolicy() DeletionPolicy {
+	return sr.Spec.DeletionPolicy
+}
+
+// GetConnectionDetailsRef returns the Secret or ConfigMap the outputs are written to.
+func (sr *SNATRule) GetConnectionDetailsRef() *ConnectionDetailsReference {
+	return sr.Spec.WriteConnectionDetailsToRef
+}
+
+// GetConditions returns the conditions of the status.
+func (sr *SNATRule) GetConditions() *[]metav1.Condition {
+	return &sr.Status.Conditions
+}
+
+// GetExternalID returns the provider's ID.
+func (sr *SNATRule) GetExternalID() string {
+	return sr.Status.ExternalID
+}
+
+// SetExternalID sets the provider's ID.
+func (sr *SNATRule) SetExternalID(id string) {
+	sr.Status.ExternalID = id
+}
+
+// GetLastSyncTime returns the time of the last successful sync.
+func (sr *SNATRule) GetLastSyncTime() *metav1.Time {
+	return sr.Status.LastSyncTime
+}
+
+// SetLastSyncTime sets the time of the last successful sync.
+func (sr *SNATRule) SetLastSyncTime(t *metav1.Time) {
+	sr.Status.LastSyncTime = t
+}
+
+// HasLastAppliedSpec returns whether a spec was applied before.
+func (sr *SNATRule) HasLastAppliedSpec() bool {
+	return sr.Status.LastAppliedSpec != nil
+}
+
+// SetLastAppliedSpec records the current spec as applied.
+func (sr *SNATRule) SetLastAppliedSpec() {
+	sr.Status.LastAppliedSpec = sr.Spec.DeepCopy()
+}
+
+// ResetLastAppliedSpec clears the applied spec.
+func (sr *SNATRule) ResetLastAppliedSpec() {
+	sr.Status.LastAppliedSpec = nil
+}
+
+// SetBlockedBy sets the chain of unready resources the resource waits for.
+func (sr *SNATRule) SetBlockedBy(blockers []BlockingResource) {
+	sr.Status.BlockedBy = blockers
+}
+
 // +kubebuilder:object:root=true
 
 // SNATRuleList contains a list of SNATRule
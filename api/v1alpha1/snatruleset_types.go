@@ -0,0 +1,97 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// SNATRuleSetLabel is set on the SNATRules created by a SNATRuleSet to the
+// name of the set.
+const SNATRuleSetLabel = "otc.peertech.de/snat-rule-set"
+
+// SNATRuleSetSpec defines the desired state of SNATRuleSet
+type SNATRuleSetSpec struct {
+	// ProviderConfigRef references the ProviderConfig to use for authentication
+	// +kubebuilder:validation:Required
+	ProviderConfigRef ProviderConfigReference `json:"providerConfigRef"`
+
+	// NATGateway defines the NAT gateway dependency
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="NAT gateway is immutable"
+	NATGateway NATGatewayDependency `json:"natGateway"`
+
+	// SubnetSelector selects the subnets a SNAT rule is created for
+	// +kubebuilder:validation:Required
+	SubnetSelector metav1.LabelSelector `json:"subnetSelector"`
+
+	// PublicIPs is the pool of public IPs used by the SNAT rules. A new rule
+	// uses the public IP with the fewest rules.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinItems=1
+	PublicIPs []PublicIPDependency `json:"publicIPs"`
+
+	// Description is an optional human-readable description of the SNAT rules
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxLength=255
+	Description string `json:"description,omitempty"`
+
+	// OrphanOnDelete prevents deletion of the external resources when the CR is deleted
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=false
+	OrphanOnDelete bool `json:"orphanOnDelete,omitempty"`
+
+	// NotFoundPolicy defines how the SNAT rules react when their external
+	// resource was deleted out-of-band
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=Recreate
+	NotFoundPolicy NotFoundPolicy `json:"notFoundPolicy,omitempty"`
+}
+
+// SNATRuleSetRule describes a SNAT rule created for a selected subnet.
+type SNATRuleSetRule struct {
+	// Subnet is the name of the selected Subnet
+	Subnet string `json:"subnet"`
+
+	// SNATRule is the name of the SNATRule created for the subnet
+	SNATRule string `json:"snatRule"`
+
+	// Ready reports whether the SNATRule is ready
+	Ready bool `json:"ready"`
+}
+
+// SNATRuleSetStatus defines the observed state of SNATRuleSet.
+type SNATRuleSetStatus struct {
+	// Conditions represent the latest available observations of the SNAT rule set's state
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// Rules lists the SNAT rules of the selected subnets
+	// +optional
+	Rules []SNATRuleSetRule `json:"rules,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
+
+// SNATRuleSet is the Schema for the snatrulesets API. It creates a SNATRule
+// for every subnet matching the selector.
+type SNATRuleSet struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty,omitzero"`
+
+	Spec   SNATRuleSetSpec   `json:"spec"`
+	Status SNATRuleSetStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// SNATRuleSetList contains a list of SNATRuleSet
+type SNATRuleSetList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []SNATRuleSet `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&SNATRuleSet{}, &SNATRuleSetList{})
+}
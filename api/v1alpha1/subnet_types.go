@@ -28,10 +28,27 @@ type SubnetSpec struct {
 	// +kubebuilder:validation:Required
 	GatewayIP string `json:"gatewayIP"`
 
+	// WriteConnectionDetailsToRef specifies the Secret or ConfigMap the outputs
+	// of the resource are written to
+	// +kubebuilder:validation:Optional
+	WriteConnectionDetailsToRef *ConnectionDetailsReference `json:"writeConnectionDetailsToRef,omitempty"`
+
 	// OrphanOnDelete prevents deletion of the external resource when the CR is deleted
 	// +kubebuilder:validation:Optional
 	// +kubebuilder:default=false
 	OrphanOnDelete bool `json:"orphanOnDelete,omitempty"`
+
+	// NotFoundPolicy defines how the resource reacts when the external
+	// resource was deleted out-of-band
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=Recreate
+	NotFoundPolicy NotFoundPolicy `json:"notFoundPolicy,omitempty"`
+
+	// DeletionPolicy defines whether the resource is deleted together with
+	// the resources it references in the same namespace
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=Block
+	DeletionPolicy DeletionPolicy `json:"deletionPolicy,omitempty"`
 }
 
 // SubnetNetworkResolved contains the resolved ID for network dependency
@@ -46,6 +63,11 @@ type SubnetStatus struct {
 	// +optional
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
 
+	// BlockedBy lists the chain of unready resources the resource is waiting
+	// for, ending with the root cause
+	// +optional
+	BlockedBy []BlockingResource `json:"blockedBy,omitempty"`
+
 	// ExternalID is the provider's ID for this Subnet
 	// +optional
 	ExternalID string `json:"externalID,omitempty"`
@@ -85,6 +107,77 @@ type Subnet struct {
 	Status SubnetStatus `json:"status,omitempty"`
 }
 
+// GetProviderConfigRef returns the ProviderConfig used for authentication.
+func (s *Subnet) GetProviderConfigRef() ProviderConfigReference {
+	return s.Spec.ProviderConfigRef
+}
+
+// GetNotFoundPolicy returns how a missing external resource is handled.
+func (s *Subnet) GetNotFoundPolicy() NotFoundPolicy {
+	return s.Spec.NotFoundPolicy
+}
+
+// GetOrphanOnDelete returns whether the external resource is kept on deletion.
+func (s *Subnet) GetOrphanOnDelete() bool {
+	return s.Spec.OrphanOnDelete
+}
+
+// GetDeletionPolicy returns whether the resource is deleted together with its
+// dependencies.
+func (s *Subnet) GetDeletionPolicy() DeletionPolicy {
+	return s.Spec.DeletionPolicy
+}
+
+// GetConnectionDetailsRef returns the Secret or ConfigMap the outputs are written to.
+func (s *Subnet) GetConnectionDetailsRef() *ConnectionDetailsReference {
+	return s.Spec.WriteConnectionDetailsToRef
+}
+
+// GetConditions returns the conditions of the status.
+func (s *Subnet) GetConditions() *[]metav1.Condition {
+	return &s.Status.Conditions
+}
+
+// GetExternalID returns the provider's ID.
+func (s *Subnet) GetExternalID() string {
+	return s.Status.ExternalID
+}
+
+// SetExternalID sets the provider's ID.
+func (s *Subnet) SetExternalID(id string) {
+	s.Status.ExternalID = id
+}
+
+// GetLastSyncTime returns the time of the last successful sync.
+func (s *Subnet) GetLastSyncTime() *metav1.Time {
+	return s.Status.LastSyncTime
+}
+
+// SetLastSyncTime sets the time of the last successful sync.
+func (s *Subnet) SetLastSyncTime(t *metav1.Time) {
+	s.Status.LastSyncTime = t
+}
+
+// HasLastAppliedSpec returns whether a spec was applied before.
+func (s *Subnet) HasLastAppliedSpec() bool {
+	return s.Status.LastAppliedSpec != nil
+}
+
+// SetLastAppliedSpec records the current spec as applied.
+func (s *Subnet) SetLastAppliedSpec() {
+	s.Status.LastAppliedSpec = s.Spec.DeepCopy()
+}
+
+// ResetLastAppliedSpec clears the applied spec.
+func (s *Subnet) ResetLastAppliedSpec() {
+	s.Status.LastAppliedSpec = nil
+}
+
+// SetBlockedBy sets the chain of unready resources the resource waits for.
+func (s *Subnet) SetBlockedBy(blockers []BlockingResource) {
+	s.Status.BlockedBy = blockers
+}
+
 // +kubebuilder:object:root=true
 
 // SubnetList contains a list of Subnet
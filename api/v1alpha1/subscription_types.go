@@ -0,0 +1,215 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// +kubebuilder:validation:Enum=email;http;https;sms
+type SubscriptionProtocol string
+
+const (
+	SubscriptionProtocolEmail SubscriptionProtocol = "email"
+	SubscriptionProtocolHTTP  SubscriptionProtocol = "http"
+	SubscriptionProtocolHTTPS SubscriptionProtocol = "https"
+	SubscriptionProtocolSMS   SubscriptionProtocol = "sms"
+)
+
+// SubscriptionSpec defines the desired state of Subscription. The SMN API
+// can not update a subscription, so the spec is immutable.
+type SubscriptionSpec struct {
+	// ProviderConfigRef references the ProviderConfig to use for authentication
+	// +kubebuilder:validation:Required
+	ProviderConfigRef ProviderConfigReference `json:"providerConfigRef"`
+
+	// Topic is the topic to subscribe to
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="topic is immutable"
+	Topic TopicDependency `json:"topic"`
+
+	// Protocol is the protocol used to notify the endpoint
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="protocol is immutable"
+	Protocol SubscriptionProtocol `json:"protocol"`
+
+	// Endpoint is the address notified, which is an email address, an
+	// HTTP(S) URL or a phone number with country code (e.g. +4915112345678)
+	// depending on the protocol
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="endpoint is immutable"
+	Endpoint string `json:"endpoint"`
+
+	// Remark is the description of the subscription
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxLength=128
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="remark is immutable"
+	Remark string `json:"remark,omitempty"`
+
+	// WriteConnectionDetailsToRef specifies the Secret or ConfigMap the outputs
+	// of the resource are written to
+	// +kubebuilder:validation:Optional
+	WriteConnectionDetailsToRef *ConnectionDetailsReference `json:"writeConnectionDetailsToRef,omitempty"`
+
+	// OrphanOnDelete prevents deletion of the external resource when the CR is deleted
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=false
+	OrphanOnDelete bool `json:"orphanOnDelete,omitempty"`
+
+	// NotFoundPolicy defines how the resource reacts when the external
+	// resource was deleted out-of-band
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=Recreate
+	NotFoundPolicy NotFoundPolicy `json:"notFoundPolicy,omitempty"`
+}
+
+// SubscriptionDependenciesResolved contains the resolved IDs for subscription
+// dependencies
+type SubscriptionDependenciesResolved struct {
+	// TopicURN is the resolved Topic URN the subscription belongs to
+	TopicURN string `json:"topicURN,omitempty"`
+}
+
+// SubscriptionStatus defines the observed state of Subscription.
+type SubscriptionStatus struct {
+	// Conditions represent the latest available observations of the Subscription's state
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// BlockedBy lists the chain of unready resources the resource is waiting
+	// for, ending with the root cause
+	// +optional
+	BlockedBy []BlockingResource `json:"blockedBy,omitempty"`
+
+	// ExternalID is the provider's ID for this Subscription, which is its URN
+	// +optional
+	ExternalID string `json:"externalID,omitempty"`
+
+	// ResolvedDependencies contains the resolved IDs for subscription dependencies
+	// +optional
+	ResolvedDependencies SubscriptionDependenciesResolved `json:"resolvedDependencies"`
+
+	// SubscriptionState is the observed state of the subscription
+	// (Unconfirmed, Confirmed or Canceled)
+	// +optional
+	SubscriptionState string `json:"subscriptionState,omitempty"`
+
+	// ObservedGeneration reflects the generation of the most recently observed Subscription spec
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// LastSyncTime is the timestamp of the last successful sync with the provider
+	// +optional
+	LastSyncTime *metav1.Time `json:"lastSyncTime,omitempty"`
+
+	// LastAppliedSpec caches the spec that was successfully applied to the
+	// external resource. It is used to detect changes to immutable fields.
+	// +optional
+	LastAppliedSpec *SubscriptionSpec `json:"lastAppliedSpec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Namespaced,categories=monitoring
+// +kubebuilder:printcolumn:name="Protocol",type=string,JSONPath=`.spec.protocol`
+// +kubebuilder:printcolumn:name="Endpoint",type=string,JSONPath=`.spec.endpoint`
+// +kubebuilder:printcolumn:name="State",type=string,JSONPath=`.status.subscriptionState`
+// +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
+// +kubebuilder:printcolumn:name="ExternalID",type=string,JSONPath=`.status.externalID`,priority=1
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// Subscription is the Schema for the subscriptions API
+type Subscription struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty,omitzero"`
+
+	Spec   SubscriptionSpec   `json:"spec"`
+	Status SubscriptionStatus `json:"status,omitempty"`
+}
+
+// GetProviderConfigRef returns the ProviderConfig used for authentication.
+func (s *Subscription) GetProviderConfigRef() ProviderConfigReference {
+	return s.Spec.ProviderConfigRef
+}
+
+// GetNotFoundPolicy returns how a missing external resource is handled.
+func (s *Subscription) GetNotFoundPolicy() NotFoundPolicy {
+	return s.Spec.NotFoundPolicy
+}
+
+// GetOrphanOnDelete returns whether the external resource is kept on deletion.
+func (s *Subscription) GetOrphanOnDelete() bool {
+	return s.Spec.OrphanOnDelete
+}
+
+// GetConnectionDetailsRef returns the Secret or ConfigMap the outputs are written to.
+func (s *Subscription) GetConnectionDetailsRef() *ConnectionDetailsReference {
+	return s.Spec.WriteConnectionDetailsToRef
+}
+
+// GetConditions returns the conditions of the status.
+func (s *Subscription) GetConditions() *[]metav1.Condition {
+	return &s.Status.Conditions
+}
+
+// GetExternalID returns the provider's ID.
+func (s *Subscription) GetExternalID() string {
+	return s.Status.ExternalID
+}
+
+// SetExternalID sets the provider's ID.
+func (s *Subscription) SetExternalID(id string) {
+	s.Status.ExternalID = id
+}
+
+// GetLastSyncTime returns the time of the last successful sync.
+func (s *Subscription) GetLastSyncTime() *metav1.Time {
+	return s.Status.LastSyncTime
+}
+
+// SetLastSyncTime sets the time of the last successful sync.
+func (s *Subscription) SetLastSyncTime(t *metav1.Time) {
+	s.Status.LastSyncTime = t
+}
+
+// HasLastAppliedSpec returns whether a spec was applied before.
+func (s *Subscription) HasLastAppliedSpec() bool {
+	return s.Status.LastAppliedSpec != nil
+}
+
+// SetLastAppliedSpec records the current spec as applied.
+func (s *Subscription) SetLastAppliedSpec() {
+	s.Status.LastAppliedSpec = s.Spec.DeepCopy()
+}
+
+// ResetLastAppliedSpec clears the applied spec.
+func (s *Subscription) ResetLastAppliedSpec() {
+	s.Status.LastAppliedSpec = nil
+}
+
+// SetBlockedBy sets the chain of unready resources the resource waits for.
+func (s *Subscription) SetBlockedBy(blockers []BlockingResource) {
+	s.Status.BlockedBy = blockers
+}
+
+// +kubebuilder:object:root=true
+
+// SubscriptionList contains a list of Subscription
+type SubscriptionList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Subscription `json:"items"`
+}
+
+// GetItems returns the list of items as a slice of client.Object.
+func (sl *SubscriptionList) GetItems() []client.Object {
+	items := make([]client.Object, len(sl.Items))
+	for i := range sl.Items {
+		items[i] = &sl.Items[i]
+	}
+	return items
+}
+
+func init() {
+	SchemeBuilder.Register(&Subscription{}, &SubscriptionList{})
+}
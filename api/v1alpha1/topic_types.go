@@ -0,0 +1,180 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// TopicSpec defines the desired state of Topic. The topic is a Simple
+// Message Notification (SMN) topic, which notifies its subscriptions about
+// published messages such as alarms. The name of the topic is the name of
+// the resource.
+type TopicSpec struct {
+	// ProviderConfigRef references the ProviderConfig to use for authentication
+	// +kubebuilder:validation:Required
+	ProviderConfigRef ProviderConfigReference `json:"providerConfigRef"`
+
+	// DisplayName is the name shown as the sender of email notifications
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxLength=192
+	DisplayName string `json:"displayName,omitempty"`
+
+	// Policy is the access policy of the topic as a JSON document. It
+	// defines which users and cloud services may publish to the topic.
+	// +kubebuilder:validation:Optional
+	Policy string `json:"policy,omitempty"`
+
+	// WriteConnectionDetailsToRef specifies the Secret or ConfigMap the outputs
+	// of the resource are written to
+	// +kubebuilder:validation:Optional
+	WriteConnectionDetailsToRef *ConnectionDetailsReference `json:"writeConnectionDetailsToRef,omitempty"`
+
+	// OrphanOnDelete prevents deletion of the external resource when the CR is deleted
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=false
+	OrphanOnDelete bool `json:"orphanOnDelete,omitempty"`
+
+	// NotFoundPolicy defines how the resource reacts when the external
+	// resource was deleted out-of-band
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=Recreate
+	NotFoundPolicy NotFoundPolicy `json:"notFoundPolicy,omitempty"`
+}
+
+// TopicStatus defines the observed state of Topic.
+type TopicStatus struct {
+	// Conditions represent the latest available observations of the Topic's state
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// BlockedBy lists the chain of unready resources the resource is waiting
+	// for, ending with the root cause
+	// +optional
+	BlockedBy []BlockingResource `json:"blockedBy,omitempty"`
+
+	// ExternalID is the provider's ID for this Topic, which is its URN
+	// +optional
+	ExternalID string `json:"externalID,omitempty"`
+
+	// TopicURN is the URN other resources use to reference the topic
+	// +optional
+	TopicURN string `json:"topicURN,omitempty"`
+
+	// ObservedGeneration reflects the generation of the most recently observed Topic spec
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// LastSyncTime is the timestamp of the last successful sync with the provider
+	// +optional
+	LastSyncTime *metav1.Time `json:"lastSyncTime,omitempty"`
+
+	// LastAppliedSpec caches the spec that was successfully applied to the
+	// external resource. It is used to detect changes to immutable fields.
+	// +optional
+	LastAppliedSpec *TopicSpec `json:"lastAppliedSpec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Namespaced,categories=monitoring
+// +kubebuilder:printcolumn:name="DisplayName",type=string,JSONPath=`.spec.displayName`
+// +kubebuilder:printcolumn:name="URN",type=string,JSONPath=`.status.topicURN`
+// +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
+// +kubebuilder:printcolumn:name="ExternalID",type=string,JSONPath=`.status.externalID`,priority=1
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// Topic is the Schema for the topics API
+type Topic struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty,omitzero"`
+
+	Spec   TopicSpec   `json:"spec"`
+	Status TopicStatus `json:"status,omitempty"`
+}
+
+// GetProviderConfigRef returns the ProviderConfig used for authentication.
+func (tp *Topic) GetProviderConfigRef() ProviderConfigReference {
+	return tp.Spec.ProviderConfigRef
+}
+
+// GetNotFoundPolicy returns how a missing external resource is handled.
+func (tp *Topic) GetNotFoundPolicy() NotFoundPolicy {
+	return tp.Spec.NotFoundPolicy
+}
+
+// GetOrphanOnDelete returns whether the external resource is kept on deletion.
+func (tp *Topic) GetOrphanOnDelete() bool {
+	return tp.Spec.OrphanOnDelete
+}
+
+// GetConnectionDetailsRef returns the Secret or ConfigMap the outputs are written to.
+func (tp *Topic) GetConnectionDetailsRef() *ConnectionDetailsReference {
+	return tp.Spec.WriteConnectionDetailsToRef
+}
+
+// GetConditions returns the conditions of the status.
+func (tp *Topic) GetConditions() *[]metav1.Condition {
+	return &tp.Status.Conditions
+}
+
+// GetExternalID returns the provider's ID.
+func (tp *Topic) GetExternalID() string {
+	return tp.Status.ExternalID
+}
+
+// SetExternalID sets the provider's ID.
+func (tp *Topic) SetExternalID(id string) {
+	tp.Status.ExternalID = id
+}
+
+// GetLastSyncTime returns the time of the last successful sync.
+func (tp *Topic) GetLastSyncTime() *metav1.Time {
+	return tp.Status.LastSyncTime
+}
+
+// SetLastSyncTime sets the time of the last successful sync.
+func (tp *Topic) SetLastSyncTime(t *metav1.Time) {
+	tp.Status.LastSyncTime = t
+}
+
+// HasLastAppliedSpec returns whether a spec was applied before.
+func (tp *Topic) HasLastAppliedSpec() bool {
+	return tp.Status.LastAppliedSpec != nil
+}
+
+// SetLastAppliedSpec records the current spec as applied.
+func (tp *Topic) SetLastAppliedSpec() {
+	tp.Status.LastAppliedSpec = tp.Spec.DeepCopy()
+}
+
+// ResetLastAppliedSpec clears the applied spec.
+func (tp *Topic) ResetLastAppliedSpec() {
+	tp.Status.LastAppliedSpec = nil
+}
+
+// SetBlockedBy sets the chain of unready resources the resource waits for.
+func (tp *Topic) SetBlockedBy(blockers []BlockingResource) {
+	tp.Status.BlockedBy = blockers
+}
+
+// +kubebuilder:object:root=true
+
+// TopicList contains a list of Topic
+type TopicList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Topic `json:"items"`
+}
+
+// GetItems returns the list of items as a slice of client.Object.
+func (tl *TopicList) GetItems() []client.Object {
+	items := make([]client.Object, len(tl.Items))
+	for i := range tl.Items {
+		items[i] = &tl.Items[i]
+	}
+	return items
+}
+
+func init() {
+	SchemeBuilder.Register(&Topic{}, &TopicList{})
+}
@@ -0,0 +1,187 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// VirtualIPSpec defines the desired state of VirtualIP
+type VirtualIPSpec struct {
+	// ProviderConfigRef references the ProviderConfig to use for authentication
+	// +kubebuilder:validation:Required
+	ProviderConfigRef ProviderConfigReference `json:"providerConfigRef"`
+
+	// Subnet defines the subnet dependency
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="subnet is immutable"
+	Subnet SubnetDependency `json:"subnet"`
+
+	// IPAddress is the fixed IP address of the virtual IP. If omitted, an
+	// address is allocated from the subnet.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="ipAddress is immutable"
+	IPAddress string `json:"ipAddress,omitempty"`
+
+	// WriteConnectionDetailsToRef specifies the Secret or ConfigMap the outputs
+	// of the resource are written to
+	// +kubebuilder:validation:Optional
+	WriteConnectionDetailsToRef *ConnectionDetailsReference `json:"writeConnectionDetailsToRef,omitempty"`
+
+	// OrphanOnDelete prevents deletion of the external resource when the CR is deleted
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=false
+	OrphanOnDelete bool `json:"orphanOnDelete,omitempty"`
+
+	// NotFoundPolicy defines how the resource reacts when the external
+	// resource was deleted out-of-band
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=Recreate
+	NotFoundPolicy NotFoundPolicy `json:"notFoundPolicy,omitempty"`
+}
+
+// VirtualIPDependenciesResolved contains the resolved IDs for virtual IP dependencies
+type VirtualIPDependenciesResolved struct {
+	// SubnetID is the resolved Subnet ID
+	SubnetID string `json:"subnetID,omitempty"`
+}
+
+// VirtualIPStatus defines the observed state of VirtualIP.
+type VirtualIPStatus struct {
+	// Conditions represent the latest available observations of the VirtualIP's state
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// BlockedBy lists the chain of unready resources the resource is waiting
+	// for, ending with the root cause
+	// +optional
+	BlockedBy []BlockingResource `json:"blockedBy,omitempty"`
+
+	// ExternalID is the provider's ID for the port backing this VirtualIP
+	// +optional
+	ExternalID string `json:"externalID,omitempty"`
+
+	// ResolvedDependencies contains the resolved IDs for virtual IP dependencies
+	// +optional
+	ResolvedDependencies VirtualIPDependenciesResolved `json:"resolvedDependencies"`
+
+	// IPAddress is the fixed IP address allocated to the virtual IP
+	// +optional
+	IPAddress string `json:"ipAddress,omitempty"`
+
+	// ObservedGeneration reflects the generation of the most recently observed VirtualIP spec
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// LastSyncTime is the timestamp of the last successful sync with the provider
+	// +optional
+	LastSyncTime *metav1.Time `json:"lastSyncTime,omitempty"`
+
+	// LastAppliedSpec caches the spec that was successfully applied to the
+	// external resource. It is used to detect changes to immutable fields.
+	// +optional
+	LastAppliedSpec *VirtualIPSpec `json:"lastAppliedSpec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Namespaced,categories=networking
+// +kubebuilder:printcolumn:name="Address",type=string,JSONPath=`.status.ipAddress`
+// +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
+// +kubebuilder:printcolumn:name="ExternalID",type=string,JSONPath=`.status.externalID`,priority=1
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// VirtualIP is the Schema for the virtualips API
+type VirtualIP struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty,omitzero"`
+
+	Spec   VirtualIPSpec   `json:"spec"`
+	Status VirtualIPStatus `json:"status,omitempty"`
+}
+
+// GetProviderConfigRef returns the ProviderConfig used for authentication.
+func (v *VirtualIP) GetProviderConfigRef() ProviderConfigReference {
+	return v.Spec.ProviderConfigRef
+}
+
+// GetNotFoundPolicy returns how a missing external resource is handled.
+func (v *VirtualIP) GetNotFoundPolicy() NotFoundPolicy {
+	return v.Spec.NotFoundPolicy
+}
+
+// GetOrphanOnDelete returns whether the external resource is kept on deletion.
+func (v *VirtualIP) GetOrphanOnDelete() bool {
+	return v.Spec.OrphanOnDelete
+}
+
+// GetConnectionDetailsRef returns the Secret or ConfigMap the outputs are written to.
+func (v *VirtualIP) GetConnectionDetailsRef() *ConnectionDetailsReference {
+	return v.Spec.WriteConnectionDetailsToRef
+}
+
+// GetConditions returns the conditions of the status.
+func (v *VirtualIP) GetConditions() *[]metav1.Condition {
+	return &v.Status.Conditions
+}
+
+// GetExternalID returns the provider's ID.
+func (v *VirtualIP) GetExternalID() string {
+	return v.Status.ExternalID
+}
+
+// SetExternalID sets the provider's ID.
+func (v *VirtualIP) SetExternalID(id string) {
+	v.Status.ExternalID = id
+}
+
+// GetLastSyncTime returns the time of the last successful sync.
+func (v *VirtualIP) GetLastSyncTime() *metav1.Time {
+	return v.Status.LastSyncTime
+}
+
+// SetLastSyncTime sets the time of the last successful sync.
+func (v *VirtualIP) SetLastSyncTime(t *metav1.Time) {
+	v.Status.LastSyncTime = t
+}
+
+// HasLastAppliedSpec returns whether a spec was applied before.
+func (v *VirtualIP) HasLastAppliedSpec() bool {
+	return v.Status.LastAppliedSpec != nil
+}
+
+// SetLastAppliedSpec records the current spec as applied.
+func (v *VirtualIP) SetLastAppliedSpec() {
+	v.Status.LastAppliedSpec = v.Spec.DeepCopy()
+}
+
+// ResetLastAppliedSpec clears the applied spec.
+func (v *VirtualIP) ResetLastAppliedSpec() {
+	v.Status.LastAppliedSpec = nil
+}
+
+// SetBlockedBy sets the chain of unready resources the resource waits for.
+func (v *VirtualIP) SetBlockedBy(blockers []BlockingResource) {
+	v.Status.BlockedBy = blockers
+}
+
+// +kubebuilder:object:root=true
+
+// VirtualIPList contains a list of VirtualIP
+type VirtualIPList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []VirtualIP `json:"items"`
+}
+
+// GetItems returns the list of items as a slice of client.Object.
+func (vl *VirtualIPList) GetItems() []client.Object {
+	items := make([]client.Object, len(vl.Items))
+	for i := range vl.Items {
+		items[i] = &vl.Items[i]
+	}
+	return items
+}
+
+func init() {
+	SchemeBuilder.Register(&VirtualIP{}, &VirtualIPList{})
+}
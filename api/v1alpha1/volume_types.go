@@ -0,0 +1,231 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// VolumeAttachment defines the instance a volume is attached to
+type VolumeAttachment struct {
+	// Instance defines the instance the volume is attached to
+	// +kubebuilder:validation:Required
+	Instance InstanceDependency `json:"instance"`
+
+	// Device is the device name of the volume within the instance (e.g.
+	// /dev/vdb). If omitted, the device name is chosen by the provider.
+	// +kubebuilder:validation:Optional
+	Device string `json:"device,omitempty"`
+}
+
+// VolumeSpec defines the desired state of Volume
+type VolumeSpec struct {
+	// ProviderConfigRef references the ProviderConfig to use for authentication
+	// +kubebuilder:validation:Required
+	ProviderConfigRef ProviderConfigReference `json:"providerConfigRef"`
+
+	// Size is the size of the volume in GB. It can only be increased.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Minimum=10
+	// +kubebuilder:validation:Maximum=32768
+	// +kubebuilder:validation:XValidation:rule="self >= oldSelf",message="size cannot be decreased"
+	Size int `json:"size"`
+
+	// Type is the disk type of the volume
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=SSD
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="type is immutable"
+	Type VolumeType `json:"type,omitempty"`
+
+	// AvailabilityZone is the availability zone the volume is created in. It
+	// must match the availability zone of the instance it is attached to.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="availabilityZone is immutable"
+	AvailabilityZone string `json:"availabilityZone"`
+
+	// KMSKey is the KMS key used to encrypt the volume. If omitted, the
+	// volume is not encrypted.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="kmsKey is immutable"
+	KMSKey *KMSKeyDependency `json:"kmsKey,omitempty"`
+
+	// Attachment defines the instance the volume is attached to. Removing the
+	// attachment detaches the volume.
+	// +kubebuilder:validation:Optional
+	Attachment *VolumeAttachment `json:"attachment,omitempty"`
+
+	// WriteConnectionDetailsToRef specifies the Secret or ConfigMap the outputs
+	// of the resource are written to
+	// +kubebuilder:validation:Optional
+	WriteConnectionDetailsToRef *ConnectionDetailsReference `json:"writeConnectionDetailsToRef,omitempty"`
+
+	// OrphanOnDelete prevents deletion of the external resource when the CR is deleted
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=false
+	OrphanOnDelete bool `json:"orphanOnDelete,omitempty"`
+
+	// NotFoundPolicy defines how the resource reacts when the external
+	// resource was deleted out-of-band
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=Recreate
+	NotFoundPolicy NotFoundPolicy `json:"notFoundPolicy,omitempty"`
+}
+
+// VolumeDependenciesResolved contains the resolved IDs for volume dependencies
+type VolumeDependenciesResolved struct {
+	// InstanceID is the resolved Instance ID the volume is attached to
+	InstanceID string `json:"instanceID,omitempty"`
+	// KMSKeyID is the resolved KMSKey ID the volume is encrypted with
+	KMSKeyID string `json:"kmsKeyID,omitempty"`
+}
+
+// VolumeStatus defines the observed state of Volume.
+type VolumeStatus struct {
+	// Conditions represent the latest available observations of the Volume's state
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// BlockedBy lists the chain of unready resources the resource is waiting
+	// for, ending with the root cause
+	// +optional
+	BlockedBy []BlockingResource `json:"blockedBy,omitempty"`
+
+	// ExternalID is the provider's ID for this Volume
+	// +optional
+	ExternalID string `json:"externalID,omitempty"`
+
+	// ResolvedDependencies contains the resolved IDs for volume dependencies
+	// +optional
+	ResolvedDependencies VolumeDependenciesResolved `json:"resolvedDependencies"`
+
+	// Size is the observed size of the volume in GB
+	// +optional
+	Size int `json:"size,omitempty"`
+
+	// AttachedInstanceID is the ID of the instance the volume is attached to
+	// +optional
+	AttachedInstanceID string `json:"attachedInstanceID,omitempty"`
+
+	// Device is the device name of the volume within the attached instance
+	// +optional
+	Device string `json:"device,omitempty"`
+
+	// ObservedGeneration reflects the generation of the most recently observed Volume spec
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// LastSyncTime is the timestamp of the last successful sync with the provider
+	// +optional
+	LastSyncTime *metav1.Time `json:"lastSyncTime,omitempty"`
+
+	// LastAppliedSpec caches the spec that was successfully applied to the
+	// external resource. It is used to detect changes to immutable fields.
+	// +optional
+	LastAppliedSpec *VolumeSpec `json:"lastAppliedSpec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Namespaced,categories=compute
+// +kubebuilder:printcolumn:name="Type",type=string,JSONPath=`.spec.type`
+// +kubebuilder:printcolumn:name="Size",type=integer,JSONPath=`.status.size`
+// +kubebuilder:printcolumn:name="Instance",type=string,JSONPath=`.status.attachedInstanceID`
+// +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
+// +kubebuilder:printcolumn:name="ExternalID",type=string,JSONPath=`.status.externalID`,priority=1
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// Volume is the Schema for the volumes API
+type Volume struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty,omitzero"`
+
+	Spec   VolumeSpec   `json:"spec"`
+	Status VolumeStatus `json:"status,omitempty"`
+}
+
+// GetProviderConfigRef returns the ProviderConfig used for authentication.
+func (v *Volume) GetProviderConfigRef() ProviderConfigReference {
+	return v.Spec.ProviderConfigRef
+}
+
+// GetNotFoundPolicy returns how a missing external resource is handled.
+func (v *Volume) GetNotFoundPolicy() NotFoundPolicy {
+	return v.Spec.NotFoundPolicy
+}
+
+// GetOrphanOnDelete returns whether the external resource is kept on deletion.
+func (v *Volume) GetOrphanOnDelete() bool {
+	return v.Spec.OrphanOnDelete
+}
+
+// GetConnectionDetailsRef returns the Secret or ConfigMap the outputs are written to.
+func (v *Volume) GetConnectionDetailsRef() *ConnectionDetailsReference {
+	return v.Spec.WriteConnectionDetailsToRef
+}
+
+// GetConditions returns the conditions of the status.
+func (v *Volume) GetConditions() *[]metav1.Condition {
+	return &v.Status.Conditions
+}
+
+// GetExternalID returns the provider's ID.
+func (v *Volume) GetExternalID() string {
+	return v.Status.ExternalID
+}
+
+// SetExternalID sets the provider's ID.
+func (v *Volume) SetExternalID(id string) {
+	v.Status.ExternalID = id
+}
+
+// GetLastSyncTime returns the time of the last successful sync.
+func (v *Volume) GetLastSyncTime() *metav1.Time {
+	return v.Status.LastSyncTime
+}
+
+// SetLastSyncTime sets the time of the last successful sync.
+func (v *Volume) SetLastSyncTime(t *metav1.Time) {
+	v.Status.LastSyncTime = t
+}
+
+// HasLastAppliedSpec returns whether a spec was applied before.
+func (v *Volume) HasLastAppliedSpec() bool {
+	return v.Status.LastAppliedSpec != nil
+}
+
+// SetLastAppliedSpec records the current spec as applied.
+func (v *Volume) SetLastAppliedSpec() {
+	v.Status.LastAppliedSpec = v.Spec.DeepCopy()
+}
+
+// ResetLastAppliedSpec clears the applied spec.
+func (v *Volume) ResetLastAppliedSpec() {
+	v.Status.LastAppliedSpec = nil
+}
+
+// SetBlockedBy sets the chain of unready resources the resource waits for.
+func (v *Volume) SetBlockedBy(blockers []BlockingResource) {
+	v.Status.BlockedBy = blockers
+}
+
+// +kubebuilder:object:root=true
+
+// VolumeList contains a list of Volume
+type VolumeList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Volume `json:"items"`
+}
+
+// GetItems returns the list of items as a slice of client.Object.
+func (vl *VolumeList) GetItems() []client.Object {
+	items := make([]client.Object, len(vl.Items))
+	for i := range vl.Items {
+		items[i] = &vl.Items[i]
+	}
+	return items
+}
+
+func init() {
+	SchemeBuilder.Register(&Volume{}, &VolumeList{})
+}
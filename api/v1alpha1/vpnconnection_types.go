@@ -0,0 +1,311 @@
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// +kubebuilder:validation:Enum=static;policy
+type VPNConnectionRoutingMode string
+
+const (
+	VPNConnectionStatic VPNConnectionRoutingMode = "static"
+	VPNConnectionPolicy VPNConnectionRoutingMode = "policy"
+)
+
+// +kubebuilder:validation:Enum=sha2-512;sha2-384;sha2-256;sha1;md5
+type VPNAuthenticationAlgorithm string
+
+// +kubebuilder:validation:Enum=aes-256-gcm-16;aes-128-gcm-16;aes-256;aes-192;aes-128;"3des"
+type VPNEncryptionAlgorithm string
+
+// +kubebuilder:validation:Enum=group1;group2;group5;group14;group15;group16;group19;group20;group21
+type VPNDHGroup string
+
+// IKEPolicy defines the phase 1 (IKE) negotiation parameters of a VPN connection
+type IKEPolicy struct {
+	// Version is the IKE version
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=v1;v2
+	// +kubebuilder:default=v2
+	Version string `json:"version,omitempty"`
+
+	// NegotiationMode is the phase 1 negotiation mode. It only applies to IKE v1.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=main;aggressive
+	// +kubebuilder:default=main
+	NegotiationMode string `json:"negotiationMode,omitempty"`
+
+	// AuthenticationAlgorithm is the authentication algorithm
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=sha2-256
+	AuthenticationAlgorithm VPNAuthenticationAlgorithm `json:"authenticationAlgorithm,omitempty"`
+
+	// EncryptionAlgorithm is the encryption algorithm
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=aes-128
+	EncryptionAlgorithm VPNEncryptionAlgorithm `json:"encryptionAlgorithm,omitempty"`
+
+	// DHGroup is the Diffie-Hellman group used for the key exchange
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=group15
+	DHGroup VPNDHGroup `json:"dhGroup,omitempty"`
+
+	// LifetimeSeconds is the lifetime of the IKE security association
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=86400
+	// +kubebuilder:validation:Minimum=60
+	// +kubebuilder:validation:Maximum=604800
+	LifetimeSeconds int `json:"lifetimeSeconds,omitempty"`
+}
+
+// IPsecPolicy defines the phase 2 (IPsec) negotiation parameters of a VPN connection
+type IPsecPolicy struct {
+	// AuthenticationAlgorithm is the authentication algorithm
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=sha2-256
+	AuthenticationAlgorithm VPNAuthenticationAlgorithm `json:"authenticationAlgorithm,omitempty"`
+
+	// EncryptionAlgorithm is the encryption algorithm
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=aes-128
+	EncryptionAlgorithm VPNEncryptionAlgorithm `json:"encryptionAlgorithm,omitempty"`
+
+	// PFS is the Diffie-Hellman group used for perfect forward secrecy, or
+	// disable to turn it off
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=group1;group2;group5;group14;group15;group16;group19;group20;group21;disable
+	// +kubebuilder:default=group15
+	PFS string `json:"pfs,omitempty"`
+
+	// LifetimeSeconds is the lifetime of the IPsec tunnel
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=3600
+	// +kubebuilder:validation:Minimum=30
+	// +kubebuilder:validation:Maximum=604800
+	LifetimeSeconds int `json:"lifetimeSeconds,omitempty"`
+}
+
+// VPNConnectionSpec defines the desired state of VPNConnection
+type VPNConnectionSpec struct {
+	// ProviderConfigRef references the ProviderConfig to use for authentication
+	// +kubebuilder:validation:Required
+	ProviderConfigRef ProviderConfigReference `json:"providerConfigRef"`
+
+	// Gateway defines the VPN gateway the connection is established from.
+	// The connection uses the primary public IP of the gateway.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="gateway is immutable"
+	Gateway VPNGatewayDependency `json:"gateway"`
+
+	// CustomerGateway defines the on-premises gateway the connection is
+	// established to
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="customerGateway is immutable"
+	CustomerGateway CustomerGatewayDependency `json:"customerGateway"`
+
+	// RoutingMode is the routing mode of the connection
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=static
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="routingMode is immutable"
+	RoutingMode VPNConnectionRoutingMode `json:"routingMode,omitempty"`
+
+	// PeerSubnets are the CIDRs of the customer networks reachable through
+	// the connection
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=50
+	PeerSubnets []string `json:"peerSubnets"`
+
+	// PreSharedKeySecretRef references a key of a Secret containing the
+	// pre-shared key of the connection. The key is updated when the Secret
+	// changes.
+	// +kubebuilder:validation:Required
+	PreSharedKeySecretRef corev1.SecretKeySelector `json:"preSharedKeySecretRef"`
+
+	// IKEPolicy defines the phase 1 negotiation parameters
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default={}
+	IKEPolicy IKEPolicy `json:"ikePolicy,omitempty"`
+
+	// IPsecPolicy defines the phase 2 negotiation parameters
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default={}
+	IPsecPolicy IPsecPolicy `json:"ipsecPolicy,omitempty"`
+
+	// WriteConnectionDetailsToRef specifies the Secret or ConfigMap the outputs
+	// of the resource are written to
+	// +kubebuilder:validation:Optional
+	WriteConnectionDetailsToRef *ConnectionDetailsReference `json:"writeConnectionDetailsToRef,omitempty"`
+
+	// OrphanOnDelete prevents deletion of the external resource when the CR is deleted
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=false
+	OrphanOnDelete bool `json:"orphanOnDelete,omitempty"`
+
+	// NotFoundPolicy defines how the resource reacts when the external
+	// resource was deleted out-of-band
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=Recreate
+	NotFoundPolicy NotFoundPolicy `json:"notFoundPolicy,omitempty"`
+}
+
+// VPNConnectionDependenciesResolved contains the resolved IDs for VPN connection dependencies
+type VPNConnectionDependenciesResolved struct {
+	// VPNGatewayID is the resolved VPNGateway ID
+	VPNGatewayID string `json:"vpnGatewayID,omitempty"`
+	// CustomerGatewayID is the resolved CustomerGateway ID
+	CustomerGatewayID string `json:"customerGatewayID,omitempty"`
+	// GatewayPublicIPID is the ID of the public IP of the VPN gateway used
+	// by the connection
+	GatewayPublicIPID string `json:"gatewayPublicIPID,omitempty"`
+}
+
+// VPNConnectionStatus defines the observed state of VPNConnection.
+type VPNConnectionStatus struct {
+	// Conditions represent the latest available observations of the VPNConnection's state
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// BlockedBy lists the chain of unready resources the resource is waiting
+	// for, ending with the root cause
+	// +optional
+	BlockedBy []BlockingResource `json:"blockedBy,omitempty"`
+
+	// ExternalID is the provider's ID for this VPNConnection
+	// +optional
+	ExternalID string `json:"externalID,omitempty"`
+
+	// ResolvedDependencies contains the resolved IDs for VPN connection dependencies
+	// +optional
+	ResolvedDependencies VPNConnectionDependenciesResolved `json:"resolvedDependencies"`
+
+	// TunnelStatus is the status of the tunnel as reported by the provider
+	// (e.g. ACTIVE or DOWN)
+	// +optional
+	TunnelStatus string `json:"tunnelStatus,omitempty"`
+
+	// PreSharedKeyHash is the SHA-256 hash of the last applied pre-shared
+	// key. It is used to detect changes to the Secret.
+	// +optional
+	PreSharedKeyHash string `json:"preSharedKeyHash,omitempty"`
+
+	// ObservedGeneration reflects the generation of the most recently observed VPNConnection spec
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// LastSyncTime is the timestamp of the last successful sync with the provider
+	// +optional
+	LastSyncTime *metav1.Time `json:"lastSyncTime,omitempty"`
+
+	// LastAppliedSpec caches the spec that was successfully applied to the
+	// external resource. It is used to detect changes to immutable fields.
+	// +optional
+	LastAppliedSpec *VPNConnectionSpec `json:"lastAppliedSpec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Namespaced,categories=networking
+// +kubebuilder:printcolumn:name="Mode",type=string,JSONPath=`.spec.routingMode`
+// +kubebuilder:printcolumn:name="Tunnel",type=string,JSONPath=`.status.tunnelStatus`
+// +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
+// +kubebuilder:printcolumn:name="ExternalID",type=string,JSONPath=`.status.externalID`,priority=1
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// VPNConnection is the Schema for the vpnconnections API
+type VPNConnection struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty,omitzero"`
+
+	Spec   VPNConnectionSpec   `json:"spec"`
+	Status VPNConnectionStatus `json:"status,omitempty"`
+}
+
+// GetProviderConfigRef returns the ProviderConfig used for authentication.
+func (c *VPNConnection) GetProviderConfigRef() ProviderConfigReference {
+	return c.Spec.ProviderConfigRef
+}
+
+// GetNotFoundPolicy returns how a missing external resource is handled.
+func (c *VPNConnection) GetNotFoundPolicy() NotFoundPolicy {
+	return c.Spec.NotFoundPolicy
+}
+
+// GetOrphanOnDelete returns whether the external resource is kept on deletion.
+func (c *VPNConnection) GetOrphanOnDelete() bool {
+	return c.Spec.OrphanOnDelete
+}
+
+// GetConnectionDetailsRef returns the Secret or ConfigMap the outputs are written to.
+func (c *VPNConnection) GetConnectionDetailsRef() *ConnectionDetailsReference {
+	return c.Spec.WriteConnectionDetailsToRef
+}
+
+// GetConditions returns the conditions of the status.
+func (c *VPNConnection) GetConditions() *[]metav1.Condition {
+	return &c.Status.Conditions
+}
+
+// GetExternalID returns the provider's ID.
+func (c *VPNConnection) GetExternalID() string {
+	return c.Status.ExternalID
+}
+
+// SetExternalID sets the provider's ID.
+func (c *VPNConnection) SetExternalID(id string) {
+	c.Status.ExternalID = id
+}
+
+// GetLastSyncTime returns the time of the last successful sync.
+func (c *VPNConnection) GetLastSyncTime() *metav1.Time {
+	return c.Status.LastSyncTime
+}
+
+// SetLastSyncTime sets the time of the last successful sync.
+func (c *VPNConnection) SetLastSyncTime(t *metav1.Time) {
+	c.Status.LastSyncTime = t
+}
+
+// HasLastAppliedSpec returns whether a spec was applied before.
+func (c *VPNConnection) HasLastAppliedSpec() bool {
+	return c.Status.LastAppliedSpec != nil
+}
+
+// SetLastAppliedSpec records the current spec as applied.
+func (c *VPNConnection) SetLastAppliedSpec() {
+	c.Status.LastAppliedSpec = c.Spec.DeepCopy()
+}
+
+// ResetLastAppliedSpec clears the applied spec.
+func (c *VPNConnection) ResetLastAppliedSpec() {
+	c.Status.LastAppliedSpec = nil
+}
+
+// SetBlockedBy sets the chain of unready resources the resource waits for.
+func (c *VPNConnection) SetBlockedBy(blockers []BlockingResource) {
+	c.Status.BlockedBy = blockers
+}
+
+// +kubebuilder:object:root=true
+
+// VPNConnectionList contains a list of VPNConnection
+type VPNConnectionList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []VPNConnection `json:"items"`
+}
+
+// GetItems returns the list of items as a slice of client.Object.
+func (vl *VPNConnectionList) GetItems() []client.Object {
+	items := make([]client.Object, len(vl.Items))
+	for i := range vl.Items {
+		items[i] = &vl.Items[i]
+	}
+	return items
+}
+
+func init() {
+	SchemeBuilder.Register(&VPNConnection{}, &VPNConnectionList{})
+}
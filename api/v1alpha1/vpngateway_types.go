@@ -0,0 +1,261 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// +kubebuilder:validation:Enum=V1G;V300;Basic;Professional1;Professional2
+type VPNGatewayFlavor string
+
+const (
+	VPNGatewayV1G           VPNGatewayFlavor = "V1G"
+	VPNGatewayV300          VPNGatewayFlavor = "V300"
+	VPNGatewayBasic         VPNGatewayFlavor = "Basic"
+	VPNGatewayProfessional1 VPNGatewayFlavor = "Professional1"
+	VPNGatewayProfessional2 VPNGatewayFlavor = "Professional2"
+)
+
+// +kubebuilder:validation:Enum=active-active;active-standby
+type VPNGatewayHAMode string
+
+const (
+	VPNGatewayActiveActive  VPNGatewayHAMode = "active-active"
+	VPNGatewayActiveStandby VPNGatewayHAMode = "active-standby"
+)
+
+// VPNGatewaySpec defines the desired state of VPNGateway
+type VPNGatewaySpec struct {
+	// ProviderConfigRef references the ProviderConfig to use for authentication
+	// +kubebuilder:validation:Required
+	ProviderConfigRef ProviderConfigReference `json:"providerConfigRef"`
+
+	// Network defines the network the VPN gateway is associated with
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="network is immutable"
+	Network NetworkDependency `json:"network"`
+
+	// Subnet defines the subnet of the network used by the VPN gateway
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="subnet is immutable"
+	Subnet SubnetDependency `json:"subnet"`
+
+	// LocalSubnets are the CIDRs of the cloud-side subnets which communicate
+	// with the customer networks through the VPN
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinItems=1
+	LocalSubnets []string `json:"localSubnets"`
+
+	// Flavor is the specification of the VPN gateway
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=Professional1
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="flavor is immutable"
+	Flavor VPNGatewayFlavor `json:"flavor,omitempty"`
+
+	// AvailabilityZones are the availability zones the VPN gateway is
+	// deployed in. If omitted, they are chosen by the provider.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="availabilityZones is immutable"
+	AvailabilityZones []string `json:"availabilityZones,omitempty"`
+
+	// BGPASN is the BGP autonomous system number of the VPN gateway
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=64512
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=4294967295
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="bgpASN is immutable"
+	BGPASN int64 `json:"bgpASN,omitempty"`
+
+	// HAMode is the high availability mode of the VPN gateway
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=active-active
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="haMode is immutable"
+	HAMode VPNGatewayHAMode `json:"haMode,omitempty"`
+
+	// PrimaryPublicIP defines the first public IP of the VPN gateway, which
+	// is the active one in active-standby mode
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="primaryPublicIP is immutable"
+	PrimaryPublicIP PublicIPDependency `json:"primaryPublicIP"`
+
+	// SecondaryPublicIP defines the second public IP of the VPN gateway,
+	// which is the standby one in active-standby mode
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="secondaryPublicIP is immutable"
+	SecondaryPublicIP PublicIPDependency `json:"secondaryPublicIP"`
+
+	// WriteConnectionDetailsToRef specifies the Secret or ConfigMap the outputs
+	// of the resource are written to
+	// +kubebuilder:validation:Optional
+	WriteConnectionDetailsToRef *ConnectionDetailsReference `json:"writeConnectionDetailsToRef,omitempty"`
+
+	// OrphanOnDelete prevents deletion of the external resource when the CR is deleted
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=false
+	OrphanOnDelete bool `json:"orphanOnDelete,omitempty"`
+
+	// NotFoundPolicy defines how the resource reacts when the external
+	// resource was deleted out-of-band
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=Recreate
+	NotFoundPolicy NotFoundPolicy `json:"notFoundPolicy,omitempty"`
+}
+
+// VPNGatewayDependenciesResolved contains the resolved IDs for VPN gateway dependencies
+type VPNGatewayDependenciesResolved struct {
+	// NetworkID is the resolved Network ID
+	NetworkID string `json:"networkID,omitempty"`
+	// SubnetID is the resolved Subnet ID
+	SubnetID string `json:"subnetID,omitempty"`
+	// PrimaryPublicIPID is the resolved ID of the primary PublicIP
+	PrimaryPublicIPID string `json:"primaryPublicIPID,omitempty"`
+	// SecondaryPublicIPID is the resolved ID of the secondary PublicIP
+	SecondaryPublicIPID string `json:"secondaryPublicIPID,omitempty"`
+}
+
+// VPNGatewayStatus defines the observed state of VPNGateway.
+type VPNGatewayStatus struct {
+	// Conditions represent the latest available observations of the VPNGateway's state
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// BlockedBy lists the chain of unready resources the resource is waiting
+	// for, ending with the root cause
+	// +optional
+	BlockedBy []BlockingResource `json:"blockedBy,omitempty"`
+
+	// ExternalID is the provider's ID for this VPNGateway
+	// +optional
+	ExternalID string `json:"externalID,omitempty"`
+
+	// ResolvedDependencies contains the resolved IDs for VPN gateway dependencies
+	// +optional
+	ResolvedDependencies VPNGatewayDependenciesResolved `json:"resolvedDependencies"`
+
+	// PrimaryAddress is the address of the primary public IP
+	// +optional
+	PrimaryAddress string `json:"primaryAddress,omitempty"`
+
+	// SecondaryAddress is the address of the secondary public IP
+	// +optional
+	SecondaryAddress string `json:"secondaryAddress,omitempty"`
+
+	// ObservedGeneration reflects the generation of the most recently observed VPNGateway spec
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// LastSyncTime is the timestamp of the last successful sync with the provider
+	// +optional
+	LastSyncTime *metav1.Time `json:"lastSyncTime,omitempty"`
+
+	// LastAppliedSpec caches the spec that was successfully applied to the
+	// external resource. It is used to detect changes to immutable fields.
+	// +optional
+	LastAppliedSpec *VPNGatewaySpec `json:"lastAppliedSpec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Namespaced,categories=networking
+// +kubebuilder:printcolumn:name="Flavor",type=string,JSONPath=`.spec.flavor`
+// +kubebuilder:printcolumn:name="Primary",type=string,JSONPath=`.status.primaryAddress`
+// +kubebuilder:printcolumn:name="Secondary",type=string,JSONPath=`.status.secondaryAddress`
+// +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
+// +kubebuilder:printcolumn:name="ExternalID",type=string,JSONPath=`.status.externalID`,priority=1
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// VPNGateway is the Schema for the vpngateways API
+type VPNGateway struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty,omitzero"`
+
+	Spec   VPNGatewaySpec   `json:"spec"`
+	Status VPNGatewayStatus `json:"status,omitempty"`
+}
+
+// GetProviderConfigRef returns the ProviderConfig used for authentication.
+func (g *VPNGateway) GetProviderConfigRef() ProviderConfigReference {
+	return g.Spec.ProviderConfigRef
+}
+
+// GetNotFoundPolicy returns how a missing external resource is handled.
+func (g *VPNGateway) GetNotFoundPolicy() NotFoundPolicy {
+	return g.Spec.NotFoundPolicy
+}
+
+// GetOrphanOnDelete returns whether the external resource is kept on deletion.
+func (g *VPNGateway) GetOrphanOnDelete() bool {
+	return g.Spec.OrphanOnDelete
+}
+
+// GetConnectionDetailsRef returns the Secret or ConfigMap the outputs are written to.
+func (g *VPNGateway) GetConnectionDetailsRef() *ConnectionDetailsReference {
+	return g.Spec.WriteConnectionDetailsToRef
+}
+
+// GetConditions returns the conditions of the status.
+func (g *VPNGateway) GetConditions() *[]metav1.Condition {
+	return &g.Status.Conditions
+}
+
+// GetExternalID returns the provider's ID.
+func (g *VPNGateway) GetExternalID() string {
+	return g.Status.ExternalID
+}
+
+// SetExternalID sets the provider's ID.
+func (g *VPNGateway) SetExternalID(id string) {
+	g.Status.ExternalID = id
+}
+
+// GetLastSyncTime returns the time of the last successful sync.
+func (g *VPNGateway) GetLastSyncTime() *metav1.Time {
+	return g.Status.LastSyncTime
+}
+
+// SetLastSyncTime sets the time of the last successful sync.
+func (g *VPNGateway) SetLastSyncTime(t *metav1.Time) {
+	g.Status.LastSyncTime = t
+}
+
+// HasLastAppliedSpec returns whether a spec was applied before.
+func (g *VPNGateway) HasLastAppliedSpec() bool {
+	return g.Status.LastAppliedSpec != nil
+}
+
+// SetLastAppliedSpec records the current spec as applied.
+func (g *VPNGateway) SetLastAppliedSpec() {
+	g.Status.LastAppliedSpec = g.Spec.DeepCopy()
+}
+
+// ResetLastAppliedSpec clears the applied spec.
+func (g *VPNGateway) ResetLastAppliedSpec() {
+	g.Status.LastAppliedSpec = nil
+}
+
+// SetBlockedBy sets the chain of unready resources the resource waits for.
+func (g *VPNGateway) SetBlockedBy(blockers []BlockingResource) {
+	g.Status.BlockedBy = blockers
+}
+
+// +kubebuilder:object:root=true
+
+// VPNGatewayList contains a list of VPNGateway
+type VPNGatewayList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []VPNGateway `json:"items"`
+}
+
+// GetItems returns the list of items as a slice of client.Object.
+func (vl *VPNGatewayList) GetItems() []client.Object {
+	items := make([]client.Object, len(vl.Items))
+	for i := range vl.Items {
+		items[i] = &vl.Items[i]
+	}
+	return items
+}
+
+func init() {
+	SchemeBuilder.Register(&VPNGateway{}, &VPNGatewayList{})
+}
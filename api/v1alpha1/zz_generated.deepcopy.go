@@ -5,13 +5,13 @@
 package v1alpha1
 
 import (
-	"k8s.io/api/core/v1"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *NATGateway) DeepCopyInto(out *NATGateway) {
+func (in *AlarmRule) DeepCopyInto(out *AlarmRule) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
@@ -19,18 +19,18 @@ func (in *NATGateway) DeepCopyInto(out *NATGateway) {
 	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NATGateway.
-func (in *NATGateway) DeepCopy() *NATGateway {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AlarmRule.
+func (in *AlarmRule) DeepCopy() *AlarmRule {
 	if in == nil {
 		return nil
 	}
-	out := new(NATGateway)
+	out := new(AlarmRule)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *NATGateway) DeepCopyObject() runtime.Object {
+func (in *AlarmRule) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -38,76 +38,71 @@ func (in *NATGateway) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *NATGatewayDependenciesResolved) DeepCopyInto(out *NATGatewayDependenciesResolved) {
+func (in *AlarmRuleCondition) DeepCopyInto(out *AlarmRuleCondition) {
 	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NATGatewayDependenciesResolved.
-func (in *NATGatewayDependenciesResolved) DeepCopy() *NATGatewayDependenciesResolved {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AlarmRuleCondition.
+func (in *AlarmRuleCondition) DeepCopy() *AlarmRuleCondition {
 	if in == nil {
 		return nil
 	}
-	out := new(NATGatewayDependenciesResolved)
+	out := new(AlarmRuleCondition)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *NATGatewayDependency) DeepCopyInto(out *NATGatewayDependency) {
+func (in *AlarmRuleDependenciesResolved) DeepCopyInto(out *AlarmRuleDependenciesResolved) {
 	*out = *in
-	if in.NATGatewayID != nil {
-		in, out := &in.NATGatewayID, &out.NATGatewayID
-		*out = new(string)
-		**out = **in
-	}
-	if in.NATGatewayRef != nil {
-		in, out := &in.NATGatewayRef, &out.NATGatewayRef
-		*out = new(v1.LocalObjectReference)
-		**out = **in
+	if in.AlarmTopicURNs != nil {
+		in, out := &in.AlarmTopicURNs, &out.AlarmTopicURNs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
-	if in.NATGatewaySelector != nil {
-		in, out := &in.NATGatewaySelector, &out.NATGatewaySelector
-		*out = new(metav1.LabelSelector)
-		(*in).DeepCopyInto(*out)
+	if in.OKTopicURNs != nil {
+		in, out := &in.OKTopicURNs, &out.OKTopicURNs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NATGatewayDependency.
-func (in *NATGatewayDependency) DeepCopy() *NATGatewayDependency {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AlarmRuleDependenciesResolved.
+func (in *AlarmRuleDependenciesResolved) DeepCopy() *AlarmRuleDependenciesResolved {
 	if in == nil {
 		return nil
 	}
-	out := new(NATGatewayDependency)
+	out := new(AlarmRuleDependenciesResolved)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *NATGatewayList) DeepCopyInto(out *NATGatewayList) {
+func (in *AlarmRuleList) DeepCopyInto(out *AlarmRuleList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]NATGateway, len(*in))
+		*out = make([]AlarmRule, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NATGatewayList.
-func (in *NATGatewayList) DeepCopy() *NATGatewayList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AlarmRuleList.
+func (in *AlarmRuleList) DeepCopy() *AlarmRuleList {
 	if in == nil {
 		return nil
 	}
-	out := new(NATGatewayList)
+	out := new(AlarmRuleList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *NATGatewayList) DeepCopyObject() runtime.Object {
+func (in *AlarmRuleList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -115,76 +110,146 @@ func (in *NATGatewayList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *NATGatewaySpec) DeepCopyInto(out *NATGatewaySpec) {
+func (in *AlarmRuleMetric) DeepCopyInto(out *AlarmRuleMetric) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AlarmRuleMetric.
+func (in *AlarmRuleMetric) DeepCopy() *AlarmRuleMetric {
+	if in == nil {
+		return nil
+	}
+	out := new(AlarmRuleMetric)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AlarmRuleResource) DeepCopyInto(out *AlarmRuleResource) {
+	*out = *in
+	if in.NATGateway != nil {
+		in, out := &in.NATGateway, &out.NATGateway
+		*out = new(NATGatewayDependency)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PublicIP != nil {
+		in, out := &in.PublicIP, &out.PublicIP
+		*out = new(PublicIPDependency)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Instance != nil {
+		in, out := &in.Instance, &out.Instance
+		*out = new(InstanceDependency)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AlarmRuleResource.
+func (in *AlarmRuleResource) DeepCopy() *AlarmRuleResource {
+	if in == nil {
+		return nil
+	}
+	out := new(AlarmRuleResource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AlarmRuleSpec) DeepCopyInto(out *AlarmRuleSpec) {
 	*out = *in
 	out.ProviderConfigRef = in.ProviderConfigRef
-	in.Network.DeepCopyInto(&out.Network)
-	in.Subnet.DeepCopyInto(&out.Subnet)
+	out.Metric = in.Metric
+	in.Resource.DeepCopyInto(&out.Resource)
+	out.Condition = in.Condition
+	if in.AlarmActions != nil {
+		in, out := &in.AlarmActions, &out.AlarmActions
+		*out = make([]TopicDependency, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.OKActions != nil {
+		in, out := &in.OKActions, &out.OKActions
+		*out = make([]TopicDependency, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.WriteConnectionDetailsToRef != nil {
+		in, out := &in.WriteConnectionDetailsToRef, &out.WriteConnectionDetailsToRef
+		*out = new(ConnectionDetailsReference)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NATGatewaySpec.
-func (in *NATGatewaySpec) DeepCopy() *NATGatewaySpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AlarmRuleSpec.
+func (in *AlarmRuleSpec) DeepCopy() *AlarmRuleSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(NATGatewaySpec)
+	out := new(AlarmRuleSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *NATGatewayStatus) DeepCopyInto(out *NATGatewayStatus) {
+func (in *AlarmRuleStatus) DeepCopyInto(out *AlarmRuleStatus) {
 	*out = *in
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
-		*out = make([]metav1.Condition, len(*in))
+		*out = make([]v1.Condition, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
-	out.ResolvedDependencies = in.ResolvedDependencies
+	if in.BlockedBy != nil {
+		in, out := &in.BlockedBy, &out.BlockedBy
+		*out = make([]BlockingResource, len(*in))
+		copy(*out, *in)
+	}
+	in.ResolvedDependencies.DeepCopyInto(&out.ResolvedDependencies)
 	if in.LastSyncTime != nil {
 		in, out := &in.LastSyncTime, &out.LastSyncTime
 		*out = (*in).DeepCopy()
 	}
 	if in.LastAppliedSpec != nil {
 		in, out := &in.LastAppliedSpec, &out.LastAppliedSpec
-		*out = new(NATGatewaySpec)
+		*out = new(AlarmRuleSpec)
 		(*in).DeepCopyInto(*out)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NATGatewayStatus.
-func (in *NATGatewayStatus) DeepCopy() *NATGatewayStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AlarmRuleStatus.
+func (in *AlarmRuleStatus) DeepCopy() *AlarmRuleStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(NATGatewayStatus)
+	out := new(AlarmRuleStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Network) DeepCopyInto(out *Network) {
+func (in *Bandwidth) DeepCopyInto(out *Bandwidth) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
+	in.Spec.DeepCopyInto(&out.Spec)
 	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Network.
-func (in *Network) DeepCopy() *Network {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Bandwidth.
+func (in *Bandwidth) DeepCopy() *Bandwidth {
 	if in == nil {
 		return nil
 	}
-	out := new(Network)
+	out := new(Bandwidth)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *Network) DeepCopyObject() runtime.Object {
+func (in *Bandwidth) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -192,61 +257,61 @@ func (in *Network) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *NetworkDependency) DeepCopyInto(out *NetworkDependency) {
+func (in *BandwidthDependency) DeepCopyInto(out *BandwidthDependency) {
 	*out = *in
-	if in.NetworkID != nil {
-		in, out := &in.NetworkID, &out.NetworkID
+	if in.BandwidthID != nil {
+		in, out := &in.BandwidthID, &out.BandwidthID
 		*out = new(string)
 		**out = **in
 	}
-	if in.NetworkRef != nil {
-		in, out := &in.NetworkRef, &out.NetworkRef
-		*out = new(v1.LocalObjectReference)
+	if in.BandwidthRef != nil {
+		in, out := &in.BandwidthRef, &out.BandwidthRef
+		*out = new(corev1.LocalObjectReference)
 		**out = **in
 	}
-	if in.NetworkSelector != nil {
-		in, out := &in.NetworkSelector, &out.NetworkSelector
-		*out = new(metav1.LabelSelector)
+	if in.BandwidthSelector != nil {
+		in, out := &in.BandwidthSelector, &out.BandwidthSelector
+		*out = new(ResourceSelector)
 		(*in).DeepCopyInto(*out)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkDependency.
-func (in *NetworkDependency) DeepCopy() *NetworkDependency {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BandwidthDependency.
+func (in *BandwidthDependency) DeepCopy() *BandwidthDependency {
 	if in == nil {
 		return nil
 	}
-	out := new(NetworkDependency)
+	out := new(BandwidthDependency)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *NetworkList) DeepCopyInto(out *NetworkList) {
+func (in *BandwidthList) DeepCopyInto(out *BandwidthList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]Network, len(*in))
+		*out = make([]Bandwidth, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkList.
-func (in *NetworkList) DeepCopy() *NetworkList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BandwidthList.
+func (in *BandwidthList) DeepCopy() *BandwidthList {
 	if in == nil {
 		return nil
 	}
-	out := new(NetworkList)
+	out := new(BandwidthList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *NetworkList) DeepCopyObject() runtime.Object {
+func (in *BandwidthList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -254,73 +319,103 @@ func (in *NetworkList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *NetworkSpec) DeepCopyInto(out *NetworkSpec) {
+func (in *BandwidthSpec) DeepCopyInto(out *BandwidthSpec) {
 	*out = *in
 	out.ProviderConfigRef = in.ProviderConfigRef
+	if in.WriteConnectionDetailsToRef != nil {
+		in, out := &in.WriteConnectionDetailsToRef, &out.WriteConnectionDetailsToRef
+		*out = new(ConnectionDetailsReference)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkSpec.
-func (in *NetworkSpec) DeepCopy() *NetworkSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BandwidthSpec.
+func (in *BandwidthSpec) DeepCopy() *BandwidthSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(NetworkSpec)
+	out := new(BandwidthSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *NetworkStatus) DeepCopyInto(out *NetworkStatus) {
+func (in *BandwidthStatus) DeepCopyInto(out *BandwidthStatus) {
 	*out = *in
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
-		*out = make([]metav1.Condition, len(*in))
+		*out = make([]v1.Condition, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.BlockedBy != nil {
+		in, out := &in.BlockedBy, &out.BlockedBy
+		*out = make([]BlockingResource, len(*in))
+		copy(*out, *in)
+	}
+	if in.PublicIPIDs != nil {
+		in, out := &in.PublicIPIDs, &out.PublicIPIDs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.LastSyncTime != nil {
 		in, out := &in.LastSyncTime, &out.LastSyncTime
 		*out = (*in).DeepCopy()
 	}
 	if in.LastAppliedSpec != nil {
 		in, out := &in.LastAppliedSpec, &out.LastAppliedSpec
-		*out = new(NetworkSpec)
-		**out = **in
+		*out = new(BandwidthSpec)
+		(*in).DeepCopyInto(*out)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkStatus.
-func (in *NetworkStatus) DeepCopy() *NetworkStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BandwidthStatus.
+func (in *BandwidthStatus) DeepCopy() *BandwidthStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(NetworkStatus)
+	out := new(BandwidthStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ProviderConfig) DeepCopyInto(out *ProviderConfig) {
+func (in *BlockingResource) DeepCopyInto(out *BlockingResource) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BlockingResource.
+func (in *BlockingResource) DeepCopy() *BlockingResource {
+	if in == nil {
+		return nil
+	}
+	out := new(BlockingResource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Bucket) DeepCopyInto(out *Bucket) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
+	in.Spec.DeepCopyInto(&out.Spec)
 	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProviderConfig.
-func (in *ProviderConfig) DeepCopy() *ProviderConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Bucket.
+func (in *Bucket) DeepCopy() *Bucket {
 	if in == nil {
 		return nil
 	}
-	out := new(ProviderConfig)
+	out := new(Bucket)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ProviderConfig) DeepCopyObject() runtime.Object {
+func (in *Bucket) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -328,31 +423,121 @@ func (in *ProviderConfig) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ProviderConfigList) DeepCopyInto(out *ProviderConfigList) {
+func (in *BucketCORSRule) DeepCopyInto(out *BucketCORSRule) {
+	*out = *in
+	if in.AllowedOrigins != nil {
+		in, out := &in.AllowedOrigins, &out.AllowedOrigins
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AllowedMethods != nil {
+		in, out := &in.AllowedMethods, &out.AllowedMethods
+		*out = make([]BucketCORSMethod, len(*in))
+		copy(*out, *in)
+	}
+	if in.AllowedHeaders != nil {
+		in, out := &in.AllowedHeaders, &out.AllowedHeaders
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExposeHeaders != nil {
+		in, out := &in.ExposeHeaders, &out.ExposeHeaders
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BucketCORSRule.
+func (in *BucketCORSRule) DeepCopy() *BucketCORSRule {
+	if in == nil {
+		return nil
+	}
+	out := new(BucketCORSRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BucketDependenciesResolved) DeepCopyInto(out *BucketDependenciesResolved) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BucketDependenciesResolved.
+func (in *BucketDependenciesResolved) DeepCopy() *BucketDependenciesResolved {
+	if in == nil {
+		return nil
+	}
+	out := new(BucketDependenciesResolved)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BucketEncryption) DeepCopyInto(out *BucketEncryption) {
+	*out = *in
+	if in.KMSKey != nil {
+		in, out := &in.KMSKey, &out.KMSKey
+		*out = new(KMSKeyDependency)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BucketEncryption.
+func (in *BucketEncryption) DeepCopy() *BucketEncryption {
+	if in == nil {
+		return nil
+	}
+	out := new(BucketEncryption)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BucketLifecycleRule) DeepCopyInto(out *BucketLifecycleRule) {
+	*out = *in
+	if in.Transitions != nil {
+		in, out := &in.Transitions, &out.Transitions
+		*out = make([]BucketTransition, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BucketLifecycleRule.
+func (in *BucketLifecycleRule) DeepCopy() *BucketLifecycleRule {
+	if in == nil {
+		return nil
+	}
+	out := new(BucketLifecycleRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BucketList) DeepCopyInto(out *BucketList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]ProviderConfig, len(*in))
+		*out = make([]Bucket, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProviderConfigList.
-func (in *ProviderConfigList) DeepCopy() *ProviderConfigList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BucketList.
+func (in *BucketList) DeepCopy() *BucketList {
 	if in == nil {
 		return nil
 	}
-	out := new(ProviderConfigList)
+	out := new(BucketList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ProviderConfigList) DeepCopyObject() runtime.Object {
+func (in *BucketList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -360,83 +545,118 @@ func (in *ProviderConfigList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ProviderConfigReference) DeepCopyInto(out *ProviderConfigReference) {
+func (in *BucketSpec) DeepCopyInto(out *BucketSpec) {
 	*out = *in
+	out.ProviderConfigRef = in.ProviderConfigRef
+	if in.LifecycleRules != nil {
+		in, out := &in.LifecycleRules, &out.LifecycleRules
+		*out = make([]BucketLifecycleRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Encryption != nil {
+		in, out := &in.Encryption, &out.Encryption
+		*out = new(BucketEncryption)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CORSRules != nil {
+		in, out := &in.CORSRules, &out.CORSRules
+		*out = make([]BucketCORSRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.WriteConnectionDetailsToRef != nil {
+		in, out := &in.WriteConnectionDetailsToRef, &out.WriteConnectionDetailsToRef
+		*out = new(ConnectionDetailsReference)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProviderConfigReference.
-func (in *ProviderConfigReference) DeepCopy() *ProviderConfigReference {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BucketSpec.
+func (in *BucketSpec) DeepCopy() *BucketSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ProviderConfigReference)
+	out := new(BucketSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ProviderConfigSpec) DeepCopyInto(out *ProviderConfigSpec) {
+func (in *BucketStatus) DeepCopyInto(out *BucketStatus) {
 	*out = *in
-	out.CredentialsSecretRef = in.CredentialsSecretRef
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.BlockedBy != nil {
+		in, out := &in.BlockedBy, &out.BlockedBy
+		*out = make([]BlockingResource, len(*in))
+		copy(*out, *in)
+	}
+	out.ResolvedDependencies = in.ResolvedDependencies
+	if in.LastSyncTime != nil {
+		in, out := &in.LastSyncTime, &out.LastSyncTime
+		*out = (*in).DeepCopy()
+	}
+	if in.LastAppliedSpec != nil {
+		in, out := &in.LastAppliedSpec, &out.LastAppliedSpec
+		*out = new(BucketSpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProviderConfigSpec.
-func (in *ProviderConfigSpec) DeepCopy() *ProviderConfigSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BucketStatus.
+func (in *BucketStatus) DeepCopy() *BucketStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(ProviderConfigSpec)
+	out := new(BucketStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ProviderConfigStatus) DeepCopyInto(out *ProviderConfigStatus) {
+func (in *BucketTransition) DeepCopyInto(out *BucketTransition) {
 	*out = *in
-	if in.Conditions != nil {
-		in, out := &in.Conditions, &out.Conditions
-		*out = make([]metav1.Condition, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
-	if in.LastValidationTime != nil {
-		in, out := &in.LastValidationTime, &out.LastValidationTime
-		*out = (*in).DeepCopy()
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProviderConfigStatus.
-func (in *ProviderConfigStatus) DeepCopy() *ProviderConfigStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BucketTransition.
+func (in *BucketTransition) DeepCopy() *BucketTransition {
 	if in == nil {
 		return nil
 	}
-	out := new(ProviderConfigStatus)
+	out := new(BucketTransition)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PublicIP) DeepCopyInto(out *PublicIP) {
+func (in *Cluster) DeepCopyInto(out *Cluster) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
+	in.Spec.DeepCopyInto(&out.Spec)
 	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PublicIP.
-func (in *PublicIP) DeepCopy() *PublicIP {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Cluster.
+func (in *Cluster) DeepCopy() *Cluster {
 	if in == nil {
 		return nil
 	}
-	out := new(PublicIP)
+	out := new(Cluster)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *PublicIP) DeepCopyObject() runtime.Object {
+func (in *Cluster) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -444,61 +664,3553 @@ func (in *PublicIP) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PublicIPDependency) DeepCopyInto(out *PublicIPDependency) {
+func (in *ClusterContainerNetwork) DeepCopyInto(out *ClusterContainerNetwork) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterContainerNetwork.
+func (in *ClusterContainerNetwork) DeepCopy() *ClusterContainerNetwork {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterContainerNetwork)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterDependenciesResolved) DeepCopyInto(out *ClusterDependenciesResolved) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterDependenciesResolved.
+func (in *ClusterDependenciesResolved) DeepCopy() *ClusterDependenciesResolved {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterDependenciesResolved)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterDependency) DeepCopyInto(out *ClusterDependency) {
+	*out = *in
+	if in.ClusterID != nil {
+		in, out := &in.ClusterID, &out.ClusterID
+		*out = new(string)
+		**out = **in
+	}
+	if in.ClusterRef != nil {
+		in, out := &in.ClusterRef, &out.ClusterRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+	if in.ClusterSelector != nil {
+		in, out := &in.ClusterSelector, &out.ClusterSelector
+		*out = new(ResourceSelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterDependency.
+func (in *ClusterDependency) DeepCopy() *ClusterDependency {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterDependency)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterList) DeepCopyInto(out *ClusterList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Cluster, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterList.
+func (in *ClusterList) DeepCopy() *ClusterList {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterSpec) DeepCopyInto(out *ClusterSpec) {
+	*out = *in
+	out.ProviderConfigRef = in.ProviderConfigRef
+	in.Network.DeepCopyInto(&out.Network)
+	in.Subnet.DeepCopyInto(&out.Subnet)
+	if in.SecurityGroup != nil {
+		in, out := &in.SecurityGroup, &out.SecurityGroup
+		*out = new(SecurityGroupDependency)
+		(*in).DeepCopyInto(*out)
+	}
+	out.ContainerNetwork = in.ContainerNetwork
+	if in.WriteKubeconfigToRef != nil {
+		in, out := &in.WriteKubeconfigToRef, &out.WriteKubeconfigToRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+	if in.WriteConnectionDetailsToRef != nil {
+		in, out := &in.WriteConnectionDetailsToRef, &out.WriteConnectionDetailsToRef
+		*out = new(ConnectionDetailsReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterSpec.
+func (in *ClusterSpec) DeepCopy() *ClusterSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterStatus) DeepCopyInto(out *ClusterStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.BlockedBy != nil {
+		in, out := &in.BlockedBy, &out.BlockedBy
+		*out = make([]BlockingResource, len(*in))
+		copy(*out, *in)
+	}
+	out.ResolvedDependencies = in.ResolvedDependencies
+	if in.LastSyncTime != nil {
+		in, out := &in.LastSyncTime, &out.LastSyncTime
+		*out = (*in).DeepCopy()
+	}
+	if in.LastAppliedSpec != nil {
+		in, out := &in.LastAppliedSpec, &out.LastAppliedSpec
+		*out = new(ClusterSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterStatus.
+func (in *ClusterStatus) DeepCopy() *ClusterStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConnectionDetailsReference) DeepCopyInto(out *ConnectionDetailsReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConnectionDetailsReference.
+func (in *ConnectionDetailsReference) DeepCopy() *ConnectionDetailsReference {
+	if in == nil {
+		return nil
+	}
+	out := new(ConnectionDetailsReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CustomerGateway) DeepCopyInto(out *CustomerGateway) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CustomerGateway.
+func (in *CustomerGateway) DeepCopy() *CustomerGateway {
+	if in == nil {
+		return nil
+	}
+	out := new(CustomerGateway)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CustomerGateway) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CustomerGatewayDependency) DeepCopyInto(out *CustomerGatewayDependency) {
+	*out = *in
+	if in.CustomerGatewayID != nil {
+		in, out := &in.CustomerGatewayID, &out.CustomerGatewayID
+		*out = new(string)
+		**out = **in
+	}
+	if in.CustomerGatewayRef != nil {
+		in, out := &in.CustomerGatewayRef, &out.CustomerGatewayRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+	if in.CustomerGatewaySelector != nil {
+		in, out := &in.CustomerGatewaySelector, &out.CustomerGatewaySelector
+		*out = new(ResourceSelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CustomerGatewayDependency.
+func (in *CustomerGatewayDependency) DeepCopy() *CustomerGatewayDependency {
+	if in == nil {
+		return nil
+	}
+	out := new(CustomerGatewayDependency)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CustomerGatewayList) DeepCopyInto(out *CustomerGatewayList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]CustomerGateway, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CustomerGatewayList.
+func (in *CustomerGatewayList) DeepCopy() *CustomerGatewayList {
+	if in == nil {
+		return nil
+	}
+	out := new(CustomerGatewayList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CustomerGatewayList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CustomerGatewaySpec) DeepCopyInto(out *CustomerGatewaySpec) {
+	*out = *in
+	out.ProviderConfigRef = in.ProviderConfigRef
+	if in.WriteConnectionDetailsToRef != nil {
+		in, out := &in.WriteConnectionDetailsToRef, &out.WriteConnectionDetailsToRef
+		*out = new(ConnectionDetailsReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CustomerGatewaySpec.
+func (in *CustomerGatewaySpec) DeepCopy() *CustomerGatewaySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CustomerGatewaySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CustomerGatewayStatus) DeepCopyInto(out *CustomerGatewayStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.BlockedBy != nil {
+		in, out := &in.BlockedBy, &out.BlockedBy
+		*out = make([]BlockingResource, len(*in))
+		copy(*out, *in)
+	}
+	if in.LastSyncTime != nil {
+		in, out := &in.LastSyncTime, &out.LastSyncTime
+		*out = (*in).DeepCopy()
+	}
+	if in.LastAppliedSpec != nil {
+		in, out := &in.LastAppliedSpec, &out.LastAppliedSpec
+		*out = new(CustomerGatewaySpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CustomerGatewayStatus.
+func (in *CustomerGatewayStatus) DeepCopy() *CustomerGatewayStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CustomerGatewayStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSZone) DeepCopyInto(out *DNSZone) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSZone.
+func (in *DNSZone) DeepCopy() *DNSZone {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSZone)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DNSZone) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSZoneDependenciesResolved) DeepCopyInto(out *DNSZoneDependenciesResolved) {
+	*out = *in
+	if in.NetworkIDs != nil {
+		in, out := &in.NetworkIDs, &out.NetworkIDs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSZoneDependenciesResolved.
+func (in *DNSZoneDependenciesResolved) DeepCopy() *DNSZoneDependenciesResolved {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSZoneDependenciesResolved)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSZoneDependency) DeepCopyInto(out *DNSZoneDependency) {
+	*out = *in
+	if in.DNSZoneID != nil {
+		in, out := &in.DNSZoneID, &out.DNSZoneID
+		*out = new(string)
+		**out = **in
+	}
+	if in.DNSZoneRef != nil {
+		in, out := &in.DNSZoneRef, &out.DNSZoneRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+	if in.DNSZoneSelector != nil {
+		in, out := &in.DNSZoneSelector, &out.DNSZoneSelector
+		*out = new(ResourceSelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSZoneDependency.
+func (in *DNSZoneDependency) DeepCopy() *DNSZoneDependency {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSZoneDependency)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSZoneList) DeepCopyInto(out *DNSZoneList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]DNSZone, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSZoneList.
+func (in *DNSZoneList) DeepCopy() *DNSZoneList {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSZoneList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DNSZoneList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSZoneSpec) DeepCopyInto(out *DNSZoneSpec) {
+	*out = *in
+	out.ProviderConfigRef = in.ProviderConfigRef
+	if in.Networks != nil {
+		in, out := &in.Networks, &out.Networks
+		*out = make([]NetworkDependency, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.WriteConnectionDetailsToRef != nil {
+		in, out := &in.WriteConnectionDetailsToRef, &out.WriteConnectionDetailsToRef
+		*out = new(ConnectionDetailsReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSZoneSpec.
+func (in *DNSZoneSpec) DeepCopy() *DNSZoneSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSZoneSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSZoneStatus) DeepCopyInto(out *DNSZoneStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.BlockedBy != nil {
+		in, out := &in.BlockedBy, &out.BlockedBy
+		*out = make([]BlockingResource, len(*in))
+		copy(*out, *in)
+	}
+	in.ResolvedDependencies.DeepCopyInto(&out.ResolvedDependencies)
+	if in.AssociatedNetworkIDs != nil {
+		in, out := &in.AssociatedNetworkIDs, &out.AssociatedNetworkIDs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.LastSyncTime != nil {
+		in, out := &in.LastSyncTime, &out.LastSyncTime
+		*out = (*in).DeepCopy()
+	}
+	if in.LastAppliedSpec != nil {
+		in, out := &in.LastAppliedSpec, &out.LastAppliedSpec
+		*out = new(DNSZoneSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSZoneStatus.
+func (in *DNSZoneStatus) DeepCopy() *DNSZoneStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSZoneStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DatabaseBackupPolicy) DeepCopyInto(out *DatabaseBackupPolicy) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatabaseBackupPolicy.
+func (in *DatabaseBackupPolicy) DeepCopy() *DatabaseBackupPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(DatabaseBackupPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DatabaseInstance) DeepCopyInto(out *DatabaseInstance) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatabaseInstance.
+func (in *DatabaseInstance) DeepCopy() *DatabaseInstance {
+	if in == nil {
+		return nil
+	}
+	out := new(DatabaseInstance)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DatabaseInstance) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DatabaseInstanceDependenciesResolved) DeepCopyInto(out *DatabaseInstanceDependenciesResolved) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatabaseInstanceDependenciesResolved.
+func (in *DatabaseInstanceDependenciesResolved) DeepCopy() *DatabaseInstanceDependenciesResolved {
+	if in == nil {
+		return nil
+	}
+	out := new(DatabaseInstanceDependenciesResolved)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DatabaseInstanceList) DeepCopyInto(out *DatabaseInstanceList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]DatabaseInstance, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatabaseInstanceList.
+func (in *DatabaseInstanceList) DeepCopy() *DatabaseInstanceList {
+	if in == nil {
+		return nil
+	}
+	out := new(DatabaseInstanceList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DatabaseInstanceList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DatabaseInstanceSpec) DeepCopyInto(out *DatabaseInstanceSpec) {
+	*out = *in
+	out.ProviderConfigRef = in.ProviderConfigRef
+	in.Volume.DeepCopyInto(&out.Volume)
+	if in.AvailabilityZones != nil {
+		in, out := &in.AvailabilityZones, &out.AvailabilityZones
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Port != nil {
+		in, out := &in.Port, &out.Port
+		*out = new(int32)
+		**out = **in
+	}
+	out.BackupPolicy = in.BackupPolicy
+	in.AdminPasswordSecretRef.DeepCopyInto(&out.AdminPasswordSecretRef)
+	in.Network.DeepCopyInto(&out.Network)
+	in.Subnet.DeepCopyInto(&out.Subnet)
+	in.SecurityGroup.DeepCopyInto(&out.SecurityGroup)
+	if in.WriteConnectionDetailsToRef != nil {
+		in, out := &in.WriteConnectionDetailsToRef, &out.WriteConnectionDetailsToRef
+		*out = new(ConnectionDetailsReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatabaseInstanceSpec.
+func (in *DatabaseInstanceSpec) DeepCopy() *DatabaseInstanceSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DatabaseInstanceSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DatabaseInstanceStatus) DeepCopyInto(out *DatabaseInstanceStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.BlockedBy != nil {
+		in, out := &in.BlockedBy, &out.BlockedBy
+		*out = make([]BlockingResource, len(*in))
+		copy(*out, *in)
+	}
+	out.ResolvedDependencies = in.ResolvedDependencies
+	if in.LastSyncTime != nil {
+		in, out := &in.LastSyncTime, &out.LastSyncTime
+		*out = (*in).DeepCopy()
+	}
+	if in.LastAppliedSpec != nil {
+		in, out := &in.LastAppliedSpec, &out.LastAppliedSpec
+		*out = new(DatabaseInstanceSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatabaseInstanceStatus.
+func (in *DatabaseInstanceStatus) DeepCopy() *DatabaseInstanceStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DatabaseInstanceStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DatabaseVolume) DeepCopyInto(out *DatabaseVolume) {
+	*out = *in
+	if in.EncryptionKey != nil {
+		in, out := &in.EncryptionKey, &out.EncryptionKey
+		*out = new(KMSKeyDependency)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatabaseVolume.
+func (in *DatabaseVolume) DeepCopy() *DatabaseVolume {
+	if in == nil {
+		return nil
+	}
+	out := new(DatabaseVolume)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IKEPolicy) DeepCopyInto(out *IKEPolicy) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IKEPolicy.
+func (in *IKEPolicy) DeepCopy() *IKEPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(IKEPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IPsecPolicy) DeepCopyInto(out *IPsecPolicy) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IPsecPolicy.
+func (in *IPsecPolicy) DeepCopy() *IPsecPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(IPsecPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Instance) DeepCopyInto(out *Instance) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Instance.
+func (in *Instance) DeepCopy() *Instance {
+	if in == nil {
+		return nil
+	}
+	out := new(Instance)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Instance) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InstanceDataVolume) DeepCopyInto(out *InstanceDataVolume) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InstanceDataVolume.
+func (in *InstanceDataVolume) DeepCopy() *InstanceDataVolume {
+	if in == nil {
+		return nil
+	}
+	out := new(InstanceDataVolume)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InstanceDependenciesResolved) DeepCopyInto(out *InstanceDependenciesResolved) {
+	*out = *in
+	if in.SubnetIDs != nil {
+		in, out := &in.SubnetIDs, &out.SubnetIDs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.SecurityGroupIDs != nil {
+		in, out := &in.SecurityGroupIDs, &out.SecurityGroupIDs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InstanceDependenciesResolved.
+func (in *InstanceDependenciesResolved) DeepCopy() *InstanceDependenciesResolved {
+	if in == nil {
+		return nil
+	}
+	out := new(InstanceDependenciesResolved)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InstanceDependency) DeepCopyInto(out *InstanceDependency) {
+	*out = *in
+	if in.InstanceID != nil {
+		in, out := &in.InstanceID, &out.InstanceID
+		*out = new(string)
+		**out = **in
+	}
+	if in.InstanceRef != nil {
+		in, out := &in.InstanceRef, &out.InstanceRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+	if in.InstanceSelector != nil {
+		in, out := &in.InstanceSelector, &out.InstanceSelector
+		*out = new(ResourceSelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InstanceDependency.
+func (in *InstanceDependency) DeepCopy() *InstanceDependency {
+	if in == nil {
+		return nil
+	}
+	out := new(InstanceDependency)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InstanceInterface) DeepCopyInto(out *InstanceInterface) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InstanceInterface.
+func (in *InstanceInterface) DeepCopy() *InstanceInterface {
+	if in == nil {
+		return nil
+	}
+	out := new(InstanceInterface)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InstanceList) DeepCopyInto(out *InstanceList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Instance, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InstanceList.
+func (in *InstanceList) DeepCopy() *InstanceList {
+	if in == nil {
+		return nil
+	}
+	out := new(InstanceList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *InstanceList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InstanceNIC) DeepCopyInto(out *InstanceNIC) {
+	*out = *in
+	in.Subnet.DeepCopyInto(&out.Subnet)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InstanceNIC.
+func (in *InstanceNIC) DeepCopy() *InstanceNIC {
+	if in == nil {
+		return nil
+	}
+	out := new(InstanceNIC)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InstanceRootVolume) DeepCopyInto(out *InstanceRootVolume) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InstanceRootVolume.
+func (in *InstanceRootVolume) DeepCopy() *InstanceRootVolume {
+	if in == nil {
+		return nil
+	}
+	out := new(InstanceRootVolume)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InstanceSpec) DeepCopyInto(out *InstanceSpec) {
+	*out = *in
+	out.ProviderConfigRef = in.ProviderConfigRef
+	if in.KeyPair != nil {
+		in, out := &in.KeyPair, &out.KeyPair
+		*out = new(KeyPairDependency)
+		(*in).DeepCopyInto(*out)
+	}
+	out.RootVolume = in.RootVolume
+	if in.DataVolumes != nil {
+		in, out := &in.DataVolumes, &out.DataVolumes
+		*out = make([]InstanceDataVolume, len(*in))
+		copy(*out, *in)
+	}
+	if in.UserDataSecretRef != nil {
+		in, out := &in.UserDataSecretRef, &out.UserDataSecretRef
+		*out = new(corev1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NICs != nil {
+		in, out := &in.NICs, &out.NICs
+		*out = make([]InstanceNIC, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.SecurityGroups != nil {
+		in, out := &in.SecurityGroups, &out.SecurityGroups
+		*out = make([]SecurityGroupDependency, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.PublicIP != nil {
+		in, out := &in.PublicIP, &out.PublicIP
+		*out = new(PublicIPDependency)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.WriteConnectionDetailsToRef != nil {
+		in, out := &in.WriteConnectionDetailsToRef, &out.WriteConnectionDetailsToRef
+		*out = new(ConnectionDetailsReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InstanceSpec.
+func (in *InstanceSpec) DeepCopy() *InstanceSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(InstanceSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InstanceStatus) DeepCopyInto(out *InstanceStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.BlockedBy != nil {
+		in, out := &in.BlockedBy, &out.BlockedBy
+		*out = make([]BlockingResource, len(*in))
+		copy(*out, *in)
+	}
+	in.ResolvedDependencies.DeepCopyInto(&out.ResolvedDependencies)
+	if in.Interfaces != nil {
+		in, out := &in.Interfaces, &out.Interfaces
+		*out = make([]InstanceInterface, len(*in))
+		copy(*out, *in)
+	}
+	if in.DataVolumeIDs != nil {
+		in, out := &in.DataVolumeIDs, &out.DataVolumeIDs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.LastSyncTime != nil {
+		in, out := &in.LastSyncTime, &out.LastSyncTime
+		*out = (*in).DeepCopy()
+	}
+	if in.LastAppliedSpec != nil {
+		in, out := &in.LastAppliedSpec, &out.LastAppliedSpec
+		*out = new(InstanceSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InstanceStatus.
+func (in *InstanceStatus) DeepCopy() *InstanceStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(InstanceStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KMSKey) DeepCopyInto(out *KMSKey) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KMSKey.
+func (in *KMSKey) DeepCopy() *KMSKey {
+	if in == nil {
+		return nil
+	}
+	out := new(KMSKey)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *KMSKey) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KMSKeyDependency) DeepCopyInto(out *KMSKeyDependency) {
+	*out = *in
+	if in.KMSKeyID != nil {
+		in, out := &in.KMSKeyID, &out.KMSKeyID
+		*out = new(string)
+		**out = **in
+	}
+	if in.KMSKeyRef != nil {
+		in, out := &in.KMSKeyRef, &out.KMSKeyRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+	if in.KMSKeySelector != nil {
+		in, out := &in.KMSKeySelector, &out.KMSKeySelector
+		*out = new(ResourceSelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KMSKeyDependency.
+func (in *KMSKeyDependency) DeepCopy() *KMSKeyDependency {
+	if in == nil {
+		return nil
+	}
+	out := new(KMSKeyDependency)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KMSKeyList) DeepCopyInto(out *KMSKeyList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]KMSKey, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KMSKeyList.
+func (in *KMSKeyList) DeepCopy() *KMSKeyList {
+	if in == nil {
+		return nil
+	}
+	out := new(KMSKeyList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *KMSKeyList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KMSKeySpec) DeepCopyInto(out *KMSKeySpec) {
+	*out = *in
+	out.ProviderConfigRef = in.ProviderConfigRef
+	if in.WriteConnectionDetailsToRef != nil {
+		in, out := &in.WriteConnectionDetailsToRef, &out.WriteConnectionDetailsToRef
+		*out = new(ConnectionDetailsReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KMSKeySpec.
+func (in *KMSKeySpec) DeepCopy() *KMSKeySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(KMSKeySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KMSKeyStatus) DeepCopyInto(out *KMSKeyStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.BlockedBy != nil {
+		in, out := &in.BlockedBy, &out.BlockedBy
+		*out = make([]BlockingResource, len(*in))
+		copy(*out, *in)
+	}
+	if in.LastSyncTime != nil {
+		in, out := &in.LastSyncTime, &out.LastSyncTime
+		*out = (*in).DeepCopy()
+	}
+	if in.LastAppliedSpec != nil {
+		in, out := &in.LastAppliedSpec, &out.LastAppliedSpec
+		*out = new(KMSKeySpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KMSKeyStatus.
+func (in *KMSKeyStatus) DeepCopy() *KMSKeyStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(KMSKeyStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeyPair) DeepCopyInto(out *KeyPair) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeyPair.
+func (in *KeyPair) DeepCopy() *KeyPair {
+	if in == nil {
+		return nil
+	}
+	out := new(KeyPair)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *KeyPair) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeyPairDependency) DeepCopyInto(out *KeyPairDependency) {
+	*out = *in
+	if in.KeyPairName != nil {
+		in, out := &in.KeyPairName, &out.KeyPairName
+		*out = new(string)
+		**out = **in
+	}
+	if in.KeyPairRef != nil {
+		in, out := &in.KeyPairRef, &out.KeyPairRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+	if in.KeyPairSelector != nil {
+		in, out := &in.KeyPairSelector, &out.KeyPairSelector
+		*out = new(ResourceSelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeyPairDependency.
+func (in *KeyPairDependency) DeepCopy() *KeyPairDependency {
+	if in == nil {
+		return nil
+	}
+	out := new(KeyPairDependency)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeyPairList) DeepCopyInto(out *KeyPairList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]KeyPair, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeyPairList.
+func (in *KeyPairList) DeepCopy() *KeyPairList {
+	if in == nil {
+		return nil
+	}
+	out := new(KeyPairList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *KeyPairList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeyPairSpec) DeepCopyInto(out *KeyPairSpec) {
+	*out = *in
+	out.ProviderConfigRef = in.ProviderConfigRef
+	if in.PublicKeySecretRef != nil {
+		in, out := &in.PublicKeySecretRef, &out.PublicKeySecretRef
+		*out = new(corev1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PrivateKeySecretRef != nil {
+		in, out := &in.PrivateKeySecretRef, &out.PrivateKeySecretRef
+		*out = new(corev1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.WriteConnectionDetailsToRef != nil {
+		in, out := &in.WriteConnectionDetailsToRef, &out.WriteConnectionDetailsToRef
+		*out = new(ConnectionDetailsReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeyPairSpec.
+func (in *KeyPairSpec) DeepCopy() *KeyPairSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(KeyPairSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeyPairStatus) DeepCopyInto(out *KeyPairStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.BlockedBy != nil {
+		in, out := &in.BlockedBy, &out.BlockedBy
+		*out = make([]BlockingResource, len(*in))
+		copy(*out, *in)
+	}
+	if in.LastSyncTime != nil {
+		in, out := &in.LastSyncTime, &out.LastSyncTime
+		*out = (*in).DeepCopy()
+	}
+	if in.LastAppliedSpec != nil {
+		in, out := &in.LastAppliedSpec, &out.LastAppliedSpec
+		*out = new(KeyPairSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeyPairStatus.
+func (in *KeyPairStatus) DeepCopy() *KeyPairStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(KeyPairStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NATGateway) DeepCopyInto(out *NATGateway) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NATGateway.
+func (in *NATGateway) DeepCopy() *NATGateway {
+	if in == nil {
+		return nil
+	}
+	out := new(NATGateway)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *NATGateway) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NATGatewayDependenciesResolved) DeepCopyInto(out *NATGatewayDependenciesResolved) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NATGatewayDependenciesResolved.
+func (in *NATGatewayDependenciesResolved) DeepCopy() *NATGatewayDependenciesResolved {
+	if in == nil {
+		return nil
+	}
+	out := new(NATGatewayDependenciesResolved)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NATGatewayDependency) DeepCopyInto(out *NATGatewayDependency) {
+	*out = *in
+	if in.NATGatewayID != nil {
+		in, out := &in.NATGatewayID, &out.NATGatewayID
+		*out = new(string)
+		**out = **in
+	}
+	if in.NATGatewayRef != nil {
+		in, out := &in.NATGatewayRef, &out.NATGatewayRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+	if in.NATGatewaySelector != nil {
+		in, out := &in.NATGatewaySelector, &out.NATGatewaySelector
+		*out = new(ResourceSelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NATGatewayDependency.
+func (in *NATGatewayDependency) DeepCopy() *NATGatewayDependency {
+	if in == nil {
+		return nil
+	}
+	out := new(NATGatewayDependency)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NATGatewayList) DeepCopyInto(out *NATGatewayList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]NATGateway, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NATGatewayList.
+func (in *NATGatewayList) DeepCopy() *NATGatewayList {
+	if in == nil {
+		return nil
+	}
+	out := new(NATGatewayList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *NATGatewayList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NATGatewaySpec) DeepCopyInto(out *NATGatewaySpec) {
+	*out = *in
+	out.ProviderConfigRef = in.ProviderConfigRef
+	in.Network.DeepCopyInto(&out.Network)
+	in.Subnet.DeepCopyInto(&out.Subnet)
+	if in.WriteConnectionDetailsToRef != nil {
+		in, out := &in.WriteConnectionDetailsToRef, &out.WriteConnectionDetailsToRef
+		*out = new(ConnectionDetailsReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NATGatewaySpec.
+func (in *NATGatewaySpec) DeepCopy() *NATGatewaySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(NATGatewaySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NATGatewayStatus) DeepCopyInto(out *NATGatewayStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.BlockedBy != nil {
+		in, out := &in.BlockedBy, &out.BlockedBy
+		*out = make([]BlockingResource, len(*in))
+		copy(*out, *in)
+	}
+	out.ResolvedDependencies = in.ResolvedDependencies
+	if in.LastSyncTime != nil {
+		in, out := &in.LastSyncTime, &out.LastSyncTime
+		*out = (*in).DeepCopy()
+	}
+	if in.LastAppliedSpec != nil {
+		in, out := &in.LastAppliedSpec, &out.LastAppliedSpec
+		*out = new(NATGatewaySpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NATGatewayStatus.
+func (in *NATGatewayStatus) DeepCopy() *NATGatewayStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(NATGatewayStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NamespacedObjectReference) DeepCopyInto(out *NamespacedObjectReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NamespacedObjectReference.
+func (in *NamespacedObjectReference) DeepCopy() *NamespacedObjectReference {
+	if in == nil {
+		return nil
+	}
+	out := new(NamespacedObjectReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Network) DeepCopyInto(out *Network) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Network.
+func (in *Network) DeepCopy() *Network {
+	if in == nil {
+		return nil
+	}
+	out := new(Network)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Network) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkDependency) DeepCopyInto(out *NetworkDependency) {
+	*out = *in
+	if in.NetworkID != nil {
+		in, out := &in.NetworkID, &out.NetworkID
+		*out = new(string)
+		**out = **in
+	}
+	if in.NetworkRef != nil {
+		in, out := &in.NetworkRef, &out.NetworkRef
+		*out = new(NamespacedObjectReference)
+		**out = **in
+	}
+	if in.NetworkSelector != nil {
+		in, out := &in.NetworkSelector, &out.NetworkSelector
+		*out = new(ResourceSelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkDependency.
+func (in *NetworkDependency) DeepCopy() *NetworkDependency {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkDependency)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkList) DeepCopyInto(out *NetworkList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Network, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkList.
+func (in *NetworkList) DeepCopy() *NetworkList {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *NetworkList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkSpec) DeepCopyInto(out *NetworkSpec) {
+	*out = *in
+	out.ProviderConfigRef = in.ProviderConfigRef
+	if in.WriteConnectionDetailsToRef != nil {
+		in, out := &in.WriteConnectionDetailsToRef, &out.WriteConnectionDetailsToRef
+		*out = new(ConnectionDetailsReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkSpec.
+func (in *NetworkSpec) DeepCopy() *NetworkSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkStack) DeepCopyInto(out *NetworkStack) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkStack.
+func (in *NetworkStack) DeepCopy() *NetworkStack {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkStack)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *NetworkStack) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkStackList) DeepCopyInto(out *NetworkStackList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]NetworkStack, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkStackList.
+func (in *NetworkStackList) DeepCopy() *NetworkStackList {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkStackList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *NetworkStackList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkStackNATGateway) DeepCopyInto(out *NetworkStackNATGateway) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkStackNATGateway.
+func (in *NetworkStackNATGateway) DeepCopy() *NetworkStackNATGateway {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkStackNATGateway)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkStackResource) DeepCopyInto(out *NetworkStackResource) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkStackResource.
+func (in *NetworkStackResource) DeepCopy() *NetworkStackResource {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkStackResource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkStackSpec) DeepCopyInto(out *NetworkStackSpec) {
+	*out = *in
+	out.ProviderConfigRef = in.ProviderConfigRef
+	if in.Subnets != nil {
+		in, out := &in.Subnets, &out.Subnets
+		*out = make([]NetworkStackSubnet, len(*in))
+		copy(*out, *in)
+	}
+	if in.NATGateway != nil {
+		in, out := &in.NATGateway, &out.NATGateway
+		*out = new(NetworkStackNATGateway)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkStackSpec.
+func (in *NetworkStackSpec) DeepCopy() *NetworkStackSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkStackSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkStackStatus) DeepCopyInto(out *NetworkStackStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = make([]NetworkStackResource, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkStackStatus.
+func (in *NetworkStackStatus) DeepCopy() *NetworkStackStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkStackStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkStackSubnet) DeepCopyInto(out *NetworkStackSubnet) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkStackSubnet.
+func (in *NetworkStackSubnet) DeepCopy() *NetworkStackSubnet {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkStackSubnet)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkStatus) DeepCopyInto(out *NetworkStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.BlockedBy != nil {
+		in, out := &in.BlockedBy, &out.BlockedBy
+		*out = make([]BlockingResource, len(*in))
+		copy(*out, *in)
+	}
+	if in.LastSyncTime != nil {
+		in, out := &in.LastSyncTime, &out.LastSyncTime
+		*out = (*in).DeepCopy()
+	}
+	if in.LastAppliedSpec != nil {
+		in, out := &in.LastAppliedSpec, &out.LastAppliedSpec
+		*out = new(NetworkSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkStatus.
+func (in *NetworkStatus) DeepCopy() *NetworkStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodePool) DeepCopyInto(out *NodePool) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodePool.
+func (in *NodePool) DeepCopy() *NodePool {
+	if in == nil {
+		return nil
+	}
+	out := new(NodePool)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *NodePool) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodePoolAutoscaling) DeepCopyInto(out *NodePoolAutoscaling) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodePoolAutoscaling.
+func (in *NodePoolAutoscaling) DeepCopy() *NodePoolAutoscaling {
+	if in == nil {
+		return nil
+	}
+	out := new(NodePoolAutoscaling)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodePoolDependenciesResolved) DeepCopyInto(out *NodePoolDependenciesResolved) {
+	*out = *in
+	if in.SecurityGroupIDs != nil {
+		in, out := &in.SecurityGroupIDs, &out.SecurityGroupIDs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodePoolDependenciesResolved.
+func (in *NodePoolDependenciesResolved) DeepCopy() *NodePoolDependenciesResolved {
+	if in == nil {
+		return nil
+	}
+	out := new(NodePoolDependenciesResolved)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodePoolList) DeepCopyInto(out *NodePoolList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]NodePool, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodePoolList.
+func (in *NodePoolList) DeepCopy() *NodePoolList {
+	if in == nil {
+		return nil
+	}
+	out := new(NodePoolList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *NodePoolList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodePoolSpec) DeepCopyInto(out *NodePoolSpec) {
+	*out = *in
+	out.ProviderConfigRef = in.ProviderConfigRef
+	in.Cluster.DeepCopyInto(&out.Cluster)
+	in.KeyPair.DeepCopyInto(&out.KeyPair)
+	if in.Subnet != nil {
+		in, out := &in.Subnet, &out.Subnet
+		*out = new(SubnetDependency)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SecurityGroups != nil {
+		in, out := &in.SecurityGroups, &out.SecurityGroups
+		*out = make([]SecurityGroupDependency, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	out.RootVolume = in.RootVolume
+	if in.DataVolumes != nil {
+		in, out := &in.DataVolumes, &out.DataVolumes
+		*out = make([]NodePoolVolume, len(*in))
+		copy(*out, *in)
+	}
+	if in.Autoscaling != nil {
+		in, out := &in.Autoscaling, &out.Autoscaling
+		*out = new(NodePoolAutoscaling)
+		**out = **in
+	}
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Taints != nil {
+		in, out := &in.Taints, &out.Taints
+		*out = make([]NodePoolTaint, len(*in))
+		copy(*out, *in)
+	}
+	if in.WriteConnectionDetailsToRef != nil {
+		in, out := &in.WriteConnectionDetailsToRef, &out.WriteConnectionDetailsToRef
+		*out = new(ConnectionDetailsReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodePoolSpec.
+func (in *NodePoolSpec) DeepCopy() *NodePoolSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(NodePoolSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodePoolStatus) DeepCopyInto(out *NodePoolStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.BlockedBy != nil {
+		in, out := &in.BlockedBy, &out.BlockedBy
+		*out = make([]BlockingResource, len(*in))
+		copy(*out, *in)
+	}
+	in.ResolvedDependencies.DeepCopyInto(&out.ResolvedDependencies)
+	if in.LastSyncTime != nil {
+		in, out := &in.LastSyncTime, &out.LastSyncTime
+		*out = (*in).DeepCopy()
+	}
+	if in.LastAppliedSpec != nil {
+		in, out := &in.LastAppliedSpec, &out.LastAppliedSpec
+		*out = new(NodePoolSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodePoolStatus.
+func (in *NodePoolStatus) DeepCopy() *NodePoolStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(NodePoolStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodePoolTaint) DeepCopyInto(out *NodePoolTaint) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodePoolTaint.
+func (in *NodePoolTaint) DeepCopy() *NodePoolTaint {
+	if in == nil {
+		return nil
+	}
+	out := new(NodePoolTaint)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodePoolVolume) DeepCopyInto(out *NodePoolVolume) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodePoolVolume.
+func (in *NodePoolVolume) DeepCopy() *NodePoolVolume {
+	if in == nil {
+		return nil
+	}
+	out := new(NodePoolVolume)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProviderConfig) DeepCopyInto(out *ProviderConfig) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProviderConfig.
+func (in *ProviderConfig) DeepCopy() *ProviderConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ProviderConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ProviderConfig) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProviderConfigList) DeepCopyInto(out *ProviderConfigList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ProviderConfig, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProviderConfigList.
+func (in *ProviderConfigList) DeepCopy() *ProviderConfigList {
+	if in == nil {
+		return nil
+	}
+	out := new(ProviderConfigList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ProviderConfigList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProviderConfigReference) DeepCopyInto(out *ProviderConfigReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProviderConfigReference.
+func (in *ProviderConfigReference) DeepCopy() *ProviderConfigReference {
+	if in == nil {
+		return nil
+	}
+	out := new(ProviderConfigReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProviderConfigSpec) DeepCopyInto(out *ProviderConfigSpec) {
+	*out = *in
+	out.CredentialsSecretRef = in.CredentialsSecretRef
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProviderConfigSpec.
+func (in *ProviderConfigSpec) DeepCopy() *ProviderConfigSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ProviderConfigSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProviderConfigStatus) DeepCopyInto(out *ProviderConfigStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.LastValidationTime != nil {
+		in, out := &in.LastValidationTime, &out.LastValidationTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProviderConfigStatus.
+func (in *ProviderConfigStatus) DeepCopy() *ProviderConfigStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ProviderConfigStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PublicIP) DeepCopyInto(out *PublicIP) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PublicIP.
+func (in *PublicIP) DeepCopy() *PublicIP {
+	if in == nil {
+		return nil
+	}
+	out := new(PublicIP)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PublicIP) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PublicIPBinding) DeepCopyInto(out *PublicIPBinding) {
+	*out = *in
+	if in.PortID != nil {
+		in, out := &in.PortID, &out.PortID
+		*out = new(string)
+		**out = **in
+	}
+	if in.VirtualIPRef != nil {
+		in, out := &in.VirtualIPRef, &out.VirtualIPRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+	if in.InstanceRef != nil {
+		in, out := &in.InstanceRef, &out.InstanceRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PublicIPBinding.
+func (in *PublicIPBinding) DeepCopy() *PublicIPBinding {
+	if in == nil {
+		return nil
+	}
+	out := new(PublicIPBinding)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PublicIPDependenciesResolved) DeepCopyInto(out *PublicIPDependenciesResolved) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PublicIPDependenciesResolved.
+func (in *PublicIPDependenciesResolved) DeepCopy() *PublicIPDependenciesResolved {
+	if in == nil {
+		return nil
+	}
+	out := new(PublicIPDependenciesResolved)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PublicIPDependency) DeepCopyInto(out *PublicIPDependency) {
+	*out = *in
+	if in.PublicIPID != nil {
+		in, out := &in.PublicIPID, &out.PublicIPID
+		*out = new(string)
+		**out = **in
+	}
+	if in.PublicIPRef != nil {
+		in, out := &in.PublicIPRef, &out.PublicIPRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+	if in.PublicIPSelector != nil {
+		in, out := &in.PublicIPSelector, &out.PublicIPSelector
+		*out = new(ResourceSelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PublicIPDependency.
+func (in *PublicIPDependency) DeepCopy() *PublicIPDependency {
+	if in == nil {
+		return nil
+	}
+	out := new(PublicIPDependency)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PublicIPList) DeepCopyInto(out *PublicIPList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]PublicIP, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PublicIPList.
+func (in *PublicIPList) DeepCopy() *PublicIPList {
+	if in == nil {
+		return nil
+	}
+	out := new(PublicIPList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PublicIPList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PublicIPSpec) DeepCopyInto(out *PublicIPSpec) {
+	*out = *in
+	out.ProviderConfigRef = in.ProviderConfigRef
+	if in.Bandwidth != nil {
+		in, out := &in.Bandwidth, &out.Bandwidth
+		*out = new(BandwidthDependency)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Binding != nil {
+		in, out := &in.Binding, &out.Binding
+		*out = new(PublicIPBinding)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.WriteConnectionDetailsToRef != nil {
+		in, out := &in.WriteConnectionDetailsToRef, &out.WriteConnectionDetailsToRef
+		*out = new(ConnectionDetailsReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PublicIPSpec.
+func (in *PublicIPSpec) DeepCopy() *PublicIPSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PublicIPSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PublicIPStatus) DeepCopyInto(out *PublicIPStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.BlockedBy != nil {
+		in, out := &in.BlockedBy, &out.BlockedBy
+		*out = make([]BlockingResource, len(*in))
+		copy(*out, *in)
+	}
+	out.ResolvedDependencies = in.ResolvedDependencies
+	if in.LastSyncTime != nil {
+		in, out := &in.LastSyncTime, &out.LastSyncTime
+		*out = (*in).DeepCopy()
+	}
+	if in.LastAppliedSpec != nil {
+		in, out := &in.LastAppliedSpec, &out.LastAppliedSpec
+		*out = new(PublicIPSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PublicIPStatus.
+func (in *PublicIPStatus) DeepCopy() *PublicIPStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PublicIPStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RecordSet) DeepCopyInto(out *RecordSet) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RecordSet.
+func (in *RecordSet) DeepCopy() *RecordSet {
+	if in == nil {
+		return nil
+	}
+	out := new(RecordSet)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RecordSet) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RecordSetDependenciesResolved) DeepCopyInto(out *RecordSetDependenciesResolved) {
+	*out = *in
+	if in.Records != nil {
+		in, out := &in.Records, &out.Records
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RecordSetDependenciesResolved.
+func (in *RecordSetDependenciesResolved) DeepCopy() *RecordSetDependenciesResolved {
+	if in == nil {
+		return nil
+	}
+	out := new(RecordSetDependenciesResolved)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RecordSetList) DeepCopyInto(out *RecordSetList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]RecordSet, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RecordSetList.
+func (in *RecordSetList) DeepCopy() *RecordSetList {
+	if in == nil {
+		return nil
+	}
+	out := new(RecordSetList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RecordSetList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RecordSetSpec) DeepCopyInto(out *RecordSetSpec) {
+	*out = *in
+	out.ProviderConfigRef = in.ProviderConfigRef
+	in.Zone.DeepCopyInto(&out.Zone)
+	if in.Records != nil {
+		in, out := &in.Records, &out.Records
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.RecordsFrom != nil {
+		in, out := &in.RecordsFrom, &out.RecordsFrom
+		*out = make([]RecordSetValueSource, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.WriteConnectionDetailsToRef != nil {
+		in, out := &in.WriteConnectionDetailsToRef, &out.WriteConnectionDetailsToRef
+		*out = new(ConnectionDetailsReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RecordSetSpec.
+func (in *RecordSetSpec) DeepCopy() *RecordSetSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RecordSetSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RecordSetStatus) DeepCopyInto(out *RecordSetStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.BlockedBy != nil {
+		in, out := &in.BlockedBy, &out.BlockedBy
+		*out = make([]BlockingResource, len(*in))
+		copy(*out, *in)
+	}
+	in.ResolvedDependencies.DeepCopyInto(&out.ResolvedDependencies)
+	if in.Records != nil {
+		in, out := &in.Records, &out.Records
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.LastSyncTime != nil {
+		in, out := &in.LastSyncTime, &out.LastSyncTime
+		*out = (*in).DeepCopy()
+	}
+	if in.LastAppliedSpec != nil {
+		in, out := &in.LastAppliedSpec, &out.LastAppliedSpec
+		*out = new(RecordSetSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RecordSetStatus.
+func (in *RecordSetStatus) DeepCopy() *RecordSetStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RecordSetStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RecordSetValueSource) DeepCopyInto(out *RecordSetValueSource) {
+	*out = *in
+	if in.PublicIPRef != nil {
+		in, out := &in.PublicIPRef, &out.PublicIPRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+	if in.VirtualIPRef != nil {
+		in, out := &in.VirtualIPRef, &out.VirtualIPRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RecordSetValueSource.
+func (in *RecordSetValueSource) DeepCopy() *RecordSetValueSource {
+	if in == nil {
+		return nil
+	}
+	out := new(RecordSetValueSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReferenceGrant) DeepCopyInto(out *ReferenceGrant) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReferenceGrant.
+func (in *ReferenceGrant) DeepCopy() *ReferenceGrant {
+	if in == nil {
+		return nil
+	}
+	out := new(ReferenceGrant)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ReferenceGrant) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReferenceGrantFrom) DeepCopyInto(out *ReferenceGrantFrom) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReferenceGrantFrom.
+func (in *ReferenceGrantFrom) DeepCopy() *ReferenceGrantFrom {
+	if in == nil {
+		return nil
+	}
+	out := new(ReferenceGrantFrom)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReferenceGrantList) DeepCopyInto(out *ReferenceGrantList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ReferenceGrant, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReferenceGrantList.
+func (in *ReferenceGrantList) DeepCopy() *ReferenceGrantList {
+	if in == nil {
+		return nil
+	}
+	out := new(ReferenceGrantList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ReferenceGrantList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReferenceGrantSpec) DeepCopyInto(out *ReferenceGrantSpec) {
+	*out = *in
+	if in.From != nil {
+		in, out := &in.From, &out.From
+		*out = make([]ReferenceGrantFrom, len(*in))
+		copy(*out, *in)
+	}
+	if in.To != nil {
+		in, out := &in.To, &out.To
+		*out = make([]ReferenceGrantTo, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReferenceGrantSpec.
+func (in *ReferenceGrantSpec) DeepCopy() *ReferenceGrantSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ReferenceGrantSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReferenceGrantTo) DeepCopyInto(out *ReferenceGrantTo) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReferenceGrantTo.
+func (in *ReferenceGrantTo) DeepCopy() *ReferenceGrantTo {
+	if in == nil {
+		return nil
+	}
+	out := new(ReferenceGrantTo)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceSelector) DeepCopyInto(out *ResourceSelector) {
+	*out = *in
+	in.LabelSelector.DeepCopyInto(&out.LabelSelector)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceSelector.
+func (in *ResourceSelector) DeepCopy() *ResourceSelector {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceSelector)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SNATRule) DeepCopyInto(out *SNATRule) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SNATRule.
+func (in *SNATRule) DeepCopy() *SNATRule {
+	if in == nil {
+		return nil
+	}
+	out := new(SNATRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SNATRule) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SNATRuleDependenciesResolved) DeepCopyInto(out *SNATRuleDependenciesResolved) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SNATRuleDependenciesResolved.
+func (in *SNATRuleDependenciesResolved) DeepCopy() *SNATRuleDependenciesResolved {
+	if in == nil {
+		return nil
+	}
+	out := new(SNATRuleDependenciesResolved)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SNATRuleList) DeepCopyInto(out *SNATRuleList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]SNATRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SNATRuleList.
+func (in *SNATRuleList) DeepCopy() *SNATRuleList {
+	if in == nil {
+		return nil
+	}
+	out := new(SNATRuleList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SNATRuleList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SNATRuleSet) DeepCopyInto(out *SNATRuleSet) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SNATRuleSet.
+func (in *SNATRuleSet) DeepCopy() *SNATRuleSet {
+	if in == nil {
+		return nil
+	}
+	out := new(SNATRuleSet)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SNATRuleSet) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SNATRuleSetList) DeepCopyInto(out *SNATRuleSetList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]SNATRuleSet, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SNATRuleSetList.
+func (in *SNATRuleSetList) DeepCopy() *SNATRuleSetList {
+	if in == nil {
+		return nil
+	}
+	out := new(SNATRuleSetList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SNATRuleSetList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SNATRuleSetRule) DeepCopyInto(out *SNATRuleSetRule) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SNATRuleSetRule.
+func (in *SNATRuleSetRule) DeepCopy() *SNATRuleSetRule {
+	if in == nil {
+		return nil
+	}
+	out := new(SNATRuleSetRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SNATRuleSetSpec) DeepCopyInto(out *SNATRuleSetSpec) {
+	*out = *in
+	out.ProviderConfigRef = in.ProviderConfigRef
+	in.NATGateway.DeepCopyInto(&out.NATGateway)
+	in.SubnetSelector.DeepCopyInto(&out.SubnetSelector)
+	if in.PublicIPs != nil {
+		in, out := &in.PublicIPs, &out.PublicIPs
+		*out = make([]PublicIPDependency, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SNATRuleSetSpec.
+func (in *SNATRuleSetSpec) DeepCopy() *SNATRuleSetSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SNATRuleSetSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SNATRuleSetStatus) DeepCopyInto(out *SNATRuleSetStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Rules != nil {
+		in, out := &in.Rules, &out.Rules
+		*out = make([]SNATRuleSetRule, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SNATRuleSetStatus.
+func (in *SNATRuleSetStatus) DeepCopy() *SNATRuleSetStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(SNATRuleSetStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SNATRuleSpec) DeepCopyInto(out *SNATRuleSpec) {
+	*out = *in
+	out.ProviderConfigRef = in.ProviderConfigRef
+	in.NATGateway.DeepCopyInto(&out.NATGateway)
+	in.Subnet.DeepCopyInto(&out.Subnet)
+	in.PublicIP.DeepCopyInto(&out.PublicIP)
+	if in.WriteConnectionDetailsToRef != nil {
+		in, out := &in.WriteConnectionDetailsToRef, &out.WriteConnectionDetailsToRef
+		*out = new(ConnectionDetailsReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SNATRuleSpec.
+func (in *SNATRuleSpec) DeepCopy() *SNATRuleSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SNATRuleSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SNATRuleStatus) DeepCopyInto(out *SNATRuleStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.BlockedBy != nil {
+		in, out := &in.BlockedBy, &out.BlockedBy
+		*out = make([]BlockingResource, len(*in))
+		copy(*out, *in)
+	}
+	out.ResolvedDependencies = in.ResolvedDependencies
+	if in.LastSyncTime != nil {
+		in, out := &in.LastSyncTime, &out.LastSyncTime
+		*out = (*in).DeepCopy()
+	}
+	if in.LastAppliedSpec != nil {
+		in, out := &in.LastAppliedSpec, &out.LastAppliedSpec
+		*out = new(SNATRuleSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SNATRuleStatus.
+func (in *SNATRuleStatus) DeepCopy() *SNATRuleStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(SNATRuleStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecurityGroup) DeepCopyInto(out *SecurityGroup) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecurityGroup.
+func (in *SecurityGroup) DeepCopy() *SecurityGroup {
+	if in == nil {
+		return nil
+	}
+	out := new(SecurityGroup)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SecurityGroup) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecurityGroupDependency) DeepCopyInto(out *SecurityGroupDependency) {
+	*out = *in
+	if in.SecurityGroupID != nil {
+		in, out := &in.SecurityGroupID, &out.SecurityGroupID
+		*out = new(string)
+		**out = **in
+	}
+	if in.SecurityGroupRef != nil {
+		in, out := &in.SecurityGroupRef, &out.SecurityGroupRef
+		*out = new(NamespacedObjectReference)
+		**out = **in
+	}
+	if in.SecurityGroupSelector != nil {
+		in, out := &in.SecurityGroupSelector, &out.SecurityGroupSelector
+		*out = new(ResourceSelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecurityGroupDependency.
+func (in *SecurityGroupDependency) DeepCopy() *SecurityGroupDependency {
+	if in == nil {
+		return nil
+	}
+	out := new(SecurityGroupDependency)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecurityGroupList) DeepCopyInto(out *SecurityGroupList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]SecurityGroup, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecurityGroupList.
+func (in *SecurityGroupList) DeepCopy() *SecurityGroupList {
+	if in == nil {
+		return nil
+	}
+	out := new(SecurityGroupList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SecurityGroupList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecurityGroupResolved) DeepCopyInto(out *SecurityGroupResolved) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecurityGroupResolved.
+func (in *SecurityGroupResolved) DeepCopy() *SecurityGroupResolved {
+	if in == nil {
+		return nil
+	}
+	out := new(SecurityGroupResolved)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecurityGroupRule) DeepCopyInto(out *SecurityGroupRule) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecurityGroupRule.
+func (in *SecurityGroupRule) DeepCopy() *SecurityGroupRule {
+	if in == nil {
+		return nil
+	}
+	out := new(SecurityGroupRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SecurityGroupRule) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecurityGroupRuleList) DeepCopyInto(out *SecurityGroupRuleList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]SecurityGroupRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecurityGroupRuleList.
+func (in *SecurityGroupRuleList) DeepCopy() *SecurityGroupRuleList {
+	if in == nil {
+		return nil
+	}
+	out := new(SecurityGroupRuleList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SecurityGroupRuleList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecurityGroupRuleSpec) DeepCopyInto(out *SecurityGroupRuleSpec) {
+	*out = *in
+	out.ProviderConfigRef = in.ProviderConfigRef
+	in.SecurityGroup.DeepCopyInto(&out.SecurityGroup)
+	if in.Priority != nil {
+		in, out := &in.Priority, &out.Priority
+		*out = new(int)
+		**out = **in
+	}
+	if in.WriteConnectionDetailsToRef != nil {
+		in, out := &in.WriteConnectionDetailsToRef, &out.WriteConnectionDetailsToRef
+		*out = new(ConnectionDetailsReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecurityGroupRuleSpec.
+func (in *SecurityGroupRuleSpec) DeepCopy() *SecurityGroupRuleSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SecurityGroupRuleSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecurityGroupRuleStatus) DeepCopyInto(out *SecurityGroupRuleStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.BlockedBy != nil {
+		in, out := &in.BlockedBy, &out.BlockedBy
+		*out = make([]BlockingResource, len(*in))
+		copy(*out, *in)
+	}
+	out.ResolvedDependencies = in.ResolvedDependencies
+	if in.LastSyncTime != nil {
+		in, out := &in.LastSyncTime, &out.LastSyncTime
+		*out = (*in).DeepCopy()
+	}
+	if in.LastAppliedSpec != nil {
+		in, out := &in.LastAppliedSpec, &out.LastAppliedSpec
+		*out = new(SecurityGroupRuleSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecurityGroupRuleStatus.
+func (in *SecurityGroupRuleStatus) DeepCopy() *SecurityGroupRuleStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(SecurityGroupRuleStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecurityGroupSpec) DeepCopyInto(out *SecurityGroupSpec) {
+	*out = *in
+	out.ProviderConfigRef = in.ProviderConfigRef
+	if in.WriteConnectionDetailsToRef != nil {
+		in, out := &in.WriteConnectionDetailsToRef, &out.WriteConnectionDetailsToRef
+		*out = new(ConnectionDetailsReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecurityGroupSpec.
+func (in *SecurityGroupSpec) DeepCopy() *SecurityGroupSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SecurityGroupSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecurityGroupStatus) DeepCopyInto(out *SecurityGroupStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.BlockedBy != nil {
+		in, out := &in.BlockedBy, &out.BlockedBy
+		*out = make([]BlockingResource, len(*in))
+		copy(*out, *in)
+	}
+	if in.LastSyncTime != nil {
+		in, out := &in.LastSyncTime, &out.LastSyncTime
+		*out = (*in).DeepCopy()
+	}
+	if in.LastAppliedSpec != nil {
+		in, out := &in.LastAppliedSpec, &out.LastAppliedSpec
+		*out = new(SecurityGroupSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecurityGroupStatus.
+func (in *SecurityGroupStatus) DeepCopy() *SecurityGroupStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(SecurityGroupStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Subnet) DeepCopyInto(out *Subnet) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Subnet.
+func (in *Subnet) DeepCopy() *Subnet {
+	if in == nil {
+		return nil
+	}
+	out := new(Subnet)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Subnet) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SubnetDependenciesResolved) DeepCopyInto(out *SubnetDependenciesResolved) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SubnetDependenciesResolved.
+func (in *SubnetDependenciesResolved) DeepCopy() *SubnetDependenciesResolved {
+	if in == nil {
+		return nil
+	}
+	out := new(SubnetDependenciesResolved)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SubnetDependency) DeepCopyInto(out *SubnetDependency) {
+	*out = *in
+	if in.SubnetID != nil {
+		in, out := &in.SubnetID, &out.SubnetID
+		*out = new(string)
+		**out = **in
+	}
+	if in.SubnetRef != nil {
+		in, out := &in.SubnetRef, &out.SubnetRef
+		*out = new(NamespacedObjectReference)
+		**out = **in
+	}
+	if in.SubnetSelector != nil {
+		in, out := &in.SubnetSelector, &out.SubnetSelector
+		*out = new(ResourceSelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SubnetDependency.
+func (in *SubnetDependency) DeepCopy() *SubnetDependency {
+	if in == nil {
+		return nil
+	}
+	out := new(SubnetDependency)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SubnetList) DeepCopyInto(out *SubnetList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Subnet, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SubnetList.
+func (in *SubnetList) DeepCopy() *SubnetList {
+	if in == nil {
+		return nil
+	}
+	out := new(SubnetList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SubnetList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SubnetSpec) DeepCopyInto(out *SubnetSpec) {
+	*out = *in
+	out.ProviderConfigRef = in.ProviderConfigRef
+	in.Network.DeepCopyInto(&out.Network)
+	if in.WriteConnectionDetailsToRef != nil {
+		in, out := &in.WriteConnectionDetailsToRef, &out.WriteConnectionDetailsToRef
+		*out = new(ConnectionDetailsReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SubnetSpec.
+func (in *SubnetSpec) DeepCopy() *SubnetSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SubnetSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SubnetStatus) DeepCopyInto(out *SubnetStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.BlockedBy != nil {
+		in, out := &in.BlockedBy, &out.BlockedBy
+		*out = make([]BlockingResource, len(*in))
+		copy(*out, *in)
+	}
+	out.ResolvedDependencies = in.ResolvedDependencies
+	if in.LastSyncTime != nil {
+		in, out := &in.LastSyncTime, &out.LastSyncTime
+		*out = (*in).DeepCopy()
+	}
+	if in.LastAppliedSpec != nil {
+		in, out := &in.LastAppliedSpec, &out.LastAppliedSpec
+		*out = new(SubnetSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SubnetStatus.
+func (in *SubnetStatus) DeepCopy() *SubnetStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(SubnetStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Subscription) DeepCopyInto(out *Subscription) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Subscription.
+func (in *Subscription) DeepCopy() *Subscription {
+	if in == nil {
+		return nil
+	}
+	out := new(Subscription)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Subscription) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SubscriptionDependenciesResolved) DeepCopyInto(out *SubscriptionDependenciesResolved) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SubscriptionDependenciesResolved.
+func (in *SubscriptionDependenciesResolved) DeepCopy() *SubscriptionDependenciesResolved {
+	if in == nil {
+		return nil
+	}
+	out := new(SubscriptionDependenciesResolved)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SubscriptionList) DeepCopyInto(out *SubscriptionList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Subscription, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SubscriptionList.
+func (in *SubscriptionList) DeepCopy() *SubscriptionList {
+	if in == nil {
+		return nil
+	}
+	out := new(SubscriptionList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SubscriptionList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SubscriptionSpec) DeepCopyInto(out *SubscriptionSpec) {
+	*out = *in
+	out.ProviderConfigRef = in.ProviderConfigRef
+	in.Topic.DeepCopyInto(&out.Topic)
+	if in.WriteConnectionDetailsToRef != nil {
+		in, out := &in.WriteConnectionDetailsToRef, &out.WriteConnectionDetailsToRef
+		*out = new(ConnectionDetailsReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SubscriptionSpec.
+func (in *SubscriptionSpec) DeepCopy() *SubscriptionSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SubscriptionSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SubscriptionStatus) DeepCopyInto(out *SubscriptionStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.BlockedBy != nil {
+		in, out := &in.BlockedBy, &out.BlockedBy
+		*out = make([]BlockingResource, len(*in))
+		copy(*out, *in)
+	}
+	out.ResolvedDependencies = in.ResolvedDependencies
+	if in.LastSyncTime != nil {
+		in, out := &in.LastSyncTime, &out.LastSyncTime
+		*out = (*in).DeepCopy()
+	}
+	if in.LastAppliedSpec != nil {
+		in, out := &in.LastAppliedSpec, &out.LastAppliedSpec
+		*out = new(SubscriptionSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SubscriptionStatus.
+func (in *SubscriptionStatus) DeepCopy() *SubscriptionStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(SubscriptionStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Topic) DeepCopyInto(out *Topic) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Topic.
+func (in *Topic) DeepCopy() *Topic {
+	if in == nil {
+		return nil
+	}
+	out := new(Topic)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Topic) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TopicDependency) DeepCopyInto(out *TopicDependency) {
 	*out = *in
-	if in.PublicIPID != nil {
-		in, out := &in.PublicIPID, &out.PublicIPID
+	if in.TopicURN != nil {
+		in, out := &in.TopicURN, &out.TopicURN
 		*out = new(string)
 		**out = **in
 	}
-	if in.PublicIPRef != nil {
-		in, out := &in.PublicIPRef, &out.PublicIPRef
-		*out = new(v1.LocalObjectReference)
+	if in.TopicRef != nil {
+		in, out := &in.TopicRef, &out.TopicRef
+		*out = new(corev1.LocalObjectReference)
 		**out = **in
 	}
-	if in.PublicIPSelector != nil {
-		in, out := &in.PublicIPSelector, &out.PublicIPSelector
-		*out = new(metav1.LabelSelector)
+	if in.TopicSelector != nil {
+		in, out := &in.TopicSelector, &out.TopicSelector
+		*out = new(ResourceSelector)
 		(*in).DeepCopyInto(*out)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PublicIPDependency.
-func (in *PublicIPDependency) DeepCopy() *PublicIPDependency {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TopicDependency.
+func (in *TopicDependency) DeepCopy() *TopicDependency {
 	if in == nil {
 		return nil
 	}
-	out := new(PublicIPDependency)
+	out := new(TopicDependency)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PublicIPList) DeepCopyInto(out *PublicIPList) {
+func (in *TopicList) DeepCopyInto(out *TopicList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]PublicIP, len(*in))
+		*out = make([]Topic, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PublicIPList.
-func (in *PublicIPList) DeepCopy() *PublicIPList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TopicList.
+func (in *TopicList) DeepCopy() *TopicList {
 	if in == nil {
 		return nil
 	}
-	out := new(PublicIPList)
+	out := new(TopicList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *PublicIPList) DeepCopyObject() runtime.Object {
+func (in *TopicList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -506,54 +4218,64 @@ func (in *PublicIPList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PublicIPSpec) DeepCopyInto(out *PublicIPSpec) {
+func (in *TopicSpec) DeepCopyInto(out *TopicSpec) {
 	*out = *in
 	out.ProviderConfigRef = in.ProviderConfigRef
+	if in.WriteConnectionDetailsToRef != nil {
+		in, out := &in.WriteConnectionDetailsToRef, &out.WriteConnectionDetailsToRef
+		*out = new(ConnectionDetailsReference)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PublicIPSpec.
-func (in *PublicIPSpec) DeepCopy() *PublicIPSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TopicSpec.
+func (in *TopicSpec) DeepCopy() *TopicSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(PublicIPSpec)
+	out := new(TopicSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PublicIPStatus) DeepCopyInto(out *PublicIPStatus) {
+func (in *TopicStatus) DeepCopyInto(out *TopicStatus) {
 	*out = *in
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
-		*out = make([]metav1.Condition, len(*in))
+		*out = make([]v1.Condition, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.BlockedBy != nil {
+		in, out := &in.BlockedBy, &out.BlockedBy
+		*out = make([]BlockingResource, len(*in))
+		copy(*out, *in)
+	}
 	if in.LastSyncTime != nil {
 		in, out := &in.LastSyncTime, &out.LastSyncTime
 		*out = (*in).DeepCopy()
 	}
 	if in.LastAppliedSpec != nil {
 		in, out := &in.LastAppliedSpec, &out.LastAppliedSpec
-		*out = new(PublicIPSpec)
-		**out = **in
+		*out = new(TopicSpec)
+		(*in).DeepCopyInto(*out)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PublicIPStatus.
-func (in *PublicIPStatus) DeepCopy() *PublicIPStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TopicStatus.
+func (in *TopicStatus) DeepCopy() *TopicStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(PublicIPStatus)
+	out := new(TopicStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *SNATRule) DeepCopyInto(out *SNATRule) {
+func (in *VPNConnection) DeepCopyInto(out *VPNConnection) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
@@ -561,18 +4283,18 @@ func (in *SNATRule) DeepCopyInto(out *SNATRule) {
 	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SNATRule.
-func (in *SNATRule) DeepCopy() *SNATRule {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VPNConnection.
+func (in *VPNConnection) DeepCopy() *VPNConnection {
 	if in == nil {
 		return nil
 	}
-	out := new(SNATRule)
+	out := new(VPNConnection)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *SNATRule) DeepCopyObject() runtime.Object {
+func (in *VPNConnection) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -580,46 +4302,46 @@ func (in *SNATRule) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *SNATRuleDependenciesResolved) DeepCopyInto(out *SNATRuleDependenciesResolved) {
+func (in *VPNConnectionDependenciesResolved) DeepCopyInto(out *VPNConnectionDependenciesResolved) {
 	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SNATRuleDependenciesResolved.
-func (in *SNATRuleDependenciesResolved) DeepCopy() *SNATRuleDependenciesResolved {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VPNConnectionDependenciesResolved.
+func (in *VPNConnectionDependenciesResolved) DeepCopy() *VPNConnectionDependenciesResolved {
 	if in == nil {
 		return nil
 	}
-	out := new(SNATRuleDependenciesResolved)
+	out := new(VPNConnectionDependenciesResolved)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *SNATRuleList) DeepCopyInto(out *SNATRuleList) {
+func (in *VPNConnectionList) DeepCopyInto(out *VPNConnectionList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]SNATRule, len(*in))
+		*out = make([]VPNConnection, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SNATRuleList.
-func (in *SNATRuleList) DeepCopy() *SNATRuleList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VPNConnectionList.
+func (in *VPNConnectionList) DeepCopy() *VPNConnectionList {
 	if in == nil {
 		return nil
 	}
-	out := new(SNATRuleList)
+	out := new(VPNConnectionList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *SNATRuleList) DeepCopyObject() runtime.Object {
+func (in *VPNConnectionList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -627,34 +4349,51 @@ func (in *SNATRuleList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *SNATRuleSpec) DeepCopyInto(out *SNATRuleSpec) {
+func (in *VPNConnectionSpec) DeepCopyInto(out *VPNConnectionSpec) {
 	*out = *in
 	out.ProviderConfigRef = in.ProviderConfigRef
-	in.NATGateway.DeepCopyInto(&out.NATGateway)
-	in.Subnet.DeepCopyInto(&out.Subnet)
-	in.PublicIP.DeepCopyInto(&out.PublicIP)
+	in.Gateway.DeepCopyInto(&out.Gateway)
+	in.CustomerGateway.DeepCopyInto(&out.CustomerGateway)
+	if in.PeerSubnets != nil {
+		in, out := &in.PeerSubnets, &out.PeerSubnets
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	in.PreSharedKeySecretRef.DeepCopyInto(&out.PreSharedKeySecretRef)
+	out.IKEPolicy = in.IKEPolicy
+	out.IPsecPolicy = in.IPsecPolicy
+	if in.WriteConnectionDetailsToRef != nil {
+		in, out := &in.WriteConnectionDetailsToRef, &out.WriteConnectionDetailsToRef
+		*out = new(ConnectionDetailsReference)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SNATRuleSpec.
-func (in *SNATRuleSpec) DeepCopy() *SNATRuleSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VPNConnectionSpec.
+func (in *VPNConnectionSpec) DeepCopy() *VPNConnectionSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(SNATRuleSpec)
+	out := new(VPNConnectionSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *SNATRuleStatus) DeepCopyInto(out *SNATRuleStatus) {
+func (in *VPNConnectionStatus) DeepCopyInto(out *VPNConnectionStatus) {
 	*out = *in
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
-		*out = make([]metav1.Condition, len(*in))
+		*out = make([]v1.Condition, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.BlockedBy != nil {
+		in, out := &in.BlockedBy, &out.BlockedBy
+		*out = make([]BlockingResource, len(*in))
+		copy(*out, *in)
+	}
 	out.ResolvedDependencies = in.ResolvedDependencies
 	if in.LastSyncTime != nil {
 		in, out := &in.LastSyncTime, &out.LastSyncTime
@@ -662,42 +4401,42 @@ func (in *SNATRuleStatus) DeepCopyInto(out *SNATRuleStatus) {
 	}
 	if in.LastAppliedSpec != nil {
 		in, out := &in.LastAppliedSpec, &out.LastAppliedSpec
-		*out = new(SNATRuleSpec)
+		*out = new(VPNConnectionSpec)
 		(*in).DeepCopyInto(*out)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SNATRuleStatus.
-func (in *SNATRuleStatus) DeepCopy() *SNATRuleStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VPNConnectionStatus.
+func (in *VPNConnectionStatus) DeepCopy() *VPNConnectionStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(SNATRuleStatus)
+	out := new(VPNConnectionStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *SecurityGroup) DeepCopyInto(out *SecurityGroup) {
+func (in *VPNGateway) DeepCopyInto(out *VPNGateway) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
+	in.Spec.DeepCopyInto(&out.Spec)
 	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecurityGroup.
-func (in *SecurityGroup) DeepCopy() *SecurityGroup {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VPNGateway.
+func (in *VPNGateway) DeepCopy() *VPNGateway {
 	if in == nil {
 		return nil
 	}
-	out := new(SecurityGroup)
+	out := new(VPNGateway)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *SecurityGroup) DeepCopyObject() runtime.Object {
+func (in *VPNGateway) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -705,61 +4444,76 @@ func (in *SecurityGroup) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *SecurityGroupDependency) DeepCopyInto(out *SecurityGroupDependency) {
+func (in *VPNGatewayDependenciesResolved) DeepCopyInto(out *VPNGatewayDependenciesResolved) {
 	*out = *in
-	if in.SecurityGroupID != nil {
-		in, out := &in.SecurityGroupID, &out.SecurityGroupID
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VPNGatewayDependenciesResolved.
+func (in *VPNGatewayDependenciesResolved) DeepCopy() *VPNGatewayDependenciesResolved {
+	if in == nil {
+		return nil
+	}
+	out := new(VPNGatewayDependenciesResolved)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VPNGatewayDependency) DeepCopyInto(out *VPNGatewayDependency) {
+	*out = *in
+	if in.VPNGatewayID != nil {
+		in, out := &in.VPNGatewayID, &out.VPNGatewayID
 		*out = new(string)
 		**out = **in
 	}
-	if in.SecurityGroupRef != nil {
-		in, out := &in.SecurityGroupRef, &out.SecurityGroupRef
-		*out = new(v1.LocalObjectReference)
+	if in.VPNGatewayRef != nil {
+		in, out := &in.VPNGatewayRef, &out.VPNGatewayRef
+		*out = new(corev1.LocalObjectReference)
 		**out = **in
 	}
-	if in.SecurityGroupSelector != nil {
-		in, out := &in.SecurityGroupSelector, &out.SecurityGroupSelector
-		*out = new(metav1.LabelSelector)
+	if in.VPNGatewaySelector != nil {
+		in, out := &in.VPNGatewaySelector, &out.VPNGatewaySelector
+		*out = new(ResourceSelector)
 		(*in).DeepCopyInto(*out)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecurityGroupDependency.
-func (in *SecurityGroupDependency) DeepCopy() *SecurityGroupDependency {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VPNGatewayDependency.
+func (in *VPNGatewayDependency) DeepCopy() *VPNGatewayDependency {
 	if in == nil {
 		return nil
 	}
-	out := new(SecurityGroupDependency)
+	out := new(VPNGatewayDependency)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *SecurityGroupList) DeepCopyInto(out *SecurityGroupList) {
+func (in *VPNGatewayList) DeepCopyInto(out *VPNGatewayList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]SecurityGroup, len(*in))
+		*out = make([]VPNGateway, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecurityGroupList.
-func (in *SecurityGroupList) DeepCopy() *SecurityGroupList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VPNGatewayList.
+func (in *VPNGatewayList) DeepCopy() *VPNGatewayList {
 	if in == nil {
 		return nil
 	}
-	out := new(SecurityGroupList)
+	out := new(VPNGatewayList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *SecurityGroupList) DeepCopyObject() runtime.Object {
+func (in *VPNGatewayList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -767,73 +4521,98 @@ func (in *SecurityGroupList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *SecurityGroupResolved) DeepCopyInto(out *SecurityGroupResolved) {
+func (in *VPNGatewaySpec) DeepCopyInto(out *VPNGatewaySpec) {
 	*out = *in
+	out.ProviderConfigRef = in.ProviderConfigRef
+	in.Network.DeepCopyInto(&out.Network)
+	in.Subnet.DeepCopyInto(&out.Subnet)
+	if in.LocalSubnets != nil {
+		in, out := &in.LocalSubnets, &out.LocalSubnets
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AvailabilityZones != nil {
+		in, out := &in.AvailabilityZones, &out.AvailabilityZones
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	in.PrimaryPublicIP.DeepCopyInto(&out.PrimaryPublicIP)
+	in.SecondaryPublicIP.DeepCopyInto(&out.SecondaryPublicIP)
+	if in.WriteConnectionDetailsToRef != nil {
+		in, out := &in.WriteConnectionDetailsToRef, &out.WriteConnectionDetailsToRef
+		*out = new(ConnectionDetailsReference)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecurityGroupResolved.
-func (in *SecurityGroupResolved) DeepCopy() *SecurityGroupResolved {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VPNGatewaySpec.
+func (in *VPNGatewaySpec) DeepCopy() *VPNGatewaySpec {
 	if in == nil {
 		return nil
 	}
-	out := new(SecurityGroupResolved)
+	out := new(VPNGatewaySpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *SecurityGroupRule) DeepCopyInto(out *SecurityGroupRule) {
+func (in *VPNGatewayStatus) DeepCopyInto(out *VPNGatewayStatus) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
-	in.Status.DeepCopyInto(&out.Status)
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.BlockedBy != nil {
+		in, out := &in.BlockedBy, &out.BlockedBy
+		*out = make([]BlockingResource, len(*in))
+		copy(*out, *in)
+	}
+	out.ResolvedDependencies = in.ResolvedDependencies
+	if in.LastSyncTime != nil {
+		in, out := &in.LastSyncTime, &out.LastSyncTime
+		*out = (*in).DeepCopy()
+	}
+	if in.LastAppliedSpec != nil {
+		in, out := &in.LastAppliedSpec, &out.LastAppliedSpec
+		*out = new(VPNGatewaySpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecurityGroupRule.
-func (in *SecurityGroupRule) DeepCopy() *SecurityGroupRule {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VPNGatewayStatus.
+func (in *VPNGatewayStatus) DeepCopy() *VPNGatewayStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(SecurityGroupRule)
+	out := new(VPNGatewayStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *SecurityGroupRule) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *SecurityGroupRuleList) DeepCopyInto(out *SecurityGroupRuleList) {
+func (in *VirtualIP) DeepCopyInto(out *VirtualIP) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
-	in.ListMeta.DeepCopyInto(&out.ListMeta)
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]SecurityGroupRule, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecurityGroupRuleList.
-func (in *SecurityGroupRuleList) DeepCopy() *SecurityGroupRuleList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualIP.
+func (in *VirtualIP) DeepCopy() *VirtualIP {
 	if in == nil {
 		return nil
 	}
-	out := new(SecurityGroupRuleList)
+	out := new(VirtualIP)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *SecurityGroupRuleList) DeepCopyObject() runtime.Object {
+func (in *VirtualIP) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -841,108 +4620,113 @@ func (in *SecurityGroupRuleList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *SecurityGroupRuleSpec) DeepCopyInto(out *SecurityGroupRuleSpec) {
+func (in *VirtualIPDependenciesResolved) DeepCopyInto(out *VirtualIPDependenciesResolved) {
 	*out = *in
-	out.ProviderConfigRef = in.ProviderConfigRef
-	in.SecurityGroup.DeepCopyInto(&out.SecurityGroup)
-	if in.Priority != nil {
-		in, out := &in.Priority, &out.Priority
-		*out = new(int)
-		**out = **in
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecurityGroupRuleSpec.
-func (in *SecurityGroupRuleSpec) DeepCopy() *SecurityGroupRuleSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualIPDependenciesResolved.
+func (in *VirtualIPDependenciesResolved) DeepCopy() *VirtualIPDependenciesResolved {
 	if in == nil {
 		return nil
 	}
-	out := new(SecurityGroupRuleSpec)
+	out := new(VirtualIPDependenciesResolved)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *SecurityGroupRuleStatus) DeepCopyInto(out *SecurityGroupRuleStatus) {
+func (in *VirtualIPList) DeepCopyInto(out *VirtualIPList) {
 	*out = *in
-	if in.Conditions != nil {
-		in, out := &in.Conditions, &out.Conditions
-		*out = make([]metav1.Condition, len(*in))
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]VirtualIP, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
-	out.ResolvedDependencies = in.ResolvedDependencies
-	if in.LastSyncTime != nil {
-		in, out := &in.LastSyncTime, &out.LastSyncTime
-		*out = (*in).DeepCopy()
-	}
-	if in.LastAppliedSpec != nil {
-		in, out := &in.LastAppliedSpec, &out.LastAppliedSpec
-		*out = new(SecurityGroupRuleSpec)
-		(*in).DeepCopyInto(*out)
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecurityGroupRuleStatus.
-func (in *SecurityGroupRuleStatus) DeepCopy() *SecurityGroupRuleStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualIPList.
+func (in *VirtualIPList) DeepCopy() *VirtualIPList {
 	if in == nil {
 		return nil
 	}
-	out := new(SecurityGroupRuleStatus)
+	out := new(VirtualIPList)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VirtualIPList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *SecurityGroupSpec) DeepCopyInto(out *SecurityGroupSpec) {
+func (in *VirtualIPSpec) DeepCopyInto(out *VirtualIPSpec) {
 	*out = *in
 	out.ProviderConfigRef = in.ProviderConfigRef
+	in.Subnet.DeepCopyInto(&out.Subnet)
+	if in.WriteConnectionDetailsToRef != nil {
+		in, out := &in.WriteConnectionDetailsToRef, &out.WriteConnectionDetailsToRef
+		*out = new(ConnectionDetailsReference)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecurityGroupSpec.
-func (in *SecurityGroupSpec) DeepCopy() *SecurityGroupSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualIPSpec.
+func (in *VirtualIPSpec) DeepCopy() *VirtualIPSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(SecurityGroupSpec)
+	out := new(VirtualIPSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *SecurityGroupStatus) DeepCopyInto(out *SecurityGroupStatus) {
+func (in *VirtualIPStatus) DeepCopyInto(out *VirtualIPStatus) {
 	*out = *in
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
-		*out = make([]metav1.Condition, len(*in))
+		*out = make([]v1.Condition, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.BlockedBy != nil {
+		in, out := &in.BlockedBy, &out.BlockedBy
+		*out = make([]BlockingResource, len(*in))
+		copy(*out, *in)
+	}
+	out.ResolvedDependencies = in.ResolvedDependencies
 	if in.LastSyncTime != nil {
 		in, out := &in.LastSyncTime, &out.LastSyncTime
 		*out = (*in).DeepCopy()
 	}
 	if in.LastAppliedSpec != nil {
 		in, out := &in.LastAppliedSpec, &out.LastAppliedSpec
-		*out = new(SecurityGroupSpec)
-		**out = **in
+		*out = new(VirtualIPSpec)
+		(*in).DeepCopyInto(*out)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecurityGroupStatus.
-func (in *SecurityGroupStatus) DeepCopy() *SecurityGroupStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualIPStatus.
+func (in *VirtualIPStatus) DeepCopy() *VirtualIPStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(SecurityGroupStatus)
+	out := new(VirtualIPStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Subnet) DeepCopyInto(out *Subnet) {
+func (in *Volume) DeepCopyInto(out *Volume) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
@@ -950,18 +4734,18 @@ func (in *Subnet) DeepCopyInto(out *Subnet) {
 	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Subnet.
-func (in *Subnet) DeepCopy() *Subnet {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Volume.
+func (in *Volume) DeepCopy() *Volume {
 	if in == nil {
 		return nil
 	}
-	out := new(Subnet)
+	out := new(Volume)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *Subnet) DeepCopyObject() runtime.Object {
+func (in *Volume) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -969,76 +4753,62 @@ func (in *Subnet) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *SubnetDependenciesResolved) DeepCopyInto(out *SubnetDependenciesResolved) {
+func (in *VolumeAttachment) DeepCopyInto(out *VolumeAttachment) {
 	*out = *in
+	in.Instance.DeepCopyInto(&out.Instance)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SubnetDependenciesResolved.
-func (in *SubnetDependenciesResolved) DeepCopy() *SubnetDependenciesResolved {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VolumeAttachment.
+func (in *VolumeAttachment) DeepCopy() *VolumeAttachment {
 	if in == nil {
 		return nil
 	}
-	out := new(SubnetDependenciesResolved)
+	out := new(VolumeAttachment)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *SubnetDependency) DeepCopyInto(out *SubnetDependency) {
+func (in *VolumeDependenciesResolved) DeepCopyInto(out *VolumeDependenciesResolved) {
 	*out = *in
-	if in.SubnetID != nil {
-		in, out := &in.SubnetID, &out.SubnetID
-		*out = new(string)
-		**out = **in
-	}
-	if in.SubnetRef != nil {
-		in, out := &in.SubnetRef, &out.SubnetRef
-		*out = new(v1.LocalObjectReference)
-		**out = **in
-	}
-	if in.SubnetSelector != nil {
-		in, out := &in.SubnetSelector, &out.SubnetSelector
-		*out = new(metav1.LabelSelector)
-		(*in).DeepCopyInto(*out)
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SubnetDependency.
-func (in *SubnetDependency) DeepCopy() *SubnetDependency {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VolumeDependenciesResolved.
+func (in *VolumeDependenciesResolved) DeepCopy() *VolumeDependenciesResolved {
 	if in == nil {
 		return nil
 	}
-	out := new(SubnetDependency)
+	out := new(VolumeDependenciesResolved)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *SubnetList) DeepCopyInto(out *SubnetList) {
+func (in *VolumeList) DeepCopyInto(out *VolumeList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]Subnet, len(*in))
+		*out = make([]Volume, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SubnetList.
-func (in *SubnetList) DeepCopy() *SubnetList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VolumeList.
+func (in *VolumeList) DeepCopy() *VolumeList {
 	if in == nil {
 		return nil
 	}
-	out := new(SubnetList)
+	out := new(VolumeList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *SubnetList) DeepCopyObject() runtime.Object {
+func (in *VolumeList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -1046,32 +4816,51 @@ func (in *SubnetList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *SubnetSpec) DeepCopyInto(out *SubnetSpec) {
+func (in *VolumeSpec) DeepCopyInto(out *VolumeSpec) {
 	*out = *in
 	out.ProviderConfigRef = in.ProviderConfigRef
-	in.Network.DeepCopyInto(&out.Network)
+	if in.KMSKey != nil {
+		in, out := &in.KMSKey, &out.KMSKey
+		*out = new(KMSKeyDependency)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Attachment != nil {
+		in, out := &in.Attachment, &out.Attachment
+		*out = new(VolumeAttachment)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.WriteConnectionDetailsToRef != nil {
+		in, out := &in.WriteConnectionDetailsToRef, &out.WriteConnectionDetailsToRef
+		*out = new(ConnectionDetailsReference)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SubnetSpec.
-func (in *SubnetSpec) DeepCopy() *SubnetSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VolumeSpec.
+func (in *VolumeSpec) DeepCopy() *VolumeSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(SubnetSpec)
+	out := new(VolumeSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *SubnetStatus) DeepCopyInto(out *SubnetStatus) {
+func (in *VolumeStatus) DeepCopyInto(out *VolumeStatus) {
 	*out = *in
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
-		*out = make([]metav1.Condition, len(*in))
+		*out = make([]v1.Condition, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.BlockedBy != nil {
+		in, out := &in.BlockedBy, &out.BlockedBy
+		*out = make([]BlockingResource, len(*in))
+		copy(*out, *in)
+	}
 	out.ResolvedDependencies = in.ResolvedDependencies
 	if in.LastSyncTime != nil {
 		in, out := &in.LastSyncTime, &out.LastSyncTime
@@ -1079,17 +4868,17 @@ func (in *SubnetStatus) DeepCopyInto(out *SubnetStatus) {
 	}
 	if in.LastAppliedSpec != nil {
 		in, out := &in.LastAppliedSpec, &out.LastAppliedSpec
-		*out = new(SubnetSpec)
+		*out = new(VolumeSpec)
 		(*in).DeepCopyInto(*out)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SubnetStatus.
-func (in *SubnetStatus) DeepCopy() *SubnetStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VolumeStatus.
+func (in *VolumeStatus) DeepCopy() *VolumeStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(SubnetStatus)
+	out := new(VolumeStatus)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"crypto/tls"
 	"flag"
 	"os"
@@ -232,9 +233,21 @@ func main() {
 		setupLog.Fatal().Err(err).Msg("Failed to start manager")
 	}
 
+	// Index the resolved dependencies, which are looked up before deletion.
+	if err := controller.SetupReferenceIndexes(context.Background(), mgr.GetFieldIndexer()); err != nil {
+		setupLog.Fatal().Err(err).Msg("Failed to set up reference indexes")
+	}
+
 	// Create the a provider cache, which gets shared among all controllers.
 	providers := controller.NewProviderCache(mgr.GetClient(), logger)
 
+	// Create the dependency graph, which gets shared among all controllers
+	// and is rebuilt periodically.
+	dependencies := controller.NewDependencyGraphCache(mgr.GetClient(), logger)
+	if err := mgr.Add(dependencies); err != nil {
+		setupLog.Fatal().Err(err).Msg("Failed to set up dependency graph")
+	}
+
 	// Create Provider controller.
 	providerConfigReconcicler := controller.NewProviderConfigReconciler(
 		mgr.GetClient(),
@@ -254,9 +267,9 @@ func main() {
 	// Create Network controller.
 	networkReconciler := controller.NewNetworkReconciler(
 		mgr.GetClient(),
-		mgr.GetScheme(),
 		logger,
 		providers,
+		dependencies,
 	)
 	if err := networkReconciler.SetupWithManager(mgr); err != nil {
 		setupLog.Fatal().Err(err).Msg("Failed to create Network controller")
@@ -270,9 +283,9 @@ func main() {
 	// Create Subnet controller.
 	subnetReconciler := controller.NewSubnetReconciler(
 		mgr.GetClient(),
-		mgr.GetScheme(),
 		logger,
 		providers,
+		dependencies,
 	)
 	if err := subnetReconciler.SetupWithManager(mgr); err != nil {
 		setupLog.Fatal().Err(err).Msg("Failed to create Subnet controller")
@@ -286,9 +299,9 @@ func main() {
 	// Create Public IP controller.
 	publicIPReconciler := controller.NewPublicIPReconciler(
 		mgr.GetClient(),
-		mgr.GetScheme(),
 		logger,
 		providers,
+		dependencies,
 	)
 	if err := publicIPReconciler.SetupWithManager(mgr); err != nil {
 		setupLog.Fatal().Err(err).Msg("Failed to create Public IP controller")
@@ -302,9 +315,9 @@ func main() {
 	// Create NAT gateway controller.
 	natGatewayReconciler := controller.NewNATGatewayReconciler(
 		mgr.GetClient(),
-		mgr.GetScheme(),
 		logger,
 		providers,
+		dependencies,
 	)
 	if err := natGatewayReconciler.SetupWithManager(mgr); err != nil {
 		setupLog.Fatal().Err(err).Msg("Failed to create NAT gateway controller")
@@ -318,9 +331,9 @@ func main() {
 	// Create SNAT rule controller.
 	snatRuleReconciler := controller.NewSNATRuleReconciler(
 		mgr.GetClient(),
-		mgr.GetScheme(),
 		logger,
 		providers,
+		dependencies,
 	)
 	if err := snatRuleReconciler.SetupWithManager(mgr); err != nil {
 		setupLog.Fatal().Err(err).Msg("Failed to create SNAT rule controller")
@@ -331,12 +344,40 @@ func main() {
 		setupLog.Fatal().Err(err).Msg("Failed to create SNAT rule webhook")
 	}
 
+	// Create network stack controller.
+	networkStackReconciler := controller.NewNetworkStackReconciler(
+		mgr.GetClient(),
+		logger,
+	)
+	if err := networkStackReconciler.SetupWithManager(mgr); err != nil {
+		setupLog.Fatal().Err(err).Msg("Failed to create network stack controller")
+	}
+
+	// Register network stack webhook
+	if err := webhookv1alpha1.SetupNetworkStackWebhookWithManager(mgr); err != nil {
+		setupLog.Fatal().Err(err).Msg("Failed to create network stack webhook")
+	}
+
+	// Create SNAT rule set controller.
+	snatRuleSetReconciler := controller.NewSNATRuleSetReconciler(
+		mgr.GetClient(),
+		logger,
+	)
+	if err := snatRuleSetReconciler.SetupWithManager(mgr); err != nil {
+		setupLog.Fatal().Err(err).Msg("Failed to create SNAT rule set controller")
+	}
+
+	// Register SNAT rule set webhook
+	if err := webhookv1alpha1.SetupSNATRuleSetWebhookWithManager(mgr); err != nil {
+		setupLog.Fatal().Err(err).Msg("Failed to create SNAT rule set webhook")
+	}
+
 	// Create Security Group controller.
 	securityGroupReconciler := controller.NewSecurityGroupReconciler(
 		mgr.GetClient(),
-		mgr.GetScheme(),
 		logger,
 		providers,
+		dependencies,
 	)
 	if err := securityGroupReconciler.SetupWithManager(mgr); err != nil {
 		setupLog.Fatal().Err(err).Msg("Failed to create Security Group controller")
@@ -350,9 +391,9 @@ func main() {
 	// Create Security Group rule controller.
 	securityGroupRuleReconciler := controller.NewSecurityGroupRuleReconciler(
 		mgr.GetClient(),
-		mgr.GetScheme(),
 		logger,
 		providers,
+		dependencies,
 	)
 	if err := securityGroupRuleReconciler.SetupWithManager(mgr); err != nil {
 		setupLog.Fatal().Err(err).Msg("Failed to create Security Group rule controller")
@@ -363,6 +404,303 @@ func main() {
 		setupLog.Fatal().Err(err).Msg("Failed to create Security Group rule webhook")
 	}
 
+	// Create Virtual IP controller.
+	virtualIPReconciler := controller.NewVirtualIPReconciler(
+		mgr.GetClient(),
+		logger,
+		providers,
+		dependencies,
+	)
+	if err := virtualIPReconciler.SetupWithManager(mgr); err != nil {
+		setupLog.Fatal().Err(err).Msg("Failed to create Virtual IP controller")
+	}
+
+	// Register Virtual IP webhook
+	if err := webhookv1alpha1.SetupVirtualIPWebhookWithManager(mgr); err != nil {
+		setupLog.Fatal().Err(err).Msg("Failed to create Virtual IP webhook")
+	}
+
+	// Create Bandwidth controller.
+	bandwidthReconciler := controller.NewBandwidthReconciler(
+		mgr.GetClient(),
+		logger,
+		providers,
+		dependencies,
+	)
+	if err := bandwidthReconciler.SetupWithManager(mgr); err != nil {
+		setupLog.Fatal().Err(err).Msg("Failed to create Bandwidth controller")
+	}
+
+	// Register Bandwidth webhook
+	if err := webhookv1alpha1.SetupBandwidthWebhookWithManager(mgr); err != nil {
+		setupLog.Fatal().Err(err).Msg("Failed to create Bandwidth webhook")
+	}
+
+	// Create Instance controller.
+	instanceReconciler := controller.NewInstanceReconciler(
+		mgr.GetClient(),
+		logger,
+		providers,
+		dependencies,
+	)
+	if err := instanceReconciler.SetupWithManager(mgr); err != nil {
+		setupLog.Fatal().Err(err).Msg("Failed to create Instance controller")
+	}
+
+	// Register Instance webhook
+	if err := webhookv1alpha1.SetupInstanceWebhookWithManager(mgr); err != nil {
+		setupLog.Fatal().Err(err).Msg("Failed to create Instance webhook")
+	}
+
+	// Create Volume controller.
+	volumeReconciler := controller.NewVolumeReconciler(
+		mgr.GetClient(),
+		logger,
+		providers,
+		dependencies,
+	)
+	if err := volumeReconciler.SetupWithManager(mgr); err != nil {
+		setupLog.Fatal().Err(err).Msg("Failed to create Volume controller")
+	}
+
+	// Register Volume webhook
+	if err := webhookv1alpha1.SetupVolumeWebhookWithManager(mgr); err != nil {
+		setupLog.Fatal().Err(err).Msg("Failed to create Volume webhook")
+	}
+
+	// Create KeyPair controller.
+	keyPairReconciler := controller.NewKeyPairReconciler(
+		mgr.GetClient(),
+		logger,
+		providers,
+		dependencies,
+	)
+	if err := keyPairReconciler.SetupWithManager(mgr); err != nil {
+		setupLog.Fatal().Err(err).Msg("Failed to create KeyPair controller")
+	}
+
+	// Register KeyPair webhook
+	if err := webhookv1alpha1.SetupKeyPairWebhookWithManager(mgr); err != nil {
+		setupLog.Fatal().Err(err).Msg("Failed to create KeyPair webhook")
+	}
+
+	// Create DNSZone controller.
+	dnsZoneReconciler := controller.NewDNSZoneReconciler(
+		mgr.GetClient(),
+		logger,
+		providers,
+		dependencies,
+	)
+	if err := dnsZoneReconciler.SetupWithManager(mgr); err != nil {
+		setupLog.Fatal().Err(err).Msg("Failed to create DNSZone controller")
+	}
+
+	// Register DNSZone webhook
+	if err := webhookv1alpha1.SetupDNSZoneWebhookWithManager(mgr); err != nil {
+		setupLog.Fatal().Err(err).Msg("Failed to create DNSZone webhook")
+	}
+
+	// Create RecordSet controller.
+	recordSetReconciler := controller.NewRecordSetReconciler(
+		mgr.GetClient(),
+		logger,
+		providers,
+		dependencies,
+	)
+	if err := recordSetReconciler.SetupWithManager(mgr); err != nil {
+		setupLog.Fatal().Err(err).Msg("Failed to create RecordSet controller")
+	}
+
+	// Register RecordSet webhook
+	if err := webhookv1alpha1.SetupRecordSetWebhookWithManager(mgr); err != nil {
+		setupLog.Fatal().Err(err).Msg("Failed to create RecordSet webhook")
+	}
+
+	// Create VPNGateway controller.
+	vpnGatewayReconciler := controller.NewVPNGatewayReconciler(
+		mgr.GetClient(),
+		logger,
+		providers,
+		dependencies,
+	)
+	if err := vpnGatewayReconciler.SetupWithManager(mgr); err != nil {
+		setupLog.Fatal().Err(err).Msg("Failed to create VPNGateway controller")
+	}
+
+	// Register VPNGateway webhook
+	if err := webhookv1alpha1.SetupVPNGatewayWebhookWithManager(mgr); err != nil {
+		setupLog.Fatal().Err(err).Msg("Failed to create VPNGateway webhook")
+	}
+
+	// Create CustomerGateway controller.
+	customerGatewayReconciler := controller.NewCustomerGatewayReconciler(
+		mgr.GetClient(),
+		logger,
+		providers,
+		dependencies,
+	)
+	if err := customerGatewayReconciler.SetupWithManager(mgr); err != nil {
+		setupLog.Fatal().Err(err).Msg("Failed to create CustomerGateway controller")
+	}
+
+	// Register CustomerGateway webhook
+	if err := webhookv1alpha1.SetupCustomerGatewayWebhookWithManager(mgr); err != nil {
+		setupLog.Fatal().Err(err).Msg("Failed to create CustomerGateway webhook")
+	}
+
+	// Create VPNConnection controller.
+	vpnConnectionReconciler := controller.NewVPNConnectionReconciler(
+		mgr.GetClient(),
+		logger,
+		providers,
+		dependencies,
+	)
+	if err := vpnConnectionReconciler.SetupWithManager(mgr); err != nil {
+		setupLog.Fatal().Err(err).Msg("Failed to create VPNConnection controller")
+	}
+
+	// Register VPNConnection webhook
+	if err := webhookv1alpha1.SetupVPNConnectionWebhookWithManager(mgr); err != nil {
+		setupLog.Fatal().Err(err).Msg("Failed to create VPNConnection webhook")
+	}
+
+	// Create Cluster controller.
+	clusterReconciler := controller.NewClusterReconciler(
+		mgr.GetClient(),
+		logger,
+		providers,
+		dependencies,
+	)
+	if err := clusterReconciler.SetupWithManager(mgr); err != nil {
+		setupLog.Fatal().Err(err).Msg("Failed to create Cluster controller")
+	}
+
+	// Register Cluster webhook
+	if err := webhookv1alpha1.SetupClusterWebhookWithManager(mgr); err != nil {
+		setupLog.Fatal().Err(err).Msg("Failed to create Cluster webhook")
+	}
+
+	// Create NodePool controller.
+	nodePoolReconciler := controller.NewNodePoolReconciler(
+		mgr.GetClient(),
+		logger,
+		providers,
+		dependencies,
+	)
+	if err := nodePoolReconciler.SetupWithManager(mgr); err != nil {
+		setupLog.Fatal().Err(err).Msg("Failed to create NodePool controller")
+	}
+
+	// Register NodePool webhook
+	if err := webhookv1alpha1.SetupNodePoolWebhookWithManager(mgr); err != nil {
+		setupLog.Fatal().Err(err).Msg("Failed to create NodePool webhook")
+	}
+
+	// Create DatabaseInstance controller.
+	databaseInstanceReconciler := controller.NewDatabaseInstanceReconciler(
+		mgr.GetClient(),
+		logger,
+		providers,
+		dependencies,
+	)
+	if err := databaseInstanceReconciler.SetupWithManager(mgr); err != nil {
+		setupLog.Fatal().Err(err).Msg("Failed to create DatabaseInstance controller")
+	}
+
+	// Register DatabaseInstance webhook
+	if err := webhookv1alpha1.SetupDatabaseInstanceWebhookWithManager(mgr); err != nil {
+		setupLog.Fatal().Err(err).Msg("Failed to create DatabaseInstance webhook")
+	}
+
+	// Create Bucket controller.
+	bucketReconciler := controller.NewBucketReconciler(
+		mgr.GetClient(),
+		logger,
+		providers,
+		dependencies,
+	)
+	if err := bucketReconciler.SetupWithManager(mgr); err != nil {
+		setupLog.Fatal().Err(err).Msg("Failed to create Bucket controller")
+	}
+
+	// Register Bucket webhook
+	if err := webhookv1alpha1.SetupBucketWebhookWithManager(mgr); err != nil {
+		setupLog.Fatal().Err(err).Msg("Failed to create Bucket webhook")
+	}
+
+	// Create KMSKey controller.
+	kmsKeyReconciler := controller.NewKMSKeyReconciler(
+		mgr.GetClient(),
+		logger,
+		providers,
+		dependencies,
+	)
+	if err := kmsKeyReconciler.SetupWithManager(mgr); err != nil {
+		setupLog.Fatal().Err(err).Msg("Failed to create KMSKey controller")
+	}
+
+	// Register KMSKey webhook
+	if err := webhookv1alpha1.SetupKMSKeyWebhookWithManager(mgr); err != nil {
+		setupLog.Fatal().Err(err).Msg("Failed to create KMSKey webhook")
+	}
+
+	// Create AlarmRule controller.
+	alarmRuleReconciler := controller.NewAlarmRuleReconciler(
+		mgr.GetClient(),
+		logger,
+		providers,
+		dependencies,
+	)
+	if err := alarmRuleReconciler.SetupWithManager(mgr); err != nil {
+		setupLog.Fatal().Err(err).Msg("Failed to create AlarmRule controller")
+	}
+
+	// Register AlarmRule webhook
+	if err := webhookv1alpha1.SetupAlarmRuleWebhookWithManager(mgr); err != nil {
+		setupLog.Fatal().Err(err).Msg("Failed to create AlarmRule webhook")
+	}
+
+	// Create Topic controller.
+	topicReconciler := controller.NewTopicReconciler(
+		mgr.GetClient(),
+		logger,
+		providers,
+		dependencies,
+	)
+	if err := topicReconciler.SetupWithManager(mgr); err != nil {
+		setupLog.Fatal().Err(err).Msg("Failed to create Topic controller")
+	}
+
+	// Register Topic webhook
+	if err := webhookv1alpha1.SetupTopicWebhookWithManager(mgr); err != nil {
+		setupLog.Fatal().Err(err).Msg("Failed to create Topic webhook")
+	}
+
+	// Create Subscription controller.
+	subscriptionReconciler := controller.NewSubscriptionReconciler(
+		mgr.GetClient(),
+		logger,
+		providers,
+		dependencies,
+	)
+	if err := subscriptionReconciler.SetupWithManager(mgr); err != nil {
+		setupLog.Fatal().Err(err).Msg("Failed to create Subscription controller")
+	}
+
+	// Register Subscription webhook
+	if err := webhookv1alpha1.SetupSubscriptionWebhookWithManager(mgr); err != nil {
+		setupLog.Fatal().Err(err).Msg("Failed to create Subscription webhook")
+	}
+
+	// Serve the dependency graph shared by the controllers for debugging.
+	err = mgr.AddMetricsServerExtraHandler(
+		"/debug/dependencies",
+		controller.NewDependencyGraphHandler(dependencies),
+	)
+	if err != nil {
+		setupLog.Fatal().Err(err).Msg("Failed to set up dependency graph endpoint")
+	}
+
 	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
 		setupLog.Fatal().Err(err).Msg("Failed to set up health check")
 	}
@@ -0,0 +1,238 @@
+package controller
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/rs/zerolog"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	otcv1alpha1 "github.com/peertech.de/otc-operator/api/v1alpha1"
+	provider "github.com/peertech.de/otc-operator/internal/provider"
+)
+
+const (
+	alarmRuleFinalizerName = "alarmrule.otc.peertech.de/finalizer"
+	alarmRuleRequeueDelay  = 30 * time.Second
+)
+
+// AlarmRuleReconciler reconciles a AlarmRule object
+type AlarmRuleReconciler = ManagedReconciler[
+	*otcv1alpha1.AlarmRule,
+	*provider.AlarmRuleInfo,
+	provider.UpdateAlarmRuleRequest,
+]
+
+func NewAlarmRuleReconciler(
+	c client.Client,
+	logger zerolog.Logger,
+	providers *ProviderCache,
+	dependencies *DependencyGraphCache,
+) *AlarmRuleReconciler {
+	return NewManagedReconciler(c, logger, providers, dependencies, alarmRuleResource)
+}
+
+// +kubebuilder:rbac:groups=otc.peertech.de,resources=alarmrules,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=otc.peertech.de,resources=alarmrules/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=otc.peertech.de,resources=alarmrules/finalizers,verbs=update
+// +kubebuilder:rbac:groups=otc.peertech.de,resources=natgateways,verbs=get;list;watch
+// +kubebuilder:rbac:groups=otc.peertech.de,resources=publicips,verbs=get;list;watch
+// +kubebuilder:rbac:groups=otc.peertech.de,resources=instances,verbs=get;list;watch
+// +kubebuilder:rbac:groups=otc.peertech.de,resources=topics,verbs=get;list;watch
+// +kubebuilder:rbac:groups=otc.peertech.de,resources=providerconfigs,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;create;update;patch
+// +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch;create;update;patch
+
+var alarmRuleResource = ManagedResource[
+	*otcv1alpha1.AlarmRule,
+	*provider.AlarmRuleInfo,
+	provider.UpdateAlarmRuleRequest,
+]{
+	ControllerName: "alarmrule",
+	Name:           "alarm rule",
+	FinalizerName:  alarmRuleFinalizerName,
+	RequeueDelay:   alarmRuleRequeueDelay,
+	// Requeue to keep the observed alarm state current.
+	ReadyRequeueDelay: alarmRuleRequeueDelay,
+
+	New: func() *otcv1alpha1.AlarmRule { return &otcv1alpha1.AlarmRule{} },
+
+	Resolve: func(
+		ctx context.Context,
+		resolver *DependencyResolver,
+		alarmRule *otcv1alpha1.AlarmRule,
+	) error {
+		resolved, err := resolver.ResolveAlarmRuleDependencies(ctx, alarmRule.Spec)
+		if err != nil {
+			return err
+		}
+
+		alarmRule.Status.ResolvedDependencies = resolved
+		return nil
+	},
+
+	// The ID of the watched resource can change if it gets recreated.
+	// Selectors prefer the previously resolved resources to stay stable.
+	Refresh: func(
+		ctx context.Context,
+		resolver *DependencyResolver,
+		alarmRule *otcv1alpha1.AlarmRule,
+	) error {
+		previous := alarmRule.Status.ResolvedDependencies
+		resolver.Prefer(previous.NATGatewayID, previous.PublicIPID, previous.InstanceID)
+		resolver.Prefer(previous.AlarmTopicURNs...)
+		resolver.Prefer(previous.OKTopicURNs...)
+		resolved, err := resolver.ResolveAlarmRuleDependencies(ctx, alarmRule.Spec)
+		if err != nil {
+			return err
+		}
+
+		alarmRule.Status.ResolvedDependencies = resolved
+		return nil
+	},
+
+	Create: func(
+		ctx context.Context,
+		p provider.Provider,
+		alarmRule *otcv1alpha1.AlarmRule,
+	) (string, error) {
+		spec := alarmRule.Spec
+		resolved := alarmRule.Status.ResolvedDependencies
+		dimensionName, dimensionValue := alarmRuleDimension(resolved)
+		resp, err := p.CreateAlarmRule(ctx, provider.CreateAlarmRuleRequest{
+			Name:               alarmRule.Name,
+			Description:        spec.Description,
+			Namespace:          spec.Metric.Namespace,
+			MetricName:         spec.Metric.Name,
+			ComparisonOperator: string(spec.Condition.ComparisonOperator),
+			Value:              spec.Condition.Value,
+			Unit:               spec.Condition.Unit,
+			Filter:             string(spec.Condition.Filter),
+			Period:             spec.Condition.Period,
+			Count:              spec.Condition.Count,
+			SuppressDuration:   spec.Condition.SuppressDuration,
+			Level:              int(spec.Level),
+			Enabled:            spec.Enabled,
+			AlarmTopicURNs:     resolved.AlarmTopicURNs,
+			OKTopicURNs:        resolved.OKTopicURNs,
+			DimensionName:      dimensionName,
+			DimensionValue:     dimensionValue,
+		})
+		return resp.ID, err
+	},
+
+	Observe: func(
+		ctx context.Context,
+		p provider.Provider,
+		alarmRule *otcv1alpha1.AlarmRule,
+	) (*provider.AlarmRuleInfo, error) {
+		info, err := p.GetAlarmRule(ctx, alarmRule.Status.ExternalID)
+		if err != nil {
+			return nil, err
+		}
+
+		// Report the observed alarm state.
+		alarmRule.Status.AlarmState = info.AlarmState
+		return info, nil
+	},
+
+	Reset: func(alarmRule *otcv1alpha1.AlarmRule) {
+		alarmRule.Status.AlarmState = ""
+	},
+
+	// The watched resource and the notified topics of an alarm rule can not
+	// be changed, so the rule is replaced if any of them got recreated.
+	Replace: func(
+		logger zerolog.Logger,
+		alarmRule *otcv1alpha1.AlarmRule,
+		info *provider.AlarmRuleInfo,
+	) bool {
+		resolved := alarmRule.Status.ResolvedDependencies
+		needsReplace := false
+
+		if _, dimensionValue := alarmRuleDimension(resolved); dimensionValue != info.DimensionValue {
+			logger.Info().
+				Str("current", info.DimensionValue).
+				Str("desired", dimensionValue).
+				Msg("Drift detected in watched resource")
+
+			needsReplace = true
+		}
+
+		if !slices.Equal(resolved.AlarmTopicURNs, info.AlarmTopicURNs) {
+			logger.Info().
+				Strs("current", info.AlarmTopicURNs).
+				Strs("desired", resolved.AlarmTopicURNs).
+				Msg("Drift detected in alarm actions")
+
+			needsReplace = true
+		}
+
+		if !slices.Equal(resolved.OKTopicURNs, info.OKTopicURNs) {
+			logger.Info().
+				Strs("current", info.OKTopicURNs).
+				Strs("desired", resolved.OKTopicURNs).
+				Msg("Drift detected in ok actions")
+
+			needsReplace = true
+		}
+
+		return needsReplace
+	},
+
+	Diff: func(
+		logger zerolog.Logger,
+		alarmRule *otcv1alpha1.AlarmRule,
+		info *provider.AlarmRuleInfo,
+	) (provider.UpdateAlarmRuleRequest, bool) {
+		var updateReq provider.UpdateAlarmRuleRequest
+		needsUpdate := false
+
+		if alarmRule.Spec.Enabled != info.Enabled {
+			logger.Info().
+				Bool("current", info.Enabled).
+				Bool("desired", alarmRule.Spec.Enabled).
+				Msg("Drift detected in enabled")
+
+			updateReq.Enabled = alarmRule.Spec.Enabled
+			needsUpdate = true
+		}
+
+		return updateReq, needsUpdate
+	},
+
+	Update: func(
+		ctx context.Context,
+		p provider.Provider,
+		alarmRule *otcv1alpha1.AlarmRule,
+		req provider.UpdateAlarmRuleRequest,
+	) error {
+		return p.UpdateAlarmRule(ctx, alarmRule.Status.ExternalID, req)
+	},
+
+	ConnectionDetails: func(
+		_ *otcv1alpha1.AlarmRule,
+		info *provider.AlarmRuleInfo,
+	) map[string]string {
+		return map[string]string{
+			"externalID": info.ID,
+		}
+	},
+
+	Delete: func(ctx context.Context, p provider.Provider, alarmRule *otcv1alpha1.AlarmRule) error {
+		return p.DeleteAlarmRule(ctx, alarmRule.Status.ExternalID)
+	},
+}
+
+// alarmRuleDimension returns the metric dimension of the resolved resource.
+func alarmRuleDimension(resolved otcv1alpha1.AlarmRuleDependenciesResolved) (string, string) {
+	switch {
+	case resolved.NATGatewayID != "":
+		return "nat_gateway_id", resolved.NATGatewayID
+	case resolved.PublicIPID != "":
+		return "publicip_id", resolved.PublicIPID
+	default:
+		return "instance_id", resolved.InstanceID
+	}
+}
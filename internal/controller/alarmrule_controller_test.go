@@ -0,0 +1,172 @@
+package controller
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	otcv1alpha1 "github.com/peertech.de/otc-operator/api/v1alpha1"
+)
+
+const (
+	testAlarmRuleName = "cpu"
+	testAlarmTopicURN = "urn:smn:eu-de:project:alerts"
+)
+
+// newTestAlarmRuleInstance returns a ready Instance with the given external
+// ID, which is watched by the test alarm rule.
+func newTestAlarmRuleInstance(externalID string) *otcv1alpha1.Instance {
+	return &otcv1alpha1.Instance{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      testInstanceName,
+			Namespace: testNamespace,
+		},
+		Status: otcv1alpha1.InstanceStatus{
+			ExternalID: externalID,
+			Conditions: []metav1.Condition{{
+				Type:               condReady,
+				Status:             metav1.ConditionTrue,
+				Reason:             reasonReady,
+				LastTransitionTime: metav1.Now(),
+			}},
+		},
+	}
+}
+
+func newTestAlarmRule() *otcv1alpha1.AlarmRule {
+	topicURN := testAlarmTopicURN
+	return &otcv1alpha1.AlarmRule{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       testAlarmRuleName,
+			Namespace:  testNamespace,
+			Finalizers: []string{alarmRuleFinalizerName},
+		},
+		Spec: otcv1alpha1.AlarmRuleSpec{
+			ProviderConfigRef: testProviderConfigRef,
+			Metric: otcv1alpha1.AlarmRuleMetric{
+				Namespace: "SYS.ECS",
+				Name:      "cpu_util",
+			},
+			Resource: otcv1alpha1.AlarmRuleResource{
+				Instance: &otcv1alpha1.InstanceDependency{
+					InstanceRef: &corev1.LocalObjectReference{Name: testInstanceName},
+				},
+			},
+			Condition: otcv1alpha1.AlarmRuleCondition{
+				ComparisonOperator: ">",
+				Value:              "80",
+				Filter:             otcv1alpha1.AlarmFilterAverage,
+				Period:             300,
+				Count:              3,
+			},
+			Enabled:      true,
+			AlarmActions: []otcv1alpha1.TopicDependency{{TopicURN: &topicURN}},
+		},
+	}
+}
+
+func TestAlarmRuleLifecycle(t *testing.T) {
+	p := &fakeProvider{}
+	r := newTestManagedReconciler(
+		t,
+		NewAlarmRuleReconciler,
+		p,
+		newTestAlarmRule(),
+		newTestAlarmRuleInstance("instance-a"),
+	)
+
+	// The rule is created for the referenced instance.
+	_, alarmRule := reconcileTestManaged(t, r, newTestAlarmRule())
+	if len(p.alarmRules.created) != 1 || p.alarmRules.created[0].DimensionName != "instance_id" ||
+		p.alarmRules.created[0].DimensionValue != "instance-a" ||
+		!slices.Equal(p.alarmRules.created[0].AlarmTopicURNs, []string{testAlarmTopicURN}) {
+		t.Fatalf("Expected rule to be created for instance %q, got %+v", "instance-a", p.alarmRules.created)
+	}
+	if alarmRule.Status.ExternalID != "alarm-1" {
+		t.Fatalf("Expected externalID %q, got %q", "alarm-1", alarmRule.Status.ExternalID)
+	}
+
+	// The rule is ready as soon as it exists and reports the alarm state.
+	_, alarmRule = reconcileTestManaged(t, r, alarmRule)
+	expectCondition(t, alarmRule, condReady, metav1.ConditionTrue, reasonProvisioned)
+	if alarmRule.Status.AlarmState != "insufficient_data" {
+		t.Fatalf("Expected alarm state %q, got %q", "insufficient_data", alarmRule.Status.AlarmState)
+	}
+
+	// A rule disabled out-of-band is enabled again.
+	p.alarmRules.info.Enabled = false
+	_, alarmRule = reconcileTestManaged(t, r, alarmRule)
+	if len(p.alarmRules.updated) != 1 || !p.alarmRules.updated[0].Enabled {
+		t.Fatalf("Expected rule to be enabled, got %+v", p.alarmRules.updated)
+	}
+
+	_, alarmRule = reconcileTestManaged(t, r, alarmRule)
+	if len(p.alarmRules.updated) != 1 {
+		t.Fatalf("Expected no further update, got %+v", p.alarmRules.updated)
+	}
+
+	// A rule disabled in the spec is stopped.
+	updateTestManaged(t, r, alarmRule, func(alarmRule *otcv1alpha1.AlarmRule) {
+		alarmRule.Spec.Enabled = false
+	})
+	_, alarmRule = reconcileTestManaged(t, r, alarmRule)
+	if len(p.alarmRules.updated) != 2 || p.alarmRules.updated[1].Enabled {
+		t.Fatalf("Expected rule to be disabled, got %+v", p.alarmRules.updated)
+	}
+	_, alarmRule = reconcileTestManaged(t, r, alarmRule)
+	expectCondition(t, alarmRule, condReady, metav1.ConditionFalse, reasonStopped)
+
+	// The rule of a recreated instance is replaced, as the watched resource
+	// can not be changed.
+	instance := &otcv1alpha1.Instance{}
+	key := client.ObjectKey{Namespace: testNamespace, Name: testInstanceName}
+	if err := r.Get(context.Background(), key, instance); err != nil {
+		t.Fatal(err)
+	}
+	instance.Status.ExternalID = "instance-b"
+	if err := r.Update(context.Background(), instance); err != nil {
+		t.Fatal(err)
+	}
+	_, alarmRule = reconcileTestManaged(t, r, alarmRule)
+	if !slices.Equal(p.alarmRules.deleted, []string{"alarm-1"}) || alarmRule.Status.ExternalID != "" {
+		t.Fatalf("Expected rule %q to be replaced, got %v", "alarm-1", p.alarmRules.deleted)
+	}
+
+	_, alarmRule = reconcileTestManaged(t, r, alarmRule)
+	if len(p.alarmRules.created) != 2 || p.alarmRules.created[1].DimensionValue != "instance-b" || p.alarmRules.created[1].Enabled {
+		t.Fatalf("Expected disabled rule to be created for instance %q, got %+v", "instance-b", p.alarmRules.created)
+	}
+
+	deleteTestManaged(t, r, alarmRule)
+	if !slices.Equal(p.alarmRules.deleted, []string{"alarm-1", "alarm-2"}) {
+		t.Fatalf("Expected external deletion of %q, got %v", "alarm-2", p.alarmRules.deleted)
+	}
+}
+
+func TestAlarmRuleNotFound(t *testing.T) {
+	alarmRule := newTestAlarmRule()
+	alarmRule.Status.ExternalID = "alarm-0"
+	alarmRule.Status.LastAppliedSpec = alarmRule.Spec.DeepCopy()
+
+	p := &fakeProvider{}
+	r := newTestManagedReconciler(
+		t,
+		NewAlarmRuleReconciler,
+		p,
+		alarmRule,
+		newTestAlarmRuleInstance("instance-a"),
+	)
+
+	// The rule deleted out-of-band is created again.
+	_, alarmRule = reconcileTestManaged(t, r, alarmRule)
+	expectRecreate(t, alarmRule)
+
+	_, alarmRule = reconcileTestManaged(t, r, alarmRule)
+	if len(p.alarmRules.created) != 1 || alarmRule.Status.ExternalID != "alarm-1" {
+		t.Fatalf("Expected rule to be recreated, got %+v", p.alarmRules.created)
+	}
+}
@@ -0,0 +1,127 @@
+package controller
+
+import (
+	"context"
+	"time"
+
+	"github.com/rs/zerolog"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	otcv1alpha1 "github.com/peertech.de/otc-operator/api/v1alpha1"
+	provider "github.com/peertech.de/otc-operator/internal/provider"
+)
+
+const (
+	bandwidthFinalizerName = "bandwidth.otc.peertech.de/finalizer"
+	bandwidthRequeueDelay  = 30 * time.Second
+)
+
+// BandwidthReconciler reconciles a Bandwidth object
+type BandwidthReconciler = ManagedReconciler[
+	*otcv1alpha1.Bandwidth,
+	*provider.BandwidthInfo,
+	provider.UpdateBandwidthRequest,
+]
+
+func NewBandwidthReconciler(
+	c client.Client,
+	logger zerolog.Logger,
+	providers *ProviderCache,
+	dependencies *DependencyGraphCache,
+) *BandwidthReconciler {
+	return NewManagedReconciler(c, logger, providers, dependencies, bandwidthResource)
+}
+
+// +kubebuilder:rbac:groups=otc.peertech.de,resources=bandwidths,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=otc.peertech.de,resources=bandwidths/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=otc.peertech.de,resources=bandwidths/finalizers,verbs=update
+// +kubebuilder:rbac:groups=otc.peertech.de,resources=providerconfigs,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;create;update;patch
+// +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch;create;update;patch
+
+var bandwidthResource = ManagedResource[
+	*otcv1alpha1.Bandwidth,
+	*provider.BandwidthInfo,
+	provider.UpdateBandwidthRequest,
+]{
+	ControllerName: "bandwidth",
+	Name:           "bandwidth",
+	FinalizerName:  bandwidthFinalizerName,
+	RequeueDelay:   bandwidthRequeueDelay,
+
+	New: func() *otcv1alpha1.Bandwidth { return &otcv1alpha1.Bandwidth{} },
+
+	Create: func(
+		ctx context.Context,
+		p provider.Provider,
+		bandwidth *otcv1alpha1.Bandwidth,
+	) (string, error) {
+		resp, err := p.CreateBandwidth(ctx, provider.CreateBandwidthRequest{
+			Name:       bandwidth.GetName(),
+			Size:       bandwidth.Spec.Size,
+			ChargeMode: bandwidth.Spec.ChargeMode,
+		})
+		return resp.ID, err
+	},
+
+	Observe: func(
+		ctx context.Context,
+		p provider.Provider,
+		bandwidth *otcv1alpha1.Bandwidth,
+	) (*provider.BandwidthInfo, error) {
+		info, err := p.GetBandwidth(ctx, bandwidth.Status.ExternalID)
+		if err != nil {
+			return nil, err
+		}
+
+		bandwidth.Status.PublicIPIDs = info.PublicIPIDs
+		return info, nil
+	},
+
+	Diff: func(
+		logger zerolog.Logger,
+		bandwidth *otcv1alpha1.Bandwidth,
+		info *provider.BandwidthInfo,
+	) (provider.UpdateBandwidthRequest, bool) {
+		var updateReq provider.UpdateBandwidthRequest
+		needsUpdate := false
+
+		// The size is compared against the provider, as it can also be changed
+		// out-of-band.
+		if bandwidth.Spec.Size != info.Size {
+			logger.Info().
+				Int("current", info.Size).
+				Int("desired", bandwidth.Spec.Size).
+				Msg("Drift detected in size")
+
+			updateReq.Size = bandwidth.Spec.Size
+			needsUpdate = true
+		}
+
+		return updateReq, needsUpdate
+	},
+
+	Update: func(
+		ctx context.Context,
+		p provider.Provider,
+		bandwidth *otcv1alpha1.Bandwidth,
+		req provider.UpdateBandwidthRequest,
+	) error {
+		return p.UpdateBandwidth(ctx, bandwidth.Status.ExternalID, req)
+	},
+
+	ConnectionDetails: func(
+		_ *otcv1alpha1.Bandwidth,
+		info *provider.BandwidthInfo,
+	) map[string]string {
+		return map[string]string{
+			"externalID": info.ID,
+		}
+	},
+
+	ReferenceChecks: ReferenceChecksFor("Bandwidth"),
+
+	Delete: func(ctx context.Context, p provider.Provider, bandwidth *otcv1alpha1.Bandwidth) error {
+		return p.DeleteBandwidth(ctx, bandwidth.Status.ExternalID)
+	},
+}
@@ -0,0 +1,101 @@
+package controller
+
+import (
+	"slices"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	otcv1alpha1 "github.com/peertech.de/otc-operator/api/v1alpha1"
+)
+
+const (
+	testBandwidthName = "shared"
+	testBandwidthID   = "bandwidth-id"
+)
+
+func newTestBandwidth() *otcv1alpha1.Bandwidth {
+	return &otcv1alpha1.Bandwidth{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       testBandwidthName,
+			Namespace:  testNamespace,
+			Finalizers: []string{bandwidthFinalizerName},
+		},
+		Spec: otcv1alpha1.BandwidthSpec{
+			ProviderConfigRef: testProviderConfigRef,
+			Size:              100,
+			ChargeMode:        otcv1alpha1.BandwidthChargeModeTraffic,
+		},
+	}
+}
+
+func TestBandwidthLifecycle(t *testing.T) {
+	p := &fakeProvider{}
+	r := newTestManagedReconciler(t, NewBandwidthReconciler, p, newTestBandwidth())
+
+	_, bandwidth := reconcileTestManaged(t, r, newTestBandwidth())
+	if len(p.bandwidths.created) != 1 || p.bandwidths.created[0].Size != 100 ||
+		p.bandwidths.created[0].ChargeMode != otcv1alpha1.BandwidthChargeModeTraffic {
+		t.Fatalf("Expected bandwidth to be created, got %+v", p.bandwidths.created)
+	}
+	if bandwidth.Status.ExternalID != testBandwidthID {
+		t.Fatalf("Expected externalID %q, got %q", testBandwidthID, bandwidth.Status.ExternalID)
+	}
+
+	// The bandwidth is not ready while it is created.
+	_, bandwidth = reconcileTestManaged(t, r, bandwidth)
+	expectCondition(t, bandwidth, condReady, metav1.ConditionFalse, reasonProvisioning)
+
+	// The public IPs joining the bandwidth are reported.
+	p.bandwidths.info.Status = "NORMAL"
+	p.bandwidths.info.PublicIPIDs = []string{testPublicIPID}
+	_, bandwidth = reconcileTestManaged(t, r, bandwidth)
+	expectCondition(t, bandwidth, condReady, metav1.ConditionTrue, reasonProvisioned)
+	if !slices.Equal(bandwidth.Status.PublicIPIDs, []string{testPublicIPID}) {
+		t.Fatalf("Expected public IP %q, got %v", testPublicIPID, bandwidth.Status.PublicIPIDs)
+	}
+
+	// A changed size is applied in place.
+	updateTestManaged(t, r, bandwidth, func(bandwidth *otcv1alpha1.Bandwidth) {
+		bandwidth.Spec.Size = 200
+	})
+	_, bandwidth = reconcileTestManaged(t, r, bandwidth)
+	if len(p.bandwidths.updated) != 1 || p.bandwidths.updated[0].Size != 200 {
+		t.Fatalf("Expected size to be updated, got %+v", p.bandwidths.updated)
+	}
+
+	// A size changed out-of-band is reverted.
+	p.bandwidths.info.Size = 50
+	_, bandwidth = reconcileTestManaged(t, r, bandwidth)
+	if len(p.bandwidths.updated) != 2 || p.bandwidths.updated[1].Size != 200 {
+		t.Fatalf("Expected size to be reverted, got %+v", p.bandwidths.updated)
+	}
+
+	_, bandwidth = reconcileTestManaged(t, r, bandwidth)
+	if len(p.bandwidths.updated) != 2 {
+		t.Fatalf("Expected no further update, got %+v", p.bandwidths.updated)
+	}
+
+	deleteTestManaged(t, r, bandwidth)
+	if !slices.Equal(p.bandwidths.deleted, []string{testBandwidthID}) {
+		t.Fatalf("Expected external deletion of %q, got %v", testBandwidthID, p.bandwidths.deleted)
+	}
+}
+
+func TestBandwidthNotFound(t *testing.T) {
+	bandwidth := newTestBandwidth()
+	bandwidth.Status.ExternalID = testBandwidthID
+	bandwidth.Status.LastAppliedSpec = bandwidth.Spec.DeepCopy()
+
+	p := &fakeProvider{}
+	r := newTestManagedReconciler(t, NewBandwidthReconciler, p, bandwidth)
+
+	// The bandwidth deleted out-of-band is created again.
+	_, bandwidth = reconcileTestManaged(t, r, bandwidth)
+	expectRecreate(t, bandwidth)
+
+	_, bandwidth = reconcileTestManaged(t, r, bandwidth)
+	if len(p.bandwidths.created) != 1 || bandwidth.Status.ExternalID != testBandwidthID {
+		t.Fatalf("Expected bandwidth to be recreated, got %+v", p.bandwidths.created)
+	}
+}
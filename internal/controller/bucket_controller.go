@@ -0,0 +1,230 @@
+package controller
+
+import (
+	"context"
+	"time"
+
+	"github.com/rs/zerolog"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	otcv1alpha1 "github.com/peertech.de/otc-operator/api/v1alpha1"
+	provider "github.com/peertech.de/otc-operator/internal/provider"
+)
+
+const (
+	bucketFinalizerName = "bucket.otc.peertech.de/finalizer"
+	bucketRequeueDelay  = 30 * time.Second
+)
+
+// BucketReconciler reconciles a Bucket object
+type BucketReconciler = ManagedReconciler[
+	*otcv1alpha1.Bucket,
+	*provider.BucketInfo,
+	provider.UpdateBucketRequest,
+]
+
+func NewBucketReconciler(
+	c client.Client,
+	logger zerolog.Logger,
+	providers *ProviderCache,
+	dependencies *DependencyGraphCache,
+) *BucketReconciler {
+	return NewManagedReconciler(c, logger, providers, dependencies, bucketResource)
+}
+
+// +kubebuilder:rbac:groups=otc.peertech.de,resources=buckets,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=otc.peertech.de,resources=buckets/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=otc.peertech.de,resources=buckets/finalizers,verbs=update
+// +kubebuilder:rbac:groups=otc.peertech.de,resources=kmskeys,verbs=get;list;watch
+// +kubebuilder:rbac:groups=otc.peertech.de,resources=providerconfigs,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;create;update;patch
+// +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch;create;update;patch
+
+var bucketResource = ManagedResource[
+	*otcv1alpha1.Bucket,
+	*provider.BucketInfo,
+	provider.UpdateBucketRequest,
+]{
+	ControllerName: "bucket",
+	Name:           "bucket",
+	FinalizerName:  bucketFinalizerName,
+	RequeueDelay:   bucketRequeueDelay,
+
+	New: func() *otcv1alpha1.Bucket { return &otcv1alpha1.Bucket{} },
+
+	Resolve: func(
+		ctx context.Context,
+		resolver *DependencyResolver,
+		bucket *otcv1alpha1.Bucket,
+	) error {
+		resolved, err := resolver.ResolveBucketDependencies(ctx, bucket.Spec)
+		if err != nil {
+			return err
+		}
+
+		bucket.Status.ResolvedDependencies = resolved
+		return nil
+	},
+
+	// The ID of a referenced KMS key can change if it gets recreated.
+	// Selectors prefer the previously resolved resources to stay stable.
+	Refresh: func(
+		ctx context.Context,
+		resolver *DependencyResolver,
+		bucket *otcv1alpha1.Bucket,
+	) error {
+		resolver.Prefer(bucket.Status.ResolvedDependencies.KMSKeyID)
+		resolved, err := resolver.ResolveBucketDependencies(ctx, bucket.Spec)
+		if err != nil {
+			return err
+		}
+
+		bucket.Status.ResolvedDependencies = resolved
+		return nil
+	},
+
+	Create: func(
+		ctx context.Context,
+		p provider.Provider,
+		bucket *otcv1alpha1.Bucket,
+	) (string, error) {
+		resp, err := p.CreateBucket(ctx, provider.CreateBucketRequest{
+			Name:         bucket.Name,
+			BucketConfig: bucketConfig(bucket.Spec, bucket.Status.ResolvedDependencies),
+		})
+		return resp.ID, err
+	},
+
+	Observe: func(
+		ctx context.Context,
+		p provider.Provider,
+		bucket *otcv1alpha1.Bucket,
+	) (*provider.BucketInfo, error) {
+		info, err := p.GetBucket(ctx, bucket.Status.ExternalID)
+		if err != nil {
+			return nil, err
+		}
+
+		// Report the observed location of the bucket.
+		bucket.Status.Region = info.Region
+		bucket.Status.Endpoint = info.Endpoint
+		return info, nil
+	},
+
+	// Diff compares the desired configuration with the observed one. As the
+	// configuration is always applied as a whole, the update request holds
+	// all of it.
+	Diff: func(
+		logger zerolog.Logger,
+		bucket *otcv1alpha1.Bucket,
+		info *provider.BucketInfo,
+	) (provider.UpdateBucketRequest, bool) {
+		updateReq := provider.UpdateBucketRequest{
+			BucketConfig: bucketConfig(bucket.Spec, bucket.Status.ResolvedDependencies),
+		}
+		needsUpdate := false
+
+		if bucket.Spec.Versioning != info.VersioningEnabled() {
+			logger.Info().
+				Str("current", info.Versioning).
+				Bool("desired", bucket.Spec.Versioning).
+				Msg("Drift detected in versioning")
+
+			needsUpdate = true
+		}
+
+		if !equality.Semantic.DeepEqual(bucket.Spec.LifecycleRules, info.LifecycleRules) {
+			logger.Info().Msg("Drift detected in lifecycle rules")
+			needsUpdate = true
+		}
+
+		if !equalBucketEncryption(updateReq.Encryption, info.Encryption) {
+			logger.Info().Msg("Drift detected in encryption")
+			needsUpdate = true
+		}
+
+		if !equality.Semantic.DeepEqual(bucket.Spec.CORSRules, info.CORSRules) {
+			logger.Info().Msg("Drift detected in CORS rules")
+			needsUpdate = true
+		}
+
+		// OBS normalizes the policy document, so a change is detected against
+		// the last applied policy. A policy removed out-of-band is detected
+		// too.
+		lastAppliedPolicy := bucket.Status.LastAppliedSpec.Policy
+		if !equalPolicy(bucket.Spec.Policy, lastAppliedPolicy) ||
+			(bucket.Spec.Policy == "") != (info.Policy == "") {
+			logger.Info().Msg("Drift detected in policy")
+			needsUpdate = true
+		}
+
+		return updateReq, needsUpdate
+	},
+
+	Update: func(
+		ctx context.Context,
+		p provider.Provider,
+		bucket *otcv1alpha1.Bucket,
+		req provider.UpdateBucketRequest,
+	) error {
+		return p.UpdateBucket(ctx, bucket.Status.ExternalID, req)
+	},
+
+	ConnectionDetails: func(
+		_ *otcv1alpha1.Bucket,
+		info *provider.BucketInfo,
+	) map[string]string {
+		return map[string]string{
+			"externalID": info.Name,
+			"bucket":     info.Name,
+			"region":     info.Region,
+			"endpoint":   info.Endpoint,
+		}
+	},
+
+	Delete: func(ctx context.Context, p provider.Provider, bucket *otcv1alpha1.Bucket) error {
+		return p.DeleteBucket(ctx, bucket.Status.ExternalID, bucket.Spec.ForceDestroy)
+	},
+}
+
+// bucketConfig returns the provider configuration for the bucket spec.
+func bucketConfig(
+	spec otcv1alpha1.BucketSpec,
+	resolved otcv1alpha1.BucketDependenciesResolved,
+) provider.BucketConfig {
+	return provider.BucketConfig{
+		Versioning:     spec.Versioning,
+		LifecycleRules: spec.LifecycleRules,
+		Encryption:     bucketEncryption(spec, resolved),
+		CORSRules:      spec.CORSRules,
+		Policy:         spec.Policy,
+	}
+}
+
+// bucketEncryption returns the desired encryption with the resolved KMS key.
+func bucketEncryption(
+	spec otcv1alpha1.BucketSpec,
+	resolved otcv1alpha1.BucketDependenciesResolved,
+) *provider.BucketEncryption {
+	if spec.Encryption == nil {
+		return nil
+	}
+	return &provider.BucketEncryption{
+		Algorithm: spec.Encryption.Algorithm,
+		KMSKeyID:  resolved.KMSKeyID,
+	}
+}
+
+// equalBucketEncryption compares the desired and observed encryption. OBS
+// reports the default key if no key is given, so the key is only compared
+// if one is desired.
+func equalBucketEncryption(desired, observed *provider.BucketEncryption) bool {
+	if desired == nil || observed == nil {
+		return desired == nil && observed == nil
+	}
+	if desired.Algorithm != observed.Algorithm {
+		return false
+	}
+	return desired.KMSKeyID == "" || desired.KMSKeyID == observed.KMSKeyID
+}
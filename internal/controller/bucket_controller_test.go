@@ -0,0 +1,95 @@
+package controller
+
+import (
+	"slices"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	otcv1alpha1 "github.com/peertech.de/otc-operator/api/v1alpha1"
+)
+
+const testBucketName = "bucket"
+
+func newTestBucket() *otcv1alpha1.Bucket {
+	return &otcv1alpha1.Bucket{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       testBucketName,
+			Namespace:  testNamespace,
+			Finalizers: []string{bucketFinalizerName},
+		},
+		Spec: otcv1alpha1.BucketSpec{
+			ProviderConfigRef: testProviderConfigRef,
+			Versioning:        true,
+			ForceDestroy:      true,
+		},
+	}
+}
+
+func TestBucketLifecycle(t *testing.T) {
+	p := &fakeProvider{}
+	r := newTestManagedReconciler(t, NewBucketReconciler, p, newTestBucket())
+
+	// The bucket is created with versioning.
+	_, bucket := reconcileTestManaged(t, r, newTestBucket())
+	if len(p.buckets.created) != 1 || !p.buckets.created[0].Versioning {
+		t.Fatalf("Expected bucket to be created with versioning, got %+v", p.buckets.created)
+	}
+	if bucket.Status.ExternalID != testBucketName {
+		t.Fatalf("Expected externalID %q, got %q", testBucketName, bucket.Status.ExternalID)
+	}
+
+	// The bucket is ready as soon as it exists.
+	_, bucket = reconcileTestManaged(t, r, bucket)
+	expectCondition(t, bucket, condReady, metav1.ConditionTrue, reasonProvisioned)
+	if len(p.buckets.updated) != 0 || bucket.Status.Region != "eu-de" {
+		t.Fatalf("Expected observed bucket without update, got %+v and %+v", bucket.Status, p.buckets.updated)
+	}
+
+	// An added lifecycle rule is applied with the whole configuration.
+	updateTestManaged(t, r, bucket, func(bucket *otcv1alpha1.Bucket) {
+		bucket.Spec.LifecycleRules = []otcv1alpha1.BucketLifecycleRule{
+			{ID: "expire-logs", Prefix: "logs/", Enabled: true, ExpirationDays: 30},
+		}
+	})
+	_, bucket = reconcileTestManaged(t, r, bucket)
+	if len(p.buckets.updated) != 1 || len(p.buckets.updated[0].LifecycleRules) != 1 || !p.buckets.updated[0].Versioning {
+		t.Fatalf("Expected lifecycle rules to be updated, got %+v", p.buckets.updated)
+	}
+
+	// Versioning suspended out-of-band is enabled again.
+	p.buckets.info.Versioning = "Suspended"
+	_, bucket = reconcileTestManaged(t, r, bucket)
+	if len(p.buckets.updated) != 2 || !p.buckets.updated[1].Versioning {
+		t.Fatalf("Expected versioning to be enabled, got %+v", p.buckets.updated)
+	}
+
+	_, bucket = reconcileTestManaged(t, r, bucket)
+	if len(p.buckets.updated) != 2 {
+		t.Fatalf("Expected no further update, got %+v", p.buckets.updated)
+	}
+
+	// The bucket is deleted with its objects.
+	deleteTestManaged(t, r, bucket)
+	if !slices.Equal(p.buckets.deleted, []string{testBucketName}) || !p.forceDestroy {
+		t.Fatalf("Expected forced deletion of %q, got %v", testBucketName, p.buckets.deleted)
+	}
+}
+
+func TestBucketNotFound(t *testing.T) {
+	bucket := newTestBucket()
+	bucket.Status.ExternalID = testBucketName
+	bucket.Status.LastAppliedSpec = bucket.Spec.DeepCopy()
+
+	p := &fakeProvider{}
+	r := newTestManagedReconciler(t, NewBucketReconciler, p, bucket)
+
+	// The bucket deleted out-of-band is created again.
+	_, bucket = reconcileTestManaged(t, r, bucket)
+	expectRecreate(t, bucket)
+
+	_, bucket = reconcileTestManaged(t, r, bucket)
+	if len(p.buckets.created) != 1 || bucket.Status.ExternalID != testBucketName {
+		t.Fatalf("Expected bucket to be recreated, got %+v", p.buckets.created)
+	}
+}
@@ -0,0 +1,150 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	otcv1alpha1 "github.com/peertech.de/otc-operator/api/v1alpha1"
+)
+
+// CascadingObject is a ManagedObject which can be deleted together with the
+// resources it depends on.
+type CascadingObject interface {
+	ManagedObject
+
+	// GetDeletionPolicy returns whether the object is deleted together with
+	// its dependencies.
+	GetDeletionPolicy() otcv1alpha1.DeletionPolicy
+}
+
+// isCascading returns whether the object is deleted together with its
+// dependencies.
+func isCascading(obj ManagedObject) bool {
+	cascading, ok := obj.(CascadingObject)
+	return ok && cascading.GetDeletionPolicy() == otcv1alpha1.DeletionPolicyCascade
+}
+
+// reconcileOwnerReferences sets owner references from the object to its
+// dependencies if the object cascades, and removes them otherwise. The
+// object is updated and requeued if its owner references changed.
+func (r *ManagedReconciler[O, I, U]) reconcileOwnerReferences(
+	ctx context.Context,
+	rc *Reconciler,
+	obj O,
+) (bool, ctrl.Result, error) {
+	if r.resource.Dependencies == nil {
+		return false, ctrl.Result{}, nil
+	}
+
+	cascade := isCascading(obj)
+	ownerReferences := append([]metav1.OwnerReference{}, obj.GetOwnerReferences()...)
+
+	for _, dep := range r.resource.Dependencies(obj) {
+		if !cascade {
+			owned, err := controllerutil.HasOwnerReference(obj.GetOwnerReferences(), dep, r.Scheme())
+			if err != nil {
+				return true, ctrl.Result{}, err
+			}
+			if owned {
+				if err := controllerutil.RemoveOwnerReference(dep, obj, r.Scheme()); err != nil {
+					return true, ctrl.Result{}, err
+				}
+			}
+			continue
+		}
+
+		// Missing dependencies are reported by the dependency resolution.
+		if err := r.Get(ctx, client.ObjectKeyFromObject(dep), dep); err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return true, ctrl.Result{}, err
+		}
+		if err := controllerutil.SetOwnerReference(dep, obj, r.Scheme()); err != nil {
+			return true, ctrl.Result{}, err
+		}
+	}
+
+	if equality.Semantic.DeepEqual(ownerReferences, obj.GetOwnerReferences()) {
+		return false, ctrl.Result{}, nil
+	}
+
+	rc.logger.Debug().Bool("cascade", cascade).Msg("Updating owner references")
+	if err := r.Update(ctx, obj); err != nil {
+		rc.logger.Debug().Msg("Failed to update owner references")
+		return true, ctrl.Result{}, err
+	}
+
+	return true, ctrl.Result{Requeue: true}, nil
+}
+
+// deleteOwnedDependents deletes the dependents which cascade from the object.
+// It returns the names of the dependents which are not gone yet.
+func (r *ManagedReconciler[O, I, U]) deleteOwnedDependents(
+	ctx context.Context,
+	rc *Reconciler,
+	obj O,
+) ([]string, error) {
+	var pending []string
+
+	for _, newList := range r.resource.Dependents {
+		list := newList()
+		if err := r.List(ctx, list, client.InNamespace(obj.GetNamespace())); err != nil {
+			return nil, fmt.Errorf("failed to list dependents: %w", err)
+		}
+		items, err := meta.ExtractList(list)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, item := range items {
+			dependent, ok := item.(client.Object)
+			if !ok || !isOwnedBy(dependent, obj) {
+				continue
+			}
+			pending = append(pending, dependent.GetName())
+			if !dependent.GetDeletionTimestamp().IsZero() {
+				continue
+			}
+
+			rc.logger.Info().
+				Str("dependent", dependent.GetName()).
+				Msg("Deleting dependent")
+			if err := r.Delete(ctx, dependent); client.IgnoreNotFound(err) != nil {
+				return nil, fmt.Errorf("failed to delete dependent %s: %w", dependent.GetName(), err)
+			}
+		}
+	}
+
+	return pending, nil
+}
+
+// isOwnedBy returns whether the object has an owner reference to the owner.
+func isOwnedBy(obj, owner client.Object) bool {
+	for _, ref := range obj.GetOwnerReferences() {
+		if ref.UID == owner.GetUID() {
+			return true
+		}
+	}
+	return false
+}
+
+// isLocalReference returns whether the reference points to the namespace of
+// the object. Owner references can not cross namespaces.
+func isLocalReference(obj client.Object, ref *otcv1alpha1.NamespacedObjectReference) bool {
+	return ref.Namespace == "" || ref.Namespace == obj.GetNamespace()
+}
+
+// dependencyMeta returns the metadata of a dependency in the namespace of the
+// object.
+func dependencyMeta(obj client.Object, name string) metav1.ObjectMeta {
+	return metav1.ObjectMeta{Name: name, Namespace: obj.GetNamespace()}
+}
@@ -0,0 +1,139 @@
+package controller
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/rs/zerolog"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	otcv1alpha1 "github.com/peertech.de/otc-operator/api/v1alpha1"
+)
+
+const (
+	testSubnetName = "subnet"
+	testNetworkUID = "network-uid"
+)
+
+func newTestCascadingSubnet(policy otcv1alpha1.DeletionPolicy) *otcv1alpha1.Subnet {
+	return &otcv1alpha1.Subnet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       testSubnetName,
+			Namespace:  testNamespace,
+			Finalizers: []string{subnetFinalizerName},
+		},
+		Spec: otcv1alpha1.SubnetSpec{
+			ProviderConfigRef: otcv1alpha1.ProviderConfigReference{Name: "provider"},
+			Network: otcv1alpha1.NetworkDependency{
+				NetworkRef: &otcv1alpha1.NamespacedObjectReference{Name: testNetworkName},
+			},
+			Cidr:           "192.168.0.0/24",
+			GatewayIP:      "192.168.0.1",
+			DeletionPolicy: policy,
+		},
+	}
+}
+
+func TestCascadeOwnerReferences(t *testing.T) {
+	network := newTestNetwork(otcv1alpha1.NotFoundPolicyRecreate)
+	network.UID = testNetworkUID
+	c := fake.NewClientBuilder().
+		WithScheme(newTestScheme(t)).
+		WithObjects(network, newTestCascadingSubnet(otcv1alpha1.DeletionPolicyCascade)).
+		WithStatusSubresource(&otcv1alpha1.Subnet{}).
+		Build()
+	r := NewSubnetReconciler(c, zerolog.Nop(), NewProviderCache(c, zerolog.Nop()), nil)
+
+	key := types.NamespacedName{Name: testSubnetName, Namespace: testNamespace}
+	reconcileSubnet := func() *otcv1alpha1.Subnet {
+		t.Helper()
+		if _, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: key}); err != nil {
+			t.Fatalf("Expected no error, got %s", err)
+		}
+		var subnet otcv1alpha1.Subnet
+		if err := c.Get(context.Background(), key, &subnet); err != nil {
+			t.Fatal(err)
+		}
+		return &subnet
+	}
+
+	subnet := reconcileSubnet()
+	if !isOwnedBy(subnet, network) {
+		t.Fatalf("Expected subnet to be owned by the network, got %v", subnet.OwnerReferences)
+	}
+
+	// Switching back to Block removes the owner reference.
+	subnet.Spec.DeletionPolicy = otcv1alpha1.DeletionPolicyBlock
+	if err := c.Update(context.Background(), subnet); err != nil {
+		t.Fatal(err)
+	}
+
+	subnet = reconcileSubnet()
+	if len(subnet.OwnerReferences) != 0 {
+		t.Fatalf("Expected no owner references, got %v", subnet.OwnerReferences)
+	}
+}
+
+func TestCascadeDeletesDependents(t *testing.T) {
+	p := &fakeProvider{}
+	network := newTestNetwork(otcv1alpha1.NotFoundPolicyRecreate)
+	network.UID = testNetworkUID
+	r, c, _ := newTestNetworkReconciler(t, network, p)
+
+	subnet := newTestCascadingSubnet(otcv1alpha1.DeletionPolicyCascade)
+	subnet.OwnerReferences = []metav1.OwnerReference{{
+		APIVersion: otcv1alpha1.GroupVersion.String(),
+		Kind:       "Network",
+		Name:       testNetworkName,
+		UID:        testNetworkUID,
+	}}
+	subnet.Status.ResolvedDependencies.NetworkID = testExternalID
+	if err := c.Create(context.Background(), subnet); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Delete(context.Background(), network); err != nil {
+		t.Fatal(err)
+	}
+
+	// The network deletes the subnet and waits for it to be gone.
+	_, network = reconcileTestNetwork(t, r, c)
+
+	subnetKey := types.NamespacedName{Name: testSubnetName, Namespace: testNamespace}
+	if err := c.Get(context.Background(), subnetKey, subnet); err != nil {
+		t.Fatal(err)
+	}
+	if subnet.DeletionTimestamp.IsZero() {
+		t.Fatal("Expected subnet to be deleted")
+	}
+	ready := meta.FindStatusCondition(network.Status.Conditions, condReady)
+	if ready == nil || ready.Reason != reasonDeletionBlocked {
+		t.Fatalf("Expected network deletion to be blocked, got %+v", ready)
+	}
+	if len(p.networks.deleted) != 0 {
+		t.Fatalf("Expected no external deletion, got %v", p.networks.deleted)
+	}
+
+	// Once the subnet is gone, the network is deleted.
+	subnet.Finalizers = nil
+	if err := c.Update(context.Background(), subnet); err != nil {
+		t.Fatal(err)
+	}
+
+	key := types.NamespacedName{Name: testNetworkName, Namespace: testNamespace}
+	if _, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: key}); err != nil {
+		t.Fatalf("Expected no error, got %s", err)
+	}
+	if !slices.Equal(p.networks.deleted, []string{testExternalID}) {
+		t.Fatalf("Expected external deletion of %q, got %v", testExternalID, p.networks.deleted)
+	}
+	err := c.Get(context.Background(), key, &otcv1alpha1.Network{})
+	if !apierrors.IsNotFound(err) {
+		t.Fatalf("Expected finalizer to be removed, got %v", err)
+	}
+}
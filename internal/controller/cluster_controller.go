@@ -0,0 +1,222 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/rs/zerolog"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	otcv1alpha1 "github.com/peertech.de/otc-operator/api/v1alpha1"
+	provider "github.com/peertech.de/otc-operator/internal/provider"
+)
+
+const (
+	clusterFinalizerName = "cluster.otc.peertech.de/finalizer"
+	clusterRequeueDelay  = 60 * time.Second
+
+	// clusterKubeconfigKey is the key of the kubeconfig in its Secret.
+	clusterKubeconfigKey = "kubeconfig"
+)
+
+// ClusterReconciler reconciles a Cluster object
+type ClusterReconciler = ManagedReconciler[
+	*otcv1alpha1.Cluster,
+	*provider.ClusterInfo,
+	provider.UpdateClusterRequest,
+]
+
+func NewClusterReconciler(
+	c client.Client,
+	logger zerolog.Logger,
+	providers *ProviderCache,
+	dependencies *DependencyGraphCache,
+) *ClusterReconciler {
+	return NewManagedReconciler(c, logger, providers, dependencies, clusterResource)
+}
+
+// +kubebuilder:rbac:groups=otc.peertech.de,resources=clusters,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=otc.peertech.de,resources=clusters/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=otc.peertech.de,resources=clusters/finalizers,verbs=update
+// +kubebuilder:rbac:groups=otc.peertech.de,resources=networks,verbs=get;list;watch
+// +kubebuilder:rbac:groups=otc.peertech.de,resources=subnets,verbs=get;list;watch
+// +kubebuilder:rbac:groups=otc.peertech.de,resources=securitygroups,verbs=get;list;watch
+// +kubebuilder:rbac:groups=otc.peertech.de,resources=nodepools,verbs=get;list;watch
+// +kubebuilder:rbac:groups=otc.peertech.de,resources=providerconfigs,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;create;update;patch
+// +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch;create;update;patch
+
+// Clusters take up to half an hour to become available. Their readiness is
+// checked in the following reconciliations.
+var clusterResource = ManagedResource[
+	*otcv1alpha1.Cluster,
+	*provider.ClusterInfo,
+	provider.UpdateClusterRequest,
+]{
+	ControllerName: "cluster",
+	Name:           "cluster",
+	FinalizerName:  clusterFinalizerName,
+	RequeueDelay:   clusterRequeueDelay,
+
+	New: func() *otcv1alpha1.Cluster { return &otcv1alpha1.Cluster{} },
+
+	Resolve: func(ctx context.Context, r *DependencyResolver, cluster *otcv1alpha1.Cluster) error {
+		resolved, err := r.ResolveClusterDependencies(ctx, cluster.Spec)
+		if err != nil {
+			return err
+		}
+		cluster.Status.ResolvedDependencies = resolved
+		return nil
+	},
+
+	Create: func(
+		ctx context.Context,
+		p provider.Provider,
+		cluster *otcv1alpha1.Cluster,
+	) (string, error) {
+		resolved := cluster.Status.ResolvedDependencies
+		resp, err := p.CreateCluster(ctx, provider.CreateClusterRequest{
+			Name:                 cluster.GetName(),
+			Flavor:               cluster.Spec.Flavor,
+			Version:              cluster.Spec.Version,
+			Description:          cluster.Spec.Description,
+			ContainerNetworkMode: cluster.Spec.ContainerNetwork.Mode,
+			ContainerNetworkCIDR: cluster.Spec.ContainerNetwork.CIDR,
+			ServiceCIDR:          cluster.Spec.ServiceCIDR,
+
+			// dependencies
+			NetworkID:       resolved.NetworkID,
+			SubnetID:        resolved.SubnetID,
+			SecurityGroupID: resolved.SecurityGroupID,
+		})
+		return resp.ID, err
+	},
+
+	Observe: func(
+		ctx context.Context,
+		p provider.Provider,
+		cluster *otcv1alpha1.Cluster,
+	) (*provider.ClusterInfo, error) {
+		info, err := p.GetCluster(ctx, cluster.Status.ExternalID)
+		if err != nil {
+			return nil, err
+		}
+
+		// Report the observed phase, version and endpoint.
+		cluster.Status.Phase = info.Phase
+		cluster.Status.Version = info.Version
+		cluster.Status.Endpoint = info.Endpoint
+		return info, nil
+	},
+
+	Diff: func(
+		logger zerolog.Logger,
+		cluster *otcv1alpha1.Cluster,
+		info *provider.ClusterInfo,
+	) (provider.UpdateClusterRequest, bool) {
+		var updateReq provider.UpdateClusterRequest
+		needsUpdate := false
+
+		// The cluster cannot be updated while it is busy.
+		if info.State() != provider.Ready {
+			return updateReq, false
+		}
+
+		if cluster.Spec.Description != info.Description {
+			logger.Info().
+				Str("current", info.Description).
+				Str("desired", cluster.Spec.Description).
+				Msg("Drift detected in description")
+
+			updateReq.Description = &cluster.Spec.Description
+			needsUpdate = true
+		}
+
+		return updateReq, needsUpdate
+	},
+
+	Update: func(
+		ctx context.Context,
+		p provider.Provider,
+		cluster *otcv1alpha1.Cluster,
+		req provider.UpdateClusterRequest,
+	) error {
+		return p.UpdateCluster(ctx, cluster.Status.ExternalID, req)
+	},
+
+	ConnectionDetails: func(_ *otcv1alpha1.Cluster, info *provider.ClusterInfo) map[string]string {
+		return map[string]string{
+			"externalID": info.ID,
+			"version":    info.Version,
+			"endpoint":   info.Endpoint,
+		}
+	},
+
+	// The certificates of the kubeconfig are only issued once the cluster
+	// is available.
+	Publish: func(
+		ctx context.Context,
+		rc *Reconciler,
+		p provider.Provider,
+		cluster *otcv1alpha1.Cluster,
+		info *provider.ClusterInfo,
+	) error {
+		if info.State() != provider.Ready {
+			return nil
+		}
+		if err := writeKubeconfig(ctx, rc, cluster, p); err != nil {
+			return fmt.Errorf("failed to write kubeconfig: %w", err)
+		}
+		return nil
+	},
+
+	// Node pools can still belong to the cluster. Deleting the cluster would
+	// delete their nodes too.
+	ReferenceChecks: ReferenceChecksFor("Cluster"),
+
+	Delete: func(ctx context.Context, p provider.Provider, cluster *otcv1alpha1.Cluster) error {
+		return p.DeleteCluster(ctx, cluster.Status.ExternalID)
+	},
+}
+
+// writeKubeconfig writes the kubeconfig of the cluster to the referenced
+// Secret. The certificates in the kubeconfig are issued on every request, so
+// it is only fetched if the Secret does not contain it yet. Otherwise, the
+// Secret would change on every reconciliation.
+func writeKubeconfig(
+	ctx context.Context,
+	rc *Reconciler,
+	cluster *otcv1alpha1.Cluster,
+	p provider.Provider,
+) error {
+	ref := cluster.Spec.WriteKubeconfigToRef
+	if ref == nil {
+		return nil
+	}
+
+	var secret corev1.Secret
+	err := rc.client.Get(ctx, client.ObjectKey{Namespace: cluster.Namespace, Name: ref.Name}, &secret)
+	if err == nil && len(secret.Data[clusterKubeconfigKey]) > 0 {
+		return nil
+	}
+	if err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("failed to get kubeconfig secret '%s': %w", ref.Name, err)
+	}
+
+	kubeconfig, err := p.GetClusterKubeconfig(ctx, cluster.Status.ExternalID)
+	if err != nil {
+		return err
+	}
+
+	return rc.WriteConnectionDetails(
+		ctx,
+		&otcv1alpha1.ConnectionDetailsReference{
+			Kind: otcv1alpha1.ConnectionDetailsSecret,
+			Name: ref.Name,
+		},
+		map[string]string{clusterKubeconfigKey: string(kubeconfig)},
+	)
+}
@@ -0,0 +1,116 @@
+package controller
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	otcv1alpha1 "github.com/peertech.de/otc-operator/api/v1alpha1"
+)
+
+const (
+	testClusterName = "cluster"
+	testClusterID   = "cluster-id"
+)
+
+func newTestCluster() *otcv1alpha1.Cluster {
+	networkID, subnetID := "network-id", testSubnetID
+	return &otcv1alpha1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       testClusterName,
+			Namespace:  testNamespace,
+			Finalizers: []string{clusterFinalizerName},
+		},
+		Spec: otcv1alpha1.ClusterSpec{
+			ProviderConfigRef:    testProviderConfigRef,
+			Flavor:               "cce.s1.small",
+			Version:              "v1.31",
+			Network:              otcv1alpha1.NetworkDependency{NetworkID: &networkID},
+			Subnet:               otcv1alpha1.SubnetDependency{SubnetID: &subnetID},
+			WriteKubeconfigToRef: &corev1.LocalObjectReference{Name: "kubeconfig"},
+		},
+	}
+}
+
+func TestClusterLifecycle(t *testing.T) {
+	ctx := context.Background()
+	p := &fakeProvider{}
+	r := newTestManagedReconciler(t, NewClusterReconciler, p, newTestCluster())
+
+	// The cluster is created in the resolved subnet.
+	_, cluster := reconcileTestManaged(t, r, newTestCluster())
+	if len(p.clusters.created) != 1 || p.clusters.created[0].SubnetID != testSubnetID {
+		t.Fatalf("Expected cluster to be created in subnet %q, got %+v", testSubnetID, p.clusters.created)
+	}
+	if cluster.Status.ExternalID != testClusterID {
+		t.Fatalf("Expected externalID %q, got %q", testClusterID, cluster.Status.ExternalID)
+	}
+
+	// The kubeconfig is not written while the cluster is created.
+	_, cluster = reconcileTestManaged(t, r, cluster)
+	expectCondition(t, cluster, condReady, metav1.ConditionFalse, reasonProvisioning)
+	if p.kubeconfigs != 0 {
+		t.Fatalf("Expected no kubeconfig request, got %d", p.kubeconfigs)
+	}
+
+	// The available cluster reports its endpoint and writes its kubeconfig.
+	p.clusters.info.Phase = "Available"
+	p.clusters.info.Endpoint = "https://cluster.example.internal:5443"
+	_, cluster = reconcileTestManaged(t, r, cluster)
+	expectCondition(t, cluster, condReady, metav1.ConditionTrue, reasonProvisioned)
+	if cluster.Status.Endpoint != p.clusters.info.Endpoint {
+		t.Fatalf("Expected observed endpoint, got %+v", cluster.Status)
+	}
+	var secret corev1.Secret
+	if err := r.Get(ctx, client.ObjectKey{Name: "kubeconfig", Namespace: testNamespace}, &secret); err != nil {
+		t.Fatal(err)
+	}
+	if string(secret.Data[clusterKubeconfigKey]) != "kubeconfig" {
+		t.Fatalf("Expected kubeconfig in Secret, got %+v", secret.Data)
+	}
+
+	// A changed description is applied, and the kubeconfig is not fetched
+	// again.
+	updateTestManaged(t, r, cluster, func(cluster *otcv1alpha1.Cluster) {
+		cluster.Spec.Description = "production"
+	})
+	_, cluster = reconcileTestManaged(t, r, cluster)
+	if len(p.clusters.updated) != 1 || *p.clusters.updated[0].Description != "production" {
+		t.Fatalf("Expected description to be updated, got %+v", p.clusters.updated)
+	}
+	if p.kubeconfigs != 1 {
+		t.Fatalf("Expected a single kubeconfig request, got %d", p.kubeconfigs)
+	}
+
+	_, cluster = reconcileTestManaged(t, r, cluster)
+	if len(p.clusters.updated) != 1 {
+		t.Fatalf("Expected no further update, got %+v", p.clusters.updated)
+	}
+
+	deleteTestManaged(t, r, cluster)
+	if !slices.Equal(p.clusters.deleted, []string{testClusterID}) {
+		t.Fatalf("Expected external deletion of %q, got %v", testClusterID, p.clusters.deleted)
+	}
+}
+
+func TestClusterNotFound(t *testing.T) {
+	cluster := newTestCluster()
+	cluster.Status.ExternalID = testClusterID
+	cluster.Status.LastAppliedSpec = cluster.Spec.DeepCopy()
+
+	p := &fakeProvider{}
+	r := newTestManagedReconciler(t, NewClusterReconciler, p, cluster)
+
+	// The cluster deleted out-of-band is created again.
+	_, cluster = reconcileTestManaged(t, r, cluster)
+	expectRecreate(t, cluster)
+
+	_, cluster = reconcileTestManaged(t, r, cluster)
+	if len(p.clusters.created) != 1 || cluster.Status.ExternalID != testClusterID {
+		t.Fatalf("Expected cluster to be recreated, got %+v", p.clusters.created)
+	}
+}
@@ -12,6 +12,9 @@ const (
 	condReady             = "Ready"
 	condDependenciesReady = "DependenciesReady"
 	condSynced            = "Synced"
+
+	condExternalResourceFound = "ExternalResourceFound"
+	condPaused                = "Paused"
 )
 
 // Condition reasons for resource lifecycle
@@ -54,6 +57,17 @@ const (
 	reasonProviderConfigNotReady  = "ProviderConfigNotReady"
 )
 
+// Condition reasons for the external resource
+const (
+	reasonFound = "Found"
+)
+
+// Condition reasons for paused reconciliations
+const (
+	reasonPaused               = "Paused"
+	reasonProviderConfigPaused = "ProviderConfigPaused"
+)
+
 // Condition reasons for specific error types
 const (
 	reasonProviderConfigError          = "ProviderConfigError"
@@ -63,6 +77,10 @@ const (
 	reasonUpdateFailed                 = "UpdateFailed"
 	reasonDeletionFailed               = "DeletionFailed"
 	reasonNotFound                     = "NotFound"
+	reasonExternallyDeleted            = "ExternallyDeleted"
+	reasonRecreating                   = "Recreating"
+	reasonConnectionDetailsFailed      = "ConnectionDetailsFailed"
+	reasonResourceConflict             = "ResourceConflict"
 )
 
 // ConditionBuilder provides a fluent API for building status conditions
@@ -448,3 +466,50 @@ func SetOrphaned(conditions *[]metav1.Condition, generation int64, opts ...Condi
 		WithMessage("Resource orphaned, no longer managed by operator"),
 	)
 }
+
+// SetExternalResourceFound marks the external resource as found.
+func SetExternalResourceFound(conditions *[]metav1.Condition, generation int64) {
+	NewCondition(condExternalResourceFound).
+		WithStatus(metav1.ConditionTrue).
+		WithReason(reasonFound).
+		WithMessage("External resource exists").
+		WithGeneration(generation).
+		Apply(conditions)
+}
+
+// SetExternalResourceNotFound marks the external resource as deleted
+// out-of-band.
+func SetExternalResourceNotFound(
+	conditions *[]metav1.Condition,
+	generation int64,
+	opts ...ConditionOption,
+) {
+	reason, message := applyOptions(
+		reasonNotFound,
+		"External resource was not found",
+		opts,
+	)
+	NewCondition(condExternalResourceFound).
+		WithStatus(metav1.ConditionFalse).
+		WithReason(reason).
+		WithMessage(message).
+		WithGeneration(generation).
+		Apply(conditions)
+}
+
+// SetPaused marks the reconciliation of the resource as paused.
+func SetPaused(conditions *[]metav1.Condition, generation int64, opts ...ConditionOption) {
+	reason, message := applyOptions(reasonPaused, "Reconciliation is paused", opts)
+	NewCondition(condPaused).
+		WithStatus(metav1.ConditionTrue).
+		WithReason(reason).
+		WithMessage(message).
+		WithGeneration(generation).
+		Apply(conditions)
+}
+
+// SetNotPaused removes the Paused condition once the reconciliation is
+// resumed.
+func SetNotPaused(conditions *[]metav1.Condition) {
+	meta.RemoveStatusCondition(conditions, condPaused)
+}
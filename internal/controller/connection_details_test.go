@@ -0,0 +1,93 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/rs/zerolog"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	otcv1alpha1 "github.com/peertech.de/otc-operator/api/v1alpha1"
+)
+
+const testConnectionDetailsName = "connection"
+
+func newTestConnectionDetailsReconciler(t *testing.T, objs ...client.Object) (*Reconciler, client.Client) {
+	t.Helper()
+
+	network := newTestNetwork(otcv1alpha1.NotFoundPolicyRecreate)
+	network.UID = testNetworkUID
+	c := fake.NewClientBuilder().
+		WithScheme(newTestScheme(t)).
+		WithObjects(objs...).
+		Build()
+
+	return &Reconciler{
+		logger: zerolog.Nop(),
+		client: c,
+		object: network,
+	}, c
+}
+
+func TestWriteConnectionDetailsMergesKeys(t *testing.T) {
+	r, c := newTestConnectionDetailsReconciler(t)
+	ref := &otcv1alpha1.ConnectionDetailsReference{Name: testConnectionDetailsName}
+
+	if err := r.WriteConnectionDetails(context.Background(), ref, map[string]string{"id": "a"}); err != nil {
+		t.Fatalf("Expected no error, got %s", err)
+	}
+
+	// Keys written by others are kept.
+	key := types.NamespacedName{Name: testConnectionDetailsName, Namespace: testNamespace}
+	var secret corev1.Secret
+	if err := c.Get(context.Background(), key, &secret); err != nil {
+		t.Fatal(err)
+	}
+	if !metav1.IsControlledBy(&secret, r.object) {
+		t.Fatalf("Expected secret to be owned by the network, got %v", secret.OwnerReferences)
+	}
+	secret.Data["other"] = []byte("value")
+	if err := c.Update(context.Background(), &secret); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := r.WriteConnectionDetails(context.Background(), ref, map[string]string{"id": "b"}); err != nil {
+		t.Fatalf("Expected no error, got %s", err)
+	}
+	if err := c.Get(context.Background(), key, &secret); err != nil {
+		t.Fatal(err)
+	}
+	if string(secret.Data["id"]) != "b" || string(secret.Data["other"]) != "value" {
+		t.Fatalf("Expected merged data, got %v", secret.Data)
+	}
+}
+
+func TestWriteConnectionDetailsDoesNotAdopt(t *testing.T) {
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      testConnectionDetailsName,
+			Namespace: testNamespace,
+		},
+		Data: map[string]string{"config": "value"},
+	}
+	r, c := newTestConnectionDetailsReconciler(t, configMap)
+	ref := &otcv1alpha1.ConnectionDetailsReference{
+		Kind: otcv1alpha1.ConnectionDetailsConfigMap,
+		Name: testConnectionDetailsName,
+	}
+
+	if err := r.WriteConnectionDetails(context.Background(), ref, map[string]string{"config": "id"}); err == nil {
+		t.Fatal("Expected an error")
+	}
+
+	if err := c.Get(context.Background(), client.ObjectKeyFromObject(configMap), configMap); err != nil {
+		t.Fatal(err)
+	}
+	if len(configMap.OwnerReferences) != 0 || configMap.Data["config"] != "value" {
+		t.Fatalf("Expected config map to be untouched, got %+v", configMap)
+	}
+}
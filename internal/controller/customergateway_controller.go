@@ -0,0 +1,98 @@
+package controller
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/rs/zerolog"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	otcv1alpha1 "github.com/peertech.de/otc-operator/api/v1alpha1"
+	provider "github.com/peertech.de/otc-operator/internal/provider"
+)
+
+const (
+	customerGatewayFinalizerName = "customergateway.otc.peertech.de/finalizer"
+	customerGatewayRequeueDelay  = 30 * time.Second
+)
+
+// CustomerGatewayReconciler reconciles a CustomerGateway object
+type CustomerGatewayReconciler = ManagedReconciler[
+	*otcv1alpha1.CustomerGateway,
+	*provider.CustomerGatewayInfo,
+	provider.UpdateCustomerGatewayRequest,
+]
+
+func NewCustomerGatewayReconciler(
+	c client.Client,
+	logger zerolog.Logger,
+	providers *ProviderCache,
+	dependencies *DependencyGraphCache,
+) *CustomerGatewayReconciler {
+	return NewManagedReconciler(c, logger, providers, dependencies, customerGatewayResource)
+}
+
+// +kubebuilder:rbac:groups=otc.peertech.de,resources=customergateways,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=otc.peertech.de,resources=customergateways/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=otc.peertech.de,resources=customergateways/finalizers,verbs=update
+// +kubebuilder:rbac:groups=otc.peertech.de,resources=vpnconnections,verbs=get;list;watch
+// +kubebuilder:rbac:groups=otc.peertech.de,resources=providerconfigs,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;create;update;patch
+// +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch;create;update;patch
+
+// customerGatewayResource has no mutable fields, so it has no Diff.
+var customerGatewayResource = ManagedResource[
+	*otcv1alpha1.CustomerGateway,
+	*provider.CustomerGatewayInfo,
+	provider.UpdateCustomerGatewayRequest,
+]{
+	ControllerName: "customergateway",
+	Name:           "customer gateway",
+	FinalizerName:  customerGatewayFinalizerName,
+	RequeueDelay:   customerGatewayRequeueDelay,
+
+	New: func() *otcv1alpha1.CustomerGateway { return &otcv1alpha1.CustomerGateway{} },
+
+	Create: func(
+		ctx context.Context,
+		p provider.Provider,
+		customerGateway *otcv1alpha1.CustomerGateway,
+	) (string, error) {
+		resp, err := p.CreateCustomerGateway(ctx, provider.CreateCustomerGatewayRequest{
+			Name:      customerGateway.GetName(),
+			IPAddress: customerGateway.Spec.IPAddress,
+			BGPASN:    int(customerGateway.Spec.BGPASN),
+		})
+		return resp.ID, err
+	},
+
+	Observe: func(
+		ctx context.Context,
+		p provider.Provider,
+		customerGateway *otcv1alpha1.CustomerGateway,
+	) (*provider.CustomerGatewayInfo, error) {
+		return p.GetCustomerGateway(ctx, customerGateway.Status.ExternalID)
+	},
+
+	ConnectionDetails: func(
+		_ *otcv1alpha1.CustomerGateway,
+		info *provider.CustomerGatewayInfo,
+	) map[string]string {
+		return map[string]string{
+			"externalID": info.ID,
+			"ipAddress":  info.IPAddress,
+			"bgpASN":     strconv.Itoa(info.BGPASN),
+		}
+	},
+
+	ReferenceChecks: ReferenceChecksFor("CustomerGateway"),
+
+	Delete: func(
+		ctx context.Context,
+		p provider.Provider,
+		customerGateway *otcv1alpha1.CustomerGateway,
+	) error {
+		return p.DeleteCustomerGateway(ctx, customerGateway.Status.ExternalID)
+	},
+}
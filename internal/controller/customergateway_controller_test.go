@@ -0,0 +1,74 @@
+package controller
+
+import (
+	"slices"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	otcv1alpha1 "github.com/peertech.de/otc-operator/api/v1alpha1"
+)
+
+const (
+	testCustomerGatewayName = "customer-gateway"
+	testCustomerGatewayID   = "customer-gateway-id"
+)
+
+func newTestCustomerGateway() *otcv1alpha1.CustomerGateway {
+	return &otcv1alpha1.CustomerGateway{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       testCustomerGatewayName,
+			Namespace:  testNamespace,
+			Finalizers: []string{customerGatewayFinalizerName},
+		},
+		Spec: otcv1alpha1.CustomerGatewaySpec{
+			ProviderConfigRef: testProviderConfigRef,
+			IPAddress:         "203.0.113.1",
+			BGPASN:            65000,
+		},
+	}
+}
+
+func TestCustomerGatewayLifecycle(t *testing.T) {
+	p := &fakeProvider{}
+	r := newTestManagedReconciler(t, NewCustomerGatewayReconciler, p, newTestCustomerGateway())
+
+	_, customerGateway := reconcileTestManaged(t, r, newTestCustomerGateway())
+	if len(p.customerGateways.created) != 1 || p.customerGateways.created[0].IPAddress != "203.0.113.1" || p.customerGateways.created[0].BGPASN != 65000 {
+		t.Fatalf("Expected customer gateway to be created, got %+v", p.customerGateways.created)
+	}
+	if customerGateway.Status.ExternalID != testCustomerGatewayID {
+		t.Fatalf("Expected externalID %q, got %q", testCustomerGatewayID, customerGateway.Status.ExternalID)
+	}
+
+	// The customer gateway is ready as soon as it exists. It cannot be
+	// updated, so it is never changed.
+	_, customerGateway = reconcileTestManaged(t, r, customerGateway)
+	expectCondition(t, customerGateway, condReady, metav1.ConditionTrue, reasonProvisioned)
+	if len(p.customerGateways.created) != 1 {
+		t.Fatalf("Expected no further creation, got %+v", p.customerGateways.created)
+	}
+
+	deleteTestManaged(t, r, customerGateway)
+	if !slices.Equal(p.customerGateways.deleted, []string{testCustomerGatewayID}) {
+		t.Fatalf("Expected external deletion of %q, got %v", testCustomerGatewayID, p.customerGateways.deleted)
+	}
+}
+
+func TestCustomerGatewayNotFound(t *testing.T) {
+	customerGateway := newTestCustomerGateway()
+	customerGateway.Status.ExternalID = testCustomerGatewayID
+	customerGateway.Status.LastAppliedSpec = customerGateway.Spec.DeepCopy()
+
+	p := &fakeProvider{}
+	r := newTestManagedReconciler(t, NewCustomerGatewayReconciler, p, customerGateway)
+
+	// The customer gateway deleted out-of-band is created again.
+	_, customerGateway = reconcileTestManaged(t, r, customerGateway)
+	expectRecreate(t, customerGateway)
+
+	_, customerGateway = reconcileTestManaged(t, r, customerGateway)
+	if len(p.customerGateways.created) != 1 || customerGateway.Status.ExternalID != testCustomerGatewayID {
+		t.Fatalf("Expected customer gateway to be recreated, got %+v", p.customerGateways.created)
+	}
+}
@@ -0,0 +1,328 @@
+package controller
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	otcv1alpha1 "github.com/peertech.de/otc-operator/api/v1alpha1"
+	provider "github.com/peertech.de/otc-operator/internal/provider"
+)
+
+const (
+	databaseInstanceFinalizerName = "databaseInstance.otc.peertech.de/finalizer"
+	databaseInstanceRequeueDelay  = 60 * time.Second
+
+	// databasePasswordLength is the length of generated admin passwords.
+	databasePasswordLength = 24
+)
+
+// DatabaseInstanceReconciler reconciles a DatabaseInstance object
+type DatabaseInstanceReconciler = ManagedReconciler[
+	*otcv1alpha1.DatabaseInstance,
+	*provider.DatabaseInstanceInfo,
+	provider.UpdateDatabaseInstanceRequest,
+]
+
+func NewDatabaseInstanceReconciler(
+	c client.Client,
+	logger zerolog.Logger,
+	providers *ProviderCache,
+	dependencies *DependencyGraphCache,
+) *DatabaseInstanceReconciler {
+	return NewManagedReconciler(c, logger, providers, dependencies, newDatabaseInstanceResource(c))
+}
+
+// +kubebuilder:rbac:groups=otc.peertech.de,resources=databaseinstances,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=otc.peertech.de,resources=databaseinstances/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=otc.peertech.de,resources=databaseinstances/finalizers,verbs=update
+// +kubebuilder:rbac:groups=otc.peertech.de,resources=networks,verbs=get;list;watch
+// +kubebuilder:rbac:groups=otc.peertech.de,resources=subnets,verbs=get;list;watch
+// +kubebuilder:rbac:groups=otc.peertech.de,resources=securitygroups,verbs=get;list;watch
+// +kubebuilder:rbac:groups=otc.peertech.de,resources=kmskeys,verbs=get;list;watch
+// +kubebuilder:rbac:groups=otc.peertech.de,resources=providerconfigs,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;create;update;patch
+// +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch;create;update;patch
+
+// newDatabaseInstanceResource returns the database instance resource, which
+// reads and writes the admin password with the client. Database instances
+// take several minutes to become available. Their readiness is checked in
+// the following reconciliations.
+func newDatabaseInstanceResource(c client.Client) ManagedResource[
+	*otcv1alpha1.DatabaseInstance,
+	*provider.DatabaseInstanceInfo,
+	provider.UpdateDatabaseInstanceRequest,
+] {
+	return ManagedResource[
+		*otcv1alpha1.DatabaseInstance,
+		*provider.DatabaseInstanceInfo,
+		provider.UpdateDatabaseInstanceRequest,
+	]{
+		ControllerName: "databaseinstance",
+		Name:           "database instance",
+		FinalizerName:  databaseInstanceFinalizerName,
+		RequeueDelay:   databaseInstanceRequeueDelay,
+
+		New: func() *otcv1alpha1.DatabaseInstance { return &otcv1alpha1.DatabaseInstance{} },
+
+		Resolve: func(
+			ctx context.Context,
+			r *DependencyResolver,
+			databaseInstance *otcv1alpha1.DatabaseInstance,
+		) error {
+			resolved, err := r.ResolveDatabaseInstanceDependencies(ctx, databaseInstance.Spec)
+			if err != nil {
+				return err
+			}
+			databaseInstance.Status.ResolvedDependencies = resolved
+			return nil
+		},
+
+		// The password is stored before the instance is created, so a
+		// generated password is not lost if the creation fails.
+		Create: func(
+			ctx context.Context,
+			p provider.Provider,
+			databaseInstance *otcv1alpha1.DatabaseInstance,
+		) (string, error) {
+			adminPassword, err := getAdminPassword(ctx, c, databaseInstance)
+			if err != nil {
+				return "", fmt.Errorf("failed to get admin password: %w", err)
+			}
+
+			resolved := databaseInstance.Status.ResolvedDependencies
+			resp, err := p.CreateDatabaseInstance(ctx, provider.CreateDatabaseInstanceRequest{
+				Name:              databaseInstance.GetName(),
+				Engine:            databaseInstance.Spec.Engine,
+				Version:           databaseInstance.Spec.Version,
+				Flavor:            databaseInstance.Spec.Flavor,
+				Volume:            databaseInstance.Spec.Volume,
+				HAMode:            databaseInstance.Spec.HAMode,
+				ReplicationMode:   databaseInstance.Spec.ReplicationMode,
+				AvailabilityZones: databaseInstance.Spec.AvailabilityZones,
+				Port:              databaseInstance.Spec.Port,
+				BackupPolicy:      databaseInstance.Spec.BackupPolicy,
+				AdminPassword:     adminPassword,
+
+				// dependencies
+				NetworkID:       resolved.NetworkID,
+				SubnetID:        resolved.SubnetID,
+				SecurityGroupID: resolved.SecurityGroupID,
+				KMSKeyID:        resolved.KMSKeyID,
+			})
+			return resp.ID, err
+		},
+
+		Observe: func(
+			ctx context.Context,
+			p provider.Provider,
+			databaseInstance *otcv1alpha1.DatabaseInstance,
+		) (*provider.DatabaseInstanceInfo, error) {
+			info, err := p.GetDatabaseInstance(ctx, databaseInstance.Status.ExternalID)
+			if err != nil {
+				return nil, err
+			}
+
+			// Report the observed status and address.
+			databaseInstance.Status.Status = info.Status
+			databaseInstance.Status.Host = info.Host
+			databaseInstance.Status.Port = info.Port
+			return info, nil
+		},
+
+		Diff: func(
+			logger zerolog.Logger,
+			databaseInstance *otcv1alpha1.DatabaseInstance,
+			info *provider.DatabaseInstanceInfo,
+		) (provider.UpdateDatabaseInstanceRequest, bool) {
+			var updateReq provider.UpdateDatabaseInstanceRequest
+			needsUpdate := false
+
+			// The instance cannot be updated while it is busy.
+			if info.State() != provider.Ready {
+				return updateReq, false
+			}
+
+			// The start time is meaningless while the backups are disabled.
+			backupPolicy := databaseInstance.Spec.BackupPolicy
+			if backupPolicy.KeepDays != info.BackupPolicy.KeepDays ||
+				(backupPolicy.KeepDays > 0 && backupPolicy.StartTime != info.BackupPolicy.StartTime) {
+				logger.Info().
+					Str("current", fmt.Sprintf("%+v", info.BackupPolicy)).
+					Str("desired", fmt.Sprintf("%+v", backupPolicy)).
+					Msg("Drift detected in backup policy")
+
+				updateReq.BackupPolicy = &backupPolicy
+				needsUpdate = true
+			}
+
+			// The storage and the flavor are changed by separate jobs, which
+			// cannot run at the same time. The flavor is changed in a later
+			// reconciliation, once the instance is available again.
+			if databaseInstance.Spec.Volume.Size > info.VolumeSize {
+				logger.Info().
+					Int("current", info.VolumeSize).
+					Int("desired", databaseInstance.Spec.Volume.Size).
+					Msg("Drift detected in volume size")
+
+				updateReq.VolumeSize = databaseInstance.Spec.Volume.Size
+				needsUpdate = true
+			} else if databaseInstance.Spec.Flavor != info.Flavor {
+				logger.Info().
+					Str("current", info.Flavor).
+					Str("desired", databaseInstance.Spec.Flavor).
+					Msg("Drift detected in flavor")
+
+				updateReq.Flavor = databaseInstance.Spec.Flavor
+				needsUpdate = true
+			}
+
+			return updateReq, needsUpdate
+		},
+
+		Update: func(
+			ctx context.Context,
+			p provider.Provider,
+			databaseInstance *otcv1alpha1.DatabaseInstance,
+			req provider.UpdateDatabaseInstanceRequest,
+		) error {
+			return p.UpdateDatabaseInstance(ctx, databaseInstance.Status.ExternalID, req)
+		},
+
+		ConnectionDetails: func(
+			databaseInstance *otcv1alpha1.DatabaseInstance,
+			info *provider.DatabaseInstanceInfo,
+		) map[string]string {
+			return map[string]string{
+				"externalID": info.ID,
+				"engine":     string(databaseInstance.Spec.Engine),
+				"host":       info.Host,
+				"port":       strconv.Itoa(int(info.Port)),
+				"username":   info.Username,
+			}
+		},
+
+		Delete: func(
+			ctx context.Context,
+			p provider.Provider,
+			databaseInstance *otcv1alpha1.DatabaseInstance,
+		) error {
+			return p.DeleteDatabaseInstance(ctx, databaseInstance.Status.ExternalID)
+		},
+	}
+}
+
+// getAdminPassword reads the admin password from the referenced Secret. If
+// the Secret does not contain the password yet, a new password is generated
+// and written to it. A Secret created this way is owned by the
+// DatabaseInstance. Other keys of the Secret are left untouched.
+func getAdminPassword(
+	ctx context.Context,
+	c client.Client,
+	databaseInstance *otcv1alpha1.DatabaseInstance,
+) (string, error) {
+	ref := databaseInstance.Spec.AdminPasswordSecretRef
+
+	var secret corev1.Secret
+	err := c.Get(
+		ctx,
+		client.ObjectKey{Namespace: databaseInstance.Namespace, Name: ref.Name},
+		&secret,
+	)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return "", fmt.Errorf("failed to get admin password secret '%s': %w", ref.Name, err)
+	}
+	if err == nil && len(secret.Data[ref.Key]) > 0 {
+		return string(secret.Data[ref.Key]), nil
+	}
+
+	password, genErr := generateDatabasePassword()
+	if genErr != nil {
+		return "", fmt.Errorf("failed to generate admin password: %w", genErr)
+	}
+
+	if apierrors.IsNotFound(err) {
+		secret = corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      ref.Name,
+				Namespace: databaseInstance.Namespace,
+			},
+			Data: map[string][]byte{ref.Key: []byte(password)},
+		}
+		if err := controllerutil.SetControllerReference(databaseInstance, &secret, c.Scheme()); err != nil {
+			return "", err
+		}
+		if err := c.Create(ctx, &secret); err != nil {
+			return "", fmt.Errorf("failed to create Secret %s: %w", ref.Name, err)
+		}
+		return password, nil
+	}
+
+	if secret.Data == nil {
+		secret.Data = map[string][]byte{}
+	}
+	secret.Data[ref.Key] = []byte(password)
+	if err := c.Update(ctx, &secret); err != nil {
+		return "", fmt.Errorf("failed to write Secret %s: %w", ref.Name, err)
+	}
+
+	return password, nil
+}
+
+// generateDatabasePassword generates a random password which satisfies the
+// complexity rules of RDS. It contains upper and lower case letters, digits
+// and special characters.
+func generateDatabasePassword() (string, error) {
+	classes := []string{
+		"ABCDEFGHJKLMNPQRSTUVWXYZ",
+		"abcdefghijkmnopqrstuvwxyz",
+		"23456789",
+		"~!@#%^*-_=+?",
+	}
+	alphabet := strings.Join(classes, "")
+
+	randomIndex := func(n int) (int, error) {
+		i, err := rand.Int(rand.Reader, big.NewInt(int64(n)))
+		if err != nil {
+			return 0, err
+		}
+		return int(i.Int64()), nil
+	}
+
+	// Start with one character of each class, then fill up and shuffle.
+	password := make([]byte, 0, databasePasswordLength)
+	for _, class := range classes {
+		i, err := randomIndex(len(class))
+		if err != nil {
+			return "", err
+		}
+		password = append(password, class[i])
+	}
+	for len(password) < databasePasswordLength {
+		i, err := randomIndex(len(alphabet))
+		if err != nil {
+			return "", err
+		}
+		password = append(password, alphabet[i])
+	}
+	for i := len(password) - 1; i > 0; i-- {
+		j, err := randomIndex(i + 1)
+		if err != nil {
+			return "", err
+		}
+		password[i], password[j] = password[j], password[i]
+	}
+
+	return string(password), nil
+}
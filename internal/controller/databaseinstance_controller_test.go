@@ -0,0 +1,140 @@
+package controller
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	otcv1alpha1 "github.com/peertech.de/otc-operator/api/v1alpha1"
+)
+
+const (
+	testDatabaseInstanceName = "database"
+	testDatabaseInstanceID   = "database-id"
+)
+
+func newTestDatabaseInstance() *otcv1alpha1.DatabaseInstance {
+	networkID, subnetID, securityGroupID := "network-id", testSubnetID, "security-group-id"
+	return &otcv1alpha1.DatabaseInstance{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       testDatabaseInstanceName,
+			Namespace:  testNamespace,
+			Finalizers: []string{databaseInstanceFinalizerName},
+		},
+		Spec: otcv1alpha1.DatabaseInstanceSpec{
+			ProviderConfigRef: testProviderConfigRef,
+			Engine:            otcv1alpha1.DatabaseEnginePostgreSQL,
+			Version:           "16",
+			Flavor:            "rds.pg.n1.large.2",
+			Volume:            otcv1alpha1.DatabaseVolume{Size: 40},
+			AvailabilityZones: []string{"eu-de-01"},
+			BackupPolicy:      otcv1alpha1.DatabaseBackupPolicy{StartTime: "01:00-02:00", KeepDays: 7},
+			AdminPasswordSecretRef: corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "database-admin"},
+				Key:                  "password",
+			},
+			Network:       otcv1alpha1.NetworkDependency{NetworkID: &networkID},
+			Subnet:        otcv1alpha1.SubnetDependency{SubnetID: &subnetID},
+			SecurityGroup: otcv1alpha1.SecurityGroupDependency{SecurityGroupID: &securityGroupID},
+		},
+	}
+}
+
+func TestDatabaseInstanceLifecycle(t *testing.T) {
+	ctx := context.Background()
+	p := &fakeProvider{}
+	r := newTestManagedReconciler(t, NewDatabaseInstanceReconciler, p, newTestDatabaseInstance())
+
+	// The instance is created with a generated admin password, which is
+	// stored in an owned Secret.
+	_, databaseInstance := reconcileTestManaged(t, r, newTestDatabaseInstance())
+	if len(p.databaseInstances.created) != 1 || p.databaseInstances.created[0].SubnetID != testSubnetID || p.databaseInstances.created[0].AdminPassword == "" {
+		t.Fatalf("Expected database instance to be created, got %+v", p.databaseInstances.created)
+	}
+	if databaseInstance.Status.ExternalID != testDatabaseInstanceID {
+		t.Fatalf("Expected externalID %q, got %q", testDatabaseInstanceID, databaseInstance.Status.ExternalID)
+	}
+	var secret corev1.Secret
+	if err := r.Get(ctx, client.ObjectKey{Name: "database-admin", Namespace: testNamespace}, &secret); err != nil {
+		t.Fatal(err)
+	}
+	if string(secret.Data["password"]) != p.databaseInstances.created[0].AdminPassword ||
+		!metav1.IsControlledBy(&secret, databaseInstance) {
+		t.Fatalf("Expected admin password in owned Secret, got %+v", secret)
+	}
+
+	// The instance is not ready while it is built.
+	_, databaseInstance = reconcileTestManaged(t, r, databaseInstance)
+	expectCondition(t, databaseInstance, condReady, metav1.ConditionFalse, reasonProvisioning)
+
+	p.databaseInstances.info.Status = "ACTIVE"
+	p.databaseInstances.info.Host = "10.0.0.20"
+	p.databaseInstances.info.Port = 5432
+	_, databaseInstance = reconcileTestManaged(t, r, databaseInstance)
+	expectCondition(t, databaseInstance, condReady, metav1.ConditionTrue, reasonProvisioned)
+	if databaseInstance.Status.Host != "10.0.0.20" || databaseInstance.Status.Port != 5432 {
+		t.Fatalf("Expected observed endpoint, got %+v", databaseInstance.Status)
+	}
+
+	// A larger volume is applied before a changed flavor.
+	updateTestManaged(t, r, databaseInstance, func(databaseInstance *otcv1alpha1.DatabaseInstance) {
+		databaseInstance.Spec.Volume.Size = 80
+		databaseInstance.Spec.Flavor = "rds.pg.n1.xlarge.2"
+	})
+	_, databaseInstance = reconcileTestManaged(t, r, databaseInstance)
+	if len(p.databaseInstances.updated) != 1 || p.databaseInstances.updated[0].VolumeSize != 80 || p.databaseInstances.updated[0].Flavor != "" {
+		t.Fatalf("Expected volume to be extended, got %+v", p.databaseInstances.updated)
+	}
+	_, databaseInstance = reconcileTestManaged(t, r, databaseInstance)
+	if len(p.databaseInstances.updated) != 2 || p.databaseInstances.updated[1].Flavor != "rds.pg.n1.xlarge.2" || p.databaseInstances.updated[1].VolumeSize != 0 {
+		t.Fatalf("Expected flavor to be changed, got %+v", p.databaseInstances.updated)
+	}
+
+	// A backup policy changed out-of-band is reverted.
+	p.databaseInstances.info.BackupPolicy.KeepDays = 1
+	_, databaseInstance = reconcileTestManaged(t, r, databaseInstance)
+	if len(p.databaseInstances.updated) != 3 || p.databaseInstances.updated[2].BackupPolicy == nil || p.databaseInstances.updated[2].BackupPolicy.KeepDays != 7 {
+		t.Fatalf("Expected backup policy to be reverted, got %+v", p.databaseInstances.updated)
+	}
+
+	_, databaseInstance = reconcileTestManaged(t, r, databaseInstance)
+	if len(p.databaseInstances.updated) != 3 {
+		t.Fatalf("Expected no further update, got %+v", p.databaseInstances.updated)
+	}
+
+	deleteTestManaged(t, r, databaseInstance)
+	if !slices.Equal(p.databaseInstances.deleted, []string{testDatabaseInstanceID}) {
+		t.Fatalf("Expected external deletion of %q, got %v", testDatabaseInstanceID, p.databaseInstances.deleted)
+	}
+}
+
+func TestDatabaseInstanceNotFound(t *testing.T) {
+	databaseInstance := newTestDatabaseInstance()
+	databaseInstance.Status.ExternalID = testDatabaseInstanceID
+	databaseInstance.Status.LastAppliedSpec = databaseInstance.Spec.DeepCopy()
+
+	// The admin password of the lost instance is reused.
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "database-admin", Namespace: testNamespace},
+		Data:       map[string][]byte{"password": []byte("admin-password")},
+	}
+
+	p := &fakeProvider{}
+	r := newTestManagedReconciler(t, NewDatabaseInstanceReconciler, p, databaseInstance, secret)
+
+	// The instance deleted out-of-band is created again.
+	_, databaseInstance = reconcileTestManaged(t, r, databaseInstance)
+	expectRecreate(t, databaseInstance)
+
+	_, databaseInstance = reconcileTestManaged(t, r, databaseInstance)
+	if len(p.databaseInstances.created) != 1 || databaseInstance.Status.ExternalID != testDatabaseInstanceID {
+		t.Fatalf("Expected database instance to be recreated, got %+v", p.databaseInstances.created)
+	}
+	if p.databaseInstances.created[0].AdminPassword != "admin-password" {
+		t.Fatalf("Expected admin password to be reused, got %q", p.databaseInstances.created[0].AdminPassword)
+	}
+}
@@ -0,0 +1,406 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	otcv1alpha1 "github.com/peertech.de/otc-operator/api/v1alpha1"
+)
+
+// dependencyGraphLists returns empty lists of all kinds in the dependency
+// graph.
+var dependencyGraphLists = []func() client.ObjectList{
+	func() client.ObjectList { return &otcv1alpha1.ProviderConfigList{} },
+	func() client.ObjectList { return &otcv1alpha1.AlarmRuleList{} },
+	func() client.ObjectList { return &otcv1alpha1.BandwidthList{} },
+	func() client.ObjectList { return &otcv1alpha1.BucketList{} },
+	func() client.ObjectList { return &otcv1alpha1.ClusterList{} },
+	func() client.ObjectList { return &otcv1alpha1.CustomerGatewayList{} },
+	func() client.ObjectList { return &otcv1alpha1.DatabaseInstanceList{} },
+	func() client.ObjectList { return &otcv1alpha1.DNSZoneList{} },
+	func() client.ObjectList { return &otcv1alpha1.InstanceList{} },
+	func() client.ObjectList { return &otcv1alpha1.KeyPairList{} },
+	func() client.ObjectList { return &otcv1alpha1.KMSKeyList{} },
+	func() client.ObjectList { return &otcv1alpha1.NATGatewayList{} },
+	func() client.ObjectList { return &otcv1alpha1.NetworkList{} },
+	func() client.ObjectList { return &otcv1alpha1.NetworkStackList{} },
+	func() client.ObjectList { return &otcv1alpha1.NodePoolList{} },
+	func() client.ObjectList { return &otcv1alpha1.PublicIPList{} },
+	func() client.ObjectList { return &otcv1alpha1.RecordSetList{} },
+	func() client.ObjectList { return &otcv1alpha1.SecurityGroupList{} },
+	func() client.ObjectList { return &otcv1alpha1.SecurityGroupRuleList{} },
+	func() client.ObjectList { return &otcv1alpha1.SNATRuleList{} },
+	func() client.ObjectList { return &otcv1alpha1.SNATRuleSetList{} },
+	func() client.ObjectList { return &otcv1alpha1.SubnetList{} },
+	func() client.ObjectList { return &otcv1alpha1.SubscriptionList{} },
+	func() client.ObjectList { return &otcv1alpha1.TopicList{} },
+	func() client.ObjectList { return &otcv1alpha1.VirtualIPList{} },
+	func() client.ObjectList { return &otcv1alpha1.VolumeList{} },
+	func() client.ObjectList { return &otcv1alpha1.VPNConnectionList{} },
+	func() client.ObjectList { return &otcv1alpha1.VPNGatewayList{} },
+}
+
+// DependencyNode is a resource in the dependency graph.
+type DependencyNode struct {
+	Kind       string `json:"kind"`
+	Namespace  string `json:"namespace"`
+	Name       string `json:"name"`
+	ExternalID string `json:"externalID,omitempty"`
+	Ready      bool   `json:"ready"`
+	Reason     string `json:"reason,omitempty"`
+	Message    string `json:"message,omitempty"`
+	// DependsOn lists the IDs of the nodes the resource depends on.
+	DependsOn []string `json:"dependsOn,omitempty"`
+
+	// refs and resolvedIDs are the edges before they are resolved to nodes.
+	refs        []string
+	resolvedIDs []string
+}
+
+// ID returns the ID of the node in the form "Kind namespace/name".
+func (n *DependencyNode) ID() string {
+	return dependencyNodeID(n.Kind, n.Namespace, n.Name)
+}
+
+func dependencyNodeID(kind, namespace, name string) string {
+	return fmt.Sprintf("%s %s/%s", kind, namespace, name)
+}
+
+// DependencyGraph is the graph of the managed resources and the resources
+// they depend on. The edges are taken from the references in the spec and
+// the resolved IDs in the status.
+type DependencyGraph struct {
+	Nodes []*DependencyNode `json:"nodes"`
+
+	byID map[string]*DependencyNode
+}
+
+// BuildDependencyGraph builds the dependency graph of all resources.
+func BuildDependencyGraph(ctx context.Context, c client.Client) (*DependencyGraph, error) {
+	g := &DependencyGraph{byID: make(map[string]*DependencyNode)}
+
+	// Maps the lower case kind to the kind, to find the kind of a reference.
+	kinds := make(map[string]string)
+	for _, newList := range dependencyGraphLists {
+		list := newList()
+		if err := c.List(ctx, list); err != nil {
+			return nil, fmt.Errorf("failed to list resources: %w", err)
+		}
+		gvk, err := c.GroupVersionKindFor(list)
+		if err != nil {
+			return nil, err
+		}
+		kind := strings.TrimSuffix(gvk.Kind, "List")
+		kinds[strings.ToLower(kind)] = kind
+
+		items, err := meta.ExtractList(list)
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range items {
+			node, err := newDependencyNode(kind, item)
+			if err != nil {
+				return nil, err
+			}
+			g.add(node)
+		}
+	}
+
+	byExternalID := make(map[string][]*DependencyNode)
+	for _, node := range g.Nodes {
+		if node.ExternalID != "" {
+			byExternalID[node.ExternalID] = append(byExternalID[node.ExternalID], node)
+		}
+	}
+
+	// Resolve the edges. Missing referenced resources are added as nodes,
+	// as they block the resources referencing them.
+	for _, node := range slices.Clone(g.Nodes) {
+		for _, ref := range node.refs {
+			kind, key, _ := strings.Cut(ref, " ")
+			namespace, name, _ := strings.Cut(key, "/")
+			kind, known := kinds[kind]
+			if !known {
+				continue
+			}
+
+			id := dependencyNodeID(kind, namespace, name)
+			if _, exists := g.byID[id]; !exists {
+				g.add(&DependencyNode{
+					Kind:      kind,
+					Namespace: namespace,
+					Name:      name,
+					Reason:    reasonNotFound,
+					Message:   "Resource does not exist",
+				})
+			}
+			node.DependsOn = append(node.DependsOn, id)
+		}
+		for _, externalID := range node.resolvedIDs {
+			for _, dep := range byExternalID[externalID] {
+				if dep != node {
+					node.DependsOn = append(node.DependsOn, dep.ID())
+				}
+			}
+		}
+		slices.Sort(node.DependsOn)
+		node.DependsOn = slices.Compact(node.DependsOn)
+	}
+
+	slices.SortFunc(g.Nodes, func(a, b *DependencyNode) int {
+		return strings.Compare(a.ID(), b.ID())
+	})
+	return g, nil
+}
+
+func (g *DependencyGraph) add(node *DependencyNode) {
+	g.Nodes = append(g.Nodes, node)
+	g.byID[node.ID()] = node
+}
+
+// newDependencyNode returns the node of a resource of the given kind, with
+// the references of its spec and the resolved IDs of its status.
+func newDependencyNode(kind string, obj runtime.Object) (*DependencyNode, error) {
+	u, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		return nil, err
+	}
+	object := unstructured.Unstructured{Object: u}
+
+	node := &DependencyNode{
+		Kind:      kind,
+		Namespace: object.GetNamespace(),
+		Name:      object.GetName(),
+	}
+	node.ExternalID, _, _ = unstructured.NestedString(u, "status", "externalID")
+
+	conditions, _, _ := unstructured.NestedSlice(u, "status", "conditions")
+	for _, c := range conditions {
+		cond, ok := c.(map[string]any)
+		if !ok || cond["type"] != condReady {
+			continue
+		}
+		node.Ready = cond["status"] == "True"
+		node.Reason, _ = cond["reason"].(string)
+		node.Message, _ = cond["message"].(string)
+	}
+
+	if spec, ok := u["spec"]; ok {
+		node.refs = collectRefs(spec, node.Namespace)
+	}
+	if resolved, ok, _ := unstructured.NestedFieldNoCopy(u, "status", "resolvedDependencies"); ok {
+		node.resolvedIDs = collectStrings(resolved)
+	}
+	return node, nil
+}
+
+// collectRefs returns the references in value as "kind namespace/name", where
+// kind is the lower case name of the field without the "Ref" suffix (e.g.
+// "natgateway" for "natGatewayRef").
+func collectRefs(value any, namespace string) []string {
+	var refs []string
+	switch v := value.(type) {
+	case map[string]any:
+		for key, field := range v {
+			ref, ok := field.(map[string]any)
+			name, hasName := ref["name"].(string)
+			if ok && hasName && strings.HasSuffix(key, "Ref") {
+				refNamespace, _ := ref["namespace"].(string)
+				if refNamespace == "" {
+					refNamespace = namespace
+				}
+				kind := strings.ToLower(strings.TrimSuffix(key, "Ref"))
+				refs = append(refs, fmt.Sprintf("%s %s/%s", kind, refNamespace, name))
+				continue
+			}
+			refs = append(refs, collectRefs(field, namespace)...)
+		}
+	case []any:
+		for _, item := range v {
+			refs = append(refs, collectRefs(item, namespace)...)
+		}
+	}
+	return refs
+}
+
+// collectStrings returns all non-empty strings in value.
+func collectStrings(value any) []string {
+	var values []string
+	switch v := value.(type) {
+	case string:
+		if v != "" {
+			values = append(values, v)
+		}
+	case map[string]any:
+		for _, field := range v {
+			values = append(values, collectStrings(field)...)
+		}
+	case []any:
+		for _, item := range v {
+			values = append(values, collectStrings(item)...)
+		}
+	}
+	return values
+}
+
+// BlockedBy returns the chain of unready resources the resource is waiting
+// for. Each resource in the chain is blocked by the next one, the last one is
+// the root cause.
+func (g *DependencyGraph) BlockedBy(kind, namespace, name string) []otcv1alpha1.BlockingResource {
+	node, ok := g.byID[dependencyNodeID(kind, namespace, name)]
+	if !ok {
+		return nil
+	}
+
+	var blockers []otcv1alpha1.BlockingResource
+	visited := map[string]bool{node.ID(): true}
+	for {
+		var next *DependencyNode
+		for _, id := range node.DependsOn {
+			if dep := g.byID[id]; !dep.Ready && !visited[id] {
+				next = dep
+				break
+			}
+		}
+		if next == nil {
+			return blockers
+		}
+
+		blockers = append(blockers, otcv1alpha1.BlockingResource{
+			Kind:      next.Kind,
+			Namespace: next.Namespace,
+			Name:      next.Name,
+			Reason:    next.Reason,
+			Message:   next.Message,
+		})
+		visited[next.ID()] = true
+		node = next
+	}
+}
+
+// WriteDOT writes the graph in the DOT language of Graphviz. Edges point from
+// a resource to its dependencies, unready resources are colored red.
+func (g *DependencyGraph) WriteDOT(w io.Writer) error {
+	var b strings.Builder
+	b.WriteString("digraph dependencies {\n")
+	for _, node := range g.Nodes {
+		color := "green"
+		if !node.Ready {
+			color = "red"
+		}
+		fmt.Fprintf(&b, "  %q [color=%s];\n", node.ID(), color)
+	}
+	for _, node := range g.Nodes {
+		for _, dep := range node.DependsOn {
+			fmt.Fprintf(&b, "  %q -> %q;\n", node.ID(), dep)
+		}
+	}
+	b.WriteString("}\n")
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// dependencyGraphRebuildInterval is the interval in which the shared
+// dependency graph is rebuilt.
+const dependencyGraphRebuildInterval = 10 * time.Second
+
+// DependencyGraphCache holds the dependency graph of all resources and
+// rebuilds it periodically. It is shared by the controllers of a manager, so
+// the resources are not listed on every status update.
+type DependencyGraphCache struct {
+	client   client.Client
+	logger   zerolog.Logger
+	interval time.Duration
+
+	mu    sync.RWMutex
+	graph *DependencyGraph
+}
+
+func NewDependencyGraphCache(c client.Client, logger zerolog.Logger) *DependencyGraphCache {
+	return &DependencyGraphCache{
+		client:   c,
+		logger:   logger.With().Str("component", "dependencygraph").Logger(),
+		interval: dependencyGraphRebuildInterval,
+	}
+}
+
+// Graph returns the last built graph, or nil if it was not built yet.
+func (d *DependencyGraphCache) Graph() *DependencyGraph {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	return d.graph
+}
+
+// Rebuild builds the graph from the current resources.
+func (d *DependencyGraphCache) Rebuild(ctx context.Context) error {
+	g, err := BuildDependencyGraph(ctx, d.client)
+	if err != nil {
+		return err
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.graph = g
+	return nil
+}
+
+// Start rebuilds the graph until the context is done.
+func (d *DependencyGraphCache) Start(ctx context.Context) error {
+	ticker := time.NewTicker(d.interval)
+	defer ticker.Stop()
+
+	for {
+		if err := d.Rebuild(ctx); err != nil {
+			d.logger.Error().Err(err).Msg("Failed to build dependency graph")
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// NeedLeaderElection returns false, as every replica reports the graph on
+// its debug endpoint.
+func (d *DependencyGraphCache) NeedLeaderElection() bool {
+	return false
+}
+
+// NewDependencyGraphHandler returns a handler serving the shared dependency
+// graph as JSON, or in the DOT language with the query parameter format=dot.
+func NewDependencyGraphHandler(d *DependencyGraphCache) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		g := d.Graph()
+		if g == nil {
+			http.Error(w, "dependency graph is not built yet", http.StatusServiceUnavailable)
+			return
+		}
+
+		switch req.URL.Query().Get("format") {
+		case "dot":
+			w.Header().Set("Content-Type", "text/vnd.graphviz")
+			_ = g.WriteDOT(w)
+		case "", "json":
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(g)
+		default:
+			http.Error(w, "format must be json or dot", http.StatusBadRequest)
+		}
+	})
+}
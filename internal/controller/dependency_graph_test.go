@@ -0,0 +1,192 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/rs/zerolog"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	otcv1alpha1 "github.com/peertech.de/otc-operator/api/v1alpha1"
+)
+
+func readyCondition(ready bool, reason, message string) []metav1.Condition {
+	status := metav1.ConditionFalse
+	if ready {
+		status = metav1.ConditionTrue
+	}
+	return []metav1.Condition{{
+		Type:               condReady,
+		Status:             status,
+		Reason:             reason,
+		Message:            message,
+		LastTransitionTime: metav1.Now(),
+	}}
+}
+
+// newTestDependencyClient returns a client with a SNAT rule waiting for a NAT
+// gateway, which waits for its subnet. The subnet is resolved to a network,
+// whose ProviderConfig is invalid.
+func newTestDependencyClient(t *testing.T) client.Client {
+	t.Helper()
+
+	objectMeta := func(name string) metav1.ObjectMeta {
+		return metav1.ObjectMeta{Name: name, Namespace: testNamespace}
+	}
+	providerConfigRef := otcv1alpha1.ProviderConfigReference{Name: "valid"}
+	networkID := "network-id"
+
+	objs := []client.Object{
+		&otcv1alpha1.ProviderConfig{
+			ObjectMeta: objectMeta("valid"),
+			Status: otcv1alpha1.ProviderConfigStatus{
+				Conditions: readyCondition(true, reasonReady, ""),
+			},
+		},
+		&otcv1alpha1.ProviderConfig{
+			ObjectMeta: objectMeta("invalid"),
+			Status: otcv1alpha1.ProviderConfigStatus{
+				Conditions: readyCondition(false, reasonValidationFailed, "invalid credentials"),
+			},
+		},
+		&otcv1alpha1.Network{
+			ObjectMeta: objectMeta("network"),
+			Spec: otcv1alpha1.NetworkSpec{
+				ProviderConfigRef: otcv1alpha1.ProviderConfigReference{Name: "invalid"},
+			},
+			Status: otcv1alpha1.NetworkStatus{
+				ExternalID: networkID,
+				Conditions: readyCondition(false, reasonProviderConfigNotReady, "ProviderConfig is not ready"),
+			},
+		},
+		&otcv1alpha1.Subnet{
+			ObjectMeta: objectMeta("subnet"),
+			Spec: otcv1alpha1.SubnetSpec{
+				ProviderConfigRef: providerConfigRef,
+				Network:           otcv1alpha1.NetworkDependency{NetworkID: &networkID},
+			},
+			Status: otcv1alpha1.SubnetStatus{
+				Conditions: readyCondition(false, reasonDependenciesNotResolved, "Waiting for dependencies"),
+				ResolvedDependencies: otcv1alpha1.SubnetDependenciesResolved{
+					NetworkID: networkID,
+				},
+			},
+		},
+		&otcv1alpha1.NATGateway{
+			ObjectMeta: objectMeta("nat"),
+			Spec: otcv1alpha1.NATGatewaySpec{
+				ProviderConfigRef: providerConfigRef,
+				Subnet: otcv1alpha1.SubnetDependency{
+					SubnetRef: &otcv1alpha1.NamespacedObjectReference{Name: "subnet"},
+				},
+			},
+			Status: otcv1alpha1.NATGatewayStatus{
+				Conditions: readyCondition(false, reasonDependenciesNotResolved, "Waiting for dependencies"),
+			},
+		},
+		&otcv1alpha1.SNATRule{
+			ObjectMeta: objectMeta("snat"),
+			Spec: otcv1alpha1.SNATRuleSpec{
+				ProviderConfigRef: providerConfigRef,
+				NATGateway: otcv1alpha1.NATGatewayDependency{
+					NATGatewayRef: &corev1.LocalObjectReference{Name: "nat"},
+				},
+				PublicIP: otcv1alpha1.PublicIPDependency{
+					PublicIPRef: &corev1.LocalObjectReference{Name: "missing"},
+				},
+			},
+		},
+	}
+
+	return fake.NewClientBuilder().
+		WithScheme(newTestScheme(t)).
+		WithObjects(objs...).
+		Build()
+}
+
+func TestDependencyGraphBlockedBy(t *testing.T) {
+	g, err := BuildDependencyGraph(context.Background(), newTestDependencyClient(t))
+	if err != nil {
+		t.Fatalf("Expected no error, got %s", err)
+	}
+
+	blockers := g.BlockedBy("SNATRule", testNamespace, "snat")
+
+	chain := make([]string, 0, len(blockers))
+	for _, blocker := range blockers {
+		chain = append(chain, blocker.Kind+"/"+blocker.Name)
+	}
+	expected := []string{"NATGateway/nat", "Subnet/subnet", "Network/network", "ProviderConfig/invalid"}
+	if !slices.Equal(chain, expected) {
+		t.Fatalf("Expected chain %v, got %v", expected, chain)
+	}
+	if root := blockers[len(blockers)-1]; root.Message != "invalid credentials" {
+		t.Fatalf("Expected root cause message %q, got %q", "invalid credentials", root.Message)
+	}
+}
+
+func TestDependencyGraphMissingReference(t *testing.T) {
+	g, err := BuildDependencyGraph(context.Background(), newTestDependencyClient(t))
+	if err != nil {
+		t.Fatalf("Expected no error, got %s", err)
+	}
+
+	var snatRule *DependencyNode
+	for _, node := range g.Nodes {
+		if node.Kind == "SNATRule" {
+			snatRule = node
+		}
+	}
+	missing := dependencyNodeID("PublicIP", testNamespace, "missing")
+	if snatRule == nil || !slices.Contains(snatRule.DependsOn, missing) {
+		t.Fatalf("Expected SNATRule to depend on %q", missing)
+	}
+	if node := g.byID[missing]; node.Ready || node.Reason != reasonNotFound {
+		t.Fatalf("Expected missing PublicIP to be not ready with reason %s", reasonNotFound)
+	}
+}
+
+func TestDependencyGraphHandler(t *testing.T) {
+	dependencies := NewDependencyGraphCache(newTestDependencyClient(t), zerolog.Nop())
+	handler := NewDependencyGraphHandler(dependencies)
+
+	// The graph is only served once it was built.
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/debug/dependencies", nil))
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Fatalf("Expected status 503, got %d", rec.Code)
+	}
+
+	if err := dependencies.Rebuild(context.Background()); err != nil {
+		t.Fatalf("Expected no error, got %s", err)
+	}
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/debug/dependencies", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", rec.Code)
+	}
+	var g DependencyGraph
+	if err := json.Unmarshal(rec.Body.Bytes(), &g); err != nil {
+		t.Fatalf("Expected JSON, got %s", err)
+	}
+	if len(g.Nodes) != 7 {
+		t.Fatalf("Expected 7 nodes, got %d", len(g.Nodes))
+	}
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/debug/dependencies?format=dot", nil))
+	edge := `"Subnet default/subnet" -> "Network default/network";`
+	if !strings.Contains(rec.Body.String(), edge) {
+		t.Fatalf("Expected DOT output to contain %s, got:\n%s", edge, rec.Body.String())
+	}
+}
@@ -9,6 +9,8 @@ import (
 	otcv1alpha1 "github.com/peertech.de/otc-operator/api/v1alpha1"
 )
 
+// +kubebuilder:rbac:groups=otc.peertech.de,resources=referencegrants,verbs=get;list;watch
+
 func NewDependencyResolver(c client.Client, namespace string) *DependencyResolver {
 	return &DependencyResolver{
 		client:    c,
@@ -19,6 +21,14 @@ func NewDependencyResolver(c client.Client, namespace string) *DependencyResolve
 type DependencyResolver struct {
 	client    client.Client
 	namespace string
+	preferred []string
+}
+
+// Prefer makes selectors which match multiple resources resolve to one with
+// the given external IDs, as long as it still matches. It is used to keep the
+// previously resolved dependencies stable.
+func (r *DependencyResolver) Prefer(ids ...string) {
+	r.preferred = append(r.preferred, ids...)
 }
 
 // ResolveNetwork resolves a NetworkDependency to its external ID
@@ -31,7 +41,14 @@ func (r *DependencyResolver) ResolveNetwork(
 		return *dep.NetworkID, nil
 	case dep.NetworkRef != nil:
 		var network otcv1alpha1.Network
-		err := resolveByRef(ctx, r.client, dep.NetworkRef, r.namespace, &network)
+		err := resolveByNamespacedRef(
+			ctx,
+			r.client,
+			dep.NetworkRef,
+			r.namespace,
+			otcv1alpha1.ReferenceGrantKindNetwork,
+			&network,
+		)
 		if err != nil {
 			return "", fmt.Errorf("failed to resolve network by reference: %w", err)
 		}
@@ -43,6 +60,7 @@ func (r *DependencyResolver) ResolveNetwork(
 			dep.NetworkSelector,
 			r.namespace,
 			&otcv1alpha1.NetworkList{},
+			r.preferred,
 		)
 		if err != nil {
 			return "", fmt.Errorf("failed to resolve network by selector: %w", err)
@@ -63,7 +81,14 @@ func (r *DependencyResolver) ResolveSubnet(
 		return *dep.SubnetID, nil
 	case dep.SubnetRef != nil:
 		var subnet otcv1alpha1.Subnet
-		err := resolveByRef(ctx, r.client, dep.SubnetRef, r.namespace, &subnet)
+		err := resolveByNamespacedRef(
+			ctx,
+			r.client,
+			dep.SubnetRef,
+			r.namespace,
+			otcv1alpha1.ReferenceGrantKindSubnet,
+			&subnet,
+		)
 		if err != nil {
 			return "", fmt.Errorf("failed to resolve subnet by reference: %w", err)
 		}
@@ -75,6 +100,7 @@ func (r *DependencyResolver) ResolveSubnet(
 			dep.SubnetSelector,
 			r.namespace,
 			&otcv1alpha1.SubnetList{},
+			r.preferred,
 		)
 		if err != nil {
 			return "", fmt.Errorf("failed to resolve subnet by selector: %w", err)
@@ -95,7 +121,14 @@ func (r *DependencyResolver) ResolveSecurityGroup(
 		return *dep.SecurityGroupID, nil
 	case dep.SecurityGroupRef != nil:
 		var sg otcv1alpha1.SecurityGroup
-		err := resolveByRef(ctx, r.client, dep.SecurityGroupRef, r.namespace, &sg)
+		err := resolveByNamespacedRef(
+			ctx,
+			r.client,
+			dep.SecurityGroupRef,
+			r.namespace,
+			otcv1alpha1.ReferenceGrantKindSecurityGroup,
+			&sg,
+		)
 		if err != nil {
 			return "", fmt.Errorf("failed to resolve security group by reference: %w", err)
 		}
@@ -107,6 +140,7 @@ func (r *DependencyResolver) ResolveSecurityGroup(
 			dep.SecurityGroupSelector,
 			r.namespace,
 			&otcv1alpha1.SecurityGroupList{},
+			r.preferred,
 		)
 		if err != nil {
 			return "", fmt.Errorf("failed to resolve security group by selector: %w", err)
@@ -126,12 +160,12 @@ func (r *DependencyResolver) ResolveNATGateway(
 	case dep.NATGatewayID != nil && *dep.NATGatewayID != "":
 		return *dep.NATGatewayID, nil
 	case dep.NATGatewayRef != nil:
-		var sg otcv1alpha1.SecurityGroup
-		err := resolveByRef(ctx, r.client, dep.NATGatewayRef, r.namespace, &sg)
+		var natGateway otcv1alpha1.NATGateway
+		err := resolveByRef(ctx, r.client, dep.NATGatewayRef, r.namespace, &natGateway)
 		if err != nil {
 			return "", fmt.Errorf("failed to resolve NAT gateway by reference: %w", err)
 		}
-		return checkReadinessAndGetID(&sg, "NATGateway")
+		return checkReadinessAndGetID(&natGateway, "NATGateway")
 	case dep.NATGatewaySelector != nil:
 		resolvedObject, err := resolveBySelector(
 			ctx,
@@ -139,6 +173,7 @@ func (r *DependencyResolver) ResolveNATGateway(
 			dep.NATGatewaySelector,
 			r.namespace,
 			&otcv1alpha1.NATGatewayList{},
+			r.preferred,
 		)
 		if err != nil {
 			return "", fmt.Errorf("failed to resolve NAT gateway by selector: %w", err)
@@ -159,12 +194,12 @@ func (r *DependencyResolver) ResolvePublicIP(
 		return *dep.PublicIPID, nil
 
 	case dep.PublicIPRef != nil:
-		var sg otcv1alpha1.SecurityGroup
-		err := resolveByRef(ctx, r.client, dep.PublicIPRef, r.namespace, &sg)
+		var publicIP otcv1alpha1.PublicIP
+		err := resolveByRef(ctx, r.client, dep.PublicIPRef, r.namespace, &publicIP)
 		if err != nil {
 			return "", fmt.Errorf("failed to resolve public IP by reference: %w", err)
 		}
-		return checkReadinessAndGetID(&sg, "PublicIP")
+		return checkReadinessAndGetID(&publicIP, "PublicIP")
 
 	case dep.PublicIPSelector != nil:
 		resolvedObject, err := resolveBySelector(
@@ -173,6 +208,7 @@ func (r *DependencyResolver) ResolvePublicIP(
 			dep.PublicIPSelector,
 			r.namespace,
 			&otcv1alpha1.PublicIPList{},
+			r.preferred,
 		)
 		if err != nil {
 			return "", fmt.Errorf("failed to resolve public IP by selector: %w", err)
@@ -184,6 +220,411 @@ func (r *DependencyResolver) ResolvePublicIP(
 	}
 }
 
+// ResolveBandwidth resolves a BandwidthDependency to its external ID
+func (r *DependencyResolver) ResolveBandwidth(
+	ctx context.Context,
+	dep otcv1alpha1.BandwidthDependency,
+) (string, error) {
+	switch {
+	case dep.BandwidthID != nil && *dep.BandwidthID != "":
+		return *dep.BandwidthID, nil
+	case dep.BandwidthRef != nil:
+		var bandwidth otcv1alpha1.Bandwidth
+		err := resolveByRef(ctx, r.client, dep.BandwidthRef, r.namespace, &bandwidth)
+		if err != nil {
+			return "", fmt.Errorf("failed to resolve bandwidth by reference: %w", err)
+		}
+		return checkReadinessAndGetID(&bandwidth, "Bandwidth")
+	case dep.BandwidthSelector != nil:
+		resolvedObject, err := resolveBySelector(
+			ctx,
+			r.client,
+			dep.BandwidthSelector,
+			r.namespace,
+			&otcv1alpha1.BandwidthList{},
+			r.preferred,
+		)
+		if err != nil {
+			return "", fmt.Errorf("failed to resolve bandwidth by selector: %w", err)
+		}
+		return checkReadinessAndGetID(resolvedObject, "Bandwidth")
+	default:
+		return "", fmt.Errorf("no bandwidth specified")
+	}
+}
+
+// ResolveInstance resolves an InstanceDependency to its external ID
+func (r *DependencyResolver) ResolveInstance(
+	ctx context.Context,
+	dep otcv1alpha1.InstanceDependency,
+) (string, error) {
+	switch {
+	case dep.InstanceID != nil && *dep.InstanceID != "":
+		return *dep.InstanceID, nil
+	case dep.InstanceRef != nil:
+		var instance otcv1alpha1.Instance
+		err := resolveByRef(ctx, r.client, dep.InstanceRef, r.namespace, &instance)
+		if err != nil {
+			return "", fmt.Errorf("failed to resolve instance by reference: %w", err)
+		}
+		return checkReadinessAndGetID(&instance, "Instance")
+	case dep.InstanceSelector != nil:
+		resolvedObject, err := resolveBySelector(
+			ctx,
+			r.client,
+			dep.InstanceSelector,
+			r.namespace,
+			&otcv1alpha1.InstanceList{},
+			r.preferred,
+		)
+		if err != nil {
+			return "", fmt.Errorf("failed to resolve instance by selector: %w", err)
+		}
+		return checkReadinessAndGetID(resolvedObject, "Instance")
+	default:
+		return "", fmt.Errorf("no instance specified")
+	}
+}
+
+// ResolveKeyPair resolves a KeyPairDependency to the name of the key pair
+func (r *DependencyResolver) ResolveKeyPair(
+	ctx context.Context,
+	dep otcv1alpha1.KeyPairDependency,
+) (string, error) {
+	switch {
+	case dep.KeyPairName != nil && *dep.KeyPairName != "":
+		return *dep.KeyPairName, nil
+	case dep.KeyPairRef != nil:
+		var keyPair otcv1alpha1.KeyPair
+		err := resolveByRef(ctx, r.client, dep.KeyPairRef, r.namespace, &keyPair)
+		if err != nil {
+			return "", fmt.Errorf("failed to resolve key pair by reference: %w", err)
+		}
+		return checkReadinessAndGetID(&keyPair, "KeyPair")
+	case dep.KeyPairSelector != nil:
+		resolvedObject, err := resolveBySelector(
+			ctx,
+			r.client,
+			dep.KeyPairSelector,
+			r.namespace,
+			&otcv1alpha1.KeyPairList{},
+			r.preferred,
+		)
+		if err != nil {
+			return "", fmt.Errorf("failed to resolve key pair by selector: %w", err)
+		}
+		return checkReadinessAndGetID(resolvedObject, "KeyPair")
+	default:
+		return "", fmt.Errorf("no key pair specified")
+	}
+}
+
+// ResolveDNSZone resolves a DNSZoneDependency to its external ID
+func (r *DependencyResolver) ResolveDNSZone(
+	ctx context.Context,
+	dep otcv1alpha1.DNSZoneDependency,
+) (string, error) {
+	switch {
+	case dep.DNSZoneID != nil && *dep.DNSZoneID != "":
+		return *dep.DNSZoneID, nil
+	case dep.DNSZoneRef != nil:
+		var zone otcv1alpha1.DNSZone
+		err := resolveByRef(ctx, r.client, dep.DNSZoneRef, r.namespace, &zone)
+		if err != nil {
+			return "", fmt.Errorf("failed to resolve DNS zone by reference: %w", err)
+		}
+		return checkReadinessAndGetID(&zone, "DNSZone")
+	case dep.DNSZoneSelector != nil:
+		resolvedObject, err := resolveBySelector(
+			ctx,
+			r.client,
+			dep.DNSZoneSelector,
+			r.namespace,
+			&otcv1alpha1.DNSZoneList{},
+			r.preferred,
+		)
+		if err != nil {
+			return "", fmt.Errorf("failed to resolve DNS zone by selector: %w", err)
+		}
+		return checkReadinessAndGetID(resolvedObject, "DNSZone")
+	default:
+		return "", fmt.Errorf("no DNS zone specified")
+	}
+}
+
+// ResolveVPNGateway resolves a VPNGatewayDependency to its external ID
+func (r *DependencyResolver) ResolveVPNGateway(
+	ctx context.Context,
+	dep otcv1alpha1.VPNGatewayDependency,
+) (string, error) {
+	switch {
+	case dep.VPNGatewayID != nil && *dep.VPNGatewayID != "":
+		return *dep.VPNGatewayID, nil
+	case dep.VPNGatewayRef != nil:
+		var vpnGateway otcv1alpha1.VPNGateway
+		err := resolveByRef(ctx, r.client, dep.VPNGatewayRef, r.namespace, &vpnGateway)
+		if err != nil {
+			return "", fmt.Errorf("failed to resolve VPN gateway by reference: %w", err)
+		}
+		return checkReadinessAndGetID(&vpnGateway, "VPNGateway")
+	case dep.VPNGatewaySelector != nil:
+		resolvedObject, err := resolveBySelector(
+			ctx,
+			r.client,
+			dep.VPNGatewaySelector,
+			r.namespace,
+			&otcv1alpha1.VPNGatewayList{},
+			r.preferred,
+		)
+		if err != nil {
+			return "", fmt.Errorf("failed to resolve VPN gateway by selector: %w", err)
+		}
+		return checkReadinessAndGetID(resolvedObject, "VPNGateway")
+	default:
+		return "", fmt.Errorf("no VPN gateway specified")
+	}
+}
+
+// ResolveCustomerGateway resolves a CustomerGatewayDependency to its external ID
+func (r *DependencyResolver) ResolveCustomerGateway(
+	ctx context.Context,
+	dep otcv1alpha1.CustomerGatewayDependency,
+) (string, error) {
+	switch {
+	case dep.CustomerGatewayID != nil && *dep.CustomerGatewayID != "":
+		return *dep.CustomerGatewayID, nil
+	case dep.CustomerGatewayRef != nil:
+		var customerGateway otcv1alpha1.CustomerGateway
+		err := resolveByRef(ctx, r.client, dep.CustomerGatewayRef, r.namespace, &customerGateway)
+		if err != nil {
+			return "", fmt.Errorf("failed to resolve customer gateway by reference: %w", err)
+		}
+		return checkReadinessAndGetID(&customerGateway, "CustomerGateway")
+	case dep.CustomerGatewaySelector != nil:
+		resolvedObject, err := resolveBySelector(
+			ctx,
+			r.client,
+			dep.CustomerGatewaySelector,
+			r.namespace,
+			&otcv1alpha1.CustomerGatewayList{},
+			r.preferred,
+		)
+		if err != nil {
+			return "", fmt.Errorf("failed to resolve customer gateway by selector: %w", err)
+		}
+		return checkReadinessAndGetID(resolvedObject, "CustomerGateway")
+	default:
+		return "", fmt.Errorf("no customer gateway specified")
+	}
+}
+
+// ResolveCluster resolves a ClusterDependency to its external ID
+func (r *DependencyResolver) ResolveCluster(
+	ctx context.Context,
+	dep otcv1alpha1.ClusterDependency,
+) (string, error) {
+	switch {
+	case dep.ClusterID != nil && *dep.ClusterID != "":
+		return *dep.ClusterID, nil
+	case dep.ClusterRef != nil:
+		var cluster otcv1alpha1.Cluster
+		err := resolveByRef(ctx, r.client, dep.ClusterRef, r.namespace, &cluster)
+		if err != nil {
+			return "", fmt.Errorf("failed to resolve cluster by reference: %w", err)
+		}
+		return checkReadinessAndGetID(&cluster, "Cluster")
+	case dep.ClusterSelector != nil:
+		resolvedObject, err := resolveBySelector(
+			ctx,
+			r.client,
+			dep.ClusterSelector,
+			r.namespace,
+			&otcv1alpha1.ClusterList{},
+			r.preferred,
+		)
+		if err != nil {
+			return "", fmt.Errorf("failed to resolve cluster by selector: %w", err)
+		}
+		return checkReadinessAndGetID(resolvedObject, "Cluster")
+	default:
+		return "", fmt.Errorf("no cluster specified")
+	}
+}
+
+// ResolveKMSKey resolves a KMSKeyDependency to the ID of the KMS key.
+func (r *DependencyResolver) ResolveKMSKey(
+	ctx context.Context,
+	dep otcv1alpha1.KMSKeyDependency,
+) (string, error) {
+	switch {
+	case dep.KMSKeyID != nil && *dep.KMSKeyID != "":
+		return *dep.KMSKeyID, nil
+	case dep.KMSKeyRef != nil:
+		var key otcv1alpha1.KMSKey
+		err := resolveByRef(ctx, r.client, dep.KMSKeyRef, r.namespace, &key)
+		if err != nil {
+			return "", fmt.Errorf("failed to resolve KMS key by reference: %w", err)
+		}
+		return checkReadinessAndGetID(&key, "KMSKey")
+	case dep.KMSKeySelector != nil:
+		resolvedObject, err := resolveBySelector(
+			ctx,
+			r.client,
+			dep.KMSKeySelector,
+			r.namespace,
+			&otcv1alpha1.KMSKeyList{},
+			r.preferred,
+		)
+		if err != nil {
+			return "", fmt.Errorf("failed to resolve KMS key by selector: %w", err)
+		}
+		return checkReadinessAndGetID(resolvedObject, "KMSKey")
+	default:
+		return "", fmt.Errorf("no KMS key specified")
+	}
+}
+
+// ResolveTopic resolves a TopicDependency to the URN of the topic
+func (r *DependencyResolver) ResolveTopic(
+	ctx context.Context,
+	dep otcv1alpha1.TopicDependency,
+) (string, error) {
+	switch {
+	case dep.TopicURN != nil && *dep.TopicURN != "":
+		return *dep.TopicURN, nil
+	case dep.TopicRef != nil:
+		var topic otcv1alpha1.Topic
+		err := resolveByRef(ctx, r.client, dep.TopicRef, r.namespace, &topic)
+		if err != nil {
+			return "", fmt.Errorf("failed to resolve topic by reference: %w", err)
+		}
+		return checkReadinessAndGetID(&topic, "Topic")
+	case dep.TopicSelector != nil:
+		resolvedObject, err := resolveBySelector(
+			ctx,
+			r.client,
+			dep.TopicSelector,
+			r.namespace,
+			&otcv1alpha1.TopicList{},
+			r.preferred,
+		)
+		if err != nil {
+			return "", fmt.Errorf("failed to resolve topic by selector: %w", err)
+		}
+		return checkReadinessAndGetID(resolvedObject, "Topic")
+	default:
+		return "", fmt.Errorf("no topic specified")
+	}
+}
+
+// ResolveRecordSetValueSource resolves a RecordSetValueSource to the address
+// of the referenced resource.
+func (r *DependencyResolver) ResolveRecordSetValueSource(
+	ctx context.Context,
+	source otcv1alpha1.RecordSetValueSource,
+) (string, error) {
+	switch {
+	case source.PublicIPRef != nil:
+		var publicIP otcv1alpha1.PublicIP
+		err := resolveByRef(ctx, r.client, source.PublicIPRef, r.namespace, &publicIP)
+		if err != nil {
+			return "", fmt.Errorf("failed to resolve public IP by reference: %w", err)
+		}
+		if _, err := checkReadinessAndGetID(&publicIP, "PublicIP"); err != nil {
+			return "", err
+		}
+		if publicIP.Status.PublicAddress == "" {
+			return "", fmt.Errorf(
+				"PublicIP dependency '%s' is not ready: no address reported yet",
+				publicIP.Name,
+			)
+		}
+		return publicIP.Status.PublicAddress, nil
+	case source.VirtualIPRef != nil:
+		var vip otcv1alpha1.VirtualIP
+		err := resolveByRef(ctx, r.client, source.VirtualIPRef, r.namespace, &vip)
+		if err != nil {
+			return "", fmt.Errorf("failed to resolve virtual IP by reference: %w", err)
+		}
+		if _, err := checkReadinessAndGetID(&vip, "VirtualIP"); err != nil {
+			return "", err
+		}
+		if vip.Status.IPAddress == "" {
+			return "", fmt.Errorf(
+				"VirtualIP dependency '%s' is not ready: no address reported yet",
+				vip.Name,
+			)
+		}
+		return vip.Status.IPAddress, nil
+	default:
+		return "", fmt.Errorf("no record source specified")
+	}
+}
+
+// ResolvePublicIPBinding resolves a PublicIPBinding to the external ID of the
+// port to bind to. If the binding references an instance, its external ID is
+// returned as well. It returns empty IDs if no binding is specified.
+func (r *DependencyResolver) ResolvePublicIPBinding(
+	ctx context.Context,
+	binding *otcv1alpha1.PublicIPBinding,
+) (portID, instanceID string, err error) {
+	switch {
+	case binding == nil:
+		return "", "", nil
+	case binding.PortID != nil && *binding.PortID != "":
+		return *binding.PortID, "", nil
+	case binding.VirtualIPRef != nil:
+		var vip otcv1alpha1.VirtualIP
+		err := resolveByRef(ctx, r.client, binding.VirtualIPRef, r.namespace, &vip)
+		if err != nil {
+			return "", "", fmt.Errorf("failed to resolve virtual IP by reference: %w", err)
+		}
+		portID, err := checkReadinessAndGetID(&vip, "VirtualIP")
+		return portID, "", err
+	case binding.InstanceRef != nil:
+		var instance otcv1alpha1.Instance
+		err := resolveByRef(ctx, r.client, binding.InstanceRef, r.namespace, &instance)
+		if err != nil {
+			return "", "", fmt.Errorf("failed to resolve instance by reference: %w", err)
+		}
+		instanceID, err := checkReadinessAndGetID(&instance, "Instance")
+		if err != nil {
+			return "", "", err
+		}
+		if len(instance.Status.Interfaces) == 0 {
+			return "", "", fmt.Errorf(
+				"Instance dependency '%s' is not ready: no network interface reported yet",
+				instance.Name,
+			)
+		}
+		return instance.Status.Interfaces[0].PortID, instanceID, nil
+	default:
+		return "", "", fmt.Errorf("no port specified")
+	}
+}
+
+// ResolvePublicIPDependencies resolves all dependencies for a PublicIP
+// resource. Optional dependencies which are not specified resolve to an empty
+// ID.
+func (r *DependencyResolver) ResolvePublicIPDependencies(
+	ctx context.Context,
+	spec otcv1alpha1.PublicIPSpec,
+) (portID, instanceID, bandwidthID string, err error) {
+	portID, instanceID, err = r.ResolvePublicIPBinding(ctx, spec.Binding)
+	if err != nil {
+		return "", "", "", err
+	}
+
+	if spec.Bandwidth != nil {
+		bandwidthID, err = r.ResolveBandwidth(ctx, *spec.Bandwidth)
+		if err != nil {
+			return "", "", "", err
+		}
+	}
+
+	return portID, instanceID, bandwidthID, nil
+}
+
 // ResolveNATGatewayDependencies resolves all dependencies for a NATGateway resource
 func (r *DependencyResolver) ResolveNATGatewayDependencies(
 	ctx context.Context,
@@ -224,3 +665,374 @@ func (r *DependencyResolver) ResolveSNATRuleDependencies(
 
 	return natGatewayID, subnetID, publicIPID, nil
 }
+
+// ResolveInstanceDependencies resolves all dependencies for an Instance
+// resource. The subnet IDs are returned in the order of the NICs.
+func (r *DependencyResolver) ResolveInstanceDependencies(
+	ctx context.Context,
+	spec otcv1alpha1.InstanceSpec,
+) (otcv1alpha1.InstanceDependenciesResolved, error) {
+	var resolved otcv1alpha1.InstanceDependenciesResolved
+
+	for _, nic := range spec.NICs {
+		subnetID, err := r.ResolveSubnet(ctx, nic.Subnet)
+		if err != nil {
+			return resolved, err
+		}
+		resolved.SubnetIDs = append(resolved.SubnetIDs, subnetID)
+	}
+
+	for _, dep := range spec.SecurityGroups {
+		securityGroupID, err := r.ResolveSecurityGroup(ctx, dep)
+		if err != nil {
+			return resolved, err
+		}
+		resolved.SecurityGroupIDs = append(resolved.SecurityGroupIDs, securityGroupID)
+	}
+
+	if spec.PublicIP != nil {
+		publicIPID, err := r.ResolvePublicIP(ctx, *spec.PublicIP)
+		if err != nil {
+			return resolved, err
+		}
+		resolved.PublicIPID = publicIPID
+	}
+
+	if spec.KeyPair != nil {
+		keyPairName, err := r.ResolveKeyPair(ctx, *spec.KeyPair)
+		if err != nil {
+			return resolved, err
+		}
+		resolved.KeyPairName = keyPairName
+	}
+
+	return resolved, nil
+}
+
+// ResolveVolumeAttachment resolves a VolumeAttachment to the external ID of
+// the instance to attach to. It returns an empty ID if no attachment is
+// specified.
+func (r *DependencyResolver) ResolveVolumeAttachment(
+	ctx context.Context,
+	attachment *otcv1alpha1.VolumeAttachment,
+) (string, error) {
+	if attachment == nil {
+		return "", nil
+	}
+
+	return r.ResolveInstance(ctx, attachment.Instance)
+}
+
+// ResolveVolumeDependencies resolves all dependencies for a Volume resource.
+func (r *DependencyResolver) ResolveVolumeDependencies(
+	ctx context.Context,
+	spec otcv1alpha1.VolumeSpec,
+) (otcv1alpha1.VolumeDependenciesResolved, error) {
+	var resolved otcv1alpha1.VolumeDependenciesResolved
+	var err error
+
+	resolved.InstanceID, err = r.ResolveVolumeAttachment(ctx, spec.Attachment)
+	if err != nil {
+		return resolved, err
+	}
+
+	if spec.KMSKey != nil {
+		resolved.KMSKeyID, err = r.ResolveKMSKey(ctx, *spec.KMSKey)
+		if err != nil {
+			return resolved, err
+		}
+	}
+
+	return resolved, nil
+}
+
+// ResolveDNSZoneDependencies resolves all dependencies for a DNSZone resource.
+// The network IDs are returned in the order of the networks.
+func (r *DependencyResolver) ResolveDNSZoneDependencies(
+	ctx context.Context,
+	spec otcv1alpha1.DNSZoneSpec,
+) (otcv1alpha1.DNSZoneDependenciesResolved, error) {
+	var resolved otcv1alpha1.DNSZoneDependenciesResolved
+
+	for _, dep := range spec.Networks {
+		networkID, err := r.ResolveNetwork(ctx, dep)
+		if err != nil {
+			return resolved, err
+		}
+		resolved.NetworkIDs = append(resolved.NetworkIDs, networkID)
+	}
+
+	return resolved, nil
+}
+
+// ResolveRecordSetDependencies resolves all dependencies for a RecordSet
+// resource.
+func (r *DependencyResolver) ResolveRecordSetDependencies(
+	ctx context.Context,
+	spec otcv1alpha1.RecordSetSpec,
+) (otcv1alpha1.RecordSetDependenciesResolved, error) {
+	var resolved otcv1alpha1.RecordSetDependenciesResolved
+
+	zoneID, err := r.ResolveDNSZone(ctx, spec.Zone)
+	if err != nil {
+		return resolved, err
+	}
+	resolved.DNSZoneID = zoneID
+
+	resolved.Records, err = r.ResolveRecordSetRecords(ctx, spec)
+	if err != nil {
+		return resolved, err
+	}
+
+	return resolved, nil
+}
+
+// ResolveRecordSetRecords resolves the values of a RecordSet resource. The
+// literal values are followed by the addresses of the referenced resources.
+func (r *DependencyResolver) ResolveRecordSetRecords(
+	ctx context.Context,
+	spec otcv1alpha1.RecordSetSpec,
+) ([]string, error) {
+	records := append([]string(nil), spec.Records...)
+	for _, source := range spec.RecordsFrom {
+		record, err := r.ResolveRecordSetValueSource(ctx, source)
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, record)
+	}
+
+	return records, nil
+}
+
+// ResolveVPNGatewayDependencies resolves all dependencies for a VPNGateway
+// resource.
+func (r *DependencyResolver) ResolveVPNGatewayDependencies(
+	ctx context.Context,
+	spec otcv1alpha1.VPNGatewaySpec,
+) (otcv1alpha1.VPNGatewayDependenciesResolved, error) {
+	var resolved otcv1alpha1.VPNGatewayDependenciesResolved
+	var err error
+
+	resolved.NetworkID, err = r.ResolveNetwork(ctx, spec.Network)
+	if err != nil {
+		return resolved, err
+	}
+
+	resolved.SubnetID, err = r.ResolveSubnet(ctx, spec.Subnet)
+	if err != nil {
+		return resolved, err
+	}
+
+	resolved.PrimaryPublicIPID, err = r.ResolvePublicIP(ctx, spec.PrimaryPublicIP)
+	if err != nil {
+		return resolved, err
+	}
+
+	resolved.SecondaryPublicIPID, err = r.ResolvePublicIP(ctx, spec.SecondaryPublicIP)
+	if err != nil {
+		return resolved, err
+	}
+
+	return resolved, nil
+}
+
+// ResolveVPNConnectionDependencies resolves all dependencies for a
+// VPNConnection resource. The public IP of the gateway is looked up by the
+// caller, as it requires the provider.
+func (r *DependencyResolver) ResolveVPNConnectionDependencies(
+	ctx context.Context,
+	spec otcv1alpha1.VPNConnectionSpec,
+) (otcv1alpha1.VPNConnectionDependenciesResolved, error) {
+	var resolved otcv1alpha1.VPNConnectionDependenciesResolved
+	var err error
+
+	resolved.VPNGatewayID, err = r.ResolveVPNGateway(ctx, spec.Gateway)
+	if err != nil {
+		return resolved, err
+	}
+
+	resolved.CustomerGatewayID, err = r.ResolveCustomerGateway(ctx, spec.CustomerGateway)
+	if err != nil {
+		return resolved, err
+	}
+
+	return resolved, nil
+}
+
+// ResolveClusterDependencies resolves all dependencies for a Cluster resource.
+func (r *DependencyResolver) ResolveClusterDependencies(
+	ctx context.Context,
+	spec otcv1alpha1.ClusterSpec,
+) (otcv1alpha1.ClusterDependenciesResolved, error) {
+	var resolved otcv1alpha1.ClusterDependenciesResolved
+	var err error
+
+	resolved.NetworkID, err = r.ResolveNetwork(ctx, spec.Network)
+	if err != nil {
+		return resolved, err
+	}
+
+	resolved.SubnetID, err = r.ResolveSubnet(ctx, spec.Subnet)
+	if err != nil {
+		return resolved, err
+	}
+
+	if spec.SecurityGroup != nil {
+		resolved.SecurityGroupID, err = r.ResolveSecurityGroup(ctx, *spec.SecurityGroup)
+		if err != nil {
+			return resolved, err
+		}
+	}
+
+	return resolved, nil
+}
+
+// ResolveNodePoolDependencies resolves all dependencies for a NodePool
+// resource.
+func (r *DependencyResolver) ResolveNodePoolDependencies(
+	ctx context.Context,
+	spec otcv1alpha1.NodePoolSpec,
+) (otcv1alpha1.NodePoolDependenciesResolved, error) {
+	var resolved otcv1alpha1.NodePoolDependenciesResolved
+	var err error
+
+	resolved.ClusterID, err = r.ResolveCluster(ctx, spec.Cluster)
+	if err != nil {
+		return resolved, err
+	}
+
+	if spec.Subnet != nil {
+		resolved.SubnetID, err = r.ResolveSubnet(ctx, *spec.Subnet)
+		if err != nil {
+			return resolved, err
+		}
+	}
+
+	for _, dep := range spec.SecurityGroups {
+		securityGroupID, err := r.ResolveSecurityGroup(ctx, dep)
+		if err != nil {
+			return resolved, err
+		}
+		resolved.SecurityGroupIDs = append(resolved.SecurityGroupIDs, securityGroupID)
+	}
+
+	resolved.KeyPairName, err = r.ResolveKeyPair(ctx, spec.KeyPair)
+	if err != nil {
+		return resolved, err
+	}
+
+	return resolved, nil
+}
+
+// ResolveDatabaseInstanceDependencies resolves all dependencies for a
+// DatabaseInstance resource.
+func (r *DependencyResolver) ResolveDatabaseInstanceDependencies(
+	ctx context.Context,
+	spec otcv1alpha1.DatabaseInstanceSpec,
+) (otcv1alpha1.DatabaseInstanceDependenciesResolved, error) {
+	var resolved otcv1alpha1.DatabaseInstanceDependenciesResolved
+	var err error
+
+	resolved.NetworkID, err = r.ResolveNetwork(ctx, spec.Network)
+	if err != nil {
+		return resolved, err
+	}
+
+	resolved.SubnetID, err = r.ResolveSubnet(ctx, spec.Subnet)
+	if err != nil {
+		return resolved, err
+	}
+
+	resolved.SecurityGroupID, err = r.ResolveSecurityGroup(ctx, spec.SecurityGroup)
+	if err != nil {
+		return resolved, err
+	}
+
+	if spec.Volume.EncryptionKey != nil {
+		resolved.KMSKeyID, err = r.ResolveKMSKey(ctx, *spec.Volume.EncryptionKey)
+		if err != nil {
+			return resolved, err
+		}
+	}
+
+	return resolved, nil
+}
+
+// ResolveBucketDependencies resolves all dependencies for a Bucket resource.
+func (r *DependencyResolver) ResolveBucketDependencies(
+	ctx context.Context,
+	spec otcv1alpha1.BucketSpec,
+) (otcv1alpha1.BucketDependenciesResolved, error) {
+	var resolved otcv1alpha1.BucketDependenciesResolved
+	var err error
+
+	if spec.Encryption != nil && spec.Encryption.KMSKey != nil {
+		resolved.KMSKeyID, err = r.ResolveKMSKey(ctx, *spec.Encryption.KMSKey)
+		if err != nil {
+			return resolved, err
+		}
+	}
+
+	return resolved, nil
+}
+
+// ResolveAlarmRuleDependencies resolves the resource watched by an AlarmRule
+// and the topics it notifies. The topic URNs are returned in the order of the
+// actions.
+func (r *DependencyResolver) ResolveAlarmRuleDependencies(
+	ctx context.Context,
+	spec otcv1alpha1.AlarmRuleSpec,
+) (otcv1alpha1.AlarmRuleDependenciesResolved, error) {
+	var resolved otcv1alpha1.AlarmRuleDependenciesResolved
+	var err error
+
+	switch resource := spec.Resource; {
+	case resource.NATGateway != nil:
+		resolved.NATGatewayID, err = r.ResolveNATGateway(ctx, *resource.NATGateway)
+	case resource.PublicIP != nil:
+		resolved.PublicIPID, err = r.ResolvePublicIP(ctx, *resource.PublicIP)
+	case resource.Instance != nil:
+		resolved.InstanceID, err = r.ResolveInstance(ctx, *resource.Instance)
+	default:
+		err = fmt.Errorf("no resource specified")
+	}
+	if err != nil {
+		return resolved, err
+	}
+
+	for _, dep := range spec.AlarmActions {
+		topicURN, err := r.ResolveTopic(ctx, dep)
+		if err != nil {
+			return resolved, err
+		}
+		resolved.AlarmTopicURNs = append(resolved.AlarmTopicURNs, topicURN)
+	}
+
+	for _, dep := range spec.OKActions {
+		topicURN, err := r.ResolveTopic(ctx, dep)
+		if err != nil {
+			return resolved, err
+		}
+		resolved.OKTopicURNs = append(resolved.OKTopicURNs, topicURN)
+	}
+
+	return resolved, nil
+}
+
+// ResolveSubscriptionDependencies resolves all dependencies for a
+// Subscription resource.
+func (r *DependencyResolver) ResolveSubscriptionDependencies(
+	ctx context.Context,
+	spec otcv1alpha1.SubscriptionSpec,
+) (otcv1alpha1.SubscriptionDependenciesResolved, error) {
+	var resolved otcv1alpha1.SubscriptionDependenciesResolved
+
+	topicURN, err := r.ResolveTopic(ctx, spec.Topic)
+	if err != nil {
+		return resolved, err
+	}
+	resolved.TopicURN = topicURN
+
+	return resolved, nil
+}
@@ -0,0 +1,214 @@
+package controller
+
+import (
+	"context"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/rs/zerolog"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	otcv1alpha1 "github.com/peertech.de/otc-operator/api/v1alpha1"
+	provider "github.com/peertech.de/otc-operator/internal/provider"
+)
+
+const (
+	dnsZoneFinalizerName = "dnszone.otc.peertech.de/finalizer"
+	dnsZoneRequeueDelay  = 30 * time.Second
+)
+
+// DNSZoneReconciler reconciles a DNSZone object
+type DNSZoneReconciler = ManagedReconciler[
+	*otcv1alpha1.DNSZone,
+	*provider.DNSZoneInfo,
+	provider.UpdateDNSZoneRequest,
+]
+
+func NewDNSZoneReconciler(
+	c client.Client,
+	logger zerolog.Logger,
+	providers *ProviderCache,
+	dependencies *DependencyGraphCache,
+) *DNSZoneReconciler {
+	return NewManagedReconciler(c, logger, providers, dependencies, dnsZoneResource)
+}
+
+// +kubebuilder:rbac:groups=otc.peertech.de,resources=dnszones,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=otc.peertech.de,resources=dnszones/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=otc.peertech.de,resources=dnszones/finalizers,verbs=update
+// +kubebuilder:rbac:groups=otc.peertech.de,resources=networks,verbs=get;list;watch
+// +kubebuilder:rbac:groups=otc.peertech.de,resources=recordsets,verbs=get;list;watch
+// +kubebuilder:rbac:groups=otc.peertech.de,resources=providerconfigs,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;create;update;patch
+// +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch;create;update;patch
+
+var dnsZoneResource = ManagedResource[
+	*otcv1alpha1.DNSZone,
+	*provider.DNSZoneInfo,
+	provider.UpdateDNSZoneRequest,
+]{
+	ControllerName: "dnszone",
+	Name:           "DNS zone",
+	FinalizerName:  dnsZoneFinalizerName,
+	RequeueDelay:   dnsZoneRequeueDelay,
+
+	New: func() *otcv1alpha1.DNSZone { return &otcv1alpha1.DNSZone{} },
+
+	Resolve: func(
+		ctx context.Context,
+		resolver *DependencyResolver,
+		zone *otcv1alpha1.DNSZone,
+	) error {
+		resolved, err := resolver.ResolveDNSZoneDependencies(ctx, zone.Spec)
+		if err != nil {
+			return err
+		}
+
+		zone.Status.ResolvedDependencies = resolved
+		return nil
+	},
+
+	// The IDs of referenced networks can change if they get recreated.
+	// Selectors prefer the previously resolved resources to stay stable.
+	Refresh: func(
+		ctx context.Context,
+		resolver *DependencyResolver,
+		zone *otcv1alpha1.DNSZone,
+	) error {
+		resolver.Prefer(zone.Status.ResolvedDependencies.NetworkIDs...)
+		resolved, err := resolver.ResolveDNSZoneDependencies(ctx, zone.Spec)
+		if err != nil {
+			return err
+		}
+
+		zone.Status.ResolvedDependencies = resolved
+		return nil
+	},
+
+	Create: func(
+		ctx context.Context,
+		p provider.Provider,
+		zone *otcv1alpha1.DNSZone,
+	) (string, error) {
+		resp, err := p.CreateDNSZone(ctx, provider.CreateDNSZoneRequest{
+			Name:        zone.Spec.DomainName,
+			Email:       zone.Spec.Email,
+			Description: zone.Spec.Description,
+			TTL:         zone.Spec.TTL,
+			Type:        zone.Spec.Type,
+			NetworkIDs:  zone.Status.ResolvedDependencies.NetworkIDs,
+		})
+		return resp.ID, err
+	},
+
+	Observe: func(
+		ctx context.Context,
+		p provider.Provider,
+		zone *otcv1alpha1.DNSZone,
+	) (*provider.DNSZoneInfo, error) {
+		info, err := p.GetDNSZone(ctx, zone.Status.ExternalID)
+		if err != nil {
+			return nil, err
+		}
+
+		// Report the observed network associations.
+		zone.Status.AssociatedNetworkIDs = info.NetworkIDs
+		return info, nil
+	},
+
+	Diff: func(
+		logger zerolog.Logger,
+		zone *otcv1alpha1.DNSZone,
+		info *provider.DNSZoneInfo,
+	) (provider.UpdateDNSZoneRequest, bool) {
+		var updateReq provider.UpdateDNSZoneRequest
+		needsUpdate := false
+
+		lastAppliedSpec := zone.Status.LastAppliedSpec
+		if zone.Spec.Email != lastAppliedSpec.Email {
+			logger.Info().
+				Str("current", lastAppliedSpec.Email).
+				Str("desired", zone.Spec.Email).
+				Msg("Drift detected in email")
+
+			updateReq.Email = zone.Spec.Email
+			needsUpdate = true
+		}
+
+		if zone.Spec.Description != lastAppliedSpec.Description {
+			logger.Info().
+				Str("current", lastAppliedSpec.Description).
+				Str("desired", zone.Spec.Description).
+				Msg("Drift detected in description")
+
+			updateReq.Description = zone.Spec.Description
+			needsUpdate = true
+		}
+
+		if zone.Spec.TTL != info.TTL {
+			logger.Info().
+				Int("current", info.TTL).
+				Int("desired", zone.Spec.TTL).
+				Msg("Drift detected in TTL")
+
+			updateReq.TTL = zone.Spec.TTL
+			needsUpdate = true
+		}
+
+		// Network associations only exist for private zones.
+		if zone.Spec.Type != otcv1alpha1.DNSZonePrivate {
+			return updateReq, needsUpdate
+		}
+
+		desiredNetworkIDs := zone.Status.ResolvedDependencies.NetworkIDs
+		for _, networkID := range desiredNetworkIDs {
+			if !slices.Contains(info.NetworkIDs, networkID) {
+				updateReq.AssociateNetworkIDs = append(updateReq.AssociateNetworkIDs, networkID)
+			}
+		}
+		for _, networkID := range info.NetworkIDs {
+			if !slices.Contains(desiredNetworkIDs, networkID) {
+				updateReq.DisassociateNetworkIDs = append(updateReq.DisassociateNetworkIDs, networkID)
+			}
+		}
+
+		if len(updateReq.AssociateNetworkIDs) > 0 || len(updateReq.DisassociateNetworkIDs) > 0 {
+			logger.Info().
+				Strs("current", info.NetworkIDs).
+				Strs("desired", desiredNetworkIDs).
+				Msg("Drift detected in networks")
+
+			needsUpdate = true
+		}
+
+		return updateReq, needsUpdate
+	},
+
+	Update: func(
+		ctx context.Context,
+		p provider.Provider,
+		zone *otcv1alpha1.DNSZone,
+		req provider.UpdateDNSZoneRequest,
+	) error {
+		return p.UpdateDNSZone(ctx, zone.Status.ExternalID, req)
+	},
+
+	ConnectionDetails: func(
+		_ *otcv1alpha1.DNSZone,
+		info *provider.DNSZoneInfo,
+	) map[string]string {
+		return map[string]string{
+			"externalID": info.ID,
+			"domainName": info.Name,
+			"ttl":        strconv.Itoa(info.TTL),
+		}
+	},
+
+	// Deleting the zone would delete the record sets in it too.
+	ReferenceChecks: ReferenceChecksFor("DNSZone"),
+
+	Delete: func(ctx context.Context, p provider.Provider, zone *otcv1alpha1.DNSZone) error {
+		return p.DeleteDNSZone(ctx, zone.Status.ExternalID)
+	},
+}
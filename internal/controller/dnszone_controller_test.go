@@ -0,0 +1,106 @@
+package controller
+
+import (
+	"slices"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	otcv1alpha1 "github.com/peertech.de/otc-operator/api/v1alpha1"
+)
+
+const (
+	testDNSZoneName = "zone"
+	testDNSZoneID   = "zone-id"
+)
+
+func newTestDNSZone() *otcv1alpha1.DNSZone {
+	networkID := "network-a"
+	return &otcv1alpha1.DNSZone{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       testDNSZoneName,
+			Namespace:  testNamespace,
+			Finalizers: []string{dnsZoneFinalizerName},
+		},
+		Spec: otcv1alpha1.DNSZoneSpec{
+			ProviderConfigRef: testProviderConfigRef,
+			DomainName:        "example.internal.",
+			Type:              otcv1alpha1.DNSZonePrivate,
+			TTL:               300,
+			Networks:          []otcv1alpha1.NetworkDependency{{NetworkID: &networkID}},
+		},
+	}
+}
+
+func TestDNSZoneLifecycle(t *testing.T) {
+	p := &fakeProvider{}
+	r := newTestManagedReconciler(t, NewDNSZoneReconciler, p, newTestDNSZone())
+
+	// The private zone is created with its network.
+	_, zone := reconcileTestManaged(t, r, newTestDNSZone())
+	if len(p.dnsZones.created) != 1 || !slices.Equal(p.dnsZones.created[0].NetworkIDs, []string{"network-a"}) {
+		t.Fatalf("Expected zone to be created with network %q, got %+v", "network-a", p.dnsZones.created)
+	}
+	if zone.Status.ExternalID != testDNSZoneID {
+		t.Fatalf("Expected externalID %q, got %q", testDNSZoneID, zone.Status.ExternalID)
+	}
+
+	// The zone is not ready while it is created.
+	_, zone = reconcileTestManaged(t, r, zone)
+	expectCondition(t, zone, condReady, metav1.ConditionFalse, reasonProvisioning)
+
+	p.dnsZones.info.Status = "ACTIVE"
+	_, zone = reconcileTestManaged(t, r, zone)
+	expectCondition(t, zone, condReady, metav1.ConditionTrue, reasonProvisioned)
+
+	// A changed TTL and an added network are applied.
+	updateTestManaged(t, r, zone, func(zone *otcv1alpha1.DNSZone) {
+		networkID := "network-b"
+		zone.Spec.TTL = 600
+		zone.Spec.Networks = append(zone.Spec.Networks, otcv1alpha1.NetworkDependency{NetworkID: &networkID})
+	})
+	_, zone = reconcileTestManaged(t, r, zone)
+	if len(p.dnsZones.updated) != 1 || p.dnsZones.updated[0].TTL != 600 ||
+		!slices.Equal(p.dnsZones.updated[0].AssociateNetworkIDs, []string{"network-b"}) {
+		t.Fatalf("Expected TTL and network to be updated, got %+v", p.dnsZones.updated)
+	}
+
+	// A network associated out-of-band is disassociated again.
+	p.dnsZones.info.NetworkIDs = append(p.dnsZones.info.NetworkIDs, "network-c")
+	_, zone = reconcileTestManaged(t, r, zone)
+	if len(p.dnsZones.updated) != 2 || p.dnsZones.updated[1].TTL != 0 ||
+		!slices.Equal(p.dnsZones.updated[1].DisassociateNetworkIDs, []string{"network-c"}) {
+		t.Fatalf("Expected network to be disassociated, got %+v", p.dnsZones.updated)
+	}
+
+	_, zone = reconcileTestManaged(t, r, zone)
+	if len(p.dnsZones.updated) != 2 {
+		t.Fatalf("Expected no further update, got %+v", p.dnsZones.updated)
+	}
+	if !slices.Equal(zone.Status.AssociatedNetworkIDs, []string{"network-a", "network-b"}) {
+		t.Fatalf("Expected observed networks, got %v", zone.Status.AssociatedNetworkIDs)
+	}
+
+	deleteTestManaged(t, r, zone)
+	if !slices.Equal(p.dnsZones.deleted, []string{testDNSZoneID}) {
+		t.Fatalf("Expected external deletion of %q, got %v", testDNSZoneID, p.dnsZones.deleted)
+	}
+}
+
+func TestDNSZoneNotFound(t *testing.T) {
+	zone := newTestDNSZone()
+	zone.Status.ExternalID = testDNSZoneID
+	zone.Status.LastAppliedSpec = zone.Spec.DeepCopy()
+
+	p := &fakeProvider{}
+	r := newTestManagedReconciler(t, NewDNSZoneReconciler, p, zone)
+
+	// The zone deleted out-of-band is created again.
+	_, zone = reconcileTestManaged(t, r, zone)
+	expectRecreate(t, zone)
+
+	_, zone = reconcileTestManaged(t, r, zone)
+	if len(p.dnsZones.created) != 1 || zone.Status.ExternalID != testDNSZoneID {
+		t.Fatalf("Expected zone to be recreated, got %+v", p.dnsZones.created)
+	}
+}
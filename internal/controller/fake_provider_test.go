@@ -0,0 +1,922 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	otcv1alpha1 "github.com/peertech.de/otc-operator/api/v1alpha1"
+	provider "github.com/peertech.de/otc-operator/internal/provider"
+)
+
+// fakeResource keeps a single external resource of the fake provider, which
+// is missing while info is nil, and records the requests for it.
+type fakeResource[I, C, U any] struct {
+	info    *I
+	created []C
+	updated []U
+	deleted []string
+}
+
+// create records the request and keeps the created resource.
+func (r *fakeResource[I, C, U]) create(req C, info *I) {
+	r.created = append(r.created, req)
+	r.info = info
+}
+
+// get returns a copy of the resource, or provider.ErrNotFound while it is
+// missing.
+func (r *fakeResource[I, C, U]) get() (*I, error) {
+	if r.info == nil {
+		return nil, provider.ErrNotFound
+	}
+	info := *r.info
+	return &info, nil
+}
+
+// update records the request and returns the resource to apply it to.
+func (r *fakeResource[I, C, U]) update(req U) *I {
+	r.updated = append(r.updated, req)
+	return r.info
+}
+
+// delete records the ID and removes the resource.
+func (r *fakeResource[I, C, U]) delete(id string) error {
+	r.deleted = append(r.deleted, id)
+	r.info = nil
+	return nil
+}
+
+// fakeProvider keeps a single external resource of every kind. All other
+// methods panic, as they are not expected to be called.
+type fakeProvider struct {
+	provider.Provider
+
+	networks fakeResource[
+		provider.NetworkInfo,
+		provider.CreateNetworkRequest,
+		provider.UpdateNetworkRequest,
+	]
+	instances fakeResource[
+		provider.InstanceInfo,
+		provider.CreateInstanceRequest,
+		provider.UpdateInstanceRequest,
+	]
+	volumes fakeResource[
+		provider.VolumeInfo,
+		provider.CreateVolumeRequest,
+		provider.UpdateVolumeRequest,
+	]
+	keyPairs fakeResource[
+		provider.KeyPairInfo,
+		provider.CreateKeyPairRequest,
+		struct{},
+	]
+	dnsZones fakeResource[
+		provider.DNSZoneInfo,
+		provider.CreateDNSZoneRequest,
+		provider.UpdateDNSZoneRequest,
+	]
+	recordSets fakeResource[
+		provider.RecordSetInfo,
+		provider.CreateRecordSetRequest,
+		provider.UpdateRecordSetRequest,
+	]
+	customerGateways fakeResource[
+		provider.CustomerGatewayInfo,
+		provider.CreateCustomerGatewayRequest,
+		struct{},
+	]
+	vpnGateways fakeResource[
+		provider.VPNGatewayInfo,
+		provider.CreateVPNGatewayRequest,
+		provider.UpdateVPNGatewayRequest,
+	]
+	vpnConnections fakeResource[
+		provider.VPNConnectionInfo,
+		provider.CreateVPNConnectionRequest,
+		provider.UpdateVPNConnectionRequest,
+	]
+	clusters fakeResource[
+		provider.ClusterInfo,
+		provider.CreateClusterRequest,
+		provider.UpdateClusterRequest,
+	]
+	nodePools fakeResource[
+		provider.NodePoolInfo,
+		provider.CreateNodePoolRequest,
+		provider.UpdateNodePoolRequest,
+	]
+	databaseInstances fakeResource[
+		provider.DatabaseInstanceInfo,
+		provider.CreateDatabaseInstanceRequest,
+		provider.UpdateDatabaseInstanceRequest,
+	]
+	buckets fakeResource[
+		provider.BucketInfo,
+		provider.CreateBucketRequest,
+		provider.UpdateBucketRequest,
+	]
+	kmsKeys fakeResource[
+		provider.KMSKeyInfo,
+		provider.CreateKMSKeyRequest,
+		provider.UpdateKMSKeyRequest,
+	]
+	alarmRules fakeResource[
+		provider.AlarmRuleInfo,
+		provider.CreateAlarmRuleRequest,
+		provider.UpdateAlarmRuleRequest,
+	]
+	topics fakeResource[
+		provider.TopicInfo,
+		provider.CreateTopicRequest,
+		provider.UpdateTopicRequest,
+	]
+	subscriptions fakeResource[
+		provider.SubscriptionInfo,
+		provider.CreateSubscriptionRequest,
+		struct{},
+	]
+	virtualIPs fakeResource[
+		provider.VirtualIPInfo,
+		provider.CreateVirtualIPRequest,
+		provider.UpdateVirtualIPRequest,
+	]
+	publicIPs fakeResource[
+		provider.PublicIPInfo,
+		provider.CreatePublicIPRequest,
+		provider.UpdatePublicIPRequest,
+	]
+	bandwidths fakeResource[
+		provider.BandwidthInfo,
+		provider.CreateBandwidthRequest,
+		provider.UpdateBandwidthRequest,
+	]
+
+	deletedDataVolumes []string
+	detachedVolumes    []string
+	kubeconfigs        int
+	forceDestroy       bool
+	pendingDays        int
+}
+
+func (p *fakeProvider) CreateNetwork(
+	_ context.Context,
+	req provider.CreateNetworkRequest,
+) (provider.CreateNetworkResponse, error) {
+	p.networks.create(req, &provider.NetworkInfo{ID: testRecreatedID, Name: req.Name, Cidr: req.Cidr})
+	return provider.CreateNetworkResponse{ID: testRecreatedID}, nil
+}
+
+func (p *fakeProvider) GetNetwork(_ context.Context, _ string) (*provider.NetworkInfo, error) {
+	return p.networks.get()
+}
+
+func (p *fakeProvider) DeleteNetwork(_ context.Context, id string) error {
+	return p.networks.delete(id)
+}
+
+func (p *fakeProvider) CreateInstance(
+	_ context.Context,
+	req provider.CreateInstanceRequest,
+) (provider.CreateInstanceResponse, error) {
+	p.instances.create(req, &provider.InstanceInfo{ID: testInstanceID, Name: req.Name, Status: "BUILD"})
+	return provider.CreateInstanceResponse{ID: testInstanceID}, nil
+}
+
+func (p *fakeProvider) GetInstance(_ context.Context, _ string) (*provider.InstanceInfo, error) {
+	return p.instances.get()
+}
+
+func (p *fakeProvider) UpdateInstance(
+	_ context.Context,
+	_ string,
+	req provider.UpdateInstanceRequest,
+) error {
+	info := p.instances.update(req)
+	if req.PowerState != nil && *req.PowerState == otcv1alpha1.InstancePowerStateStopped {
+		info.Status = "SHUTOFF"
+	}
+	return nil
+}
+
+func (p *fakeProvider) DeleteInstance(
+	_ context.Context,
+	id string,
+	dataVolumeIDs []string,
+) error {
+	p.deletedDataVolumes = append(p.deletedDataVolumes, dataVolumeIDs...)
+	return p.instances.delete(id)
+}
+
+func (p *fakeProvider) CreateVolume(
+	_ context.Context,
+	req provider.CreateVolumeRequest,
+) (provider.CreateVolumeResponse, error) {
+	p.volumes.create(req, &provider.VolumeInfo{
+		ID:     testVolumeExternalID,
+		Name:   req.Name,
+		Size:   req.Size,
+		Status: "creating",
+	})
+	return provider.CreateVolumeResponse{ID: testVolumeExternalID}, nil
+}
+
+func (p *fakeProvider) GetVolume(_ context.Context, _ string) (*provider.VolumeInfo, error) {
+	return p.volumes.get()
+}
+
+func (p *fakeProvider) UpdateVolume(
+	_ context.Context,
+	id string,
+	req provider.UpdateVolumeRequest,
+) error {
+	info := p.volumes.update(req)
+	if req.Size != 0 {
+		info.Size = req.Size
+	}
+	if req.InstanceID != nil && *req.InstanceID == "" {
+		p.detachedVolumes = append(p.detachedVolumes, id)
+		info.AttachedInstanceID = ""
+	}
+	return nil
+}
+
+func (p *fakeProvider) DeleteVolume(_ context.Context, id string) error {
+	return p.volumes.delete(id)
+}
+
+// CreateKeyPair returns a private key for generated key pairs.
+func (p *fakeProvider) CreateKeyPair(
+	_ context.Context,
+	req provider.CreateKeyPairRequest,
+) (provider.CreateKeyPairResponse, error) {
+	info := &provider.KeyPairInfo{ID: req.Name, Fingerprint: "fingerprint", PublicKey: req.PublicKey}
+	p.keyPairs.create(req, info)
+
+	resp := provider.CreateKeyPairResponse{ID: req.Name}
+	if req.PublicKey == "" {
+		info.PublicKey = "public-key"
+		resp.PrivateKey = "private-key"
+	}
+	return resp, nil
+}
+
+func (p *fakeProvider) GetKeyPair(_ context.Context, _ string) (*provider.KeyPairInfo, error) {
+	return p.keyPairs.get()
+}
+
+func (p *fakeProvider) DeleteKeyPair(_ context.Context, id string) error {
+	return p.keyPairs.delete(id)
+}
+
+func (p *fakeProvider) CreateDNSZone(
+	_ context.Context,
+	req provider.CreateDNSZoneRequest,
+) (provider.CreateDNSZoneResponse, error) {
+	p.dnsZones.create(req, &provider.DNSZoneInfo{
+		ID:         testDNSZoneID,
+		Name:       req.Name,
+		TTL:        req.TTL,
+		Type:       string(req.Type),
+		Status:     "PENDING_CREATE",
+		NetworkIDs: slices.Clone(req.NetworkIDs),
+	})
+	return provider.CreateDNSZoneResponse{ID: testDNSZoneID}, nil
+}
+
+func (p *fakeProvider) GetDNSZone(_ context.Context, _ string) (*provider.DNSZoneInfo, error) {
+	info, err := p.dnsZones.get()
+	if err != nil {
+		return nil, err
+	}
+	info.NetworkIDs = slices.Clone(info.NetworkIDs)
+	return info, nil
+}
+
+func (p *fakeProvider) UpdateDNSZone(
+	_ context.Context,
+	_ string,
+	req provider.UpdateDNSZoneRequest,
+) error {
+	info := p.dnsZones.update(req)
+	if req.TTL != 0 {
+		info.TTL = req.TTL
+	}
+	info.NetworkIDs = append(info.NetworkIDs, req.AssociateNetworkIDs...)
+	info.NetworkIDs = slices.DeleteFunc(info.NetworkIDs, func(id string) bool {
+		return slices.Contains(req.DisassociateNetworkIDs, id)
+	})
+	return nil
+}
+
+func (p *fakeProvider) DeleteDNSZone(_ context.Context, id string) error {
+	return p.dnsZones.delete(id)
+}
+
+func (p *fakeProvider) CreateRecordSet(
+	_ context.Context,
+	req provider.CreateRecordSetRequest,
+) (provider.CreateRecordSetResponse, error) {
+	p.recordSets.create(req, &provider.RecordSetInfo{
+		ID:      testRecordSetID,
+		Name:    req.Name,
+		Type:    req.Type,
+		TTL:     req.TTL,
+		Records: slices.Clone(req.Records),
+		Status:  "PENDING_CREATE",
+		ZoneID:  req.ZoneID,
+	})
+	return provider.CreateRecordSetResponse{ID: testRecordSetID}, nil
+}
+
+func (p *fakeProvider) GetRecordSet(
+	_ context.Context,
+	_ string,
+	_ string,
+) (*provider.RecordSetInfo, error) {
+	info, err := p.recordSets.get()
+	if err != nil {
+		return nil, err
+	}
+	info.Records = slices.Clone(info.Records)
+	return info, nil
+}
+
+func (p *fakeProvider) UpdateRecordSet(
+	_ context.Context,
+	_ string,
+	_ string,
+	req provider.UpdateRecordSetRequest,
+) error {
+	info := p.recordSets.update(req)
+	if req.TTL != 0 {
+		info.TTL = req.TTL
+	}
+	if req.Records != nil {
+		info.Records = slices.Clone(req.Records)
+	}
+	return nil
+}
+
+// DeleteRecordSet records the zone and the record set ID.
+func (p *fakeProvider) DeleteRecordSet(_ context.Context, zoneID string, id string) error {
+	return p.recordSets.delete(zoneID + "/" + id)
+}
+
+func (p *fakeProvider) CreateCustomerGateway(
+	_ context.Context,
+	req provider.CreateCustomerGatewayRequest,
+) (provider.CreateCustomerGatewayResponse, error) {
+	p.customerGateways.create(req, &provider.CustomerGatewayInfo{
+		ID:        testCustomerGatewayID,
+		Name:      req.Name,
+		IPAddress: req.IPAddress,
+		BGPASN:    req.BGPASN,
+	})
+	return provider.CreateCustomerGatewayResponse{ID: testCustomerGatewayID}, nil
+}
+
+func (p *fakeProvider) GetCustomerGateway(
+	_ context.Context,
+	_ string,
+) (*provider.CustomerGatewayInfo, error) {
+	return p.customerGateways.get()
+}
+
+func (p *fakeProvider) DeleteCustomerGateway(_ context.Context, id string) error {
+	return p.customerGateways.delete(id)
+}
+
+func (p *fakeProvider) CreateVPNGateway(
+	_ context.Context,
+	req provider.CreateVPNGatewayRequest,
+) (provider.CreateVPNGatewayResponse, error) {
+	p.vpnGateways.create(req, &provider.VPNGatewayInfo{
+		ID:                  testVPNGatewayID,
+		Name:                req.Name,
+		LocalSubnets:        slices.Clone(req.LocalSubnets),
+		Status:              "PENDING_CREATE",
+		NetworkID:           req.NetworkID,
+		SubnetID:            req.SubnetID,
+		PrimaryPublicIPID:   req.PrimaryPublicIPID,
+		SecondaryPublicIPID: req.SecondaryPublicIPID,
+	})
+	return provider.CreateVPNGatewayResponse{ID: testVPNGatewayID}, nil
+}
+
+func (p *fakeProvider) GetVPNGateway(_ context.Context, _ string) (*provider.VPNGatewayInfo, error) {
+	info, err := p.vpnGateways.get()
+	if err != nil {
+		return nil, err
+	}
+	info.LocalSubnets = slices.Clone(info.LocalSubnets)
+	return info, nil
+}
+
+func (p *fakeProvider) UpdateVPNGateway(
+	_ context.Context,
+	_ string,
+	req provider.UpdateVPNGatewayRequest,
+) error {
+	info := p.vpnGateways.update(req)
+	info.LocalSubnets = slices.Clone(req.LocalSubnets)
+	return nil
+}
+
+func (p *fakeProvider) DeleteVPNGateway(_ context.Context, id string) error {
+	return p.vpnGateways.delete(id)
+}
+
+func (p *fakeProvider) CreateVPNConnection(
+	_ context.Context,
+	req provider.CreateVPNConnectionRequest,
+) (provider.CreateVPNConnectionResponse, error) {
+	p.vpnConnections.create(req, &provider.VPNConnectionInfo{
+		ID:                testVPNConnectionID,
+		Name:              req.Name,
+		PeerSubnets:       slices.Clone(req.PeerSubnets),
+		Status:            "PENDING_CREATE",
+		VPNGatewayID:      req.VPNGatewayID,
+		GatewayPublicIPID: req.GatewayPublicIPID,
+		CustomerGatewayID: req.CustomerGatewayID,
+	})
+	return provider.CreateVPNConnectionResponse{ID: testVPNConnectionID}, nil
+}
+
+func (p *fakeProvider) GetVPNConnection(
+	_ context.Context,
+	_ string,
+) (*provider.VPNConnectionInfo, error) {
+	info, err := p.vpnConnections.get()
+	if err != nil {
+		return nil, err
+	}
+	info.PeerSubnets = slices.Clone(info.PeerSubnets)
+	return info, nil
+}
+
+func (p *fakeProvider) UpdateVPNConnection(
+	_ context.Context,
+	_ string,
+	req provider.UpdateVPNConnectionRequest,
+) error {
+	info := p.vpnConnections.update(req)
+	if req.PeerSubnets != nil {
+		info.PeerSubnets = slices.Clone(req.PeerSubnets)
+	}
+	return nil
+}
+
+func (p *fakeProvider) DeleteVPNConnection(_ context.Context, id string) error {
+	return p.vpnConnections.delete(id)
+}
+
+func (p *fakeProvider) CreateCluster(
+	_ context.Context,
+	req provider.CreateClusterRequest,
+) (provider.CreateClusterResponse, error) {
+	p.clusters.create(req, &provider.ClusterInfo{
+		ID:          testClusterID,
+		Name:        req.Name,
+		Version:     req.Version,
+		Description: req.Description,
+		Phase:       "Creating",
+		NetworkID:   req.NetworkID,
+		SubnetID:    req.SubnetID,
+	})
+	return provider.CreateClusterResponse{ID: testClusterID}, nil
+}
+
+func (p *fakeProvider) GetCluster(_ context.Context, _ string) (*provider.ClusterInfo, error) {
+	return p.clusters.get()
+}
+
+// GetClusterKubeconfig counts the kubeconfig requests.
+func (p *fakeProvider) GetClusterKubeconfig(_ context.Context, _ string) ([]byte, error) {
+	p.kubeconfigs++
+	return []byte("kubeconfig"), nil
+}
+
+func (p *fakeProvider) UpdateCluster(
+	_ context.Context,
+	_ string,
+	req provider.UpdateClusterRequest,
+) error {
+	info := p.clusters.update(req)
+	if req.Description != nil {
+		info.Description = *req.Description
+	}
+	return nil
+}
+
+func (p *fakeProvider) DeleteCluster(_ context.Context, id string) error {
+	return p.clusters.delete(id)
+}
+
+func (p *fakeProvider) CreateNodePool(
+	_ context.Context,
+	req provider.CreateNodePoolRequest,
+) (provider.CreateNodePoolResponse, error) {
+	p.nodePools.create(req, &provider.NodePoolInfo{
+		ID:        testNodePoolID,
+		Name:      req.Name,
+		NodeCount: req.NodeCount,
+		Labels:    maps.Clone(req.Labels),
+		Phase:     "Synchronizing",
+	})
+	return provider.CreateNodePoolResponse{ID: testNodePoolID}, nil
+}
+
+func (p *fakeProvider) GetNodePool(
+	_ context.Context,
+	_ string,
+	_ string,
+) (*provider.NodePoolInfo, error) {
+	info, err := p.nodePools.get()
+	if err != nil {
+		return nil, err
+	}
+	info.Labels = maps.Clone(info.Labels)
+	return info, nil
+}
+
+func (p *fakeProvider) UpdateNodePool(
+	_ context.Context,
+	_ string,
+	_ string,
+	req provider.UpdateNodePoolRequest,
+) error {
+	info := p.nodePools.update(req)
+	info.NodeCount = req.NodeCount
+	info.Autoscaling = req.Autoscaling
+	info.Taints = req.Taints
+	maps.Copy(info.Labels, req.Labels)
+	for _, key := range req.RemovedLabels {
+		delete(info.Labels, key)
+	}
+	return nil
+}
+
+// DeleteNodePool records the cluster and the node pool ID.
+func (p *fakeProvider) DeleteNodePool(_ context.Context, clusterID string, id string) error {
+	return p.nodePools.delete(clusterID + "/" + id)
+}
+
+func (p *fakeProvider) CreateDatabaseInstance(
+	_ context.Context,
+	req provider.CreateDatabaseInstanceRequest,
+) (provider.CreateDatabaseInstanceResponse, error) {
+	p.databaseInstances.create(req, &provider.DatabaseInstanceInfo{
+		ID:           testDatabaseInstanceID,
+		Name:         req.Name,
+		Flavor:       req.Flavor,
+		VolumeSize:   req.Volume.Size,
+		BackupPolicy: req.BackupPolicy,
+		Status:       "BUILD",
+	})
+	return provider.CreateDatabaseInstanceResponse{ID: testDatabaseInstanceID}, nil
+}
+
+func (p *fakeProvider) GetDatabaseInstance(
+	_ context.Context,
+	_ string,
+) (*provider.DatabaseInstanceInfo, error) {
+	return p.databaseInstances.get()
+}
+
+func (p *fakeProvider) UpdateDatabaseInstance(
+	_ context.Context,
+	_ string,
+	req provider.UpdateDatabaseInstanceRequest,
+) error {
+	info := p.databaseInstances.update(req)
+	if req.VolumeSize != 0 {
+		info.VolumeSize = req.VolumeSize
+	}
+	if req.Flavor != "" {
+		info.Flavor = req.Flavor
+	}
+	if req.BackupPolicy != nil {
+		info.BackupPolicy = *req.BackupPolicy
+	}
+	return nil
+}
+
+func (p *fakeProvider) DeleteDatabaseInstance(_ context.Context, id string) error {
+	return p.databaseInstances.delete(id)
+}
+
+// applyBucketConfig sets the observed configuration of the bucket to config.
+func applyBucketConfig(info *provider.BucketInfo, config provider.BucketConfig) {
+	info.Versioning = "Suspended"
+	if config.Versioning {
+		info.Versioning = "Enabled"
+	}
+	info.LifecycleRules = config.LifecycleRules
+	info.Encryption = config.Encryption
+	info.CORSRules = config.CORSRules
+	info.Policy = config.Policy
+}
+
+func (p *fakeProvider) CreateBucket(
+	_ context.Context,
+	req provider.CreateBucketRequest,
+) (provider.CreateBucketResponse, error) {
+	info := &provider.BucketInfo{Name: req.Name, Region: "eu-de"}
+	applyBucketConfig(info, req.BucketConfig)
+	p.buckets.create(req, info)
+	return provider.CreateBucketResponse{ID: req.Name}, nil
+}
+
+func (p *fakeProvider) GetBucket(_ context.Context, _ string) (*provider.BucketInfo, error) {
+	return p.buckets.get()
+}
+
+func (p *fakeProvider) UpdateBucket(
+	_ context.Context,
+	_ string,
+	req provider.UpdateBucketRequest,
+) error {
+	applyBucketConfig(p.buckets.update(req), req.BucketConfig)
+	return nil
+}
+
+// DeleteBucket records whether the bucket was force destroyed.
+func (p *fakeProvider) DeleteBucket(_ context.Context, name string, forceDestroy bool) error {
+	p.forceDestroy = forceDestroy
+	return p.buckets.delete(name)
+}
+
+func (p *fakeProvider) CreateKMSKey(
+	_ context.Context,
+	req provider.CreateKMSKeyRequest,
+) (provider.CreateKMSKeyResponse, error) {
+	p.kmsKeys.create(req, &provider.KMSKeyInfo{
+		ID:                   testKMSKeyID,
+		Alias:                req.Alias,
+		Description:          req.Description,
+		RotationEnabled:      req.RotationEnabled,
+		RotationIntervalDays: req.RotationIntervalDays,
+		Status:               "1",
+	})
+	return provider.CreateKMSKeyResponse{ID: testKMSKeyID}, nil
+}
+
+func (p *fakeProvider) GetKMSKey(_ context.Context, _ string) (*provider.KMSKeyInfo, error) {
+	return p.kmsKeys.get()
+}
+
+func (p *fakeProvider) UpdateKMSKey(
+	_ context.Context,
+	_ string,
+	req provider.UpdateKMSKeyRequest,
+) error {
+	info := p.kmsKeys.update(req)
+	if req.Alias != "" {
+		info.Alias = req.Alias
+	}
+	if req.Description != nil {
+		info.Description = *req.Description
+	}
+	if req.RotationEnabled != nil {
+		info.RotationEnabled = *req.RotationEnabled
+	}
+	if req.RotationIntervalDays != 0 {
+		info.RotationIntervalDays = req.RotationIntervalDays
+	}
+	return nil
+}
+
+// DeleteKMSKey records the pending days of the scheduled deletion.
+func (p *fakeProvider) DeleteKMSKey(_ context.Context, id string, pendingDays int) error {
+	p.pendingDays = pendingDays
+	return p.kmsKeys.delete(id)
+}
+
+// CreateAlarmRule returns a new ID for every created alarm rule.
+func (p *fakeProvider) CreateAlarmRule(
+	_ context.Context,
+	req provider.CreateAlarmRuleRequest,
+) (provider.CreateAlarmRuleResponse, error) {
+	id := fmt.Sprintf("alarm-%d", len(p.alarmRules.created)+1)
+	p.alarmRules.create(req, &provider.AlarmRuleInfo{
+		ID:             id,
+		Name:           req.Name,
+		Namespace:      req.Namespace,
+		MetricName:     req.MetricName,
+		DimensionName:  req.DimensionName,
+		DimensionValue: req.DimensionValue,
+		Enabled:        req.Enabled,
+		AlarmState:     "insufficient_data",
+		AlarmTopicURNs: req.AlarmTopicURNs,
+		OKTopicURNs:    req.OKTopicURNs,
+	})
+	return provider.CreateAlarmRuleResponse{ID: id}, nil
+}
+
+func (p *fakeProvider) GetAlarmRule(_ context.Context, _ string) (*provider.AlarmRuleInfo, error) {
+	info, err := p.alarmRules.get()
+	if err != nil {
+		return nil, err
+	}
+	info.AlarmTopicURNs = slices.Clone(info.AlarmTopicURNs)
+	info.OKTopicURNs = slices.Clone(info.OKTopicURNs)
+	return info, nil
+}
+
+func (p *fakeProvider) UpdateAlarmRule(
+	_ context.Context,
+	_ string,
+	req provider.UpdateAlarmRuleRequest,
+) error {
+	p.alarmRules.update(req).Enabled = req.Enabled
+	return nil
+}
+
+func (p *fakeProvider) DeleteAlarmRule(_ context.Context, id string) error {
+	return p.alarmRules.delete(id)
+}
+
+// normalizePolicy strips the whitespace of a policy like SMN does.
+func normalizePolicy(policy string) string {
+	return strings.ReplaceAll(policy, " ", "")
+}
+
+func (p *fakeProvider) CreateTopic(
+	_ context.Context,
+	req provider.CreateTopicRequest,
+) (provider.CreateTopicResponse, error) {
+	p.topics.create(req, &provider.TopicInfo{
+		URN:         testTopicURN,
+		Name:        req.Name,
+		DisplayName: req.DisplayName,
+		Policy:      normalizePolicy(req.Policy),
+	})
+	return provider.CreateTopicResponse{URN: testTopicURN}, nil
+}
+
+func (p *fakeProvider) GetTopic(_ context.Context, _ string) (*provider.TopicInfo, error) {
+	return p.topics.get()
+}
+
+func (p *fakeProvider) UpdateTopic(
+	_ context.Context,
+	_ string,
+	req provider.UpdateTopicRequest,
+) error {
+	info := p.topics.update(req)
+	if req.DisplayName != nil {
+		info.DisplayName = *req.DisplayName
+	}
+	if req.Policy != nil {
+		info.Policy = normalizePolicy(*req.Policy)
+	}
+	return nil
+}
+
+func (p *fakeProvider) DeleteTopic(_ context.Context, urn string) error {
+	return p.topics.delete(urn)
+}
+
+// CreateSubscription returns a new URN for every created subscription.
+func (p *fakeProvider) CreateSubscription(
+	_ context.Context,
+	req provider.CreateSubscriptionRequest,
+) (provider.CreateSubscriptionResponse, error) {
+	urn := fmt.Sprintf("%s:subscription-%d", req.TopicURN, len(p.subscriptions.created)+1)
+	p.subscriptions.create(req, &provider.SubscriptionInfo{
+		URN:      urn,
+		TopicURN: req.TopicURN,
+		Protocol: req.Protocol,
+		Endpoint: req.Endpoint,
+		Remark:   req.Remark,
+	})
+	return provider.CreateSubscriptionResponse{URN: urn}, nil
+}
+
+// GetSubscription only finds the subscription in the topic it was created in.
+func (p *fakeProvider) GetSubscription(
+	_ context.Context,
+	topicURN, _ string,
+) (*provider.SubscriptionInfo, error) {
+	info, err := p.subscriptions.get()
+	if err != nil || info.TopicURN != topicURN {
+		return nil, provider.ErrNotFound
+	}
+	return info, nil
+}
+
+func (p *fakeProvider) DeleteSubscription(_ context.Context, urn string) error {
+	return p.subscriptions.delete(urn)
+}
+
+func (p *fakeProvider) CreateVirtualIP(
+	_ context.Context,
+	req provider.CreateVirtualIPRequest,
+) (provider.CreateVirtualIPResponse, error) {
+	p.virtualIPs.create(req, &provider.VirtualIPInfo{
+		ID:        testVirtualIPID,
+		Name:      req.Name,
+		IPAddress: req.IPAddress,
+		Status:    "BUILD",
+		SubnetID:  req.SubnetID,
+	})
+	return provider.CreateVirtualIPResponse{ID: testVirtualIPID}, nil
+}
+
+func (p *fakeProvider) GetVirtualIP(_ context.Context, _ string) (*provider.VirtualIPInfo, error) {
+	return p.virtualIPs.get()
+}
+
+func (p *fakeProvider) DeleteVirtualIP(_ context.Context, id string) error {
+	return p.virtualIPs.delete(id)
+}
+
+func (p *fakeProvider) CreatePublicIP(
+	_ context.Context,
+	req provider.CreatePublicIPRequest,
+) (provider.CreatePublicIPResponse, error) {
+	p.publicIPs.create(req, &provider.PublicIPInfo{
+		ID:                 testPublicIPID,
+		Name:               req.Name,
+		PublicAddress:      "80.158.0.10",
+		Type:               string(req.Type),
+		BandwidthSize:      req.BandwidthSize,
+		BandwidthName:      req.BandwidthName,
+		BandwidthShareType: string(req.BandwidthShareType),
+		Status:             "PENDING_CREATE",
+		PortID:             req.PortID,
+		BandwidthID:        req.BandwidthID,
+	})
+	return provider.CreatePublicIPResponse{ID: testPublicIPID}, nil
+}
+
+func (p *fakeProvider) GetPublicIP(_ context.Context, _ string) (*provider.PublicIPInfo, error) {
+	return p.publicIPs.get()
+}
+
+func (p *fakeProvider) UpdatePublicIP(
+	_ context.Context,
+	_ string,
+	req provider.UpdatePublicIPRequest,
+) error {
+	info := p.publicIPs.update(req)
+	if req.PortID != nil {
+		info.PortID = *req.PortID
+	}
+	if req.BandwidthName != "" {
+		info.BandwidthName = req.BandwidthName
+	}
+	if req.BandwidthSize != 0 {
+		info.BandwidthSize = req.BandwidthSize
+	}
+	return nil
+}
+
+// DeletePublicIP refuses to release a bound public IP like the provider.
+func (p *fakeProvider) DeletePublicIP(_ context.Context, id string) error {
+	if p.publicIPs.info != nil && p.publicIPs.info.PortID != "" {
+		return fmt.Errorf("public IP %s is bound to port %s", id, p.publicIPs.info.PortID)
+	}
+	return p.publicIPs.delete(id)
+}
+
+func (p *fakeProvider) CreateBandwidth(
+	_ context.Context,
+	req provider.CreateBandwidthRequest,
+) (provider.CreateBandwidthResponse, error) {
+	p.bandwidths.create(req, &provider.BandwidthInfo{
+		ID:         testBandwidthID,
+		Name:       req.Name,
+		Size:       req.Size,
+		ShareType:  "WHOLE",
+		ChargeMode: string(req.ChargeMode),
+		Status:     "PENDING_CREATE",
+	})
+	return provider.CreateBandwidthResponse{ID: testBandwidthID}, nil
+}
+
+func (p *fakeProvider) GetBandwidth(_ context.Context, _ string) (*provider.BandwidthInfo, error) {
+	info, err := p.bandwidths.get()
+	if err != nil {
+		return nil, err
+	}
+	info.PublicIPIDs = slices.Clone(info.PublicIPIDs)
+	return info, nil
+}
+
+func (p *fakeProvider) UpdateBandwidth(
+	_ context.Context,
+	_ string,
+	req provider.UpdateBandwidthRequest,
+) error {
+	p.bandwidths.update(req).Size = req.Size
+	return nil
+}
+
+func (p *fakeProvider) DeleteBandwidth(_ context.Context, id string) error {
+	return p.bandwidths.delete(id)
+}
@@ -0,0 +1,300 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/rs/zerolog"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	otcv1alpha1 "github.com/peertech.de/otc-operator/api/v1alpha1"
+	provider "github.com/peertech.de/otc-operator/internal/provider"
+)
+
+const (
+	instanceFinalizerName = "instance.otc.peertech.de/finalizer"
+	instanceRequeueDelay  = 30 * time.Second
+)
+
+// InstanceReconciler reconciles an Instance object
+type InstanceReconciler = ManagedReconciler[
+	*otcv1alpha1.Instance,
+	*instanceObservation,
+	provider.UpdateInstanceRequest,
+]
+
+func NewInstanceReconciler(
+	c client.Client,
+	logger zerolog.Logger,
+	providers *ProviderCache,
+	dependencies *DependencyGraphCache,
+) *InstanceReconciler {
+	return NewManagedReconciler(c, logger, providers, dependencies, newInstanceResource(c))
+}
+
+// instanceObservation is the observed instance together with its desired
+// power state, as a stopped instance is ready if it is meant to be stopped.
+type instanceObservation struct {
+	*provider.InstanceInfo
+
+	powerState otcv1alpha1.InstancePowerState
+}
+
+// State returns the state of the instance, reporting a stopped instance as
+// ready if it is meant to be stopped.
+func (o *instanceObservation) State() provider.State {
+	state := o.InstanceInfo.State()
+	if state == provider.Stopped && o.powerState == otcv1alpha1.InstancePowerStateStopped {
+		return provider.Ready
+	}
+	return state
+}
+
+// +kubebuilder:rbac:groups=otc.peertech.de,resources=instances,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=otc.peertech.de,resources=instances/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=otc.peertech.de,resources=instances/finalizers,verbs=update
+// +kubebuilder:rbac:groups=otc.peertech.de,resources=subnets,verbs=get;list;watch
+// +kubebuilder:rbac:groups=otc.peertech.de,resources=securitygroups,verbs=get;list;watch
+// +kubebuilder:rbac:groups=otc.peertech.de,resources=publicips,verbs=get;list;watch
+// +kubebuilder:rbac:groups=otc.peertech.de,resources=volumes,verbs=get;list;watch
+// +kubebuilder:rbac:groups=otc.peertech.de,resources=keypairs,verbs=get;list;watch
+// +kubebuilder:rbac:groups=otc.peertech.de,resources=providerconfigs,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;create;update;patch
+// +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch;create;update;patch
+
+// newInstanceResource returns the instance resource, which reads the user
+// data with the client.
+func newInstanceResource(c client.Client) ManagedResource[
+	*otcv1alpha1.Instance,
+	*instanceObservation,
+	provider.UpdateInstanceRequest,
+] {
+	return ManagedResource[
+		*otcv1alpha1.Instance,
+		*instanceObservation,
+		provider.UpdateInstanceRequest,
+	]{
+		ControllerName: "instance",
+		Name:           "instance",
+		FinalizerName:  instanceFinalizerName,
+		RequeueDelay:   instanceRequeueDelay,
+
+		New: func() *otcv1alpha1.Instance { return &otcv1alpha1.Instance{} },
+
+		// The user data is a dependency of the instance.
+		Resolve: func(
+			ctx context.Context,
+			r *DependencyResolver,
+			instance *otcv1alpha1.Instance,
+		) error {
+			resolved, err := r.ResolveInstanceDependencies(ctx, instance.Spec)
+			if err != nil {
+				return err
+			}
+			if _, err := getUserData(ctx, c, instance); err != nil {
+				return err
+			}
+			instance.Status.ResolvedDependencies = resolved
+			return nil
+		},
+
+		// The power state is applied on the next reconciliation, as the
+		// instance is always created running.
+		Create: func(
+			ctx context.Context,
+			p provider.Provider,
+			instance *otcv1alpha1.Instance,
+		) (string, error) {
+			userData, err := getUserData(ctx, c, instance)
+			if err != nil {
+				return "", err
+			}
+
+			resolved := instance.Status.ResolvedDependencies
+			nics := make([]provider.CreateInstanceNIC, len(instance.Spec.NICs))
+			for i, nic := range instance.Spec.NICs {
+				nics[i] = provider.CreateInstanceNIC{
+					SubnetID:  resolved.SubnetIDs[i],
+					IPAddress: nic.IPAddress,
+				}
+			}
+
+			resp, err := p.CreateInstance(ctx, provider.CreateInstanceRequest{
+				Name:             instance.GetName(),
+				Flavor:           instance.Spec.Flavor,
+				ImageID:          instance.Spec.ImageID,
+				AvailabilityZone: instance.Spec.AvailabilityZone,
+				RootVolume:       instance.Spec.RootVolume,
+				DataVolumes:      instance.Spec.DataVolumes,
+				UserData:         userData,
+				NICs:             nics,
+				SecurityGroupIDs: resolved.SecurityGroupIDs,
+				PublicIPID:       resolved.PublicIPID,
+				KeyPairName:      resolved.KeyPairName,
+			})
+			return resp.ID, err
+		},
+
+		Observe: func(
+			ctx context.Context,
+			p provider.Provider,
+			instance *otcv1alpha1.Instance,
+		) (*instanceObservation, error) {
+			info, err := p.GetInstance(ctx, instance.Status.ExternalID)
+			if err != nil {
+				return nil, err
+			}
+
+			// Report the observed power state, interfaces, addresses and data
+			// volumes.
+			updateInstanceStatus(instance, info)
+			return &instanceObservation{
+				InstanceInfo: info,
+				powerState:   instance.Spec.PowerState,
+			}, nil
+		},
+
+		Diff: func(
+			logger zerolog.Logger,
+			instance *otcv1alpha1.Instance,
+			info *instanceObservation,
+		) (provider.UpdateInstanceRequest, bool) {
+			var updateReq provider.UpdateInstanceRequest
+			needsUpdate := false
+
+			// The power state is compared against the provider, as the
+			// instance can also be started or stopped out-of-band. Transient
+			// states are left alone.
+			desiredPowerState := instance.Spec.PowerState
+			if desiredPowerState == "" {
+				desiredPowerState = otcv1alpha1.InstancePowerStateRunning
+			}
+
+			state := info.InstanceInfo.State()
+			if (state == provider.Ready && desiredPowerState == otcv1alpha1.InstancePowerStateStopped) ||
+				(state == provider.Stopped && desiredPowerState == otcv1alpha1.InstancePowerStateRunning) {
+				logger.Info().
+					Str("current", instance.Status.PowerState).
+					Str("desired", string(desiredPowerState)).
+					Msg("Drift detected in power state")
+
+				updateReq.PowerState = &desiredPowerState
+				needsUpdate = true
+			}
+
+			return updateReq, needsUpdate
+		},
+
+		Update: func(
+			ctx context.Context,
+			p provider.Provider,
+			instance *otcv1alpha1.Instance,
+			req provider.UpdateInstanceRequest,
+		) error {
+			return p.UpdateInstance(ctx, instance.Status.ExternalID, req)
+		},
+
+		ConnectionDetails: func(
+			instance *otcv1alpha1.Instance,
+			info *instanceObservation,
+		) map[string]string {
+			return map[string]string{
+				"externalID":     info.ID,
+				"privateAddress": instance.Status.PrivateAddress,
+				"publicAddress":  instance.Status.PublicAddress,
+			}
+		},
+
+		// Public IPs can still be bound or volumes attached to the instance.
+		ReferenceChecks: ReferenceChecksFor("Instance"),
+
+		Delete: func(ctx context.Context, p provider.Provider, instance *otcv1alpha1.Instance) error {
+			return p.DeleteInstance(ctx, instance.Status.ExternalID, instance.Status.DataVolumeIDs)
+		},
+	}
+}
+
+// getUserData reads the user data from the referenced Secret.
+func getUserData(
+	ctx context.Context,
+	c client.Client,
+	instance *otcv1alpha1.Instance,
+) ([]byte, error) {
+	ref := instance.Spec.UserDataSecretRef
+	if ref == nil {
+		return nil, nil
+	}
+
+	var secret corev1.Secret
+	err := c.Get(ctx, client.ObjectKey{Namespace: instance.Namespace, Name: ref.Name}, &secret)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user data secret '%s': %w", ref.Name, err)
+	}
+
+	userData, ok := secret.Data[ref.Key]
+	if !ok {
+		return nil, fmt.Errorf("user data secret '%s' has no key '%s'", ref.Name, ref.Key)
+	}
+
+	return userData, nil
+}
+
+// updateInstanceStatus reports the observed state of the instance. The
+// interfaces are ordered like the NICs in the spec, so the primary network
+// interface comes first.
+func updateInstanceStatus(
+	instance *otcv1alpha1.Instance,
+	info *provider.InstanceInfo,
+) {
+	switch info.State() {
+	case provider.Ready:
+		instance.Status.PowerState = string(otcv1alpha1.InstancePowerStateRunning)
+	case provider.Stopped:
+		instance.Status.PowerState = string(otcv1alpha1.InstancePowerStateStopped)
+	default:
+		instance.Status.PowerState = info.Status
+	}
+
+	used := make([]bool, len(info.Interfaces))
+	var interfaces []otcv1alpha1.InstanceInterface
+	for _, subnetID := range instance.Status.ResolvedDependencies.SubnetIDs {
+		for i, iface := range info.Interfaces {
+			if used[i] || iface.SubnetID != subnetID {
+				continue
+			}
+			used[i] = true
+			interfaces = append(interfaces, otcv1alpha1.InstanceInterface{
+				PortID:    iface.PortID,
+				SubnetID:  iface.SubnetID,
+				IPAddress: iface.IPAddress,
+			})
+			break
+		}
+	}
+	for i, iface := range info.Interfaces {
+		if !used[i] {
+			interfaces = append(interfaces, otcv1alpha1.InstanceInterface{
+				PortID:    iface.PortID,
+				SubnetID:  iface.SubnetID,
+				IPAddress: iface.IPAddress,
+			})
+		}
+	}
+	instance.Status.Interfaces = interfaces
+
+	instance.Status.PrivateAddress = ""
+	if len(interfaces) > 0 {
+		instance.Status.PrivateAddress = interfaces[0].IPAddress
+	}
+
+	instance.Status.PublicAddress = ""
+	if len(info.PublicAddresses) > 0 {
+		instance.Status.PublicAddress = info.PublicAddresses[0]
+	}
+
+	// The data volumes are recorded, so that they are deleted even if a
+	// deletion of the instance is retried after the instance is gone.
+	instance.Status.DataVolumeIDs = info.DataVolumeIDs
+}
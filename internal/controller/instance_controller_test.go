@@ -0,0 +1,108 @@
+package controller
+
+import (
+	"slices"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	otcv1alpha1 "github.com/peertech.de/otc-operator/api/v1alpha1"
+	provider "github.com/peertech.de/otc-operator/internal/provider"
+)
+
+const testSubnetID = "subnet-id"
+
+func newTestInstance() *otcv1alpha1.Instance {
+	subnetID := testSubnetID
+	return &otcv1alpha1.Instance{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       testInstanceName,
+			Namespace:  testNamespace,
+			Finalizers: []string{instanceFinalizerName},
+		},
+		Spec: otcv1alpha1.InstanceSpec{
+			ProviderConfigRef: testProviderConfigRef,
+			Flavor:            "s3.medium.2",
+			ImageID:           "image-id",
+			NICs: []otcv1alpha1.InstanceNIC{{
+				Subnet: otcv1alpha1.SubnetDependency{SubnetID: &subnetID},
+			}},
+			PowerState: otcv1alpha1.InstancePowerStateRunning,
+		},
+	}
+}
+
+func TestInstanceLifecycle(t *testing.T) {
+	p := &fakeProvider{}
+	r := newTestManagedReconciler(t, NewInstanceReconciler, p, newTestInstance())
+
+	// The instance is created with the resolved subnet.
+	_, instance := reconcileTestManaged(t, r, newTestInstance())
+	if len(p.instances.created) != 1 || p.instances.created[0].NICs[0].SubnetID != testSubnetID {
+		t.Fatalf("Expected instance to be created in subnet %q, got %+v", testSubnetID, p.instances.created)
+	}
+	if instance.Status.ExternalID != testInstanceID {
+		t.Fatalf("Expected externalID %q, got %q", testInstanceID, instance.Status.ExternalID)
+	}
+
+	// The instance is not ready while it is built.
+	_, instance = reconcileTestManaged(t, r, instance)
+	expectCondition(t, instance, condReady, metav1.ConditionFalse, reasonProvisioning)
+
+	// The running instance reports its addresses and data volumes.
+	p.instances.info.Status = "ACTIVE"
+	p.instances.info.Interfaces = []provider.InstanceInterfaceInfo{
+		{PortID: "port-id", SubnetID: testSubnetID, IPAddress: "10.0.0.10"},
+	}
+	p.instances.info.DataVolumeIDs = []string{"data-volume-id"}
+	_, instance = reconcileTestManaged(t, r, instance)
+	expectCondition(t, instance, condReady, metav1.ConditionTrue, reasonProvisioned)
+	if instance.Status.PrivateAddress != "10.0.0.10" ||
+		instance.Status.PowerState != string(otcv1alpha1.InstancePowerStateRunning) ||
+		!slices.Equal(instance.Status.DataVolumeIDs, []string{"data-volume-id"}) {
+		t.Fatalf("Expected observed status, got %+v", instance.Status)
+	}
+
+	// A changed power state is applied.
+	updateTestManaged(t, r, instance, func(instance *otcv1alpha1.Instance) {
+		instance.Spec.PowerState = otcv1alpha1.InstancePowerStateStopped
+	})
+	_, instance = reconcileTestManaged(t, r, instance)
+	if len(p.instances.updated) != 1 || *p.instances.updated[0].PowerState != otcv1alpha1.InstancePowerStateStopped {
+		t.Fatalf("Expected instance to be stopped, got %+v", p.instances.updated)
+	}
+
+	// The stopped instance is ready, as it is meant to be stopped.
+	_, instance = reconcileTestManaged(t, r, instance)
+	expectCondition(t, instance, condReady, metav1.ConditionTrue, reasonReady)
+	if len(p.instances.updated) != 1 {
+		t.Fatalf("Expected no further update, got %+v", p.instances.updated)
+	}
+
+	// The data volumes recorded in the status are deleted with the instance.
+	deleteTestManaged(t, r, instance)
+	if !slices.Equal(p.instances.deleted, []string{testInstanceID}) {
+		t.Fatalf("Expected external deletion of %q, got %v", testInstanceID, p.instances.deleted)
+	}
+	if !slices.Equal(p.deletedDataVolumes, []string{"data-volume-id"}) {
+		t.Fatalf("Expected deletion of data volume %q, got %v", "data-volume-id", p.deletedDataVolumes)
+	}
+}
+
+func TestInstanceNotFound(t *testing.T) {
+	instance := newTestInstance()
+	instance.Status.ExternalID = testInstanceID
+	instance.Status.LastAppliedSpec = instance.Spec.DeepCopy()
+
+	p := &fakeProvider{}
+	r := newTestManagedReconciler(t, NewInstanceReconciler, p, instance)
+
+	// The instance deleted out-of-band is created again.
+	_, instance = reconcileTestManaged(t, r, instance)
+	expectRecreate(t, instance)
+
+	_, instance = reconcileTestManaged(t, r, instance)
+	if len(p.instances.created) != 1 || instance.Status.ExternalID != testInstanceID {
+		t.Fatalf("Expected instance to be recreated, got %+v", p.instances.created)
+	}
+}
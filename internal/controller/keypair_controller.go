@@ -0,0 +1,232 @@
+package controller
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	otcv1alpha1 "github.com/peertech.de/otc-operator/api/v1alpha1"
+	provider "github.com/peertech.de/otc-operator/internal/provider"
+)
+
+const (
+	keyPairFinalizerName = "keypair.otc.peertech.de/finalizer"
+	keyPairRequeueDelay  = 30 * time.Second
+
+	// keyPairNameHashLength is the number of hex characters of the hash
+	// suffix of key pair names at the provider.
+	keyPairNameHashLength = 8
+	// keyPairNameMaxLength is the maximum length of key pair names at the
+	// provider.
+	keyPairNameMaxLength = 64
+)
+
+// KeyPairReconciler reconciles a KeyPair object
+type KeyPairReconciler = ManagedReconciler[
+	*otcv1alpha1.KeyPair,
+	*provider.KeyPairInfo,
+	provider.UpdateKeyPairRequest,
+]
+
+func NewKeyPairReconciler(
+	c client.Client,
+	logger zerolog.Logger,
+	providers *ProviderCache,
+	dependencies *DependencyGraphCache,
+) *KeyPairReconciler {
+	return NewManagedReconciler(c, logger, providers, dependencies, newKeyPairResource(c))
+}
+
+// +kubebuilder:rbac:groups=otc.peertech.de,resources=keypairs,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=otc.peertech.de,resources=keypairs/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=otc.peertech.de,resources=keypairs/finalizers,verbs=update
+// +kubebuilder:rbac:groups=otc.peertech.de,resources=instances,verbs=get;list;watch
+// +kubebuilder:rbac:groups=otc.peertech.de,resources=nodepools,verbs=get;list;watch
+// +kubebuilder:rbac:groups=otc.peertech.de,resources=providerconfigs,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;create;update;patch
+// +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch;create;update;patch
+
+// newKeyPairResource returns the key pair resource, which reads and writes
+// the keys with the client. Key pairs cannot be updated, so it has no Diff.
+func newKeyPairResource(c client.Client) ManagedResource[
+	*otcv1alpha1.KeyPair,
+	*provider.KeyPairInfo,
+	provider.UpdateKeyPairRequest,
+] {
+	return ManagedResource[
+		*otcv1alpha1.KeyPair,
+		*provider.KeyPairInfo,
+		provider.UpdateKeyPairRequest,
+	]{
+		ControllerName: "keypair",
+		Name:           "key pair",
+		FinalizerName:  keyPairFinalizerName,
+		RequeueDelay:   keyPairRequeueDelay,
+
+		New: func() *otcv1alpha1.KeyPair { return &otcv1alpha1.KeyPair{} },
+
+		// The public key to import is a dependency of the key pair.
+		Resolve: func(
+			ctx context.Context,
+			_ *DependencyResolver,
+			keyPair *otcv1alpha1.KeyPair,
+		) error {
+			_, err := getPublicKey(ctx, c, keyPair)
+			return err
+		},
+
+		Create: func(
+			ctx context.Context,
+			p provider.Provider,
+			keyPair *otcv1alpha1.KeyPair,
+		) (string, error) {
+			publicKey, err := getPublicKey(ctx, c, keyPair)
+			if err != nil {
+				return "", err
+			}
+
+			resp, err := p.CreateKeyPair(ctx, provider.CreateKeyPairRequest{
+				Name:      keyPairProviderName(keyPair),
+				PublicKey: publicKey,
+			})
+			if err != nil {
+				return "", err
+			}
+
+			// The private key of a generated key pair is only returned once,
+			// so the key pair is deleted again if it cannot be stored.
+			if keyPair.Spec.PrivateKeySecretRef != nil {
+				if err := writePrivateKey(ctx, c, keyPair, resp.PrivateKey); err != nil {
+					if delErr := p.DeleteKeyPair(ctx, resp.ID); delErr != nil {
+						err = fmt.Errorf("%w, and failed to delete key pair: %w", err, delErr)
+					}
+					return "", fmt.Errorf("failed to store private key: %w", err)
+				}
+			}
+
+			return resp.ID, nil
+		},
+
+		Observe: func(
+			ctx context.Context,
+			p provider.Provider,
+			keyPair *otcv1alpha1.KeyPair,
+		) (*provider.KeyPairInfo, error) {
+			info, err := p.GetKeyPair(ctx, keyPair.Status.ExternalID)
+			if err != nil {
+				return nil, err
+			}
+
+			// Report the observed public key.
+			keyPair.Status.Fingerprint = info.Fingerprint
+			keyPair.Status.PublicKey = info.PublicKey
+			return info, nil
+		},
+
+		ConnectionDetails: func(
+			_ *otcv1alpha1.KeyPair,
+			info *provider.KeyPairInfo,
+		) map[string]string {
+			return map[string]string{
+				"externalID":  info.ID,
+				"fingerprint": info.Fingerprint,
+				"publicKey":   info.PublicKey,
+			}
+		},
+
+		ReferenceChecks: ReferenceChecksFor("KeyPair"),
+
+		Delete: func(ctx context.Context, p provider.Provider, keyPair *otcv1alpha1.KeyPair) error {
+			return p.DeleteKeyPair(ctx, keyPair.Status.ExternalID)
+		},
+	}
+}
+
+// keyPairProviderName returns the name of the key pair at the provider. Key
+// pairs are identified by their name, so it is qualified with the namespace
+// to keep key pairs of different namespaces apart. The hash suffix keeps the
+// names of different namespace and name pairs apart, like namespace "a-b"
+// with name "c" and namespace "a" with name "b-c".
+func keyPairProviderName(keyPair *otcv1alpha1.KeyPair) string {
+	sum := sha256.Sum256([]byte(keyPair.GetNamespace() + "/" + keyPair.GetName()))
+	suffix := hex.EncodeToString(sum[:])[:keyPairNameHashLength]
+
+	prefix := keyPair.GetNamespace() + "-" + keyPair.GetName()
+	if maxLength := keyPairNameMaxLength - len(suffix) - 1; len(prefix) > maxLength {
+		prefix = strings.TrimRight(prefix[:maxLength], "-.")
+	}
+	return prefix + "-" + suffix
+}
+
+// getPublicKey reads the public key to import from the referenced Secret. It
+// returns an empty key if a new key pair is to be generated.
+func getPublicKey(
+	ctx context.Context,
+	c client.Client,
+	keyPair *otcv1alpha1.KeyPair,
+) (string, error) {
+	ref := keyPair.Spec.PublicKeySecretRef
+	if ref == nil {
+		return "", nil
+	}
+
+	var secret corev1.Secret
+	err := c.Get(ctx, client.ObjectKey{Namespace: keyPair.Namespace, Name: ref.Name}, &secret)
+	if err != nil {
+		return "", fmt.Errorf("failed to get public key secret '%s': %w", ref.Name, err)
+	}
+
+	publicKey, ok := secret.Data[ref.Key]
+	if !ok || len(publicKey) == 0 {
+		return "", fmt.Errorf("public key secret '%s' has no key '%s'", ref.Name, ref.Key)
+	}
+
+	return string(publicKey), nil
+}
+
+// writePrivateKey writes the private key of a generated key pair to the
+// referenced Secret. Other keys of the Secret are left untouched. Existing
+// Secrets are only written if they are already owned by the key pair.
+func writePrivateKey(
+	ctx context.Context,
+	c client.Client,
+	keyPair *otcv1alpha1.KeyPair,
+	privateKey string,
+) error {
+	ref := keyPair.Spec.PrivateKeySecretRef
+	if privateKey == "" {
+		return fmt.Errorf("provider returned no private key")
+	}
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      ref.Name,
+			Namespace: keyPair.Namespace,
+		},
+	}
+	_, err := controllerutil.CreateOrUpdate(ctx, c, secret, func() error {
+		// Do not take over Secrets created by users or other resources.
+		if secret.GetResourceVersion() != "" && !metav1.IsControlledBy(secret, keyPair) {
+			return fmt.Errorf("already exists and is not owned by %s", keyPair.GetName())
+		}
+		if secret.Data == nil {
+			secret.Data = map[string][]byte{}
+		}
+		secret.Data[ref.Key] = []byte(privateKey)
+		return controllerutil.SetControllerReference(keyPair, secret, c.Scheme())
+	})
+	if err != nil {
+		return fmt.Errorf("failed to write Secret %s: %w", ref.Name, err)
+	}
+
+	return nil
+}
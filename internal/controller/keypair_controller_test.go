@@ -0,0 +1,178 @@
+package controller
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	otcv1alpha1 "github.com/peertech.de/otc-operator/api/v1alpha1"
+)
+
+const testKeyPairName = "deploy"
+
+func newTestKeyPair() *otcv1alpha1.KeyPair {
+	return &otcv1alpha1.KeyPair{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       testKeyPairName,
+			Namespace:  testNamespace,
+			Finalizers: []string{keyPairFinalizerName},
+		},
+		Spec: otcv1alpha1.KeyPairSpec{
+			ProviderConfigRef: testProviderConfigRef,
+			PrivateKeySecretRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "ssh"},
+				Key:                  "id_rsa",
+			},
+		},
+	}
+}
+
+func TestKeyPairLifecycle(t *testing.T) {
+	ctx := context.Background()
+	p := &fakeProvider{}
+	r := newTestManagedReconciler(t, NewKeyPairReconciler, p, newTestKeyPair())
+
+	// The generated key pair is created and its private key is stored.
+	_, keyPair := reconcileTestManaged(t, r, newTestKeyPair())
+	if len(p.keyPairs.created) != 1 || p.keyPairs.created[0].PublicKey != "" {
+		t.Fatalf("Expected key pair to be generated, got %+v", p.keyPairs.created)
+	}
+	if keyPair.Status.ExternalID != keyPairProviderName(keyPair) {
+		t.Fatalf("Expected externalID %q, got %q", keyPairProviderName(keyPair), keyPair.Status.ExternalID)
+	}
+	var secret corev1.Secret
+	key := client.ObjectKey{Name: "ssh", Namespace: testNamespace}
+	if err := r.Get(ctx, key, &secret); err != nil {
+		t.Fatal(err)
+	}
+	if string(secret.Data["id_rsa"]) != "private-key" || !metav1.IsControlledBy(&secret, keyPair) {
+		t.Fatalf("Expected private key in owned Secret, got %+v", secret)
+	}
+
+	// The key pair is ready as soon as it exists.
+	_, keyPair = reconcileTestManaged(t, r, keyPair)
+	expectCondition(t, keyPair, condReady, metav1.ConditionTrue, reasonProvisioned)
+	if keyPair.Status.Fingerprint != "fingerprint" || keyPair.Status.PublicKey != "public-key" {
+		t.Fatalf("Expected observed public key, got %+v", keyPair.Status)
+	}
+
+	// Key pairs cannot be updated, so a changed public key is only reported.
+	p.keyPairs.info.Fingerprint = "other-fingerprint"
+	_, keyPair = reconcileTestManaged(t, r, keyPair)
+	if len(p.keyPairs.created) != 1 || keyPair.Status.Fingerprint != "other-fingerprint" {
+		t.Fatalf("Expected observed fingerprint without recreation, got %+v", keyPair.Status)
+	}
+
+	deleteTestManaged(t, r, keyPair)
+	if !slices.Equal(p.keyPairs.deleted, []string{p.keyPairs.created[0].Name}) {
+		t.Fatalf("Expected external deletion of %q, got %v", p.keyPairs.created[0].Name, p.keyPairs.deleted)
+	}
+}
+
+func TestKeyPairNotFound(t *testing.T) {
+	keyPair := newTestKeyPair()
+	keyPair.Spec.PrivateKeySecretRef = nil
+	keyPair.Status.ExternalID = keyPairProviderName(keyPair)
+	keyPair.Status.LastAppliedSpec = keyPair.Spec.DeepCopy()
+
+	p := &fakeProvider{}
+	r := newTestManagedReconciler(t, NewKeyPairReconciler, p, keyPair)
+
+	// The key pair deleted out-of-band is created again.
+	_, keyPair = reconcileTestManaged(t, r, keyPair)
+	expectRecreate(t, keyPair)
+
+	_, keyPair = reconcileTestManaged(t, r, keyPair)
+	if len(p.keyPairs.created) != 1 || keyPair.Status.ExternalID != p.keyPairs.created[0].Name {
+		t.Fatalf("Expected key pair to be recreated, got %+v", p.keyPairs.created)
+	}
+}
+
+func TestKeyPairProviderNameIncludesNamespace(t *testing.T) {
+	c := newIndexedClientBuilder(t).Build()
+	p := &fakeProvider{}
+	resource := newKeyPairResource(c)
+
+	// Key pairs get distinct IDs, even if the joined namespace and name are
+	// equal.
+	keyPairs := []metav1.ObjectMeta{
+		{Name: testKeyPairName, Namespace: "team-a"},
+		{Name: testKeyPairName, Namespace: "team-b"},
+		{Name: "c", Namespace: "a-b"},
+		{Name: "b-c", Namespace: "a"},
+	}
+	var ids []string
+	for _, objectMeta := range keyPairs {
+		keyPair := &otcv1alpha1.KeyPair{ObjectMeta: objectMeta}
+		id, err := resource.Create(context.Background(), p, keyPair)
+		if err != nil {
+			t.Fatalf("Expected no error, got %s", err)
+		}
+		if !strings.HasPrefix(id, objectMeta.Namespace+"-"+objectMeta.Name+"-") {
+			t.Fatalf("Expected namespace qualified name, got %q", id)
+		}
+		if slices.Contains(ids, id) {
+			t.Fatalf("Expected distinct names, got %q twice", id)
+		}
+		ids = append(ids, id)
+	}
+}
+
+func TestKeyPairProviderNameIsTruncated(t *testing.T) {
+	keyPair := &otcv1alpha1.KeyPair{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      strings.Repeat("k", 63),
+			Namespace: strings.Repeat("n", 63),
+		},
+	}
+
+	name := keyPairProviderName(keyPair)
+	if len(name) > keyPairNameMaxLength {
+		t.Fatalf("Expected name of at most %d characters, got %d", keyPairNameMaxLength, len(name))
+	}
+}
+
+func TestKeyPairDoesNotAdoptForeignSecret(t *testing.T) {
+	ctx := context.Background()
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "ssh", Namespace: testNamespace},
+		Data:       map[string][]byte{"id_rsa": []byte("user-key")},
+	}
+	c := newIndexedClientBuilder(t).WithObjects(secret).Build()
+	p := &fakeProvider{}
+	resource := newKeyPairResource(c)
+
+	keyPair := &otcv1alpha1.KeyPair{
+		ObjectMeta: metav1.ObjectMeta{Name: testKeyPairName, Namespace: testNamespace, UID: "keypair-uid"},
+		Spec: otcv1alpha1.KeyPairSpec{
+			PrivateKeySecretRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: secret.Name},
+				Key:                  "id_rsa",
+			},
+		},
+	}
+
+	// The generated key pair is deleted again, as its private key can not be
+	// stored.
+	if _, err := resource.Create(ctx, p, keyPair); err == nil {
+		t.Fatal("Expected an error for a Secret not owned by the key pair")
+	}
+	if len(p.keyPairs.created) != 1 || !slices.Equal(p.keyPairs.deleted, []string{p.keyPairs.created[0].Name}) {
+		t.Fatalf("Expected the created key pair to be deleted, got %v", p.keyPairs.deleted)
+	}
+
+	// The Secret of the user is left untouched.
+	var got corev1.Secret
+	if err := c.Get(ctx, client.ObjectKeyFromObject(secret), &got); err != nil {
+		t.Fatal(err)
+	}
+	if string(got.Data["id_rsa"]) != "user-key" || len(got.OwnerReferences) != 0 {
+		t.Fatalf("Expected Secret to be untouched, got %+v", got)
+	}
+}
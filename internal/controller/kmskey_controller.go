@@ -0,0 +1,170 @@
+package controller
+
+import (
+	"context"
+	"time"
+
+	"github.com/rs/zerolog"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	otcv1alpha1 "github.com/peertech.de/otc-operator/api/v1alpha1"
+	provider "github.com/peertech.de/otc-operator/internal/provider"
+)
+
+const (
+	kmsKeyFinalizerName = "kmskey.otc.peertech.de/finalizer"
+	kmsKeyRequeueDelay  = 30 * time.Second
+)
+
+// KMSKeyReconciler reconciles a KMSKey object
+type KMSKeyReconciler = ManagedReconciler[
+	*otcv1alpha1.KMSKey,
+	*provider.KMSKeyInfo,
+	provider.UpdateKMSKeyRequest,
+]
+
+func NewKMSKeyReconciler(
+	c client.Client,
+	logger zerolog.Logger,
+	providers *ProviderCache,
+	dependencies *DependencyGraphCache,
+) *KMSKeyReconciler {
+	return NewManagedReconciler(c, logger, providers, dependencies, kmsKeyResource)
+}
+
+// +kubebuilder:rbac:groups=otc.peertech.de,resources=kmskeys,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=otc.peertech.de,resources=kmskeys/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=otc.peertech.de,resources=kmskeys/finalizers,verbs=update
+// +kubebuilder:rbac:groups=otc.peertech.de,resources=volumes,verbs=get;list;watch
+// +kubebuilder:rbac:groups=otc.peertech.de,resources=buckets,verbs=get;list;watch
+// +kubebuilder:rbac:groups=otc.peertech.de,resources=databaseinstances,verbs=get;list;watch
+// +kubebuilder:rbac:groups=otc.peertech.de,resources=providerconfigs,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;create;update;patch
+// +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch;create;update;patch
+
+var kmsKeyResource = ManagedResource[
+	*otcv1alpha1.KMSKey,
+	*provider.KMSKeyInfo,
+	provider.UpdateKMSKeyRequest,
+]{
+	ControllerName: "kmskey",
+	Name:           "KMS key",
+	FinalizerName:  kmsKeyFinalizerName,
+	RequeueDelay:   kmsKeyRequeueDelay,
+
+	New: func() *otcv1alpha1.KMSKey { return &otcv1alpha1.KMSKey{} },
+
+	Create: func(
+		ctx context.Context,
+		p provider.Provider,
+		key *otcv1alpha1.KMSKey,
+	) (string, error) {
+		resp, err := p.CreateKMSKey(ctx, provider.CreateKMSKeyRequest{
+			Alias:                key.Spec.Alias,
+			Description:          key.Spec.Description,
+			RotationEnabled:      key.Spec.RotationEnabled,
+			RotationIntervalDays: key.Spec.RotationIntervalDays,
+		})
+		return resp.ID, err
+	},
+
+	Observe: func(
+		ctx context.Context,
+		p provider.Provider,
+		key *otcv1alpha1.KMSKey,
+	) (*provider.KMSKeyInfo, error) {
+		info, err := p.GetKMSKey(ctx, key.Status.ExternalID)
+		if err != nil {
+			return nil, err
+		}
+
+		// Report the observed key state.
+		key.Status.KeyState = info.KeyState()
+		return info, nil
+	},
+
+	Diff: func(
+		logger zerolog.Logger,
+		key *otcv1alpha1.KMSKey,
+		info *provider.KMSKeyInfo,
+	) (provider.UpdateKMSKeyRequest, bool) {
+		var updateReq provider.UpdateKMSKeyRequest
+		needsUpdate := false
+
+		// A key scheduled for deletion or pending activation can not be
+		// updated.
+		if info.State() != provider.Ready {
+			return updateReq, false
+		}
+
+		if key.Spec.Alias != info.Alias {
+			logger.Info().
+				Str("current", info.Alias).
+				Str("desired", key.Spec.Alias).
+				Msg("Drift detected in alias")
+
+			updateReq.Alias = key.Spec.Alias
+			needsUpdate = true
+		}
+
+		if key.Spec.Description != info.Description {
+			logger.Info().
+				Str("current", info.Description).
+				Str("desired", key.Spec.Description).
+				Msg("Drift detected in description")
+
+			updateReq.Description = &key.Spec.Description
+			needsUpdate = true
+		}
+
+		if key.Spec.RotationEnabled != info.RotationEnabled {
+			logger.Info().
+				Bool("current", info.RotationEnabled).
+				Bool("desired", key.Spec.RotationEnabled).
+				Msg("Drift detected in rotation")
+
+			updateReq.RotationEnabled = &key.Spec.RotationEnabled
+			needsUpdate = true
+		}
+
+		// The interval is only reported while the rotation is enabled.
+		if key.Spec.RotationEnabled &&
+			key.Spec.RotationIntervalDays != info.RotationIntervalDays {
+			logger.Info().
+				Int("current", info.RotationIntervalDays).
+				Int("desired", key.Spec.RotationIntervalDays).
+				Msg("Drift detected in rotation interval")
+
+			updateReq.RotationIntervalDays = key.Spec.RotationIntervalDays
+			needsUpdate = true
+		}
+
+		return updateReq, needsUpdate
+	},
+
+	Update: func(
+		ctx context.Context,
+		p provider.Provider,
+		key *otcv1alpha1.KMSKey,
+		req provider.UpdateKMSKeyRequest,
+	) error {
+		return p.UpdateKMSKey(ctx, key.Status.ExternalID, req)
+	},
+
+	ConnectionDetails: func(
+		_ *otcv1alpha1.KMSKey,
+		info *provider.KMSKeyInfo,
+	) map[string]string {
+		return map[string]string{
+			"externalID": info.ID,
+			"alias":      info.Alias,
+		}
+	},
+
+	// Deleting the key would make the data encrypted with it unreadable.
+	ReferenceChecks: ReferenceChecksFor("KMSKey"),
+
+	Delete: func(ctx context.Context, p provider.Provider, key *otcv1alpha1.KMSKey) error {
+		return p.DeleteKMSKey(ctx, key.Status.ExternalID, key.Spec.PendingDeletionWindowDays)
+	},
+}
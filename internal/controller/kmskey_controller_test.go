@@ -0,0 +1,116 @@
+package controller
+
+import (
+	"slices"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	otcv1alpha1 "github.com/peertech.de/otc-operator/api/v1alpha1"
+)
+
+const (
+	testKMSKeyName = "key"
+	testKMSKeyID   = "key-id"
+)
+
+func newTestKMSKey() *otcv1alpha1.KMSKey {
+	return &otcv1alpha1.KMSKey{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       testKMSKeyName,
+			Namespace:  testNamespace,
+			Finalizers: []string{kmsKeyFinalizerName},
+		},
+		Spec: otcv1alpha1.KMSKeySpec{
+			ProviderConfigRef:         testProviderConfigRef,
+			Alias:                     "app-key",
+			Description:               "Key of the app",
+			PendingDeletionWindowDays: 7,
+		},
+	}
+}
+
+func TestKMSKeyLifecycle(t *testing.T) {
+	p := &fakeProvider{}
+	r := newTestManagedReconciler(t, NewKMSKeyReconciler, p, newTestKMSKey())
+
+	// The key is created with its alias.
+	_, key := reconcileTestManaged(t, r, newTestKMSKey())
+	if len(p.kmsKeys.created) != 1 || p.kmsKeys.created[0].Alias != "app-key" {
+		t.Fatalf("Expected key to be created with alias %q, got %+v", "app-key", p.kmsKeys.created)
+	}
+	if key.Status.ExternalID != testKMSKeyID {
+		t.Fatalf("Expected externalID %q, got %q", testKMSKeyID, key.Status.ExternalID)
+	}
+
+	// The key is not ready while it is pending activation.
+	_, key = reconcileTestManaged(t, r, key)
+	expectCondition(t, key, condReady, metav1.ConditionFalse, reasonProvisioning)
+	if key.Status.KeyState != "PendingActivation" {
+		t.Fatalf("Expected key state %q, got %q", "PendingActivation", key.Status.KeyState)
+	}
+
+	p.kmsKeys.info.Status = "2"
+	_, key = reconcileTestManaged(t, r, key)
+	expectCondition(t, key, condReady, metav1.ConditionTrue, reasonProvisioned)
+	if key.Status.KeyState != "Enabled" {
+		t.Fatalf("Expected key state %q, got %q", "Enabled", key.Status.KeyState)
+	}
+
+	// An enabled rotation is applied with its interval.
+	updateTestManaged(t, r, key, func(key *otcv1alpha1.KMSKey) {
+		key.Spec.RotationEnabled = true
+		key.Spec.RotationIntervalDays = 90
+	})
+	_, key = reconcileTestManaged(t, r, key)
+	if len(p.kmsKeys.updated) != 1 || p.kmsKeys.updated[0].RotationEnabled == nil || !*p.kmsKeys.updated[0].RotationEnabled ||
+		p.kmsKeys.updated[0].RotationIntervalDays != 90 || p.kmsKeys.updated[0].Description != nil {
+		t.Fatalf("Expected rotation to be enabled, got %+v", p.kmsKeys.updated)
+	}
+
+	// A description changed out-of-band is reverted.
+	p.kmsKeys.info.Description = "changed"
+	_, key = reconcileTestManaged(t, r, key)
+	if len(p.kmsKeys.updated) != 2 || p.kmsKeys.updated[1].Description == nil ||
+		*p.kmsKeys.updated[1].Description != "Key of the app" || p.kmsKeys.updated[1].RotationEnabled != nil {
+		t.Fatalf("Expected description to be reverted, got %+v", p.kmsKeys.updated)
+	}
+
+	_, key = reconcileTestManaged(t, r, key)
+	if len(p.kmsKeys.updated) != 2 {
+		t.Fatalf("Expected no further update, got %+v", p.kmsKeys.updated)
+	}
+
+	// A key scheduled for deletion out-of-band is not updated.
+	p.kmsKeys.info.Status = "4"
+	p.kmsKeys.info.Alias = "changed"
+	_, key = reconcileTestManaged(t, r, key)
+	expectCondition(t, key, condReady, metav1.ConditionFalse, reasonFailed)
+	if len(p.kmsKeys.updated) != 2 {
+		t.Fatalf("Expected no update of a key pending deletion, got %+v", p.kmsKeys.updated)
+	}
+
+	// The key is scheduled for deletion with the configured window.
+	deleteTestManaged(t, r, key)
+	if !slices.Equal(p.kmsKeys.deleted, []string{testKMSKeyID}) || p.pendingDays != 7 {
+		t.Fatalf("Expected deletion of %q after 7 days, got %v after %d days", testKMSKeyID, p.kmsKeys.deleted, p.pendingDays)
+	}
+}
+
+func TestKMSKeyNotFound(t *testing.T) {
+	key := newTestKMSKey()
+	key.Status.ExternalID = testKMSKeyID
+	key.Status.LastAppliedSpec = key.Spec.DeepCopy()
+
+	p := &fakeProvider{}
+	r := newTestManagedReconciler(t, NewKMSKeyReconciler, p, key)
+
+	// The key deleted out-of-band is created again.
+	_, key = reconcileTestManaged(t, r, key)
+	expectRecreate(t, key)
+
+	_, key = reconcileTestManaged(t, r, key)
+	if len(p.kmsKeys.created) != 1 || key.Status.ExternalID != testKMSKeyID {
+		t.Fatalf("Expected key to be recreated, got %+v", p.kmsKeys.created)
+	}
+}
@@ -0,0 +1,680 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/rs/zerolog"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	otcv1alpha1 "github.com/peertech.de/otc-operator/api/v1alpha1"
+	provider "github.com/peertech.de/otc-operator/internal/provider"
+)
+
+// dependencyRequeueDelay is the delay before dependencies which are not ready
+// yet are resolved again.
+const dependencyRequeueDelay = 10 * time.Second
+
+// ManagedObject is a kind backed by an external resource. It gives the
+// ManagedReconciler access to the spec and status fields all kinds share.
+type ManagedObject interface {
+	client.Object
+
+	// GetProviderConfigRef returns the ProviderConfig used for authentication.
+	GetProviderConfigRef() otcv1alpha1.ProviderConfigReference
+	// GetNotFoundPolicy returns how an external resource which was deleted
+	// out-of-band is handled.
+	GetNotFoundPolicy() otcv1alpha1.NotFoundPolicy
+	// GetOrphanOnDelete returns whether the external resource is kept when the
+	// object is deleted.
+	GetOrphanOnDelete() bool
+	// GetConnectionDetailsRef returns the Secret or ConfigMap the outputs are
+	// written to.
+	GetConnectionDetailsRef() *otcv1alpha1.ConnectionDetailsReference
+	// GetConditions returns the conditions of the status.
+	GetConditions() *[]metav1.Condition
+
+	// GetExternalID returns the provider's ID of the external resource.
+	GetExternalID() string
+	// SetExternalID sets the provider's ID of the external resource.
+	SetExternalID(id string)
+	// GetLastSyncTime returns the time of the last successful sync.
+	GetLastSyncTime() *metav1.Time
+	// SetLastSyncTime sets the time of the last successful sync.
+	SetLastSyncTime(t *metav1.Time)
+	// HasLastAppliedSpec returns whether a spec was applied before.
+	HasLastAppliedSpec() bool
+	// SetLastAppliedSpec records the current spec as applied.
+	SetLastAppliedSpec()
+	// ResetLastAppliedSpec clears the applied spec.
+	ResetLastAppliedSpec()
+	// SetBlockedBy sets the chain of unready resources the resource waits
+	// for.
+	SetBlockedBy(blockers []otcv1alpha1.BlockingResource)
+}
+
+// ManagedResource describes how a kind is mapped to its external resource.
+// The ManagedReconciler runs the lifecycle shared by all kinds and calls the
+// hooks for the parts which are specific to the kind. O is the kind, I the
+// observed external resource and U the update request of the provider.
+type ManagedResource[O ManagedObject, I provider.Checker, U any] struct {
+	// ControllerName is the name of the controller used for logging and
+	// metrics (e.g. "natgateway").
+	ControllerName string
+	// Name is the human readable name of the kind used in log and condition
+	// messages (e.g. "NAT gateway").
+	Name string
+	// FinalizerName is the finalizer protecting the external resource.
+	FinalizerName string
+	// RequeueDelay is the delay before a resource which is not ready or
+	// failed is reconciled again.
+	RequeueDelay time.Duration
+	// ReadyRequeueDelay is the delay before a ready resource is reconciled
+	// again to keep its observed status current. Ready resources are not
+	// requeued if it is zero.
+	ReadyRequeueDelay time.Duration
+
+	// New returns an empty object of the kind.
+	New func() O
+
+	// Resolve resolves the dependencies before the external resource is
+	// created and stores them in the status. It is optional.
+	Resolve func(ctx context.Context, r *DependencyResolver, obj O) error
+	// Refresh resolves the dependencies which can change during the lifetime
+	// of the external resource. It is called after Observe and is optional.
+	Refresh func(ctx context.Context, r *DependencyResolver, obj O) error
+	// Create creates the external resource and returns its ID.
+	Create func(ctx context.Context, p provider.Provider, obj O) (string, error)
+	// Observe fetches the external resource and reports the observed fields
+	// in the status. It returns provider.ErrNotFound if the external resource
+	// is gone, which is handled according to the NotFoundPolicy.
+	Observe func(ctx context.Context, p provider.Provider, obj O) (I, error)
+	// Reset clears the observed fields of the status when the external
+	// resource is gone or replaced. It is optional.
+	Reset func(obj O)
+	// Replace returns whether the external resource has to be deleted and
+	// created again, because a field which can not be updated changed. It is
+	// called after Refresh and is optional.
+	Replace func(logger zerolog.Logger, obj O, info I) bool
+	// Diff compares the spec against the last applied spec and the observed
+	// resource, and returns the update request if they drifted apart.
+	Diff func(logger zerolog.Logger, obj O, info I) (U, bool)
+	// Update applies the update request to the external resource.
+	Update func(ctx context.Context, p provider.Provider, obj O, req U) error
+	// ConnectionDetails returns the outputs written to the connection details
+	// Secret or ConfigMap.
+	ConnectionDetails func(obj O, info I) map[string]string
+	// Publish writes outputs besides the connection details, like a
+	// kubeconfig. It is called after the connection details are written and
+	// is optional.
+	Publish func(ctx context.Context, rc *Reconciler, p provider.Provider, obj O, info I) error
+	// Release frees the external resource from the resources it is bound to
+	// before it is deleted, like detaching a volume. It observes the external
+	// resource, as the status is not refreshed while the object is deleted,
+	// and returns the resources which still use it and block the deletion.
+	// It is not called if the external resource is orphaned and is optional.
+	Release func(ctx context.Context, p provider.Provider, obj O) ([]string, error)
+	// ReferenceChecks block the deletion while other resources still
+	// reference the external resource.
+	ReferenceChecks []ReferenceCheck
+	// Dependencies returns the referenced resources in the namespace of the
+	// object, which own the object if its deletion policy is Cascade. Only
+	// the name and namespace of the returned objects are set. It is optional.
+	Dependencies func(obj O) []client.Object
+	// Dependents returns empty lists of the kinds which can depend on the
+	// kind. Dependents owned by the object are deleted before it.
+	Dependents []func() client.ObjectList
+	// Delete deletes the external resource.
+	Delete func(ctx context.Context, p provider.Provider, obj O) error
+
+	// Watches maps changes of other kinds to the objects which have to be
+	// reconciled again. It is optional.
+	Watches []ManagedWatch
+}
+
+// ManagedWatch maps changes of another kind to the objects which use it.
+type ManagedWatch struct {
+	// Object is an empty object of the watched kind.
+	Object client.Object
+	// Map returns the objects to reconcile for the changed object.
+	Map func(ctx context.Context, c client.Client, obj client.Object) ([]reconcile.Request, error)
+}
+
+// dependencyError marks errors of hooks which wait for a dependency that is
+// only known while creating or observing the external resource, like a
+// Secret. They are reported like dependencies which can not be resolved.
+type dependencyError struct {
+	err error
+}
+
+func (e *dependencyError) Error() string { return e.err.Error() }
+func (e *dependencyError) Unwrap() error { return e.err }
+
+// dependencyNotReady marks the error as a dependency which is not ready yet.
+func dependencyNotReady(err error) error {
+	return &dependencyError{err: err}
+}
+
+// isDependencyNotReady returns whether the error is a dependency which is not
+// ready yet.
+func isDependencyNotReady(err error) bool {
+	var depErr *dependencyError
+	return errors.As(err, &depErr)
+}
+
+// ManagedReconciler reconciles a kind described by a ManagedResource.
+type ManagedReconciler[O ManagedObject, I provider.Checker, U any] struct {
+	client.Client
+
+	logger       zerolog.Logger
+	recorder     record.EventRecorder
+	providers    *ProviderCache
+	dependencies *DependencyGraphCache
+	resource     ManagedResource[O, I, U]
+}
+
+// NewManagedReconciler returns a reconciler for the kind described by the
+// resource.
+func NewManagedReconciler[O ManagedObject, I provider.Checker, U any](
+	c client.Client,
+	logger zerolog.Logger,
+	providers *ProviderCache,
+	dependencies *DependencyGraphCache,
+	resource ManagedResource[O, I, U],
+) *ManagedReconciler[O, I, U] {
+	return &ManagedReconciler[O, I, U]{
+		Client:       c,
+		logger:       logger.With().Str("controller", resource.ControllerName).Logger(),
+		providers:    providers,
+		dependencies: dependencies,
+		resource:     resource,
+	}
+}
+
+func (r *ManagedReconciler[O, I, U]) Reconcile(
+	ctx context.Context,
+	req ctrl.Request,
+) (ctrl.Result, error) {
+	scopedLogger := r.logger.With().
+		Str(r.resource.ControllerName, req.NamespacedName.Name).
+		Str("namespace", req.NamespacedName.Namespace).
+		Logger()
+
+	obj := r.resource.New()
+	if err := r.Get(ctx, req.NamespacedName, obj); err != nil {
+		if apierrors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		scopedLogger.Error().Err(err).Msg("Failed to get resource")
+		return ctrl.Result{}, err
+	}
+
+	rc := &Reconciler{
+		logger:         scopedLogger,
+		client:         r.Client,
+		recorder:       r.recorder,
+		providers:      r.providers,
+		dependencies:   r.dependencies,
+		object:         obj,
+		originalObject: obj.DeepCopyObject().(client.Object),
+		conditions:     obj.GetConditions(),
+		generation:     obj.GetGeneration(),
+		finalizerName:  r.resource.FinalizerName,
+		requeueAfter:   r.resource.RequeueDelay,
+	}
+
+	// Ensure the status is updated.
+	defer rc.UpdateStatus(ctx)
+
+	// Skip paused resources.
+	if paused, result := rc.CheckPaused(ctx, obj.GetProviderConfigRef()); paused {
+		return result, nil
+	}
+
+	// Handle deletion.
+	if !obj.GetDeletionTimestamp().IsZero() {
+		return r.reconcileDelete(ctx, rc, obj)
+	}
+
+	// Ensure the finalizer is present.
+	if added, result, err := rc.AddFinalizer(ctx); added {
+		return result, err
+	}
+
+	// Ensure the owner references match the deletion policy.
+	if updated, result, err := r.reconcileOwnerReferences(ctx, rc, obj); updated {
+		return result, err
+	}
+
+	// Check if the referenced ProviderConfig is ready.
+	_, shouldRequeue, result, err := rc.CheckProviderConfig(ctx, obj.GetProviderConfigRef())
+	if shouldRequeue {
+		return result, err
+	}
+
+	// Get or create cached provider client.
+	p, _, err := r.providers.GetOrCreate(ctx, obj.GetProviderConfigRef(), obj.GetNamespace())
+	if err != nil {
+		rc.SetReconciliationFailed(
+			WithReason(reasonProviderConfigError),
+			WithMessage(err.Error()),
+		)
+		scopedLogger.Error().Err(err).Msg("Failed to get or create provider client")
+		return ctrl.Result{RequeueAfter: r.resource.RequeueDelay}, nil
+	}
+
+	// If the external resource has no known ID, it needs to be created.
+	if obj.GetExternalID() == "" {
+		return r.reconcileCreate(ctx, scopedLogger, rc, obj, p)
+	}
+
+	return r.reconcileUpdate(ctx, scopedLogger, rc, obj, p)
+}
+
+// reconcileCreate resolves the dependencies and creates the external
+// resource.
+func (r *ManagedReconciler[O, I, U]) reconcileCreate(
+	ctx context.Context,
+	logger zerolog.Logger,
+	rc *Reconciler,
+	obj O,
+	p provider.Provider,
+) (ctrl.Result, error) {
+	// Resolve dependencies.
+	if r.resource.Resolve != nil {
+		resolver := NewDependencyResolver(r.Client, obj.GetNamespace())
+		if err := r.resource.Resolve(ctx, resolver, obj); err != nil {
+			return r.waitForDependencies(rc, err)
+		}
+		rc.SetDependenciesReady()
+	}
+
+	// Create the external resource.
+	logger.Info().Msgf("Creating %s", r.resource.Name)
+
+	// Set creating status.
+	rc.SetCreating()
+
+	id, err := r.resource.Create(ctx, p, obj)
+	if isDependencyNotReady(err) {
+		return r.waitForDependencies(rc, err)
+	}
+	if err != nil {
+		rc.SetReconciliationFailed(
+			WithReason(reasonProvisioningFailed),
+			WithMessagef("Failed to create resource: %v", err),
+		)
+		logger.Error().Err(err).Msgf("Failed to create %s", r.resource.Name)
+		return ctrl.Result{RequeueAfter: r.resource.RequeueDelay}, nil
+	}
+
+	// Update status fields. A recreated resource is found again, so that it
+	// is deleted with the object.
+	obj.SetExternalID(id)
+	obj.SetLastAppliedSpec()
+	rc.SetExternalResourceFound()
+
+	logger.Info().
+		Str("external-id", id).
+		Msgf("Successfully created %s", r.resource.Name)
+
+	return ctrl.Result{}, nil
+}
+
+// reconcileUpdate handles the logic for an existing external resource. It
+// checks for drift, updates the resource and reports its status.
+func (r *ManagedReconciler[O, I, U]) reconcileUpdate(
+	ctx context.Context,
+	logger zerolog.Logger,
+	rc *Reconciler,
+	obj O,
+	p provider.Provider,
+) (ctrl.Result, error) {
+	if !obj.HasLastAppliedSpec() {
+		logger.Warn().Msg("LastAppliedSpec is not set, establishing baseline from current spec.")
+		obj.SetLastAppliedSpec()
+		// Requeue to ensure the status update is persisted before proceeding.
+		return ctrl.Result{Requeue: true}, nil
+	}
+
+	// Fetch the external resource.
+	info, err := r.resource.Observe(ctx, p, obj)
+	if errors.Is(err, provider.ErrNotFound) {
+		if r.resource.Reset != nil {
+			r.resource.Reset(obj)
+		}
+		return rc.HandleNotFound(obj)
+	}
+	if isDependencyNotReady(err) {
+		return r.waitForDependencies(rc, err)
+	}
+	if err != nil {
+		rc.SetReconciliationFailed(
+			WithReason(reasonProviderError),
+			WithMessagef("Failed to check existing %s: %v", r.resource.Name, err),
+		)
+		logger.Error().Err(err).Msgf("Failed to check existing %s", r.resource.Name)
+		return ctrl.Result{RequeueAfter: r.resource.RequeueDelay}, nil
+	}
+
+	rc.SetExternalResourceFound()
+
+	logger.Debug().
+		Str("external-id", obj.GetExternalID()).
+		Str("message", info.Message()).
+		Msgf("Found existing %s", r.resource.Name)
+
+	// Resolve the dependencies which can change.
+	if r.resource.Refresh != nil {
+		resolver := NewDependencyResolver(r.Client, obj.GetNamespace())
+		if err := r.resource.Refresh(ctx, resolver, obj); err != nil {
+			return r.waitForDependencies(rc, err)
+		}
+		rc.SetDependenciesReady()
+	}
+
+	if r.resource.Replace != nil && r.resource.Replace(logger, obj, info) {
+		return r.handleReplace(ctx, logger, rc, obj, p)
+	}
+
+	if r.resource.Diff != nil {
+		if req, needsUpdate := r.resource.Diff(logger, obj, info); needsUpdate {
+			return r.handleDrift(ctx, logger, rc, obj, p, req)
+		}
+	}
+
+	// Publish connection details.
+	if r.resource.ConnectionDetails != nil {
+		err = rc.WriteConnectionDetails(
+			ctx,
+			obj.GetConnectionDetailsRef(),
+			r.resource.ConnectionDetails(obj, info),
+		)
+		if err != nil {
+			rc.SetReconciliationFailed(
+				WithReason(reasonConnectionDetailsFailed),
+				WithMessagef("Failed to write connection details: %v", err),
+			)
+			logger.Error().Err(err).Msg("Failed to write connection details")
+			return ctrl.Result{RequeueAfter: r.resource.RequeueDelay}, nil
+		}
+	}
+
+	// Publish further outputs.
+	if r.resource.Publish != nil {
+		if err := r.resource.Publish(ctx, rc, p, obj, info); err != nil {
+			rc.SetReconciliationFailed(
+				WithReason(reasonConnectionDetailsFailed),
+				WithMessagef("Failed to publish outputs: %v", err),
+			)
+			logger.Error().Err(err).Msg("Failed to publish outputs")
+			return ctrl.Result{RequeueAfter: r.resource.RequeueDelay}, nil
+		}
+	}
+
+	// Check readiness status.
+	return r.checkReadiness(rc, obj, info)
+}
+
+// handleReplace deletes the external resource, so that it is created again
+// with the current spec.
+func (r *ManagedReconciler[O, I, U]) handleReplace(
+	ctx context.Context,
+	logger zerolog.Logger,
+	rc *Reconciler,
+	obj O,
+	p provider.Provider,
+) (ctrl.Result, error) {
+	logger.Info().Msgf("Replacing %s", r.resource.Name)
+
+	// Set updating status.
+	rc.SetUpdating()
+
+	if err := r.resource.Delete(ctx, p, obj); err != nil && !errors.Is(err, provider.ErrNotFound) {
+		rc.SetReconciliationFailed(
+			WithReason(reasonUpdateFailed),
+			WithMessagef("Failed to replace resource: %v", err),
+		)
+		logger.Error().Err(err).Msgf("Failed to delete replaced %s", r.resource.Name)
+		return ctrl.Result{RequeueAfter: r.resource.RequeueDelay}, nil
+	}
+
+	// Forget the deleted resource, so that it is created again.
+	obj.SetExternalID("")
+	obj.ResetLastAppliedSpec()
+	if r.resource.Reset != nil {
+		r.resource.Reset(obj)
+	}
+
+	// Requeue immediately to create the replacement.
+	return ctrl.Result{Requeue: true}, nil
+}
+
+// handleDrift applies updates to the drifted resource.
+func (r *ManagedReconciler[O, I, U]) handleDrift(
+	ctx context.Context,
+	logger zerolog.Logger,
+	rc *Reconciler,
+	obj O,
+	p provider.Provider,
+	req U,
+) (ctrl.Result, error) {
+	logger.Info().Msg("Applying updates to external resource")
+
+	// Set updating status.
+	rc.SetUpdating()
+
+	if err := r.resource.Update(ctx, p, obj, req); err != nil {
+		rc.SetReconciliationFailed(
+			WithReason(reasonUpdateFailed),
+			WithMessagef("Failed to update resource: %v", err),
+		)
+		logger.Error().Err(err).Msg("Failed to update resource")
+		return ctrl.Result{RequeueAfter: r.resource.RequeueDelay}, nil
+	}
+
+	// Update LastAppliedSpec.
+	obj.SetLastAppliedSpec()
+
+	logger.Info().Msg("Successfully updated")
+
+	// Requeue immediately to re-check the status after the update.
+	return ctrl.Result{Requeue: true}, nil
+}
+
+// checkReadiness updates the status conditions based on the provider's
+// reported status.
+func (r *ManagedReconciler[O, I, U]) checkReadiness(
+	rc *Reconciler,
+	obj O,
+	info I,
+) (ctrl.Result, error) {
+	switch info.State() {
+	case provider.Ready:
+		now := metav1.Now()
+
+		isNewlyProvisioned := obj.GetLastSyncTime() == nil
+		obj.SetLastSyncTime(&now)
+
+		if isNewlyProvisioned {
+			rc.SetProvisioned()
+		} else {
+			rc.SetSyncedAndReady()
+		}
+		return ctrl.Result{RequeueAfter: r.resource.ReadyRequeueDelay}, nil
+	case provider.Stopped:
+		rc.SetStopped(WithMessage(info.Message()))
+		return ctrl.Result{RequeueAfter: r.resource.RequeueDelay}, nil
+	case provider.Failed:
+		rc.SetReconciliationFailed(
+			WithReason(reasonFailed),
+			WithMessage(info.Message()),
+		)
+		return ctrl.Result{RequeueAfter: r.resource.RequeueDelay}, nil
+	case provider.Provisioning:
+		rc.SetProvisioning(WithMessage(info.Message()))
+		return ctrl.Result{RequeueAfter: r.resource.RequeueDelay}, nil
+	default:
+		rc.SetReconciliationFailed(
+			WithReason(reasonUnknown),
+			WithMessage(info.Message()),
+		)
+		return ctrl.Result{RequeueAfter: r.resource.RequeueDelay}, nil
+	}
+}
+
+func (r *ManagedReconciler[O, I, U]) reconcileDelete(
+	ctx context.Context,
+	rc *Reconciler,
+	obj O,
+) (ctrl.Result, error) {
+	// If the resource never got an external ID, nothing can reference it and
+	// there is nothing to delete.
+	if obj.GetExternalID() == "" {
+		return rc.Delete(
+			ctx,
+			obj.GetProviderConfigRef(),
+			obj.GetOrphanOnDelete(),
+			obj.GetExternalID(),
+			func(c context.Context, p provider.Provider) error {
+				return nil
+			},
+		)
+	}
+
+	// Delete the dependents which cascade from this resource first.
+	pending, err := r.deleteOwnedDependents(ctx, rc, obj)
+	if err != nil {
+		rc.SetReconciliationFailed(
+			WithReason(reasonDeletionFailed),
+			WithMessagef("Failed to delete dependents: %v", err),
+		)
+		return ctrl.Result{RequeueAfter: r.resource.RequeueDelay}, err
+	}
+	if len(pending) > 0 {
+		rc.SetDeletionBlocked(
+			WithMessagef("Waiting for dependents %v to be deleted", pending),
+		)
+		return ctrl.Result{RequeueAfter: r.resource.RequeueDelay}, nil
+	}
+
+	// Release the external resource from the resources it is bound to.
+	if r.resource.Release != nil && !obj.GetOrphanOnDelete() {
+		if released, result, err := r.release(ctx, rc, obj); !released {
+			return result, err
+		}
+	}
+
+	// Check if any other resources are still referencing this one.
+	blocked, result, err := rc.BlockOnAnyReference(
+		ctx,
+		obj.GetNamespace(),
+		obj.GetExternalID(),
+		r.resource.ReferenceChecks...,
+	)
+	if blocked {
+		return result, err
+	}
+
+	return rc.Delete(
+		ctx,
+		obj.GetProviderConfigRef(),
+		obj.GetOrphanOnDelete(),
+		obj.GetExternalID(),
+		func(c context.Context, p provider.Provider) error {
+			return r.resource.Delete(c, p, obj)
+		},
+	)
+}
+
+// release calls the Release hook and reports whether the deletion can
+// proceed.
+func (r *ManagedReconciler[O, I, U]) release(
+	ctx context.Context,
+	rc *Reconciler,
+	obj O,
+) (bool, ctrl.Result, error) {
+	// An external resource which was deleted out-of-band is not bound to
+	// anything.
+	if meta.IsStatusConditionFalse(*rc.conditions, condExternalResourceFound) {
+		return true, ctrl.Result{}, nil
+	}
+
+	p, _, err := r.providers.GetOrCreate(ctx, obj.GetProviderConfigRef(), obj.GetNamespace())
+	if err != nil {
+		// Without a ProviderConfig the external resource is orphaned by
+		// the deletion.
+		if apierrors.IsNotFound(err) {
+			return true, ctrl.Result{}, nil
+		}
+		rc.SetReconciliationFailed(
+			WithReason(reasonDeletionFailed),
+			WithMessagef("Cannot access provider: %v", err),
+		)
+		return false, ctrl.Result{RequeueAfter: r.resource.RequeueDelay}, nil
+	}
+
+	blockers, err := r.resource.Release(ctx, p, obj)
+	if err != nil {
+		rc.SetReconciliationFailed(
+			WithReason(reasonDeletionFailed),
+			WithMessagef("Failed to release %s: %v", r.resource.Name, err),
+		)
+		return false, ctrl.Result{RequeueAfter: r.resource.RequeueDelay}, nil
+	}
+	if len(blockers) > 0 {
+		rc.SetDeletionBlocked(
+			WithMessagef("Still in use by %v", blockers),
+		)
+		return false, ctrl.Result{RequeueAfter: r.resource.RequeueDelay}, nil
+	}
+
+	return true, ctrl.Result{}, nil
+}
+
+// waitForDependencies reports dependencies which can not be resolved yet.
+func (r *ManagedReconciler[O, I, U]) waitForDependencies(
+	rc *Reconciler,
+	err error,
+) (ctrl.Result, error) {
+	rc.SetDependenciesNotReady(err.Error())
+	rc.SetNotReady(
+		WithReason(reasonDependenciesNotResolved),
+		WithMessagef("Waiting for dependencies: %v", err),
+	)
+	return ctrl.Result{RequeueAfter: dependencyRequeueDelay}, nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *ManagedReconciler[O, I, U]) SetupWithManager(mgr ctrl.Manager) error {
+	r.recorder = mgr.GetEventRecorderFor(r.resource.ControllerName)
+
+	b := ctrl.NewControllerManagedBy(mgr).
+		For(r.resource.New()).
+		Owns(&corev1.Secret{}).
+		Owns(&corev1.ConfigMap{})
+
+	for _, w := range r.resource.Watches {
+		b = b.Watches(
+			w.Object,
+			handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, obj client.Object) []reconcile.Request {
+				requests, err := w.Map(ctx, r.Client, obj)
+				if err != nil {
+					r.logger.Error().Err(err).Msg("Failed to map watched object")
+				}
+				return requests
+			}),
+		)
+	}
+
+	return b.Named(r.resource.ControllerName).Complete(r)
+}
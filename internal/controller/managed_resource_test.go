@@ -0,0 +1,166 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/rs/zerolog"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	otcv1alpha1 "github.com/peertech.de/otc-operator/api/v1alpha1"
+	provider "github.com/peertech.de/otc-operator/internal/provider"
+)
+
+// testProviderConfigRef references the ProviderConfig created by
+// newTestManagedReconciler.
+var testProviderConfigRef = otcv1alpha1.ProviderConfigReference{Name: "provider"}
+
+// newTestManagedReconciler returns the reconciler created by newReconciler
+// for obj, backed by a fake client with the objects, a ready ProviderConfig
+// and the given provider.
+func newTestManagedReconciler[O ManagedObject, I provider.Checker, U any](
+	t *testing.T,
+	newReconciler func(
+		client.Client,
+		zerolog.Logger,
+		*ProviderCache,
+		*DependencyGraphCache,
+	) *ManagedReconciler[O, I, U],
+	p provider.Provider,
+	obj O,
+	objs ...client.Object,
+) *ManagedReconciler[O, I, U] {
+	t.Helper()
+
+	providerConfig := &otcv1alpha1.ProviderConfig{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      testProviderConfigRef.Name,
+			Namespace: testNamespace,
+		},
+		Status: otcv1alpha1.ProviderConfigStatus{
+			Conditions: []metav1.Condition{{
+				Type:               condReady,
+				Status:             metav1.ConditionTrue,
+				Reason:             "Ready",
+				LastTransitionTime: metav1.Now(),
+			}},
+		},
+	}
+
+	c := newIndexedClientBuilder(t).
+		WithObjects(append(objs, providerConfig, obj)...).
+		WithStatusSubresource(obj).
+		Build()
+
+	// Pre-populate the cache, so no provider client is created.
+	providers := NewProviderCache(c, zerolog.Nop())
+	providers.cache[testNamespace+"/"+providerConfig.Name] = &providerEntry{
+		provider:         p,
+		configGeneration: providerConfig.Generation,
+	}
+
+	r := newReconciler(c, zerolog.Nop(), providers, nil)
+	r.recorder = record.NewFakeRecorder(10)
+	return r
+}
+
+// reconcileTestManaged reconciles obj and returns it as stored afterwards.
+func reconcileTestManaged[O ManagedObject, I provider.Checker, U any](
+	t *testing.T,
+	r *ManagedReconciler[O, I, U],
+	obj O,
+) (ctrl.Result, O) {
+	t.Helper()
+
+	key := client.ObjectKeyFromObject(obj)
+	result, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: key})
+	if err != nil {
+		t.Fatalf("Expected no error, got %s", err)
+	}
+
+	stored := r.resource.New()
+	if err := r.Get(context.Background(), key, stored); err != nil {
+		t.Fatal(err)
+	}
+	return result, stored
+}
+
+// updateTestManaged updates the spec of the stored obj with update.
+func updateTestManaged[O ManagedObject, I provider.Checker, U any](
+	t *testing.T,
+	r *ManagedReconciler[O, I, U],
+	obj O,
+	update func(O),
+) {
+	t.Helper()
+
+	update(obj)
+	if err := r.Update(context.Background(), obj); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// deleteTestManaged deletes obj and reconciles the deletion. It fails unless
+// the finalizer was removed.
+func deleteTestManaged[O ManagedObject, I provider.Checker, U any](
+	t *testing.T,
+	r *ManagedReconciler[O, I, U],
+	obj O,
+) {
+	t.Helper()
+
+	if err := r.Delete(context.Background(), obj); err != nil {
+		t.Fatal(err)
+	}
+
+	key := client.ObjectKeyFromObject(obj)
+	if _, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: key}); err != nil {
+		t.Fatalf("Expected no error, got %s", err)
+	}
+	err := r.Get(context.Background(), key, r.resource.New())
+	if !apierrors.IsNotFound(err) {
+		t.Fatalf("Expected finalizer to be removed, got %v", err)
+	}
+}
+
+// expectCondition fails unless the condition of obj has the status and the
+// reason.
+func expectCondition(
+	t *testing.T,
+	obj ManagedObject,
+	condType string,
+	status metav1.ConditionStatus,
+	reason string,
+) {
+	t.Helper()
+
+	cond := meta.FindStatusCondition(*obj.GetConditions(), condType)
+	if cond == nil {
+		t.Fatalf("Expected %s condition, got none", condType)
+	}
+	if cond.Status != status || cond.Reason != reason {
+		t.Fatalf(
+			"Expected %s condition %s/%s, got %s/%s: %s",
+			condType, status, reason, cond.Status, cond.Reason, cond.Message,
+		)
+	}
+}
+
+// expectRecreate fails unless the external resource of obj is forgotten, so
+// that it is created again.
+func expectRecreate(t *testing.T, obj ManagedObject) {
+	t.Helper()
+
+	if obj.GetExternalID() != "" {
+		t.Fatalf("Expected externalID to be reset, got %q", obj.GetExternalID())
+	}
+	if obj.HasLastAppliedSpec() {
+		t.Fatal("Expected lastAppliedSpec to be reset")
+	}
+	expectCondition(t, obj, condExternalResourceFound, metav1.ConditionFalse, reasonRecreating)
+}
@@ -0,0 +1,379 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	otcv1alpha1 "github.com/peertech.de/otc-operator/api/v1alpha1"
+)
+
+const networkStackRequeueDelay = 30 * time.Second
+
+// errNotControlledByStack is returned for an existing resource with the name
+// of a resource of the stack, which is not controlled by the stack.
+var errNotControlledByStack = errors.New("already exists and is not owned by NetworkStack")
+
+func NewNetworkStackReconciler(
+	c client.Client,
+	logger zerolog.Logger,
+) *NetworkStackReconciler {
+	return &NetworkStackReconciler{
+		Client: c,
+		logger: logger.With().Str("controller", "networkstack").Logger(),
+	}
+}
+
+// NetworkStackReconciler reconciles a NetworkStack object. It creates the
+// Network, Subnets, PublicIP, NATGateway and SNATRules of the stack, which in
+// turn manage the external resources.
+type NetworkStackReconciler struct {
+	client.Client
+
+	logger   zerolog.Logger
+	recorder record.EventRecorder
+}
+
+// +kubebuilder:rbac:groups=otc.peertech.de,resources=networkstacks,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=otc.peertech.de,resources=networkstacks/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=otc.peertech.de,resources=networkstacks/finalizers,verbs=update
+// +kubebuilder:rbac:groups=otc.peertech.de,resources=networks,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=otc.peertech.de,resources=subnets,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=otc.peertech.de,resources=publicips,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=otc.peertech.de,resources=natgateways,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=otc.peertech.de,resources=snatrules,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=otc.peertech.de,resources=providerconfigs,verbs=get;list;watch
+
+func (r *NetworkStackReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	scopedLogger := r.logger.With().
+		Str("networkstack", req.NamespacedName.Name).
+		Str("namespace", req.NamespacedName.Namespace).
+		Logger()
+
+	var networkStack otcv1alpha1.NetworkStack
+	if err := r.Get(ctx, req.NamespacedName, &networkStack); err != nil {
+		if apierrors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		scopedLogger.Error().Err(err).Msg("Failed to get resource")
+		return ctrl.Result{}, err
+	}
+
+	rc := &Reconciler{
+		logger:         scopedLogger,
+		client:         r.Client,
+		recorder:       r.recorder,
+		object:         &networkStack,
+		originalObject: networkStack.DeepCopy(),
+		conditions:     &networkStack.Status.Conditions,
+		generation:     networkStack.Generation,
+		requeueAfter:   networkStackRequeueDelay,
+	}
+
+	// Ensure the status is updated.
+	defer rc.UpdateStatus(ctx)
+
+	// Skip paused resources.
+	if paused, result := rc.CheckPaused(ctx, networkStack.Spec.ProviderConfigRef); paused {
+		return result, nil
+	}
+
+	// The resources are owned by the stack and removed by the garbage
+	// collector, so there is nothing to do on deletion.
+	if !networkStack.GetDeletionTimestamp().IsZero() {
+		return ctrl.Result{}, nil
+	}
+
+	return r.reconcile(ctx, scopedLogger, rc, &networkStack)
+}
+
+func (r *NetworkStackReconciler) reconcile(
+	ctx context.Context,
+	logger zerolog.Logger,
+	rc *Reconciler,
+	networkStack *otcv1alpha1.NetworkStack,
+) (ctrl.Result, error) {
+	spec := networkStack.Spec
+	var resources []otcv1alpha1.NetworkStackResource
+
+	// apply creates or updates a resource of the stack and records it in the
+	// status.
+	apply := func(kind string, obj ManagedObject, mutate func()) error {
+		op, err := controllerutil.CreateOrUpdate(ctx, r.Client, obj, func() error {
+			// Do not take over resources created by users or other
+			// resources, as they would be overwritten and deleted with the
+			// stack.
+			if obj.GetResourceVersion() != "" && !metav1.IsControlledBy(obj, networkStack) {
+				return fmt.Errorf("%w %s", errNotControlledByStack, networkStack.Name)
+			}
+
+			labels := obj.GetLabels()
+			if labels == nil {
+				labels = make(map[string]string)
+			}
+			labels[otcv1alpha1.NetworkStackLabel] = networkStack.Name
+			obj.SetLabels(labels)
+
+			mutate()
+			return controllerutil.SetControllerReference(networkStack, obj, r.Scheme())
+		})
+		if err != nil {
+			return fmt.Errorf("failed to apply %s %s: %w", kind, obj.GetName(), err)
+		}
+		if op != controllerutil.OperationResultNone {
+			logger.Info().
+				Str("kind", kind).
+				Str("name", obj.GetName()).
+				Str("operation", string(op)).
+				Msg("Applied resource")
+		}
+
+		resources = append(resources, otcv1alpha1.NetworkStackResource{
+			Kind:  kind,
+			Name:  obj.GetName(),
+			Ready: meta.IsStatusConditionTrue(*obj.GetConditions(), condReady),
+		})
+		return nil
+	}
+
+	network := &otcv1alpha1.Network{ObjectMeta: networkStackMeta(networkStack, networkStack.Name)}
+	err := apply("Network", network, func() {
+		network.Spec.ProviderConfigRef = spec.ProviderConfigRef
+		network.Spec.Description = spec.Description
+		network.Spec.Cidr = spec.Cidr
+		network.Spec.OrphanOnDelete = spec.OrphanOnDelete
+		network.Spec.NotFoundPolicy = spec.NotFoundPolicy
+	})
+	if err != nil {
+		return r.applyFailed(logger, rc, err)
+	}
+	networkStack.Status.NetworkID = network.Status.ExternalID
+
+	subnetNames := make([]string, 0, len(spec.Subnets))
+	snatRuleNames := make([]string, 0, len(spec.Subnets))
+	for _, s := range spec.Subnets {
+		name := networkStackSubnetName(networkStack, s.Name)
+		subnet := &otcv1alpha1.Subnet{ObjectMeta: networkStackMeta(networkStack, name)}
+		err := apply("Subnet", subnet, func() {
+			subnet.Spec.ProviderConfigRef = spec.ProviderConfigRef
+			subnet.Spec.Network = otcv1alpha1.NetworkDependency{
+				NetworkRef: &otcv1alpha1.NamespacedObjectReference{Name: network.Name},
+			}
+			subnet.Spec.Description = s.Description
+			subnet.Spec.Cidr = s.Cidr
+			subnet.Spec.GatewayIP = s.GatewayIP
+			subnet.Spec.OrphanOnDelete = spec.OrphanOnDelete
+			subnet.Spec.NotFoundPolicy = spec.NotFoundPolicy
+		})
+		if err != nil {
+			return r.applyFailed(logger, rc, err)
+		}
+		subnetNames = append(subnetNames, subnet.Name)
+	}
+
+	var natGatewayNames, publicIPNames []string
+	if nat := spec.NATGateway; nat != nil {
+		publicIP := &otcv1alpha1.PublicIP{ObjectMeta: networkStackMeta(networkStack, networkStack.Name)}
+		err := apply("PublicIP", publicIP, func() {
+			publicIP.Spec.ProviderConfigRef = spec.ProviderConfigRef
+			publicIP.Spec.Type = nat.PublicIPType
+			publicIP.Spec.BandwidthSize = nat.BandwidthSize
+			publicIP.Spec.BandwidthShareType = otcv1alpha1.PublicIPBandwidthDedicated
+			publicIP.Spec.OrphanOnDelete = spec.OrphanOnDelete
+			publicIP.Spec.NotFoundPolicy = spec.NotFoundPolicy
+		})
+		if err != nil {
+			return r.applyFailed(logger, rc, err)
+		}
+		publicIPNames = append(publicIPNames, publicIP.Name)
+
+		natGateway := &otcv1alpha1.NATGateway{ObjectMeta: networkStackMeta(networkStack, networkStack.Name)}
+		err = apply("NATGateway", natGateway, func() {
+			natGateway.Spec.ProviderConfigRef = spec.ProviderConfigRef
+			natGateway.Spec.Network = otcv1alpha1.NetworkDependency{
+				NetworkRef: &otcv1alpha1.NamespacedObjectReference{Name: network.Name},
+			}
+			natGateway.Spec.Subnet = otcv1alpha1.SubnetDependency{
+				SubnetRef: &otcv1alpha1.NamespacedObjectReference{
+					Name: networkStackSubnetName(networkStack, nat.Subnet),
+				},
+			}
+			natGateway.Spec.Description = spec.Description
+			natGateway.Spec.Type = nat.Type
+			natGateway.Spec.OrphanOnDelete = spec.OrphanOnDelete
+			natGateway.Spec.NotFoundPolicy = spec.NotFoundPolicy
+		})
+		if err != nil {
+			return r.applyFailed(logger, rc, err)
+		}
+		natGatewayNames = append(natGatewayNames, natGateway.Name)
+
+		for _, s := range spec.Subnets {
+			if !s.SNAT {
+				continue
+			}
+			name := networkStackSubnetName(networkStack, s.Name)
+			snatRule := &otcv1alpha1.SNATRule{ObjectMeta: networkStackMeta(networkStack, name)}
+			err := apply("SNATRule", snatRule, func() {
+				snatRule.Spec.ProviderConfigRef = spec.ProviderConfigRef
+				snatRule.Spec.NATGateway = otcv1alpha1.NATGatewayDependency{
+					NATGatewayRef: &corev1.LocalObjectReference{Name: natGateway.Name},
+				}
+				snatRule.Spec.Subnet = otcv1alpha1.SubnetDependency{
+					SubnetRef: &otcv1alpha1.NamespacedObjectReference{Name: name},
+				}
+				snatRule.Spec.PublicIP = otcv1alpha1.PublicIPDependency{
+					PublicIPRef: &corev1.LocalObjectReference{Name: publicIP.Name},
+				}
+				snatRule.Spec.Description = spec.Description
+				snatRule.Spec.OrphanOnDelete = spec.OrphanOnDelete
+				snatRule.Spec.NotFoundPolicy = spec.NotFoundPolicy
+			})
+			if err != nil {
+				return r.applyFailed(logger, rc, err)
+			}
+			snatRuleNames = append(snatRuleNames, snatRule.Name)
+		}
+	}
+	networkStack.Status.Resources = resources
+
+	// Delete the resources which were removed from the spec.
+	prune := []struct {
+		list client.ObjectList
+		keep []string
+	}{
+		{&otcv1alpha1.SNATRuleList{}, snatRuleNames},
+		{&otcv1alpha1.NATGatewayList{}, natGatewayNames},
+		{&otcv1alpha1.PublicIPList{}, publicIPNames},
+		{&otcv1alpha1.SubnetList{}, subnetNames},
+	}
+	for _, p := range prune {
+		if err := r.prune(ctx, logger, networkStack, p.list, p.keep); err != nil {
+			rc.SetReconciliationFailed(
+				WithReason(reasonDeletionFailed),
+				WithMessagef("Failed to delete removed resources: %v", err),
+			)
+			logger.Error().Err(err).Msg("Failed to delete removed resources")
+			return ctrl.Result{RequeueAfter: networkStackRequeueDelay}, nil
+		}
+	}
+
+	rc.SetSynced()
+
+	var notReady []string
+	for _, resource := range resources {
+		if !resource.Ready {
+			notReady = append(notReady, resource.Kind+" "+resource.Name)
+		}
+	}
+	if len(notReady) > 0 {
+		// The resources are watched, so their readiness triggers a new
+		// reconciliation.
+		rc.SetNotReady(
+			WithReason(reasonProvisioning),
+			WithMessagef("Waiting for %s", strings.Join(notReady, ", ")),
+		)
+		return ctrl.Result{}, nil
+	}
+
+	rc.SetReady(WithMessagef("%d resources are ready", len(resources)))
+	return ctrl.Result{}, nil
+}
+
+// applyFailed reports a resource of the stack which could not be applied.
+func (r *NetworkStackReconciler) applyFailed(
+	logger zerolog.Logger,
+	rc *Reconciler,
+	err error,
+) (ctrl.Result, error) {
+	reason := reasonProvisioningFailed
+	if errors.Is(err, errNotControlledByStack) {
+		reason = reasonResourceConflict
+	}
+
+	rc.SetReconciliationFailed(
+		WithReason(reason),
+		WithMessage(err.Error()),
+	)
+	logger.Error().Err(err).Msg("Failed to apply resource")
+	return ctrl.Result{RequeueAfter: networkStackRequeueDelay}, nil
+}
+
+// prune deletes the resources of the list owned by the stack whose name is
+// not kept.
+func (r *NetworkStackReconciler) prune(
+	ctx context.Context,
+	logger zerolog.Logger,
+	networkStack *otcv1alpha1.NetworkStack,
+	list client.ObjectList,
+	keep []string,
+) error {
+	err := r.List(
+		ctx,
+		list,
+		client.InNamespace(networkStack.Namespace),
+		client.MatchingLabels{otcv1alpha1.NetworkStackLabel: networkStack.Name},
+	)
+	if err != nil {
+		return err
+	}
+
+	items, err := meta.ExtractList(list)
+	if err != nil {
+		return err
+	}
+	for _, item := range items {
+		obj, ok := item.(client.Object)
+		if !ok || !metav1.IsControlledBy(obj, networkStack) || slices.Contains(keep, obj.GetName()) {
+			continue
+		}
+		if !obj.GetDeletionTimestamp().IsZero() {
+			continue
+		}
+
+		logger.Info().Str("name", obj.GetName()).Msg("Deleting removed resource")
+		if err := r.Delete(ctx, obj); client.IgnoreNotFound(err) != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// networkStackMeta returns the metadata of a resource of the stack.
+func networkStackMeta(networkStack *otcv1alpha1.NetworkStack, name string) metav1.ObjectMeta {
+	return metav1.ObjectMeta{Name: name, Namespace: networkStack.Namespace}
+}
+
+// networkStackSubnetName returns the name of the Subnet created for a subnet
+// of the stack.
+func networkStackSubnetName(networkStack *otcv1alpha1.NetworkStack, subnet string) string {
+	return childName(networkStack.Name, subnet)
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *NetworkStackReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	r.recorder = mgr.GetEventRecorderFor("networkstack")
+
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&otcv1alpha1.NetworkStack{}).
+		Owns(&otcv1alpha1.Network{}).
+		Owns(&otcv1alpha1.Subnet{}).
+		Owns(&otcv1alpha1.PublicIP{}).
+		Owns(&otcv1alpha1.NATGateway{}).
+		Owns(&otcv1alpha1.SNATRule{}).
+		Named("networkstack").
+		Complete(r)
+}
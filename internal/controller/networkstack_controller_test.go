@@ -0,0 +1,263 @@
+package controller
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	otcv1alpha1 "github.com/peertech.de/otc-operator/api/v1alpha1"
+)
+
+const testNetworkStackName = "stack"
+
+func newTestNetworkStack() *otcv1alpha1.NetworkStack {
+	return &otcv1alpha1.NetworkStack{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      testNetworkStackName,
+			Namespace: testNamespace,
+			UID:       "network-stack-uid",
+		},
+		Spec: otcv1alpha1.NetworkStackSpec{
+			ProviderConfigRef: otcv1alpha1.ProviderConfigReference{Name: "provider"},
+			Cidr:              "10.0.0.0/16",
+			Subnets: []otcv1alpha1.NetworkStackSubnet{
+				{Name: "public", Cidr: "10.0.0.0/24", GatewayIP: "10.0.0.1"},
+				{Name: "private", Cidr: "10.0.1.0/24", GatewayIP: "10.0.1.1", SNAT: true},
+			},
+			NATGateway: &otcv1alpha1.NetworkStackNATGateway{
+				Subnet:        "public",
+				Type:          otcv1alpha1.TypeSmall,
+				PublicIPType:  otcv1alpha1.PublicIPBGP,
+				BandwidthSize: 10,
+			},
+		},
+	}
+}
+
+func newTestNetworkStackReconciler(t *testing.T, objs ...client.Object) (*NetworkStackReconciler, client.Client) {
+	t.Helper()
+
+	c := fake.NewClientBuilder().
+		WithScheme(newTestScheme(t)).
+		WithObjects(objs...).
+		WithStatusSubresource(&otcv1alpha1.NetworkStack{}).
+		Build()
+
+	return NewNetworkStackReconciler(c, zerolog.Nop()), c
+}
+
+func reconcileTestNetworkStack(
+	t *testing.T,
+	r *NetworkStackReconciler,
+	c client.Client,
+) *otcv1alpha1.NetworkStack {
+	t.Helper()
+
+	key := types.NamespacedName{Name: testNetworkStackName, Namespace: testNamespace}
+	if _, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: key}); err != nil {
+		t.Fatalf("Expected no error, got %s", err)
+	}
+
+	var networkStack otcv1alpha1.NetworkStack
+	if err := c.Get(context.Background(), key, &networkStack); err != nil {
+		t.Fatal(err)
+	}
+	return &networkStack
+}
+
+// listTestNetworkStackResources returns the names of the resources of the
+// list owned by the stack.
+func listTestNetworkStackResources(
+	t *testing.T,
+	c client.Client,
+	networkStack *otcv1alpha1.NetworkStack,
+	list client.ObjectList,
+) []string {
+	t.Helper()
+
+	if err := c.List(context.Background(), list, client.InNamespace(testNamespace)); err != nil {
+		t.Fatal(err)
+	}
+	items, err := meta.ExtractList(list)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var names []string
+	for _, item := range items {
+		obj := item.(client.Object)
+		if !metav1.IsControlledBy(obj, networkStack) {
+			t.Fatalf("Expected %s to be owned by the stack", obj.GetName())
+		}
+		if obj.GetLabels()[otcv1alpha1.NetworkStackLabel] != networkStack.Name {
+			t.Fatalf("Expected %s to have the stack label", obj.GetName())
+		}
+		names = append(names, obj.GetName())
+	}
+	slices.Sort(names)
+	return names
+}
+
+func TestNetworkStackCreatesResources(t *testing.T) {
+	r, c := newTestNetworkStackReconciler(t, newTestNetworkStack())
+
+	networkStack := reconcileTestNetworkStack(t, r, c)
+	privateSubnet := networkStackSubnetName(networkStack, "private")
+	publicSubnet := networkStackSubnetName(networkStack, "public")
+
+	expected := []struct {
+		list  client.ObjectList
+		names []string
+	}{
+		{&otcv1alpha1.NetworkList{}, []string{"stack"}},
+		{&otcv1alpha1.SubnetList{}, []string{privateSubnet, publicSubnet}},
+		{&otcv1alpha1.PublicIPList{}, []string{"stack"}},
+		{&otcv1alpha1.NATGatewayList{}, []string{"stack"}},
+		{&otcv1alpha1.SNATRuleList{}, []string{privateSubnet}},
+	}
+	for _, e := range expected {
+		names := listTestNetworkStackResources(t, c, networkStack, e.list)
+		if !slices.Equal(names, e.names) {
+			t.Fatalf("Expected %v, got %v", e.names, names)
+		}
+	}
+
+	var snatRule otcv1alpha1.SNATRule
+	key := types.NamespacedName{Name: privateSubnet, Namespace: testNamespace}
+	if err := c.Get(context.Background(), key, &snatRule); err != nil {
+		t.Fatal(err)
+	}
+	if snatRule.Spec.Subnet.SubnetRef.Name != privateSubnet ||
+		snatRule.Spec.NATGateway.NATGatewayRef.Name != "stack" ||
+		snatRule.Spec.PublicIP.PublicIPRef.Name != "stack" {
+		t.Fatalf("Expected SNAT rule to reference the resources of the stack, got %+v", snatRule.Spec)
+	}
+
+	if len(networkStack.Status.Resources) != 6 {
+		t.Fatalf("Expected 6 resources in status, got %d", len(networkStack.Status.Resources))
+	}
+	ready := meta.FindStatusCondition(networkStack.Status.Conditions, condReady)
+	if ready == nil || ready.Status != metav1.ConditionFalse {
+		t.Fatalf("Expected stack to be not ready, got %+v", ready)
+	}
+}
+
+func TestNetworkStackPrunesRemovedResources(t *testing.T) {
+	r, c := newTestNetworkStackReconciler(t, newTestNetworkStack())
+	networkStack := reconcileTestNetworkStack(t, r, c)
+
+	// Remove the private subnet and the NAT gateway.
+	networkStack.Spec.Subnets = networkStack.Spec.Subnets[:1]
+	networkStack.Spec.NATGateway = nil
+	if err := c.Update(context.Background(), networkStack); err != nil {
+		t.Fatal(err)
+	}
+
+	networkStack = reconcileTestNetworkStack(t, r, c)
+
+	expected := []struct {
+		list  client.ObjectList
+		names []string
+	}{
+		{&otcv1alpha1.NetworkList{}, []string{"stack"}},
+		{&otcv1alpha1.SubnetList{}, []string{networkStackSubnetName(networkStack, "public")}},
+		{&otcv1alpha1.PublicIPList{}, nil},
+		{&otcv1alpha1.NATGatewayList{}, nil},
+		{&otcv1alpha1.SNATRuleList{}, nil},
+	}
+	for _, e := range expected {
+		names := listTestNetworkStackResources(t, c, networkStack, e.list)
+		if !slices.Equal(names, e.names) {
+			t.Fatalf("Expected %v, got %v", e.names, names)
+		}
+	}
+	if len(networkStack.Status.Resources) != 2 {
+		t.Fatalf("Expected 2 resources in status, got %d", len(networkStack.Status.Resources))
+	}
+}
+
+func TestNetworkStackReady(t *testing.T) {
+	networkStack := newTestNetworkStack()
+	networkStack.Spec.NATGateway = nil
+	networkStack.Spec.Subnets[1].SNAT = false
+	r, c := newTestNetworkStackReconciler(t, networkStack)
+	reconcileTestNetworkStack(t, r, c)
+
+	// Mark all resources ready.
+	markReady := func(obj ManagedObject, name string) {
+		key := types.NamespacedName{Name: name, Namespace: testNamespace}
+		if err := c.Get(context.Background(), key, obj); err != nil {
+			t.Fatal(err)
+		}
+		*obj.GetConditions() = readyCondition(true, reasonReady, "")
+		if err := c.Update(context.Background(), obj); err != nil {
+			t.Fatal(err)
+		}
+	}
+	markReady(&otcv1alpha1.Network{}, "stack")
+	markReady(&otcv1alpha1.Subnet{}, networkStackSubnetName(networkStack, "public"))
+	markReady(&otcv1alpha1.Subnet{}, networkStackSubnetName(networkStack, "private"))
+
+	networkStack = reconcileTestNetworkStack(t, r, c)
+
+	ready := meta.FindStatusCondition(networkStack.Status.Conditions, condReady)
+	if ready == nil || ready.Status != metav1.ConditionTrue {
+		t.Fatalf("Expected stack to be ready, got %+v", ready)
+	}
+	if ready.Message != "3 resources are ready" {
+		t.Fatalf("Expected message %q, got %q", "3 resources are ready", ready.Message)
+	}
+}
+
+func TestNetworkStackDoesNotAdoptExistingResources(t *testing.T) {
+	network := &otcv1alpha1.Network{
+		ObjectMeta: metav1.ObjectMeta{Name: testNetworkStackName, Namespace: testNamespace},
+		Spec:       otcv1alpha1.NetworkSpec{Cidr: "192.168.0.0/16"},
+	}
+	r, c := newTestNetworkStackReconciler(t, newTestNetworkStack(), network)
+
+	networkStack := reconcileTestNetworkStack(t, r, c)
+
+	ready := meta.FindStatusCondition(networkStack.Status.Conditions, condReady)
+	if ready == nil || ready.Reason != reasonResourceConflict {
+		t.Fatalf("Expected a resource conflict, got %+v", ready)
+	}
+
+	// The existing network is left untouched.
+	key := types.NamespacedName{Name: testNetworkStackName, Namespace: testNamespace}
+	if err := c.Get(context.Background(), key, network); err != nil {
+		t.Fatal(err)
+	}
+	if network.Spec.Cidr != "192.168.0.0/16" || len(network.OwnerReferences) != 0 ||
+		network.Labels[otcv1alpha1.NetworkStackLabel] != "" {
+		t.Fatalf("Expected network to be untouched, got %+v", network)
+	}
+}
+
+func TestNetworkStackSubnetName(t *testing.T) {
+	stack := func(name string) *otcv1alpha1.NetworkStack {
+		return &otcv1alpha1.NetworkStack{ObjectMeta: metav1.ObjectMeta{Name: name}}
+	}
+
+	if networkStackSubnetName(stack("a-b"), "c") == networkStackSubnetName(stack("a"), "b-c") {
+		t.Fatalf("Expected distinct names, got %s", networkStackSubnetName(stack("a"), "b-c"))
+	}
+
+	name := networkStackSubnetName(stack(strings.Repeat("a", 60)), strings.Repeat("b", 60))
+	if len(name) > validation.DNS1123LabelMaxLength {
+		t.Fatalf("Expected at most %d characters, got %d", validation.DNS1123LabelMaxLength, len(name))
+	}
+	if errs := validation.IsDNS1123Label(name); len(errs) != 0 {
+		t.Fatalf("Expected a DNS-1123 label, got %q: %v", name, errs)
+	}
+}
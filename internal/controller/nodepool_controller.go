@@ -0,0 +1,246 @@
+package controller
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	otcv1alpha1 "github.com/peertech.de/otc-operator/api/v1alpha1"
+	provider "github.com/peertech.de/otc-operator/internal/provider"
+)
+
+const (
+	nodePoolFinalizerName = "nodepool.otc.peertech.de/finalizer"
+	nodePoolRequeueDelay  = 30 * time.Second
+)
+
+// NodePoolReconciler reconciles a NodePool object
+type NodePoolReconciler = ManagedReconciler[
+	*otcv1alpha1.NodePool,
+	*provider.NodePoolInfo,
+	provider.UpdateNodePoolRequest,
+]
+
+func NewNodePoolReconciler(
+	c client.Client,
+	logger zerolog.Logger,
+	providers *ProviderCache,
+	dependencies *DependencyGraphCache,
+) *NodePoolReconciler {
+	return NewManagedReconciler(c, logger, providers, dependencies, nodePoolResource)
+}
+
+// +kubebuilder:rbac:groups=otc.peertech.de,resources=nodepools,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=otc.peertech.de,resources=nodepools/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=otc.peertech.de,resources=nodepools/finalizers,verbs=update
+// +kubebuilder:rbac:groups=otc.peertech.de,resources=clusters,verbs=get;list;watch
+// +kubebuilder:rbac:groups=otc.peertech.de,resources=subnets,verbs=get;list;watch
+// +kubebuilder:rbac:groups=otc.peertech.de,resources=securitygroups,verbs=get;list;watch
+// +kubebuilder:rbac:groups=otc.peertech.de,resources=keypairs,verbs=get;list;watch
+// +kubebuilder:rbac:groups=otc.peertech.de,resources=providerconfigs,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;create;update;patch
+// +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch;create;update;patch
+
+// nodePoolResource checks the nodes after the RequeueDelay, as they take
+// several minutes to join the cluster.
+var nodePoolResource = ManagedResource[
+	*otcv1alpha1.NodePool,
+	*provider.NodePoolInfo,
+	provider.UpdateNodePoolRequest,
+]{
+	ControllerName: "nodepool",
+	Name:           "node pool",
+	FinalizerName:  nodePoolFinalizerName,
+	RequeueDelay:   nodePoolRequeueDelay,
+
+	New: func() *otcv1alpha1.NodePool { return &otcv1alpha1.NodePool{} },
+
+	Resolve: func(
+		ctx context.Context,
+		resolver *DependencyResolver,
+		nodePool *otcv1alpha1.NodePool,
+	) error {
+		resolved, err := resolver.ResolveNodePoolDependencies(ctx, nodePool.Spec)
+		if err != nil {
+			return err
+		}
+
+		nodePool.Status.ResolvedDependencies = resolved
+		return nil
+	},
+
+	Create: func(
+		ctx context.Context,
+		p provider.Provider,
+		nodePool *otcv1alpha1.NodePool,
+	) (string, error) {
+		resolved := nodePool.Status.ResolvedDependencies
+		resp, err := p.CreateNodePool(ctx, provider.CreateNodePoolRequest{
+			Name:             nodePool.GetName(),
+			Flavor:           nodePool.Spec.Flavor,
+			AvailabilityZone: nodePool.Spec.AvailabilityZone,
+			OS:               nodePool.Spec.OS,
+			RootVolume:       nodePool.Spec.RootVolume,
+			DataVolumes:      nodePool.Spec.DataVolumes,
+			NodeCount:        nodePool.Spec.NodeCount,
+			Autoscaling:      nodePool.Spec.Autoscaling,
+			Labels:           nodePool.Spec.Labels,
+			Taints:           nodePool.Spec.Taints,
+
+			// dependencies
+			ClusterID:        resolved.ClusterID,
+			SubnetID:         resolved.SubnetID,
+			SecurityGroupIDs: resolved.SecurityGroupIDs,
+			KeyPairName:      resolved.KeyPairName,
+		})
+		return resp.ID, err
+	},
+
+	Observe: func(
+		ctx context.Context,
+		p provider.Provider,
+		nodePool *otcv1alpha1.NodePool,
+	) (*provider.NodePoolInfo, error) {
+		info, err := p.GetNodePool(
+			ctx,
+			nodePool.Status.ResolvedDependencies.ClusterID,
+			nodePool.Status.ExternalID,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		// Report the observed phase and node count.
+		nodePool.Status.Phase = info.Phase
+		nodePool.Status.CurrentNodeCount = info.CurrentNodeCount
+		return info, nil
+	},
+
+	Diff: func(
+		logger zerolog.Logger,
+		nodePool *otcv1alpha1.NodePool,
+		info *provider.NodePoolInfo,
+	) (provider.UpdateNodePoolRequest, bool) {
+		updateReq := provider.UpdateNodePoolRequest{
+			NodeCount:   info.NodeCount,
+			Autoscaling: nodePool.Spec.Autoscaling,
+			Labels:      nodePool.Spec.Labels,
+			Taints:      nodePool.Spec.Taints,
+		}
+		needsUpdate := false
+
+		// The node pool cannot be updated while it is busy. A scaling node
+		// pool can be scaled again, though.
+		if info.State() != provider.Ready && !info.Scaling() {
+			return updateReq, false
+		}
+
+		// The node count is managed by the autoscaler if it is enabled.
+		if nodePool.Spec.Autoscaling == nil && nodePool.Spec.NodeCount != info.NodeCount {
+			logger.Info().
+				Int("current", info.NodeCount).
+				Int("desired", nodePool.Spec.NodeCount).
+				Msg("Drift detected in node count")
+
+			updateReq.NodeCount = nodePool.Spec.NodeCount
+			needsUpdate = true
+		}
+
+		if !equalNodePoolAutoscaling(nodePool.Spec.Autoscaling, info.Autoscaling) {
+			logger.Info().Msg("Drift detected in autoscaling")
+			needsUpdate = true
+		}
+
+		// Only the labels managed by the operator are compared, as the
+		// provider adds labels of its own.
+		for key, value := range nodePool.Spec.Labels {
+			if current, ok := info.Labels[key]; !ok || current != value {
+				logger.Info().
+					Str("label", key).
+					Msg("Drift detected in labels")
+				needsUpdate = true
+			}
+		}
+		for key := range nodePool.Status.LastAppliedSpec.Labels {
+			if _, desired := nodePool.Spec.Labels[key]; desired {
+				continue
+			}
+			if _, ok := info.Labels[key]; ok {
+				logger.Info().
+					Str("label", key).
+					Msg("Drift detected in labels, label was removed")
+				updateReq.RemovedLabels = append(updateReq.RemovedLabels, key)
+				needsUpdate = true
+			}
+		}
+
+		if !equalNodePoolTaints(nodePool.Spec.Taints, info.Taints) {
+			logger.Info().Msg("Drift detected in taints")
+			needsUpdate = true
+		}
+
+		return updateReq, needsUpdate
+	},
+
+	Update: func(
+		ctx context.Context,
+		p provider.Provider,
+		nodePool *otcv1alpha1.NodePool,
+		req provider.UpdateNodePoolRequest,
+	) error {
+		return p.UpdateNodePool(
+			ctx,
+			nodePool.Status.ResolvedDependencies.ClusterID,
+			nodePool.Status.ExternalID,
+			req,
+		)
+	},
+
+	ConnectionDetails: func(
+		nodePool *otcv1alpha1.NodePool,
+		info *provider.NodePoolInfo,
+	) map[string]string {
+		return map[string]string{
+			"externalID": info.ID,
+			"clusterID":  nodePool.Status.ResolvedDependencies.ClusterID,
+		}
+	},
+
+	Delete: func(ctx context.Context, p provider.Provider, nodePool *otcv1alpha1.NodePool) error {
+		return p.DeleteNodePool(
+			ctx,
+			nodePool.Status.ResolvedDependencies.ClusterID,
+			nodePool.Status.ExternalID,
+		)
+	},
+}
+
+// equalNodePoolAutoscaling reports whether two autoscaling settings are equal.
+// Nil means autoscaling is disabled.
+func equalNodePoolAutoscaling(a, b *otcv1alpha1.NodePoolAutoscaling) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// equalNodePoolTaints reports whether two sets of taints are equal, ignoring
+// their order.
+func equalNodePoolTaints(a, b []otcv1alpha1.NodePoolTaint) bool {
+	compare := func(x, y otcv1alpha1.NodePoolTaint) int {
+		return cmp.Or(
+			strings.Compare(x.Key, y.Key),
+			strings.Compare(string(x.Effect), string(y.Effect)),
+			strings.Compare(x.Value, y.Value),
+		)
+	}
+	return slices.Equal(
+		slices.SortedFunc(slices.Values(a), compare),
+		slices.SortedFunc(slices.Values(b), compare),
+	)
+}
@@ -0,0 +1,107 @@
+package controller
+
+import (
+	"slices"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	otcv1alpha1 "github.com/peertech.de/otc-operator/api/v1alpha1"
+)
+
+const (
+	testNodePoolName = "nodepool"
+	testNodePoolID   = "nodepool-id"
+)
+
+func newTestNodePool() *otcv1alpha1.NodePool {
+	clusterID, keyPairName := testClusterID, testKeyPairName
+	return &otcv1alpha1.NodePool{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       testNodePoolName,
+			Namespace:  testNamespace,
+			Finalizers: []string{nodePoolFinalizerName},
+		},
+		Spec: otcv1alpha1.NodePoolSpec{
+			ProviderConfigRef: testProviderConfigRef,
+			Cluster:           otcv1alpha1.ClusterDependency{ClusterID: &clusterID},
+			Flavor:            "s3.large.2",
+			KeyPair:           otcv1alpha1.KeyPairDependency{KeyPairName: &keyPairName},
+			NodeCount:         2,
+			Labels:            map[string]string{"role": "worker", "team": "platform"},
+		},
+	}
+}
+
+func TestNodePoolLifecycle(t *testing.T) {
+	p := &fakeProvider{}
+	r := newTestManagedReconciler(t, NewNodePoolReconciler, p, newTestNodePool())
+
+	// The node pool is created in the resolved cluster.
+	_, nodePool := reconcileTestManaged(t, r, newTestNodePool())
+	if len(p.nodePools.created) != 1 || p.nodePools.created[0].ClusterID != testClusterID ||
+		p.nodePools.created[0].KeyPairName != testKeyPairName {
+		t.Fatalf("Expected node pool to be created in cluster %q, got %+v", testClusterID, p.nodePools.created)
+	}
+	if nodePool.Status.ExternalID != testNodePoolID {
+		t.Fatalf("Expected externalID %q, got %q", testNodePoolID, nodePool.Status.ExternalID)
+	}
+
+	// The node pool is not ready while it is synchronized.
+	_, nodePool = reconcileTestManaged(t, r, nodePool)
+	expectCondition(t, nodePool, condReady, metav1.ConditionFalse, reasonProvisioning)
+	if len(p.nodePools.updated) != 0 {
+		t.Fatalf("Expected no update, got %+v", p.nodePools.updated)
+	}
+
+	p.nodePools.info.Phase = ""
+	p.nodePools.info.CurrentNodeCount = 2
+	_, nodePool = reconcileTestManaged(t, r, nodePool)
+	expectCondition(t, nodePool, condReady, metav1.ConditionTrue, reasonProvisioned)
+
+	// A changed node count and a removed label are applied.
+	updateTestManaged(t, r, nodePool, func(nodePool *otcv1alpha1.NodePool) {
+		nodePool.Spec.NodeCount = 3
+		delete(nodePool.Spec.Labels, "team")
+	})
+	_, nodePool = reconcileTestManaged(t, r, nodePool)
+	if len(p.nodePools.updated) != 1 || p.nodePools.updated[0].NodeCount != 3 ||
+		!slices.Equal(p.nodePools.updated[0].RemovedLabels, []string{"team"}) {
+		t.Fatalf("Expected node count and labels to be updated, got %+v", p.nodePools.updated)
+	}
+
+	// The node pool is not ready while it is scaled.
+	_, nodePool = reconcileTestManaged(t, r, nodePool)
+	expectCondition(t, nodePool, condReady, metav1.ConditionFalse, reasonProvisioning)
+
+	p.nodePools.info.CurrentNodeCount = 3
+	_, nodePool = reconcileTestManaged(t, r, nodePool)
+	expectCondition(t, nodePool, condReady, metav1.ConditionTrue, reasonReady)
+	if len(p.nodePools.updated) != 1 || nodePool.Status.CurrentNodeCount != 3 {
+		t.Fatalf("Expected no further update, got %+v", p.nodePools.updated)
+	}
+
+	deleteTestManaged(t, r, nodePool)
+	if !slices.Equal(p.nodePools.deleted, []string{testClusterID + "/" + testNodePoolID}) {
+		t.Fatalf("Expected external deletion of %q, got %v", testNodePoolID, p.nodePools.deleted)
+	}
+}
+
+func TestNodePoolNotFound(t *testing.T) {
+	nodePool := newTestNodePool()
+	nodePool.Status.ExternalID = testNodePoolID
+	nodePool.Status.LastAppliedSpec = nodePool.Spec.DeepCopy()
+	nodePool.Status.ResolvedDependencies.ClusterID = testClusterID
+
+	p := &fakeProvider{}
+	r := newTestManagedReconciler(t, NewNodePoolReconciler, p, nodePool)
+
+	// The node pool deleted out-of-band is created again.
+	_, nodePool = reconcileTestManaged(t, r, nodePool)
+	expectRecreate(t, nodePool)
+
+	_, nodePool = reconcileTestManaged(t, r, nodePool)
+	if len(p.nodePools.created) != 1 || nodePool.Status.ExternalID != testNodePoolID {
+		t.Fatalf("Expected node pool to be recreated, got %+v", p.nodePools.created)
+	}
+}
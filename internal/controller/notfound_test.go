@@ -0,0 +1,289 @@
+package controller
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/rs/zerolog"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	otcv1alpha1 "github.com/peertech.de/otc-operator/api/v1alpha1"
+	provider "github.com/peertech.de/otc-operator/internal/provider"
+)
+
+const (
+	testNamespace   = "default"
+	testExternalID  = "network-id"
+	testRecreatedID = "recreated-network-id"
+	testNetworkName = "network"
+)
+
+// newTestScheme returns a scheme with the core and the operator types.
+func newTestScheme(t *testing.T) *runtime.Scheme {
+	t.Helper()
+
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	if err := otcv1alpha1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	return scheme
+}
+
+// newTestNetworkReconciler returns a NetworkReconciler for the given network,
+// backed by a fake client and the given provider.
+func newTestNetworkReconciler(
+	t *testing.T,
+	network *otcv1alpha1.Network,
+	p provider.Provider,
+) (*NetworkReconciler, client.Client, *record.FakeRecorder) {
+	t.Helper()
+
+	providerConfig := &otcv1alpha1.ProviderConfig{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "provider",
+			Namespace: testNamespace,
+		},
+		Status: otcv1alpha1.ProviderConfigStatus{
+			Conditions: []metav1.Condition{{
+				Type:               condReady,
+				Status:             metav1.ConditionTrue,
+				Reason:             "Ready",
+				LastTransitionTime: metav1.Now(),
+			}},
+		},
+	}
+
+	c := newIndexedClientBuilder(t).
+		WithObjects(providerConfig, network).
+		WithStatusSubresource(&otcv1alpha1.Network{}).
+		Build()
+
+	// Pre-populate the cache, so no provider client is created.
+	providers := NewProviderCache(c, zerolog.Nop())
+	providers.cache[testNamespace+"/"+providerConfig.Name] = &providerEntry{
+		provider:         p,
+		configGeneration: providerConfig.Generation,
+	}
+
+	recorder := record.NewFakeRecorder(10)
+	r := NewNetworkReconciler(c, zerolog.Nop(), providers, nil)
+	r.recorder = recorder
+	return r, c, recorder
+}
+
+func newTestNetwork(policy otcv1alpha1.NotFoundPolicy) *otcv1alpha1.Network {
+	spec := otcv1alpha1.NetworkSpec{
+		ProviderConfigRef: otcv1alpha1.ProviderConfigReference{Name: "provider"},
+		Cidr:              "192.168.0.0/16",
+		NotFoundPolicy:    policy,
+	}
+	return &otcv1alpha1.Network{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       testNetworkName,
+			Namespace:  testNamespace,
+			Finalizers: []string{networkFinalizerName},
+		},
+		Spec: spec,
+		Status: otcv1alpha1.NetworkStatus{
+			ExternalID:      testExternalID,
+			LastAppliedSpec: spec.DeepCopy(),
+		},
+	}
+}
+
+func reconcileTestNetwork(
+	t *testing.T,
+	r *NetworkReconciler,
+	c client.Client,
+) (ctrl.Result, *otcv1alpha1.Network) {
+	t.Helper()
+
+	key := types.NamespacedName{Name: testNetworkName, Namespace: testNamespace}
+	result, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: key})
+	if err != nil {
+		t.Fatalf("Expected no error, got %s", err)
+	}
+
+	var network otcv1alpha1.Network
+	if err := c.Get(context.Background(), key, &network); err != nil {
+		t.Fatal(err)
+	}
+	return result, &network
+}
+
+func expectNotFoundEvent(t *testing.T, recorder *record.FakeRecorder) {
+	t.Helper()
+
+	select {
+	case event := <-recorder.Events:
+		if !strings.Contains(event, eventExternalResourceNotFound) {
+			t.Fatalf("Expected %s event, got %q", eventExternalResourceNotFound, event)
+		}
+	default:
+		t.Fatalf("Expected %s event, got none", eventExternalResourceNotFound)
+	}
+}
+
+func expectExternalResourceFound(
+	t *testing.T,
+	network *otcv1alpha1.Network,
+	status metav1.ConditionStatus,
+	reason string,
+) {
+	t.Helper()
+
+	cond := meta.FindStatusCondition(network.Status.Conditions, condExternalResourceFound)
+	if cond == nil {
+		t.Fatalf("Expected %s condition, got none", condExternalResourceFound)
+	}
+	if cond.Status != status || cond.Reason != reason {
+		t.Fatalf(
+			"Expected %s condition %s/%s, got %s/%s",
+			condExternalResourceFound, status, reason, cond.Status, cond.Reason,
+		)
+	}
+}
+
+func TestNotFoundPolicyRecreate(t *testing.T) {
+	r, c, recorder := newTestNetworkReconciler(
+		t,
+		newTestNetwork(otcv1alpha1.NotFoundPolicyRecreate),
+		&fakeProvider{},
+	)
+
+	result, network := reconcileTestNetwork(t, r, c)
+
+	if !result.Requeue {
+		t.Fatalf("Expected requeue, got %+v", result)
+	}
+	if network.Status.ExternalID != "" {
+		t.Fatalf("Expected externalID to be reset, got %q", network.Status.ExternalID)
+	}
+	if network.Status.LastAppliedSpec != nil {
+		t.Fatal("Expected lastAppliedSpec to be reset")
+	}
+	expectExternalResourceFound(t, network, metav1.ConditionFalse, reasonRecreating)
+	expectNotFoundEvent(t, recorder)
+}
+
+func TestNotFoundPolicyDefaultsToRecreate(t *testing.T) {
+	r, c, _ := newTestNetworkReconciler(t, newTestNetwork(""), &fakeProvider{})
+
+	_, network := reconcileTestNetwork(t, r, c)
+
+	if network.Status.ExternalID != "" {
+		t.Fatalf("Expected externalID to be reset, got %q", network.Status.ExternalID)
+	}
+	expectExternalResourceFound(t, network, metav1.ConditionFalse, reasonRecreating)
+}
+
+func TestNotFoundPolicyMarkDeleted(t *testing.T) {
+	r, c, recorder := newTestNetworkReconciler(
+		t,
+		newTestNetwork(otcv1alpha1.NotFoundPolicyMarkDeleted),
+		&fakeProvider{},
+	)
+
+	result, network := reconcileTestNetwork(t, r, c)
+
+	if !result.IsZero() {
+		t.Fatalf("Expected no requeue, got %+v", result)
+	}
+	if network.Status.ExternalID != testExternalID {
+		t.Fatalf("Expected externalID %q to be kept, got %q", testExternalID, network.Status.ExternalID)
+	}
+	expectExternalResourceFound(t, network, metav1.ConditionFalse, reasonExternallyDeleted)
+	expectNotFoundEvent(t, recorder)
+}
+
+func TestNotFoundPolicyFail(t *testing.T) {
+	r, c, recorder := newTestNetworkReconciler(
+		t,
+		newTestNetwork(otcv1alpha1.NotFoundPolicyFail),
+		&fakeProvider{},
+	)
+
+	result, network := reconcileTestNetwork(t, r, c)
+
+	if result.RequeueAfter != networkRequeueDelay {
+		t.Fatalf("Expected requeue after %s, got %+v", networkRequeueDelay, result)
+	}
+	if network.Status.ExternalID != testExternalID {
+		t.Fatalf("Expected externalID %q to be kept, got %q", testExternalID, network.Status.ExternalID)
+	}
+	expectExternalResourceFound(t, network, metav1.ConditionFalse, reasonNotFound)
+	if !meta.IsStatusConditionFalse(network.Status.Conditions, condReady) {
+		t.Fatal("Expected Ready condition to be false")
+	}
+	expectNotFoundEvent(t, recorder)
+}
+
+func TestNotFoundPolicyMarkDeletedSkipsExternalDeletion(t *testing.T) {
+	p := &fakeProvider{}
+	r, c, _ := newTestNetworkReconciler(
+		t,
+		newTestNetwork(otcv1alpha1.NotFoundPolicyMarkDeleted),
+		p,
+	)
+
+	_, network := reconcileTestNetwork(t, r, c)
+	if err := c.Delete(context.Background(), network); err != nil {
+		t.Fatal(err)
+	}
+
+	key := types.NamespacedName{Name: testNetworkName, Namespace: testNamespace}
+	if _, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: key}); err != nil {
+		t.Fatalf("Expected no error, got %s", err)
+	}
+
+	if len(p.networks.deleted) != 0 {
+		t.Fatalf("Expected no external deletion, got %v", p.networks.deleted)
+	}
+	err := c.Get(context.Background(), key, &otcv1alpha1.Network{})
+	if !apierrors.IsNotFound(err) {
+		t.Fatalf("Expected finalizer to be removed, got %v", err)
+	}
+}
+
+func TestNotFoundPolicyRecreateDeletesRecreatedResource(t *testing.T) {
+	p := &fakeProvider{}
+	r, c, _ := newTestNetworkReconciler(
+		t,
+		newTestNetwork(otcv1alpha1.NotFoundPolicyRecreate),
+		p,
+	)
+
+	// The network is recreated and deleted with the object before it was
+	// observed again.
+	reconcileTestNetwork(t, r, c)
+	_, network := reconcileTestNetwork(t, r, c)
+	if len(p.networks.created) != 1 || network.Status.ExternalID != testRecreatedID {
+		t.Fatalf("Expected network to be recreated, got %+v", p.networks.created)
+	}
+	expectExternalResourceFound(t, network, metav1.ConditionTrue, reasonFound)
+
+	if err := c.Delete(context.Background(), network); err != nil {
+		t.Fatal(err)
+	}
+
+	key := types.NamespacedName{Name: testNetworkName, Namespace: testNamespace}
+	if _, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: key}); err != nil {
+		t.Fatalf("Expected no error, got %s", err)
+	}
+
+	if len(p.networks.deleted) != 1 || p.networks.deleted[0] != testRecreatedID {
+		t.Fatalf("Expected external deletion of %q, got %v", testRecreatedID, p.networks.deleted)
+	}
+}
@@ -0,0 +1,158 @@
+package controller
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	otcv1alpha1 "github.com/peertech.de/otc-operator/api/v1alpha1"
+)
+
+// annotate sets the given annotations on the object stored under key.
+func annotate(
+	t *testing.T,
+	c client.Client,
+	obj client.Object,
+	key types.NamespacedName,
+	annotations map[string]string,
+) {
+	t.Helper()
+
+	if err := c.Get(context.Background(), key, obj); err != nil {
+		t.Fatal(err)
+	}
+	obj.SetAnnotations(annotations)
+	if err := c.Update(context.Background(), obj); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func expectPaused(t *testing.T, network *otcv1alpha1.Network, reason string) {
+	t.Helper()
+
+	cond := meta.FindStatusCondition(network.Status.Conditions, condPaused)
+	if cond == nil {
+		t.Fatalf("Expected %s condition, got none", condPaused)
+	}
+	if cond.Status != metav1.ConditionTrue || cond.Reason != reason {
+		t.Fatalf(
+			"Expected %s condition True/%s, got %s/%s",
+			condPaused, reason, cond.Status, cond.Reason,
+		)
+	}
+}
+
+func TestPausedAnnotation(t *testing.T) {
+	network := newTestNetwork(otcv1alpha1.NotFoundPolicyRecreate)
+	network.Annotations = map[string]string{otcv1alpha1.PausedAnnotation: "true"}
+	r, c, _ := newTestNetworkReconciler(t, network, &fakeProvider{})
+
+	result, network := reconcileTestNetwork(t, r, c)
+
+	if !result.IsZero() {
+		t.Fatalf("Expected no requeue, got %+v", result)
+	}
+	// The fake provider reports the network as not found, so the external ID
+	// is only kept if the provider was not called.
+	if network.Status.ExternalID != testExternalID {
+		t.Fatalf("Expected externalID %q to be kept, got %q", testExternalID, network.Status.ExternalID)
+	}
+	expectPaused(t, network, reasonPaused)
+}
+
+func TestPausedAnnotationResume(t *testing.T) {
+	network := newTestNetwork(otcv1alpha1.NotFoundPolicyMarkDeleted)
+	network.Annotations = map[string]string{otcv1alpha1.PausedAnnotation: "true"}
+	r, c, _ := newTestNetworkReconciler(t, network, &fakeProvider{})
+
+	_, network = reconcileTestNetwork(t, r, c)
+	expectPaused(t, network, reasonPaused)
+
+	annotate(t, c, network, client.ObjectKeyFromObject(network), nil)
+	_, network = reconcileTestNetwork(t, r, c)
+
+	if meta.FindStatusCondition(network.Status.Conditions, condPaused) != nil {
+		t.Fatalf("Expected %s condition to be removed", condPaused)
+	}
+	expectExternalResourceFound(t, network, metav1.ConditionFalse, reasonExternallyDeleted)
+}
+
+func TestPausedProviderConfig(t *testing.T) {
+	r, c, _ := newTestNetworkReconciler(
+		t,
+		newTestNetwork(otcv1alpha1.NotFoundPolicyRecreate),
+		&fakeProvider{},
+	)
+	annotate(
+		t,
+		c,
+		&otcv1alpha1.ProviderConfig{},
+		types.NamespacedName{Name: "provider", Namespace: testNamespace},
+		map[string]string{otcv1alpha1.PausedAnnotation: "true"},
+	)
+
+	result, network := reconcileTestNetwork(t, r, c)
+
+	if result.RequeueAfter != networkRequeueDelay {
+		t.Fatalf("Expected requeue after %s, got %+v", networkRequeueDelay, result)
+	}
+	if network.Status.ExternalID != testExternalID {
+		t.Fatalf("Expected externalID %q to be kept, got %q", testExternalID, network.Status.ExternalID)
+	}
+	expectPaused(t, network, reasonProviderConfigPaused)
+}
+
+func TestPausedAnnotationDeletion(t *testing.T) {
+	p := &fakeProvider{}
+	network := newTestNetwork(otcv1alpha1.NotFoundPolicyRecreate)
+	network.Annotations = map[string]string{otcv1alpha1.PausedAnnotation: "true"}
+	r, c, _ := newTestNetworkReconciler(t, network, p)
+
+	if err := c.Delete(context.Background(), network); err != nil {
+		t.Fatal(err)
+	}
+
+	key := types.NamespacedName{Name: testNetworkName, Namespace: testNamespace}
+	if _, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: key}); err != nil {
+		t.Fatalf("Expected no error, got %s", err)
+	}
+
+	if !slices.Equal(p.networks.deleted, []string{testExternalID}) {
+		t.Fatalf("Expected external deletion of %q, got %v", testExternalID, p.networks.deleted)
+	}
+	err := c.Get(context.Background(), key, &otcv1alpha1.Network{})
+	if !apierrors.IsNotFound(err) {
+		t.Fatalf("Expected finalizer to be removed, got %v", err)
+	}
+}
+
+func TestPauseDeletionAnnotation(t *testing.T) {
+	p := &fakeProvider{}
+	network := newTestNetwork(otcv1alpha1.NotFoundPolicyRecreate)
+	network.Annotations = map[string]string{
+		otcv1alpha1.PausedAnnotation:        "true",
+		otcv1alpha1.PauseDeletionAnnotation: "true",
+	}
+	r, c, _ := newTestNetworkReconciler(t, network, p)
+
+	if err := c.Delete(context.Background(), network); err != nil {
+		t.Fatal(err)
+	}
+
+	_, network = reconcileTestNetwork(t, r, c)
+
+	if len(p.networks.deleted) != 0 {
+		t.Fatalf("Expected no external deletion, got %v", p.networks.deleted)
+	}
+	if !slices.Contains(network.Finalizers, networkFinalizerName) {
+		t.Fatal("Expected finalizer to be kept")
+	}
+	expectPaused(t, network, reasonPaused)
+}
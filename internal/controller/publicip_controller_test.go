@@ -1,7 +1,299 @@
 package controller
 
 import (
-	. "github.com/onsi/ginkgo/v2"
+	"slices"
+	"testing"
+
+	"github.com/rs/zerolog"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	otcv1alpha1 "github.com/peertech.de/otc-operator/api/v1alpha1"
+	provider "github.com/peertech.de/otc-operator/internal/provider"
 )
 
-var _ = Describe("PublicIP Controller", func() {})
+const (
+	testPublicIPName = "eip"
+	testPublicIPID   = "public-ip-id"
+)
+
+func newTestPublicIP() *otcv1alpha1.PublicIP {
+	return &otcv1alpha1.PublicIP{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       testPublicIPName,
+			Namespace:  testNamespace,
+			Finalizers: []string{publicIPFinalizerName},
+		},
+		Spec: otcv1alpha1.PublicIPSpec{
+			ProviderConfigRef:  testProviderConfigRef,
+			Type:               otcv1alpha1.PublicIPBGP,
+			BandwidthSize:      10,
+			BandwidthShareType: otcv1alpha1.PublicIPBandwidthDedicated,
+		},
+	}
+}
+
+// newTestPublicIPVirtualIP returns a ready VirtualIP with the test external
+// ID, which the test public IP can be bound to.
+func newTestPublicIPVirtualIP() *otcv1alpha1.VirtualIP {
+	return &otcv1alpha1.VirtualIP{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      testVirtualIPName,
+			Namespace: testNamespace,
+		},
+		Status: otcv1alpha1.VirtualIPStatus{
+			ExternalID: testVirtualIPID,
+			Conditions: []metav1.Condition{{
+				Type:               condReady,
+				Status:             metav1.ConditionTrue,
+				Reason:             reasonReady,
+				LastTransitionTime: metav1.Now(),
+			}},
+		},
+	}
+}
+
+func TestPublicIPBindingDrift(t *testing.T) {
+	portBinding := func(portID string) *otcv1alpha1.PublicIPBinding {
+		return &otcv1alpha1.PublicIPBinding{PortID: &portID}
+	}
+
+	tests := []struct {
+		name        string
+		binding     *otcv1alpha1.PublicIPBinding
+		lastApplied *otcv1alpha1.PublicIPBinding
+		boundPortID string
+		needsUpdate bool
+		expected    string
+	}{
+		{"bind", portBinding("port-a"), nil, "", true, "port-a"},
+		{"bound", portBinding("port-a"), portBinding("port-a"), "port-a", false, ""},
+		{"rebind", portBinding("port-b"), portBinding("port-a"), "port-a", true, "port-b"},
+		{"unbind removed binding", nil, portBinding("port-a"), "port-a", true, ""},
+		// Public IPs bound by other means are left alone.
+		{"unmanaged binding", nil, nil, "port-x", false, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			publicIP := newTestPublicIP()
+			publicIP.Spec.Binding = tt.binding
+			lastAppliedSpec := publicIP.Spec.DeepCopy()
+			lastAppliedSpec.Binding = tt.lastApplied
+			publicIP.Status.LastAppliedSpec = lastAppliedSpec
+			if tt.binding != nil {
+				publicIP.Status.ResolvedDependencies.PortID = *tt.binding.PortID
+			}
+			info := &provider.PublicIPInfo{
+				BandwidthName: bandwidthPrefix + testPublicIPName,
+				BandwidthSize: 10,
+				PortID:        tt.boundPortID,
+			}
+
+			req, needsUpdate := publicIPResource.Diff(zerolog.Nop(), publicIP, info)
+			if needsUpdate != tt.needsUpdate || (req.PortID != nil) != tt.needsUpdate {
+				t.Fatalf("Expected binding update %t, got %+v (update: %t)", tt.needsUpdate, req, needsUpdate)
+			}
+			if tt.needsUpdate && *req.PortID != tt.expected {
+				t.Fatalf("Expected binding to %q, got %q", tt.expected, *req.PortID)
+			}
+		})
+	}
+}
+
+func TestPublicIPBindingLifecycle(t *testing.T) {
+	publicIP := newTestPublicIP()
+	publicIP.Spec.Binding = &otcv1alpha1.PublicIPBinding{
+		VirtualIPRef: &corev1.LocalObjectReference{Name: testVirtualIPName},
+	}
+
+	p := &fakeProvider{}
+	r := newTestManagedReconciler(t, NewPublicIPReconciler, p, publicIP, newTestPublicIPVirtualIP())
+
+	// The public IP is created bound to the port of the virtual IP.
+	_, publicIP = reconcileTestManaged(t, r, publicIP)
+	if len(p.publicIPs.created) != 1 || p.publicIPs.created[0].PortID != testVirtualIPID {
+		t.Fatalf("Expected public IP to be created bound to %q, got %+v", testVirtualIPID, p.publicIPs.created)
+	}
+
+	p.publicIPs.info.Status = "ACTIVE"
+	_, publicIP = reconcileTestManaged(t, r, publicIP)
+	expectCondition(t, publicIP, condReady, metav1.ConditionTrue, reasonProvisioned)
+	if publicIP.Status.BoundPortID != testVirtualIPID {
+		t.Fatalf("Expected bound port %q, got %q", testVirtualIPID, publicIP.Status.BoundPortID)
+	}
+	if len(p.publicIPs.updated) != 0 {
+		t.Fatalf("Expected no update, got %+v", p.publicIPs.updated)
+	}
+
+	// The public IP is unbound when the binding is removed.
+	updateTestManaged(t, r, publicIP, func(publicIP *otcv1alpha1.PublicIP) {
+		publicIP.Spec.Binding = nil
+	})
+	_, publicIP = reconcileTestManaged(t, r, publicIP)
+	if len(p.publicIPs.updated) != 1 || p.publicIPs.updated[0].PortID == nil ||
+		*p.publicIPs.updated[0].PortID != "" {
+		t.Fatalf("Expected public IP to be unbound, got %+v", p.publicIPs.updated)
+	}
+
+	_, publicIP = reconcileTestManaged(t, r, publicIP)
+	if len(p.publicIPs.updated) != 1 {
+		t.Fatalf("Expected no further update, got %+v", p.publicIPs.updated)
+	}
+	if publicIP.Status.BoundPortID != "" {
+		t.Fatalf("Expected no bound port, got %q", publicIP.Status.BoundPortID)
+	}
+
+	// The unbound public IP is released right away.
+	deleteTestManaged(t, r, publicIP)
+	if len(p.publicIPs.updated) != 1 || !slices.Equal(p.publicIPs.deleted, []string{testPublicIPID}) {
+		t.Fatalf("Expected external deletion of %q, got %v", testPublicIPID, p.publicIPs.deleted)
+	}
+}
+
+func TestPublicIPDeletionUnbinds(t *testing.T) {
+	portID := "port-id"
+	publicIP := newTestPublicIP()
+	publicIP.Spec.Binding = &otcv1alpha1.PublicIPBinding{PortID: &portID}
+
+	p := &fakeProvider{}
+	r := newTestManagedReconciler(t, NewPublicIPReconciler, p, publicIP)
+
+	reconcileTestManaged(t, r, publicIP)
+	p.publicIPs.info.Status = "ACTIVE"
+	_, publicIP = reconcileTestManaged(t, r, publicIP)
+
+	// The bound public IP cannot be released, so it is unbound first.
+	deleteTestManaged(t, r, publicIP)
+	if len(p.publicIPs.updated) != 1 || p.publicIPs.updated[0].PortID == nil ||
+		*p.publicIPs.updated[0].PortID != "" {
+		t.Fatalf("Expected public IP to be unbound, got %+v", p.publicIPs.updated)
+	}
+	if !slices.Equal(p.publicIPs.deleted, []string{testPublicIPID}) {
+		t.Fatalf("Expected external deletion of %q, got %v", testPublicIPID, p.publicIPs.deleted)
+	}
+}
+
+// newTestPublicIPBandwidth returns a ready Bandwidth with the test external
+// ID, which the test public IP can join.
+func newTestPublicIPBandwidth() *otcv1alpha1.Bandwidth {
+	return &otcv1alpha1.Bandwidth{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      testBandwidthName,
+			Namespace: testNamespace,
+		},
+		Status: otcv1alpha1.BandwidthStatus{
+			ExternalID: testBandwidthID,
+			Conditions: []metav1.Condition{{
+				Type:               condReady,
+				Status:             metav1.ConditionTrue,
+				Reason:             reasonReady,
+				LastTransitionTime: metav1.Now(),
+			}},
+		},
+	}
+}
+
+func TestPublicIPSharedBandwidth(t *testing.T) {
+	publicIP := newTestPublicIP()
+	publicIP.Spec.BandwidthSize = 0
+	publicIP.Spec.BandwidthShareType = otcv1alpha1.PublicIPBandwidthShared
+	publicIP.Spec.Bandwidth = &otcv1alpha1.BandwidthDependency{
+		BandwidthRef: &corev1.LocalObjectReference{Name: testBandwidthName},
+	}
+
+	p := &fakeProvider{}
+	r := newTestManagedReconciler(t, NewPublicIPReconciler, p, publicIP, newTestPublicIPBandwidth())
+
+	// The public IP joins the shared bandwidth.
+	_, publicIP = reconcileTestManaged(t, r, publicIP)
+	if len(p.publicIPs.created) != 1 || p.publicIPs.created[0].BandwidthID != testBandwidthID {
+		t.Fatalf("Expected public IP to join bandwidth %q, got %+v", testBandwidthID, p.publicIPs.created)
+	}
+
+	// The size and the name of the shared bandwidth are managed by the
+	// Bandwidth, so they are not drift of the public IP.
+	p.publicIPs.info.Status = "ACTIVE"
+	p.publicIPs.info.BandwidthName = testBandwidthName
+	p.publicIPs.info.BandwidthSize = 100
+	_, publicIP = reconcileTestManaged(t, r, publicIP)
+	expectCondition(t, publicIP, condReady, metav1.ConditionTrue, reasonProvisioned)
+	if len(p.publicIPs.updated) != 0 {
+		t.Fatalf("Expected no update, got %+v", p.publicIPs.updated)
+	}
+	if publicIP.Status.ResolvedDependencies.BandwidthID != testBandwidthID {
+		t.Fatalf("Expected bandwidth %q, got %q", testBandwidthID, publicIP.Status.ResolvedDependencies.BandwidthID)
+	}
+}
+
+func TestPublicIPBandwidthResize(t *testing.T) {
+	p := &fakeProvider{}
+	r := newTestManagedReconciler(t, NewPublicIPReconciler, p, newTestPublicIP())
+
+	_, publicIP := reconcileTestManaged(t, r, newTestPublicIP())
+	if len(p.publicIPs.created) != 1 || p.publicIPs.created[0].BandwidthSize != 10 ||
+		p.publicIPs.created[0].BandwidthName != bandwidthPrefix+testPublicIPName {
+		t.Fatalf("Expected public IP to be created with its bandwidth, got %+v", p.publicIPs.created)
+	}
+
+	p.publicIPs.info.Status = "DOWN"
+	_, publicIP = reconcileTestManaged(t, r, publicIP)
+	expectCondition(t, publicIP, condReady, metav1.ConditionTrue, reasonProvisioned)
+
+	// A changed bandwidth size is applied in place.
+	updateTestManaged(t, r, publicIP, func(publicIP *otcv1alpha1.PublicIP) {
+		publicIP.Spec.BandwidthSize = 20
+	})
+	_, publicIP = reconcileTestManaged(t, r, publicIP)
+	if len(p.publicIPs.updated) != 1 || p.publicIPs.updated[0].BandwidthSize != 20 ||
+		p.publicIPs.updated[0].PortID != nil || p.publicIPs.updated[0].BandwidthName != "" {
+		t.Fatalf("Expected bandwidth size to be updated, got %+v", p.publicIPs.updated)
+	}
+
+	_, publicIP = reconcileTestManaged(t, r, publicIP)
+	if len(p.publicIPs.updated) != 1 {
+		t.Fatalf("Expected no further update, got %+v", p.publicIPs.updated)
+	}
+	if len(p.publicIPs.created) != 1 {
+		t.Fatalf("Expected public IP to be kept, got %+v", p.publicIPs.created)
+	}
+
+	deleteTestManaged(t, r, publicIP)
+	if !slices.Equal(p.publicIPs.deleted, []string{testPublicIPID}) {
+		t.Fatalf("Expected external deletion of %q, got %v", testPublicIPID, p.publicIPs.deleted)
+	}
+}
+
+func TestPublicIPBandwidthNameDrift(t *testing.T) {
+	spec := otcv1alpha1.PublicIPSpec{BandwidthSize: 10}
+	publicIP := &otcv1alpha1.PublicIP{
+		ObjectMeta: metav1.ObjectMeta{Name: "eip", Namespace: testNamespace},
+		Spec:       spec,
+		Status:     otcv1alpha1.PublicIPStatus{LastAppliedSpec: spec.DeepCopy()},
+	}
+
+	tests := []struct {
+		name          string
+		bandwidthName string
+		expected      string
+	}{
+		{"prefixed", "bandwidth-eip", ""},
+		// Bandwidths created before the prefix was introduced are not
+		// renamed.
+		{"legacy", "eip", ""},
+		{"renamed out-of-band", "other", "bandwidth-eip"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			info := &provider.PublicIPInfo{
+				BandwidthName: tt.bandwidthName,
+				BandwidthSize: 10,
+			}
+
+			req, needsUpdate := publicIPResource.Diff(zerolog.Nop(), publicIP, info)
+			if needsUpdate != (tt.expected != "") || req.BandwidthName != tt.expected {
+				t.Fatalf("Expected bandwidth name update %q, got %q (update: %t)",
+					tt.expected, req.BandwidthName, needsUpdate)
+			}
+		})
+	}
+}
@@ -3,11 +3,15 @@ package controller
 import (
 	"context"
 	"fmt"
+	"maps"
 	"time"
 
 	"github.com/rs/zerolog"
+	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
@@ -29,7 +33,9 @@ type ReferenceCheck interface {
 type Reconciler struct {
 	logger         zerolog.Logger
 	client         client.Client
+	recorder       record.EventRecorder
 	providers      *ProviderCache
+	dependencies   *DependencyGraphCache
 	object         client.Object
 	originalObject client.Object
 	conditions     *[]metav1.Condition
@@ -63,6 +69,10 @@ func (rc *Reconciler) RemoveFinalizer(ctx context.Context) error {
 
 // UpdateStatus updates the status subresource.
 func (rc *Reconciler) UpdateStatus(ctx context.Context) error {
+	if obj, ok := rc.object.(ManagedObject); ok {
+		rc.updateBlockedBy(obj)
+	}
+
 	err := rc.client.Status().Patch(
 		ctx,
 		rc.object,
@@ -80,6 +90,32 @@ func (rc *Reconciler) UpdateStatus(ctx context.Context) error {
 	return nil
 }
 
+// updateBlockedBy reports the chain of unready resources the object is
+// waiting for while its dependencies are not ready.
+func (rc *Reconciler) updateBlockedBy(obj ManagedObject) {
+	if !meta.IsStatusConditionFalse(*rc.conditions, condDependenciesReady) {
+		obj.SetBlockedBy(nil)
+		return
+	}
+
+	// The shared graph is rebuilt periodically, so the chain can lag behind
+	// by the rebuild interval.
+	if rc.dependencies == nil {
+		return
+	}
+	g := rc.dependencies.Graph()
+	if g == nil {
+		return
+	}
+
+	gvk, err := rc.client.GroupVersionKindFor(obj)
+	if err != nil {
+		rc.logger.Debug().Err(err).Msg("Failed to get kind for dependency graph")
+		return
+	}
+	obj.SetBlockedBy(g.BlockedBy(gvk.Kind, obj.GetNamespace(), obj.GetName()))
+}
+
 // CheckProviderConfig validates the provider config and returns the provider.
 func (rc *Reconciler) CheckProviderConfig(
 	ctx context.Context,
@@ -101,6 +137,136 @@ func (rc *Reconciler) CheckProviderConfig(
 	return pc, false, ctrl.Result{}, nil
 }
 
+// CheckPaused checks whether the reconciliation is paused by the
+// PausedAnnotation of the resource or of the referenced ProviderConfig. The
+// deletion of a resource is only paused if the PauseDeletionAnnotation is set
+// as well.
+func (rc *Reconciler) CheckPaused(
+	ctx context.Context,
+	ref otcv1alpha1.ProviderConfigReference,
+) (paused bool, result ctrl.Result) {
+	deleting := !rc.object.GetDeletionTimestamp().IsZero()
+
+	if isPaused(rc.object, deleting) {
+		rc.logger.Debug().Msg("Reconciliation is paused")
+		rc.SetPaused(
+			WithMessagef("Reconciliation is paused by the %s annotation", otcv1alpha1.PausedAnnotation),
+		)
+		// Removing the annotation triggers a new reconciliation.
+		return true, ctrl.Result{}
+	}
+
+	pcKey := client.ObjectKey{Name: ref.Name, Namespace: ref.Namespace}
+	if pcKey.Namespace == "" {
+		pcKey.Namespace = rc.object.GetNamespace()
+	}
+
+	// Errors are ignored, they are reported by CheckProviderConfig.
+	var pc otcv1alpha1.ProviderConfig
+	if err := rc.client.Get(ctx, pcKey, &pc); err == nil && isPaused(&pc, deleting) {
+		rc.logger.Debug().Str("providerConfig", pc.Name).Msg("Reconciliation is paused by ProviderConfig")
+		rc.SetPaused(
+			WithReason(reasonProviderConfigPaused),
+			WithMessagef("Reconciliation is paused by ProviderConfig %s", pc.Name),
+		)
+		// ProviderConfigs are not watched, so check again later.
+		return true, ctrl.Result{RequeueAfter: rc.requeueAfter}
+	}
+
+	rc.SetNotPaused()
+	return false, ctrl.Result{}
+}
+
+// isPaused reports whether the annotations of obj pause its reconciliation.
+func isPaused(obj client.Object, deleting bool) bool {
+	annotations := obj.GetAnnotations()
+	if annotations[otcv1alpha1.PausedAnnotation] != "true" {
+		return false
+	}
+	return !deleting || annotations[otcv1alpha1.PauseDeletionAnnotation] == "true"
+}
+
+// eventExternalResourceNotFound is the reason of the event emitted when an
+// external resource was deleted out-of-band.
+const eventExternalResourceNotFound = "ExternalResourceNotFound"
+
+// +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
+
+// HandleNotFound handles an external resource which was deleted out-of-band,
+// as defined by the NotFoundPolicy of the resource. The external resource is
+// either recreated, the resource is marked as deleted, or the reconciliation
+// fails until the external resource is restored.
+func (rc *Reconciler) HandleNotFound(obj ManagedObject) (ctrl.Result, error) {
+	externalID := obj.GetExternalID()
+	policy := obj.GetNotFoundPolicy()
+
+	logger := rc.logger.With().
+		Str("external-id", externalID).
+		Str("not-found-policy", string(policy)).
+		Logger()
+
+	switch policy {
+	case otcv1alpha1.NotFoundPolicyMarkDeleted:
+		logger.Warn().Msg("External resource not found, marking resource as deleted")
+
+		message := fmt.Sprintf("External resource with ID %s was deleted out-of-band", externalID)
+		rc.recorder.Event(obj, corev1.EventTypeWarning, eventExternalResourceNotFound, message)
+		rc.SetExternalResourceNotFound(
+			WithReason(reasonExternallyDeleted),
+			WithMessage(message),
+		)
+		rc.SetReconciliationFailed(
+			WithReason(reasonExternallyDeleted),
+			WithMessage(message),
+		)
+
+		// Nothing is left to reconcile. Changing the policy triggers a new
+		// reconciliation.
+		return ctrl.Result{}, nil
+	case otcv1alpha1.NotFoundPolicyFail:
+		logger.Error().Msg("External resource not found")
+
+		message := fmt.Sprintf(
+			"External resource with ID %s was not found and needs to be restored",
+			externalID,
+		)
+		rc.recorder.Event(obj, corev1.EventTypeWarning, eventExternalResourceNotFound, message)
+		rc.SetExternalResourceNotFound(WithMessage(message))
+		rc.SetReconciliationFailed(
+			WithReason(reasonNotFound),
+			WithMessage(message),
+		)
+
+		return ctrl.Result{RequeueAfter: rc.requeueAfter}, nil
+	default:
+		// Recreate the resource in the next reconciliation.
+		logger.Warn().Msg("External resource not found by ID, resetting externalID to trigger creation")
+
+		message := fmt.Sprintf(
+			"External resource with ID %s was not found and will be recreated",
+			externalID,
+		)
+		rc.recorder.Event(obj, corev1.EventTypeWarning, eventExternalResourceNotFound, message)
+		rc.SetExternalResourceNotFound(
+			WithReason(reasonRecreating),
+			WithMessage(message),
+		)
+		rc.SetNotSynced(
+			WithReason(reasonNotFound),
+			WithMessage(message),
+		)
+		rc.SetNotReady(
+			WithReason(reasonNotFound),
+			WithMessage("Resource needs to be recreated"),
+		)
+
+		// Reset status fields.
+		obj.SetExternalID("")
+		obj.ResetLastAppliedSpec()
+		return ctrl.Result{Requeue: true}, nil
+	}
+}
+
 // BlockOnAnyReference runs all provided reference checks and blocks deletion
 // if any references exist.
 func (rc *Reconciler) BlockOnAnyReference(
@@ -144,105 +310,83 @@ func (rc *Reconciler) BlockOnAnyReference(
 	return false, ctrl.Result{}, nil
 }
 
-type SecurityGroupRuleReferenceCheck struct{}
-
-func (SecurityGroupRuleReferenceCheck) Resource() string { return "SecurityGroupRules" }
-
-func (SecurityGroupRuleReferenceCheck) Check(
-	ctx context.Context,
-	c client.Client,
-	namespace string,
-	externalID string,
-) ([]string, error) {
-	var list otcv1alpha1.SecurityGroupRuleList
-	err := c.List(ctx, &list, client.InNamespace(namespace))
-	if err != nil {
-		return nil, fmt.Errorf("list SecurityGroupRules: %w", err)
-	}
-
-	var refs []string
-	for _, item := range list.Items {
-		if item.Status.ResolvedDependencies.SecurityGroupID == externalID {
-			refs = append(refs, item.Name)
-		}
+// referencerName returns the name of a referencing object. Objects in another
+// namespace than the referenced resource are qualified with their namespace.
+func referencerName(namespace string, obj client.Object) string {
+	if obj.GetNamespace() == namespace {
+		return obj.GetName()
 	}
-
-	return refs, nil
+	return obj.GetNamespace() + "/" + obj.GetName()
 }
 
-type NATGatewayNetworkReferenceCheck struct{}
-
-func (NATGatewayNetworkReferenceCheck) Resource() string { return "NATGateways" }
-
-func (NATGatewayNetworkReferenceCheck) Check(
+// WriteConnectionDetails writes the connection details to the referenced
+// Secret or ConfigMap, creating it if necessary. The object is owned by the
+// reconciled resource, so it is garbage collected together with it. Existing
+// objects are only written if they are already owned by the resource, and
+// keys not part of the connection details are kept. Nothing is written if ref
+// is nil.
+func (rc *Reconciler) WriteConnectionDetails(
 	ctx context.Context,
-	c client.Client,
-	namespace, externalID string,
-) ([]string, error) {
-	var list otcv1alpha1.NATGatewayList
-	err := c.List(ctx, &list, client.InNamespace(namespace))
-	if err != nil {
-		return nil, fmt.Errorf("list NATGateways: %w", err)
+	ref *otcv1alpha1.ConnectionDetailsReference,
+	details map[string]string,
+) error {
+	if ref == nil {
+		return nil
 	}
 
-	var refs []string
-	for _, item := range list.Items {
-		if item.Status.ResolvedDependencies.NetworkID == externalID {
-			refs = append(refs, item.Name)
-		}
+	meta := metav1.ObjectMeta{
+		Name:      ref.Name,
+		Namespace: rc.object.GetNamespace(),
 	}
 
-	return refs, nil
-}
-
-type SNATRuleNetworkReferenceCheck struct{}
-
-func (SNATRuleNetworkReferenceCheck) Resource() string { return "SNATRules" }
-
-func (SNATRuleNetworkReferenceCheck) Check(
-	ctx context.Context,
-	c client.Client,
-	namespace, externalID string,
-) ([]string, error) {
-	var list otcv1alpha1.SNATRuleList
-	err := c.List(ctx, &list, client.InNamespace(namespace))
-	if err != nil {
-		return nil, fmt.Errorf("list SNATRules: %w", err)
-	}
-
-	var refs []string
-	for _, item := range list.Items {
-		if item.Status.ResolvedDependencies.NATGatewayID == externalID {
-			refs = append(refs, item.Name)
+	var obj client.Object
+	var mutate func()
+	switch ref.Kind {
+	case otcv1alpha1.ConnectionDetailsSecret, "":
+		secret := &corev1.Secret{ObjectMeta: meta}
+		obj = secret
+		mutate = func() {
+			if secret.Data == nil {
+				secret.Data = make(map[string][]byte, len(details))
+			}
+			for key, value := range details {
+				secret.Data[key] = []byte(value)
+			}
+		}
+	case otcv1alpha1.ConnectionDetailsConfigMap:
+		configMap := &corev1.ConfigMap{ObjectMeta: meta}
+		obj = configMap
+		mutate = func() {
+			if configMap.Data == nil {
+				configMap.Data = make(map[string]string, len(details))
+			}
+			maps.Copy(configMap.Data, details)
 		}
+	default:
+		return fmt.Errorf("unknown connection details kind: %s", ref.Kind)
 	}
 
-	return refs, nil
-}
-
-type SubnetNetworkReferenceCheck struct{}
-
-func (SubnetNetworkReferenceCheck) Resource() string { return "Subnets" }
-
-func (SubnetNetworkReferenceCheck) Check(
-	ctx context.Context,
-	c client.Client,
-	namespace, externalID string,
-) ([]string, error) {
-	var list otcv1alpha1.SubnetList
-	err := c.List(ctx, &list, client.InNamespace(namespace))
+	result, err := controllerutil.CreateOrUpdate(ctx, rc.client, obj, func() error {
+		// Do not take over objects created by users or other resources.
+		if obj.GetResourceVersion() != "" && !metav1.IsControlledBy(obj, rc.object) {
+			return fmt.Errorf("already exists and is not owned by %s", rc.object.GetName())
+		}
+		mutate()
+		return controllerutil.SetControllerReference(rc.object, obj, rc.client.Scheme())
+	})
 	if err != nil {
-		return nil, fmt.Errorf("list Subnets: %w", err)
+		return fmt.Errorf("failed to write %s %s: %w", ref.Kind, ref.Name, err)
 	}
 
-	var refs []string
-	for _, item := range list.Items {
-		if item.Status.ResolvedDependencies.NetworkID == externalID {
-			refs = append(refs, item.Name)
-		}
+	if result != controllerutil.OperationResultNone {
+		rc.logger.Debug().
+			Str("kind", string(ref.Kind)).
+			Str("name", ref.Name).
+			Str("operation", string(result)).
+			Msg("Wrote connection details")
 	}
 
-	return refs, nil
+	return nil
 }
 
 // Delete performs standardized finalizer-based deletion.
@@ -298,6 +442,12 @@ func (rc *Reconciler) Delete(
 		return ctrl.Result{RequeueAfter: rc.requeueAfter}, nil
 	}
 
+	// An external resource which was deleted out-of-band can not be deleted.
+	if meta.IsStatusConditionFalse(*rc.conditions, condExternalResourceFound) {
+		scopedLogger.Info().Msg("Skipping external deletion of missing resource")
+		externalID = ""
+	}
+
 	// Perform external deletion unless orphaning is requested.
 	if !orphanOnDelete && externalID != "" {
 		scopedLogger.Info().Msg("Deleting external resource")
@@ -431,3 +581,23 @@ func (rc *Reconciler) SetDeleted() {
 func (rc *Reconciler) SetOrphaned(opts ...ConditionOption) {
 	SetOrphaned(rc.conditions, rc.generation, opts...)
 }
+
+// SetExternalResourceFound marks the external resource as found
+func (rc *Reconciler) SetExternalResourceFound() {
+	SetExternalResourceFound(rc.conditions, rc.generation)
+}
+
+// SetExternalResourceNotFound marks the external resource as not found
+func (rc *Reconciler) SetExternalResourceNotFound(opts ...ConditionOption) {
+	SetExternalResourceNotFound(rc.conditions, rc.generation, opts...)
+}
+
+// SetPaused marks the reconciliation as paused
+func (rc *Reconciler) SetPaused(opts ...ConditionOption) {
+	SetPaused(rc.conditions, rc.generation, opts...)
+}
+
+// SetNotPaused removes the Paused condition
+func (rc *Reconciler) SetNotPaused() {
+	SetNotPaused(rc.conditions)
+}
@@ -0,0 +1,266 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	otcv1alpha1 "github.com/peertech.de/otc-operator/api/v1alpha1"
+	provider "github.com/peertech.de/otc-operator/internal/provider"
+)
+
+const (
+	recordSetFinalizerName = "recordset.otc.peertech.de/finalizer"
+	recordSetRequeueDelay  = 30 * time.Second
+)
+
+// RecordSetReconciler reconciles a RecordSet object
+type RecordSetReconciler = ManagedReconciler[
+	*otcv1alpha1.RecordSet,
+	*provider.RecordSetInfo,
+	provider.UpdateRecordSetRequest,
+]
+
+func NewRecordSetReconciler(
+	c client.Client,
+	logger zerolog.Logger,
+	providers *ProviderCache,
+	dependencies *DependencyGraphCache,
+) *RecordSetReconciler {
+	return NewManagedReconciler(c, logger, providers, dependencies, recordSetResource)
+}
+
+// +kubebuilder:rbac:groups=otc.peertech.de,resources=recordsets,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=otc.peertech.de,resources=recordsets/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=otc.peertech.de,resources=recordsets/finalizers,verbs=update
+// +kubebuilder:rbac:groups=otc.peertech.de,resources=dnszones,verbs=get;list;watch
+// +kubebuilder:rbac:groups=otc.peertech.de,resources=publicips,verbs=get;list;watch
+// +kubebuilder:rbac:groups=otc.peertech.de,resources=virtualips,verbs=get;list;watch
+// +kubebuilder:rbac:groups=otc.peertech.de,resources=providerconfigs,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;create;update;patch
+// +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch;create;update;patch
+
+var recordSetResource = ManagedResource[
+	*otcv1alpha1.RecordSet,
+	*provider.RecordSetInfo,
+	provider.UpdateRecordSetRequest,
+]{
+	ControllerName: "recordset",
+	Name:           "record set",
+	FinalizerName:  recordSetFinalizerName,
+	RequeueDelay:   recordSetRequeueDelay,
+
+	New: func() *otcv1alpha1.RecordSet { return &otcv1alpha1.RecordSet{} },
+
+	Resolve: func(
+		ctx context.Context,
+		resolver *DependencyResolver,
+		recordSet *otcv1alpha1.RecordSet,
+	) error {
+		resolved, err := resolver.ResolveRecordSetDependencies(ctx, recordSet.Spec)
+		if err != nil {
+			return err
+		}
+
+		recordSet.Status.ResolvedDependencies = resolved
+		return nil
+	},
+
+	// The addresses of referenced resources can change. The zone is
+	// immutable and was resolved on creation.
+	Refresh: func(
+		ctx context.Context,
+		resolver *DependencyResolver,
+		recordSet *otcv1alpha1.RecordSet,
+	) error {
+		records, err := resolver.ResolveRecordSetRecords(ctx, recordSet.Spec)
+		if err != nil {
+			return err
+		}
+
+		recordSet.Status.ResolvedDependencies.Records = records
+		return nil
+	},
+
+	Create: func(
+		ctx context.Context,
+		p provider.Provider,
+		recordSet *otcv1alpha1.RecordSet,
+	) (string, error) {
+		resp, err := p.CreateRecordSet(ctx, provider.CreateRecordSetRequest{
+			Name:        recordSet.Spec.Name,
+			Description: recordSet.Spec.Description,
+			Type:        string(recordSet.Spec.Type),
+			TTL:         recordSet.Spec.TTL,
+			Records:     recordSet.Status.ResolvedDependencies.Records,
+			ZoneID:      recordSet.Status.ResolvedDependencies.DNSZoneID,
+		})
+		return resp.ID, err
+	},
+
+	Observe: func(
+		ctx context.Context,
+		p provider.Provider,
+		recordSet *otcv1alpha1.RecordSet,
+	) (*provider.RecordSetInfo, error) {
+		info, err := p.GetRecordSet(
+			ctx,
+			recordSet.Status.ResolvedDependencies.DNSZoneID,
+			recordSet.Status.ExternalID,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		// Report the observed records.
+		recordSet.Status.Records = info.Records
+		return info, nil
+	},
+
+	Diff: func(
+		logger zerolog.Logger,
+		recordSet *otcv1alpha1.RecordSet,
+		info *provider.RecordSetInfo,
+	) (provider.UpdateRecordSetRequest, bool) {
+		var updateReq provider.UpdateRecordSetRequest
+		needsUpdate := false
+
+		lastAppliedSpec := recordSet.Status.LastAppliedSpec
+		if recordSet.Spec.Description != lastAppliedSpec.Description {
+			logger.Info().
+				Str("current", lastAppliedSpec.Description).
+				Str("desired", recordSet.Spec.Description).
+				Msg("Drift detected in description")
+
+			updateReq.Description = recordSet.Spec.Description
+			needsUpdate = true
+		}
+
+		if recordSet.Spec.TTL != info.TTL {
+			logger.Info().
+				Int("current", info.TTL).
+				Int("desired", recordSet.Spec.TTL).
+				Msg("Drift detected in TTL")
+
+			updateReq.TTL = recordSet.Spec.TTL
+			needsUpdate = true
+		}
+
+		// The order of the records is not significant.
+		desiredRecords := slices.Sorted(slices.Values(recordSet.Status.ResolvedDependencies.Records))
+		currentRecords := slices.Sorted(slices.Values(info.Records))
+		if !slices.Equal(desiredRecords, currentRecords) {
+			logger.Info().
+				Strs("current", currentRecords).
+				Strs("desired", desiredRecords).
+				Msg("Drift detected in records")
+
+			updateReq.Records = desiredRecords
+			needsUpdate = true
+		}
+
+		return updateReq, needsUpdate
+	},
+
+	Update: func(
+		ctx context.Context,
+		p provider.Provider,
+		recordSet *otcv1alpha1.RecordSet,
+		req provider.UpdateRecordSetRequest,
+	) error {
+		return p.UpdateRecordSet(
+			ctx,
+			recordSet.Status.ResolvedDependencies.DNSZoneID,
+			recordSet.Status.ExternalID,
+			req,
+		)
+	},
+
+	ConnectionDetails: func(
+		_ *otcv1alpha1.RecordSet,
+		info *provider.RecordSetInfo,
+	) map[string]string {
+		return map[string]string{
+			"externalID": info.ID,
+			"name":       info.Name,
+			"records":    strings.Join(info.Records, ","),
+		}
+	},
+
+	Delete: func(ctx context.Context, p provider.Provider, recordSet *otcv1alpha1.RecordSet) error {
+		return p.DeleteRecordSet(
+			ctx,
+			recordSet.Status.ResolvedDependencies.DNSZoneID,
+			recordSet.Status.ExternalID,
+		)
+	},
+
+	// Watch for changes to PublicIPs and VirtualIPs whose addresses are used
+	// by RecordSets.
+	Watches: []ManagedWatch{
+		{Object: &otcv1alpha1.PublicIP{}, Map: findRecordSetsForPublicIP},
+		{Object: &otcv1alpha1.VirtualIP{}, Map: findRecordSetsForVirtualIP},
+	},
+}
+
+func findRecordSetsForPublicIP(
+	ctx context.Context,
+	c client.Client,
+	publicIP client.Object,
+) ([]reconcile.Request, error) {
+	matches := func(source otcv1alpha1.RecordSetValueSource) bool {
+		return source.PublicIPRef != nil && source.PublicIPRef.Name == publicIP.GetName()
+	}
+	return findRecordSetsForSource(ctx, c, publicIP, matches)
+}
+
+func findRecordSetsForVirtualIP(
+	ctx context.Context,
+	c client.Client,
+	vip client.Object,
+) ([]reconcile.Request, error) {
+	matches := func(source otcv1alpha1.RecordSetValueSource) bool {
+		return source.VirtualIPRef != nil && source.VirtualIPRef.Name == vip.GetName()
+	}
+	return findRecordSetsForSource(ctx, c, vip, matches)
+}
+
+// findRecordSetsForSource returns requests for all RecordSets in the
+// namespace of obj which take a value from a source matching the given
+// function.
+func findRecordSetsForSource(
+	ctx context.Context,
+	c client.Client,
+	obj client.Object,
+	matches func(otcv1alpha1.RecordSetValueSource) bool,
+) ([]reconcile.Request, error) {
+	var recordSets otcv1alpha1.RecordSetList
+	err := c.List(
+		ctx,
+		&recordSets,
+		client.InNamespace(obj.GetNamespace()),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list RecordSets for record source watch: %w", err)
+	}
+
+	requests := make([]reconcile.Request, 0)
+	for _, rs := range recordSets.Items {
+		if slices.ContainsFunc(rs.Spec.RecordsFrom, matches) {
+			requests = append(requests, reconcile.Request{
+				NamespacedName: types.NamespacedName{
+					Name:      rs.Name,
+					Namespace: rs.Namespace,
+				},
+			})
+		}
+	}
+	return requests, nil
+}
@@ -0,0 +1,102 @@
+package controller
+
+import (
+	"slices"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	otcv1alpha1 "github.com/peertech.de/otc-operator/api/v1alpha1"
+)
+
+const (
+	testRecordSetName = "www"
+	testRecordSetID   = "recordset-id"
+)
+
+func newTestRecordSet() *otcv1alpha1.RecordSet {
+	zoneID := testDNSZoneID
+	return &otcv1alpha1.RecordSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       testRecordSetName,
+			Namespace:  testNamespace,
+			Finalizers: []string{recordSetFinalizerName},
+		},
+		Spec: otcv1alpha1.RecordSetSpec{
+			ProviderConfigRef: testProviderConfigRef,
+			Zone:              otcv1alpha1.DNSZoneDependency{DNSZoneID: &zoneID},
+			Name:              "www.example.internal.",
+			Type:              otcv1alpha1.RecordSetA,
+			TTL:               300,
+			Records:           []string{"10.0.0.10", "10.0.0.11"},
+		},
+	}
+}
+
+func TestRecordSetLifecycle(t *testing.T) {
+	p := &fakeProvider{}
+	r := newTestManagedReconciler(t, NewRecordSetReconciler, p, newTestRecordSet())
+
+	// The record set is created in the resolved zone.
+	_, recordSet := reconcileTestManaged(t, r, newTestRecordSet())
+	if len(p.recordSets.created) != 1 || p.recordSets.created[0].ZoneID != testDNSZoneID {
+		t.Fatalf("Expected record set to be created in zone %q, got %+v", testDNSZoneID, p.recordSets.created)
+	}
+	if recordSet.Status.ExternalID != testRecordSetID {
+		t.Fatalf("Expected externalID %q, got %q", testRecordSetID, recordSet.Status.ExternalID)
+	}
+
+	// The record set is not ready while it is created.
+	_, recordSet = reconcileTestManaged(t, r, recordSet)
+	expectCondition(t, recordSet, condReady, metav1.ConditionFalse, reasonProvisioning)
+
+	// Records returned in a different order are no drift.
+	p.recordSets.info.Status = "ACTIVE"
+	slices.Reverse(p.recordSets.info.Records)
+	_, recordSet = reconcileTestManaged(t, r, recordSet)
+	expectCondition(t, recordSet, condReady, metav1.ConditionTrue, reasonProvisioned)
+	if len(p.recordSets.updated) != 0 {
+		t.Fatalf("Expected no update, got %+v", p.recordSets.updated)
+	}
+
+	// Changed records are applied.
+	updateTestManaged(t, r, recordSet, func(recordSet *otcv1alpha1.RecordSet) {
+		recordSet.Spec.Records = []string{"10.0.0.12"}
+	})
+	_, recordSet = reconcileTestManaged(t, r, recordSet)
+	if len(p.recordSets.updated) != 1 || !slices.Equal(p.recordSets.updated[0].Records, []string{"10.0.0.12"}) ||
+		p.recordSets.updated[0].TTL != 0 {
+		t.Fatalf("Expected records to be updated, got %+v", p.recordSets.updated)
+	}
+
+	// A TTL changed out-of-band is reverted.
+	p.recordSets.info.TTL = 60
+	_, recordSet = reconcileTestManaged(t, r, recordSet)
+	if len(p.recordSets.updated) != 2 || p.recordSets.updated[1].TTL != 300 || p.recordSets.updated[1].Records != nil {
+		t.Fatalf("Expected TTL to be reverted, got %+v", p.recordSets.updated)
+	}
+
+	deleteTestManaged(t, r, recordSet)
+	if !slices.Equal(p.recordSets.deleted, []string{testDNSZoneID + "/" + testRecordSetID}) {
+		t.Fatalf("Expected external deletion of %q, got %v", testRecordSetID, p.recordSets.deleted)
+	}
+}
+
+func TestRecordSetNotFound(t *testing.T) {
+	recordSet := newTestRecordSet()
+	recordSet.Status.ExternalID = testRecordSetID
+	recordSet.Status.LastAppliedSpec = recordSet.Spec.DeepCopy()
+	recordSet.Status.ResolvedDependencies.DNSZoneID = testDNSZoneID
+
+	p := &fakeProvider{}
+	r := newTestManagedReconciler(t, NewRecordSetReconciler, p, recordSet)
+
+	// The record set deleted out-of-band is created again.
+	_, recordSet = reconcileTestManaged(t, r, recordSet)
+	expectRecreate(t, recordSet)
+
+	_, recordSet = reconcileTestManaged(t, r, recordSet)
+	if len(p.recordSets.created) != 1 || recordSet.Status.ExternalID != testRecordSetID {
+		t.Fatalf("Expected record set to be recreated, got %+v", p.recordSets.created)
+	}
+}
@@ -0,0 +1,390 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	otcv1alpha1 "github.com/peertech.de/otc-operator/api/v1alpha1"
+)
+
+// referenceIndex is a field index on the IDs a kind resolved for one of its
+// dependencies. The reference checks use it to find the resources which still
+// reference an external resource, without listing all resources of the kind.
+type referenceIndex struct {
+	// dependency is the kind of the referenced resource.
+	dependency string
+	// resource is the human readable plural of the referencing kind.
+	resource string
+	// obj is an empty object of the referencing kind.
+	obj client.Object
+	// newList returns an empty list of the referencing kind.
+	newList func() client.ObjectList
+	// field is the name of the index.
+	field string
+	// extract returns the IDs of the dependency resolved by the object.
+	extract client.IndexerFunc
+}
+
+// newReferenceIndex returns the index of the IDs of the dependency resolved by
+// the kind of obj.
+func newReferenceIndex[O client.Object](
+	dependency string,
+	resource string,
+	obj O,
+	newList func() client.ObjectList,
+	field string,
+	extract func(obj O) []string,
+) referenceIndex {
+	return referenceIndex{
+		dependency: dependency,
+		resource:   resource,
+		obj:        obj,
+		newList:    newList,
+		field:      field,
+		extract: func(o client.Object) []string {
+			typed, ok := o.(O)
+			if !ok {
+				return nil
+			}
+			return slices.DeleteFunc(extract(typed), func(id string) bool { return id == "" })
+		},
+	}
+}
+
+// referenceIndexes lists every dependency edge, i.e. every kind and field
+// which resolves the ID of another kind.
+var referenceIndexes = []referenceIndex{
+	// Network
+	newReferenceIndex("Network", "Subnets",
+		&otcv1alpha1.Subnet{}, func() client.ObjectList { return &otcv1alpha1.SubnetList{} },
+		"status.resolvedDependencies.networkID",
+		func(o *otcv1alpha1.Subnet) []string { return []string{o.Status.ResolvedDependencies.NetworkID} },
+	),
+	newReferenceIndex("Network", "NATGateways",
+		&otcv1alpha1.NATGateway{}, func() client.ObjectList { return &otcv1alpha1.NATGatewayList{} },
+		"status.resolvedDependencies.networkID",
+		func(o *otcv1alpha1.NATGateway) []string { return []string{o.Status.ResolvedDependencies.NetworkID} },
+	),
+	newReferenceIndex("Network", "VPNGateways",
+		&otcv1alpha1.VPNGateway{}, func() client.ObjectList { return &otcv1alpha1.VPNGatewayList{} },
+		"status.resolvedDependencies.networkID",
+		func(o *otcv1alpha1.VPNGateway) []string { return []string{o.Status.ResolvedDependencies.NetworkID} },
+	),
+	newReferenceIndex("Network", "Clusters",
+		&otcv1alpha1.Cluster{}, func() client.ObjectList { return &otcv1alpha1.ClusterList{} },
+		"status.resolvedDependencies.networkID",
+		func(o *otcv1alpha1.Cluster) []string { return []string{o.Status.ResolvedDependencies.NetworkID} },
+	),
+	newReferenceIndex("Network", "DatabaseInstances",
+		&otcv1alpha1.DatabaseInstance{}, func() client.ObjectList { return &otcv1alpha1.DatabaseInstanceList{} },
+		"status.resolvedDependencies.networkID",
+		func(o *otcv1alpha1.DatabaseInstance) []string {
+			return []string{o.Status.ResolvedDependencies.NetworkID}
+		},
+	),
+	newReferenceIndex("Network", "DNSZones",
+		&otcv1alpha1.DNSZone{}, func() client.ObjectList { return &otcv1alpha1.DNSZoneList{} },
+		"status.resolvedDependencies.networkIDs",
+		func(o *otcv1alpha1.DNSZone) []string { return o.Status.ResolvedDependencies.NetworkIDs },
+	),
+
+	// Subnet
+	newReferenceIndex("Subnet", "NATGateways",
+		&otcv1alpha1.NATGateway{}, func() client.ObjectList { return &otcv1alpha1.NATGatewayList{} },
+		"status.resolvedDependencies.subnetID",
+		func(o *otcv1alpha1.NATGateway) []string { return []string{o.Status.ResolvedDependencies.SubnetID} },
+	),
+	newReferenceIndex("Subnet", "SNATRules",
+		&otcv1alpha1.SNATRule{}, func() client.ObjectList { return &otcv1alpha1.SNATRuleList{} },
+		"status.resolvedDependencies.subnetID",
+		func(o *otcv1alpha1.SNATRule) []string { return []string{o.Status.ResolvedDependencies.SubnetID} },
+	),
+	newReferenceIndex("Subnet", "VirtualIPs",
+		&otcv1alpha1.VirtualIP{}, func() client.ObjectList { return &otcv1alpha1.VirtualIPList{} },
+		"status.resolvedDependencies.subnetID",
+		func(o *otcv1alpha1.VirtualIP) []string { return []string{o.Status.ResolvedDependencies.SubnetID} },
+	),
+	newReferenceIndex("Subnet", "Instances",
+		&otcv1alpha1.Instance{}, func() client.ObjectList { return &otcv1alpha1.InstanceList{} },
+		"status.resolvedDependencies.subnetIDs",
+		func(o *otcv1alpha1.Instance) []string { return o.Status.ResolvedDependencies.SubnetIDs },
+	),
+	newReferenceIndex("Subnet", "VPNGateways",
+		&otcv1alpha1.VPNGateway{}, func() client.ObjectList { return &otcv1alpha1.VPNGatewayList{} },
+		"status.resolvedDependencies.subnetID",
+		func(o *otcv1alpha1.VPNGateway) []string { return []string{o.Status.ResolvedDependencies.SubnetID} },
+	),
+	newReferenceIndex("Subnet", "Clusters",
+		&otcv1alpha1.Cluster{}, func() client.ObjectList { return &otcv1alpha1.ClusterList{} },
+		"status.resolvedDependencies.subnetID",
+		func(o *otcv1alpha1.Cluster) []string { return []string{o.Status.ResolvedDependencies.SubnetID} },
+	),
+	newReferenceIndex("Subnet", "NodePools",
+		&otcv1alpha1.NodePool{}, func() client.ObjectList { return &otcv1alpha1.NodePoolList{} },
+		"status.resolvedDependencies.subnetID",
+		func(o *otcv1alpha1.NodePool) []string { return []string{o.Status.ResolvedDependencies.SubnetID} },
+	),
+	newReferenceIndex("Subnet", "DatabaseInstances",
+		&otcv1alpha1.DatabaseInstance{}, func() client.ObjectList { return &otcv1alpha1.DatabaseInstanceList{} },
+		"status.resolvedDependencies.subnetID",
+		func(o *otcv1alpha1.DatabaseInstance) []string {
+			return []string{o.Status.ResolvedDependencies.SubnetID}
+		},
+	),
+
+	// SecurityGroup
+	newReferenceIndex("SecurityGroup", "SecurityGroupRules",
+		&otcv1alpha1.SecurityGroupRule{}, func() client.ObjectList { return &otcv1alpha1.SecurityGroupRuleList{} },
+		"status.resolvedDependencies.securityGroupID",
+		func(o *otcv1alpha1.SecurityGroupRule) []string {
+			return []string{o.Status.ResolvedDependencies.SecurityGroupID}
+		},
+	),
+	newReferenceIndex("SecurityGroup", "Instances",
+		&otcv1alpha1.Instance{}, func() client.ObjectList { return &otcv1alpha1.InstanceList{} },
+		"status.resolvedDependencies.securityGroupIDs",
+		func(o *otcv1alpha1.Instance) []string { return o.Status.ResolvedDependencies.SecurityGroupIDs },
+	),
+	newReferenceIndex("SecurityGroup", "Clusters",
+		&otcv1alpha1.Cluster{}, func() client.ObjectList { return &otcv1alpha1.ClusterList{} },
+		"status.resolvedDependencies.securityGroupID",
+		func(o *otcv1alpha1.Cluster) []string { return []string{o.Status.ResolvedDependencies.SecurityGroupID} },
+	),
+	newReferenceIndex("SecurityGroup", "NodePools",
+		&otcv1alpha1.NodePool{}, func() client.ObjectList { return &otcv1alpha1.NodePoolList{} },
+		"status.resolvedDependencies.securityGroupIDs",
+		func(o *otcv1alpha1.NodePool) []string { return o.Status.ResolvedDependencies.SecurityGroupIDs },
+	),
+	newReferenceIndex("SecurityGroup", "DatabaseInstances",
+		&otcv1alpha1.DatabaseInstance{}, func() client.ObjectList { return &otcv1alpha1.DatabaseInstanceList{} },
+		"status.resolvedDependencies.securityGroupID",
+		func(o *otcv1alpha1.DatabaseInstance) []string {
+			return []string{o.Status.ResolvedDependencies.SecurityGroupID}
+		},
+	),
+
+	// NATGateway
+	newReferenceIndex("NATGateway", "SNATRules",
+		&otcv1alpha1.SNATRule{}, func() client.ObjectList { return &otcv1alpha1.SNATRuleList{} },
+		"status.resolvedDependencies.natGatewayID",
+		func(o *otcv1alpha1.SNATRule) []string { return []string{o.Status.ResolvedDependencies.NATGatewayID} },
+	),
+	newReferenceIndex("NATGateway", "AlarmRules",
+		&otcv1alpha1.AlarmRule{}, func() client.ObjectList { return &otcv1alpha1.AlarmRuleList{} },
+		"status.resolvedDependencies.natGatewayID",
+		func(o *otcv1alpha1.AlarmRule) []string { return []string{o.Status.ResolvedDependencies.NATGatewayID} },
+	),
+
+	// PublicIP
+	newReferenceIndex("PublicIP", "SNATRules",
+		&otcv1alpha1.SNATRule{}, func() client.ObjectList { return &otcv1alpha1.SNATRuleList{} },
+		"status.resolvedDependencies.publicIPID",
+		func(o *otcv1alpha1.SNATRule) []string { return []string{o.Status.ResolvedDependencies.PublicIPID} },
+	),
+	newReferenceIndex("PublicIP", "Instances",
+		&otcv1alpha1.Instance{}, func() client.ObjectList { return &otcv1alpha1.InstanceList{} },
+		"status.resolvedDependencies.publicIPID",
+		func(o *otcv1alpha1.Instance) []string { return []string{o.Status.ResolvedDependencies.PublicIPID} },
+	),
+	newReferenceIndex("PublicIP", "VPNGateways",
+		&otcv1alpha1.VPNGateway{}, func() client.ObjectList { return &otcv1alpha1.VPNGatewayList{} },
+		"status.resolvedDependencies.publicIPIDs",
+		func(o *otcv1alpha1.VPNGateway) []string {
+			resolved := o.Status.ResolvedDependencies
+			return []string{resolved.PrimaryPublicIPID, resolved.SecondaryPublicIPID}
+		},
+	),
+	newReferenceIndex("PublicIP", "AlarmRules",
+		&otcv1alpha1.AlarmRule{}, func() client.ObjectList { return &otcv1alpha1.AlarmRuleList{} },
+		"status.resolvedDependencies.publicIPID",
+		func(o *otcv1alpha1.AlarmRule) []string { return []string{o.Status.ResolvedDependencies.PublicIPID} },
+	),
+
+	// Bandwidth
+	newReferenceIndex("Bandwidth", "PublicIPs",
+		&otcv1alpha1.PublicIP{}, func() client.ObjectList { return &otcv1alpha1.PublicIPList{} },
+		"status.resolvedDependencies.bandwidthID",
+		func(o *otcv1alpha1.PublicIP) []string { return []string{o.Status.ResolvedDependencies.BandwidthID} },
+	),
+
+	// Instance
+	newReferenceIndex("Instance", "PublicIPs",
+		&otcv1alpha1.PublicIP{}, func() client.ObjectList { return &otcv1alpha1.PublicIPList{} },
+		"status.resolvedDependencies.instanceID",
+		func(o *otcv1alpha1.PublicIP) []string { return []string{o.Status.ResolvedDependencies.InstanceID} },
+	),
+	newReferenceIndex("Instance", "Volumes",
+		&otcv1alpha1.Volume{}, func() client.ObjectList { return &otcv1alpha1.VolumeList{} },
+		"status.instanceIDs",
+		func(o *otcv1alpha1.Volume) []string {
+			return []string{o.Status.ResolvedDependencies.InstanceID, o.Status.AttachedInstanceID}
+		},
+	),
+	newReferenceIndex("Instance", "AlarmRules",
+		&otcv1alpha1.AlarmRule{}, func() client.ObjectList { return &otcv1alpha1.AlarmRuleList{} },
+		"status.resolvedDependencies.instanceID",
+		func(o *otcv1alpha1.AlarmRule) []string { return []string{o.Status.ResolvedDependencies.InstanceID} },
+	),
+
+	// VirtualIP
+	newReferenceIndex("VirtualIP", "PublicIPs",
+		&otcv1alpha1.PublicIP{}, func() client.ObjectList { return &otcv1alpha1.PublicIPList{} },
+		"status.resolvedDependencies.portID",
+		func(o *otcv1alpha1.PublicIP) []string { return []string{o.Status.ResolvedDependencies.PortID} },
+	),
+
+	// KeyPair
+	newReferenceIndex("KeyPair", "Instances",
+		&otcv1alpha1.Instance{}, func() client.ObjectList { return &otcv1alpha1.InstanceList{} },
+		"status.resolvedDependencies.keyPairName",
+		func(o *otcv1alpha1.Instance) []string { return []string{o.Status.ResolvedDependencies.KeyPairName} },
+	),
+	newReferenceIndex("KeyPair", "NodePools",
+		&otcv1alpha1.NodePool{}, func() client.ObjectList { return &otcv1alpha1.NodePoolList{} },
+		"status.resolvedDependencies.keyPairName",
+		func(o *otcv1alpha1.NodePool) []string { return []string{o.Status.ResolvedDependencies.KeyPairName} },
+	),
+
+	// DNSZone
+	newReferenceIndex("DNSZone", "RecordSets",
+		&otcv1alpha1.RecordSet{}, func() client.ObjectList { return &otcv1alpha1.RecordSetList{} },
+		"status.resolvedDependencies.dnsZoneID",
+		func(o *otcv1alpha1.RecordSet) []string { return []string{o.Status.ResolvedDependencies.DNSZoneID} },
+	),
+
+	// VPNGateway
+	newReferenceIndex("VPNGateway", "VPNConnections",
+		&otcv1alpha1.VPNConnection{}, func() client.ObjectList { return &otcv1alpha1.VPNConnectionList{} },
+		"status.resolvedDependencies.vpnGatewayID",
+		func(o *otcv1alpha1.VPNConnection) []string {
+			return []string{o.Status.ResolvedDependencies.VPNGatewayID}
+		},
+	),
+
+	// CustomerGateway
+	newReferenceIndex("CustomerGateway", "VPNConnections",
+		&otcv1alpha1.VPNConnection{}, func() client.ObjectList { return &otcv1alpha1.VPNConnectionList{} },
+		"status.resolvedDependencies.customerGatewayID",
+		func(o *otcv1alpha1.VPNConnection) []string {
+			return []string{o.Status.ResolvedDependencies.CustomerGatewayID}
+		},
+	),
+
+	// Cluster
+	newReferenceIndex("Cluster", "NodePools",
+		&otcv1alpha1.NodePool{}, func() client.ObjectList { return &otcv1alpha1.NodePoolList{} },
+		"status.resolvedDependencies.clusterID",
+		func(o *otcv1alpha1.NodePool) []string { return []string{o.Status.ResolvedDependencies.ClusterID} },
+	),
+
+	// KMSKey
+	newReferenceIndex("KMSKey", "Volumes",
+		&otcv1alpha1.Volume{}, func() client.ObjectList { return &otcv1alpha1.VolumeList{} },
+		"status.resolvedDependencies.kmsKeyID",
+		func(o *otcv1alpha1.Volume) []string { return []string{o.Status.ResolvedDependencies.KMSKeyID} },
+	),
+	newReferenceIndex("KMSKey", "Buckets",
+		&otcv1alpha1.Bucket{}, func() client.ObjectList { return &otcv1alpha1.BucketList{} },
+		"status.resolvedDependencies.kmsKeyID",
+		func(o *otcv1alpha1.Bucket) []string { return []string{o.Status.ResolvedDependencies.KMSKeyID} },
+	),
+	newReferenceIndex("KMSKey", "DatabaseInstances",
+		&otcv1alpha1.DatabaseInstance{}, func() client.ObjectList { return &otcv1alpha1.DatabaseInstanceList{} },
+		"status.resolvedDependencies.kmsKeyID",
+		func(o *otcv1alpha1.DatabaseInstance) []string {
+			return []string{o.Status.ResolvedDependencies.KMSKeyID}
+		},
+	),
+
+	// Topic
+	newReferenceIndex("Topic", "Subscriptions",
+		&otcv1alpha1.Subscription{}, func() client.ObjectList { return &otcv1alpha1.SubscriptionList{} },
+		"status.resolvedDependencies.topicURN",
+		func(o *otcv1alpha1.Subscription) []string { return []string{o.Status.ResolvedDependencies.TopicURN} },
+	),
+	newReferenceIndex("Topic", "AlarmRules",
+		&otcv1alpha1.AlarmRule{}, func() client.ObjectList { return &otcv1alpha1.AlarmRuleList{} },
+		"status.resolvedDependencies.topicURNs",
+		func(o *otcv1alpha1.AlarmRule) []string {
+			resolved := o.Status.ResolvedDependencies
+			return slices.Concat(resolved.AlarmTopicURNs, resolved.OKTopicURNs)
+		},
+	),
+}
+
+// SetupReferenceIndexes registers the field indexes used by the reference
+// checks.
+func SetupReferenceIndexes(ctx context.Context, indexer client.FieldIndexer) error {
+	for _, index := range referenceIndexes {
+		if err := indexer.IndexField(ctx, index.obj, index.field, index.extract); err != nil {
+			return fmt.Errorf("failed to index %s by %s: %w", index.resource, index.field, err)
+		}
+	}
+	return nil
+}
+
+// ReferenceChecksFor returns the reference checks of all kinds which can
+// depend on the kind.
+func ReferenceChecksFor(kind string) []ReferenceCheck {
+	var checks []ReferenceCheck
+	for _, index := range referenceIndexes {
+		if index.dependency == kind {
+			checks = append(checks, IndexedReferenceCheck{index: index})
+		}
+	}
+	return checks
+}
+
+// IndexedReferenceCheck finds the resources of a kind which resolved the
+// external ID of a dependency, using the field index of the edge.
+type IndexedReferenceCheck struct {
+	index referenceIndex
+}
+
+func (chk IndexedReferenceCheck) Resource() string { return chk.index.resource }
+
+func (chk IndexedReferenceCheck) Check(
+	ctx context.Context,
+	c client.Client,
+	namespace, externalID string,
+) ([]string, error) {
+	opts := []client.ListOption{client.MatchingFields{chk.index.field: externalID}}
+	// Only some kinds can be referenced from other namespaces.
+	if !isCrossNamespaceKind(chk.index.dependency) {
+		opts = append(opts, client.InNamespace(namespace))
+	}
+
+	list := chk.index.newList()
+	if err := c.List(ctx, list, opts...); err != nil {
+		return nil, fmt.Errorf("list %s: %w", chk.index.resource, err)
+	}
+	items, err := meta.ExtractList(list)
+	if err != nil {
+		return nil, err
+	}
+
+	var refs []string
+	for _, item := range items {
+		if obj, ok := item.(client.Object); ok {
+			refs = append(refs, referencerName(namespace, obj))
+		}
+	}
+	return refs, nil
+}
+
+// isCrossNamespaceKind returns whether resources of the kind can be
+// referenced from other namespaces with a ReferenceGrant.
+func isCrossNamespaceKind(kind string) bool {
+	switch otcv1alpha1.ReferenceGrantKind(kind) {
+	case otcv1alpha1.ReferenceGrantKindNetwork,
+		otcv1alpha1.ReferenceGrantKindSubnet,
+		otcv1alpha1.ReferenceGrantKindSecurityGroup:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,155 @@
+package controller
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	otcv1alpha1 "github.com/peertech.de/otc-operator/api/v1alpha1"
+)
+
+// newIndexedClientBuilder returns a fake client builder with the test scheme
+// and the field indexes of the reference checks.
+func newIndexedClientBuilder(t *testing.T) *fake.ClientBuilder {
+	t.Helper()
+
+	b := fake.NewClientBuilder().WithScheme(newTestScheme(t))
+	for _, index := range referenceIndexes {
+		b = b.WithIndex(index.obj, index.field, index.extract)
+	}
+	return b
+}
+
+// findReferenceCheck returns the check of the resources of a kind referencing
+// the dependency.
+func findReferenceCheck(t *testing.T, dependency, resource string) ReferenceCheck {
+	t.Helper()
+
+	for _, chk := range ReferenceChecksFor(dependency) {
+		if chk.Resource() == resource {
+			return chk
+		}
+	}
+	t.Fatalf("Expected a check of %s referencing %s", resource, dependency)
+	return nil
+}
+
+func newTestReferencingSNATRule(namespace string) *otcv1alpha1.SNATRule {
+	return &otcv1alpha1.SNATRule{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "snat",
+			Namespace: namespace,
+		},
+		Status: otcv1alpha1.SNATRuleStatus{
+			ResolvedDependencies: otcv1alpha1.SNATRuleDependenciesResolved{
+				NATGatewayID: "nat-id",
+				SubnetID:     "subnet-id",
+				PublicIPID:   "eip-id",
+			},
+		},
+	}
+}
+
+func TestReferenceChecksForSNATRule(t *testing.T) {
+	c := newIndexedClientBuilder(t).
+		WithObjects(newTestReferencingSNATRule(testNamespace)).
+		Build()
+
+	tests := []struct {
+		dependency string
+		externalID string
+	}{
+		{"NATGateway", "nat-id"},
+		{"Subnet", "subnet-id"},
+		{"PublicIP", "eip-id"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.dependency, func(t *testing.T) {
+			chk := findReferenceCheck(t, tt.dependency, "SNATRules")
+
+			refs, err := chk.Check(context.Background(), c, testNamespace, tt.externalID)
+			if err != nil {
+				t.Fatalf("Expected no error, got %s", err)
+			}
+			if !slices.Equal(refs, []string{"snat"}) {
+				t.Fatalf("Expected reference by snat, got %v", refs)
+			}
+
+			refs, err = chk.Check(context.Background(), c, testNamespace, "other-id")
+			if err != nil {
+				t.Fatalf("Expected no error, got %s", err)
+			}
+			if len(refs) != 0 {
+				t.Fatalf("Expected no references, got %v", refs)
+			}
+		})
+	}
+}
+
+func TestReferenceCheckNamespaced(t *testing.T) {
+	c := newIndexedClientBuilder(t).
+		WithObjects(newTestReferencingSNATRule(teamNamespace)).
+		Build()
+
+	// Public IPs can not be referenced from other namespaces.
+	refs, err := findReferenceCheck(t, "PublicIP", "SNATRules").Check(
+		context.Background(),
+		c,
+		platformNamespace,
+		"eip-id",
+	)
+	if err != nil {
+		t.Fatalf("Expected no error, got %s", err)
+	}
+	if len(refs) != 0 {
+		t.Fatalf("Expected no references, got %v", refs)
+	}
+
+	// Subnets can.
+	refs, err = findReferenceCheck(t, "Subnet", "SNATRules").Check(
+		context.Background(),
+		c,
+		platformNamespace,
+		"subnet-id",
+	)
+	if err != nil {
+		t.Fatalf("Expected no error, got %s", err)
+	}
+	if !slices.Equal(refs, []string{"team/snat"}) {
+		t.Fatalf("Expected reference by team/snat, got %v", refs)
+	}
+}
+
+func TestResolveNATGatewayByRef(t *testing.T) {
+	natGateway := &otcv1alpha1.NATGateway{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "nat",
+			Namespace: testNamespace,
+		},
+		Status: otcv1alpha1.NATGatewayStatus{
+			ExternalID: "nat-id",
+			Conditions: readyCondition(true, reasonReady, ""),
+		},
+	}
+	c := fake.NewClientBuilder().
+		WithScheme(newTestScheme(t)).
+		WithObjects(natGateway).
+		Build()
+
+	id, err := NewDependencyResolver(c, testNamespace).ResolveNATGateway(
+		context.Background(),
+		otcv1alpha1.NATGatewayDependency{
+			NATGatewayRef: &corev1.LocalObjectReference{Name: "nat"},
+		},
+	)
+	if err != nil {
+		t.Fatalf("Expected no error, got %s", err)
+	}
+	if id != "nat-id" {
+		t.Fatalf("Expected NAT gateway ID %q, got %q", "nat-id", id)
+	}
+}
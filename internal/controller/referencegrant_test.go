@@ -0,0 +1,152 @@
+package controller
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	otcv1alpha1 "github.com/peertech.de/otc-operator/api/v1alpha1"
+)
+
+const (
+	platformNamespace = "platform"
+	teamNamespace     = "team"
+	sharedNetworkID   = "shared-network-id"
+)
+
+// newSharedNetwork returns a ready Network in the platform namespace.
+func newSharedNetwork() *otcv1alpha1.Network {
+	return &otcv1alpha1.Network{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "shared",
+			Namespace: platformNamespace,
+		},
+		Status: otcv1alpha1.NetworkStatus{
+			ExternalID: sharedNetworkID,
+			Conditions: []metav1.Condition{{
+				Type:               condReady,
+				Status:             metav1.ConditionTrue,
+				Reason:             reasonReady,
+				LastTransitionTime: metav1.Now(),
+			}},
+		},
+	}
+}
+
+func newReferenceGrant(to otcv1alpha1.ReferenceGrantTo) *otcv1alpha1.ReferenceGrant {
+	return &otcv1alpha1.ReferenceGrant{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "team",
+			Namespace: platformNamespace,
+		},
+		Spec: otcv1alpha1.ReferenceGrantSpec{
+			From: []otcv1alpha1.ReferenceGrantFrom{{Namespace: teamNamespace}},
+			To:   []otcv1alpha1.ReferenceGrantTo{to},
+		},
+	}
+}
+
+func resolveSharedNetwork(t *testing.T, objs ...client.Object) (string, error) {
+	t.Helper()
+
+	c := fake.NewClientBuilder().
+		WithScheme(newTestScheme(t)).
+		WithObjects(objs...).
+		Build()
+
+	return NewDependencyResolver(c, teamNamespace).ResolveNetwork(
+		context.Background(),
+		otcv1alpha1.NetworkDependency{
+			NetworkRef: &otcv1alpha1.NamespacedObjectReference{
+				Name:      "shared",
+				Namespace: platformNamespace,
+			},
+		},
+	)
+}
+
+func TestResolveNetworkWithoutReferenceGrant(t *testing.T) {
+	_, err := resolveSharedNetwork(t, newSharedNetwork())
+
+	if err == nil || !strings.Contains(err.Error(), "ReferenceGrant") {
+		t.Fatalf("Expected missing ReferenceGrant error, got %v", err)
+	}
+}
+
+func TestResolveNetworkWithReferenceGrant(t *testing.T) {
+	id, err := resolveSharedNetwork(
+		t,
+		newSharedNetwork(),
+		newReferenceGrant(otcv1alpha1.ReferenceGrantTo{Kind: otcv1alpha1.ReferenceGrantKindNetwork}),
+	)
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %s", err)
+	}
+	if id != sharedNetworkID {
+		t.Fatalf("Expected network ID %q, got %q", sharedNetworkID, id)
+	}
+}
+
+func TestResolveNetworkWithReferenceGrantForOtherName(t *testing.T) {
+	_, err := resolveSharedNetwork(
+		t,
+		newSharedNetwork(),
+		newReferenceGrant(otcv1alpha1.ReferenceGrantTo{
+			Kind: otcv1alpha1.ReferenceGrantKindNetwork,
+			Name: "other",
+		}),
+	)
+
+	if err == nil {
+		t.Fatal("Expected missing ReferenceGrant error, got none")
+	}
+}
+
+func TestResolveNetworkWithReferenceGrantForOtherKind(t *testing.T) {
+	_, err := resolveSharedNetwork(
+		t,
+		newSharedNetwork(),
+		newReferenceGrant(otcv1alpha1.ReferenceGrantTo{Kind: otcv1alpha1.ReferenceGrantKindSubnet}),
+	)
+
+	if err == nil {
+		t.Fatal("Expected missing ReferenceGrant error, got none")
+	}
+}
+
+func TestReferenceCheckAcrossNamespaces(t *testing.T) {
+	subnet := &otcv1alpha1.Subnet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "subnet",
+			Namespace: teamNamespace,
+		},
+		Status: otcv1alpha1.SubnetStatus{
+			ResolvedDependencies: otcv1alpha1.SubnetDependenciesResolved{
+				NetworkID: sharedNetworkID,
+			},
+		},
+	}
+	c := newIndexedClientBuilder(t).
+		WithObjects(newSharedNetwork(), subnet).
+		Build()
+
+	refs, err := findReferenceCheck(t, "Network", "Subnets").Check(
+		context.Background(),
+		c,
+		platformNamespace,
+		sharedNetworkID,
+	)
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %s", err)
+	}
+	if !slices.Equal(refs, []string{"team/subnet"}) {
+		t.Fatalf("Expected reference by team/subnet, got %v", refs)
+	}
+}
@@ -6,11 +6,6 @@ import (
 	"time"
 
 	"github.com/rs/zerolog"
-
-	apierrors "k8s.io/apimachinery/pkg/api/errors"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/runtime"
-	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	otcv1alpha1 "github.com/peertech.de/otc-operator/api/v1alpha1"
@@ -22,27 +17,20 @@ const (
 	securityGroupRuleRequeueDelay  = 30 * time.Second
 )
 
+// SecurityGroupRuleReconciler reconciles a SecurityGroupRule object
+type SecurityGroupRuleReconciler = ManagedReconciler[
+	*otcv1alpha1.SecurityGroupRule,
+	*provider.SecurityGroupRuleInfo,
+	provider.UpdateSecurityGroupRuleRequest,
+]
+
 func NewSecurityGroupRuleReconciler(
 	c client.Client,
-	scheme *runtime.Scheme,
 	logger zerolog.Logger,
 	providers *ProviderCache,
+	dependencies *DependencyGraphCache,
 ) *SecurityGroupRuleReconciler {
-	return &SecurityGroupRuleReconciler{
-		Client:    c,
-		Scheme:    scheme,
-		logger:    logger.With().Str("controller", "security-group-rule").Logger(),
-		providers: providers,
-	}
-}
-
-// SecurityGroupRuleReconciler reconciles a SecurityGroupRule object
-type SecurityGroupRuleReconciler struct {
-	client.Client
-	Scheme *runtime.Scheme
-
-	logger    zerolog.Logger
-	providers *ProviderCache
+	return NewManagedReconciler(c, logger, providers, dependencies, securityGroupRuleResource)
 }
 
 // +kubebuilder:rbac:groups=otc.peertech.de,resources=securitygrouprules,verbs=get;list;watch;create;update;patch;delete
@@ -50,370 +38,91 @@ type SecurityGroupRuleReconciler struct {
 // +kubebuilder:rbac:groups=otc.peertech.de,resources=securitygrouprules/finalizers,verbs=update
 // +kubebuilder:rbac:groups=otc.peertech.de,resources=securitygroups,verbs=get;list;watch
 // +kubebuilder:rbac:groups=otc.peertech.de,resources=providerconfigs,verbs=get;list;watch
-// +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
-
-func (r *SecurityGroupRuleReconciler) Reconcile(
-	ctx context.Context,
-	req ctrl.Request,
-) (ctrl.Result, error) {
-	scopedLogger := r.logger.With().
-		Str("op", "Reconcile").
-		Str("security-group-rule", req.NamespacedName.Name).
-		Str("namespace", req.NamespacedName.Namespace).
-		Logger()
-
-	var securityGroupRule otcv1alpha1.SecurityGroupRule
-	if err := r.Get(ctx, req.NamespacedName, &securityGroupRule); err != nil {
-		if apierrors.IsNotFound(err) {
-			return ctrl.Result{}, nil
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;create;update;patch
+// +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch;create;update;patch
+
+// securityGroupRuleResource can not update rules, a drifted rule is replaced
+// instead.
+var securityGroupRuleResource = ManagedResource[
+	*otcv1alpha1.SecurityGroupRule,
+	*provider.SecurityGroupRuleInfo,
+	provider.UpdateSecurityGroupRuleRequest,
+]{
+	ControllerName: "securitygrouprule",
+	Name:           "security group rule",
+	FinalizerName:  securityGroupRuleFinalizerName,
+	RequeueDelay:   securityGroupRuleRequeueDelay,
+
+	New: func() *otcv1alpha1.SecurityGroupRule { return &otcv1alpha1.SecurityGroupRule{} },
+
+	Resolve: func(
+		ctx context.Context,
+		resolver *DependencyResolver,
+		securityGroupRule *otcv1alpha1.SecurityGroupRule,
+	) error {
+		securityGroupID, err := resolver.ResolveSecurityGroup(ctx, securityGroupRule.Spec.SecurityGroup)
+		if err != nil {
+			return err
 		}
-		scopedLogger.Error().Err(err).Msg("Failed to get resource")
-		return ctrl.Result{}, err
-	}
-
-	rc := &Reconciler{
-		logger:         scopedLogger,
-		client:         r.Client,
-		providers:      r.providers,
-		object:         &securityGroupRule,
-		originalObject: securityGroupRule.DeepCopy(),
-		conditions:     &securityGroupRule.Status.Conditions,
-		generation:     securityGroupRule.Generation,
-		finalizerName:  securityGroupRuleFinalizerName,
-		requeueAfter:   securityGroupRuleRequeueDelay,
-	}
-
-	// Ensure the status is updated.
-	defer rc.UpdateStatus(ctx)
-
-	// Handle deletion.
-	if !securityGroupRule.ObjectMeta.DeletionTimestamp.IsZero() {
-		return r.reconcileDelete(ctx, rc, &securityGroupRule)
-	}
-
-	// Ensure the finalizer is present.
-	if added, result, err := rc.AddFinalizer(ctx); added {
-		return result, err
-	}
-
-	// Check if the referenced ProviderConfig is ready.
-	_, shouldReque, result, err := rc.CheckProviderConfig(
-		ctx,
-		securityGroupRule.Spec.ProviderConfigRef,
-	)
-	if shouldReque {
-		return result, err
-	}
-
-	// Get or create cached provider client.
-	p, _, err := r.providers.GetOrCreate(
-		ctx,
-		securityGroupRule.Spec.ProviderConfigRef,
-		securityGroupRule.Namespace,
-	)
-	if err != nil {
-		rc.SetReconciliationFailed(
-			WithReason(reasonProviderConfigError),
-			WithMessage(err.Error()),
-		)
-		scopedLogger.Error().Err(err).Msg("Failed to get or create provider client")
-		return ctrl.Result{RequeueAfter: securityGroupRuleRequeueDelay}, nil
-	}
-
-	return r.reconcile(ctx, scopedLogger, rc, &securityGroupRule, p)
-}
-
-func (r *SecurityGroupRuleReconciler) reconcile(
-	ctx context.Context,
-	logger zerolog.Logger,
-	rc *Reconciler,
-	securityGroupRule *otcv1alpha1.SecurityGroupRule,
-	p provider.Provider,
-) (ctrl.Result, error) {
-	// If the external resource has no known ID, it needs to be created.
-	if securityGroupRule.Status.ExternalID == "" {
-		return r.reconcileCreate(ctx, logger, rc, securityGroupRule, p)
-	}
-
-	return r.reconcileUpdate(ctx, logger, rc, securityGroupRule, p)
-}
-
-// reconcileCreate handles the logic for creating a new external resource.
-func (r *SecurityGroupRuleReconciler) reconcileCreate(
-	ctx context.Context,
-	logger zerolog.Logger,
-	rc *Reconciler,
-	securityGroupRule *otcv1alpha1.SecurityGroupRule,
-	p provider.Provider,
-) (ctrl.Result, error) {
-	// Resolve dependencies.
-	resolver := NewDependencyResolver(r.Client, securityGroupRule.Namespace)
-	securityGroupID, err := resolver.ResolveSecurityGroup(ctx, securityGroupRule.Spec.SecurityGroup)
-	if err != nil {
-		rc.SetDependenciesNotReady(err.Error())
-		rc.SetNotReady(
-			WithReason(reasonDependenciesNotResolved),
-			WithMessagef("Waiting for dependencies: %v", err),
-		)
-		return ctrl.Result{RequeueAfter: 10 * time.Second}, nil
-	}
-
-	rc.SetDependenciesReady()
-	securityGroupRule.Status.ResolvedDependencies.SecurityGroupID = securityGroupID
-
-	// Create the external resource.
-	logger.Info().Msg("Creating Security Group Rule")
-
-	// Set creating status.
-	rc.SetCreating()
-
-	err = r.createRule(ctx, logger, p, rc, securityGroupRule, securityGroupID)
-	if err != nil {
-		return ctrl.Result{RequeueAfter: securityGroupRuleRequeueDelay}, err
-	}
-
-	logger.Info().
-		Str("external-id", securityGroupRule.Status.ExternalID).
-		Msg("Successfully created Security Group Rule")
-
-	return ctrl.Result{}, nil
-}
-
-func (r *SecurityGroupRuleReconciler) createRule(
-	ctx context.Context,
-	logger zerolog.Logger,
-	p provider.Provider,
-	rc *Reconciler,
-	securityGroupRule *otcv1alpha1.SecurityGroupRule,
-	securityGroupID string,
-) error {
-	createReq := provider.CreateSecurityGroupRuleRequest{
-		Name:            securityGroupRule.GetName(),
-		Description:     securityGroupRule.Spec.Description,
-		Direction:       string(securityGroupRule.Spec.Direction),
-		Protocol:        string(securityGroupRule.Spec.Protocol),
-		EtherType:       string(securityGroupRule.Spec.Ethertype),
-		Multiport:       securityGroupRule.Spec.Multiport,
-		Action:          string(securityGroupRule.Spec.Action),
-		SecurityGroupID: securityGroupID,
-	}
-	if securityGroupRule.Spec.Priority != nil {
-		createReq.Priority = securityGroupRule.Spec.Priority
-	}
-
-	resp, err := p.CreateSecurityGroupRule(ctx, createReq)
-	if err != nil {
-		rc.SetReconciliationFailed(
-			WithReason(reasonProvisioningFailed),
-			WithMessagef("Failed to create resource: %v", err),
-		)
-		logger.Error().Err(err).Msg("Failed to create Security Group Rule")
-		return err
-	}
-
-	// Update status fields.
-	securityGroupRule.Status.ExternalID = resp.ID
-	securityGroupRule.Status.LastAppliedSpec = securityGroupRule.Spec.DeepCopy()
-
-	return nil
-}
-
-// reconcileUpdate handles the logic for an existing external resource. It
-// checks for drift, updates the resource and reports its status.
-func (r *SecurityGroupRuleReconciler) reconcileUpdate(
-	ctx context.Context,
-	logger zerolog.Logger,
-	rc *Reconciler,
-	securityGroupRule *otcv1alpha1.SecurityGroupRule,
-	p provider.Provider,
-) (ctrl.Result, error) {
-	lastAppliedSpec := securityGroupRule.Status.LastAppliedSpec
-	if lastAppliedSpec == nil {
-		logger.Warn().Msg("LastAppliedSpec is not set, establishing baseline from current spec.")
-		securityGroupRule.Status.LastAppliedSpec = securityGroupRule.Spec.DeepCopy()
-		// Requeue to ensure the status update is persisted before proceeding.
-		return ctrl.Result{Requeue: true}, nil
-	}
-
-	// Fetch the external resource.
-	info, err := p.GetSecurityGroupRule(ctx, securityGroupRule.Status.ExternalID)
-	if err != nil {
-		// TODO: this might be to harsh, as the resource could be fully
-		// functional, but the server API is unreachable.
-		rc.SetReconciliationFailed(
-			WithReason(reasonProviderError),
-			WithMessagef("Failed to check existing SecurityGroupRule: %v", err),
-		)
-		logger.Error().Err(err).Msg("Failed to check existing Security Group Rule")
-		return ctrl.Result{RequeueAfter: securityGroupRuleRequeueDelay}, nil
-	}
-
-	// Handle resource being deleted out-of-band. This can happen if the
-	// resource was deleted manually from the provider. We will trigger the
-	// creation logic in the next reconciliation.
-	if info == nil {
-		logger.Warn().
-			Msg("External security group not found by ID, resetting externalID to trigger creation")
-
-		rc.SetNotSynced(
-			WithReason(reasonNotFound),
-			WithMessagef(
-				"External resource with ID %s was not found and will be recreated",
-				securityGroupRule.Status.ExternalID,
-			),
-		)
-		rc.SetNotReady(
-			WithReason(reasonNotFound),
-			WithMessage("Resource needs to be recreated"),
-		)
-
-		// Reset status fields.
-		securityGroupRule.Status.ExternalID = ""
-		securityGroupRule.Status.LastAppliedSpec = nil
-		return ctrl.Result{Requeue: true}, nil
-	}
-
-	logger.Debug().
-		Str("external-id", info.ID).
-		Msg("Found existing security group")
-
-	needsUpdate := r.detectDrift(logger, securityGroupRule)
-	if needsUpdate {
-		return r.handleDrift(ctx, logger, p, rc, securityGroupRule)
-	}
 
-	// Check readiness status.
-	return r.checkReadiness(rc, securityGroupRule, info)
-}
-
-func (r *SecurityGroupRuleReconciler) detectDrift(
-	logger zerolog.Logger,
-	rule *otcv1alpha1.SecurityGroupRule,
-) bool {
-	if rule.Status.LastAppliedSpec == nil {
+		securityGroupRule.Status.ResolvedDependencies.SecurityGroupID = securityGroupID
+		return nil
+	},
+
+	Create: func(
+		ctx context.Context,
+		p provider.Provider,
+		securityGroupRule *otcv1alpha1.SecurityGroupRule,
+	) (string, error) {
+		resp, err := p.CreateSecurityGroupRule(ctx, provider.CreateSecurityGroupRuleRequest{
+			Name:            securityGroupRule.GetName(),
+			Description:     securityGroupRule.Spec.Description,
+			Direction:       string(securityGroupRule.Spec.Direction),
+			Protocol:        string(securityGroupRule.Spec.Protocol),
+			EtherType:       string(securityGroupRule.Spec.Ethertype),
+			Multiport:       securityGroupRule.Spec.Multiport,
+			Action:          string(securityGroupRule.Spec.Action),
+			Priority:        securityGroupRule.Spec.Priority,
+			SecurityGroupID: securityGroupRule.Status.ResolvedDependencies.SecurityGroupID,
+		})
+		return resp.ID, err
+	},
+
+	Observe: func(
+		ctx context.Context,
+		p provider.Provider,
+		securityGroupRule *otcv1alpha1.SecurityGroupRule,
+	) (*provider.SecurityGroupRuleInfo, error) {
+		return p.GetSecurityGroupRule(ctx, securityGroupRule.Status.ExternalID)
+	},
+
+	Replace: func(
+		logger zerolog.Logger,
+		securityGroupRule *otcv1alpha1.SecurityGroupRule,
+		_ *provider.SecurityGroupRuleInfo,
+	) bool {
+		if !reflect.DeepEqual(securityGroupRule.Spec, *securityGroupRule.Status.LastAppliedSpec) {
+			logger.Info().Msg("Drift detected")
+			return true
+		}
 		return false
-	}
-
-	if !reflect.DeepEqual(rule.Spec, *rule.Status.LastAppliedSpec) {
-		logger.Info().Msg("Drift detected")
-		return true
-	}
-
-	return false
-}
-
-// handleDrift applies updates to the drifted resource.
-func (r *SecurityGroupRuleReconciler) handleDrift(
-	ctx context.Context,
-	logger zerolog.Logger,
-	p provider.Provider,
-	rc *Reconciler,
-	securityGroupRule *otcv1alpha1.SecurityGroupRule,
-) (ctrl.Result, error) {
-	logger.Info().Msg("Applying updates to external resource")
-
-	// Set updating status.
-	rc.SetUpdating()
-
-	logger.Info().
-		Str("external-id", securityGroupRule.Status.ExternalID).
-		Msg("Deleting drifted Security Group Rule")
-
-	if err := p.DeleteSecurityGroupRule(ctx, securityGroupRule.Status.ExternalID); err != nil {
-		rc.SetReconciliationFailed(
-			WithReason(reasonUpdateFailed),
-			WithMessagef("Failed to delete drifted resource: %v", err),
-		)
-		logger.Error().Err(err).Msg("Failed to delete resource")
-		return ctrl.Result{RequeueAfter: securityGroupRuleRequeueDelay}, err
-	}
-
-	// Clear the ExternalID. If the subsequent create fails, the next reconcile
-	// loop will treat this as a "Create" scenario.
-	securityGroupRule.Status.ExternalID = ""
-
-	securityGroupID := securityGroupRule.Status.ResolvedDependencies.SecurityGroupID
-	if securityGroupID == "" {
-		rc.SetReconciliationFailed(
-			WithReason(reasonDependenciesNotResolved),
-			WithMessage("Parent Security Group ID missing from status"),
-		)
-
-		// Force a requeue to run through reconcileCreate
-		return ctrl.Result{Requeue: true}, nil
-	}
-
-	logger.Info().Msg("Creatig new Security Group Rule")
-	err := r.createRule(ctx, logger, p, rc, securityGroupRule, securityGroupID)
-	if err != nil {
-		return ctrl.Result{RequeueAfter: securityGroupRuleRequeueDelay}, err
-	}
-
-	logger.Info().
-		Str("external-id", securityGroupRule.Status.ExternalID).
-		Msg("Successfully created Security Group Rule")
-
-	// Requeue immediately to re-check the status after the update.
-	return ctrl.Result{Requeue: true}, nil
-}
-
-// checkReadiness updates the status conditions based on the provider's reported status.
-func (r *SecurityGroupRuleReconciler) checkReadiness(
-	rc *Reconciler,
-	securityGroupRule *otcv1alpha1.SecurityGroupRule,
-	info *provider.SecurityGroupRuleInfo,
-) (ctrl.Result, error) {
-	switch info.State() {
-	case provider.Ready:
-		now := metav1.Now()
-
-		isNewlyProvisioned := securityGroupRule.Status.LastSyncTime == nil
-		securityGroupRule.Status.LastSyncTime = &now
-
-		if isNewlyProvisioned {
-			rc.SetProvisioned()
-		} else {
-			rc.SetSyncedAndReady()
+	},
+
+	ConnectionDetails: func(
+		_ *otcv1alpha1.SecurityGroupRule,
+		info *provider.SecurityGroupRuleInfo,
+	) map[string]string {
+		return map[string]string{
+			"externalID":      info.ID,
+			"securityGroupID": info.SecurityGroupID,
 		}
-		return ctrl.Result{}, nil
-	case provider.Failed:
-		rc.SetReconciliationFailed(
-			WithReason(reasonFailed),
-			WithMessage(info.Message()),
-		)
-		return ctrl.Result{RequeueAfter: securityGroupRuleRequeueDelay}, nil
-	case provider.Provisioning:
-		rc.SetProvisioning(WithMessage(info.Message()))
-		return ctrl.Result{RequeueAfter: securityGroupRuleRequeueDelay}, nil
-	default:
-		rc.SetReconciliationFailed(
-			WithReason(reasonUnknown),
-			WithMessage(info.Message()),
-		)
-		return ctrl.Result{RequeueAfter: securityGroupRuleRequeueDelay}, nil
-	}
-}
-
-func (r *SecurityGroupRuleReconciler) reconcileDelete(
-	ctx context.Context,
-	rc *Reconciler,
-	securityGroupRule *otcv1alpha1.SecurityGroupRule,
-) (ctrl.Result, error) {
-	return rc.Delete(
-		ctx,
-		securityGroupRule.Spec.ProviderConfigRef,
-		securityGroupRule.Spec.OrphanOnDelete,
-		securityGroupRule.Status.ExternalID,
-		func(c context.Context, p provider.Provider) error {
-			return p.DeleteSecurityGroupRule(c, securityGroupRule.Status.ExternalID)
-		},
-	)
-}
-
-// SetupWithManager sets up the controller with the Manager.
-func (r *SecurityGroupRuleReconciler) SetupWithManager(mgr ctrl.Manager) error {
-	return ctrl.NewControllerManagedBy(mgr).
-		For(&otcv1alpha1.SecurityGroupRule{}).
-		Named("securitygrouprule").
-		Complete(r)
+	},
+
+	Delete: func(
+		ctx context.Context,
+		p provider.Provider,
+		securityGroupRule *otcv1alpha1.SecurityGroupRule,
+	) error {
+		return p.DeleteSecurityGroupRule(ctx, securityGroupRule.Status.ExternalID)
+	},
 }
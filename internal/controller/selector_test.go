@@ -0,0 +1,194 @@
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	otcv1alpha1 "github.com/peertech.de/otc-operator/api/v1alpha1"
+)
+
+// newSelectableSubnet returns a private subnet created age ago.
+func newSelectableSubnet(name string, age time.Duration, ready bool) *otcv1alpha1.Subnet {
+	status := metav1.ConditionFalse
+	if ready {
+		status = metav1.ConditionTrue
+	}
+
+	return &otcv1alpha1.Subnet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              name,
+			Namespace:         testNamespace,
+			CreationTimestamp: metav1.NewTime(time.Now().Add(-age).Truncate(time.Second)),
+			Labels: map[string]string{
+				"tier": "private",
+				"az":   "eu-de-01",
+			},
+		},
+		Status: otcv1alpha1.SubnetStatus{
+			ExternalID: name + "-id",
+			Conditions: []metav1.Condition{{
+				Type:               condReady,
+				Status:             status,
+				Reason:             reasonReady,
+				LastTransitionTime: metav1.Now(),
+			}},
+		},
+	}
+}
+
+func resolveSubnetBySelector(
+	t *testing.T,
+	selector *otcv1alpha1.ResourceSelector,
+	preferred []string,
+	objs ...client.Object,
+) (string, error) {
+	t.Helper()
+
+	c := fake.NewClientBuilder().
+		WithScheme(newTestScheme(t)).
+		WithObjects(objs...).
+		Build()
+
+	resolver := NewDependencyResolver(c, testNamespace)
+	resolver.Prefer(preferred...)
+	return resolver.ResolveSubnet(
+		context.Background(),
+		otcv1alpha1.SubnetDependency{SubnetSelector: selector},
+	)
+}
+
+// privateSubnetSelector selects the private subnets in eu-de-01.
+func privateSubnetSelector(policy otcv1alpha1.SelectionPolicy, readyOnly bool) *otcv1alpha1.ResourceSelector {
+	return &otcv1alpha1.ResourceSelector{
+		LabelSelector: metav1.LabelSelector{
+			MatchLabels: map[string]string{"tier": "private"},
+			MatchExpressions: []metav1.LabelSelectorRequirement{{
+				Key:      "az",
+				Operator: metav1.LabelSelectorOpIn,
+				Values:   []string{"eu-de-01"},
+			}},
+		},
+		Policy:    policy,
+		ReadyOnly: readyOnly,
+	}
+}
+
+func TestResolveBySelectorMatchExpressions(t *testing.T) {
+	selector := &otcv1alpha1.ResourceSelector{
+		LabelSelector: metav1.LabelSelector{
+			MatchExpressions: []metav1.LabelSelectorRequirement{{
+				Key:      "tier",
+				Operator: metav1.LabelSelectorOpExists,
+			}},
+		},
+	}
+
+	id, err := resolveSubnetBySelector(t, selector, nil, newSelectableSubnet("a", time.Hour, true))
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %s", err)
+	}
+	if id != "a-id" {
+		t.Fatalf("Expected subnet a-id, got %q", id)
+	}
+}
+
+func TestResolveBySelectorUnique(t *testing.T) {
+	_, err := resolveSubnetBySelector(
+		t,
+		privateSubnetSelector(otcv1alpha1.SelectionPolicyUnique, false),
+		nil,
+		newSelectableSubnet("a", time.Hour, true),
+		newSelectableSubnet("b", 2*time.Hour, true),
+	)
+
+	if err == nil {
+		t.Fatal("Expected error for multiple matches, got none")
+	}
+}
+
+func TestResolveBySelectorPolicy(t *testing.T) {
+	tests := []struct {
+		name      string
+		policy    otcv1alpha1.SelectionPolicy
+		readyOnly bool
+		preferred []string
+		expected  string
+		wantErr   bool
+	}{
+		// The oldest subnet is not ready, so resolving it fails.
+		{name: "oldest", policy: otcv1alpha1.SelectionPolicyOldest, wantErr: true},
+		{name: "newest", policy: otcv1alpha1.SelectionPolicyNewest, expected: "a-id"},
+		{
+			name:      "oldest ready",
+			policy:    otcv1alpha1.SelectionPolicyOldest,
+			readyOnly: true,
+			expected:  "b-id",
+		},
+		{
+			name:      "previously resolved",
+			policy:    otcv1alpha1.SelectionPolicyNewest,
+			preferred: []string{"b-id"},
+			expected:  "b-id",
+		},
+		{
+			name:      "previously resolved not ready",
+			policy:    otcv1alpha1.SelectionPolicyNewest,
+			readyOnly: true,
+			preferred: []string{"c-id"},
+			expected:  "a-id",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			id, err := resolveSubnetBySelector(
+				t,
+				privateSubnetSelector(tt.policy, tt.readyOnly),
+				tt.preferred,
+				newSelectableSubnet("a", time.Hour, true),
+				newSelectableSubnet("b", 2*time.Hour, true),
+				newSelectableSubnet("c", 3*time.Hour, false),
+			)
+
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("Expected error, got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected no error, got %s", err)
+			}
+			if id != tt.expected {
+				t.Fatalf("Expected subnet %q, got %q", tt.expected, id)
+			}
+		})
+	}
+}
+
+func TestResolveBySelectorTieBreak(t *testing.T) {
+	b := newSelectableSubnet("b", time.Hour, true)
+	a := newSelectableSubnet("a", time.Hour, true)
+	a.CreationTimestamp = b.CreationTimestamp
+
+	id, err := resolveSubnetBySelector(
+		t,
+		privateSubnetSelector(otcv1alpha1.SelectionPolicyOldest, false),
+		nil,
+		b,
+		a,
+	)
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %s", err)
+	}
+	if id != "a-id" {
+		t.Fatalf("Expected subnet a-id, got %q", id)
+	}
+}
@@ -0,0 +1,385 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/rs/zerolog"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	otcv1alpha1 "github.com/peertech.de/otc-operator/api/v1alpha1"
+)
+
+const (
+	snatRuleSetRequeueDelay = 30 * time.Second
+)
+
+func NewSNATRuleSetReconciler(
+	c client.Client,
+	logger zerolog.Logger,
+) *SNATRuleSetReconciler {
+	return &SNATRuleSetReconciler{
+		Client: c,
+		logger: logger.With().Str("controller", "snatruleset").Logger(),
+	}
+}
+
+// SNATRuleSetReconciler reconciles a SNATRuleSet object. It creates a
+// SNATRule for every selected subnet, which in turn manages the external
+// SNAT rule.
+type SNATRuleSetReconciler struct {
+	client.Client
+
+	logger   zerolog.Logger
+	recorder record.EventRecorder
+}
+
+// +kubebuilder:rbac:groups=otc.peertech.de,resources=snatrulesets,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=otc.peertech.de,resources=snatrulesets/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=otc.peertech.de,resources=snatrulesets/finalizers,verbs=update
+// +kubebuilder:rbac:groups=otc.peertech.de,resources=snatrules,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=otc.peertech.de,resources=subnets,verbs=get;list;watch
+// +kubebuilder:rbac:groups=otc.peertech.de,resources=providerconfigs,verbs=get;list;watch
+
+func (r *SNATRuleSetReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	scopedLogger := r.logger.With().
+		Str("snatruleset", req.NamespacedName.Name).
+		Str("namespace", req.NamespacedName.Namespace).
+		Logger()
+
+	var snatRuleSet otcv1alpha1.SNATRuleSet
+	if err := r.Get(ctx, req.NamespacedName, &snatRuleSet); err != nil {
+		if apierrors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		scopedLogger.Error().Err(err).Msg("Failed to get resource")
+		return ctrl.Result{}, err
+	}
+
+	rc := &Reconciler{
+		logger:         scopedLogger,
+		client:         r.Client,
+		recorder:       r.recorder,
+		object:         &snatRuleSet,
+		originalObject: snatRuleSet.DeepCopy(),
+		conditions:     &snatRuleSet.Status.Conditions,
+		generation:     snatRuleSet.Generation,
+		requeueAfter:   snatRuleSetRequeueDelay,
+	}
+
+	// Ensure the status is updated.
+	defer rc.UpdateStatus(ctx)
+
+	// Skip paused resources.
+	if paused, result := rc.CheckPaused(ctx, snatRuleSet.Spec.ProviderConfigRef); paused {
+		return result, nil
+	}
+
+	// The SNATRules are owned by the set and removed by the garbage
+	// collector, so there is nothing to do on deletion.
+	if !snatRuleSet.GetDeletionTimestamp().IsZero() {
+		return ctrl.Result{}, nil
+	}
+
+	return r.reconcile(ctx, scopedLogger, rc, &snatRuleSet)
+}
+
+func (r *SNATRuleSetReconciler) reconcile(
+	ctx context.Context,
+	logger zerolog.Logger,
+	rc *Reconciler,
+	snatRuleSet *otcv1alpha1.SNATRuleSet,
+) (ctrl.Result, error) {
+	subnets, err := r.selectSubnets(ctx, snatRuleSet)
+	if err != nil {
+		rc.SetReconciliationFailed(
+			WithReason(reasonDependenciesNotResolved),
+			WithMessagef("Failed to select subnets: %v", err),
+		)
+		logger.Error().Err(err).Msg("Failed to select subnets")
+		return ctrl.Result{RequeueAfter: snatRuleSetRequeueDelay}, nil
+	}
+
+	var snatRules otcv1alpha1.SNATRuleList
+	err = r.List(
+		ctx,
+		&snatRules,
+		client.InNamespace(snatRuleSet.Namespace),
+		client.MatchingLabels{otcv1alpha1.SNATRuleSetLabel: snatRuleSet.Name},
+	)
+	if err != nil {
+		rc.SetReconciliationFailed(
+			WithReason(reasonUnknown),
+			WithMessagef("Failed to list SNAT rules: %v", err),
+		)
+		logger.Error().Err(err).Msg("Failed to list SNAT rules")
+		return ctrl.Result{RequeueAfter: snatRuleSetRequeueDelay}, nil
+	}
+
+	// Keep the SNATRules of selected subnets. The others are deleted, as well
+	// as SNATRules whose public IP was removed from the pool. They are
+	// recreated with a public IP from the pool once they are gone.
+	existing := make(map[string]*otcv1alpha1.SNATRule)
+	deleting := make(map[string]*otcv1alpha1.SNATRule)
+	usage := make([]int, len(snatRuleSet.Spec.PublicIPs))
+	for i := range snatRules.Items {
+		snatRule := &snatRules.Items[i]
+		if !metav1.IsControlledBy(snatRule, snatRuleSet) {
+			continue
+		}
+
+		subnet := snatRuleSubnet(snatRule)
+		index := slices.IndexFunc(snatRuleSet.Spec.PublicIPs, func(dep otcv1alpha1.PublicIPDependency) bool {
+			return equality.Semantic.DeepEqual(dep, snatRule.Spec.PublicIP)
+		})
+		if slices.Contains(subnets, subnet) && index >= 0 {
+			existing[subnet] = snatRule
+			usage[index]++
+			continue
+		}
+
+		deleting[subnet] = snatRule
+		if !snatRule.GetDeletionTimestamp().IsZero() {
+			continue
+		}
+		logger.Info().Str("snatrule", snatRule.Name).Msg("Deleting SNAT rule")
+		if err := r.Delete(ctx, snatRule); client.IgnoreNotFound(err) != nil {
+			rc.SetReconciliationFailed(
+				WithReason(reasonDeletionFailed),
+				WithMessagef("Failed to delete SNAT rule %s: %v", snatRule.Name, err),
+			)
+			logger.Error().Err(err).Str("snatrule", snatRule.Name).Msg("Failed to delete SNAT rule")
+			return ctrl.Result{RequeueAfter: snatRuleSetRequeueDelay}, nil
+		}
+	}
+
+	rules := make([]otcv1alpha1.SNATRuleSetRule, 0, len(subnets))
+	ready := 0
+	for _, subnet := range subnets {
+		snatRule, found := existing[subnet]
+		if pending, ok := deleting[subnet]; ok && !found {
+			// The SNATRule is recreated after the deletion, as the name is
+			// still taken.
+			rules = append(rules, otcv1alpha1.SNATRuleSetRule{
+				Subnet:   subnet,
+				SNATRule: pending.Name,
+			})
+			continue
+		}
+		if !found {
+			// Use the public IP with the fewest SNAT rules.
+			index := 0
+			for i := range usage {
+				if usage[i] < usage[index] {
+					index = i
+				}
+			}
+			usage[index]++
+
+			snatRule, err = r.createSNATRule(ctx, snatRuleSet, subnet, index)
+			if err != nil {
+				rc.SetReconciliationFailed(
+					WithReason(reasonProvisioningFailed),
+					WithMessagef("Failed to create SNAT rule for subnet %s: %v", subnet, err),
+				)
+				logger.Error().Err(err).Str("subnet", subnet).Msg("Failed to create SNAT rule")
+				return ctrl.Result{RequeueAfter: snatRuleSetRequeueDelay}, nil
+			}
+			logger.Info().Str("snatrule", snatRule.Name).Msg("Created SNAT rule")
+		} else if err := r.updateSNATRule(ctx, snatRuleSet, snatRule); err != nil {
+			rc.SetReconciliationFailed(
+				WithReason(reasonUpdateFailed),
+				WithMessagef("Failed to update SNAT rule %s: %v", snatRule.Name, err),
+			)
+			logger.Error().Err(err).Str("snatrule", snatRule.Name).Msg("Failed to update SNAT rule")
+			return ctrl.Result{RequeueAfter: snatRuleSetRequeueDelay}, nil
+		}
+
+		isReady := meta.IsStatusConditionTrue(snatRule.Status.Conditions, condReady)
+		if isReady {
+			ready++
+		}
+		rules = append(rules, otcv1alpha1.SNATRuleSetRule{
+			Subnet:   subnet,
+			SNATRule: snatRule.Name,
+			Ready:    isReady,
+		})
+	}
+	snatRuleSet.Status.Rules = rules
+
+	rc.SetSynced()
+	if ready < len(rules) {
+		// The SNATRules are watched, so their readiness triggers a new
+		// reconciliation.
+		rc.SetNotReady(
+			WithReason(reasonProvisioning),
+			WithMessagef("%d of %d SNAT rules are ready", ready, len(rules)),
+		)
+		return ctrl.Result{}, nil
+	}
+
+	rc.SetReady(WithMessagef("%d SNAT rules are ready", len(rules)))
+	return ctrl.Result{}, nil
+}
+
+// selectSubnets returns the sorted names of the subnets matching the selector.
+// Subnets being deleted are not selected, so their SNAT rules are removed.
+func (r *SNATRuleSetReconciler) selectSubnets(
+	ctx context.Context,
+	snatRuleSet *otcv1alpha1.SNATRuleSet,
+) ([]string, error) {
+	selector, err := metav1.LabelSelectorAsSelector(&snatRuleSet.Spec.SubnetSelector)
+	if err != nil {
+		return nil, fmt.Errorf("invalid subnet selector: %w", err)
+	}
+
+	var subnets otcv1alpha1.SubnetList
+	err = r.List(
+		ctx,
+		&subnets,
+		client.InNamespace(snatRuleSet.Namespace),
+		client.MatchingLabelsSelector{Selector: selector},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list subnets: %w", err)
+	}
+
+	names := make([]string, 0, len(subnets.Items))
+	for _, subnet := range subnets.Items {
+		if subnet.GetDeletionTimestamp().IsZero() {
+			names = append(names, subnet.Name)
+		}
+	}
+	slices.Sort(names)
+	return names, nil
+}
+
+// createSNATRule creates the SNATRule for the subnet using the public IP at
+// the given index of the pool.
+func (r *SNATRuleSetReconciler) createSNATRule(
+	ctx context.Context,
+	snatRuleSet *otcv1alpha1.SNATRuleSet,
+	subnet string,
+	publicIP int,
+) (*otcv1alpha1.SNATRule, error) {
+	snatRule := &otcv1alpha1.SNATRule{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      snatRuleName(snatRuleSet.Name, subnet),
+			Namespace: snatRuleSet.Namespace,
+			Labels: map[string]string{
+				otcv1alpha1.SNATRuleSetLabel: snatRuleSet.Name,
+			},
+		},
+		Spec: otcv1alpha1.SNATRuleSpec{
+			ProviderConfigRef: snatRuleSet.Spec.ProviderConfigRef,
+			NATGateway:        snatRuleSet.Spec.NATGateway,
+			Subnet: otcv1alpha1.SubnetDependency{
+				SubnetRef: &otcv1alpha1.NamespacedObjectReference{Name: subnet},
+			},
+			PublicIP:       snatRuleSet.Spec.PublicIPs[publicIP],
+			Description:    snatRuleSet.Spec.Description,
+			OrphanOnDelete: snatRuleSet.Spec.OrphanOnDelete,
+			NotFoundPolicy: snatRuleSet.Spec.NotFoundPolicy,
+		},
+	}
+	if err := controllerutil.SetControllerReference(snatRuleSet, snatRule, r.Scheme()); err != nil {
+		return nil, err
+	}
+
+	if err := r.Create(ctx, snatRule); err != nil {
+		return nil, err
+	}
+	return snatRule, nil
+}
+
+// updateSNATRule applies the mutable fields of the set to the SNATRule.
+func (r *SNATRuleSetReconciler) updateSNATRule(
+	ctx context.Context,
+	snatRuleSet *otcv1alpha1.SNATRuleSet,
+	snatRule *otcv1alpha1.SNATRule,
+) error {
+	if snatRule.Spec.Description == snatRuleSet.Spec.Description &&
+		snatRule.Spec.OrphanOnDelete == snatRuleSet.Spec.OrphanOnDelete &&
+		snatRule.Spec.NotFoundPolicy == snatRuleSet.Spec.NotFoundPolicy {
+		return nil
+	}
+
+	patch := client.MergeFrom(snatRule.DeepCopy())
+	snatRule.Spec.Description = snatRuleSet.Spec.Description
+	snatRule.Spec.OrphanOnDelete = snatRuleSet.Spec.OrphanOnDelete
+	snatRule.Spec.NotFoundPolicy = snatRuleSet.Spec.NotFoundPolicy
+	return r.Patch(ctx, snatRule, patch)
+}
+
+// snatRuleName returns the name of the SNATRule of a set for the subnet.
+func snatRuleName(snatRuleSet, subnet string) string {
+	return childName(snatRuleSet, subnet)
+}
+
+// snatRuleSubnet returns the name of the subnet a SNATRule of a set was
+// created for.
+func snatRuleSubnet(snatRule *otcv1alpha1.SNATRule) string {
+	if snatRule.Spec.Subnet.SubnetRef == nil {
+		return ""
+	}
+	return snatRule.Spec.Subnet.SubnetRef.Name
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *SNATRuleSetReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	r.recorder = mgr.GetEventRecorderFor("snatruleset")
+
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&otcv1alpha1.SNATRuleSet{}).
+		Owns(&otcv1alpha1.SNATRule{}).
+		// Watch for subnets, so SNAT rules are created and deleted as subnets
+		// come and go or change their labels.
+		Watches(
+			&otcv1alpha1.Subnet{},
+			handler.EnqueueRequestsFromMapFunc(r.findSNATRuleSetsForSubnet),
+		).
+		Named("snatruleset").
+		Complete(r)
+}
+
+// findSNATRuleSetsForSubnet returns requests for all SNATRuleSets in the
+// namespace of the subnet. The labels of the subnet may have changed, so the
+// sets which selected it before are reconciled as well.
+func (r *SNATRuleSetReconciler) findSNATRuleSetsForSubnet(
+	ctx context.Context,
+	subnet client.Object,
+) []reconcile.Request {
+	var snatRuleSets otcv1alpha1.SNATRuleSetList
+	err := r.List(
+		ctx,
+		&snatRuleSets,
+		client.InNamespace(subnet.GetNamespace()),
+	)
+	if err != nil {
+		r.logger.Error().Err(err).Msg("failed to list SNATRuleSets for subnet watch")
+		return nil
+	}
+
+	requests := make([]reconcile.Request, 0, len(snatRuleSets.Items))
+	for _, set := range snatRuleSets.Items {
+		requests = append(requests, reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Name:      set.Name,
+				Namespace: set.Namespace,
+			},
+		})
+	}
+	return requests
+}
@@ -0,0 +1,194 @@
+package controller
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/rs/zerolog"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	otcv1alpha1 "github.com/peertech.de/otc-operator/api/v1alpha1"
+)
+
+const testSNATRuleSetName = "private"
+
+func newTestSNATRuleSet(publicIPs ...string) *otcv1alpha1.SNATRuleSet {
+	pool := make([]otcv1alpha1.PublicIPDependency, 0, len(publicIPs))
+	for _, name := range publicIPs {
+		pool = append(pool, otcv1alpha1.PublicIPDependency{
+			PublicIPRef: &corev1.LocalObjectReference{Name: name},
+		})
+	}
+
+	return &otcv1alpha1.SNATRuleSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      testSNATRuleSetName,
+			Namespace: testNamespace,
+			UID:       "snat-rule-set-uid",
+		},
+		Spec: otcv1alpha1.SNATRuleSetSpec{
+			ProviderConfigRef: otcv1alpha1.ProviderConfigReference{Name: "provider"},
+			NATGateway: otcv1alpha1.NATGatewayDependency{
+				NATGatewayRef: &corev1.LocalObjectReference{Name: "nat"},
+			},
+			SubnetSelector: metav1.LabelSelector{
+				MatchLabels: map[string]string{"tier": "private"},
+			},
+			PublicIPs: pool,
+		},
+	}
+}
+
+func newTestSNATRuleSetSubnet(name, tier string) *otcv1alpha1.Subnet {
+	return &otcv1alpha1.Subnet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: testNamespace,
+			Labels:    map[string]string{"tier": tier},
+		},
+	}
+}
+
+func newTestSNATRuleSetReconciler(t *testing.T, objs ...client.Object) (*SNATRuleSetReconciler, client.Client) {
+	t.Helper()
+
+	c := fake.NewClientBuilder().
+		WithScheme(newTestScheme(t)).
+		WithObjects(objs...).
+		WithStatusSubresource(&otcv1alpha1.SNATRuleSet{}).
+		Build()
+
+	return NewSNATRuleSetReconciler(c, zerolog.Nop()), c
+}
+
+func reconcileTestSNATRuleSet(
+	t *testing.T,
+	r *SNATRuleSetReconciler,
+	c client.Client,
+) (*otcv1alpha1.SNATRuleSet, map[string]otcv1alpha1.SNATRule) {
+	t.Helper()
+
+	key := types.NamespacedName{Name: testSNATRuleSetName, Namespace: testNamespace}
+	if _, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: key}); err != nil {
+		t.Fatalf("Expected no error, got %s", err)
+	}
+
+	var snatRuleSet otcv1alpha1.SNATRuleSet
+	if err := c.Get(context.Background(), key, &snatRuleSet); err != nil {
+		t.Fatal(err)
+	}
+
+	var snatRules otcv1alpha1.SNATRuleList
+	if err := c.List(context.Background(), &snatRules, client.InNamespace(testNamespace)); err != nil {
+		t.Fatal(err)
+	}
+	bySubnet := make(map[string]otcv1alpha1.SNATRule)
+	for _, snatRule := range snatRules.Items {
+		bySubnet[snatRuleSubnet(&snatRule)] = snatRule
+	}
+	return &snatRuleSet, bySubnet
+}
+
+func publicIPName(snatRule otcv1alpha1.SNATRule) string {
+	return snatRule.Spec.PublicIP.PublicIPRef.Name
+}
+
+func TestSNATRuleSetCreatesRulePerSubnet(t *testing.T) {
+	r, c := newTestSNATRuleSetReconciler(
+		t,
+		newTestSNATRuleSet("eip-a", "eip-b"),
+		newTestSNATRuleSetSubnet("a", "private"),
+		newTestSNATRuleSetSubnet("b", "private"),
+		newTestSNATRuleSetSubnet("c", "private"),
+		newTestSNATRuleSetSubnet("public", "public"),
+	)
+
+	snatRuleSet, snatRules := reconcileTestSNATRuleSet(t, r, c)
+
+	if len(snatRules) != 3 {
+		t.Fatalf("Expected 3 SNAT rules, got %d", len(snatRules))
+	}
+	expected := map[string]string{"a": "eip-a", "b": "eip-b", "c": "eip-a"}
+	for subnet, publicIP := range expected {
+		snatRule, ok := snatRules[subnet]
+		if !ok {
+			t.Fatalf("Expected SNAT rule for subnet %s", subnet)
+		}
+		if publicIPName(snatRule) != publicIP {
+			t.Fatalf("Expected subnet %s to use %s, got %s", subnet, publicIP, publicIPName(snatRule))
+		}
+		if !metav1.IsControlledBy(&snatRule, snatRuleSet) {
+			t.Fatalf("Expected SNAT rule %s to be owned by the set", snatRule.Name)
+		}
+	}
+	if len(snatRuleSet.Status.Rules) != 3 {
+		t.Fatalf("Expected 3 rules in status, got %d", len(snatRuleSet.Status.Rules))
+	}
+}
+
+func TestSNATRuleSetFollowsSubnets(t *testing.T) {
+	r, c := newTestSNATRuleSetReconciler(
+		t,
+		newTestSNATRuleSet("eip-a", "eip-b"),
+		newTestSNATRuleSetSubnet("a", "private"),
+		newTestSNATRuleSetSubnet("b", "private"),
+	)
+	_, before := reconcileTestSNATRuleSet(t, r, c)
+
+	// Unselect subnet a and add subnet c.
+	subnet := &otcv1alpha1.Subnet{}
+	key := types.NamespacedName{Name: "a", Namespace: testNamespace}
+	if err := c.Get(context.Background(), key, subnet); err != nil {
+		t.Fatal(err)
+	}
+	subnet.Labels = map[string]string{"tier": "public"}
+	if err := c.Update(context.Background(), subnet); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Create(context.Background(), newTestSNATRuleSetSubnet("c", "private")); err != nil {
+		t.Fatal(err)
+	}
+
+	snatRuleSet, after := reconcileTestSNATRuleSet(t, r, c)
+
+	subnets := make([]string, 0, len(after))
+	for subnet := range after {
+		subnets = append(subnets, subnet)
+	}
+	slices.Sort(subnets)
+	if !slices.Equal(subnets, []string{"b", "c"}) {
+		t.Fatalf("Expected SNAT rules for subnets b and c, got %v", subnets)
+	}
+	// Subnet b keeps its public IP, subnet c takes the one freed by a.
+	if publicIPName(after["b"]) != publicIPName(before["b"]) {
+		t.Fatalf("Expected subnet b to keep %s, got %s", publicIPName(before["b"]), publicIPName(after["b"]))
+	}
+	if publicIPName(after["c"]) != "eip-a" {
+		t.Fatalf("Expected subnet c to use eip-a, got %s", publicIPName(after["c"]))
+	}
+	if len(snatRuleSet.Status.Rules) != 2 {
+		t.Fatalf("Expected 2 rules in status, got %d", len(snatRuleSet.Status.Rules))
+	}
+}
+
+func TestSNATRuleName(t *testing.T) {
+	if snatRuleName("a-b", "c") == snatRuleName("a", "b-c") {
+		t.Fatalf("Expected distinct names, got %s", snatRuleName("a", "b-c"))
+	}
+	if snatRuleName("a", "b") != snatRuleName("a", "b") {
+		t.Fatal("Expected stable names")
+	}
+
+	name := snatRuleName(strings.Repeat("a", 60), strings.Repeat("b", 60))
+	if len(name) > validation.DNS1123LabelMaxLength {
+		t.Fatalf("Expected at most %d characters, got %d", validation.DNS1123LabelMaxLength, len(name))
+	}
+}
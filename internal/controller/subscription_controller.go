@@ -0,0 +1,162 @@
+package controller
+
+import (
+	"context"
+	"time"
+
+	"github.com/rs/zerolog"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	otcv1alpha1 "github.com/peertech.de/otc-operator/api/v1alpha1"
+	provider "github.com/peertech.de/otc-operator/internal/provider"
+)
+
+const (
+	subscriptionFinalizerName = "subscription.otc.peertech.de/finalizer"
+	subscriptionRequeueDelay  = 30 * time.Second
+)
+
+// SubscriptionReconciler reconciles a Subscription object
+type SubscriptionReconciler = ManagedReconciler[
+	*otcv1alpha1.Subscription,
+	*provider.SubscriptionInfo,
+	provider.UpdateSubscriptionRequest,
+]
+
+func NewSubscriptionReconciler(
+	c client.Client,
+	logger zerolog.Logger,
+	providers *ProviderCache,
+	dependencies *DependencyGraphCache,
+) *SubscriptionReconciler {
+	return NewManagedReconciler(c, logger, providers, dependencies, subscriptionResource)
+}
+
+// +kubebuilder:rbac:groups=otc.peertech.de,resources=subscriptions,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=otc.peertech.de,resources=subscriptions/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=otc.peertech.de,resources=subscriptions/finalizers,verbs=update
+// +kubebuilder:rbac:groups=otc.peertech.de,resources=topics,verbs=get;list;watch
+// +kubebuilder:rbac:groups=otc.peertech.de,resources=providerconfigs,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;create;update;patch
+// +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch;create;update;patch
+
+// subscriptionResource has no mutable fields, so it has no Diff.
+var subscriptionResource = ManagedResource[
+	*otcv1alpha1.Subscription,
+	*provider.SubscriptionInfo,
+	provider.UpdateSubscriptionRequest,
+]{
+	ControllerName: "subscription",
+	Name:           "subscription",
+	FinalizerName:  subscriptionFinalizerName,
+	RequeueDelay:   subscriptionRequeueDelay,
+
+	New: func() *otcv1alpha1.Subscription { return &otcv1alpha1.Subscription{} },
+
+	Resolve: func(
+		ctx context.Context,
+		resolver *DependencyResolver,
+		subscription *otcv1alpha1.Subscription,
+	) error {
+		resolved, err := resolver.ResolveSubscriptionDependencies(ctx, subscription.Spec)
+		if err != nil {
+			return err
+		}
+
+		subscription.Status.ResolvedDependencies = resolved
+		return nil
+	},
+
+	// The URN of a referenced topic can change if it gets recreated.
+	// Selectors prefer the previously resolved resources to stay stable.
+	Refresh: func(
+		ctx context.Context,
+		resolver *DependencyResolver,
+		subscription *otcv1alpha1.Subscription,
+	) error {
+		resolver.Prefer(subscription.Status.ResolvedDependencies.TopicURN)
+		resolved, err := resolver.ResolveSubscriptionDependencies(ctx, subscription.Spec)
+		if err != nil {
+			return err
+		}
+
+		subscription.Status.ResolvedDependencies = resolved
+		return nil
+	},
+
+	Create: func(
+		ctx context.Context,
+		p provider.Provider,
+		subscription *otcv1alpha1.Subscription,
+	) (string, error) {
+		resp, err := p.CreateSubscription(ctx, provider.CreateSubscriptionRequest{
+			Protocol: string(subscription.Spec.Protocol),
+			Endpoint: subscription.Spec.Endpoint,
+			Remark:   subscription.Spec.Remark,
+			TopicURN: subscription.Status.ResolvedDependencies.TopicURN,
+		})
+		return resp.URN, err
+	},
+
+	// Observe fetches the subscription from the topic it was created in.
+	Observe: func(
+		ctx context.Context,
+		p provider.Provider,
+		subscription *otcv1alpha1.Subscription,
+	) (*provider.SubscriptionInfo, error) {
+		info, err := p.GetSubscription(
+			ctx,
+			subscription.Status.ResolvedDependencies.TopicURN,
+			subscription.Status.ExternalID,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		// Report the observed subscription state.
+		subscription.Status.SubscriptionState = info.SubscriptionState()
+		return info, nil
+	},
+
+	Reset: func(subscription *otcv1alpha1.Subscription) {
+		subscription.Status.SubscriptionState = ""
+	},
+
+	// A subscription can not be moved to another topic, so it is replaced if
+	// the topic got recreated.
+	Replace: func(
+		logger zerolog.Logger,
+		subscription *otcv1alpha1.Subscription,
+		info *provider.SubscriptionInfo,
+	) bool {
+		topicURN := subscription.Status.ResolvedDependencies.TopicURN
+		if topicURN == info.TopicURN {
+			return false
+		}
+
+		logger.Info().
+			Str("current", info.TopicURN).
+			Str("desired", topicURN).
+			Msg("Drift detected in topic, replacing subscription")
+		return true
+	},
+
+	ConnectionDetails: func(
+		_ *otcv1alpha1.Subscription,
+		info *provider.SubscriptionInfo,
+	) map[string]string {
+		return map[string]string{
+			"externalID":      info.URN,
+			"subscriptionURN": info.URN,
+			"topicURN":        info.TopicURN,
+		}
+	},
+
+	Delete: func(
+		ctx context.Context,
+		p provider.Provider,
+		subscription *otcv1alpha1.Subscription,
+	) error {
+		return p.DeleteSubscription(ctx, subscription.Status.ExternalID)
+	},
+}
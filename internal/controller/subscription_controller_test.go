@@ -0,0 +1,147 @@
+package controller
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	otcv1alpha1 "github.com/peertech.de/otc-operator/api/v1alpha1"
+)
+
+const testSubscriptionName = "oncall"
+
+// newTestSubscriptionTopic returns a ready Topic with the given URN, which is
+// referenced by the test subscription.
+func newTestSubscriptionTopic(urn string) *otcv1alpha1.Topic {
+	return &otcv1alpha1.Topic{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      testTopicName,
+			Namespace: testNamespace,
+		},
+		Status: otcv1alpha1.TopicStatus{
+			ExternalID: urn,
+			TopicURN:   urn,
+			Conditions: []metav1.Condition{{
+				Type:               condReady,
+				Status:             metav1.ConditionTrue,
+				Reason:             reasonReady,
+				LastTransitionTime: metav1.Now(),
+			}},
+		},
+	}
+}
+
+func newTestSubscription() *otcv1alpha1.Subscription {
+	return &otcv1alpha1.Subscription{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       testSubscriptionName,
+			Namespace:  testNamespace,
+			Finalizers: []string{subscriptionFinalizerName},
+		},
+		Spec: otcv1alpha1.SubscriptionSpec{
+			ProviderConfigRef: testProviderConfigRef,
+			Topic: otcv1alpha1.TopicDependency{
+				TopicRef: &corev1.LocalObjectReference{Name: testTopicName},
+			},
+			Protocol: otcv1alpha1.SubscriptionProtocolEmail,
+			Endpoint: "oncall@example.org",
+		},
+	}
+}
+
+func TestSubscriptionLifecycle(t *testing.T) {
+	p := &fakeProvider{}
+	r := newTestManagedReconciler(
+		t,
+		NewSubscriptionReconciler,
+		p,
+		newTestSubscription(),
+		newTestSubscriptionTopic(testTopicURN),
+	)
+
+	// The subscription is created in the referenced topic.
+	_, subscription := reconcileTestManaged(t, r, newTestSubscription())
+	if len(p.subscriptions.created) != 1 || p.subscriptions.created[0].TopicURN != testTopicURN {
+		t.Fatalf("Expected subscription to be created in topic %q, got %+v", testTopicURN, p.subscriptions.created)
+	}
+	firstURN := testTopicURN + ":subscription-1"
+	if subscription.Status.ExternalID != firstURN {
+		t.Fatalf("Expected externalID %q, got %q", firstURN, subscription.Status.ExternalID)
+	}
+
+	// The subscription is not ready until the endpoint confirmed it.
+	_, subscription = reconcileTestManaged(t, r, subscription)
+	expectCondition(t, subscription, condReady, metav1.ConditionFalse, reasonProvisioning)
+	if subscription.Status.SubscriptionState != "Unconfirmed" {
+		t.Fatalf("Expected state %q, got %q", "Unconfirmed", subscription.Status.SubscriptionState)
+	}
+
+	p.subscriptions.info.Status = 1
+	_, subscription = reconcileTestManaged(t, r, subscription)
+	expectCondition(t, subscription, condReady, metav1.ConditionTrue, reasonProvisioned)
+
+	// A subscription canceled by the endpoint is stopped.
+	p.subscriptions.info.Status = 3
+	_, subscription = reconcileTestManaged(t, r, subscription)
+	expectCondition(t, subscription, condReady, metav1.ConditionFalse, reasonStopped)
+	if subscription.Status.SubscriptionState != "Canceled" {
+		t.Fatalf("Expected state %q, got %q", "Canceled", subscription.Status.SubscriptionState)
+	}
+
+	// The subscription of a recreated topic is replaced, as it can not be
+	// moved to another topic.
+	const otherTopicURN = "urn:smn:eu-de:project:alerts-2"
+	topic := &otcv1alpha1.Topic{}
+	key := client.ObjectKey{Namespace: testNamespace, Name: testTopicName}
+	if err := r.Get(context.Background(), key, topic); err != nil {
+		t.Fatal(err)
+	}
+	topic.Status.ExternalID = otherTopicURN
+	if err := r.Update(context.Background(), topic); err != nil {
+		t.Fatal(err)
+	}
+	_, subscription = reconcileTestManaged(t, r, subscription)
+	if !slices.Equal(p.subscriptions.deleted, []string{firstURN}) || subscription.Status.ExternalID != "" {
+		t.Fatalf("Expected subscription %q to be replaced, got %v", firstURN, p.subscriptions.deleted)
+	}
+
+	_, subscription = reconcileTestManaged(t, r, subscription)
+	if len(p.subscriptions.created) != 2 || p.subscriptions.created[1].TopicURN != otherTopicURN {
+		t.Fatalf("Expected subscription to be created in topic %q, got %+v", otherTopicURN, p.subscriptions.created)
+	}
+
+	secondURN := otherTopicURN + ":subscription-2"
+	deleteTestManaged(t, r, subscription)
+	if !slices.Equal(p.subscriptions.deleted, []string{firstURN, secondURN}) {
+		t.Fatalf("Expected external deletion of %q, got %v", secondURN, p.subscriptions.deleted)
+	}
+}
+
+func TestSubscriptionNotFound(t *testing.T) {
+	subscription := newTestSubscription()
+	subscription.Status.ExternalID = testTopicURN + ":subscription-0"
+	subscription.Status.ResolvedDependencies.TopicURN = testTopicURN
+	subscription.Status.LastAppliedSpec = subscription.Spec.DeepCopy()
+
+	p := &fakeProvider{}
+	r := newTestManagedReconciler(
+		t,
+		NewSubscriptionReconciler,
+		p,
+		subscription,
+		newTestSubscriptionTopic(testTopicURN),
+	)
+
+	// The subscription deleted out-of-band is created again.
+	_, subscription = reconcileTestManaged(t, r, subscription)
+	expectRecreate(t, subscription)
+
+	_, subscription = reconcileTestManaged(t, r, subscription)
+	if len(p.subscriptions.created) != 1 || subscription.Status.ExternalID != testTopicURN+":subscription-1" {
+		t.Fatalf("Expected subscription to be recreated, got %+v", p.subscriptions.created)
+	}
+}
@@ -0,0 +1,153 @@
+package controller
+
+import (
+	"context"
+	"time"
+
+	"github.com/rs/zerolog"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	otcv1alpha1 "github.com/peertech.de/otc-operator/api/v1alpha1"
+	provider "github.com/peertech.de/otc-operator/internal/provider"
+)
+
+const (
+	topicFinalizerName = "topic.otc.peertech.de/finalizer"
+	topicRequeueDelay  = 30 * time.Second
+)
+
+// TopicReconciler reconciles a Topic object
+type TopicReconciler = ManagedReconciler[
+	*otcv1alpha1.Topic,
+	*provider.TopicInfo,
+	provider.UpdateTopicRequest,
+]
+
+func NewTopicReconciler(
+	c client.Client,
+	logger zerolog.Logger,
+	providers *ProviderCache,
+	dependencies *DependencyGraphCache,
+) *TopicReconciler {
+	return NewManagedReconciler(c, logger, providers, dependencies, topicResource)
+}
+
+// +kubebuilder:rbac:groups=otc.peertech.de,resources=topics,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=otc.peertech.de,resources=topics/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=otc.peertech.de,resources=topics/finalizers,verbs=update
+// +kubebuilder:rbac:groups=otc.peertech.de,resources=subscriptions,verbs=get;list;watch
+// +kubebuilder:rbac:groups=otc.peertech.de,resources=alarmrules,verbs=get;list;watch
+// +kubebuilder:rbac:groups=otc.peertech.de,resources=providerconfigs,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;create;update;patch
+// +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch;create;update;patch
+
+var topicResource = ManagedResource[
+	*otcv1alpha1.Topic,
+	*provider.TopicInfo,
+	provider.UpdateTopicRequest,
+]{
+	ControllerName: "topic",
+	Name:           "topic",
+	FinalizerName:  topicFinalizerName,
+	RequeueDelay:   topicRequeueDelay,
+
+	New: func() *otcv1alpha1.Topic { return &otcv1alpha1.Topic{} },
+
+	Create: func(
+		ctx context.Context,
+		p provider.Provider,
+		topic *otcv1alpha1.Topic,
+	) (string, error) {
+		resp, err := p.CreateTopic(ctx, provider.CreateTopicRequest{
+			Name:        topic.Name,
+			DisplayName: topic.Spec.DisplayName,
+			Policy:      topic.Spec.Policy,
+		})
+		if err != nil {
+			return "", err
+		}
+
+		topic.Status.TopicURN = resp.URN
+		return resp.URN, nil
+	},
+
+	Observe: func(
+		ctx context.Context,
+		p provider.Provider,
+		topic *otcv1alpha1.Topic,
+	) (*provider.TopicInfo, error) {
+		info, err := p.GetTopic(ctx, topic.Status.ExternalID)
+		if err != nil {
+			return nil, err
+		}
+
+		// Report the observed URN.
+		topic.Status.TopicURN = info.URN
+		return info, nil
+	},
+
+	// The URN belongs to the deleted topic.
+	Reset: func(topic *otcv1alpha1.Topic) {
+		topic.Status.TopicURN = ""
+	},
+
+	Diff: func(
+		logger zerolog.Logger,
+		topic *otcv1alpha1.Topic,
+		info *provider.TopicInfo,
+	) (provider.UpdateTopicRequest, bool) {
+		var updateReq provider.UpdateTopicRequest
+		needsUpdate := false
+
+		if topic.Spec.DisplayName != info.DisplayName {
+			logger.Info().
+				Str("current", info.DisplayName).
+				Str("desired", topic.Spec.DisplayName).
+				Msg("Drift detected in display name")
+
+			updateReq.DisplayName = &topic.Spec.DisplayName
+			needsUpdate = true
+		}
+
+		// SMN normalizes the policy document, so a change is detected against
+		// the last applied policy. A policy removed out-of-band is detected
+		// too.
+		lastAppliedPolicy := topic.Status.LastAppliedSpec.Policy
+		if !equalPolicy(topic.Spec.Policy, lastAppliedPolicy) ||
+			(topic.Spec.Policy == "") != (info.Policy == "") {
+			logger.Info().Msg("Drift detected in policy")
+
+			updateReq.Policy = &topic.Spec.Policy
+			needsUpdate = true
+		}
+
+		return updateReq, needsUpdate
+	},
+
+	Update: func(
+		ctx context.Context,
+		p provider.Provider,
+		topic *otcv1alpha1.Topic,
+		req provider.UpdateTopicRequest,
+	) error {
+		return p.UpdateTopic(ctx, topic.Status.ExternalID, req)
+	},
+
+	ConnectionDetails: func(
+		_ *otcv1alpha1.Topic,
+		info *provider.TopicInfo,
+	) map[string]string {
+		return map[string]string{
+			"externalID": info.URN,
+			"topicURN":   info.URN,
+			"name":       info.Name,
+		}
+	},
+
+	// Deleting the topic would delete the subscriptions too.
+	ReferenceChecks: ReferenceChecksFor("Topic"),
+
+	Delete: func(ctx context.Context, p provider.Provider, topic *otcv1alpha1.Topic) error {
+		return p.DeleteTopic(ctx, topic.Status.ExternalID)
+	},
+}
@@ -0,0 +1,102 @@
+package controller
+
+import (
+	"slices"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	otcv1alpha1 "github.com/peertech.de/otc-operator/api/v1alpha1"
+)
+
+const (
+	testTopicName = "alerts"
+	testTopicURN  = "urn:smn:eu-de:project:alerts"
+	testPolicy    = `{"Version": "2016-09-07", "Statement": []}`
+)
+
+func newTestTopic() *otcv1alpha1.Topic {
+	return &otcv1alpha1.Topic{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       testTopicName,
+			Namespace:  testNamespace,
+			Finalizers: []string{topicFinalizerName},
+		},
+		Spec: otcv1alpha1.TopicSpec{
+			ProviderConfigRef: testProviderConfigRef,
+			DisplayName:       "Alerts",
+			Policy:            testPolicy,
+		},
+	}
+}
+
+func TestTopicLifecycle(t *testing.T) {
+	p := &fakeProvider{}
+	r := newTestManagedReconciler(t, NewTopicReconciler, p, newTestTopic())
+
+	// The topic is created with its policy.
+	_, topic := reconcileTestManaged(t, r, newTestTopic())
+	if len(p.topics.created) != 1 || p.topics.created[0].Policy != testPolicy {
+		t.Fatalf("Expected topic to be created with policy, got %+v", p.topics.created)
+	}
+	if topic.Status.ExternalID != testTopicURN || topic.Status.TopicURN != testTopicURN {
+		t.Fatalf("Expected URN %q, got %+v", testTopicURN, topic.Status)
+	}
+
+	// The topic is ready as soon as it exists. The normalized policy is not
+	// detected as drift.
+	_, topic = reconcileTestManaged(t, r, topic)
+	expectCondition(t, topic, condReady, metav1.ConditionTrue, reasonProvisioned)
+	if len(p.topics.updated) != 0 {
+		t.Fatalf("Expected no update of the normalized policy, got %+v", p.topics.updated)
+	}
+
+	// A changed display name is applied without the policy.
+	updateTestManaged(t, r, topic, func(topic *otcv1alpha1.Topic) {
+		topic.Spec.DisplayName = "Critical alerts"
+	})
+	_, topic = reconcileTestManaged(t, r, topic)
+	if len(p.topics.updated) != 1 || p.topics.updated[0].DisplayName == nil ||
+		*p.topics.updated[0].DisplayName != "Critical alerts" || p.topics.updated[0].Policy != nil {
+		t.Fatalf("Expected display name to be updated, got %+v", p.topics.updated)
+	}
+
+	// A policy removed out-of-band is applied again.
+	p.topics.info.Policy = ""
+	_, topic = reconcileTestManaged(t, r, topic)
+	if len(p.topics.updated) != 2 || p.topics.updated[1].Policy == nil || *p.topics.updated[1].Policy != testPolicy {
+		t.Fatalf("Expected policy to be applied again, got %+v", p.topics.updated)
+	}
+
+	_, topic = reconcileTestManaged(t, r, topic)
+	if len(p.topics.updated) != 2 {
+		t.Fatalf("Expected no further update, got %+v", p.topics.updated)
+	}
+
+	deleteTestManaged(t, r, topic)
+	if !slices.Equal(p.topics.deleted, []string{testTopicURN}) {
+		t.Fatalf("Expected external deletion of %q, got %v", testTopicURN, p.topics.deleted)
+	}
+}
+
+func TestTopicNotFound(t *testing.T) {
+	topic := newTestTopic()
+	topic.Status.ExternalID = testTopicURN
+	topic.Status.TopicURN = testTopicURN
+	topic.Status.LastAppliedSpec = topic.Spec.DeepCopy()
+
+	p := &fakeProvider{}
+	r := newTestManagedReconciler(t, NewTopicReconciler, p, topic)
+
+	// The topic deleted out-of-band is created again.
+	_, topic = reconcileTestManaged(t, r, topic)
+	expectRecreate(t, topic)
+	if topic.Status.TopicURN != "" {
+		t.Fatalf("Expected URN of the deleted topic to be reset, got %q", topic.Status.TopicURN)
+	}
+
+	_, topic = reconcileTestManaged(t, r, topic)
+	if len(p.topics.created) != 1 || topic.Status.TopicURN != testTopicURN {
+		t.Fatalf("Expected topic to be recreated, got %+v", p.topics.created)
+	}
+}
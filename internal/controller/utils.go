@@ -1,18 +1,30 @@
 package controller
 
 import (
+	"cmp"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
+	"reflect"
+	"slices"
+	"strings"
 
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	meta "k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	otcv1alpha1 "github.com/peertech.de/otc-operator/api/v1alpha1"
 )
 
+// childNameHashLength is the number of hex characters of the hash suffix of
+// the names of child resources.
+const childNameHashLength = 8
+
 // ObjectListWithItems is an interface that combines client.ObjectList with a
 // method to retrieve its items in a generic way.
 type ObjectListWithItems interface {
@@ -85,19 +97,80 @@ func resolveByRef(
 	return c.Get(ctx, objKey, obj)
 }
 
-// resolveBySelector lists resources matching labels and ensures exactly one is found.
+// resolveByNamespacedRef fetches a single Kubernetes resource referenced by a
+// NamespacedObjectReference. A reference to another namespace than ns must be
+// allowed by a ReferenceGrant in the referenced namespace.
+func resolveByNamespacedRef(
+	ctx context.Context,
+	c client.Client,
+	ref *otcv1alpha1.NamespacedObjectReference,
+	ns string,
+	kind otcv1alpha1.ReferenceGrantKind,
+	obj client.Object,
+) error {
+	refNamespace := ref.Namespace
+	if refNamespace == "" {
+		refNamespace = ns
+	}
+
+	if refNamespace != ns {
+		granted, err := isReferenceGranted(ctx, c, ns, refNamespace, kind, ref.Name)
+		if err != nil {
+			return err
+		}
+		if !granted {
+			return fmt.Errorf(
+				"no ReferenceGrant in namespace %s allows namespace %s to reference %s '%s'",
+				refNamespace,
+				ns,
+				kind,
+				ref.Name,
+			)
+		}
+	}
+
+	objKey := client.ObjectKey{Name: ref.Name, Namespace: refNamespace}
+	return c.Get(ctx, objKey, obj)
+}
+
+// isReferenceGranted reports whether a ReferenceGrant in namespace to allows
+// resources in namespace from to reference the resource of the given kind and
+// name.
+func isReferenceGranted(
+	ctx context.Context,
+	c client.Client,
+	from, to string,
+	kind otcv1alpha1.ReferenceGrantKind,
+	name string,
+) (bool, error) {
+	var grants otcv1alpha1.ReferenceGrantList
+	if err := c.List(ctx, &grants, client.InNamespace(to)); err != nil {
+		return false, fmt.Errorf("failed to list ReferenceGrants in namespace %s: %w", to, err)
+	}
+
+	return slices.ContainsFunc(grants.Items, func(grant otcv1alpha1.ReferenceGrant) bool {
+		return grant.Allows(from, kind, name)
+	}), nil
+}
+
+// resolveBySelector lists resources matching the selector and selects one of
+// them. Resources which are not ready are ignored if the selector is
+// ReadyOnly. If multiple resources match, a resource with one of the preferred
+// external IDs is kept, so a previous selection stays stable. Otherwise the
+// selection policy decides, with ties broken by name.
 func resolveBySelector(
 	ctx context.Context,
 	c client.Client,
-	selector *metav1.LabelSelector,
+	selector *otcv1alpha1.ResourceSelector,
 	ns string,
 	listObj ObjectListWithItems,
+	preferred []string,
 ) (client.Object, error) {
-	if len(selector.MatchLabels) == 0 {
-		return nil, fmt.Errorf("matchLabels cannot be empty for selector")
+	if len(selector.MatchLabels) == 0 && len(selector.MatchExpressions) == 0 {
+		return nil, fmt.Errorf("matchLabels or matchExpressions must be set for selector")
 	}
 
-	labelSelector, err := metav1.LabelSelectorAsSelector(selector)
+	labelSelector, err := metav1.LabelSelectorAsSelector(&selector.LabelSelector)
 	if err != nil {
 		return nil, fmt.Errorf("invalid label selector: %w", err)
 	}
@@ -109,28 +182,54 @@ func resolveBySelector(
 
 	if err := c.List(ctx, listObj, opts...); err != nil {
 		return nil, fmt.Errorf(
-			"failed to list resources with selector %v: %w",
-			selector.MatchLabels,
+			"failed to list resources with selector %s: %w",
+			labelSelector,
 			err,
 		)
 	}
 
 	items := listObj.GetItems()
+	if selector.ReadyOnly {
+		items = slices.DeleteFunc(items, func(item client.Object) bool {
+			obj, ok := item.(ManagedObject)
+			return !ok || !meta.IsStatusConditionTrue(*obj.GetConditions(), condReady)
+		})
+	}
+
 	if len(items) == 0 {
 		return nil, fmt.Errorf(
-			"no resources found matching selector %v in namespace %s",
-			selector.MatchLabels,
+			"no resources found matching selector %s in namespace %s",
+			labelSelector,
 			ns,
 		)
 	}
-	if len(items) > 1 {
+	if len(items) == 1 {
+		return items[0], nil
+	}
+
+	if selector.Policy == "" || selector.Policy == otcv1alpha1.SelectionPolicyUnique {
 		return nil, fmt.Errorf(
-			"expected exactly one resource to match selector %v, but found %d",
-			selector.MatchLabels,
+			"expected exactly one resource to match selector %s, but found %d",
+			labelSelector,
 			len(items),
 		)
 	}
 
+	for _, item := range items {
+		if obj, ok := item.(ManagedObject); ok && slices.Contains(preferred, obj.GetExternalID()) {
+			return item, nil
+		}
+	}
+
+	slices.SortFunc(items, func(a, b client.Object) int {
+		return cmp.Or(
+			a.GetCreationTimestamp().Compare(b.GetCreationTimestamp().Time),
+			cmp.Compare(a.GetName(), b.GetName()),
+		)
+	})
+	if selector.Policy == otcv1alpha1.SelectionPolicyNewest {
+		return items[len(items)-1], nil
+	}
 	return items[0], nil
 }
 
@@ -150,6 +249,42 @@ func checkReadinessAndGetID(obj client.Object, kind string) (string, error) {
 	case *otcv1alpha1.SecurityGroup:
 		externalID = o.Status.ExternalID
 		conditions = o.Status.Conditions
+	case *otcv1alpha1.NATGateway:
+		externalID = o.Status.ExternalID
+		conditions = o.Status.Conditions
+	case *otcv1alpha1.Bandwidth:
+		externalID = o.Status.ExternalID
+		conditions = o.Status.Conditions
+	case *otcv1alpha1.PublicIP:
+		externalID = o.Status.ExternalID
+		conditions = o.Status.Conditions
+	case *otcv1alpha1.Instance:
+		externalID = o.Status.ExternalID
+		conditions = o.Status.Conditions
+	case *otcv1alpha1.VirtualIP:
+		externalID = o.Status.ExternalID
+		conditions = o.Status.Conditions
+	case *otcv1alpha1.KeyPair:
+		externalID = o.Status.ExternalID
+		conditions = o.Status.Conditions
+	case *otcv1alpha1.DNSZone:
+		externalID = o.Status.ExternalID
+		conditions = o.Status.Conditions
+	case *otcv1alpha1.VPNGateway:
+		externalID = o.Status.ExternalID
+		conditions = o.Status.Conditions
+	case *otcv1alpha1.CustomerGateway:
+		externalID = o.Status.ExternalID
+		conditions = o.Status.Conditions
+	case *otcv1alpha1.Cluster:
+		externalID = o.Status.ExternalID
+		conditions = o.Status.Conditions
+	case *otcv1alpha1.KMSKey:
+		externalID = o.Status.ExternalID
+		conditions = o.Status.Conditions
+	case *otcv1alpha1.Topic:
+		externalID = o.Status.ExternalID
+		conditions = o.Status.Conditions
 	default:
 		return "", fmt.Errorf("unhandled dependency type for kind %s", kind)
 	}
@@ -172,3 +307,38 @@ func checkReadinessAndGetID(obj client.Object, kind string) (string, error) {
 
 	return externalID, nil
 }
+
+// equalPolicy compares two JSON policy documents semantically, ignoring
+// formatting and key order.
+func equalPolicy(a, b string) bool {
+	if a == b {
+		return true
+	}
+	if a == "" || b == "" {
+		return false
+	}
+
+	var docA, docB any
+	if err := json.Unmarshal([]byte(a), &docA); err != nil {
+		return false
+	}
+	if err := json.Unmarshal([]byte(b), &docB); err != nil {
+		return false
+	}
+	return reflect.DeepEqual(docA, docB)
+}
+
+// childName returns the name of a resource created by a parent for one of
+// its children. The hash suffix keeps the names of different parent and child
+// pairs apart, like parent "a-b" with child "c" and parent "a" with child
+// "b-c". The name is truncated to a DNS-1123 label.
+func childName(parent, child string) string {
+	sum := sha256.Sum256([]byte(parent + "/" + child))
+	suffix := hex.EncodeToString(sum[:])[:childNameHashLength]
+
+	prefix := parent + "-" + child
+	if maxLength := validation.DNS1123LabelMaxLength - len(suffix) - 1; len(prefix) > maxLength {
+		prefix = strings.TrimRight(prefix[:maxLength], "-.")
+	}
+	return prefix + "-" + suffix
+}
@@ -0,0 +1,115 @@
+package controller
+
+import (
+	"context"
+	"time"
+
+	"github.com/rs/zerolog"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	otcv1alpha1 "github.com/peertech.de/otc-operator/api/v1alpha1"
+	provider "github.com/peertech.de/otc-operator/internal/provider"
+)
+
+const (
+	virtualIPFinalizerName = "virtualIP.otc.peertech.de/finalizer"
+	virtualIPRequeueDelay  = 30 * time.Second
+)
+
+// VirtualIPReconciler reconciles a VirtualIP object
+type VirtualIPReconciler = ManagedReconciler[
+	*otcv1alpha1.VirtualIP,
+	*provider.VirtualIPInfo,
+	provider.UpdateVirtualIPRequest,
+]
+
+func NewVirtualIPReconciler(
+	c client.Client,
+	logger zerolog.Logger,
+	providers *ProviderCache,
+	dependencies *DependencyGraphCache,
+) *VirtualIPReconciler {
+	return NewManagedReconciler(c, logger, providers, dependencies, virtualIPResource)
+}
+
+// +kubebuilder:rbac:groups=otc.peertech.de,resources=virtualips,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=otc.peertech.de,resources=virtualips/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=otc.peertech.de,resources=virtualips/finalizers,verbs=update
+// +kubebuilder:rbac:groups=otc.peertech.de,resources=subnets,verbs=get;list;watch
+// +kubebuilder:rbac:groups=otc.peertech.de,resources=providerconfigs,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;create;update;patch
+// +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch;create;update;patch
+
+// virtualIPResource has no mutable fields, so it has no Diff.
+var virtualIPResource = ManagedResource[
+	*otcv1alpha1.VirtualIP,
+	*provider.VirtualIPInfo,
+	provider.UpdateVirtualIPRequest,
+]{
+	ControllerName: "virtualip",
+	Name:           "virtual IP",
+	FinalizerName:  virtualIPFinalizerName,
+	RequeueDelay:   virtualIPRequeueDelay,
+
+	New: func() *otcv1alpha1.VirtualIP { return &otcv1alpha1.VirtualIP{} },
+
+	Resolve: func(
+		ctx context.Context,
+		resolver *DependencyResolver,
+		virtualIP *otcv1alpha1.VirtualIP,
+	) error {
+		subnetID, err := resolver.ResolveSubnet(ctx, virtualIP.Spec.Subnet)
+		if err != nil {
+			return err
+		}
+
+		virtualIP.Status.ResolvedDependencies = otcv1alpha1.VirtualIPDependenciesResolved{
+			SubnetID: subnetID,
+		}
+		return nil
+	},
+
+	Create: func(
+		ctx context.Context,
+		p provider.Provider,
+		virtualIP *otcv1alpha1.VirtualIP,
+	) (string, error) {
+		resp, err := p.CreateVirtualIP(ctx, provider.CreateVirtualIPRequest{
+			Name:      virtualIP.GetName(),
+			IPAddress: virtualIP.Spec.IPAddress,
+			SubnetID:  virtualIP.Status.ResolvedDependencies.SubnetID,
+		})
+		return resp.ID, err
+	},
+
+	Observe: func(
+		ctx context.Context,
+		p provider.Provider,
+		virtualIP *otcv1alpha1.VirtualIP,
+	) (*provider.VirtualIPInfo, error) {
+		info, err := p.GetVirtualIP(ctx, virtualIP.Status.ExternalID)
+		if err != nil {
+			return nil, err
+		}
+
+		// Report the observed address.
+		virtualIP.Status.IPAddress = info.IPAddress
+		return info, nil
+	},
+
+	ConnectionDetails: func(
+		_ *otcv1alpha1.VirtualIP,
+		info *provider.VirtualIPInfo,
+	) map[string]string {
+		return map[string]string{
+			"externalID": info.ID,
+			"ipAddress":  info.IPAddress,
+		}
+	},
+
+	ReferenceChecks: ReferenceChecksFor("VirtualIP"),
+
+	Delete: func(ctx context.Context, p provider.Provider, virtualIP *otcv1alpha1.VirtualIP) error {
+		return p.DeleteVirtualIP(ctx, virtualIP.Status.ExternalID)
+	},
+}
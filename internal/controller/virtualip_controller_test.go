@@ -0,0 +1,81 @@
+package controller
+
+import (
+	"slices"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	otcv1alpha1 "github.com/peertech.de/otc-operator/api/v1alpha1"
+)
+
+const (
+	testVirtualIPName = "vip"
+	testVirtualIPID   = "virtual-ip-id"
+)
+
+func newTestVirtualIP() *otcv1alpha1.VirtualIP {
+	subnetID := testSubnetID
+	return &otcv1alpha1.VirtualIP{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       testVirtualIPName,
+			Namespace:  testNamespace,
+			Finalizers: []string{virtualIPFinalizerName},
+		},
+		Spec: otcv1alpha1.VirtualIPSpec{
+			ProviderConfigRef: testProviderConfigRef,
+			Subnet:            otcv1alpha1.SubnetDependency{SubnetID: &subnetID},
+			IPAddress:         "10.0.0.10",
+		},
+	}
+}
+
+func TestVirtualIPLifecycle(t *testing.T) {
+	p := &fakeProvider{}
+	r := newTestManagedReconciler(t, NewVirtualIPReconciler, p, newTestVirtualIP())
+
+	// The virtual IP is created in its subnet with the fixed address.
+	_, virtualIP := reconcileTestManaged(t, r, newTestVirtualIP())
+	if len(p.virtualIPs.created) != 1 || p.virtualIPs.created[0].SubnetID != testSubnetID ||
+		p.virtualIPs.created[0].IPAddress != "10.0.0.10" {
+		t.Fatalf("Expected virtual IP to be created in subnet %q, got %+v", testSubnetID, p.virtualIPs.created)
+	}
+	if virtualIP.Status.ExternalID != testVirtualIPID {
+		t.Fatalf("Expected externalID %q, got %q", testVirtualIPID, virtualIP.Status.ExternalID)
+	}
+
+	// The virtual IP is not ready while it is built.
+	_, virtualIP = reconcileTestManaged(t, r, virtualIP)
+	expectCondition(t, virtualIP, condReady, metav1.ConditionFalse, reasonProvisioning)
+
+	// A virtual IP which is not bound to an instance yet is ready.
+	p.virtualIPs.info.Status = "DOWN"
+	_, virtualIP = reconcileTestManaged(t, r, virtualIP)
+	expectCondition(t, virtualIP, condReady, metav1.ConditionTrue, reasonProvisioned)
+	if virtualIP.Status.IPAddress != "10.0.0.10" {
+		t.Fatalf("Expected address %q, got %q", "10.0.0.10", virtualIP.Status.IPAddress)
+	}
+
+	deleteTestManaged(t, r, virtualIP)
+	if !slices.Equal(p.virtualIPs.deleted, []string{testVirtualIPID}) {
+		t.Fatalf("Expected external deletion of %q, got %v", testVirtualIPID, p.virtualIPs.deleted)
+	}
+}
+
+func TestVirtualIPNotFound(t *testing.T) {
+	virtualIP := newTestVirtualIP()
+	virtualIP.Status.ExternalID = testVirtualIPID
+	virtualIP.Status.LastAppliedSpec = virtualIP.Spec.DeepCopy()
+
+	p := &fakeProvider{}
+	r := newTestManagedReconciler(t, NewVirtualIPReconciler, p, virtualIP)
+
+	// The virtual IP deleted out-of-band is created again.
+	_, virtualIP = reconcileTestManaged(t, r, virtualIP)
+	expectRecreate(t, virtualIP)
+
+	_, virtualIP = reconcileTestManaged(t, r, virtualIP)
+	if len(p.virtualIPs.created) != 1 || virtualIP.Status.ExternalID != testVirtualIPID {
+		t.Fatalf("Expected virtual IP to be recreated, got %+v", p.virtualIPs.created)
+	}
+}
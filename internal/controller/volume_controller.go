@@ -0,0 +1,281 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/rs/zerolog"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	otcv1alpha1 "github.com/peertech.de/otc-operator/api/v1alpha1"
+	provider "github.com/peertech.de/otc-operator/internal/provider"
+)
+
+const (
+	volumeFinalizerName = "volume.otc.peertech.de/finalizer"
+	volumeRequeueDelay  = 30 * time.Second
+)
+
+// VolumeReconciler reconciles a Volume object
+type VolumeReconciler = ManagedReconciler[
+	*otcv1alpha1.Volume,
+	*provider.VolumeInfo,
+	provider.UpdateVolumeRequest,
+]
+
+func NewVolumeReconciler(
+	c client.Client,
+	logger zerolog.Logger,
+	providers *ProviderCache,
+	dependencies *DependencyGraphCache,
+) *VolumeReconciler {
+	return NewManagedReconciler(c, logger, providers, dependencies, newVolumeResource(c))
+}
+
+// +kubebuilder:rbac:groups=otc.peertech.de,resources=volumes,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=otc.peertech.de,resources=volumes/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=otc.peertech.de,resources=volumes/finalizers,verbs=update
+// +kubebuilder:rbac:groups=otc.peertech.de,resources=instances,verbs=get;list;watch
+// +kubebuilder:rbac:groups=otc.peertech.de,resources=kmskeys,verbs=get;list;watch
+// +kubebuilder:rbac:groups=otc.peertech.de,resources=providerconfigs,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;create;update;patch
+// +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch;create;update;patch
+
+// newVolumeResource returns the volume resource, which attaches a created
+// volume on the next reconciliation and looks up the attached instance with
+// the client.
+func newVolumeResource(c client.Client) ManagedResource[
+	*otcv1alpha1.Volume,
+	*provider.VolumeInfo,
+	provider.UpdateVolumeRequest,
+] {
+	return ManagedResource[
+		*otcv1alpha1.Volume,
+		*provider.VolumeInfo,
+		provider.UpdateVolumeRequest,
+	]{
+		ControllerName: "volume",
+		Name:           "volume",
+		FinalizerName:  volumeFinalizerName,
+		RequeueDelay:   volumeRequeueDelay,
+
+		New: func() *otcv1alpha1.Volume { return &otcv1alpha1.Volume{} },
+
+		Resolve: func(
+			ctx context.Context,
+			resolver *DependencyResolver,
+			volume *otcv1alpha1.Volume,
+		) error {
+			resolved, err := resolver.ResolveVolumeDependencies(ctx, volume.Spec)
+			if err != nil {
+				return err
+			}
+
+			volume.Status.ResolvedDependencies = resolved
+			return nil
+		},
+
+		// The IDs of a referenced instance or KMS key can change if they get
+		// recreated. Selectors prefer the previously resolved resources to stay
+		// stable.
+		Refresh: func(
+			ctx context.Context,
+			resolver *DependencyResolver,
+			volume *otcv1alpha1.Volume,
+		) error {
+			previous := volume.Status.ResolvedDependencies
+			resolver.Prefer(previous.InstanceID, previous.KMSKeyID)
+			resolved, err := resolver.ResolveVolumeDependencies(ctx, volume.Spec)
+			if err != nil {
+				return err
+			}
+
+			volume.Status.ResolvedDependencies = resolved
+			return nil
+		},
+
+		Create: func(
+			ctx context.Context,
+			p provider.Provider,
+			volume *otcv1alpha1.Volume,
+		) (string, error) {
+			resp, err := p.CreateVolume(ctx, provider.CreateVolumeRequest{
+				Name:             volume.GetName(),
+				Size:             volume.Spec.Size,
+				Type:             volume.Spec.Type,
+				AvailabilityZone: volume.Spec.AvailabilityZone,
+				KMSKeyID:         volume.Status.ResolvedDependencies.KMSKeyID,
+			})
+			return resp.ID, err
+		},
+
+		Observe: func(
+			ctx context.Context,
+			p provider.Provider,
+			volume *otcv1alpha1.Volume,
+		) (*provider.VolumeInfo, error) {
+			info, err := p.GetVolume(ctx, volume.Status.ExternalID)
+			if err != nil {
+				return nil, err
+			}
+
+			// Report the observed size and attachment.
+			volume.Status.Size = info.Size
+			volume.Status.AttachedInstanceID = info.AttachedInstanceID
+			volume.Status.Device = info.Device
+			return info, nil
+		},
+
+		Reset: func(volume *otcv1alpha1.Volume) {
+			volume.Status.AttachedInstanceID = ""
+			volume.Status.Device = ""
+		},
+
+		Diff: func(
+			logger zerolog.Logger,
+			volume *otcv1alpha1.Volume,
+			info *provider.VolumeInfo,
+		) (provider.UpdateVolumeRequest, bool) {
+			var updateReq provider.UpdateVolumeRequest
+			needsUpdate := false
+
+			// Volumes can only grow, so a larger volume is left alone.
+			if volume.Spec.Size > info.Size {
+				logger.Info().
+					Int("current", info.Size).
+					Int("desired", volume.Spec.Size).
+					Msg("Drift detected in size")
+
+				updateReq.Size = volume.Spec.Size
+				needsUpdate = true
+			}
+
+			// The attachment is only managed if it is specified, or if it was
+			// specified before and got removed. This leaves volumes which are
+			// attached by other means untouched.
+			lastAppliedSpec := volume.Status.LastAppliedSpec
+			managesAttachment := volume.Spec.Attachment != nil || lastAppliedSpec.Attachment != nil
+
+			desiredInstanceID := volume.Status.ResolvedDependencies.InstanceID
+			if managesAttachment && info.AttachedInstanceID != desiredInstanceID {
+				logger.Info().
+					Str("current", info.AttachedInstanceID).
+					Str("desired", desiredInstanceID).
+					Msg("Drift detected in attachment")
+
+				updateReq.InstanceID = &desiredInstanceID
+				if volume.Spec.Attachment != nil {
+					updateReq.Device = volume.Spec.Attachment.Device
+				}
+				needsUpdate = true
+			}
+
+			return updateReq, needsUpdate
+		},
+
+		Update: func(
+			ctx context.Context,
+			p provider.Provider,
+			volume *otcv1alpha1.Volume,
+			req provider.UpdateVolumeRequest,
+		) error {
+			return p.UpdateVolume(ctx, volume.Status.ExternalID, req)
+		},
+
+		ConnectionDetails: func(
+			_ *otcv1alpha1.Volume,
+			info *provider.VolumeInfo,
+		) map[string]string {
+			return map[string]string{
+				"externalID": info.ID,
+				"size":       strconv.Itoa(info.Size),
+				"device":     info.Device,
+			}
+		},
+
+		// The attachment is refreshed before the deletion, as the status is not
+		// observed while the volume is deleted. An attached volume is only
+		// detached if the spec no longer declares the attachment or the instance
+		// is deleted as well, as the instance may still use it otherwise.
+		Release: func(
+			ctx context.Context,
+			p provider.Provider,
+			volume *otcv1alpha1.Volume,
+		) ([]string, error) {
+			info, err := p.GetVolume(ctx, volume.Status.ExternalID)
+			if errors.Is(err, provider.ErrNotFound) {
+				volume.Status.AttachedInstanceID = ""
+				volume.Status.Device = ""
+				return nil, nil
+			}
+			if err != nil {
+				return nil, err
+			}
+
+			volume.Status.AttachedInstanceID = info.AttachedInstanceID
+			volume.Status.Device = info.Device
+			if info.AttachedInstanceID == "" {
+				return nil, nil
+			}
+
+			if volume.Spec.Attachment != nil {
+				blocker, err := volumeAttachmentBlocker(ctx, c, volume, info.AttachedInstanceID)
+				if err != nil {
+					return nil, err
+				}
+				if blocker != "" {
+					return []string{blocker}, nil
+				}
+			}
+
+			detached := ""
+			if err := p.UpdateVolume(ctx, volume.Status.ExternalID, provider.UpdateVolumeRequest{
+				InstanceID: &detached,
+			}); err != nil {
+				return nil, fmt.Errorf("failed to detach volume: %w", err)
+			}
+
+			volume.Status.AttachedInstanceID = ""
+			volume.Status.Device = ""
+			volume.Status.ResolvedDependencies.InstanceID = ""
+			return nil, nil
+		},
+
+		Delete: func(ctx context.Context, p provider.Provider, volume *otcv1alpha1.Volume) error {
+			return p.DeleteVolume(ctx, volume.Status.ExternalID)
+		},
+	}
+}
+
+// volumeAttachmentBlocker returns the name of the instance the volume is
+// attached to, or an empty string if the instance is deleted as well. An
+// instance declared by its ID may not be managed by an Instance, so the volume
+// stays attached to it.
+func volumeAttachmentBlocker(
+	ctx context.Context,
+	c client.Client,
+	volume *otcv1alpha1.Volume,
+	instanceID string,
+) (string, error) {
+	var instances otcv1alpha1.InstanceList
+	if err := c.List(ctx, &instances, client.InNamespace(volume.Namespace)); err != nil {
+		return "", fmt.Errorf("failed to list instances: %w", err)
+	}
+	for _, instance := range instances.Items {
+		if instance.Status.ExternalID != instanceID {
+			continue
+		}
+		if !instance.DeletionTimestamp.IsZero() {
+			return "", nil
+		}
+		return instance.Name, nil
+	}
+
+	if volume.Spec.Attachment.Instance.InstanceID != nil {
+		return instanceID, nil
+	}
+	return "", nil
+}
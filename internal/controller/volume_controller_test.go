@@ -0,0 +1,312 @@
+package controller
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	otcv1alpha1 "github.com/peertech.de/otc-operator/api/v1alpha1"
+	provider "github.com/peertech.de/otc-operator/internal/provider"
+)
+
+const (
+	testVolumeName       = "volume"
+	testVolumeExternalID = "volume-id"
+	testInstanceName     = "instance"
+	testInstanceID       = "instance-id"
+)
+
+func newTestVolume() *otcv1alpha1.Volume {
+	return &otcv1alpha1.Volume{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       testVolumeName,
+			Namespace:  testNamespace,
+			Finalizers: []string{volumeFinalizerName},
+		},
+		Spec: otcv1alpha1.VolumeSpec{
+			ProviderConfigRef: testProviderConfigRef,
+			Size:              10,
+			Type:              otcv1alpha1.VolumeTypeSSD,
+			AvailabilityZone:  "eu-de-01",
+		},
+	}
+}
+
+func TestVolumeLifecycle(t *testing.T) {
+	p := &fakeProvider{}
+	r := newTestManagedReconciler(t, NewVolumeReconciler, p, newTestVolume())
+
+	// The volume is created with the requested size.
+	_, volume := reconcileTestManaged(t, r, newTestVolume())
+	if len(p.volumes.created) != 1 || p.volumes.created[0].Size != 10 {
+		t.Fatalf("Expected volume of size 10 to be created, got %+v", p.volumes.created)
+	}
+	if volume.Status.ExternalID != testVolumeExternalID {
+		t.Fatalf("Expected externalID %q, got %q", testVolumeExternalID, volume.Status.ExternalID)
+	}
+
+	// The volume is not ready while it is created.
+	_, volume = reconcileTestManaged(t, r, volume)
+	expectCondition(t, volume, condReady, metav1.ConditionFalse, reasonProvisioning)
+
+	p.volumes.info.Status = "available"
+	_, volume = reconcileTestManaged(t, r, volume)
+	expectCondition(t, volume, condReady, metav1.ConditionTrue, reasonProvisioned)
+	if volume.Status.Size != 10 {
+		t.Fatalf("Expected observed size 10, got %d", volume.Status.Size)
+	}
+
+	// A larger size is applied.
+	updateTestManaged(t, r, volume, func(volume *otcv1alpha1.Volume) {
+		volume.Spec.Size = 20
+	})
+	_, volume = reconcileTestManaged(t, r, volume)
+	if len(p.volumes.updated) != 1 || p.volumes.updated[0].Size != 20 || p.volumes.updated[0].InstanceID != nil {
+		t.Fatalf("Expected volume to be extended to 20, got %+v", p.volumes.updated)
+	}
+
+	// A volume extended out-of-band is not shrunk.
+	p.volumes.info.Size = 30
+	_, volume = reconcileTestManaged(t, r, volume)
+	if len(p.volumes.updated) != 1 {
+		t.Fatalf("Expected no further update, got %+v", p.volumes.updated)
+	}
+	if volume.Status.Size != 30 {
+		t.Fatalf("Expected observed size 30, got %d", volume.Status.Size)
+	}
+
+	deleteTestManaged(t, r, volume)
+	if !slices.Equal(p.volumes.deleted, []string{testVolumeExternalID}) {
+		t.Fatalf("Expected external deletion of %q, got %v", testVolumeExternalID, p.volumes.deleted)
+	}
+	if len(p.detachedVolumes) != 0 {
+		t.Fatalf("Expected no detachment, got %v", p.detachedVolumes)
+	}
+}
+
+func TestVolumeNotFound(t *testing.T) {
+	volume := newTestVolume()
+	volume.Status.ExternalID = testVolumeExternalID
+	volume.Status.LastAppliedSpec = volume.Spec.DeepCopy()
+
+	p := &fakeProvider{}
+	r := newTestManagedReconciler(t, NewVolumeReconciler, p, volume)
+
+	// The volume deleted out-of-band is created again.
+	_, volume = reconcileTestManaged(t, r, volume)
+	expectRecreate(t, volume)
+
+	_, volume = reconcileTestManaged(t, r, volume)
+	if len(p.volumes.created) != 1 || volume.Status.ExternalID != testVolumeExternalID {
+		t.Fatalf("Expected volume to be recreated, got %+v", p.volumes.created)
+	}
+}
+
+// newTestAttachedVolumeReconciler returns a VolumeReconciler for a volume
+// attached to the test instance, backed by a fake client with the given
+// objects.
+func newTestAttachedVolumeReconciler(
+	t *testing.T,
+	objs ...client.Object,
+) (*VolumeReconciler, client.Client, *fakeProvider, *otcv1alpha1.Volume) {
+	t.Helper()
+
+	providerConfig := &otcv1alpha1.ProviderConfig{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "provider",
+			Namespace: testNamespace,
+		},
+		Status: otcv1alpha1.ProviderConfigStatus{
+			Conditions: []metav1.Condition{{
+				Type:               condReady,
+				Status:             metav1.ConditionTrue,
+				Reason:             "Ready",
+				LastTransitionTime: metav1.Now(),
+			}},
+		},
+	}
+	volume := &otcv1alpha1.Volume{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       testVolumeName,
+			Namespace:  testNamespace,
+			Finalizers: []string{volumeFinalizerName},
+		},
+		Spec: otcv1alpha1.VolumeSpec{
+			ProviderConfigRef: otcv1alpha1.ProviderConfigReference{Name: providerConfig.Name},
+			Attachment: &otcv1alpha1.VolumeAttachment{
+				Instance: otcv1alpha1.InstanceDependency{
+					InstanceRef: &corev1.LocalObjectReference{Name: testInstanceName},
+				},
+			},
+		},
+		Status: otcv1alpha1.VolumeStatus{
+			ExternalID: testVolumeExternalID,
+			ResolvedDependencies: otcv1alpha1.VolumeDependenciesResolved{
+				InstanceID: testInstanceID,
+			},
+		},
+	}
+
+	c := newIndexedClientBuilder(t).
+		WithObjects(append(objs, providerConfig, volume)...).
+		WithStatusSubresource(&otcv1alpha1.Volume{}).
+		Build()
+
+	// Pre-populate the cache, so no provider client is created.
+	p := &fakeProvider{}
+	p.volumes.info = &provider.VolumeInfo{
+		ID:                 testVolumeExternalID,
+		Status:             "in-use",
+		AttachedInstanceID: testInstanceID,
+	}
+	providers := NewProviderCache(c, zerolog.Nop())
+	providers.cache[testNamespace+"/"+providerConfig.Name] = &providerEntry{
+		provider:         p,
+		configGeneration: providerConfig.Generation,
+	}
+
+	r := NewVolumeReconciler(c, zerolog.Nop(), providers, nil)
+	r.recorder = record.NewFakeRecorder(10)
+	return r, c, p, volume
+}
+
+func TestVolumeDeletionDetachesRemovedAttachment(t *testing.T) {
+	ctx := context.Background()
+
+	instance := &otcv1alpha1.Instance{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      testInstanceName,
+			Namespace: testNamespace,
+		},
+		Status: otcv1alpha1.InstanceStatus{
+			ExternalID: testInstanceID,
+		},
+	}
+	r, c, p, volume := newTestAttachedVolumeReconciler(t, instance)
+
+	if err := c.Delete(ctx, volume); err != nil {
+		t.Fatal(err)
+	}
+
+	// The volume is still declared to be attached, so it is neither detached
+	// nor deleted. The attachment is observed, although the status was not
+	// refreshed before.
+	key := types.NamespacedName{Name: testVolumeName, Namespace: testNamespace}
+	if _, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key}); err != nil {
+		t.Fatalf("Expected no error, got %s", err)
+	}
+	if err := c.Get(ctx, key, volume); err != nil {
+		t.Fatal(err)
+	}
+	ready := meta.FindStatusCondition(volume.Status.Conditions, condReady)
+	if ready == nil || ready.Reason != reasonDeletionBlocked {
+		t.Fatalf("Expected volume deletion to be blocked, got %+v", ready)
+	}
+	if !strings.Contains(ready.Message, testInstanceName) {
+		t.Fatalf("Expected message to name instance %q, got %q", testInstanceName, ready.Message)
+	}
+	if volume.Status.AttachedInstanceID != testInstanceID {
+		t.Fatalf("Expected attachment %q to be observed, got %q", testInstanceID, volume.Status.AttachedInstanceID)
+	}
+	if len(p.detachedVolumes) != 0 || len(p.volumes.deleted) != 0 {
+		t.Fatalf("Expected no detachment or deletion, got %v and %v", p.detachedVolumes, p.volumes.deleted)
+	}
+
+	// The attached volume blocks the deletion of the instance.
+	var refs []string
+	for _, chk := range ReferenceChecksFor("Instance") {
+		names, err := chk.Check(ctx, c, testNamespace, testInstanceID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		refs = append(refs, names...)
+	}
+	if !slices.Equal(refs, []string{testVolumeName}) {
+		t.Fatalf("Expected instance to be referenced by %q, got %v", testVolumeName, refs)
+	}
+
+	// Once the attachment is removed from the spec, the volume is detached
+	// and deleted.
+	volume.Spec.Attachment = nil
+	if err := c.Update(ctx, volume); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key}); err != nil {
+		t.Fatalf("Expected no error, got %s", err)
+	}
+	if !slices.Equal(p.detachedVolumes, []string{testVolumeExternalID}) {
+		t.Fatalf("Expected detachment of %q, got %v", testVolumeExternalID, p.detachedVolumes)
+	}
+	if !slices.Equal(p.volumes.deleted, []string{testVolumeExternalID}) {
+		t.Fatalf("Expected external deletion of %q, got %v", testVolumeExternalID, p.volumes.deleted)
+	}
+	err := c.Get(ctx, key, &otcv1alpha1.Volume{})
+	if !apierrors.IsNotFound(err) {
+		t.Fatalf("Expected finalizer to be removed, got %v", err)
+	}
+}
+
+func TestVolumeDeletionDetachesFromDeletedInstance(t *testing.T) {
+	tests := []struct {
+		name string
+		objs []client.Object
+	}{
+		{
+			name: "instance being deleted",
+			objs: []client.Object{&otcv1alpha1.Instance{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:              testInstanceName,
+					Namespace:         testNamespace,
+					Finalizers:        []string{instanceFinalizerName},
+					DeletionTimestamp: &metav1.Time{Time: time.Now()},
+				},
+				Status: otcv1alpha1.InstanceStatus{
+					ExternalID: testInstanceID,
+				},
+			}},
+		},
+		{
+			name: "instance gone",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			r, c, p, volume := newTestAttachedVolumeReconciler(t, tt.objs...)
+
+			if err := c.Delete(ctx, volume); err != nil {
+				t.Fatal(err)
+			}
+
+			// The volume still declares the attachment, but the instance
+			// is deleted as well, so the volume does not block it.
+			key := types.NamespacedName{Name: testVolumeName, Namespace: testNamespace}
+			if _, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key}); err != nil {
+				t.Fatalf("Expected no error, got %s", err)
+			}
+			if !slices.Equal(p.detachedVolumes, []string{testVolumeExternalID}) {
+				t.Fatalf("Expected detachment of %q, got %v", testVolumeExternalID, p.detachedVolumes)
+			}
+			if !slices.Equal(p.volumes.deleted, []string{testVolumeExternalID}) {
+				t.Fatalf("Expected external deletion of %q, got %v", testVolumeExternalID, p.volumes.deleted)
+			}
+			err := c.Get(ctx, key, &otcv1alpha1.Volume{})
+			if !apierrors.IsNotFound(err) {
+				t.Fatalf("Expected finalizer to be removed, got %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,325 @@
+package controller
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	otcv1alpha1 "github.com/peertech.de/otc-operator/api/v1alpha1"
+	provider "github.com/peertech.de/otc-operator/internal/provider"
+)
+
+const (
+	vpnConnectionFinalizerName = "vpnconnection.otc.peertech.de/finalizer"
+	vpnConnectionRequeueDelay  = 30 * time.Second
+)
+
+// VPNConnectionReconciler reconciles a VPNConnection object
+type VPNConnectionReconciler = ManagedReconciler[
+	*otcv1alpha1.VPNConnection,
+	*vpnConnectionObservation,
+	provider.UpdateVPNConnectionRequest,
+]
+
+func NewVPNConnectionReconciler(
+	c client.Client,
+	logger zerolog.Logger,
+	providers *ProviderCache,
+	dependencies *DependencyGraphCache,
+) *VPNConnectionReconciler {
+	return NewManagedReconciler(c, logger, providers, dependencies, newVPNConnectionResource(c))
+}
+
+// vpnConnectionObservation is the observed VPN connection together with the
+// pre-shared key read from its Secret, as the provider does not report the
+// key.
+type vpnConnectionObservation struct {
+	*provider.VPNConnectionInfo
+
+	preSharedKey string
+}
+
+// +kubebuilder:rbac:groups=otc.peertech.de,resources=vpnconnections,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=otc.peertech.de,resources=vpnconnections/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=otc.peertech.de,resources=vpnconnections/finalizers,verbs=update
+// +kubebuilder:rbac:groups=otc.peertech.de,resources=vpngateways,verbs=get;list;watch
+// +kubebuilder:rbac:groups=otc.peertech.de,resources=customergateways,verbs=get;list;watch
+// +kubebuilder:rbac:groups=otc.peertech.de,resources=providerconfigs,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;create;update;patch
+// +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch;create;update;patch
+
+// newVPNConnectionResource returns the VPN connection resource, which reads
+// the pre-shared key with the client. A connection whose tunnel is down is
+// reported as stopped.
+func newVPNConnectionResource(c client.Client) ManagedResource[
+	*otcv1alpha1.VPNConnection,
+	*vpnConnectionObservation,
+	provider.UpdateVPNConnectionRequest,
+] {
+	return ManagedResource[
+		*otcv1alpha1.VPNConnection,
+		*vpnConnectionObservation,
+		provider.UpdateVPNConnectionRequest,
+	]{
+		ControllerName: "vpnconnection",
+		Name:           "VPN connection",
+		FinalizerName:  vpnConnectionFinalizerName,
+		RequeueDelay:   vpnConnectionRequeueDelay,
+
+		New: func() *otcv1alpha1.VPNConnection { return &otcv1alpha1.VPNConnection{} },
+
+		// The pre-shared key is a dependency of the connection.
+		Resolve: func(
+			ctx context.Context,
+			r *DependencyResolver,
+			vpnConnection *otcv1alpha1.VPNConnection,
+		) error {
+			resolved, err := r.ResolveVPNConnectionDependencies(ctx, vpnConnection.Spec)
+			if err != nil {
+				return err
+			}
+			if _, err := getPreSharedKey(ctx, c, vpnConnection); err != nil {
+				return err
+			}
+			vpnConnection.Status.ResolvedDependencies = resolved
+			return nil
+		},
+
+		// The connection is established from the primary public IP of the
+		// VPN gateway, which is looked up at the provider.
+		Create: func(
+			ctx context.Context,
+			p provider.Provider,
+			vpnConnection *otcv1alpha1.VPNConnection,
+		) (string, error) {
+			resolved := &vpnConnection.Status.ResolvedDependencies
+			gateway, err := p.GetVPNGateway(ctx, resolved.VPNGatewayID)
+			if err != nil {
+				return "", dependencyNotReady(fmt.Errorf(
+					"failed to get VPN gateway '%s': %w",
+					resolved.VPNGatewayID,
+					err,
+				))
+			}
+			if gateway.PrimaryPublicIPID == "" {
+				return "", dependencyNotReady(fmt.Errorf(
+					"VPN gateway '%s' has no public IP yet",
+					resolved.VPNGatewayID,
+				))
+			}
+			resolved.GatewayPublicIPID = gateway.PrimaryPublicIPID
+
+			preSharedKey, err := getPreSharedKey(ctx, c, vpnConnection)
+			if err != nil {
+				return "", dependencyNotReady(err)
+			}
+
+			resp, err := p.CreateVPNConnection(ctx, provider.CreateVPNConnectionRequest{
+				Name:         vpnConnection.GetName(),
+				RoutingMode:  vpnConnection.Spec.RoutingMode,
+				PeerSubnets:  vpnConnection.Spec.PeerSubnets,
+				PreSharedKey: preSharedKey,
+				IKEPolicy:    vpnConnection.Spec.IKEPolicy,
+				IPsecPolicy:  vpnConnection.Spec.IPsecPolicy,
+
+				// dependencies
+				VPNGatewayID:      resolved.VPNGatewayID,
+				GatewayPublicIPID: resolved.GatewayPublicIPID,
+				CustomerGatewayID: resolved.CustomerGatewayID,
+			})
+			if err != nil {
+				return "", err
+			}
+
+			vpnConnection.Status.PreSharedKeyHash = hashPreSharedKey(preSharedKey)
+			return resp.ID, nil
+		},
+
+		// The pre-shared key is re-read on every reconciliation, so changes
+		// to the Secret are applied.
+		Observe: func(
+			ctx context.Context,
+			p provider.Provider,
+			vpnConnection *otcv1alpha1.VPNConnection,
+		) (*vpnConnectionObservation, error) {
+			info, err := p.GetVPNConnection(ctx, vpnConnection.Status.ExternalID)
+			if err != nil {
+				return nil, err
+			}
+
+			// Report the observed tunnel status.
+			vpnConnection.Status.TunnelStatus = info.Status
+
+			preSharedKey, err := getPreSharedKey(ctx, c, vpnConnection)
+			if err != nil {
+				return nil, dependencyNotReady(err)
+			}
+
+			return &vpnConnectionObservation{
+				VPNConnectionInfo: info,
+				preSharedKey:      preSharedKey,
+			}, nil
+		},
+
+		// The pre-shared key was read successfully, so the dependencies are
+		// ready again.
+		Refresh: func(context.Context, *DependencyResolver, *otcv1alpha1.VPNConnection) error {
+			return nil
+		},
+
+		Diff: func(
+			logger zerolog.Logger,
+			vpnConnection *otcv1alpha1.VPNConnection,
+			info *vpnConnectionObservation,
+		) (provider.UpdateVPNConnectionRequest, bool) {
+			var updateReq provider.UpdateVPNConnectionRequest
+			needsUpdate := false
+
+			current := slices.Sorted(slices.Values(info.PeerSubnets))
+			desired := slices.Sorted(slices.Values(vpnConnection.Spec.PeerSubnets))
+			if !slices.Equal(current, desired) {
+				logger.Info().
+					Strs("current", info.PeerSubnets).
+					Strs("desired", vpnConnection.Spec.PeerSubnets).
+					Msg("Drift detected in peer subnets")
+
+				updateReq.PeerSubnets = vpnConnection.Spec.PeerSubnets
+				needsUpdate = true
+			}
+
+			// The provider reports policies with its own defaults, so they
+			// are compared against the last applied spec.
+			lastAppliedSpec := vpnConnection.Status.LastAppliedSpec
+			if vpnConnection.Spec.IKEPolicy != lastAppliedSpec.IKEPolicy {
+				logger.Info().Msg("Drift detected in IKE policy")
+
+				updateReq.IKEPolicy = &vpnConnection.Spec.IKEPolicy
+				needsUpdate = true
+			}
+
+			if vpnConnection.Spec.IPsecPolicy != lastAppliedSpec.IPsecPolicy {
+				logger.Info().Msg("Drift detected in IPsec policy")
+
+				updateReq.IPsecPolicy = &vpnConnection.Spec.IPsecPolicy
+				needsUpdate = true
+			}
+
+			if hashPreSharedKey(info.preSharedKey) != vpnConnection.Status.PreSharedKeyHash {
+				logger.Info().Msg("Drift detected in pre-shared key")
+
+				updateReq.PreSharedKey = info.preSharedKey
+				needsUpdate = true
+			}
+
+			return updateReq, needsUpdate
+		},
+
+		Update: func(
+			ctx context.Context,
+			p provider.Provider,
+			vpnConnection *otcv1alpha1.VPNConnection,
+			req provider.UpdateVPNConnectionRequest,
+		) error {
+			if err := p.UpdateVPNConnection(ctx, vpnConnection.Status.ExternalID, req); err != nil {
+				return err
+			}
+			if req.PreSharedKey != "" {
+				vpnConnection.Status.PreSharedKeyHash = hashPreSharedKey(req.PreSharedKey)
+			}
+			return nil
+		},
+
+		ConnectionDetails: func(
+			_ *otcv1alpha1.VPNConnection,
+			info *vpnConnectionObservation,
+		) map[string]string {
+			return map[string]string{
+				"externalID":  info.ID,
+				"peerSubnets": strings.Join(info.PeerSubnets, ","),
+			}
+		},
+
+		Delete: func(
+			ctx context.Context,
+			p provider.Provider,
+			vpnConnection *otcv1alpha1.VPNConnection,
+		) error {
+			return p.DeleteVPNConnection(ctx, vpnConnection.Status.ExternalID)
+		},
+
+		// Watch for changes to the Secrets holding the pre-shared keys.
+		Watches: []ManagedWatch{
+			{Object: &corev1.Secret{}, Map: findVPNConnectionsForSecret},
+		},
+	}
+}
+
+// getPreSharedKey reads the pre-shared key from the referenced Secret.
+func getPreSharedKey(
+	ctx context.Context,
+	c client.Client,
+	vpnConnection *otcv1alpha1.VPNConnection,
+) (string, error) {
+	ref := vpnConnection.Spec.PreSharedKeySecretRef
+
+	var secret corev1.Secret
+	err := c.Get(ctx, client.ObjectKey{Namespace: vpnConnection.Namespace, Name: ref.Name}, &secret)
+	if err != nil {
+		return "", fmt.Errorf("failed to get pre-shared key secret '%s': %w", ref.Name, err)
+	}
+
+	preSharedKey, ok := secret.Data[ref.Key]
+	if !ok || len(preSharedKey) == 0 {
+		return "", fmt.Errorf("pre-shared key secret '%s' has no key '%s'", ref.Name, ref.Key)
+	}
+
+	return string(preSharedKey), nil
+}
+
+// hashPreSharedKey returns the hex encoded SHA-256 hash of a pre-shared key.
+// Only the hash is stored in the status, so the key is not exposed.
+func hashPreSharedKey(preSharedKey string) string {
+	sum := sha256.Sum256([]byte(preSharedKey))
+	return hex.EncodeToString(sum[:])
+}
+
+// findVPNConnectionsForSecret returns requests for all VPNConnections in the
+// namespace of the Secret which read their pre-shared key from it.
+func findVPNConnectionsForSecret(
+	ctx context.Context,
+	c client.Client,
+	secret client.Object,
+) ([]reconcile.Request, error) {
+	var vpnConnections otcv1alpha1.VPNConnectionList
+	err := c.List(
+		ctx,
+		&vpnConnections,
+		client.InNamespace(secret.GetNamespace()),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list VPNConnections for secret watch: %w", err)
+	}
+
+	requests := make([]reconcile.Request, 0)
+	for _, vc := range vpnConnections.Items {
+		if vc.Spec.PreSharedKeySecretRef.Name == secret.GetName() {
+			requests = append(requests, reconcile.Request{
+				NamespacedName: types.NamespacedName{
+					Name:      vc.Name,
+					Namespace: vc.Namespace,
+				},
+			})
+		}
+	}
+	return requests, nil
+}
@@ -0,0 +1,139 @@
+package controller
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	otcv1alpha1 "github.com/peertech.de/otc-operator/api/v1alpha1"
+	provider "github.com/peertech.de/otc-operator/internal/provider"
+)
+
+const (
+	testVPNConnectionName = "vpn-connection"
+	testVPNConnectionID   = "vpn-connection-id"
+)
+
+func newTestPreSharedKeySecret() *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "psk", Namespace: testNamespace},
+		Data:       map[string][]byte{"key": []byte("secret")},
+	}
+}
+
+// newTestVPNConnectionProvider returns a fake provider with an active VPN
+// gateway.
+func newTestVPNConnectionProvider() *fakeProvider {
+	p := &fakeProvider{}
+	p.vpnGateways.info = &provider.VPNGatewayInfo{
+		ID:                testVPNGatewayID,
+		Status:            "ACTIVE",
+		PrimaryPublicIPID: "public-ip-1",
+	}
+	return p
+}
+
+func newTestVPNConnection() *otcv1alpha1.VPNConnection {
+	vpnGatewayID, customerGatewayID := testVPNGatewayID, testCustomerGatewayID
+	return &otcv1alpha1.VPNConnection{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       testVPNConnectionName,
+			Namespace:  testNamespace,
+			Finalizers: []string{vpnConnectionFinalizerName},
+		},
+		Spec: otcv1alpha1.VPNConnectionSpec{
+			ProviderConfigRef: testProviderConfigRef,
+			Gateway:           otcv1alpha1.VPNGatewayDependency{VPNGatewayID: &vpnGatewayID},
+			CustomerGateway: otcv1alpha1.CustomerGatewayDependency{
+				CustomerGatewayID: &customerGatewayID,
+			},
+			PeerSubnets: []string{"192.168.0.0/24"},
+			PreSharedKeySecretRef: corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "psk"},
+				Key:                  "key",
+			},
+		},
+	}
+}
+
+func TestVPNConnectionLifecycle(t *testing.T) {
+	p := newTestVPNConnectionProvider()
+	r := newTestManagedReconciler(
+		t, NewVPNConnectionReconciler, p, newTestVPNConnection(), newTestPreSharedKeySecret(),
+	)
+
+	// The connection is created with the public IP of the gateway and the
+	// pre-shared key.
+	_, vpnConnection := reconcileTestManaged(t, r, newTestVPNConnection())
+	if len(p.vpnConnections.created) != 1 || p.vpnConnections.created[0].GatewayPublicIPID != "public-ip-1" ||
+		p.vpnConnections.created[0].PreSharedKey != "secret" {
+		t.Fatalf("Expected connection to be created, got %+v", p.vpnConnections.created)
+	}
+	if vpnConnection.Status.ExternalID != testVPNConnectionID {
+		t.Fatalf("Expected externalID %q, got %q", testVPNConnectionID, vpnConnection.Status.ExternalID)
+	}
+
+	// The connection is not ready while it is created.
+	_, vpnConnection = reconcileTestManaged(t, r, vpnConnection)
+	expectCondition(t, vpnConnection, condReady, metav1.ConditionFalse, reasonProvisioning)
+
+	p.vpnConnections.info.Status = "ACTIVE"
+	_, vpnConnection = reconcileTestManaged(t, r, vpnConnection)
+	expectCondition(t, vpnConnection, condReady, metav1.ConditionTrue, reasonProvisioned)
+	if len(p.vpnConnections.updated) != 0 {
+		t.Fatalf("Expected no update, got %+v", p.vpnConnections.updated)
+	}
+
+	// Changed peer subnets are applied.
+	updateTestManaged(t, r, vpnConnection, func(vpnConnection *otcv1alpha1.VPNConnection) {
+		vpnConnection.Spec.PeerSubnets = []string{"192.168.1.0/24"}
+	})
+	_, vpnConnection = reconcileTestManaged(t, r, vpnConnection)
+	if len(p.vpnConnections.updated) != 1 || !slices.Equal(p.vpnConnections.updated[0].PeerSubnets, []string{"192.168.1.0/24"}) ||
+		p.vpnConnections.updated[0].PreSharedKey != "" {
+		t.Fatalf("Expected peer subnets to be updated, got %+v", p.vpnConnections.updated)
+	}
+
+	// A rotated pre-shared key is applied once.
+	secret := newTestPreSharedKeySecret()
+	secret.Data["key"] = []byte("rotated")
+	if err := r.Update(context.Background(), secret); err != nil {
+		t.Fatal(err)
+	}
+	_, vpnConnection = reconcileTestManaged(t, r, vpnConnection)
+	if len(p.vpnConnections.updated) != 2 || p.vpnConnections.updated[1].PreSharedKey != "rotated" || p.vpnConnections.updated[1].PeerSubnets != nil {
+		t.Fatalf("Expected pre-shared key to be updated, got %+v", p.vpnConnections.updated)
+	}
+	_, vpnConnection = reconcileTestManaged(t, r, vpnConnection)
+	if len(p.vpnConnections.updated) != 2 {
+		t.Fatalf("Expected no further update, got %+v", p.vpnConnections.updated)
+	}
+
+	deleteTestManaged(t, r, vpnConnection)
+	if !slices.Equal(p.vpnConnections.deleted, []string{testVPNConnectionID}) {
+		t.Fatalf("Expected external deletion of %q, got %v", testVPNConnectionID, p.vpnConnections.deleted)
+	}
+}
+
+func TestVPNConnectionNotFound(t *testing.T) {
+	vpnConnection := newTestVPNConnection()
+	vpnConnection.Status.ExternalID = testVPNConnectionID
+	vpnConnection.Status.LastAppliedSpec = vpnConnection.Spec.DeepCopy()
+
+	p := newTestVPNConnectionProvider()
+	r := newTestManagedReconciler(
+		t, NewVPNConnectionReconciler, p, vpnConnection, newTestPreSharedKeySecret(),
+	)
+
+	// The connection deleted out-of-band is created again.
+	_, vpnConnection = reconcileTestManaged(t, r, vpnConnection)
+	expectRecreate(t, vpnConnection)
+
+	_, vpnConnection = reconcileTestManaged(t, r, vpnConnection)
+	if len(p.vpnConnections.created) != 1 || vpnConnection.Status.ExternalID != testVPNConnectionID {
+		t.Fatalf("Expected connection to be recreated, got %+v", p.vpnConnections.created)
+	}
+}
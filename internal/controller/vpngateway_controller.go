@@ -0,0 +1,167 @@
+package controller
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/rs/zerolog"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	otcv1alpha1 "github.com/peertech.de/otc-operator/api/v1alpha1"
+	provider "github.com/peertech.de/otc-operator/internal/provider"
+)
+
+const (
+	vpnGatewayFinalizerName = "vpngateway.otc.peertech.de/finalizer"
+	vpnGatewayRequeueDelay  = 30 * time.Second
+)
+
+// VPNGatewayReconciler reconciles a VPNGateway object
+type VPNGatewayReconciler = ManagedReconciler[
+	*otcv1alpha1.VPNGateway,
+	*provider.VPNGatewayInfo,
+	provider.UpdateVPNGatewayRequest,
+]
+
+func NewVPNGatewayReconciler(
+	c client.Client,
+	logger zerolog.Logger,
+	providers *ProviderCache,
+	dependencies *DependencyGraphCache,
+) *VPNGatewayReconciler {
+	return NewManagedReconciler(c, logger, providers, dependencies, vpnGatewayResource)
+}
+
+// +kubebuilder:rbac:groups=otc.peertech.de,resources=vpngateways,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=otc.peertech.de,resources=vpngateways/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=otc.peertech.de,resources=vpngateways/finalizers,verbs=update
+// +kubebuilder:rbac:groups=otc.peertech.de,resources=networks,verbs=get;list;watch
+// +kubebuilder:rbac:groups=otc.peertech.de,resources=subnets,verbs=get;list;watch
+// +kubebuilder:rbac:groups=otc.peertech.de,resources=publicips,verbs=get;list;watch
+// +kubebuilder:rbac:groups=otc.peertech.de,resources=vpnconnections,verbs=get;list;watch
+// +kubebuilder:rbac:groups=otc.peertech.de,resources=providerconfigs,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;create;update;patch
+// +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch;create;update;patch
+
+// vpnGatewayResource checks the gateway after the RequeueDelay, as it takes
+// several minutes to become active.
+var vpnGatewayResource = ManagedResource[
+	*otcv1alpha1.VPNGateway,
+	*provider.VPNGatewayInfo,
+	provider.UpdateVPNGatewayRequest,
+]{
+	ControllerName: "vpngateway",
+	Name:           "VPN gateway",
+	FinalizerName:  vpnGatewayFinalizerName,
+	RequeueDelay:   vpnGatewayRequeueDelay,
+
+	New: func() *otcv1alpha1.VPNGateway { return &otcv1alpha1.VPNGateway{} },
+
+	Resolve: func(
+		ctx context.Context,
+		resolver *DependencyResolver,
+		vpnGateway *otcv1alpha1.VPNGateway,
+	) error {
+		resolved, err := resolver.ResolveVPNGatewayDependencies(ctx, vpnGateway.Spec)
+		if err != nil {
+			return err
+		}
+
+		vpnGateway.Status.ResolvedDependencies = resolved
+		return nil
+	},
+
+	Create: func(
+		ctx context.Context,
+		p provider.Provider,
+		vpnGateway *otcv1alpha1.VPNGateway,
+	) (string, error) {
+		resolved := vpnGateway.Status.ResolvedDependencies
+		resp, err := p.CreateVPNGateway(ctx, provider.CreateVPNGatewayRequest{
+			Name:              vpnGateway.GetName(),
+			LocalSubnets:      vpnGateway.Spec.LocalSubnets,
+			Flavor:            vpnGateway.Spec.Flavor,
+			AvailabilityZones: vpnGateway.Spec.AvailabilityZones,
+			BGPASN:            int(vpnGateway.Spec.BGPASN),
+			HAMode:            vpnGateway.Spec.HAMode,
+
+			// dependencies
+			NetworkID:           resolved.NetworkID,
+			SubnetID:            resolved.SubnetID,
+			PrimaryPublicIPID:   resolved.PrimaryPublicIPID,
+			SecondaryPublicIPID: resolved.SecondaryPublicIPID,
+		})
+		return resp.ID, err
+	},
+
+	Observe: func(
+		ctx context.Context,
+		p provider.Provider,
+		vpnGateway *otcv1alpha1.VPNGateway,
+	) (*provider.VPNGatewayInfo, error) {
+		info, err := p.GetVPNGateway(ctx, vpnGateway.Status.ExternalID)
+		if err != nil {
+			return nil, err
+		}
+
+		// Report the observed addresses.
+		vpnGateway.Status.PrimaryAddress = info.PrimaryAddress
+		vpnGateway.Status.SecondaryAddress = info.SecondaryAddress
+		return info, nil
+	},
+
+	Diff: func(
+		logger zerolog.Logger,
+		vpnGateway *otcv1alpha1.VPNGateway,
+		info *provider.VPNGatewayInfo,
+	) (provider.UpdateVPNGatewayRequest, bool) {
+		var updateReq provider.UpdateVPNGatewayRequest
+		needsUpdate := false
+
+		// The gateway cannot be updated while it is busy.
+		if info.State() != provider.Ready {
+			return updateReq, false
+		}
+
+		current := slices.Sorted(slices.Values(info.LocalSubnets))
+		desired := slices.Sorted(slices.Values(vpnGateway.Spec.LocalSubnets))
+		if !slices.Equal(current, desired) {
+			logger.Info().
+				Strs("current", info.LocalSubnets).
+				Strs("desired", vpnGateway.Spec.LocalSubnets).
+				Msg("Drift detected in local subnets")
+
+			updateReq.LocalSubnets = vpnGateway.Spec.LocalSubnets
+			needsUpdate = true
+		}
+
+		return updateReq, needsUpdate
+	},
+
+	Update: func(
+		ctx context.Context,
+		p provider.Provider,
+		vpnGateway *otcv1alpha1.VPNGateway,
+		req provider.UpdateVPNGatewayRequest,
+	) error {
+		return p.UpdateVPNGateway(ctx, vpnGateway.Status.ExternalID, req)
+	},
+
+	ConnectionDetails: func(
+		_ *otcv1alpha1.VPNGateway,
+		info *provider.VPNGatewayInfo,
+	) map[string]string {
+		return map[string]string{
+			"externalID":       info.ID,
+			"primaryAddress":   info.PrimaryAddress,
+			"secondaryAddress": info.SecondaryAddress,
+		}
+	},
+
+	ReferenceChecks: ReferenceChecksFor("VPNGateway"),
+
+	Delete: func(ctx context.Context, p provider.Provider, vpnGateway *otcv1alpha1.VPNGateway) error {
+		return p.DeleteVPNGateway(ctx, vpnGateway.Status.ExternalID)
+	},
+}
@@ -0,0 +1,102 @@
+package controller
+
+import (
+	"slices"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	otcv1alpha1 "github.com/peertech.de/otc-operator/api/v1alpha1"
+)
+
+const (
+	testVPNGatewayName = "vpn-gateway"
+	testVPNGatewayID   = "vpn-gateway-id"
+)
+
+func newTestVPNGateway() *otcv1alpha1.VPNGateway {
+	networkID, subnetID := "network-id", testSubnetID
+	primaryPublicIPID, secondaryPublicIPID := "public-ip-1", "public-ip-2"
+	return &otcv1alpha1.VPNGateway{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       testVPNGatewayName,
+			Namespace:  testNamespace,
+			Finalizers: []string{vpnGatewayFinalizerName},
+		},
+		Spec: otcv1alpha1.VPNGatewaySpec{
+			ProviderConfigRef: testProviderConfigRef,
+			Network:           otcv1alpha1.NetworkDependency{NetworkID: &networkID},
+			Subnet:            otcv1alpha1.SubnetDependency{SubnetID: &subnetID},
+			LocalSubnets:      []string{"10.0.0.0/24"},
+			PrimaryPublicIP:   otcv1alpha1.PublicIPDependency{PublicIPID: &primaryPublicIPID},
+			SecondaryPublicIP: otcv1alpha1.PublicIPDependency{PublicIPID: &secondaryPublicIPID},
+		},
+	}
+}
+
+func TestVPNGatewayLifecycle(t *testing.T) {
+	p := &fakeProvider{}
+	r := newTestManagedReconciler(t, NewVPNGatewayReconciler, p, newTestVPNGateway())
+
+	// The gateway is created with the resolved dependencies.
+	_, vpnGateway := reconcileTestManaged(t, r, newTestVPNGateway())
+	if len(p.vpnGateways.created) != 1 || p.vpnGateways.created[0].SubnetID != testSubnetID ||
+		p.vpnGateways.created[0].SecondaryPublicIPID != "public-ip-2" {
+		t.Fatalf("Expected gateway to be created with its dependencies, got %+v", p.vpnGateways.created)
+	}
+	if vpnGateway.Status.ExternalID != testVPNGatewayID {
+		t.Fatalf("Expected externalID %q, got %q", testVPNGatewayID, vpnGateway.Status.ExternalID)
+	}
+
+	// The gateway is not ready while it is created.
+	_, vpnGateway = reconcileTestManaged(t, r, vpnGateway)
+	expectCondition(t, vpnGateway, condReady, metav1.ConditionFalse, reasonProvisioning)
+
+	// The active gateway reports its addresses.
+	p.vpnGateways.info.Status = "ACTIVE"
+	p.vpnGateways.info.PrimaryAddress = "80.158.0.1"
+	_, vpnGateway = reconcileTestManaged(t, r, vpnGateway)
+	expectCondition(t, vpnGateway, condReady, metav1.ConditionTrue, reasonProvisioned)
+	if vpnGateway.Status.PrimaryAddress != "80.158.0.1" {
+		t.Fatalf("Expected observed address, got %+v", vpnGateway.Status)
+	}
+
+	// Changed local subnets are applied.
+	updateTestManaged(t, r, vpnGateway, func(vpnGateway *otcv1alpha1.VPNGateway) {
+		vpnGateway.Spec.LocalSubnets = []string{"10.0.1.0/24", "10.0.0.0/24"}
+	})
+	_, vpnGateway = reconcileTestManaged(t, r, vpnGateway)
+	if len(p.vpnGateways.updated) != 1 || len(p.vpnGateways.updated[0].LocalSubnets) != 2 {
+		t.Fatalf("Expected local subnets to be updated, got %+v", p.vpnGateways.updated)
+	}
+
+	// The order of the local subnets is no drift.
+	slices.Reverse(p.vpnGateways.info.LocalSubnets)
+	_, vpnGateway = reconcileTestManaged(t, r, vpnGateway)
+	if len(p.vpnGateways.updated) != 1 {
+		t.Fatalf("Expected no further update, got %+v", p.vpnGateways.updated)
+	}
+
+	deleteTestManaged(t, r, vpnGateway)
+	if !slices.Equal(p.vpnGateways.deleted, []string{testVPNGatewayID}) {
+		t.Fatalf("Expected external deletion of %q, got %v", testVPNGatewayID, p.vpnGateways.deleted)
+	}
+}
+
+func TestVPNGatewayNotFound(t *testing.T) {
+	vpnGateway := newTestVPNGateway()
+	vpnGateway.Status.ExternalID = testVPNGatewayID
+	vpnGateway.Status.LastAppliedSpec = vpnGateway.Spec.DeepCopy()
+
+	p := &fakeProvider{}
+	r := newTestManagedReconciler(t, NewVPNGatewayReconciler, p, vpnGateway)
+
+	// The gateway deleted out-of-band is created again.
+	_, vpnGateway = reconcileTestManaged(t, r, vpnGateway)
+	expectRecreate(t, vpnGateway)
+
+	_, vpnGateway = reconcileTestManaged(t, r, vpnGateway)
+	if len(p.vpnGateways.created) != 1 || vpnGateway.Status.ExternalID != testVPNGatewayID {
+		t.Fatalf("Expected gateway to be recreated, got %+v", p.vpnGateways.created)
+	}
+}
@@ -0,0 +1,215 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	gophercloud "github.com/opentelekomcloud/gophertelekomcloud"
+	"github.com/opentelekomcloud/gophertelekomcloud/openstack/ces/v1/alarms"
+)
+
+// NOTE: Possible alarm states:
+// - ok - The metric is within the threshold.
+// - alarm - The condition of the rule is met.
+// - insufficient_data - There is not enough data to evaluate the rule.
+// - invalid - The watched resource no longer exists.
+
+const (
+	alarmStateInvalid = "invalid"
+
+	// alarmActionNotification notifies the SMN topics of an action.
+	alarmActionNotification = "notification"
+)
+
+type CreateAlarmRuleRequest struct {
+	Name               string
+	Description        string
+	Namespace          string
+	MetricName         string
+	ComparisonOperator string
+	Value              string
+	Unit               string
+	Filter             string
+	Period             int
+	Count              int
+	SuppressDuration   int
+	Level              int
+	Enabled            bool
+	AlarmTopicURNs     []string
+	OKTopicURNs        []string
+
+	// dependencies
+	DimensionName  string
+	DimensionValue string
+}
+
+// UpdateAlarmRuleRequest contains the changes to an alarm rule. Cloud Eye can
+// only enable and disable an existing rule.
+type UpdateAlarmRuleRequest struct {
+	Enabled bool
+}
+
+type CreateAlarmRuleResponse struct {
+	ID string
+}
+
+type AlarmRuleInfo struct {
+	ID             string
+	Name           string
+	Namespace      string
+	MetricName     string
+	DimensionName  string
+	DimensionValue string
+	Enabled        bool
+	AlarmState     string
+	AlarmTopicURNs []string
+	OKTopicURNs    []string
+}
+
+func (i *AlarmRuleInfo) State() State {
+	switch {
+	case i.AlarmState == alarmStateInvalid:
+		return Failed
+	case !i.Enabled:
+		return Stopped
+	default:
+		return Ready
+	}
+}
+
+func (i *AlarmRuleInfo) Message() string {
+	switch i.State() {
+	case Ready:
+		return fmt.Sprintf("Alarm rule is enabled with state: %s", i.AlarmState)
+	case Stopped:
+		return "Alarm rule is disabled"
+	case Failed:
+		return fmt.Sprintf(
+			"Alarm rule is invalid, the resource %s=%s no longer exists",
+			i.DimensionName,
+			i.DimensionValue,
+		)
+	default:
+		return fmt.Sprintf("Alarm rule is in an unhandled state: %s", i.AlarmState)
+	}
+}
+
+func (p *provider) CreateAlarmRule(
+	ctx context.Context,
+	r CreateAlarmRuleRequest,
+) (CreateAlarmRuleResponse, error) {
+	value, err := strconv.ParseFloat(r.Value, 64)
+	if err != nil {
+		return CreateAlarmRuleResponse{}, fmt.Errorf("invalid alarm value %q: %w", r.Value, err)
+	}
+
+	actionEnabled := len(r.AlarmTopicURNs) > 0 || len(r.OKTopicURNs) > 0
+	id, err := alarms.CreateAlarm(p.cesClient, alarms.CreateAlarmOpts{
+		AlarmName:        r.Name,
+		AlarmDescription: r.Description,
+		Metric: alarms.MetricForAlarm{
+			Namespace:  r.Namespace,
+			MetricName: r.MetricName,
+			Dimensions: []alarms.MetricsDimension{
+				{Name: r.DimensionName, Value: r.DimensionValue},
+			},
+		},
+		Condition: alarms.Condition{
+			ComparisonOperator: r.ComparisonOperator,
+			Count:              r.Count,
+			Filter:             r.Filter,
+			Period:             r.Period,
+			Unit:               r.Unit,
+			Value:              value,
+			SuppressDuration:   r.SuppressDuration,
+		},
+		AlarmEnabled:       &r.Enabled,
+		AlarmActionEnabled: &actionEnabled,
+		AlarmLevel:         r.Level,
+		AlarmActions:       alarmActions(r.AlarmTopicURNs),
+		OkActions:          alarmActions(r.OKTopicURNs),
+	})
+	if err != nil {
+		return CreateAlarmRuleResponse{}, fmt.Errorf("failed to create alarm rule: %w", err)
+	}
+
+	return CreateAlarmRuleResponse{ID: id}, nil
+}
+
+func (p *provider) GetAlarmRule(ctx context.Context, id string) (*AlarmRuleInfo, error) {
+	rules, err := alarms.ShowAlarm(p.cesClient, id)
+	if err != nil {
+		if _, ok := err.(gophercloud.ErrDefault404); ok {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get alarm rule: %w", err)
+	}
+	if len(rules) == 0 {
+		return nil, ErrNotFound
+	}
+
+	rule := rules[0]
+	info := &AlarmRuleInfo{
+		ID:             rule.AlarmId,
+		Name:           rule.AlarmName,
+		Namespace:      rule.Metric.Namespace,
+		MetricName:     rule.Metric.MetricName,
+		Enabled:        rule.AlarmEnabled,
+		AlarmState:     rule.AlarmState,
+		AlarmTopicURNs: alarmTopicURNs(rule.AlarmActions),
+		OKTopicURNs:    alarmTopicURNs(rule.OkActions),
+	}
+	if len(rule.Metric.Dimensions) > 0 {
+		info.DimensionName = rule.Metric.Dimensions[0].Name
+		info.DimensionValue = rule.Metric.Dimensions[0].Value
+	}
+
+	return info, nil
+}
+
+func (p *provider) UpdateAlarmRule(ctx context.Context, id string, r UpdateAlarmRuleRequest) error {
+	err := alarms.UpdateAlarmAction(p.cesClient, id, alarms.ModifyAlarmActionRequest{
+		AlarmEnabled: r.Enabled,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update alarm rule: %w", err)
+	}
+
+	return nil
+}
+
+func (p *provider) DeleteAlarmRule(ctx context.Context, id string) error {
+	err := alarms.DeleteAlarm(p.cesClient, id)
+	if err != nil {
+		if _, ok := err.(gophercloud.ErrDefault404); ok {
+			return nil
+		}
+		return fmt.Errorf("failed to delete alarm rule: %w", err)
+	}
+
+	return nil
+}
+
+// alarmActions notifies all topics with a single notification action.
+func alarmActions(topicURNs []string) []alarms.AlarmActions {
+	if len(topicURNs) == 0 {
+		return nil
+	}
+
+	return []alarms.AlarmActions{
+		{
+			Type:             alarmActionNotification,
+			NotificationList: topicURNs,
+		},
+	}
+}
+
+// alarmTopicURNs returns the topics notified by the actions.
+func alarmTopicURNs(actions []alarms.AlarmActions) []string {
+	var urns []string
+	for _, action := range actions {
+		urns = append(urns, action.NotificationList...)
+	}
+	return urns
+}
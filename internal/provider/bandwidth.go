@@ -0,0 +1,206 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	gophercloud "github.com/opentelekomcloud/gophertelekomcloud"
+	"github.com/opentelekomcloud/gophertelekomcloud/openstack/networking/v2/bandwidths"
+
+	otcv1alpha1 "github.com/peertech.de/otc-operator/api/v1alpha1"
+	"github.com/peertech.de/otc-operator/internal/retry"
+)
+
+// NOTE: Possible statuses:
+// - NORMAL - The bandwidth is normal.
+// - FREEZED - The bandwidth is frozen.
+
+type CreateBandwidthRequest struct {
+	Name       string
+	Size       int
+	ChargeMode otcv1alpha1.BandwidthChargeMode
+}
+
+type UpdateBandwidthRequest struct {
+	Size int
+}
+
+type CreateBandwidthResponse struct {
+	ID string
+}
+
+type BandwidthInfo struct {
+	ID          string
+	Name        string
+	Size        int
+	ShareType   string
+	ChargeMode  string
+	Status      string
+	PublicIPIDs []string
+}
+
+func (i *BandwidthInfo) State() State {
+	switch i.Status {
+	case "NORMAL", "ACTIVE":
+		return Ready
+	case "FREEZED":
+		return Stopped
+	case "ERROR":
+		return Failed
+	case "CREATING", "PENDING_CREATE", "PENDING_UPDATE":
+		return Provisioning
+	default:
+		return Unknown
+	}
+}
+
+func (i *BandwidthInfo) Message() string {
+	switch i.State() {
+	case Ready:
+		return "Bandwidth is active"
+	case Stopped:
+		return "Bandwidth is frozen"
+	case Failed:
+		return fmt.Sprintf("Bandwidth is in a failed state: %s", i.Status)
+	case Provisioning:
+		return fmt.Sprintf("Bandwidth busy with status: %s", i.Status)
+	default:
+		return fmt.Sprintf("Bandwidth is in an unhandled state: %s", i.Status)
+	}
+}
+
+func (p *provider) CreateBandwidth(
+	ctx context.Context,
+	r CreateBandwidthRequest,
+) (CreateBandwidthResponse, error) {
+	var providerChargeMode string
+	switch r.ChargeMode {
+	case otcv1alpha1.BandwidthChargeModeBandwidth, "":
+		providerChargeMode = "bandwidth"
+	case otcv1alpha1.BandwidthChargeModeTraffic:
+		providerChargeMode = "traffic"
+	default:
+		return CreateBandwidthResponse{}, fmt.Errorf(
+			"unknown bandwidth charge mode: %s",
+			r.ChargeMode,
+		)
+	}
+
+	createOpts := bandwidthCreateOpts{
+		Name:       r.Name,
+		Size:       r.Size,
+		ChargeMode: providerChargeMode,
+	}
+
+	bandwidth, err := bandwidths.Create(p.networkv2Client, createOpts).Extract()
+	if err != nil {
+		return CreateBandwidthResponse{}, fmt.Errorf("failed to create bandwidth: %w", err)
+	}
+
+	if err := p.waitForBandwidth(ctx, bandwidth.ID); err != nil {
+		return CreateBandwidthResponse{}, fmt.Errorf(
+			"failed to wait for bandwidth creation: %w",
+			err,
+		)
+	}
+
+	return CreateBandwidthResponse{ID: bandwidth.ID}, nil
+}
+
+// bandwidthCreateOpts implements bandwidths.CreateOptsBuilder. In contrast to
+// bandwidths.CreateOpts it also supports the charge mode.
+type bandwidthCreateOpts struct {
+	Name       string `json:"name" required:"true"`
+	Size       int    `json:"size" required:"true"`
+	ChargeMode string `json:"charge_mode,omitempty"`
+}
+
+func (opts bandwidthCreateOpts) ToBandwidthCreateMap() (map[string]interface{}, error) {
+	return gophercloud.BuildRequestBody(opts, "bandwidth")
+}
+
+func (p *provider) GetBandwidth(ctx context.Context, id string) (*BandwidthInfo, error) {
+	bandwidth, err := bandwidths.Get(p.networkv2Client, id).Extract()
+	if err != nil {
+		if _, ok := err.(gophercloud.ErrDefault404); ok {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get bandwidth: %w", err)
+	}
+
+	bandwidthInfo := &BandwidthInfo{
+		ID:         bandwidth.ID,
+		Name:       bandwidth.Name,
+		Size:       bandwidth.Size,
+		ShareType:  bandwidth.ShareType,
+		ChargeMode: bandwidth.ChargeMode,
+		Status:     bandwidth.Status,
+	}
+	for _, publicIP := range bandwidth.PublicIpInfo {
+		bandwidthInfo.PublicIPIDs = append(bandwidthInfo.PublicIPIDs, publicIP.ID)
+	}
+
+	return bandwidthInfo, nil
+}
+
+func (p *provider) UpdateBandwidth(
+	ctx context.Context,
+	id string,
+	r UpdateBandwidthRequest,
+) error {
+	updateOpts := bandwidths.UpdateOpts{
+		Size: r.Size,
+	}
+
+	_, err := bandwidths.Update(p.networkv2Client, id, updateOpts).Extract()
+	if err != nil {
+		return fmt.Errorf("failed to update bandwidth: %w", err)
+	}
+
+	return nil
+}
+
+func (p *provider) DeleteBandwidth(ctx context.Context, id string) error {
+	err := bandwidths.Delete(p.networkv2Client, id).ExtractErr()
+	if err != nil {
+		if _, ok := err.(gophercloud.ErrDefault404); ok {
+			return nil
+		}
+		return fmt.Errorf("failed to delete bandwidth: %w", err)
+	}
+
+	return nil
+}
+
+func (p *provider) waitForBandwidth(ctx context.Context, id string) error {
+	err := retry.Do(ctx, func() (bool, error) {
+		info, err := p.GetBandwidth(ctx, id)
+		if err != nil {
+			return true, err
+		}
+
+		switch info.State() {
+		case Ready:
+			return false, nil
+		case Stopped:
+			return false, fmt.Errorf(
+				"bandwidth entered a non-ready terminal state: %s",
+				info.Status,
+			)
+		case Failed:
+			return false, ErrFailedToCreate
+		default: // Provisioning or Unknown
+			return true, nil
+		}
+	},
+		retry.WithMaxAttempts(defaultMaxRetryAttempts),
+		retry.WithDelay(5*time.Second),
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to wait for bandwidth creation: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,512 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/opentelekomcloud/gophertelekomcloud/openstack/obs"
+
+	otcv1alpha1 "github.com/peertech.de/otc-operator/api/v1alpha1"
+)
+
+const (
+	// bucketDeleteBatchSize is the maximum number of objects deleted with a
+	// single request.
+	bucketDeleteBatchSize = 1000
+)
+
+// BucketEncryption is the default server-side encryption of a bucket.
+type BucketEncryption struct {
+	Algorithm otcv1alpha1.BucketEncryptionAlgorithm
+
+	// KMSKeyID is the ID of the KMS key for the kms algorithm. If empty, the
+	// default key of OBS is used.
+	KMSKeyID string
+}
+
+// BucketConfig contains the configuration of a bucket. It is applied as a
+// whole, settings which are not set are removed from the bucket.
+type BucketConfig struct {
+	Versioning     bool
+	LifecycleRules []otcv1alpha1.BucketLifecycleRule
+	Encryption     *BucketEncryption
+	CORSRules      []otcv1alpha1.BucketCORSRule
+	Policy         string
+}
+
+type CreateBucketRequest struct {
+	Name string
+	BucketConfig
+}
+
+type UpdateBucketRequest struct {
+	BucketConfig
+}
+
+type CreateBucketResponse struct {
+	ID string
+}
+
+type BucketInfo struct {
+	Name     string
+	Region   string
+	Endpoint string
+
+	// Versioning is the versioning status of the bucket. It is empty if
+	// versioning was never enabled.
+	Versioning     string
+	LifecycleRules []otcv1alpha1.BucketLifecycleRule
+	Encryption     *BucketEncryption
+	CORSRules      []otcv1alpha1.BucketCORSRule
+	Policy         string
+}
+
+// State returns Ready, as a bucket is available as soon as it exists.
+func (i *BucketInfo) State() State {
+	return Ready
+}
+
+func (i *BucketInfo) Message() string {
+	return "Bucket is available"
+}
+
+// VersioningEnabled reports whether versioning is enabled. A suspended
+// versioning keeps the existing versions, but creates no new ones.
+func (i *BucketInfo) VersioningEnabled() bool {
+	return i.Versioning == string(obs.VersioningStatusEnabled)
+}
+
+// isOBSNotFound checks if the error is an OBS error with status 404.
+func isOBSNotFound(err error) bool {
+	var obsErr obs.ObsError
+	return errors.As(err, &obsErr) && obsErr.StatusCode == http.StatusNotFound
+}
+
+// obs returns the OBS client, which is only available with access key
+// credentials.
+func (p *provider) obs() (*obs.ObsClient, error) {
+	if p.obsClient == nil {
+		return nil, ErrNoAccessKey
+	}
+	return p.obsClient, nil
+}
+
+// CreateBucket creates an OBS bucket in the region of the provider and
+// applies its configuration. Creating a bucket which is already owned by the
+// account succeeds, so a failed configuration can be retried.
+func (p *provider) CreateBucket(
+	ctx context.Context,
+	r CreateBucketRequest,
+) (CreateBucketResponse, error) {
+	client, err := p.obs()
+	if err != nil {
+		return CreateBucketResponse{}, err
+	}
+
+	_, err = client.CreateBucket(&obs.CreateBucketInput{
+		Bucket: r.Name,
+		BucketLocation: obs.BucketLocation{
+			Location: p.region,
+		},
+	})
+	if err != nil {
+		var obsErr obs.ObsError
+		if !errors.As(err, &obsErr) || obsErr.Code != "BucketAlreadyOwnedByYou" {
+			return CreateBucketResponse{}, fmt.Errorf("failed to create bucket: %w", err)
+		}
+	}
+
+	err = p.UpdateBucket(ctx, r.Name, UpdateBucketRequest{BucketConfig: r.BucketConfig})
+	if err != nil {
+		return CreateBucketResponse{}, err
+	}
+
+	return CreateBucketResponse{ID: r.Name}, nil
+}
+
+func (p *provider) GetBucket(ctx context.Context, name string) (*BucketInfo, error) {
+	client, err := p.obs()
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = client.HeadBucket(name)
+	if err != nil {
+		if isOBSNotFound(err) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get bucket: %w", err)
+	}
+
+	bucketInfo := &BucketInfo{
+		Name:     name,
+		Endpoint: p.bucketEndpoint(name),
+	}
+
+	location, err := client.GetBucketLocation(name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get bucket location: %w", err)
+	}
+	bucketInfo.Region = location.Location
+
+	versioning, err := client.GetBucketVersioning(name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get bucket versioning: %w", err)
+	}
+	bucketInfo.Versioning = string(versioning.Status)
+
+	// The remaining settings return 404 if they are not configured.
+	lifecycle, err := client.GetBucketLifecycleConfiguration(name)
+	if err != nil && !isOBSNotFound(err) {
+		return nil, fmt.Errorf("failed to get bucket lifecycle rules: %w", err)
+	}
+	if err == nil {
+		bucketInfo.LifecycleRules = fromOBSLifecycleRules(lifecycle.LifecycleRules)
+	}
+
+	encryption, err := client.GetBucketEncryption(name)
+	if err != nil && !isOBSNotFound(err) {
+		return nil, fmt.Errorf("failed to get bucket encryption: %w", err)
+	}
+	if err == nil && encryption.SSEAlgorithm != "" {
+		bucketInfo.Encryption = &BucketEncryption{
+			Algorithm: otcv1alpha1.BucketEncryptionAlgorithm(encryption.SSEAlgorithm),
+			KMSKeyID:  encryption.KMSMasterKeyID,
+		}
+	}
+
+	cors, err := client.GetBucketCors(name)
+	if err != nil && !isOBSNotFound(err) {
+		return nil, fmt.Errorf("failed to get bucket CORS rules: %w", err)
+	}
+	if err == nil {
+		bucketInfo.CORSRules = fromOBSCorsRules(cors.CorsRules)
+	}
+
+	policy, err := client.GetBucketPolicy(name)
+	if err != nil && !isOBSNotFound(err) {
+		return nil, fmt.Errorf("failed to get bucket policy: %w", err)
+	}
+	if err == nil {
+		bucketInfo.Policy = policy.Policy
+	}
+
+	return bucketInfo, nil
+}
+
+// UpdateBucket replaces the configuration of the bucket.
+func (p *provider) UpdateBucket(ctx context.Context, name string, r UpdateBucketRequest) error {
+	client, err := p.obs()
+	if err != nil {
+		return err
+	}
+
+	// Versioning can not be disabled once it was enabled, only suspended. A
+	// bucket which never had versioning enabled is left untouched.
+	versioning, err := client.GetBucketVersioning(name)
+	if err != nil {
+		return fmt.Errorf("failed to get bucket versioning: %w", err)
+	}
+	status := versioning.Status
+	if r.Versioning {
+		status = obs.VersioningStatusEnabled
+	} else if status == obs.VersioningStatusEnabled {
+		status = obs.VersioningStatusSuspended
+	}
+	if status != versioning.Status {
+		_, err = client.SetBucketVersioning(&obs.SetBucketVersioningInput{
+			Bucket: name,
+			BucketVersioningConfiguration: obs.BucketVersioningConfiguration{
+				Status: status,
+			},
+		})
+		if err != nil {
+			return fmt.Errorf("failed to set bucket versioning: %w", err)
+		}
+	}
+
+	if len(r.LifecycleRules) > 0 {
+		_, err = client.SetBucketLifecycleConfiguration(&obs.SetBucketLifecycleConfigurationInput{
+			Bucket: name,
+			BucketLifecycleConfiguration: obs.BucketLifecycleConfiguration{
+				LifecycleRules: toOBSLifecycleRules(r.LifecycleRules),
+			},
+		})
+	} else {
+		_, err = client.DeleteBucketLifecycleConfiguration(name)
+	}
+	if err != nil && !isOBSNotFound(err) {
+		return fmt.Errorf("failed to set bucket lifecycle rules: %w", err)
+	}
+
+	if r.Encryption != nil {
+		_, err = client.SetBucketEncryption(&obs.SetBucketEncryptionInput{
+			Bucket: name,
+			BucketEncryptionConfiguration: obs.BucketEncryptionConfiguration{
+				SSEAlgorithm:   string(r.Encryption.Algorithm),
+				KMSMasterKeyID: r.Encryption.KMSKeyID,
+			},
+		})
+	} else {
+		_, err = client.DeleteBucketEncryption(name)
+	}
+	if err != nil && !isOBSNotFound(err) {
+		return fmt.Errorf("failed to set bucket encryption: %w", err)
+	}
+
+	if len(r.CORSRules) > 0 {
+		_, err = client.SetBucketCors(&obs.SetBucketCorsInput{
+			Bucket: name,
+			BucketCors: obs.BucketCors{
+				CorsRules: toOBSCorsRules(r.CORSRules),
+			},
+		})
+	} else {
+		_, err = client.DeleteBucketCors(name)
+	}
+	if err != nil && !isOBSNotFound(err) {
+		return fmt.Errorf("failed to set bucket CORS rules: %w", err)
+	}
+
+	if r.Policy != "" {
+		_, err = client.SetBucketPolicy(&obs.SetBucketPolicyInput{
+			Bucket: name,
+			Policy: r.Policy,
+		})
+	} else {
+		_, err = client.DeleteBucketPolicy(name)
+	}
+	if err != nil && !isOBSNotFound(err) {
+		return fmt.Errorf("failed to set bucket policy: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteBucket deletes an OBS bucket. If forceDestroy is set, all object
+// versions, delete markers and incomplete multipart uploads are removed
+// first, otherwise the deletion of a non-empty bucket fails.
+func (p *provider) DeleteBucket(ctx context.Context, name string, forceDestroy bool) error {
+	client, err := p.obs()
+	if err != nil {
+		return err
+	}
+
+	if forceDestroy {
+		if err := emptyBucket(ctx, client, name); err != nil {
+			if isOBSNotFound(err) {
+				return nil
+			}
+			return fmt.Errorf("failed to empty bucket: %w", err)
+		}
+	}
+
+	_, err = client.DeleteBucket(name)
+	if err != nil {
+		if isOBSNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to delete bucket: %w", err)
+	}
+
+	return nil
+}
+
+// emptyBucket deletes all object versions and delete markers of the bucket
+// and aborts its incomplete multipart uploads.
+func emptyBucket(ctx context.Context, client *obs.ObsClient, name string) error {
+	listOpts := &obs.ListVersionsInput{
+		Bucket: name,
+		ListObjsInput: obs.ListObjsInput{
+			MaxKeys: bucketDeleteBatchSize,
+		},
+	}
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		versions, err := client.ListVersions(listOpts)
+		if err != nil {
+			return err
+		}
+
+		objects := make(
+			[]obs.ObjectToDelete,
+			0,
+			len(versions.Versions)+len(versions.DeleteMarkers),
+		)
+		for _, v := range versions.Versions {
+			objects = append(objects, obs.ObjectToDelete{Key: v.Key, VersionId: v.VersionId})
+		}
+		for _, m := range versions.DeleteMarkers {
+			objects = append(objects, obs.ObjectToDelete{Key: m.Key, VersionId: m.VersionId})
+		}
+
+		// A listing page can contain up to MaxKeys versions and delete
+		// markers each, so the objects are deleted in batches.
+		for start := 0; start < len(objects); start += bucketDeleteBatchSize {
+			end := min(start+bucketDeleteBatchSize, len(objects))
+			resp, err := client.DeleteObjects(&obs.DeleteObjectsInput{
+				Bucket:  name,
+				Quiet:   true,
+				Objects: objects[start:end],
+			})
+			if err != nil {
+				return err
+			}
+			if len(resp.Errors) > 0 {
+				return fmt.Errorf(
+					"failed to delete object %q: %s",
+					resp.Errors[0].Key,
+					resp.Errors[0].Message,
+				)
+			}
+		}
+
+		if !versions.IsTruncated {
+			break
+		}
+		listOpts.KeyMarker = versions.NextKeyMarker
+		listOpts.VersionIdMarker = versions.NextVersionIdMarker
+	}
+
+	uploadOpts := &obs.ListMultipartUploadsInput{
+		Bucket:     name,
+		MaxUploads: bucketDeleteBatchSize,
+	}
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		uploads, err := client.ListMultipartUploads(uploadOpts)
+		if err != nil {
+			return err
+		}
+
+		for _, u := range uploads.Uploads {
+			_, err := client.AbortMultipartUpload(&obs.AbortMultipartUploadInput{
+				Bucket:   name,
+				Key:      u.Key,
+				UploadId: u.UploadId,
+			})
+			if err != nil && !isOBSNotFound(err) {
+				return err
+			}
+		}
+
+		if !uploads.IsTruncated {
+			break
+		}
+		uploadOpts.KeyMarker = uploads.NextKeyMarker
+		uploadOpts.UploadIdMarker = uploads.NextUploadIdMarker
+	}
+
+	return nil
+}
+
+// bucketEndpoint returns the virtual-hosted-style endpoint of the bucket.
+func (p *provider) bucketEndpoint(name string) string {
+	u, err := url.Parse(p.obsEndpoint)
+	if err != nil || u.Host == "" {
+		return ""
+	}
+	return fmt.Sprintf("https://%s.%s", name, u.Host)
+}
+
+func toOBSLifecycleRules(rules []otcv1alpha1.BucketLifecycleRule) []obs.LifecycleRule {
+	obsRules := make([]obs.LifecycleRule, 0, len(rules))
+	for _, rule := range rules {
+		obsRule := obs.LifecycleRule{
+			ID:     rule.ID,
+			Prefix: rule.Prefix,
+			Status: obs.RuleStatusDisabled,
+			Expiration: obs.Expiration{
+				Days: rule.ExpirationDays,
+			},
+			NoncurrentVersionExpiration: obs.NoncurrentVersionExpiration{
+				NoncurrentDays: rule.NoncurrentVersionExpirationDays,
+			},
+		}
+		if rule.Enabled {
+			obsRule.Status = obs.RuleStatusEnabled
+		}
+		for _, t := range rule.Transitions {
+			obsRule.Transitions = append(obsRule.Transitions, obs.Transition{
+				Days:         t.Days,
+				StorageClass: obs.StorageClassType(t.StorageClass),
+			})
+		}
+		obsRules = append(obsRules, obsRule)
+	}
+	return obsRules
+}
+
+func fromOBSLifecycleRules(obsRules []obs.LifecycleRule) []otcv1alpha1.BucketLifecycleRule {
+	if len(obsRules) == 0 {
+		return nil
+	}
+
+	rules := make([]otcv1alpha1.BucketLifecycleRule, 0, len(obsRules))
+	for _, obsRule := range obsRules {
+		rule := otcv1alpha1.BucketLifecycleRule{
+			ID:                              obsRule.ID,
+			Prefix:                          obsRule.Prefix,
+			Enabled:                         obsRule.Status == obs.RuleStatusEnabled,
+			ExpirationDays:                  obsRule.Expiration.Days,
+			NoncurrentVersionExpirationDays: obsRule.NoncurrentVersionExpiration.NoncurrentDays,
+		}
+		for _, t := range obsRule.Transitions {
+			rule.Transitions = append(rule.Transitions, otcv1alpha1.BucketTransition{
+				Days:         t.Days,
+				StorageClass: otcv1alpha1.BucketStorageClass(t.StorageClass),
+			})
+		}
+		rules = append(rules, rule)
+	}
+	return rules
+}
+
+func toOBSCorsRules(rules []otcv1alpha1.BucketCORSRule) []obs.CorsRule {
+	obsRules := make([]obs.CorsRule, 0, len(rules))
+	for _, rule := range rules {
+		methods := make([]string, 0, len(rule.AllowedMethods))
+		for _, m := range rule.AllowedMethods {
+			methods = append(methods, string(m))
+		}
+		obsRules = append(obsRules, obs.CorsRule{
+			AllowedOrigin: rule.AllowedOrigins,
+			AllowedMethod: methods,
+			AllowedHeader: rule.AllowedHeaders,
+			ExposeHeader:  rule.ExposeHeaders,
+			MaxAgeSeconds: rule.MaxAgeSeconds,
+		})
+	}
+	return obsRules
+}
+
+func fromOBSCorsRules(obsRules []obs.CorsRule) []otcv1alpha1.BucketCORSRule {
+	if len(obsRules) == 0 {
+		return nil
+	}
+
+	rules := make([]otcv1alpha1.BucketCORSRule, 0, len(obsRules))
+	for _, obsRule := range obsRules {
+		methods := make([]otcv1alpha1.BucketCORSMethod, 0, len(obsRule.AllowedMethod))
+		for _, m := range obsRule.AllowedMethod {
+			methods = append(methods, otcv1alpha1.BucketCORSMethod(m))
+		}
+		rules = append(rules, otcv1alpha1.BucketCORSRule{
+			AllowedOrigins: obsRule.AllowedOrigin,
+			AllowedMethods: methods,
+			AllowedHeaders: obsRule.AllowedHeader,
+			ExposeHeaders:  obsRule.ExposeHeader,
+			MaxAgeSeconds:  obsRule.MaxAgeSeconds,
+		})
+	}
+	return rules
+}
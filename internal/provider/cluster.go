@@ -0,0 +1,265 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	gophercloud "github.com/opentelekomcloud/gophertelekomcloud"
+	"github.com/opentelekomcloud/gophertelekomcloud/openstack/cce/v3/clusters"
+	"sigs.k8s.io/yaml"
+
+	otcv1alpha1 "github.com/peertech.de/otc-operator/api/v1alpha1"
+)
+
+// NOTE: Possible phases:
+// - Available - The cluster is running properly.
+// - Unavailable - The cluster is exceptional.
+// - ScalingUp - The cluster is being scaled out.
+// - ScalingDown - The cluster is being scaled in.
+// - Creating - The cluster is being created.
+// - Deleting - The cluster is being deleted.
+// - Upgrading - The cluster is being upgraded.
+// - Resizing - The cluster flavor is being changed.
+// - RollingBack - The cluster is being rolled back.
+// - RollbackFailed - The cluster rollback failed.
+// - Hibernating - The cluster is being hibernated.
+// - Hibernation - The cluster is hibernated.
+// - Awaking - The cluster is being woken up.
+// - Empty - The cluster has no resources.
+
+type CreateClusterRequest struct {
+	Name                 string
+	Flavor               string
+	Version              string
+	Description          string
+	ContainerNetworkMode otcv1alpha1.ClusterContainerNetworkMode
+	ContainerNetworkCIDR string
+	ServiceCIDR          string
+
+	// dependencies
+	NetworkID       string
+	SubnetID        string
+	SecurityGroupID string
+}
+
+type UpdateClusterRequest struct {
+	// Description replaces the description of the cluster. Nil leaves the
+	// description untouched.
+	Description *string
+}
+
+type CreateClusterResponse struct {
+	ID string
+}
+
+type ClusterInfo struct {
+	ID          string
+	Name        string
+	Flavor      string
+	Version     string
+	Description string
+	Phase       string
+	Reason      string
+	Endpoint    string
+
+	// dependencies
+	NetworkID       string
+	SubnetID        string
+	SecurityGroupID string
+}
+
+func (i *ClusterInfo) State() State {
+	switch i.Phase {
+	case "Available":
+		return Ready
+	case "Hibernation":
+		return Stopped
+	case "Unavailable", "RollbackFailed", "Error":
+		return Failed
+	case "Creating",
+		"Deleting",
+		"ScalingUp",
+		"ScalingDown",
+		"Upgrading",
+		"Resizing",
+		"RollingBack",
+		"Hibernating",
+		"Awaking",
+		"Empty":
+		return Provisioning
+	default:
+		return Unknown
+	}
+}
+
+func (i *ClusterInfo) Message() string {
+	switch i.State() {
+	case Ready:
+		return "Cluster is available"
+	case Stopped:
+		return "Cluster is hibernated"
+	case Failed:
+		if i.Reason != "" {
+			return fmt.Sprintf("Cluster is in a failed state: %s (%s)", i.Phase, i.Reason)
+		}
+		return fmt.Sprintf("Cluster is in a failed state: %s", i.Phase)
+	case Provisioning:
+		return fmt.Sprintf("Cluster busy with phase: %s", i.Phase)
+	default:
+		return fmt.Sprintf("Cluster is in an unhandled state: %s", i.Phase)
+	}
+}
+
+// CreateCluster creates a CCE cluster. It does not wait for the cluster to
+// become available, as this takes up to half an hour. The readiness is
+// checked by the caller.
+func (p *provider) CreateCluster(
+	ctx context.Context,
+	r CreateClusterRequest,
+) (CreateClusterResponse, error) {
+	containerNetworkMode := r.ContainerNetworkMode
+	if containerNetworkMode == "" {
+		containerNetworkMode = otcv1alpha1.ClusterContainerNetworkOverlayL2
+	}
+
+	createOpts := clusters.CreateOpts{
+		Kind:       "Cluster",
+		ApiVersion: "v3",
+		Metadata: clusters.CreateMetaData{
+			Name: r.Name,
+		},
+		Spec: clusters.Spec{
+			Type:        "VirtualMachine",
+			Flavor:      r.Flavor,
+			Version:     r.Version,
+			Description: r.Description,
+			ContainerNetwork: clusters.ContainerNetworkSpec{
+				Mode: string(containerNetworkMode),
+				Cidr: r.ContainerNetworkCIDR,
+			},
+			Authentication: clusters.AuthenticationSpec{
+				Mode:                "rbac",
+				AuthenticatingProxy: map[string]string{},
+			},
+			KubernetesSvcIpRange: r.ServiceCIDR,
+
+			// dependencies
+			HostNetwork: clusters.HostNetworkSpec{
+				VpcId:           r.NetworkID,
+				SubnetId:        r.SubnetID,
+				SecurityGroupId: r.SecurityGroupID,
+			},
+		},
+	}
+
+	cluster, err := clusters.Create(p.cceClient, createOpts)
+	if err != nil {
+		return CreateClusterResponse{}, fmt.Errorf("failed to create cluster: %w", err)
+	}
+
+	return CreateClusterResponse{ID: cluster.Metadata.Id}, nil
+}
+
+func (p *provider) GetCluster(ctx context.Context, id string) (*ClusterInfo, error) {
+	cluster, err := clusters.Get(p.cceClient, id)
+	if err != nil {
+		if _, ok := err.(gophercloud.ErrDefault404); ok {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get cluster: %w", err)
+	}
+
+	clusterInfo := &ClusterInfo{
+		ID:          cluster.Metadata.Id,
+		Name:        cluster.Metadata.Name,
+		Flavor:      cluster.Spec.Flavor,
+		Version:     cluster.Spec.Version,
+		Description: cluster.Spec.Description,
+		Phase:       cluster.Status.Phase,
+		Reason:      cluster.Status.Reason,
+
+		// dependencies
+		NetworkID:       cluster.Spec.HostNetwork.VpcId,
+		SubnetID:        cluster.Spec.HostNetwork.SubnetId,
+		SecurityGroupID: cluster.Spec.HostNetwork.SecurityGroupId,
+	}
+
+	// NOTE: Depending on the region, the endpoints are either typed URLs or a
+	// single entry with the internal and external address.
+	for _, endpoint := range cluster.Status.Endpoints {
+		switch {
+		case endpoint.Type == "Internal":
+			clusterInfo.Endpoint = endpoint.Url
+		case endpoint.Internal != "":
+			clusterInfo.Endpoint = endpoint.Internal
+		}
+	}
+
+	return clusterInfo, nil
+}
+
+// GetClusterKubeconfig returns the kubeconfig of the cluster in YAML format.
+func (p *provider) GetClusterKubeconfig(ctx context.Context, id string) ([]byte, error) {
+	cert, err := clusters.GetCert(p.cceClient, id)
+	if err != nil {
+		if _, ok := err.(gophercloud.ErrDefault404); ok {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get cluster certificate: %w", err)
+	}
+
+	kubeconfig, err := yaml.Marshal(cert)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal kubeconfig: %w", err)
+	}
+
+	return kubeconfig, nil
+}
+
+// updateClusterOpts mirrors clusters.UpdateOpts. The description is always
+// sent, as the SDK omits it when it is cleared.
+type updateClusterOpts struct {
+	Spec updateClusterSpec `json:"spec"`
+}
+
+type updateClusterSpec struct {
+	Description string `json:"description"`
+}
+
+func (p *provider) UpdateCluster(ctx context.Context, id string, r UpdateClusterRequest) error {
+	if r.Description == nil {
+		return nil
+	}
+
+	updateOpts := updateClusterOpts{
+		Spec: updateClusterSpec{
+			Description: *r.Description,
+		},
+	}
+
+	_, err := p.cceClient.Put(
+		p.cceClient.ServiceURL("clusters", id),
+		updateOpts,
+		nil,
+		&gophercloud.RequestOpts{OkCodes: []int{200}},
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update cluster: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteCluster deletes a CCE cluster. It does not wait for the deletion to
+// complete.
+func (p *provider) DeleteCluster(ctx context.Context, id string) error {
+	err := clusters.Delete(p.cceClient, id, clusters.DeleteQueryParams{})
+	if err != nil {
+		if _, ok := err.(gophercloud.ErrDefault404); ok {
+			return nil
+		}
+		return fmt.Errorf("failed to delete cluster: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,93 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	gophercloud "github.com/opentelekomcloud/gophertelekomcloud"
+	customergateway "github.com/opentelekomcloud/gophertelekomcloud/openstack/evpn/v5/customer-gateway"
+)
+
+type CreateCustomerGatewayRequest struct {
+	Name      string
+	IPAddress string
+	BGPASN    int
+}
+
+type CreateCustomerGatewayResponse struct {
+	ID string
+}
+
+type UpdateCustomerGatewayRequest struct{}
+
+type CustomerGatewayInfo struct {
+	ID        string
+	Name      string
+	IPAddress string
+	BGPASN    int
+}
+
+// State returns the state of the customer gateway. Customer gateways are
+// ready as soon as they exist.
+func (i *CustomerGatewayInfo) State() State {
+	return Ready
+}
+
+func (i *CustomerGatewayInfo) Message() string {
+	return "Customer gateway is available"
+}
+
+func (p *provider) CreateCustomerGateway(
+	ctx context.Context,
+	r CreateCustomerGatewayRequest,
+) (CreateCustomerGatewayResponse, error) {
+	bgpASN := r.BGPASN
+	createOpts := customergateway.CreateOpts{
+		Name:    r.Name,
+		IdType:  "ip",
+		IdValue: r.IPAddress,
+		BgpAsn:  &bgpASN,
+	}
+
+	customerGateway, err := customergateway.Create(p.evpnClient, createOpts)
+	if err != nil {
+		return CreateCustomerGatewayResponse{}, fmt.Errorf(
+			"failed to create customer gateway: %w",
+			err,
+		)
+	}
+
+	return CreateCustomerGatewayResponse{ID: customerGateway.ID}, nil
+}
+
+func (p *provider) GetCustomerGateway(
+	ctx context.Context,
+	id string,
+) (*CustomerGatewayInfo, error) {
+	customerGateway, err := customergateway.Get(p.evpnClient, id)
+	if err != nil {
+		if _, ok := err.(gophercloud.ErrDefault404); ok {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get customer gateway: %w", err)
+	}
+
+	return &CustomerGatewayInfo{
+		ID:        customerGateway.ID,
+		Name:      customerGateway.Name,
+		IPAddress: customerGateway.IdValue,
+		BGPASN:    customerGateway.BgpAsn,
+	}, nil
+}
+
+func (p *provider) DeleteCustomerGateway(ctx context.Context, id string) error {
+	err := customergateway.Delete(p.evpnClient, id)
+	if err != nil {
+		if _, ok := err.(gophercloud.ErrDefault404); ok {
+			return nil
+		}
+		return fmt.Errorf("failed to delete customer gateway: %w", err)
+	}
+
+	return nil
+}
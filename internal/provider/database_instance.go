@@ -0,0 +1,301 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	gophercloud "github.com/opentelekomcloud/gophertelekomcloud"
+	"github.com/opentelekomcloud/gophertelekomcloud/openstack/rds/v3/backups"
+	"github.com/opentelekomcloud/gophertelekomcloud/openstack/rds/v3/instances"
+
+	otcv1alpha1 "github.com/peertech.de/otc-operator/api/v1alpha1"
+)
+
+// NOTE: Possible statuses:
+// - BUILD - The instance is being created.
+// - ACTIVE - The instance is available.
+// - FAILED - The instance is abnormal.
+// - FROZEN - The instance is frozen.
+// - MODIFYING - The storage of the instance is being scaled up.
+// - REBOOTING - The instance is being rebooted.
+// - RESTORING - A backup is being restored to the instance.
+// - MODIFYING INSTANCE TYPE - The instance is changing from single to HA.
+// - SWITCHOVER - A primary/standby switchover is in progress.
+// - MIGRATING - The instance is being migrated.
+// - BACKING UP - The instance is being backed up.
+// - MODIFYING DATABASE PORT - The database port is being changed.
+// - STORAGE FULL - The storage of the instance is full.
+// - SHUTDOWN - The instance is stopped.
+
+const (
+	// databaseBackupPeriod is the days of the week the automated backups
+	// are taken on.
+	databaseBackupPeriod = "1,2,3,4,5,6,7"
+)
+
+type CreateDatabaseInstanceRequest struct {
+	Name              string
+	Engine            otcv1alpha1.DatabaseEngine
+	Version           string
+	Flavor            string
+	Volume            otcv1alpha1.DatabaseVolume
+	HAMode            otcv1alpha1.DatabaseHAMode
+	ReplicationMode   otcv1alpha1.DatabaseReplicationMode
+	AvailabilityZones []string
+	Port              *int32
+	BackupPolicy      otcv1alpha1.DatabaseBackupPolicy
+	AdminPassword     string
+
+	// dependencies
+	NetworkID       string
+	SubnetID        string
+	SecurityGroupID string
+	KMSKeyID        string
+}
+
+// UpdateDatabaseInstanceRequest contains the changes to a database instance.
+// The flavor and the volume size are changed by jobs which cannot run at the
+// same time, so only one of them should be set per update.
+type UpdateDatabaseInstanceRequest struct {
+	// Flavor resizes the instance to the given flavor. An empty string leaves
+	// the flavor untouched.
+	Flavor string
+
+	// VolumeSize extends the storage to the given size in GB. Zero leaves the
+	// size untouched.
+	VolumeSize int
+
+	// BackupPolicy replaces the backup policy. Nil leaves the policy
+	// untouched.
+	BackupPolicy *otcv1alpha1.DatabaseBackupPolicy
+}
+
+type CreateDatabaseInstanceResponse struct {
+	ID string
+}
+
+type DatabaseInstanceInfo struct {
+	ID           string
+	Name         string
+	Engine       string
+	Version      string
+	Flavor       string
+	VolumeType   string
+	VolumeSize   int
+	Host         string
+	Port         int32
+	Username     string
+	BackupPolicy otcv1alpha1.DatabaseBackupPolicy
+	Status       string
+
+	// dependencies
+	NetworkID       string
+	SubnetID        string
+	SecurityGroupID string
+	KMSKeyID        string
+}
+
+func (i *DatabaseInstanceInfo) State() State {
+	switch i.Status {
+	case "ACTIVE":
+		return Ready
+	case "SHUTDOWN":
+		return Stopped
+	case "FAILED", "FROZEN", "STORAGE FULL":
+		return Failed
+	case "BUILD",
+		"MODIFYING",
+		"REBOOTING",
+		"RESTORING",
+		"MODIFYING INSTANCE TYPE",
+		"SWITCHOVER",
+		"MIGRATING",
+		"BACKING UP",
+		"MODIFYING DATABASE PORT":
+		return Provisioning
+	default:
+		return Unknown
+	}
+}
+
+func (i *DatabaseInstanceInfo) Message() string {
+	switch i.State() {
+	case Ready:
+		return "Database instance is available"
+	case Stopped:
+		return "Database instance is stopped"
+	case Failed:
+		return fmt.Sprintf("Database instance is in a failed state: %s", i.Status)
+	case Provisioning:
+		return fmt.Sprintf("Database instance busy with status: %s", i.Status)
+	default:
+		return fmt.Sprintf("Database instance is in an unhandled state: %s", i.Status)
+	}
+}
+
+// CreateDatabaseInstance creates an RDS instance. It does not wait for the
+// instance to become available, as this takes several minutes. The readiness
+// is checked by the caller.
+func (p *provider) CreateDatabaseInstance(
+	ctx context.Context,
+	r CreateDatabaseInstanceRequest,
+) (CreateDatabaseInstanceResponse, error) {
+	volumeType := r.Volume.Type
+	if volumeType == "" {
+		volumeType = otcv1alpha1.DatabaseVolumeTypeUltraHigh
+	}
+
+	createOpts := instances.CreateRdsOpts{
+		Name: r.Name,
+		Datastore: &instances.Datastore{
+			Type:    string(r.Engine),
+			Version: r.Version,
+		},
+		FlavorRef: r.Flavor,
+		Volume: &instances.Volume{
+			Type: string(volumeType),
+			Size: r.Volume.Size,
+		},
+		BackupStrategy: &instances.BackupStrategy{
+			StartTime: r.BackupPolicy.StartTime,
+			KeepDays:  r.BackupPolicy.KeepDays,
+		},
+		Password:         r.AdminPassword,
+		Region:           p.region,
+		AvailabilityZone: strings.Join(r.AvailabilityZones, ","),
+
+		// dependencies
+		VpcId:            r.NetworkID,
+		SubnetId:         r.SubnetID,
+		SecurityGroupId:  r.SecurityGroupID,
+		DiskEncryptionId: r.KMSKeyID,
+	}
+	if r.HAMode == otcv1alpha1.DatabaseHAModeHA {
+		replicationMode := r.ReplicationMode
+		if replicationMode == "" {
+			replicationMode = otcv1alpha1.DatabaseReplicationAsync
+		}
+		createOpts.Ha = &instances.Ha{
+			Mode:            "Ha",
+			ReplicationMode: string(replicationMode),
+		}
+	}
+	if r.Port != nil {
+		createOpts.Port = strconv.Itoa(int(*r.Port))
+	}
+
+	instance, err := instances.Create(p.rdsClient, createOpts)
+	if err != nil {
+		return CreateDatabaseInstanceResponse{}, fmt.Errorf(
+			"failed to create database instance: %w",
+			err,
+		)
+	}
+
+	return CreateDatabaseInstanceResponse{ID: instance.Instance.Id}, nil
+}
+
+func (p *provider) GetDatabaseInstance(
+	ctx context.Context,
+	id string,
+) (*DatabaseInstanceInfo, error) {
+	resp, err := instances.List(p.rdsClient, instances.ListOpts{Id: id})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get database instance: %w", err)
+	}
+	if len(resp.Instances) == 0 {
+		return nil, ErrNotFound
+	}
+	instance := resp.Instances[0]
+
+	instanceInfo := &DatabaseInstanceInfo{
+		ID:         instance.Id,
+		Name:       instance.Name,
+		Engine:     instance.DataStore.Type,
+		Version:    instance.DataStore.Version,
+		Flavor:     instance.FlavorRef,
+		VolumeType: instance.Volume.Type,
+		VolumeSize: instance.Volume.Size,
+		Port:       int32(instance.Port),
+		Username:   instance.DbUserName,
+		BackupPolicy: otcv1alpha1.DatabaseBackupPolicy{
+			StartTime: instance.BackupStrategy.StartTime,
+			KeepDays:  instance.BackupStrategy.KeepDays,
+		},
+		Status: instance.Status,
+
+		// dependencies
+		NetworkID:       instance.VpcId,
+		SubnetID:        instance.SubnetId,
+		SecurityGroupID: instance.SecurityGroupId,
+		KMSKeyID:        instance.DiskEncryptionId,
+	}
+	if len(instance.PrivateIps) > 0 {
+		instanceInfo.Host = instance.PrivateIps[0]
+	}
+
+	return instanceInfo, nil
+}
+
+// UpdateDatabaseInstance applies the changes to the database instance. It
+// does not wait for the resize jobs to complete, the instance reports a busy
+// status until they are done.
+func (p *provider) UpdateDatabaseInstance(
+	ctx context.Context,
+	id string,
+	r UpdateDatabaseInstanceRequest,
+) error {
+	if r.BackupPolicy != nil {
+		keepDays := r.BackupPolicy.KeepDays
+		updateOpts := backups.UpdateOpts{
+			InstanceId: id,
+			KeepDays:   &keepDays,
+		}
+		if keepDays > 0 {
+			updateOpts.StartTime = r.BackupPolicy.StartTime
+			updateOpts.Period = databaseBackupPeriod
+		}
+
+		if err := backups.Update(p.rdsClient, updateOpts); err != nil {
+			return fmt.Errorf("failed to update backup policy: %w", err)
+		}
+	}
+
+	if r.VolumeSize > 0 {
+		_, err := instances.EnlargeVolume(p.rdsClient, instances.EnlargeVolumeRdsOpts{
+			InstanceId: id,
+			Size:       r.VolumeSize,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to extend database volume: %w", err)
+		}
+	}
+
+	if r.Flavor != "" {
+		_, err := instances.Resize(p.rdsClient, instances.ResizeOpts{
+			InstanceId: id,
+			SpecCode:   r.Flavor,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to resize database instance: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// DeleteDatabaseInstance deletes an RDS instance. It does not wait for the
+// deletion to complete.
+func (p *provider) DeleteDatabaseInstance(ctx context.Context, id string) error {
+	_, err := instances.Delete(p.rdsClient, id)
+	if err != nil {
+		if _, ok := err.(gophercloud.ErrDefault404); ok {
+			return nil
+		}
+		return fmt.Errorf("failed to delete database instance: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,280 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	gophercloud "github.com/opentelekomcloud/gophertelekomcloud"
+	"github.com/opentelekomcloud/gophertelekomcloud/openstack/dns/v2/zones"
+
+	otcv1alpha1 "github.com/peertech.de/otc-operator/api/v1alpha1"
+	"github.com/peertech.de/otc-operator/internal/retry"
+)
+
+// NOTE: Possible statuses of zones and their network associations:
+// - ACTIVE - The zone or association is ready.
+// - PENDING_CREATE - The zone or association is being created.
+// - PENDING_UPDATE - The zone is being updated.
+// - PENDING_DELETE - The zone or association is being deleted.
+// - ERROR - The zone or association is abnormal.
+
+type CreateDNSZoneRequest struct {
+	Name        string
+	Email       string
+	Description string
+	TTL         int
+	Type        otcv1alpha1.DNSZoneType
+
+	// dependencies
+	NetworkIDs []string
+}
+
+type UpdateDNSZoneRequest struct {
+	// Email, Description and TTL update the zone. Empty values leave the
+	// fields untouched.
+	Email       string
+	Description string
+	TTL         int
+
+	// AssociateNetworkIDs and DisassociateNetworkIDs change the networks a
+	// private zone is associated with. Networks are associated first, so the
+	// zone is never left without a network.
+	AssociateNetworkIDs    []string
+	DisassociateNetworkIDs []string
+}
+
+type CreateDNSZoneResponse struct {
+	ID string
+}
+
+type DNSZoneInfo struct {
+	ID          string
+	Name        string
+	Email       string
+	Description string
+	TTL         int
+	Type        string
+	Status      string
+
+	// dependencies
+	NetworkIDs []string
+
+	// networkStatuses maps the associated network IDs to the status of their
+	// association.
+	networkStatuses map[string]string
+}
+
+func (i *DNSZoneInfo) State() State {
+	switch i.Status {
+	case "ACTIVE":
+		for _, status := range i.networkStatuses {
+			switch status {
+			case "ACTIVE":
+			case "ERROR":
+				return Failed
+			default:
+				return Provisioning
+			}
+		}
+		return Ready
+	case "ERROR":
+		return Failed
+	case "PENDING_CREATE", "PENDING_UPDATE", "PENDING_DELETE":
+		return Provisioning
+	default:
+		return Unknown
+	}
+}
+
+func (i *DNSZoneInfo) Message() string {
+	switch i.State() {
+	case Ready:
+		return "DNS zone is active"
+	case Failed:
+		return fmt.Sprintf("DNS zone is in a failed state: %s", i.Status)
+	case Provisioning:
+		return fmt.Sprintf("DNS zone busy with status: %s", i.Status)
+	default:
+		return fmt.Sprintf("DNS zone is in an unhandled state: %s", i.Status)
+	}
+}
+
+func (p *provider) CreateDNSZone(
+	ctx context.Context,
+	r CreateDNSZoneRequest,
+) (CreateDNSZoneResponse, error) {
+	createOpts := zones.CreateOpts{
+		Name:        r.Name,
+		Email:       r.Email,
+		Description: r.Description,
+		TTL:         r.TTL,
+		ZoneType:    string(r.Type),
+	}
+
+	// Private zones are created with their first network, the remaining
+	// networks are associated afterwards.
+	var networkIDs []string
+	if r.Type == otcv1alpha1.DNSZonePrivate {
+		if len(r.NetworkIDs) == 0 {
+			return CreateDNSZoneResponse{}, fmt.Errorf("private DNS zone requires a network")
+		}
+		createOpts.Router = &zones.RouterOpts{RouterID: r.NetworkIDs[0]}
+		networkIDs = r.NetworkIDs[1:]
+	}
+
+	zone, err := zones.Create(p.dnsClient, createOpts).Extract()
+	if err != nil {
+		return CreateDNSZoneResponse{}, fmt.Errorf("failed to create DNS zone: %w", err)
+	}
+
+	if err := p.waitForDNSZone(ctx, zone.ID); err != nil {
+		return CreateDNSZoneResponse{}, fmt.Errorf(
+			"failed to wait for DNS zone creation: %w",
+			err,
+		)
+	}
+
+	if err := p.associateDNSZone(ctx, zone.ID, networkIDs); err != nil {
+		return CreateDNSZoneResponse{}, err
+	}
+
+	return CreateDNSZoneResponse{ID: zone.ID}, nil
+}
+
+func (p *provider) GetDNSZone(ctx context.Context, id string) (*DNSZoneInfo, error) {
+	zone, err := zones.Get(p.dnsClient, id).Extract()
+	if err != nil {
+		if _, ok := err.(gophercloud.ErrDefault404); ok {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get DNS zone: %w", err)
+	}
+
+	info := &DNSZoneInfo{
+		ID:              zone.ID,
+		Name:            zone.Name,
+		Email:           zone.Email,
+		Description:     zone.Description,
+		TTL:             zone.TTL,
+		Type:            zone.ZoneType,
+		Status:          zone.Status,
+		networkStatuses: make(map[string]string, len(zone.Routers)),
+	}
+
+	for _, router := range zone.Routers {
+		info.NetworkIDs = append(info.NetworkIDs, router.RouterID)
+		info.networkStatuses[router.RouterID] = router.Status
+	}
+
+	return info, nil
+}
+
+func (p *provider) UpdateDNSZone(
+	ctx context.Context,
+	id string,
+	r UpdateDNSZoneRequest,
+) error {
+	if r.Email != "" || r.Description != "" || r.TTL != 0 {
+		updateOpts := zones.UpdateOpts{
+			Email:       r.Email,
+			Description: r.Description,
+			TTL:         r.TTL,
+		}
+		_, err := zones.Update(p.dnsClient, id, updateOpts).Extract()
+		if err != nil {
+			return fmt.Errorf("failed to update DNS zone: %w", err)
+		}
+
+		if err := p.waitForDNSZone(ctx, id); err != nil {
+			return fmt.Errorf("failed to wait for DNS zone update: %w", err)
+		}
+	}
+
+	if err := p.associateDNSZone(ctx, id, r.AssociateNetworkIDs); err != nil {
+		return err
+	}
+
+	for _, networkID := range r.DisassociateNetworkIDs {
+		opts := zones.RouterOpts{RouterID: networkID}
+		_, err := zones.DisassociateZone(p.dnsClient, id, opts).Extract()
+		if err != nil {
+			return fmt.Errorf(
+				"failed to disassociate DNS zone from network %s: %w",
+				networkID,
+				err,
+			)
+		}
+	}
+
+	if len(r.DisassociateNetworkIDs) > 0 {
+		if err := p.waitForDNSZone(ctx, id); err != nil {
+			return fmt.Errorf("failed to wait for DNS zone disassociation: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (p *provider) DeleteDNSZone(ctx context.Context, id string) error {
+	_, err := zones.Delete(p.dnsClient, id).Extract()
+	if err != nil {
+		if _, ok := err.(gophercloud.ErrDefault404); ok {
+			return nil
+		}
+		return fmt.Errorf("failed to delete DNS zone: %w", err)
+	}
+
+	return nil
+}
+
+// associateDNSZone associates a private zone with the given networks and waits
+// until the associations are active.
+func (p *provider) associateDNSZone(ctx context.Context, id string, networkIDs []string) error {
+	for _, networkID := range networkIDs {
+		opts := zones.RouterOpts{RouterID: networkID}
+		_, err := zones.AssociateZone(p.dnsClient, id, opts).Extract()
+		if err != nil {
+			return fmt.Errorf(
+				"failed to associate DNS zone with network %s: %w",
+				networkID,
+				err,
+			)
+		}
+	}
+
+	if len(networkIDs) > 0 {
+		if err := p.waitForDNSZone(ctx, id); err != nil {
+			return fmt.Errorf("failed to wait for DNS zone association: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (p *provider) waitForDNSZone(ctx context.Context, id string) error {
+	err := retry.Do(ctx, func() (bool, error) {
+		info, err := p.GetDNSZone(ctx, id)
+		if err != nil {
+			return true, err
+		}
+
+		switch info.State() {
+		case Ready:
+			return false, nil
+		case Failed:
+			return false, ErrFailedToCreate
+		default: // Provisioning or Unknown
+			return true, nil
+		}
+	},
+		retry.WithMaxAttempts(defaultMaxRetryAttempts),
+		retry.WithDelay(5*time.Second),
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to wait for DNS zone: %w", err)
+	}
+
+	return nil
+}
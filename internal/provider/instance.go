@@ -0,0 +1,442 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	gophercloud "github.com/opentelekomcloud/gophertelekomcloud"
+	"github.com/opentelekomcloud/gophertelekomcloud/openstack/compute/v2/extensions/startstop"
+	"github.com/opentelekomcloud/gophertelekomcloud/openstack/ecs/v1/cloudservers"
+	"github.com/opentelekomcloud/gophertelekomcloud/openstack/networking/v2/ports"
+
+	otcv1alpha1 "github.com/peertech.de/otc-operator/api/v1alpha1"
+	"github.com/peertech.de/otc-operator/internal/retry"
+)
+
+// NOTE: Possible statuses:
+// - BUILD - The instance is being created.
+// - REBOOT - The instance is being restarted.
+// - HARD_REBOOT - The instance is being forcibly restarted.
+// - REBUILD - The instance is being rebuilt.
+// - MIGRATING - The instance is being live migrated.
+// - RESIZE - The instance received a specifications modification request.
+// - VERIFY_RESIZE - The instance is verifying the modified specifications.
+// - ACTIVE - The instance is running.
+// - SHUTOFF - The instance is stopped.
+// - ERROR - An error has occurred on the instance.
+// - DELETED - The instance has been deleted.
+
+const (
+	// instanceJobTimeout is the time in seconds to wait for ECS jobs.
+	instanceJobTimeout = defaultMaxRetryAttempts * 5
+)
+
+type CreateInstanceRequest struct {
+	Name             string
+	Flavor           string
+	ImageID          string
+	AvailabilityZone string
+	RootVolume       otcv1alpha1.InstanceRootVolume
+	DataVolumes      []otcv1alpha1.InstanceDataVolume
+	UserData         []byte
+
+	// dependencies
+	NICs             []CreateInstanceNIC
+	SecurityGroupIDs []string
+	PublicIPID       string
+	KeyPairName      string
+}
+
+type CreateInstanceNIC struct {
+	SubnetID  string
+	IPAddress string
+}
+
+type UpdateInstanceRequest struct {
+	// PowerState starts or stops the instance. Nil leaves the power state
+	// untouched.
+	PowerState *otcv1alpha1.InstancePowerState
+}
+
+type CreateInstanceResponse struct {
+	ID string
+}
+
+type InstanceInfo struct {
+	ID               string
+	Name             string
+	Flavor           string
+	ImageID          string
+	AvailabilityZone string
+	Status           string
+	Interfaces       []InstanceInterfaceInfo
+	PublicAddresses  []string
+
+	// DataVolumeIDs are the IDs of the data volumes created together with
+	// the instance. Volumes attached later are not included.
+	DataVolumeIDs []string
+}
+
+type InstanceInterfaceInfo struct {
+	PortID    string
+	SubnetID  string
+	IPAddress string
+}
+
+func (i *InstanceInfo) State() State {
+	switch i.Status {
+	case "ACTIVE":
+		return Ready
+	case "SHUTOFF":
+		return Stopped
+	case "ERROR", "DELETED":
+		return Failed
+	case "BUILD",
+		"REBOOT",
+		"HARD_REBOOT",
+		"REBUILD",
+		"MIGRATING",
+		"RESIZE",
+		"VERIFY_RESIZE":
+		return Provisioning
+	default:
+		return Unknown
+	}
+}
+
+func (i *InstanceInfo) Message() string {
+	switch i.State() {
+	case Ready:
+		return "Instance is running"
+	case Stopped:
+		return "Instance is stopped"
+	case Failed:
+		return fmt.Sprintf("Instance is in a failed state: %s", i.Status)
+	case Provisioning:
+		return fmt.Sprintf("Instance busy with status: %s", i.Status)
+	default:
+		return fmt.Sprintf("Instance is in an unhandled state: %s", i.Status)
+	}
+}
+
+func (p *provider) CreateInstance(
+	ctx context.Context,
+	r CreateInstanceRequest,
+) (CreateInstanceResponse, error) {
+	if len(r.NICs) == 0 {
+		return CreateInstanceResponse{}, fmt.Errorf("at least one NIC is required")
+	}
+
+	// The ECS API requires the VPC, which is derived from the subnet of the
+	// primary NIC.
+	subnet, err := p.GetSubnet(ctx, r.NICs[0].SubnetID)
+	if err != nil {
+		return CreateInstanceResponse{}, fmt.Errorf("failed to get subnet of primary NIC: %w", err)
+	}
+
+	rootVolumeType := r.RootVolume.Type
+	if rootVolumeType == "" {
+		rootVolumeType = otcv1alpha1.VolumeTypeSSD
+	}
+
+	createOpts := cloudservers.CreateOpts{
+		Name:      r.Name,
+		FlavorRef: r.Flavor,
+		ImageRef:  r.ImageID,
+		KeyName:   r.KeyPairName,
+		UserData:  r.UserData,
+		VpcId:     subnet.NetworkID,
+		RootVolume: cloudservers.RootVolume{
+			VolumeType: string(rootVolumeType),
+			Size:       r.RootVolume.Size,
+		},
+	}
+	if r.AvailabilityZone != "" {
+		createOpts.AvailabilityZone = &r.AvailabilityZone
+	}
+	for _, nic := range r.NICs {
+		createOpts.Nics = append(createOpts.Nics, cloudservers.Nic{
+			SubnetId:  nic.SubnetID,
+			IpAddress: nic.IPAddress,
+		})
+	}
+	for _, volume := range r.DataVolumes {
+		volumeType := volume.Type
+		if volumeType == "" {
+			volumeType = otcv1alpha1.VolumeTypeSSD
+		}
+		createOpts.DataVolumes = append(createOpts.DataVolumes, cloudservers.DataVolume{
+			VolumeType: string(volumeType),
+			Size:       volume.Size,
+		})
+	}
+	for _, id := range r.SecurityGroupIDs {
+		createOpts.SecurityGroups = append(
+			createOpts.SecurityGroups,
+			cloudservers.SecurityGroup{ID: id},
+		)
+	}
+	if r.PublicIPID != "" {
+		createOpts.PublicIp = &cloudservers.PublicIp{Id: r.PublicIPID}
+	}
+
+	job, err := cloudservers.Create(p.computev1Client, createOpts).ExtractJobResponse()
+	if err != nil {
+		return CreateInstanceResponse{}, fmt.Errorf("failed to create instance: %w", err)
+	}
+
+	// The ID is returned as soon as the job reports it, so that the instance
+	// is not created again if it takes long to build. The readiness is
+	// observed by GetInstance.
+	id, err := p.waitForInstanceJobServerID(ctx, job.JobID)
+	if err != nil {
+		return CreateInstanceResponse{}, fmt.Errorf(
+			"failed to wait for instance ID: %w",
+			err,
+		)
+	}
+
+	return CreateInstanceResponse{ID: id}, nil
+}
+
+func (p *provider) GetInstance(ctx context.Context, id string) (*InstanceInfo, error) {
+	server, err := cloudservers.Get(p.computev1Client, id).Extract()
+	if err != nil {
+		if _, ok := err.(gophercloud.ErrDefault404); ok {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get instance: %w", err)
+	}
+
+	instanceInfo := &InstanceInfo{
+		ID:               server.ID,
+		Name:             server.Name,
+		Flavor:           server.Flavor.ID,
+		ImageID:          server.Image.ID,
+		AvailabilityZone: server.AvailabilityZone,
+		Status:           server.Status,
+	}
+	for _, addresses := range server.Addresses {
+		for _, address := range addresses {
+			if address.Type == "floating" {
+				instanceInfo.PublicAddresses = append(instanceInfo.PublicAddresses, address.Addr)
+			}
+		}
+	}
+	sort.Strings(instanceInfo.PublicAddresses)
+
+	// Only the volumes created together with the instance are deleted on
+	// termination, volumes attached later are not.
+	for _, volume := range server.VolumeAttached {
+		if volume.BootIndex != "0" && strings.EqualFold(volume.DeleteOnTermination, "true") {
+			instanceInfo.DataVolumeIDs = append(instanceInfo.DataVolumeIDs, volume.ID)
+		}
+	}
+
+	// The server addresses lack the subnet, so the interfaces are taken from
+	// the ports of the instance.
+	pages, err := ports.List(p.networkv2Client, ports.ListOpts{DeviceID: id}).AllPages()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list instance ports: %w", err)
+	}
+	instancePorts, err := ports.ExtractPorts(pages)
+	if err != nil {
+		return nil, fmt.Errorf("failed to extract instance ports: %w", err)
+	}
+	for _, port := range instancePorts {
+		iface := InstanceInterfaceInfo{
+			PortID: port.ID,
+
+			// NOTE: The VPC subnet ID is the ID of the underlying neutron
+			// network.
+			SubnetID: port.NetworkID,
+		}
+		if len(port.FixedIPs) > 0 {
+			iface.IPAddress = port.FixedIPs[0].IPAddress
+		}
+		instanceInfo.Interfaces = append(instanceInfo.Interfaces, iface)
+	}
+
+	return instanceInfo, nil
+}
+
+func (p *provider) UpdateInstance(
+	ctx context.Context,
+	id string,
+	r UpdateInstanceRequest,
+) error {
+	if r.PowerState != nil {
+		var desired State
+		switch *r.PowerState {
+		case otcv1alpha1.InstancePowerStateRunning:
+			err := startstop.Start(p.computev2Client, id).ExtractErr()
+			if err != nil {
+				return fmt.Errorf("failed to start instance: %w", err)
+			}
+			desired = Ready
+		case otcv1alpha1.InstancePowerStateStopped:
+			err := startstop.Stop(p.computev2Client, id).ExtractErr()
+			if err != nil {
+				return fmt.Errorf("failed to stop instance: %w", err)
+			}
+			desired = Stopped
+		default:
+			return fmt.Errorf("unknown instance power state: %s", *r.PowerState)
+		}
+
+		if err := p.waitForInstance(ctx, id, desired); err != nil {
+			return fmt.Errorf("failed to wait for instance power state: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// DeleteInstance deletes the instance together with the given data volumes,
+// which were created with it. The data volumes are deleted even if the
+// instance is already gone, so that a failed deletion can be retried.
+func (p *provider) DeleteInstance(ctx context.Context, id string, dataVolumeIDs []string) error {
+	// The ECS API deletes instances asynchronously and does not report
+	// missing instances, so check for existence first. The instance of a
+	// retried deletion can already be gone.
+	_, err := p.GetInstance(ctx, id)
+	switch {
+	case errors.Is(err, ErrNotFound):
+	case err != nil:
+		return err
+	default:
+		deleteOpts := cloudservers.DeleteOpts{
+			Servers: []cloudservers.Server{{Id: id}},
+
+			// Public IPs and attached volumes are managed by their own
+			// resources. The root volume is always deleted with the
+			// instance, the data volumes created with it are deleted below.
+			DeletePublicIP: false,
+			DeleteVolume:   false,
+		}
+
+		job, err := cloudservers.Delete(p.computev1Client, deleteOpts).ExtractJobResponse()
+		if err != nil {
+			return fmt.Errorf("failed to delete instance: %w", err)
+		}
+
+		err = cloudservers.WaitForJobSuccess(p.computev1Client, instanceJobTimeout, job.JobID)
+		if err != nil {
+			return fmt.Errorf("failed to wait for instance deletion job: %w", err)
+		}
+	}
+
+	// The data volumes created together with the instance are part of it.
+	for _, volumeID := range dataVolumeIDs {
+		if err := p.deleteInstanceDataVolume(ctx, volumeID); err != nil {
+			return fmt.Errorf("failed to delete data volume %s: %w", volumeID, err)
+		}
+	}
+
+	return nil
+}
+
+// deleteInstanceDataVolume deletes a data volume of a deleted instance once it
+// is detached.
+func (p *provider) deleteInstanceDataVolume(ctx context.Context, id string) error {
+	found := true
+	err := retry.Do(ctx, func() (bool, error) {
+		info, err := p.GetVolume(ctx, id)
+		if errors.Is(err, ErrNotFound) {
+			found = false
+			return false, nil
+		}
+		if err != nil {
+			return true, err
+		}
+
+		// The volume is detached asynchronously.
+		detached := info.AttachedInstanceID == "" && info.State() != Provisioning
+		return !detached, nil
+	},
+		retry.WithMaxAttempts(defaultMaxRetryAttempts),
+		retry.WithDelay(5*time.Second),
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to wait for volume detachment: %w", err)
+	}
+	if !found {
+		return nil
+	}
+
+	return p.DeleteVolume(ctx, id)
+}
+
+// waitForInstanceJobServerID waits until the instance creation job reports
+// the ID of the server. The ID is known before the server is built.
+func (p *provider) waitForInstanceJobServerID(ctx context.Context, jobID string) (string, error) {
+	var id string
+	err := retry.Do(ctx, func() (bool, error) {
+		var job cloudservers.JobStatus
+		_, err := p.computev1Client.Get(
+			p.computev1Client.ServiceURL("jobs", jobID),
+			&job,
+			nil,
+		)
+		if err != nil {
+			return true, err
+		}
+
+		for _, subJob := range job.Entities.SubJobs {
+			if serverID := subJob.Entities["server_id"]; serverID != "" {
+				id = serverID
+				return false, nil
+			}
+		}
+
+		switch job.Status {
+		case "FAIL":
+			return false, fmt.Errorf("job failed with code %s: %s", job.ErrorCode, job.FailReason)
+		case "SUCCESS":
+			return false, fmt.Errorf("job returned no instance ID")
+		default: // INIT or RUNNING
+			return true, nil
+		}
+	},
+		retry.WithMaxAttempts(defaultMaxRetryAttempts),
+		retry.WithDelay(5*time.Second),
+	)
+
+	if err != nil {
+		return "", fmt.Errorf("failed to wait for instance creation job: %w", err)
+	}
+
+	return id, nil
+}
+
+func (p *provider) waitForInstance(ctx context.Context, id string, desired State) error {
+	err := retry.Do(ctx, func() (bool, error) {
+		info, err := p.GetInstance(ctx, id)
+		if err != nil {
+			return true, err
+		}
+
+		switch info.State() {
+		case desired:
+			return false, nil
+		case Failed:
+			return false, fmt.Errorf("instance entered a failed state: %s", info.Status)
+		default: // Provisioning, Unknown or the opposite power state
+			return true, nil
+		}
+	},
+		retry.WithMaxAttempts(defaultMaxRetryAttempts),
+		retry.WithDelay(5*time.Second),
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to wait for instance: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,90 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	gophercloud "github.com/opentelekomcloud/gophertelekomcloud"
+	"github.com/opentelekomcloud/gophertelekomcloud/openstack/compute/v2/extensions/keypairs"
+)
+
+type CreateKeyPairRequest struct {
+	Name string
+
+	// PublicKey is the OpenSSH public key to import. If empty, a new key pair
+	// is generated.
+	PublicKey string
+}
+
+type CreateKeyPairResponse struct {
+	// ID is the name of the key pair, which identifies it at the provider.
+	ID string
+
+	// PrivateKey is the private key of a generated key pair. It is only
+	// returned once on creation.
+	PrivateKey string
+}
+
+type UpdateKeyPairRequest struct{}
+
+type KeyPairInfo struct {
+	ID          string
+	Fingerprint string
+	PublicKey   string
+}
+
+// State returns the state of the key pair. Key pairs are ready as soon as
+// they exist.
+func (k *KeyPairInfo) State() State {
+	return Ready
+}
+
+func (k *KeyPairInfo) Message() string {
+	return "Key pair is available"
+}
+
+func (p *provider) CreateKeyPair(
+	ctx context.Context,
+	r CreateKeyPairRequest,
+) (CreateKeyPairResponse, error) {
+	keyPair, err := keypairs.Create(p.computev2Client, keypairs.CreateOpts{
+		Name:      r.Name,
+		PublicKey: r.PublicKey,
+	}).Extract()
+	if err != nil {
+		return CreateKeyPairResponse{}, fmt.Errorf("failed to create key pair: %w", err)
+	}
+
+	return CreateKeyPairResponse{
+		ID:         keyPair.Name,
+		PrivateKey: keyPair.PrivateKey,
+	}, nil
+}
+
+func (p *provider) GetKeyPair(ctx context.Context, id string) (*KeyPairInfo, error) {
+	keyPair, err := keypairs.Get(p.computev2Client, id).Extract()
+	if err != nil {
+		if _, ok := err.(gophercloud.ErrDefault404); ok {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get key pair: %w", err)
+	}
+
+	return &KeyPairInfo{
+		ID:          keyPair.Name,
+		Fingerprint: keyPair.Fingerprint,
+		PublicKey:   keyPair.PublicKey,
+	}, nil
+}
+
+func (p *provider) DeleteKeyPair(ctx context.Context, id string) error {
+	err := keypairs.Delete(p.computev2Client, id).ExtractErr()
+	if err != nil {
+		if _, ok := err.(gophercloud.ErrDefault404); ok {
+			return nil
+		}
+		return fmt.Errorf("failed to delete key pair: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,234 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	gophercloud "github.com/opentelekomcloud/gophertelekomcloud"
+	"github.com/opentelekomcloud/gophertelekomcloud/openstack/kms/v1/keys"
+)
+
+// NOTE: Possible key states:
+// - 1 - The key is pending activation.
+// - 2 - The key is enabled.
+// - 3 - The key is disabled.
+// - 4 - The key is scheduled for deletion.
+// - 5 - The key is pending import of its key material.
+
+const (
+	kmsKeyStatePendingActivation = "1"
+	kmsKeyStateEnabled           = "2"
+	kmsKeyStateDisabled          = "3"
+	kmsKeyStatePendingDeletion   = "4"
+	kmsKeyStatePendingImport     = "5"
+)
+
+type CreateKMSKeyRequest struct {
+	Alias                string
+	Description          string
+	RotationEnabled      bool
+	RotationIntervalDays int
+}
+
+// UpdateKMSKeyRequest contains the changes to a KMS key. Fields which are not
+// set are left untouched.
+type UpdateKMSKeyRequest struct {
+	Alias                string
+	Description          *string
+	RotationEnabled      *bool
+	RotationIntervalDays int
+}
+
+type CreateKMSKeyResponse struct {
+	ID string
+}
+
+type KMSKeyInfo struct {
+	ID                   string
+	Alias                string
+	Description          string
+	RotationEnabled      bool
+	RotationIntervalDays int
+	Status               string
+}
+
+func (i *KMSKeyInfo) State() State {
+	switch i.Status {
+	case kmsKeyStateEnabled:
+		return Ready
+	case kmsKeyStateDisabled:
+		return Stopped
+	case kmsKeyStatePendingDeletion:
+		return Failed
+	case kmsKeyStatePendingActivation, kmsKeyStatePendingImport:
+		return Provisioning
+	default:
+		return Unknown
+	}
+}
+
+func (i *KMSKeyInfo) Message() string {
+	switch i.State() {
+	case Ready:
+		return "KMS key is enabled"
+	case Stopped:
+		return "KMS key is disabled"
+	case Failed:
+		return "KMS key is scheduled for deletion, the deletion has to be cancelled at the provider"
+	case Provisioning:
+		return fmt.Sprintf("KMS key is pending with state: %s", i.KeyState())
+	default:
+		return fmt.Sprintf("KMS key is in an unhandled state: %s", i.Status)
+	}
+}
+
+// KeyState returns a readable name of the key state.
+func (i *KMSKeyInfo) KeyState() string {
+	switch i.Status {
+	case kmsKeyStatePendingActivation:
+		return "PendingActivation"
+	case kmsKeyStateEnabled:
+		return "Enabled"
+	case kmsKeyStateDisabled:
+		return "Disabled"
+	case kmsKeyStatePendingDeletion:
+		return "PendingDeletion"
+	case kmsKeyStatePendingImport:
+		return "PendingImport"
+	default:
+		return i.Status
+	}
+}
+
+func (p *provider) CreateKMSKey(
+	ctx context.Context,
+	r CreateKMSKeyRequest,
+) (CreateKMSKeyResponse, error) {
+	key, err := keys.Create(p.kmsClient, keys.CreateOpts{
+		KeyAlias:       r.Alias,
+		KeyDescription: r.Description,
+	})
+	if err != nil {
+		return CreateKMSKeyResponse{}, fmt.Errorf("failed to create KMS key: %w", err)
+	}
+
+	if r.RotationEnabled {
+		err = p.UpdateKMSKey(ctx, key.KeyID, UpdateKMSKeyRequest{
+			RotationEnabled:      &r.RotationEnabled,
+			RotationIntervalDays: r.RotationIntervalDays,
+		})
+		if err != nil {
+			return CreateKMSKeyResponse{}, err
+		}
+	}
+
+	return CreateKMSKeyResponse{ID: key.KeyID}, nil
+}
+
+func (p *provider) GetKMSKey(ctx context.Context, id string) (*KMSKeyInfo, error) {
+	key, err := keys.Get(p.kmsClient, id)
+	if err != nil {
+		if _, ok := err.(gophercloud.ErrDefault404); ok {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get KMS key: %w", err)
+	}
+
+	keyInfo := &KMSKeyInfo{
+		ID:          key.KeyID,
+		Alias:       key.KeyAlias,
+		Description: key.KeyDescription,
+		Status:      key.KeyState,
+	}
+
+	// The rotation status can not be queried for a key scheduled for
+	// deletion.
+	if key.KeyState == kmsKeyStatePendingDeletion {
+		return keyInfo, nil
+	}
+
+	rotation, err := keys.GetKeyRotationStatus(p.kmsClient, keys.RotationOpts{KeyID: id})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get KMS key rotation: %w", err)
+	}
+	keyInfo.RotationEnabled = rotation.Enabled
+	keyInfo.RotationIntervalDays = rotation.Interval
+
+	return keyInfo, nil
+}
+
+func (p *provider) UpdateKMSKey(ctx context.Context, id string, r UpdateKMSKeyRequest) error {
+	if r.Alias != "" {
+		_, err := keys.UpdateAlias(p.kmsClient, keys.UpdateAliasOpts{
+			KeyID:    id,
+			KeyAlias: r.Alias,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to update KMS key alias: %w", err)
+		}
+	}
+
+	if r.Description != nil {
+		_, err := keys.UpdateDes(p.kmsClient, keys.UpdateDesOpts{
+			KeyID:          id,
+			KeyDescription: *r.Description,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to update KMS key description: %w", err)
+		}
+	}
+
+	if r.RotationEnabled != nil {
+		var err error
+		if *r.RotationEnabled {
+			err = keys.EnableKeyRotation(p.kmsClient, id)
+		} else {
+			err = keys.DisableKeyRotation(p.kmsClient, id)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to update KMS key rotation: %w", err)
+		}
+	}
+
+	// The interval can only be set while the rotation is enabled.
+	if r.RotationIntervalDays > 0 && (r.RotationEnabled == nil || *r.RotationEnabled) {
+		err := keys.UpdateKeyRotationInterval(p.kmsClient, keys.RotationOpts{
+			KeyID:    id,
+			Interval: r.RotationIntervalDays,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to update KMS key rotation interval: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// DeleteKMSKey schedules the deletion of a KMS key after the given number of
+// days. Keys which are already scheduled for deletion are left untouched.
+func (p *provider) DeleteKMSKey(ctx context.Context, id string, pendingDays int) error {
+	key, err := keys.Get(p.kmsClient, id)
+	if err != nil {
+		if _, ok := err.(gophercloud.ErrDefault404); ok {
+			return nil
+		}
+		return fmt.Errorf("failed to get KMS key: %w", err)
+	}
+	if key.KeyState == kmsKeyStatePendingDeletion {
+		return nil
+	}
+
+	_, err = keys.Delete(p.kmsClient, keys.DeleteOpts{
+		KeyID:       id,
+		PendingDays: strconv.Itoa(pendingDays),
+	})
+	if err != nil {
+		if _, ok := err.(gophercloud.ErrDefault404); ok {
+			return nil
+		}
+		return fmt.Errorf("failed to schedule KMS key deletion: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,321 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	gophercloud "github.com/opentelekomcloud/gophertelekomcloud"
+	"github.com/opentelekomcloud/gophertelekomcloud/openstack/cce/v3/nodepools"
+	"github.com/opentelekomcloud/gophertelekomcloud/openstack/cce/v3/nodes"
+
+	otcv1alpha1 "github.com/peertech.de/otc-operator/api/v1alpha1"
+)
+
+// NOTE: Possible phases:
+// - "" - The node pool is available.
+// - Synchronizing - The node pool is being scaled or updated.
+// - Synchronized - The node pool has been updated.
+// - SoldOut - No more nodes of the flavor are available.
+// - Deleting - The node pool is being deleted.
+// - Error - The node pool is abnormal.
+
+type CreateNodePoolRequest struct {
+	Name             string
+	Flavor           string
+	AvailabilityZone string
+	OS               string
+	RootVolume       otcv1alpha1.NodePoolVolume
+	DataVolumes      []otcv1alpha1.NodePoolVolume
+	NodeCount        int
+	Autoscaling      *otcv1alpha1.NodePoolAutoscaling
+	Labels           map[string]string
+	Taints           []otcv1alpha1.NodePoolTaint
+
+	// dependencies
+	ClusterID        string
+	SubnetID         string
+	SecurityGroupIDs []string
+	KeyPairName      string
+}
+
+// UpdateNodePoolRequest contains the settings of a node pool which can be
+// changed in place. The update replaces all of them.
+type UpdateNodePoolRequest struct {
+	NodeCount   int
+	Autoscaling *otcv1alpha1.NodePoolAutoscaling
+	Labels      map[string]string
+	Taints      []otcv1alpha1.NodePoolTaint
+
+	// RemovedLabels are the labels previously applied by the operator which
+	// are removed from the nodes. Other labels are left untouched, as some are
+	// managed by the provider.
+	RemovedLabels []string
+}
+
+type CreateNodePoolResponse struct {
+	ID string
+}
+
+type NodePoolInfo struct {
+	ID               string
+	Name             string
+	Flavor           string
+	AvailabilityZone string
+	NodeCount        int
+	CurrentNodeCount int
+	Autoscaling      *otcv1alpha1.NodePoolAutoscaling
+	Labels           map[string]string
+	Taints           []otcv1alpha1.NodePoolTaint
+	Phase            string
+}
+
+// Scaling reports whether the node pool is idle, but has not yet reached its
+// node count. This is not the case if autoscaling manages the node count.
+func (i *NodePoolInfo) Scaling() bool {
+	idle := i.Phase == "" || i.Phase == "Synchronized"
+	return idle && i.Autoscaling == nil && i.CurrentNodeCount != i.NodeCount
+}
+
+// State returns the state of the node pool. A scaling node pool is reported
+// as provisioning.
+func (i *NodePoolInfo) State() State {
+	switch i.Phase {
+	case "", "Synchronized":
+		if i.Scaling() {
+			return Provisioning
+		}
+		return Ready
+	case "SoldOut", "Error":
+		return Failed
+	case "Synchronizing", "Deleting":
+		return Provisioning
+	default:
+		return Unknown
+	}
+}
+
+func (i *NodePoolInfo) Message() string {
+	switch i.State() {
+	case Ready:
+		return "Node pool is available"
+	case Failed:
+		return fmt.Sprintf("Node pool is in a failed state: %s", i.Phase)
+	case Provisioning:
+		if i.Scaling() {
+			return fmt.Sprintf(
+				"Node pool is scaling: %d of %d nodes",
+				i.CurrentNodeCount,
+				i.NodeCount,
+			)
+		}
+		return fmt.Sprintf("Node pool busy with phase: %s", i.Phase)
+	default:
+		return fmt.Sprintf("Node pool is in an unhandled state: %s", i.Phase)
+	}
+}
+
+// CreateNodePool creates a node pool in a CCE cluster. It does not wait for
+// the nodes to become available, as this takes several minutes. The readiness
+// is checked by the caller.
+func (p *provider) CreateNodePool(
+	ctx context.Context,
+	r CreateNodePoolRequest,
+) (CreateNodePoolResponse, error) {
+	nodeTemplate := nodes.Spec{
+		Flavor: r.Flavor,
+		Az:     r.AvailabilityZone,
+		Os:     r.OS,
+		Count:  1,
+		Login: nodes.LoginSpec{
+			SshKey: r.KeyPairName,
+		},
+		RootVolume: toNodeVolume(r.RootVolume),
+		K8sTags:    r.Labels,
+		Taints:     toNodeTaints(r.Taints),
+	}
+	for _, volume := range r.DataVolumes {
+		nodeTemplate.DataVolumes = append(nodeTemplate.DataVolumes, toNodeVolume(volume))
+	}
+	if r.SubnetID != "" {
+		nodeTemplate.NodeNicSpec = nodes.NodeNicSpec{
+			PrimaryNic: nodes.PrimaryNic{SubnetId: r.SubnetID},
+		}
+	}
+
+	createOpts := nodepools.CreateOpts{
+		Kind:       "NodePool",
+		ApiVersion: "v3",
+		Metadata: nodepools.CreateMetaData{
+			Name: r.Name,
+		},
+		Spec: nodepools.CreateSpec{
+			Type:             "vm",
+			NodeTemplate:     nodeTemplate,
+			InitialNodeCount: r.NodeCount,
+			Autoscaling:      toAutoscalingSpec(r.Autoscaling),
+
+			// dependencies
+			CustomSecurityGroupIds: r.SecurityGroupIDs,
+		},
+	}
+
+	nodePool, err := nodepools.Create(p.cceClient, r.ClusterID, createOpts)
+	if err != nil {
+		return CreateNodePoolResponse{}, fmt.Errorf("failed to create node pool: %w", err)
+	}
+
+	return CreateNodePoolResponse{ID: nodePool.Metadata.Id}, nil
+}
+
+func (p *provider) GetNodePool(
+	ctx context.Context,
+	clusterID, id string,
+) (*NodePoolInfo, error) {
+	nodePool, err := nodepools.Get(p.cceClient, clusterID, id)
+	if err != nil {
+		if _, ok := err.(gophercloud.ErrDefault404); ok {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get node pool: %w", err)
+	}
+
+	nodePoolInfo := &NodePoolInfo{
+		ID:               nodePool.Metadata.Id,
+		Name:             nodePool.Metadata.Name,
+		Flavor:           nodePool.Spec.NodeTemplate.Flavor,
+		AvailabilityZone: nodePool.Spec.NodeTemplate.Az,
+		NodeCount:        nodePool.Spec.InitialNodeCount,
+		CurrentNodeCount: nodePool.Status.CurrentNode,
+		Labels:           nodePool.Spec.NodeTemplate.K8sTags,
+		Phase:            nodePool.Status.Phase,
+	}
+	if autoscaling := nodePool.Spec.Autoscaling; autoscaling.Enable {
+		nodePoolInfo.Autoscaling = &otcv1alpha1.NodePoolAutoscaling{
+			MinNodeCount:             autoscaling.MinNodeCount,
+			MaxNodeCount:             autoscaling.MaxNodeCount,
+			ScaleDownCooldownMinutes: autoscaling.ScaleDownCooldownTime,
+			Priority:                 autoscaling.Priority,
+		}
+	}
+	for _, taint := range nodePool.Spec.NodeTemplate.Taints {
+		nodePoolInfo.Taints = append(nodePoolInfo.Taints, otcv1alpha1.NodePoolTaint{
+			Key:    taint.Key,
+			Value:  taint.Value,
+			Effect: otcv1alpha1.NodePoolTaintEffect(taint.Effect),
+		})
+	}
+
+	return nodePoolInfo, nil
+}
+
+// updateNodePoolOpts mirrors nodepools.UpdateOpts. The autoscaling settings
+// are always sent, as the SDK omits them when autoscaling is disabled.
+type updateNodePoolOpts struct {
+	Metadata nodepools.UpdateMetaData `json:"metadata"`
+	Spec     updateNodePoolSpec       `json:"spec"`
+}
+
+type updateNodePoolSpec struct {
+	NodeTemplate     nodes.Spec                `json:"nodeTemplate"`
+	InitialNodeCount int                       `json:"initialNodeCount"`
+	Autoscaling      nodepools.AutoscalingSpec `json:"autoscaling"`
+}
+
+// UpdateNodePool applies the settings to the node pool. The node template is
+// read from the node pool, as the API requires it to be sent in full. The
+// scaling is tracked by the caller.
+func (p *provider) UpdateNodePool(
+	ctx context.Context,
+	clusterID, id string,
+	r UpdateNodePoolRequest,
+) error {
+	nodePool, err := nodepools.Get(p.cceClient, clusterID, id)
+	if err != nil {
+		return fmt.Errorf("failed to get node pool: %w", err)
+	}
+
+	nodeTemplate := nodePool.Spec.NodeTemplate
+	labels := make(map[string]string, len(nodeTemplate.K8sTags)+len(r.Labels))
+	for key, value := range nodeTemplate.K8sTags {
+		labels[key] = value
+	}
+	for _, key := range r.RemovedLabels {
+		delete(labels, key)
+	}
+	for key, value := range r.Labels {
+		labels[key] = value
+	}
+	nodeTemplate.K8sTags = labels
+	nodeTemplate.Taints = toNodeTaints(r.Taints)
+
+	updateOpts := updateNodePoolOpts{
+		Metadata: nodepools.UpdateMetaData{Name: nodePool.Metadata.Name},
+		Spec: updateNodePoolSpec{
+			NodeTemplate:     nodeTemplate,
+			InitialNodeCount: r.NodeCount,
+			Autoscaling:      toAutoscalingSpec(r.Autoscaling),
+		},
+	}
+
+	_, err = p.cceClient.Put(
+		p.cceClient.ServiceURL("clusters", clusterID, "nodepools", id),
+		updateOpts,
+		nil,
+		&gophercloud.RequestOpts{OkCodes: []int{200}},
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update node pool: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteNodePool deletes a node pool together with its nodes. It does not
+// wait for the deletion to complete.
+func (p *provider) DeleteNodePool(ctx context.Context, clusterID, id string) error {
+	err := nodepools.Delete(p.cceClient, clusterID, id)
+	if err != nil {
+		if _, ok := err.(gophercloud.ErrDefault404); ok {
+			return nil
+		}
+		return fmt.Errorf("failed to delete node pool: %w", err)
+	}
+
+	return nil
+}
+
+func toNodeVolume(v otcv1alpha1.NodePoolVolume) nodes.VolumeSpec {
+	volumeType := v.Type
+	if volumeType == "" {
+		volumeType = otcv1alpha1.VolumeTypeSSD
+	}
+	return nodes.VolumeSpec{
+		Size:       v.Size,
+		VolumeType: string(volumeType),
+	}
+}
+
+func toNodeTaints(taints []otcv1alpha1.NodePoolTaint) []nodes.TaintSpec {
+	var specs []nodes.TaintSpec
+	for _, taint := range taints {
+		specs = append(specs, nodes.TaintSpec{
+			Key:    taint.Key,
+			Value:  taint.Value,
+			Effect: string(taint.Effect),
+		})
+	}
+	return specs
+}
+
+func toAutoscalingSpec(a *otcv1alpha1.NodePoolAutoscaling) nodepools.AutoscalingSpec {
+	if a == nil {
+		return nodepools.AutoscalingSpec{}
+	}
+	return nodepools.AutoscalingSpec{
+		Enable:                true,
+		MinNodeCount:          a.MinNodeCount,
+		MaxNodeCount:          a.MaxNodeCount,
+		ScaleDownCooldownTime: a.ScaleDownCooldownMinutes,
+		Priority:              a.Priority,
+	}
+}
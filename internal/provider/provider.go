@@ -11,6 +11,7 @@ import (
 	"github.com/opentelekomcloud/gophertelekomcloud/openstack/networking/v1/subnets"
 	"github.com/opentelekomcloud/gophertelekomcloud/openstack/networking/v1/vpcs"
 	"github.com/opentelekomcloud/gophertelekomcloud/openstack/networking/v2/extensions/natgateways"
+	"github.com/opentelekomcloud/gophertelekomcloud/openstack/obs"
 	"github.com/opentelekomcloud/gophertelekomcloud/openstack/vpc/v3/security/group"
 )
 
@@ -21,6 +22,7 @@ const (
 var (
 	ErrNotFound       = fmt.Errorf("not found")
 	ErrFailedToCreate = fmt.Errorf("failed to create")
+	ErrNoAccessKey    = fmt.Errorf("access key credentials are required")
 )
 
 type Provider interface {
@@ -56,6 +58,7 @@ type Provider interface {
 		r CreatePublicIPRequest,
 	) (CreatePublicIPResponse, error)
 	GetPublicIP(ctx context.Context, id string) (*PublicIPInfo, error)
+	UpdatePublicIP(ctx context.Context, id string, r UpdatePublicIPRequest) error
 	DeletePublicIP(ctx context.Context, id string) error
 
 	CreateNATGateway(
@@ -72,6 +75,135 @@ type Provider interface {
 	) (CreateSNATRuleResponse, error)
 	GetSNATRule(ctx context.Context, id string) (*SNATRuleInfo, error)
 	DeleteSNATRule(ctx context.Context, id string) error
+
+	CreateBandwidth(
+		ctx context.Context,
+		r CreateBandwidthRequest,
+	) (CreateBandwidthResponse, error)
+	GetBandwidth(ctx context.Context, id string) (*BandwidthInfo, error)
+	UpdateBandwidth(ctx context.Context, id string, r UpdateBandwidthRequest) error
+	DeleteBandwidth(ctx context.Context, id string) error
+
+	CreateVirtualIP(
+		ctx context.Context,
+		r CreateVirtualIPRequest,
+	) (CreateVirtualIPResponse, error)
+	GetVirtualIP(ctx context.Context, id string) (*VirtualIPInfo, error)
+	DeleteVirtualIP(ctx context.Context, id string) error
+
+	CreateInstance(
+		ctx context.Context,
+		r CreateInstanceRequest,
+	) (CreateInstanceResponse, error)
+	GetInstance(ctx context.Context, id string) (*InstanceInfo, error)
+	UpdateInstance(ctx context.Context, id string, r UpdateInstanceRequest) error
+	DeleteInstance(ctx context.Context, id string, dataVolumeIDs []string) error
+
+	CreateVolume(
+		ctx context.Context,
+		r CreateVolumeRequest,
+	) (CreateVolumeResponse, error)
+	GetVolume(ctx context.Context, id string) (*VolumeInfo, error)
+	UpdateVolume(ctx context.Context, id string, r UpdateVolumeRequest) error
+	DeleteVolume(ctx context.Context, id string) error
+
+	CreateKeyPair(
+		ctx context.Context,
+		r CreateKeyPairRequest,
+	) (CreateKeyPairResponse, error)
+	GetKeyPair(ctx context.Context, id string) (*KeyPairInfo, error)
+	DeleteKeyPair(ctx context.Context, id string) error
+
+	CreateDNSZone(
+		ctx context.Context,
+		r CreateDNSZoneRequest,
+	) (CreateDNSZoneResponse, error)
+	GetDNSZone(ctx context.Context, id string) (*DNSZoneInfo, error)
+	UpdateDNSZone(ctx context.Context, id string, r UpdateDNSZoneRequest) error
+	DeleteDNSZone(ctx context.Context, id string) error
+
+	CreateRecordSet(
+		ctx context.Context,
+		r CreateRecordSetRequest,
+	) (CreateRecordSetResponse, error)
+	GetRecordSet(ctx context.Context, zoneID, id string) (*RecordSetInfo, error)
+	UpdateRecordSet(ctx context.Context, zoneID, id string, r UpdateRecordSetRequest) error
+	DeleteRecordSet(ctx context.Context, zoneID, id string) error
+
+	CreateVPNGateway(
+		ctx context.Context,
+		r CreateVPNGatewayRequest,
+	) (CreateVPNGatewayResponse, error)
+	GetVPNGateway(ctx context.Context, id string) (*VPNGatewayInfo, error)
+	UpdateVPNGateway(ctx context.Context, id string, r UpdateVPNGatewayRequest) error
+	DeleteVPNGateway(ctx context.Context, id string) error
+
+	CreateCustomerGateway(
+		ctx context.Context,
+		r CreateCustomerGatewayRequest,
+	) (CreateCustomerGatewayResponse, error)
+	GetCustomerGateway(ctx context.Context, id string) (*CustomerGatewayInfo, error)
+	DeleteCustomerGateway(ctx context.Context, id string) error
+
+	CreateVPNConnection(
+		ctx context.Context,
+		r CreateVPNConnectionRequest,
+	) (CreateVPNConnectionResponse, error)
+	GetVPNConnection(ctx context.Context, id string) (*VPNConnectionInfo, error)
+	UpdateVPNConnection(ctx context.Context, id string, r UpdateVPNConnectionRequest) error
+	DeleteVPNConnection(ctx context.Context, id string) error
+
+	CreateCluster(
+		ctx context.Context,
+		r CreateClusterRequest,
+	) (CreateClusterResponse, error)
+	GetCluster(ctx context.Context, id string) (*ClusterInfo, error)
+	GetClusterKubeconfig(ctx context.Context, id string) ([]byte, error)
+	UpdateCluster(ctx context.Context, id string, r UpdateClusterRequest) error
+	DeleteCluster(ctx context.Context, id string) error
+
+	CreateNodePool(
+		ctx context.Context,
+		r CreateNodePoolRequest,
+	) (CreateNodePoolResponse, error)
+	GetNodePool(ctx context.Context, clusterID, id string) (*NodePoolInfo, error)
+	UpdateNodePool(ctx context.Context, clusterID, id string, r UpdateNodePoolRequest) error
+	DeleteNodePool(ctx context.Context, clusterID, id string) error
+
+	CreateDatabaseInstance(
+		ctx context.Context,
+		r CreateDatabaseInstanceRequest,
+	) (CreateDatabaseInstanceResponse, error)
+	GetDatabaseInstance(ctx context.Context, id string) (*DatabaseInstanceInfo, error)
+	UpdateDatabaseInstance(ctx context.Context, id string, r UpdateDatabaseInstanceRequest) error
+	DeleteDatabaseInstance(ctx context.Context, id string) error
+
+	CreateBucket(ctx context.Context, r CreateBucketRequest) (CreateBucketResponse, error)
+	GetBucket(ctx context.Context, name string) (*BucketInfo, error)
+	UpdateBucket(ctx context.Context, name string, r UpdateBucketRequest) error
+	DeleteBucket(ctx context.Context, name string, forceDestroy bool) error
+
+	CreateKMSKey(ctx context.Context, r CreateKMSKeyRequest) (CreateKMSKeyResponse, error)
+	GetKMSKey(ctx context.Context, id string) (*KMSKeyInfo, error)
+	UpdateKMSKey(ctx context.Context, id string, r UpdateKMSKeyRequest) error
+	DeleteKMSKey(ctx context.Context, id string, pendingDays int) error
+
+	CreateAlarmRule(ctx context.Context, r CreateAlarmRuleRequest) (CreateAlarmRuleResponse, error)
+	GetAlarmRule(ctx context.Context, id string) (*AlarmRuleInfo, error)
+	UpdateAlarmRule(ctx context.Context, id string, r UpdateAlarmRuleRequest) error
+	DeleteAlarmRule(ctx context.Context, id string) error
+
+	CreateTopic(ctx context.Context, r CreateTopicRequest) (CreateTopicResponse, error)
+	GetTopic(ctx context.Context, urn string) (*TopicInfo, error)
+	UpdateTopic(ctx context.Context, urn string, r UpdateTopicRequest) error
+	DeleteTopic(ctx context.Context, urn string) error
+
+	CreateSubscription(
+		ctx context.Context,
+		r CreateSubscriptionRequest,
+	) (CreateSubscriptionResponse, error)
+	GetSubscription(ctx context.Context, topicURN, urn string) (*SubscriptionInfo, error)
+	DeleteSubscription(ctx context.Context, urn string) error
 }
 
 func New(opts ...Option) (Provider, error) {
@@ -147,6 +279,16 @@ func New(opts ...Option) (Provider, error) {
 		return nil, fmt.Errorf("failed to create network v1 client: %w", err)
 	}
 
+	networkv2, err := openstack.NewNetworkV2(
+		client,
+		gophercloud.EndpointOpts{
+			Region: options.Region,
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create network v2 client: %w", err)
+	}
+
 	networkv3, err := openstack.NewVpcV3(
 		client,
 		gophercloud.EndpointOpts{
@@ -167,12 +309,148 @@ func New(opts ...Option) (Provider, error) {
 		return nil, fmt.Errorf("failed to create network v3 client: %w", err)
 	}
 
+	computev1, err := openstack.NewComputeV1(
+		client,
+		gophercloud.EndpointOpts{
+			Region: options.Region,
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create compute v1 client: %w", err)
+	}
+
+	computev2, err := openstack.NewComputeV2(
+		client,
+		gophercloud.EndpointOpts{
+			Region: options.Region,
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create compute v2 client: %w", err)
+	}
+
+	evs, err := openstack.NewBlockStorageV3(
+		client,
+		gophercloud.EndpointOpts{
+			Region: options.Region,
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create block storage client: %w", err)
+	}
+
+	dns, err := openstack.NewDNSV2(
+		client,
+		gophercloud.EndpointOpts{
+			Region: options.Region,
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create DNS client: %w", err)
+	}
+
+	evpn, err := openstack.NewEVPNServiceV3(
+		client,
+		gophercloud.EndpointOpts{
+			Region: options.Region,
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create VPN client: %w", err)
+	}
+
+	cce, err := openstack.NewCCE(
+		client,
+		gophercloud.EndpointOpts{
+			Region: options.Region,
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create CCE client: %w", err)
+	}
+
+	rds, err := openstack.NewRDSV3(
+		client,
+		gophercloud.EndpointOpts{
+			Region: options.Region,
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create RDS client: %w", err)
+	}
+
+	kms, err := openstack.NewKMSV1(
+		client,
+		gophercloud.EndpointOpts{
+			Region: options.Region,
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create KMS client: %w", err)
+	}
+
+	ces, err := openstack.NewCESClient(
+		client,
+		gophercloud.EndpointOpts{
+			Region: options.Region,
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Cloud Eye client: %w", err)
+	}
+
+	smn, err := openstack.NewSMNV2(
+		client,
+		gophercloud.EndpointOpts{
+			Region: options.Region,
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create SMN client: %w", err)
+	}
+
+	// OBS only supports access key authentication, so the client is only
+	// available with access key credentials.
+	var obsClient *obs.ObsClient
+	var obsEndpoint string
+	if options.AccessKey != "" && options.SecretKey != "" {
+		obsService, err := openstack.NewOBSService(
+			client,
+			gophercloud.EndpointOpts{
+				Region: options.Region,
+			},
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find OBS endpoint: %w", err)
+		}
+
+		obsEndpoint = obsService.Endpoint
+		obsClient, err = obs.New(options.AccessKey, options.SecretKey, obsEndpoint)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create OBS client: %w", err)
+		}
+	}
+
 	p := &provider{
 		client:          client,
 		identityClient:  identityV3,
 		networkv1Client: networkv1,
+		networkv2Client: networkv2,
 		networkv3Client: networkv3,
 		natClient:       natv2,
+		computev1Client: computev1,
+		computev2Client: computev2,
+		evsClient:       evs,
+		dnsClient:       dns,
+		evpnClient:      evpn,
+		cceClient:       cce,
+		rdsClient:       rds,
+		kmsClient:       kms,
+		cesClient:       ces,
+		smnClient:       smn,
+		obsClient:       obsClient,
+		obsEndpoint:     obsEndpoint,
+		region:          options.Region,
 	}
 
 	return p, nil
@@ -182,8 +460,28 @@ type provider struct {
 	client          *gophercloud.ProviderClient
 	identityClient  *gophercloud.ServiceClient
 	networkv1Client *gophercloud.ServiceClient
+	networkv2Client *gophercloud.ServiceClient
 	networkv3Client *gophercloud.ServiceClient
 	natClient       *gophercloud.ServiceClient
+	computev1Client *gophercloud.ServiceClient
+	computev2Client *gophercloud.ServiceClient
+	evsClient       *gophercloud.ServiceClient
+	dnsClient       *gophercloud.ServiceClient
+	evpnClient      *gophercloud.ServiceClient
+	cceClient       *gophercloud.ServiceClient
+	rdsClient       *gophercloud.ServiceClient
+	kmsClient       *gophercloud.ServiceClient
+	cesClient       *gophercloud.ServiceClient
+	smnClient       *gophercloud.ServiceClient
+	obsClient       *obs.ObsClient
+
+	// obsEndpoint is the endpoint of the OBS client. Buckets are addressed
+	// as subdomains of it.
+	obsEndpoint string
+
+	// region is the region the clients are bound to. Some APIs require it in
+	// the request body.
+	region string
 }
 
 // Validate validates the connection and permissions.
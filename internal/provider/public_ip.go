@@ -2,11 +2,13 @@ package provider
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
 	gophercloud "github.com/opentelekomcloud/gophertelekomcloud"
 	"github.com/opentelekomcloud/gophertelekomcloud/openstack/networking/v1/eips"
+	"github.com/opentelekomcloud/gophertelekomcloud/openstack/networking/v2/bandwidths"
 
 	otcv1alpha1 "github.com/peertech.de/otc-operator/api/v1alpha1"
 	"github.com/peertech.de/otc-operator/internal/retry"
@@ -27,18 +29,28 @@ import (
 // - VPN (Bound to a VPN)
 // - ERROR (Exceptions)
 
-// TODO: When creating the EIP, should we use a prefix for the bandwidth name?
-// - like bandwidth-$NAME
-// TODO: Should we support using a already existing bandwidth?
 type CreatePublicIPRequest struct {
 	Name               string
 	Type               otcv1alpha1.PublicIPType
 	BandwidthName      string
 	BandwidthSize      int
 	BandwidthShareType otcv1alpha1.PublicIPBandwidthShareType
+
+	// dependencies
+	PortID      string
+	BandwidthID string
 }
 
-type UpdatePublicIPRequest struct{}
+type UpdatePublicIPRequest struct {
+	// PortID is the port to bind the public IP to. An empty string unbinds
+	// the public IP, nil leaves the binding untouched.
+	PortID *string
+
+	// BandwidthName and BandwidthSize update the bandwidth of the public IP.
+	// Empty values leave the bandwidth untouched.
+	BandwidthName string
+	BandwidthSize int
+}
 
 type CreatePublicIPResponse struct {
 	ID string
@@ -54,18 +66,23 @@ type PublicIPInfo struct {
 	BandwidthName      string
 	BandwidthShareType string
 	Status             string
+
+	// dependencies
+	PortID      string
+	BandwidthID string
 }
 
 func (i *PublicIPInfo) State() State {
 	switch i.Status {
 	case "ACTIVE",
 		"OK",
-		"ELB", // NOTE: we don't support ELB yet
-		"VPN": // NOTE: we don't support VPN yet
+		"DOWN", // EIP is allocated, but not bound to a port
+		"ELB",  // NOTE: we don't support ELB yet
+		"VPN":  // EIP is used by a VPNGateway
 		return Ready
-	case "DOWN", "FREEZED": // EIP is unbound or frozen
+	case "FREEZED": // EIP is frozen
 		return Stopped
-	case "ERROR", "error":
+	case "ERROR", "error", "BIND_ERROR":
 		return Failed
 	case "BINDING",
 		"NOTIFYING",
@@ -82,6 +99,9 @@ func (i *PublicIPInfo) State() State {
 func (i *PublicIPInfo) Message() string {
 	switch i.State() {
 	case Ready:
+		if i.PortID == "" {
+			return "Public IP is allocated and unbound"
+		}
 		return "Public IP is active"
 	case Stopped:
 		return "Public IP is inactive."
@@ -121,16 +141,26 @@ func (p *provider) CreatePublicIP(
 		)
 	}
 
+	bandwidthOpts := eips.BandwidthOpts{
+		Name:      r.BandwidthName,
+		Size:      r.BandwidthSize,
+		ShareType: providerShareType,
+	}
+	// Join the existing shared bandwidth instead of creating a new one.
+	if r.BandwidthID != "" {
+		bandwidthOpts = eips.BandwidthOpts{
+			Id:        r.BandwidthID,
+			ShareType: "WHOLE",
+		}
+	}
+
 	createOpts := eips.ApplyOpts{
 		IP: eips.PublicIpOpts{
-			Type: providerType,
-			Name: r.Name,
-		},
-		Bandwidth: eips.BandwidthOpts{
-			Name:      r.Name,
-			Size:      r.BandwidthSize,
-			ShareType: providerShareType,
+			Type:   providerType,
+			Name:   r.Name,
+			PortID: r.PortID,
 		},
+		Bandwidth: bandwidthOpts,
 	}
 
 	publicIP, err := eips.Apply(p.networkv1Client, createOpts).Extract()
@@ -166,11 +196,85 @@ func (p *provider) GetPublicIP(ctx context.Context, id string) (*PublicIPInfo, e
 		BandwidthSize:      publicIP.BandwidthSize,
 		BandwidthShareType: publicIP.BandwidthShareType,
 		Status:             publicIP.Status,
+
+		// dependencies
+		PortID:      publicIP.PortID,
+		BandwidthID: publicIP.BandwidthID,
+	}
+
+	// The public IP API does not report the bandwidth name.
+	if publicIP.BandwidthID != "" {
+		bandwidth, err := p.GetBandwidth(ctx, publicIP.BandwidthID)
+		if err != nil {
+			// A missing bandwidth must not be mistaken for a missing public
+			// IP, callers would otherwise recreate it.
+			if errors.Is(err, ErrNotFound) {
+				return nil, fmt.Errorf("public IP bandwidth %s not found", publicIP.BandwidthID)
+			}
+			return nil, fmt.Errorf("failed to get public IP bandwidth: %w", err)
+		}
+		publicIPInfo.BandwidthName = bandwidth.Name
 	}
 
 	return publicIPInfo, nil
 }
 
+func (p *provider) UpdatePublicIP(
+	ctx context.Context,
+	id string,
+	r UpdatePublicIPRequest,
+) error {
+	if r.BandwidthName != "" || r.BandwidthSize != 0 {
+		publicIP, err := eips.Get(p.networkv1Client, id).Extract()
+		if err != nil {
+			return fmt.Errorf("failed to get public IP: %w", err)
+		}
+
+		updateOpts := bandwidths.UpdateOpts{
+			Name: r.BandwidthName,
+			Size: r.BandwidthSize,
+		}
+		_, err = bandwidths.Update(p.networkv2Client, publicIP.BandwidthID, updateOpts).Extract()
+		if err != nil {
+			return fmt.Errorf("failed to update public IP bandwidth: %w", err)
+		}
+	}
+
+	if r.PortID != nil {
+		opts := publicIPBindingOpts{PortID: *r.PortID}
+		_, err := eips.Update(p.networkv1Client, id, opts).Extract()
+		if err != nil {
+			return fmt.Errorf("failed to update public IP binding: %w", err)
+		}
+
+		if err := p.waitForPublicIPBinding(ctx, id, *r.PortID); err != nil {
+			return fmt.Errorf("failed to wait for public IP binding: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// publicIPBindingOpts implements eips.UpdateOptsBuilder. In contrast to
+// eips.UpdateOpts it sends an explicit null port ID, which unbinds the public
+// IP.
+type publicIPBindingOpts struct {
+	PortID string
+}
+
+func (opts publicIPBindingOpts) ToPublicIpUpdateMap() (map[string]interface{}, error) {
+	var portID interface{}
+	if opts.PortID != "" {
+		portID = opts.PortID
+	}
+
+	return map[string]interface{}{
+		"publicip": map[string]interface{}{
+			"port_id": portID,
+		},
+	}, nil
+}
+
 func (p *provider) DeletePublicIP(ctx context.Context, id string) error {
 	err := eips.Delete(p.networkv1Client, id).ExtractErr()
 	if err != nil {
@@ -203,7 +307,6 @@ func (p *provider) waitForPublicIP(ctx context.Context, id string) error {
 		default: // Provisioning or Unknown
 			return true, nil
 		}
-
 	},
 		retry.WithMaxAttempts(defaultMaxRetryAttempts),
 		retry.WithDelay(5*time.Second),
@@ -215,3 +318,37 @@ func (p *provider) waitForPublicIP(ctx context.Context, id string) error {
 
 	return nil
 }
+
+func (p *provider) waitForPublicIPBinding(ctx context.Context, id, portID string) error {
+	err := retry.Do(ctx, func() (bool, error) {
+		info, err := p.GetPublicIP(ctx, id)
+		if err != nil {
+			return true, err
+		}
+
+		switch info.State() {
+		case Ready:
+			// The status can briefly stay unchanged after the update was
+			// accepted, so wait until the requested port is reported.
+			return info.PortID != portID, nil
+		case Stopped:
+			return false, fmt.Errorf(
+				"public IP entered a non-ready terminal state: %s",
+				info.Status,
+			)
+		case Failed:
+			return false, fmt.Errorf("public IP binding failed: %s", info.Status)
+		default: // Provisioning or Unknown
+			return true, nil
+		}
+	},
+		retry.WithMaxAttempts(defaultMaxRetryAttempts),
+		retry.WithDelay(5*time.Second),
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to wait for public IP binding: %w", err)
+	}
+
+	return nil
+}
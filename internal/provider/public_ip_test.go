@@ -0,0 +1,56 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	gophercloud "github.com/opentelekomcloud/gophertelekomcloud"
+)
+
+// newTestServiceClient returns a service client sending its requests to the
+// server.
+func newTestServiceClient(server *httptest.Server) *gophercloud.ServiceClient {
+	return &gophercloud.ServiceClient{
+		ProviderClient: &gophercloud.ProviderClient{
+			HTTPClient: *server.Client(),
+			ProjectID:  "project",
+		},
+		Endpoint: server.URL + "/",
+	}
+}
+
+func TestGetPublicIPMissingBandwidth(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/publicips/eip-id"):
+			_, _ = w.Write([]byte(`{"publicip": {"id": "eip-id", "bandwidth_id": "bandwidth-id"}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{}`))
+		}
+	}))
+	defer server.Close()
+
+	p := &provider{
+		networkv1Client: newTestServiceClient(server),
+		networkv2Client: newTestServiceClient(server),
+	}
+
+	_, err := p.GetPublicIP(context.Background(), "eip-id")
+	if err == nil {
+		t.Fatal("Expected an error")
+	}
+	if errors.Is(err, ErrNotFound) {
+		t.Fatalf("Expected a missing bandwidth not to be reported as not found, got %s", err)
+	}
+
+	_, err = p.GetPublicIP(context.Background(), "other-id")
+	if !errors.Is(err, ErrNotFound) {
+		t.Fatalf("Expected a missing public IP to be reported as not found, got %v", err)
+	}
+}
@@ -0,0 +1,193 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	gophercloud "github.com/opentelekomcloud/gophertelekomcloud"
+	"github.com/opentelekomcloud/gophertelekomcloud/openstack/dns/v2/recordsets"
+
+	"github.com/peertech.de/otc-operator/internal/retry"
+)
+
+// NOTE: Possible statuses:
+// - ACTIVE - The record set is ready.
+// - PENDING_CREATE - The record set is being created.
+// - PENDING_UPDATE - The record set is being updated.
+// - PENDING_DELETE - The record set is being deleted.
+// - ERROR - The record set is abnormal.
+
+type CreateRecordSetRequest struct {
+	Name        string
+	Description string
+	Type        string
+	TTL         int
+	Records     []string
+
+	// dependencies
+	ZoneID string
+}
+
+type UpdateRecordSetRequest struct {
+	// Description, TTL and Records update the record set. Empty values leave
+	// the fields untouched.
+	Description string
+	TTL         int
+	Records     []string
+}
+
+type CreateRecordSetResponse struct {
+	ID string
+}
+
+type RecordSetInfo struct {
+	ID          string
+	Name        string
+	Description string
+	Type        string
+	TTL         int
+	Records     []string
+	Status      string
+
+	// dependencies
+	ZoneID string
+}
+
+func (i *RecordSetInfo) State() State {
+	switch i.Status {
+	case "ACTIVE":
+		return Ready
+	case "ERROR":
+		return Failed
+	case "PENDING_CREATE", "PENDING_UPDATE", "PENDING_DELETE":
+		return Provisioning
+	default:
+		return Unknown
+	}
+}
+
+func (i *RecordSetInfo) Message() string {
+	switch i.State() {
+	case Ready:
+		return "Record set is active"
+	case Failed:
+		return fmt.Sprintf("Record set is in a failed state: %s", i.Status)
+	case Provisioning:
+		return fmt.Sprintf("Record set busy with status: %s", i.Status)
+	default:
+		return fmt.Sprintf("Record set is in an unhandled state: %s", i.Status)
+	}
+}
+
+func (p *provider) CreateRecordSet(
+	ctx context.Context,
+	r CreateRecordSetRequest,
+) (CreateRecordSetResponse, error) {
+	createOpts := recordsets.CreateOpts{
+		Name:        r.Name,
+		Description: r.Description,
+		Type:        r.Type,
+		TTL:         r.TTL,
+		Records:     r.Records,
+	}
+
+	recordSet, err := recordsets.Create(p.dnsClient, r.ZoneID, createOpts).Extract()
+	if err != nil {
+		return CreateRecordSetResponse{}, fmt.Errorf("failed to create record set: %w", err)
+	}
+
+	if err := p.waitForRecordSet(ctx, r.ZoneID, recordSet.ID); err != nil {
+		return CreateRecordSetResponse{}, fmt.Errorf(
+			"failed to wait for record set creation: %w",
+			err,
+		)
+	}
+
+	return CreateRecordSetResponse{ID: recordSet.ID}, nil
+}
+
+func (p *provider) GetRecordSet(ctx context.Context, zoneID, id string) (*RecordSetInfo, error) {
+	recordSet, err := recordsets.Get(p.dnsClient, zoneID, id).Extract()
+	if err != nil {
+		if _, ok := err.(gophercloud.ErrDefault404); ok {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get record set: %w", err)
+	}
+
+	return &RecordSetInfo{
+		ID:          recordSet.ID,
+		Name:        recordSet.Name,
+		Description: recordSet.Description,
+		Type:        recordSet.Type,
+		TTL:         recordSet.TTL,
+		Records:     recordSet.Records,
+		Status:      recordSet.Status,
+
+		// dependencies
+		ZoneID: recordSet.ZoneID,
+	}, nil
+}
+
+func (p *provider) UpdateRecordSet(
+	ctx context.Context,
+	zoneID, id string,
+	r UpdateRecordSetRequest,
+) error {
+	updateOpts := recordsets.UpdateOpts{
+		Description: r.Description,
+		TTL:         r.TTL,
+		Records:     r.Records,
+	}
+
+	_, err := recordsets.Update(p.dnsClient, zoneID, id, updateOpts).Extract()
+	if err != nil {
+		return fmt.Errorf("failed to update record set: %w", err)
+	}
+
+	if err := p.waitForRecordSet(ctx, zoneID, id); err != nil {
+		return fmt.Errorf("failed to wait for record set update: %w", err)
+	}
+
+	return nil
+}
+
+func (p *provider) DeleteRecordSet(ctx context.Context, zoneID, id string) error {
+	err := recordsets.Delete(p.dnsClient, zoneID, id).ExtractErr()
+	if err != nil {
+		if _, ok := err.(gophercloud.ErrDefault404); ok {
+			return nil
+		}
+		return fmt.Errorf("failed to delete record set: %w", err)
+	}
+
+	return nil
+}
+
+func (p *provider) waitForRecordSet(ctx context.Context, zoneID, id string) error {
+	err := retry.Do(ctx, func() (bool, error) {
+		info, err := p.GetRecordSet(ctx, zoneID, id)
+		if err != nil {
+			return true, err
+		}
+
+		switch info.State() {
+		case Ready:
+			return false, nil
+		case Failed:
+			return false, fmt.Errorf("record set entered a failed state: %s", info.Status)
+		default: // Provisioning or Unknown
+			return true, nil
+		}
+	},
+		retry.WithMaxAttempts(defaultMaxRetryAttempts),
+		retry.WithDelay(5*time.Second),
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to wait for record set: %w", err)
+	}
+
+	return nil
+}
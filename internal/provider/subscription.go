@@ -0,0 +1,163 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	gophercloud "github.com/opentelekomcloud/gophertelekomcloud"
+	"github.com/opentelekomcloud/gophertelekomcloud/openstack/smn/v2/subscriptions"
+)
+
+// NOTE: Possible subscription statuses:
+// - 0 - The subscription is not confirmed by the endpoint yet.
+// - 1 - The subscription is confirmed.
+// - 2 - The confirmation is not required.
+// - 3 - The subscription was canceled by the endpoint.
+// - 4 - The subscription was deleted.
+
+const (
+	subscriptionStatusUnconfirmed = 0
+	subscriptionStatusConfirmed   = 1
+	subscriptionStatusNoConfirm   = 2
+	subscriptionStatusCanceled    = 3
+	subscriptionStatusDeleted     = 4
+
+	// subscriptionPageSize is the maximum number of subscriptions SMN returns
+	// per page.
+	subscriptionPageSize = 100
+)
+
+type CreateSubscriptionRequest struct {
+	Protocol string
+	Endpoint string
+	Remark   string
+
+	// dependencies
+	TopicURN string
+}
+
+type CreateSubscriptionResponse struct {
+	URN string
+}
+
+type UpdateSubscriptionRequest struct{}
+
+type SubscriptionInfo struct {
+	URN      string
+	TopicURN string
+	Protocol string
+	Endpoint string
+	Remark   string
+	Status   int
+}
+
+func (i *SubscriptionInfo) State() State {
+	switch i.Status {
+	case subscriptionStatusConfirmed, subscriptionStatusNoConfirm:
+		return Ready
+	case subscriptionStatusUnconfirmed:
+		return Provisioning
+	case subscriptionStatusCanceled:
+		return Stopped
+	default:
+		return Unknown
+	}
+}
+
+func (i *SubscriptionInfo) Message() string {
+	switch i.State() {
+	case Ready:
+		return "Subscription is confirmed"
+	case Provisioning:
+		return fmt.Sprintf("Waiting for the confirmation of the subscription by %s", i.Endpoint)
+	case Stopped:
+		return "Subscription was canceled by the endpoint"
+	default:
+		return fmt.Sprintf("Subscription is in an unhandled state: %d", i.Status)
+	}
+}
+
+// SubscriptionState returns a readable name of the subscription status.
+func (i *SubscriptionInfo) SubscriptionState() string {
+	switch i.Status {
+	case subscriptionStatusUnconfirmed:
+		return "Unconfirmed"
+	case subscriptionStatusConfirmed, subscriptionStatusNoConfirm:
+		return "Confirmed"
+	case subscriptionStatusCanceled:
+		return "Canceled"
+	default:
+		return strconv.Itoa(i.Status)
+	}
+}
+
+func (p *provider) CreateSubscription(
+	ctx context.Context,
+	r CreateSubscriptionRequest,
+) (CreateSubscriptionResponse, error) {
+	subscription, err := subscriptions.Create(p.smnClient, subscriptions.CreateOpts{
+		Protocol: r.Protocol,
+		Endpoint: r.Endpoint,
+		Remark:   r.Remark,
+	}, r.TopicURN)
+	if err != nil {
+		return CreateSubscriptionResponse{}, fmt.Errorf("failed to create subscription: %w", err)
+	}
+
+	return CreateSubscriptionResponse{URN: subscription.SubscriptionUrn}, nil
+}
+
+// GetSubscription looks up a subscription within the subscriptions of its
+// topic, as SMN can not get a single subscription.
+func (p *provider) GetSubscription(
+	ctx context.Context,
+	topicURN, urn string,
+) (*SubscriptionInfo, error) {
+	for offset := 0; ; offset += subscriptionPageSize {
+		page, err := subscriptions.ListTopic(p.smnClient, subscriptions.ListTopicOpts{
+			TopicUrn: topicURN,
+			Offset:   strconv.Itoa(offset),
+			Limit:    subscriptionPageSize,
+		})
+		if err != nil {
+			if _, ok := err.(gophercloud.ErrDefault404); ok {
+				return nil, ErrNotFound
+			}
+			return nil, fmt.Errorf("failed to list subscriptions: %w", err)
+		}
+
+		for _, subscription := range page {
+			if subscription.SubscriptionUrn != urn {
+				continue
+			}
+			if subscription.Status == subscriptionStatusDeleted {
+				return nil, ErrNotFound
+			}
+			return &SubscriptionInfo{
+				URN:      subscription.SubscriptionUrn,
+				TopicURN: subscription.TopicUrn,
+				Protocol: subscription.Protocol,
+				Endpoint: subscription.Endpoint,
+				Remark:   subscription.Remark,
+				Status:   subscription.Status,
+			}, nil
+		}
+
+		if len(page) < subscriptionPageSize {
+			return nil, ErrNotFound
+		}
+	}
+}
+
+func (p *provider) DeleteSubscription(ctx context.Context, urn string) error {
+	err := subscriptions.Delete(p.smnClient, urn)
+	if err != nil {
+		if _, ok := err.(gophercloud.ErrDefault404); ok {
+			return nil
+		}
+		return fmt.Errorf("failed to delete subscription: %w", err)
+	}
+
+	return nil
+}
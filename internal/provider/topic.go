@@ -0,0 +1,139 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	gophercloud "github.com/opentelekomcloud/gophertelekomcloud"
+	"github.com/opentelekomcloud/gophertelekomcloud/openstack/smn/v2/topicattributes"
+	"github.com/opentelekomcloud/gophertelekomcloud/openstack/smn/v2/topics"
+)
+
+// topicPolicyAttribute is the topic attribute holding the access policy.
+const topicPolicyAttribute = "access_policy"
+
+type CreateTopicRequest struct {
+	Name        string
+	DisplayName string
+	Policy      string
+}
+
+// UpdateTopicRequest contains the changes to a topic. Fields which are not
+// set are left untouched.
+type UpdateTopicRequest struct {
+	DisplayName *string
+	// Policy replaces the access policy. An empty policy removes it.
+	Policy *string
+}
+
+type CreateTopicResponse struct {
+	URN string
+}
+
+type TopicInfo struct {
+	URN         string
+	Name        string
+	DisplayName string
+	Policy      string
+}
+
+// State returns Ready, as topics are available as soon as they are created.
+func (i *TopicInfo) State() State {
+	return Ready
+}
+
+func (i *TopicInfo) Message() string {
+	return "Topic is available"
+}
+
+func (p *provider) CreateTopic(
+	ctx context.Context,
+	r CreateTopicRequest,
+) (CreateTopicResponse, error) {
+	topic, err := topics.Create(p.smnClient, topics.CreateOpts{
+		Name:        r.Name,
+		DisplayName: r.DisplayName,
+	})
+	if err != nil {
+		return CreateTopicResponse{}, fmt.Errorf("failed to create topic: %w", err)
+	}
+
+	if r.Policy != "" {
+		err = p.UpdateTopic(ctx, topic.TopicUrn, UpdateTopicRequest{Policy: &r.Policy})
+		if err != nil {
+			return CreateTopicResponse{}, err
+		}
+	}
+
+	return CreateTopicResponse{URN: topic.TopicUrn}, nil
+}
+
+func (p *provider) GetTopic(ctx context.Context, urn string) (*TopicInfo, error) {
+	topic, err := topics.Get(p.smnClient, urn)
+	if err != nil {
+		if _, ok := err.(gophercloud.ErrDefault404); ok {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get topic: %w", err)
+	}
+
+	attributes, err := topicattributes.List(
+		p.smnClient,
+		urn,
+		topicattributes.ListOpts{Name: topicPolicyAttribute},
+	).Extract()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get topic policy: %w", err)
+	}
+
+	return &TopicInfo{
+		URN:         topic.TopicUrn,
+		Name:        topic.Name,
+		DisplayName: topic.DisplayName,
+		Policy:      attributes[topicPolicyAttribute],
+	}, nil
+}
+
+func (p *provider) UpdateTopic(ctx context.Context, urn string, r UpdateTopicRequest) error {
+	if r.DisplayName != nil {
+		_, err := topics.Update(p.smnClient, topics.UpdateOpts{
+			Id:          urn,
+			DisplayName: *r.DisplayName,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to update topic display name: %w", err)
+		}
+	}
+
+	if r.Policy != nil {
+		var err error
+		if *r.Policy == "" {
+			err = topicattributes.Delete(p.smnClient, urn, topicPolicyAttribute).ExtractErr()
+		} else {
+			err = topicattributes.Update(
+				p.smnClient,
+				urn,
+				topicPolicyAttribute,
+				topicattributes.UpdateOpts{Value: *r.Policy},
+			).ExtractErr()
+		}
+		if err != nil {
+			return fmt.Errorf("failed to update topic policy: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// DeleteTopic deletes a topic together with all of its subscriptions.
+func (p *provider) DeleteTopic(ctx context.Context, urn string) error {
+	err := topics.Delete(p.smnClient, urn)
+	if err != nil {
+		if _, ok := err.(gophercloud.ErrDefault404); ok {
+			return nil
+		}
+		return fmt.Errorf("failed to delete topic: %w", err)
+	}
+
+	return nil
+}
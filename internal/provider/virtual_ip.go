@@ -0,0 +1,165 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	gophercloud "github.com/opentelekomcloud/gophertelekomcloud"
+	"github.com/opentelekomcloud/gophertelekomcloud/openstack/networking/v2/ports"
+
+	"github.com/peertech.de/otc-operator/internal/retry"
+)
+
+// NOTE: A virtual IP is a port with the device owner "neutron:VIP_PORT".
+// Possible statuses:
+// - ACTIVE - The port is bound to an instance.
+// - DOWN - The port is not bound to an instance.
+// - BUILD - The port is being created.
+// - ERROR - The port is abnormal.
+
+const virtualIPDeviceOwner = "neutron:VIP_PORT"
+
+type CreateVirtualIPRequest struct {
+	Name      string
+	IPAddress string
+
+	// dependencies
+	SubnetID string
+}
+
+type CreateVirtualIPResponse struct {
+	ID string
+}
+
+type UpdateVirtualIPRequest struct{}
+
+type VirtualIPInfo struct {
+	ID        string
+	Name      string
+	IPAddress string
+	Status    string
+
+	// dependencies
+	SubnetID string
+}
+
+func (i *VirtualIPInfo) State() State {
+	switch i.Status {
+	case "ACTIVE",
+		"DOWN": // VIP is not bound to an instance yet, which is fine
+		return Ready
+	case "ERROR":
+		return Failed
+	case "BUILD":
+		return Provisioning
+	default:
+		return Unknown
+	}
+}
+
+func (i *VirtualIPInfo) Message() string {
+	switch i.State() {
+	case Ready:
+		return "Virtual IP is active"
+	case Failed:
+		return fmt.Sprintf("Virtual IP is in a failed state: %s", i.Status)
+	case Provisioning:
+		return fmt.Sprintf("Virtual IP busy with status: %s", i.Status)
+	default:
+		return fmt.Sprintf("Virtual IP is in an unhandled state: %s", i.Status)
+	}
+}
+
+func (p *provider) CreateVirtualIP(
+	ctx context.Context,
+	r CreateVirtualIPRequest,
+) (CreateVirtualIPResponse, error) {
+	createOpts := ports.CreateOpts{
+		Name:        r.Name,
+		DeviceOwner: virtualIPDeviceOwner,
+
+		// NOTE: The VPC subnet ID is the ID of the underlying neutron network.
+		NetworkID: r.SubnetID,
+	}
+	if r.IPAddress != "" {
+		createOpts.FixedIPs = []map[string]string{{"ip_address": r.IPAddress}}
+	}
+
+	port, err := ports.Create(p.networkv2Client, createOpts).Extract()
+	if err != nil {
+		return CreateVirtualIPResponse{}, fmt.Errorf("failed to create virtual IP: %w", err)
+	}
+
+	if err := p.waitForVirtualIP(ctx, port.ID); err != nil {
+		return CreateVirtualIPResponse{}, fmt.Errorf(
+			"failed to wait for virtual IP creation: %w",
+			err,
+		)
+	}
+
+	return CreateVirtualIPResponse{ID: port.ID}, nil
+}
+
+func (p *provider) GetVirtualIP(ctx context.Context, id string) (*VirtualIPInfo, error) {
+	port, err := ports.Get(p.networkv2Client, id).Extract()
+	if err != nil {
+		if _, ok := err.(gophercloud.ErrDefault404); ok {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get virtual IP: %w", err)
+	}
+
+	virtualIPInfo := &VirtualIPInfo{
+		ID:     port.ID,
+		Name:   port.Name,
+		Status: port.Status,
+
+		// dependencies
+		SubnetID: port.NetworkID,
+	}
+	if len(port.FixedIPs) > 0 {
+		virtualIPInfo.IPAddress = port.FixedIPs[0].IPAddress
+	}
+
+	return virtualIPInfo, nil
+}
+
+func (p *provider) DeleteVirtualIP(ctx context.Context, id string) error {
+	err := ports.Delete(p.networkv2Client, id).ExtractErr()
+	if err != nil {
+		if _, ok := err.(gophercloud.ErrDefault404); ok {
+			return nil
+		}
+		return fmt.Errorf("failed to delete virtual IP: %w", err)
+	}
+
+	return nil
+}
+
+func (p *provider) waitForVirtualIP(ctx context.Context, id string) error {
+	err := retry.Do(ctx, func() (bool, error) {
+		info, err := p.GetVirtualIP(ctx, id)
+		if err != nil {
+			return true, err
+		}
+
+		switch info.State() {
+		case Ready:
+			return false, nil
+		case Failed:
+			return false, ErrFailedToCreate
+		default: // Provisioning or Unknown
+			return true, nil
+		}
+	},
+		retry.WithMaxAttempts(defaultMaxRetryAttempts),
+		retry.WithDelay(5*time.Second),
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to wait for virtual IP creation: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,308 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	gophercloud "github.com/opentelekomcloud/gophertelekomcloud"
+	"github.com/opentelekomcloud/gophertelekomcloud/openstack/compute/v2/extensions/volumeattach"
+	"github.com/opentelekomcloud/gophertelekomcloud/openstack/evs/extensions/volumeactions"
+	volumesv1 "github.com/opentelekomcloud/gophertelekomcloud/openstack/evs/v1/volumes"
+	"github.com/opentelekomcloud/gophertelekomcloud/openstack/evs/v3/volumes"
+
+	otcv1alpha1 "github.com/peertech.de/otc-operator/api/v1alpha1"
+	"github.com/peertech.de/otc-operator/internal/retry"
+)
+
+// NOTE: Possible statuses:
+// - creating - The volume is being created.
+// - available - The volume is ready and not attached.
+// - in-use - The volume is attached to an instance.
+// - attaching - The volume is being attached.
+// - detaching - The volume is being detached.
+// - extending - The volume is being resized.
+// - deleting - The volume is being deleted.
+// - error - An error occurred during creation.
+// - error_deleting - An error occurred during deletion.
+// - error_extending - An error occurred during resizing.
+// - error_restoring - An error occurred during restoring.
+
+const (
+	// volumeJobTimeout is the time in seconds to wait for EVS jobs.
+	volumeJobTimeout = defaultMaxRetryAttempts * 5
+)
+
+type CreateVolumeRequest struct {
+	Name             string
+	Size             int
+	Type             otcv1alpha1.VolumeType
+	AvailabilityZone string
+	KMSKeyID         string
+}
+
+type UpdateVolumeRequest struct {
+	// Size extends the volume to the given size in GB. Zero leaves the size
+	// untouched.
+	Size int
+
+	// InstanceID attaches the volume to the given instance, detaching it from
+	// any other instance first. An empty string detaches the volume. Nil
+	// leaves the attachment untouched.
+	InstanceID *string
+	Device     string
+}
+
+type CreateVolumeResponse struct {
+	ID string
+}
+
+type VolumeInfo struct {
+	ID                 string
+	Name               string
+	Size               int
+	Type               string
+	AvailabilityZone   string
+	Status             string
+	AttachedInstanceID string
+	Device             string
+}
+
+func (v *VolumeInfo) State() State {
+	switch v.Status {
+	case "available", "in-use":
+		return Ready
+	case "error", "error_deleting", "error_extending", "error_restoring":
+		return Failed
+	case "creating",
+		"attaching",
+		"detaching",
+		"extending",
+		"deleting",
+		"downloading",
+		"uploading",
+		"retyping",
+		"restoring-backup":
+		return Provisioning
+	default:
+		return Unknown
+	}
+}
+
+func (v *VolumeInfo) Message() string {
+	switch v.State() {
+	case Ready:
+		return "Volume is available"
+	case Failed:
+		return fmt.Sprintf("Volume is in a failed state: %s", v.Status)
+	case Provisioning:
+		return fmt.Sprintf("Volume busy with status: %s", v.Status)
+	default:
+		return fmt.Sprintf("Volume is in an unhandled state: %s", v.Status)
+	}
+}
+
+func (p *provider) CreateVolume(
+	ctx context.Context,
+	r CreateVolumeRequest,
+) (CreateVolumeResponse, error) {
+	volumeType := r.Type
+	if volumeType == "" {
+		volumeType = otcv1alpha1.VolumeTypeSSD
+	}
+
+	createOpts := volumes.CreateOpts{
+		Name:             r.Name,
+		Size:             r.Size,
+		VolumeType:       string(volumeType),
+		AvailabilityZone: r.AvailabilityZone,
+	}
+	if r.KMSKeyID != "" {
+		createOpts.Metadata = map[string]string{
+			"__system__encrypted": "1",
+			"__system__cmkid":     r.KMSKeyID,
+		}
+	}
+
+	job, err := volumes.Create(p.evsClient, createOpts).ExtractJobResponse()
+	if err != nil {
+		return CreateVolumeResponse{}, fmt.Errorf("failed to create volume: %w", err)
+	}
+
+	err = volumes.WaitForJobSuccess(p.evsClient, volumeJobTimeout, job.JobID)
+	if err != nil {
+		return CreateVolumeResponse{}, fmt.Errorf(
+			"failed to wait for volume creation job: %w",
+			err,
+		)
+	}
+
+	entity, err := volumes.GetJobEntity(p.evsClient, job.JobID, "volume_id")
+	if err != nil {
+		return CreateVolumeResponse{}, fmt.Errorf("failed to get volume ID: %w", err)
+	}
+	id, ok := entity.(string)
+	if !ok || id == "" {
+		return CreateVolumeResponse{}, fmt.Errorf("volume creation job returned no ID")
+	}
+
+	if err := p.waitForVolume(ctx, id); err != nil {
+		return CreateVolumeResponse{}, fmt.Errorf(
+			"failed to wait for volume creation: %w",
+			err,
+		)
+	}
+
+	return CreateVolumeResponse{ID: id}, nil
+}
+
+func (p *provider) GetVolume(ctx context.Context, id string) (*VolumeInfo, error) {
+	volume, err := volumes.Get(p.evsClient, id).Extract()
+	if err != nil {
+		if _, ok := err.(gophercloud.ErrDefault404); ok {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get volume: %w", err)
+	}
+
+	volumeInfo := &VolumeInfo{
+		ID:               volume.ID,
+		Name:             volume.Name,
+		Size:             volume.Size,
+		Type:             volume.VolumeType,
+		AvailabilityZone: volume.AvailabilityZone,
+		Status:           volume.Status,
+	}
+	if len(volume.Attachments) > 0 {
+		volumeInfo.AttachedInstanceID = volume.Attachments[0].ServerID
+		volumeInfo.Device = volume.Attachments[0].Device
+	}
+
+	return volumeInfo, nil
+}
+
+func (p *provider) UpdateVolume(
+	ctx context.Context,
+	id string,
+	r UpdateVolumeRequest,
+) error {
+	info, err := p.GetVolume(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	// Volumes can be extended while attached, so this is done before
+	// changing the attachment.
+	if r.Size > 0 && r.Size != info.Size {
+		err := volumeactions.ExtendSize(
+			p.evsClient,
+			id,
+			volumeactions.ExtendSizeOpts{NewSize: r.Size},
+		)
+		if err != nil {
+			return fmt.Errorf("failed to extend volume: %w", err)
+		}
+
+		if err := p.waitForVolume(ctx, id); err != nil {
+			return fmt.Errorf("failed to wait for volume resize: %w", err)
+		}
+	}
+
+	if r.InstanceID != nil && *r.InstanceID != info.AttachedInstanceID {
+		if info.AttachedInstanceID != "" {
+			if err := p.detachVolume(ctx, id, info.AttachedInstanceID); err != nil {
+				return err
+			}
+		}
+
+		if *r.InstanceID != "" {
+			_, err := volumeattach.Create(
+				p.computev2Client,
+				*r.InstanceID,
+				volumeattach.CreateOpts{
+					VolumeID: id,
+					Device:   r.Device,
+				},
+			).Extract()
+			if err != nil {
+				return fmt.Errorf("failed to attach volume: %w", err)
+			}
+
+			if err := p.waitForVolume(ctx, id); err != nil {
+				return fmt.Errorf("failed to wait for volume attachment: %w", err)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (p *provider) DeleteVolume(ctx context.Context, id string) error {
+	info, err := p.GetVolume(ctx, id)
+	if err != nil {
+		if err == ErrNotFound {
+			return nil
+		}
+		return err
+	}
+
+	// Attached volumes cannot be deleted, and are not detached implicitly.
+	if info.AttachedInstanceID != "" {
+		return fmt.Errorf("volume is attached to instance %s", info.AttachedInstanceID)
+	}
+
+	err = volumesv1.Delete(p.evsClient, id)
+	if err != nil {
+		if _, ok := err.(gophercloud.ErrDefault404); ok {
+			return nil
+		}
+		return fmt.Errorf("failed to delete volume: %w", err)
+	}
+
+	return nil
+}
+
+func (p *provider) detachVolume(ctx context.Context, id, instanceID string) error {
+	// NOTE: The attachment ID of the compute API is the volume ID.
+	err := volumeattach.Delete(p.computev2Client, instanceID, id).ExtractErr()
+	if err != nil {
+		if _, ok := err.(gophercloud.ErrDefault404); !ok {
+			return fmt.Errorf("failed to detach volume: %w", err)
+		}
+	}
+
+	if err := p.waitForVolume(ctx, id); err != nil {
+		return fmt.Errorf("failed to wait for volume detachment: %w", err)
+	}
+
+	return nil
+}
+
+// waitForVolume waits until the volume has settled, i.e. is no longer busy
+// being created, resized, attached or detached.
+func (p *provider) waitForVolume(ctx context.Context, id string) error {
+	err := retry.Do(ctx, func() (bool, error) {
+		info, err := p.GetVolume(ctx, id)
+		if err != nil {
+			return true, err
+		}
+
+		switch info.State() {
+		case Ready:
+			return false, nil
+		case Failed:
+			return false, fmt.Errorf("volume entered a failed state: %s", info.Status)
+		default: // Provisioning, Unknown
+			return true, nil
+		}
+	},
+		retry.WithMaxAttempts(defaultMaxRetryAttempts),
+		retry.WithDelay(5*time.Second),
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to wait for volume: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,284 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	gophercloud "github.com/opentelekomcloud/gophertelekomcloud"
+	"github.com/opentelekomcloud/gophertelekomcloud/openstack/evpn/v5/connection"
+
+	otcv1alpha1 "github.com/peertech.de/otc-operator/api/v1alpha1"
+	"github.com/peertech.de/otc-operator/internal/retry"
+)
+
+// NOTE: Possible statuses:
+// - ACTIVE - The tunnel is established.
+// - DOWN - The connection is configured, but the tunnel is not established.
+// - PENDING_CREATE - The connection is being created.
+// - PENDING_UPDATE - The connection is being updated.
+// - PENDING_DELETE - The connection is being deleted.
+// - FREEZED - The connection is frozen.
+// - ERROR - The connection is abnormal.
+// - UNKNOWN - The status is unknown.
+
+type CreateVPNConnectionRequest struct {
+	Name         string
+	RoutingMode  otcv1alpha1.VPNConnectionRoutingMode
+	PeerSubnets  []string
+	PreSharedKey string
+	IKEPolicy    otcv1alpha1.IKEPolicy
+	IPsecPolicy  otcv1alpha1.IPsecPolicy
+
+	// dependencies
+	VPNGatewayID      string
+	GatewayPublicIPID string
+	CustomerGatewayID string
+}
+
+type UpdateVPNConnectionRequest struct {
+	// PeerSubnets and PreSharedKey update the connection. Empty values leave
+	// the fields untouched.
+	PeerSubnets  []string
+	PreSharedKey string
+
+	// IKEPolicy and IPsecPolicy replace the policies of the connection. Nil
+	// values leave the policies untouched.
+	IKEPolicy   *otcv1alpha1.IKEPolicy
+	IPsecPolicy *otcv1alpha1.IPsecPolicy
+}
+
+type CreateVPNConnectionResponse struct {
+	ID string
+}
+
+type VPNConnectionInfo struct {
+	ID          string
+	Name        string
+	RoutingMode string
+	PeerSubnets []string
+	IKEPolicy   otcv1alpha1.IKEPolicy
+	IPsecPolicy otcv1alpha1.IPsecPolicy
+	Status      string
+
+	// dependencies
+	VPNGatewayID      string
+	GatewayPublicIPID string
+	CustomerGatewayID string
+}
+
+// State returns the state of the VPN connection. A connection whose tunnel is
+// down is reported as stopped, as it is configured correctly but waits for
+// the customer gateway.
+func (i *VPNConnectionInfo) State() State {
+	switch i.Status {
+	case "ACTIVE":
+		return Ready
+	case "DOWN", "FREEZED":
+		return Stopped
+	case "ERROR":
+		return Failed
+	case "PENDING_CREATE", "PENDING_UPDATE", "PENDING_DELETE":
+		return Provisioning
+	default:
+		return Unknown
+	}
+}
+
+func (i *VPNConnectionInfo) Message() string {
+	switch i.State() {
+	case Ready:
+		return "VPN tunnel is up"
+	case Stopped:
+		if i.Status == "FREEZED" {
+			return "VPN connection is frozen"
+		}
+		return "VPN tunnel is down"
+	case Failed:
+		return fmt.Sprintf("VPN connection is in a failed state: %s", i.Status)
+	case Provisioning:
+		return fmt.Sprintf("VPN connection busy with status: %s", i.Status)
+	default:
+		return fmt.Sprintf("VPN connection is in an unhandled state: %s", i.Status)
+	}
+}
+
+func (p *provider) CreateVPNConnection(
+	ctx context.Context,
+	r CreateVPNConnectionRequest,
+) (CreateVPNConnectionResponse, error) {
+	createOpts := connection.CreateOpts{
+		Name:        r.Name,
+		Style:       string(r.RoutingMode),
+		PeerSubnets: r.PeerSubnets,
+		Psk:         r.PreSharedKey,
+		IkePolicy:   toIKEPolicy(r.IKEPolicy),
+		IpSecPolicy: toIPsecPolicy(r.IPsecPolicy),
+
+		// dependencies
+		VgwId: r.VPNGatewayID,
+		VgwIp: r.GatewayPublicIPID,
+		CgwId: r.CustomerGatewayID,
+	}
+
+	vpnConnection, err := connection.Create(p.evpnClient, createOpts)
+	if err != nil {
+		return CreateVPNConnectionResponse{}, fmt.Errorf(
+			"failed to create VPN connection: %w",
+			err,
+		)
+	}
+
+	if err := p.waitForVPNConnection(ctx, vpnConnection.ID); err != nil {
+		return CreateVPNConnectionResponse{}, fmt.Errorf(
+			"failed to wait for VPN connection creation: %w",
+			err,
+		)
+	}
+
+	return CreateVPNConnectionResponse{ID: vpnConnection.ID}, nil
+}
+
+func (p *provider) GetVPNConnection(ctx context.Context, id string) (*VPNConnectionInfo, error) {
+	vpnConnection, err := connection.Get(p.evpnClient, id)
+	if err != nil {
+		if _, ok := err.(gophercloud.ErrDefault404); ok {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get VPN connection: %w", err)
+	}
+
+	info := &VPNConnectionInfo{
+		ID:          vpnConnection.ID,
+		Name:        vpnConnection.Name,
+		RoutingMode: vpnConnection.Style,
+		PeerSubnets: vpnConnection.PeerSubnets,
+		IKEPolicy: otcv1alpha1.IKEPolicy{
+			Version:                 vpnConnection.IkePolicy.IkeVersion,
+			NegotiationMode:         vpnConnection.IkePolicy.PhaseOneNegotiationMode,
+			AuthenticationAlgorithm: otcv1alpha1.VPNAuthenticationAlgorithm(vpnConnection.IkePolicy.AuthenticationAlgorithm),
+			EncryptionAlgorithm:     otcv1alpha1.VPNEncryptionAlgorithm(vpnConnection.IkePolicy.EncryptionAlgorithm),
+			DHGroup:                 otcv1alpha1.VPNDHGroup(vpnConnection.IkePolicy.DhGroup),
+		},
+		IPsecPolicy: otcv1alpha1.IPsecPolicy{
+			AuthenticationAlgorithm: otcv1alpha1.VPNAuthenticationAlgorithm(vpnConnection.IpSecPolicy.AuthenticationAlgorithm),
+			EncryptionAlgorithm:     otcv1alpha1.VPNEncryptionAlgorithm(vpnConnection.IpSecPolicy.EncryptionAlgorithm),
+			PFS:                     vpnConnection.IpSecPolicy.Pfs,
+		},
+		Status: vpnConnection.Status,
+
+		// dependencies
+		VPNGatewayID:      vpnConnection.VgwId,
+		GatewayPublicIPID: vpnConnection.VgwIp,
+		CustomerGatewayID: vpnConnection.CgwId,
+	}
+
+	if vpnConnection.IkePolicy.LifetimeSeconds != nil {
+		info.IKEPolicy.LifetimeSeconds = *vpnConnection.IkePolicy.LifetimeSeconds
+	}
+	if vpnConnection.IpSecPolicy.LifetimeSeconds != nil {
+		info.IPsecPolicy.LifetimeSeconds = *vpnConnection.IpSecPolicy.LifetimeSeconds
+	}
+
+	return info, nil
+}
+
+func (p *provider) UpdateVPNConnection(
+	ctx context.Context,
+	id string,
+	r UpdateVPNConnectionRequest,
+) error {
+	updateOpts := connection.UpdateOpts{
+		ConnectionID: id,
+		PeerSubnets:  r.PeerSubnets,
+		Psk:          r.PreSharedKey,
+	}
+	if r.IKEPolicy != nil {
+		updateOpts.IkePolicy = toIKEPolicy(*r.IKEPolicy)
+	}
+	if r.IPsecPolicy != nil {
+		updateOpts.IpSecPolicy = toIPsecPolicy(*r.IPsecPolicy)
+	}
+
+	if _, err := connection.Update(p.evpnClient, updateOpts); err != nil {
+		return fmt.Errorf("failed to update VPN connection: %w", err)
+	}
+
+	if err := p.waitForVPNConnection(ctx, id); err != nil {
+		return fmt.Errorf("failed to wait for VPN connection update: %w", err)
+	}
+
+	return nil
+}
+
+func (p *provider) DeleteVPNConnection(ctx context.Context, id string) error {
+	err := connection.Delete(p.evpnClient, id)
+	if err != nil {
+		if _, ok := err.(gophercloud.ErrDefault404); ok {
+			return nil
+		}
+		return fmt.Errorf("failed to delete VPN connection: %w", err)
+	}
+
+	return nil
+}
+
+// waitForVPNConnection waits until the connection is configured. The tunnel
+// does not have to be up, as this depends on the customer gateway.
+func (p *provider) waitForVPNConnection(ctx context.Context, id string) error {
+	err := retry.Do(ctx, func() (bool, error) {
+		info, err := p.GetVPNConnection(ctx, id)
+		if err != nil {
+			return true, err
+		}
+
+		switch info.State() {
+		case Ready, Stopped:
+			return false, nil
+		case Failed:
+			return false, fmt.Errorf("VPN connection entered a failed state: %s", info.Status)
+		default: // Provisioning or Unknown
+			return true, nil
+		}
+	},
+		retry.WithMaxAttempts(defaultMaxRetryAttempts),
+		retry.WithDelay(5*time.Second),
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to wait for VPN connection: %w", err)
+	}
+
+	return nil
+}
+
+func toIKEPolicy(p otcv1alpha1.IKEPolicy) *connection.IkePolicy {
+	policy := &connection.IkePolicy{
+		IkeVersion:              p.Version,
+		AuthenticationAlgorithm: string(p.AuthenticationAlgorithm),
+		EncryptionAlgorithm:     string(p.EncryptionAlgorithm),
+		DhGroup:                 string(p.DHGroup),
+	}
+	if p.LifetimeSeconds > 0 {
+		lifetime := p.LifetimeSeconds
+		policy.LifetimeSeconds = &lifetime
+	}
+	// The negotiation mode is only accepted for IKE v1.
+	if p.Version == "v1" {
+		policy.PhaseOneNegotiationMode = p.NegotiationMode
+	}
+	return policy
+}
+
+func toIPsecPolicy(p otcv1alpha1.IPsecPolicy) *connection.IpSecPolicy {
+	policy := &connection.IpSecPolicy{
+		AuthenticationAlgorithm: string(p.AuthenticationAlgorithm),
+		EncryptionAlgorithm:     string(p.EncryptionAlgorithm),
+		Pfs:                     p.PFS,
+	}
+	if p.LifetimeSeconds > 0 {
+		lifetime := p.LifetimeSeconds
+		policy.LifetimeSeconds = &lifetime
+	}
+	return policy
+}
@@ -0,0 +1,219 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	gophercloud "github.com/opentelekomcloud/gophertelekomcloud"
+	"github.com/opentelekomcloud/gophertelekomcloud/openstack/evpn/v5/gateway"
+
+	otcv1alpha1 "github.com/peertech.de/otc-operator/api/v1alpha1"
+	"github.com/peertech.de/otc-operator/internal/retry"
+)
+
+// NOTE: Possible statuses:
+// - ACTIVE - The VPN gateway is ready.
+// - PENDING_CREATE - The VPN gateway is being created.
+// - PENDING_UPDATE - The VPN gateway is being updated.
+// - PENDING_DELETE - The VPN gateway is being deleted.
+// - FREEZED - The VPN gateway is frozen.
+// - ERROR - The VPN gateway is abnormal.
+
+type CreateVPNGatewayRequest struct {
+	Name              string
+	LocalSubnets      []string
+	Flavor            otcv1alpha1.VPNGatewayFlavor
+	AvailabilityZones []string
+	BGPASN            int
+	HAMode            otcv1alpha1.VPNGatewayHAMode
+
+	// dependencies
+	NetworkID           string
+	SubnetID            string
+	PrimaryPublicIPID   string
+	SecondaryPublicIPID string
+}
+
+type UpdateVPNGatewayRequest struct {
+	// LocalSubnets replaces the local subnets of the gateway. An empty value
+	// leaves the field untouched.
+	LocalSubnets []string
+}
+
+type CreateVPNGatewayResponse struct {
+	ID string
+}
+
+type VPNGatewayInfo struct {
+	ID                string
+	Name              string
+	LocalSubnets      []string
+	Flavor            string
+	AvailabilityZones []string
+	BGPASN            int
+	HAMode            string
+	Status            string
+
+	PrimaryAddress   string
+	SecondaryAddress string
+
+	// dependencies
+	NetworkID           string
+	SubnetID            string
+	PrimaryPublicIPID   string
+	SecondaryPublicIPID string
+}
+
+func (i *VPNGatewayInfo) State() State {
+	switch i.Status {
+	case "ACTIVE":
+		return Ready
+	case "FREEZED":
+		return Stopped
+	case "ERROR":
+		return Failed
+	case "PENDING_CREATE", "PENDING_UPDATE", "PENDING_DELETE":
+		return Provisioning
+	default:
+		return Unknown
+	}
+}
+
+func (i *VPNGatewayInfo) Message() string {
+	switch i.State() {
+	case Ready:
+		return "VPN gateway is active"
+	case Stopped:
+		return "VPN gateway is frozen"
+	case Failed:
+		return fmt.Sprintf("VPN gateway is in a failed state: %s", i.Status)
+	case Provisioning:
+		return fmt.Sprintf("VPN gateway busy with status: %s", i.Status)
+	default:
+		return fmt.Sprintf("VPN gateway is in an unhandled state: %s", i.Status)
+	}
+}
+
+// CreateVPNGateway creates a VPN gateway. It does not wait for the gateway to
+// become active, as this takes several minutes. The readiness is checked by
+// the caller.
+func (p *provider) CreateVPNGateway(
+	ctx context.Context,
+	r CreateVPNGatewayRequest,
+) (CreateVPNGatewayResponse, error) {
+	bgpASN := r.BGPASN
+	createOpts := gateway.CreateOpts{
+		Name:                r.Name,
+		NetworkType:         "public",
+		AttachmentType:      "vpc",
+		LocalSubnets:        r.LocalSubnets,
+		BgpAsn:              &bgpASN,
+		Flavor:              string(r.Flavor),
+		AvailabilityZoneIds: r.AvailabilityZones,
+		HaMode:              string(r.HAMode),
+
+		// dependencies
+		VpcId:         r.NetworkID,
+		ConnectSubnet: r.SubnetID,
+		Eip1:          &gateway.Eip{ID: r.PrimaryPublicIPID},
+		Eip2:          &gateway.Eip{ID: r.SecondaryPublicIPID},
+	}
+
+	vpnGateway, err := gateway.Create(p.evpnClient, createOpts)
+	if err != nil {
+		return CreateVPNGatewayResponse{}, fmt.Errorf("failed to create VPN gateway: %w", err)
+	}
+
+	return CreateVPNGatewayResponse{ID: vpnGateway.ID}, nil
+}
+
+func (p *provider) GetVPNGateway(ctx context.Context, id string) (*VPNGatewayInfo, error) {
+	vpnGateway, err := gateway.Get(p.evpnClient, id)
+	if err != nil {
+		if _, ok := err.(gophercloud.ErrDefault404); ok {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get VPN gateway: %w", err)
+	}
+
+	return &VPNGatewayInfo{
+		ID:                vpnGateway.ID,
+		Name:              vpnGateway.Name,
+		LocalSubnets:      vpnGateway.LocalSubnets,
+		Flavor:            vpnGateway.Flavor,
+		AvailabilityZones: vpnGateway.AvailabilityZoneIds,
+		BGPASN:            vpnGateway.BgpAsn,
+		HAMode:            vpnGateway.HaMode,
+		Status:            vpnGateway.Status,
+
+		PrimaryAddress:   vpnGateway.Eip1.IpAddress,
+		SecondaryAddress: vpnGateway.Eip2.IpAddress,
+
+		// dependencies
+		NetworkID:           vpnGateway.VpcId,
+		SubnetID:            vpnGateway.ConnectSubnet,
+		PrimaryPublicIPID:   vpnGateway.Eip1.ID,
+		SecondaryPublicIPID: vpnGateway.Eip2.ID,
+	}, nil
+}
+
+func (p *provider) UpdateVPNGateway(
+	ctx context.Context,
+	id string,
+	r UpdateVPNGatewayRequest,
+) error {
+	updateOpts := gateway.UpdateOpts{
+		GatewayID:    id,
+		LocalSubnets: r.LocalSubnets,
+	}
+
+	if _, err := gateway.Update(p.evpnClient, updateOpts); err != nil {
+		return fmt.Errorf("failed to update VPN gateway: %w", err)
+	}
+
+	if err := p.waitForVPNGateway(ctx, id); err != nil {
+		return fmt.Errorf("failed to wait for VPN gateway update: %w", err)
+	}
+
+	return nil
+}
+
+func (p *provider) DeleteVPNGateway(ctx context.Context, id string) error {
+	err := gateway.Delete(p.evpnClient, id)
+	if err != nil {
+		if _, ok := err.(gophercloud.ErrDefault404); ok {
+			return nil
+		}
+		return fmt.Errorf("failed to delete VPN gateway: %w", err)
+	}
+
+	return nil
+}
+
+func (p *provider) waitForVPNGateway(ctx context.Context, id string) error {
+	err := retry.Do(ctx, func() (bool, error) {
+		info, err := p.GetVPNGateway(ctx, id)
+		if err != nil {
+			return true, err
+		}
+
+		switch info.State() {
+		case Ready:
+			return false, nil
+		case Failed:
+			return false, fmt.Errorf("VPN gateway entered a failed state: %s", info.Status)
+		default: // Provisioning, Stopped or Unknown
+			return true, nil
+		}
+	},
+		retry.WithMaxAttempts(defaultMaxRetryAttempts),
+		retry.WithDelay(5*time.Second),
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to wait for VPN gateway: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,312 @@
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	otcv1alpha1 "github.com/peertech.de/otc-operator/api/v1alpha1"
+)
+
+// validAlarmRuleName matches the names Cloud Eye accepts for alarm rules.
+var validAlarmRuleName = regexp.MustCompile(`^[a-zA-Z0-9_-]{1,128}$`)
+
+// SetupAlarmRuleWebhookWithManager registers the webhook for AlarmRule in the manager.
+func SetupAlarmRuleWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).For(&otcv1alpha1.AlarmRule{}).
+		WithValidator(&AlarmRuleCustomValidator{}).
+		Complete()
+}
+
+// TODO(user): change verbs to "verbs=create;update;delete" if you want to enable deletion validation.
+// +kubebuilder:webhook:path=/validate-otc-peertech-de-v1alpha1-alarmrule,mutating=false,failurePolicy=fail,sideEffects=None,groups=otc.peertech.de,resources=alarmrules,verbs=create;update,versions=v1alpha1,name=valarmrule-v1alpha1.kb.io,admissionReviewVersions=v1
+
+// AlarmRuleCustomValidator struct is responsible for validating the AlarmRule resource
+// when it is created, updated, or deleted.
+type AlarmRuleCustomValidator struct{}
+
+var _ webhook.CustomValidator = &AlarmRuleCustomValidator{}
+
+// ValidateCreate implements webhook.CustomValidator so a webhook will be registered for the type AlarmRule.
+func (v *AlarmRuleCustomValidator) ValidateCreate(
+	_ context.Context,
+	obj runtime.Object,
+) (admission.Warnings, error) {
+	alarmRule, ok := obj.(*otcv1alpha1.AlarmRule)
+	if !ok {
+		return nil, fmt.Errorf("expected a AlarmRule object but got %T", obj)
+	}
+
+	var warnings admission.Warnings
+	var errors field.ErrorList
+
+	// Validate the resource name, which is the name of the alarm rule
+	if !validAlarmRuleName.MatchString(alarmRule.Name) {
+		errors = append(errors, field.Invalid(
+			field.NewPath("metadata", "name"),
+			alarmRule.Name,
+			"name must be 1 to 128 characters of letters, digits, underscores (_) and hyphens (-)",
+		))
+	}
+
+	// Validate ProviderConfigRef
+	if err := validateProviderConfigRefName(alarmRule.Spec.ProviderConfigRef); err != nil {
+		errors = append(errors, err)
+	}
+
+	// Validate the watched resource and its metric namespace
+	errors = append(errors, validateAlarmRuleResource(alarmRule.Spec)...)
+
+	// Validate the notified topics
+	errors = append(
+		errors,
+		validateAlarmRuleActions(field.NewPath("spec", "alarmActions"), alarmRule.Spec.AlarmActions)...,
+	)
+	errors = append(
+		errors,
+		validateAlarmRuleActions(field.NewPath("spec", "okActions"), alarmRule.Spec.OKActions)...,
+	)
+
+	// Validate WriteConnectionDetailsToRef
+	if err := validateConnectionDetailsRef(alarmRule.Spec.WriteConnectionDetailsToRef); err != nil {
+		errors = append(errors, err)
+	}
+
+	// Warn if nobody is notified about the alarm
+	if len(alarmRule.Spec.AlarmActions) == 0 {
+		warnings = append(
+			warnings,
+			"alarmActions is empty: the alarm is only shown in Cloud Eye and nobody is notified",
+		)
+	}
+
+	// Warn about orphanOnDelete if true
+	if alarmRule.Spec.OrphanOnDelete {
+		warnings = append(
+			warnings,
+			"orphanOnDelete is true: external alarm rule will not be deleted when this resource is deleted",
+		)
+	}
+
+	if len(errors) == 0 {
+		return warnings, nil
+	}
+
+	return warnings, apierrors.NewInvalid(
+		alarmRule.GroupVersionKind().GroupKind(),
+		alarmRule.Name,
+		errors,
+	)
+}
+
+// ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the type AlarmRule.
+func (v *AlarmRuleCustomValidator) ValidateUpdate(
+	_ context.Context,
+	oldObj, newObj runtime.Object,
+) (admission.Warnings, error) {
+	oldAlarmRule, ok := oldObj.(*otcv1alpha1.AlarmRule)
+	if !ok {
+		return nil, fmt.Errorf("expected a AlarmRule object for the oldObj but got %T", oldObj)
+	}
+	newAlarmRule, ok := newObj.(*otcv1alpha1.AlarmRule)
+	if !ok {
+		return nil, fmt.Errorf("expected a AlarmRule object for the newObj but got %T", newObj)
+	}
+
+	var warnings admission.Warnings
+	var errors field.ErrorList
+
+	oldSpec := oldAlarmRule.Spec
+	newSpec := newAlarmRule.Spec
+
+	// Check immutable ProviderConfigRef
+	if !equalProviderConfigRef(oldSpec.ProviderConfigRef, newSpec.ProviderConfigRef) {
+		errors = append(
+			errors,
+			field.Forbidden(
+				field.NewPath("spec", "providerConfigRef"),
+				"is immutable and cannot be changed after creation",
+			),
+		)
+	}
+
+	// Check the fields Cloud Eye can not update
+	immutable := []struct {
+		name  string
+		equal bool
+	}{
+		{"description", oldSpec.Description == newSpec.Description},
+		{"metric", oldSpec.Metric == newSpec.Metric},
+		{"resource", equalAlarmRuleResource(oldSpec.Resource, newSpec.Resource)},
+		{"condition", oldSpec.Condition == newSpec.Condition},
+		{"level", oldSpec.Level == newSpec.Level},
+		{"alarmActions", slices.EqualFunc(oldSpec.AlarmActions, newSpec.AlarmActions, equalTopicDependency)},
+		{"okActions", slices.EqualFunc(oldSpec.OKActions, newSpec.OKActions, equalTopicDependency)},
+	}
+	for _, f := range immutable {
+		if !f.equal {
+			errors = append(
+				errors,
+				field.Forbidden(
+					field.NewPath("spec", f.name),
+					"is immutable and cannot be changed after creation",
+				),
+			)
+		}
+	}
+
+	// Validate WriteConnectionDetailsToRef
+	if err := validateConnectionDetailsRef(newSpec.WriteConnectionDetailsToRef); err != nil {
+		errors = append(errors, err)
+	}
+
+	// Warn if the alarm rule is being disabled
+	if oldSpec.Enabled && !newSpec.Enabled {
+		warnings = append(
+			warnings,
+			"enabled changed to false: the alarm rule will no longer be evaluated",
+		)
+	}
+
+	// Warn if orphanOnDelete is being changed from false to true
+	if !oldSpec.OrphanOnDelete && newSpec.OrphanOnDelete {
+		warnings = append(
+			warnings,
+			"orphanOnDelete changed to true: external alarm rule will not be deleted when this resource is deleted",
+		)
+	}
+
+	// Warn if orphanOnDelete is being changed from true to false
+	if oldSpec.OrphanOnDelete && !newSpec.OrphanOnDelete {
+		warnings = append(
+			warnings,
+			"orphanOnDelete changed to false: external alarm rule will be deleted when this resource is deleted",
+		)
+	}
+
+	if len(errors) == 0 {
+		return warnings, nil
+	}
+
+	return warnings, apierrors.NewInvalid(
+		oldAlarmRule.GroupVersionKind().GroupKind(),
+		oldAlarmRule.Name,
+		errors,
+	)
+}
+
+// ValidateDelete implements webhook.CustomValidator so a webhook will be registered for the type AlarmRule.
+func (v *AlarmRuleCustomValidator) ValidateDelete(
+	ctx context.Context,
+	obj runtime.Object,
+) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// validateAlarmRuleResource checks that exactly one resource is watched and
+// that the metric namespace provides the dimension of its kind.
+func validateAlarmRuleResource(spec otcv1alpha1.AlarmRuleSpec) field.ErrorList {
+	var errors field.ErrorList
+
+	path := field.NewPath("spec", "resource")
+	resource := spec.Resource
+
+	count := 0
+	var namespaces []string
+	if resource.NATGateway != nil {
+		count++
+		namespaces = []string{"SYS.NAT"}
+		if err := validateNATGatewayDependency(*resource.NATGateway); err != nil {
+			errors = append(
+				errors,
+				field.Invalid(path.Child("natGateway"), resource.NATGateway, err.Error()),
+			)
+		}
+	}
+	if resource.PublicIP != nil {
+		count++
+		namespaces = []string{"SYS.VPC"}
+		if err := validatePublicIPDependency(*resource.PublicIP); err != nil {
+			errors = append(
+				errors,
+				field.Invalid(path.Child("publicIP"), resource.PublicIP, err.Error()),
+			)
+		}
+	}
+	if resource.Instance != nil {
+		count++
+		namespaces = []string{"SYS.ECS", "AGT.ECS"}
+		if err := validateInstanceDependency(*resource.Instance); err != nil {
+			errors = append(
+				errors,
+				field.Invalid(path.Child("instance"), resource.Instance, err.Error()),
+			)
+		}
+	}
+
+	if count != 1 {
+		return append(
+			errors,
+			field.Invalid(
+				path,
+				resource,
+				"exactly one of natGateway, publicIP or instance must be specified",
+			),
+		)
+	}
+
+	if !slices.Contains(namespaces, spec.Metric.Namespace) {
+		errors = append(
+			errors,
+			field.NotSupported(
+				field.NewPath("spec", "metric", "namespace"),
+				spec.Metric.Namespace,
+				namespaces,
+			),
+		)
+	}
+
+	return errors
+}
+
+func validateAlarmRuleActions(
+	path *field.Path,
+	actions []otcv1alpha1.TopicDependency,
+) field.ErrorList {
+	var errors field.ErrorList
+
+	for i, action := range actions {
+		if err := validateTopicDependency(action); err != nil {
+			errors = append(errors, field.Invalid(path.Index(i), action, err.Error()))
+		}
+	}
+
+	return errors
+}
+
+func equalAlarmRuleResource(a, b otcv1alpha1.AlarmRuleResource) bool {
+	if (a.NATGateway == nil) != (b.NATGateway == nil) ||
+		(a.PublicIP == nil) != (b.PublicIP == nil) ||
+		(a.Instance == nil) != (b.Instance == nil) {
+		return false
+	}
+
+	switch {
+	case a.NATGateway != nil:
+		return equalNATGatewayDependency(*a.NATGateway, *b.NATGateway)
+	case a.PublicIP != nil:
+		return equalPublicIPDependency(*a.PublicIP, *b.PublicIP)
+	case a.Instance != nil:
+		return equalInstanceDependency(*a.Instance, *b.Instance)
+	default:
+		return true
+	}
+}
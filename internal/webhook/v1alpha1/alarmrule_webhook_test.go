@@ -0,0 +1,173 @@
+package v1alpha1
+
+import (
+	"context"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	otcv1alpha1 "github.com/peertech.de/otc-operator/api/v1alpha1"
+)
+
+func newTestAlarmRule() *otcv1alpha1.AlarmRule {
+	instanceID := "instance-id"
+	topicURN := "urn:smn:eu-de:project:alerts"
+	return &otcv1alpha1.AlarmRule{
+		ObjectMeta: metav1.ObjectMeta{Name: "cpu"},
+		Spec: otcv1alpha1.AlarmRuleSpec{
+			ProviderConfigRef: otcv1alpha1.ProviderConfigReference{Name: "provider"},
+			Metric:            otcv1alpha1.AlarmRuleMetric{Namespace: "SYS.ECS", Name: "cpu_util"},
+			Resource: otcv1alpha1.AlarmRuleResource{
+				Instance: &otcv1alpha1.InstanceDependency{InstanceID: &instanceID},
+			},
+			Condition: otcv1alpha1.AlarmRuleCondition{
+				ComparisonOperator: ">",
+				Value:              "80",
+				Filter:             otcv1alpha1.AlarmFilterAverage,
+				Period:             300,
+				Count:              3,
+			},
+			Enabled:      true,
+			AlarmActions: []otcv1alpha1.TopicDependency{{TopicURN: &topicURN}},
+		},
+	}
+}
+
+func TestAlarmRuleValidateCreate(t *testing.T) {
+	tests := []struct {
+		name     string
+		update   func(*otcv1alpha1.AlarmRule)
+		wantErr  bool
+		warnings int
+	}{
+		{
+			name:   "instance rule",
+			update: func(*otcv1alpha1.AlarmRule) {},
+		},
+		{
+			name: "public IP rule",
+			update: func(alarmRule *otcv1alpha1.AlarmRule) {
+				publicIPID := "public-ip-id"
+				alarmRule.Spec.Metric = otcv1alpha1.AlarmRuleMetric{Namespace: "SYS.VPC", Name: "upstream_bandwidth"}
+				alarmRule.Spec.Resource = otcv1alpha1.AlarmRuleResource{
+					PublicIP: &otcv1alpha1.PublicIPDependency{PublicIPID: &publicIPID},
+				}
+			},
+		},
+		{
+			name: "rule without alarm actions",
+			update: func(alarmRule *otcv1alpha1.AlarmRule) {
+				alarmRule.Spec.AlarmActions = nil
+			},
+			warnings: 1,
+		},
+		{
+			name: "invalid name",
+			update: func(alarmRule *otcv1alpha1.AlarmRule) {
+				alarmRule.Name = "cpu.high"
+			},
+			wantErr: true,
+		},
+		{
+			name: "rule without resource",
+			update: func(alarmRule *otcv1alpha1.AlarmRule) {
+				alarmRule.Spec.Resource = otcv1alpha1.AlarmRuleResource{}
+			},
+			wantErr: true,
+		},
+		{
+			name: "metric namespace of another resource",
+			update: func(alarmRule *otcv1alpha1.AlarmRule) {
+				alarmRule.Spec.Metric.Namespace = "SYS.NAT"
+			},
+			wantErr: true,
+		},
+		{
+			name: "empty topic URN",
+			update: func(alarmRule *otcv1alpha1.AlarmRule) {
+				topicURN := ""
+				alarmRule.Spec.OKActions = []otcv1alpha1.TopicDependency{{TopicURN: &topicURN}}
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			alarmRule := newTestAlarmRule()
+			tt.update(alarmRule)
+
+			warnings, err := (&AlarmRuleCustomValidator{}).ValidateCreate(context.Background(), alarmRule)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Expected error %v, got %v", tt.wantErr, err)
+			}
+			if len(warnings) != tt.warnings {
+				t.Fatalf("Expected %d warnings, got %v", tt.warnings, warnings)
+			}
+		})
+	}
+}
+
+func TestAlarmRuleValidateUpdate(t *testing.T) {
+	tests := []struct {
+		name     string
+		update   func(*otcv1alpha1.AlarmRule)
+		wantErr  bool
+		warnings int
+	}{
+		{
+			name: "rule disabled",
+			update: func(alarmRule *otcv1alpha1.AlarmRule) {
+				alarmRule.Spec.Enabled = false
+			},
+			warnings: 1,
+		},
+		{
+			name: "condition",
+			update: func(alarmRule *otcv1alpha1.AlarmRule) {
+				alarmRule.Spec.Condition.Value = "90"
+			},
+			wantErr: true,
+		},
+		{
+			name: "resource",
+			update: func(alarmRule *otcv1alpha1.AlarmRule) {
+				instanceID := "other-instance-id"
+				alarmRule.Spec.Resource.Instance.InstanceID = &instanceID
+			},
+			wantErr: true,
+		},
+		{
+			name: "alarm actions",
+			update: func(alarmRule *otcv1alpha1.AlarmRule) {
+				alarmRule.Spec.AlarmActions = nil
+			},
+			wantErr: true,
+		},
+		{
+			name: "provider config",
+			update: func(alarmRule *otcv1alpha1.AlarmRule) {
+				alarmRule.Spec.ProviderConfigRef.Name = "other-provider"
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			oldAlarmRule := newTestAlarmRule()
+			newAlarmRule := newTestAlarmRule()
+			tt.update(newAlarmRule)
+
+			warnings, err := (&AlarmRuleCustomValidator{}).ValidateUpdate(
+				context.Background(),
+				oldAlarmRule,
+				newAlarmRule,
+			)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Expected error %v, got %v", tt.wantErr, err)
+			}
+			if len(warnings) != tt.warnings {
+				t.Fatalf("Expected %d warnings, got %v", tt.warnings, warnings)
+			}
+		})
+	}
+}
@@ -0,0 +1,188 @@
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	otcv1alpha1 "github.com/peertech.de/otc-operator/api/v1alpha1"
+)
+
+// SetupBandwidthWebhookWithManager registers the webhook for Bandwidth in the manager.
+func SetupBandwidthWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).For(&otcv1alpha1.Bandwidth{}).
+		WithValidator(&BandwidthCustomValidator{}).
+		Complete()
+}
+
+// TODO(user): change verbs to "verbs=create;update;delete" if you want to enable deletion validation.
+// +kubebuilder:webhook:path=/validate-otc-peertech-de-v1alpha1-bandwidth,mutating=false,failurePolicy=fail,sideEffects=None,groups=otc.peertech.de,resources=bandwidths,verbs=create;update,versions=v1alpha1,name=vbandwidth-v1alpha1.kb.io,admissionReviewVersions=v1
+
+// BandwidthCustomValidator struct is responsible for validating the Bandwidth resource
+// when it is created, updated, or deleted.
+type BandwidthCustomValidator struct{}
+
+var _ webhook.CustomValidator = &BandwidthCustomValidator{}
+
+// ValidateCreate implements webhook.CustomValidator so a webhook will be registered for the type Bandwidth.
+func (v *BandwidthCustomValidator) ValidateCreate(
+	_ context.Context,
+	obj runtime.Object,
+) (admission.Warnings, error) {
+	bandwidth, ok := obj.(*otcv1alpha1.Bandwidth)
+	if !ok {
+		return nil, fmt.Errorf("expected a Bandwidth object but got %T", obj)
+	}
+
+	var warnings admission.Warnings
+	var errors field.ErrorList
+
+	// Validate the resource name
+	if !validName.MatchString(bandwidth.Name) {
+		errors = append(errors, field.Invalid(
+			field.NewPath("metadata", "name"),
+			bandwidth.Name,
+			"name must contain only letters, digits, underscores (_), hyphens (-), and periods (.)",
+		))
+	}
+
+	// Validate ProviderConfigRef
+	if err := validateProviderConfigRefName(bandwidth.Spec.ProviderConfigRef); err != nil {
+		errors = append(errors, err)
+	}
+
+	// Validate size
+	if bandwidth.Spec.Size <= 0 {
+		errors = append(
+			errors,
+			field.Invalid(
+				field.NewPath("spec", "size"),
+				bandwidth.Spec.Size,
+				"must be greater than 0",
+			),
+		)
+	}
+
+	// Validate WriteConnectionDetailsToRef
+	if err := validateConnectionDetailsRef(bandwidth.Spec.WriteConnectionDetailsToRef); err != nil {
+		errors = append(errors, err)
+	}
+
+	// Warn about orphanOnDelete if true
+	if bandwidth.Spec.OrphanOnDelete {
+		warnings = append(
+			warnings,
+			"orphanOnDelete is true: external bandwidth will not be deleted when this resource is deleted",
+		)
+	}
+
+	if len(errors) == 0 {
+		return warnings, nil
+	}
+
+	return warnings, apierrors.NewInvalid(
+		bandwidth.GroupVersionKind().GroupKind(),
+		bandwidth.Name,
+		errors,
+	)
+}
+
+// ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the type Bandwidth.
+func (v *BandwidthCustomValidator) ValidateUpdate(
+	_ context.Context,
+	oldObj, newObj runtime.Object,
+) (admission.Warnings, error) {
+	oldBandwidth, ok := oldObj.(*otcv1alpha1.Bandwidth)
+	if !ok {
+		return nil, fmt.Errorf("expected a Bandwidth object for the oldObj but got %T", oldObj)
+	}
+	newBandwidth, ok := newObj.(*otcv1alpha1.Bandwidth)
+	if !ok {
+		return nil, fmt.Errorf("expected a Bandwidth object for the newObj but got %T", newObj)
+	}
+
+	var warnings admission.Warnings
+	var errors field.ErrorList
+
+	// Check immutable ProviderConfigRef
+	if !equalProviderConfigRef(
+		oldBandwidth.Spec.ProviderConfigRef,
+		newBandwidth.Spec.ProviderConfigRef,
+	) {
+		errors = append(
+			errors,
+			field.Forbidden(
+				field.NewPath("spec", "providerConfigRef"),
+				"is immutable and cannot be changed after creation",
+			),
+		)
+	}
+
+	// Check immutable charge mode field
+	if newBandwidth.Spec.ChargeMode != oldBandwidth.Spec.ChargeMode {
+		errors = append(
+			errors,
+			field.Forbidden(
+				field.NewPath("spec", "chargeMode"),
+				"is immutable and cannot be changed after creation",
+			),
+		)
+	}
+
+	// Validate size
+	if newBandwidth.Spec.Size <= 0 {
+		errors = append(
+			errors,
+			field.Invalid(
+				field.NewPath("spec", "size"),
+				newBandwidth.Spec.Size,
+				"must be greater than 0",
+			),
+		)
+	}
+
+	// Validate WriteConnectionDetailsToRef
+	if err := validateConnectionDetailsRef(newBandwidth.Spec.WriteConnectionDetailsToRef); err != nil {
+		errors = append(errors, err)
+	}
+
+	// Warn if orphanOnDelete is being changed from false to true
+	if !oldBandwidth.Spec.OrphanOnDelete && newBandwidth.Spec.OrphanOnDelete {
+		warnings = append(
+			warnings,
+			"orphanOnDelete changed to true: external bandwidth will not be deleted when this resource is deleted",
+		)
+	}
+
+	// Warn if orphanOnDelete is being changed from true to false
+	if oldBandwidth.Spec.OrphanOnDelete && !newBandwidth.Spec.OrphanOnDelete {
+		warnings = append(
+			warnings,
+			"orphanOnDelete changed to false: external bandwidth will be deleted when this resource is deleted",
+		)
+	}
+
+	if len(errors) == 0 {
+		return warnings, nil
+	}
+
+	return warnings, apierrors.NewInvalid(
+		oldBandwidth.GroupVersionKind().GroupKind(),
+		oldBandwidth.Name,
+		errors,
+	)
+}
+
+// ValidateDelete implements webhook.CustomValidator so a webhook will be registered for the type Bandwidth.
+func (v *BandwidthCustomValidator) ValidateDelete(
+	ctx context.Context,
+	obj runtime.Object,
+) (admission.Warnings, error) {
+	return nil, nil
+}
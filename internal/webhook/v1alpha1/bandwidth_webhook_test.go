@@ -0,0 +1,100 @@
+package v1alpha1
+
+import (
+	"context"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	otcv1alpha1 "github.com/peertech.de/otc-operator/api/v1alpha1"
+)
+
+func newTestBandwidth() *otcv1alpha1.Bandwidth {
+	return &otcv1alpha1.Bandwidth{
+		ObjectMeta: metav1.ObjectMeta{Name: "shared"},
+		Spec: otcv1alpha1.BandwidthSpec{
+			ProviderConfigRef: otcv1alpha1.ProviderConfigReference{Name: "provider"},
+			Size:              100,
+			ChargeMode:        otcv1alpha1.BandwidthChargeModeTraffic,
+		},
+	}
+}
+
+func TestBandwidthValidateCreate(t *testing.T) {
+	tests := []struct {
+		name    string
+		update  func(*otcv1alpha1.Bandwidth)
+		wantErr bool
+	}{
+		{
+			name:   "valid",
+			update: func(*otcv1alpha1.Bandwidth) {},
+		},
+		{
+			name: "no size",
+			update: func(bandwidth *otcv1alpha1.Bandwidth) {
+				bandwidth.Spec.Size = 0
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bandwidth := newTestBandwidth()
+			tt.update(bandwidth)
+
+			_, err := (&BandwidthCustomValidator{}).ValidateCreate(context.Background(), bandwidth)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Expected error %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestBandwidthValidateUpdate(t *testing.T) {
+	tests := []struct {
+		name    string
+		update  func(*otcv1alpha1.Bandwidth)
+		wantErr bool
+	}{
+		{
+			name: "size",
+			update: func(bandwidth *otcv1alpha1.Bandwidth) {
+				bandwidth.Spec.Size = 200
+			},
+		},
+		{
+			name: "no size",
+			update: func(bandwidth *otcv1alpha1.Bandwidth) {
+				bandwidth.Spec.Size = 0
+			},
+			wantErr: true,
+		},
+		{
+			name: "charge mode",
+			update: func(bandwidth *otcv1alpha1.Bandwidth) {
+				bandwidth.Spec.ChargeMode = otcv1alpha1.BandwidthChargeModeBandwidth
+			},
+			wantErr: true,
+		},
+		{
+			name: "provider config",
+			update: func(bandwidth *otcv1alpha1.Bandwidth) {
+				bandwidth.Spec.ProviderConfigRef.Name = "other"
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			oldBandwidth := newTestBandwidth()
+			newBandwidth := newTestBandwidth()
+			tt.update(newBandwidth)
+
+			_, err := (&BandwidthCustomValidator{}).ValidateUpdate(context.Background(), oldBandwidth, newBandwidth)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Expected error %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,338 @@
+package v1alpha1
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"regexp"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	otcv1alpha1 "github.com/peertech.de/otc-operator/api/v1alpha1"
+)
+
+// validBucketName matches names of 3 to 63 lowercase letters, digits, hyphens
+// and periods, which start and end with a letter or digit.
+var validBucketName = regexp.MustCompile(`^[a-z0-9][a-z0-9.-]{1,61}[a-z0-9]$`)
+
+// SetupBucketWebhookWithManager registers the webhook for Bucket in the manager.
+func SetupBucketWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).For(&otcv1alpha1.Bucket{}).
+		WithValidator(&BucketCustomValidator{}).
+		Complete()
+}
+
+// TODO(user): change verbs to "verbs=create;update;delete" if you want to enable deletion validation.
+// +kubebuilder:webhook:path=/validate-otc-peertech-de-v1alpha1-bucket,mutating=false,failurePolicy=fail,sideEffects=None,groups=otc.peertech.de,resources=buckets,verbs=create;update,versions=v1alpha1,name=vbucket-v1alpha1.kb.io,admissionReviewVersions=v1
+
+// BucketCustomValidator struct is responsible for validating the Bucket resource
+// when it is created, updated, or deleted.
+type BucketCustomValidator struct{}
+
+var _ webhook.CustomValidator = &BucketCustomValidator{}
+
+// ValidateCreate implements webhook.CustomValidator so a webhook will be registered for the type Bucket.
+func (v *BucketCustomValidator) ValidateCreate(
+	_ context.Context,
+	obj runtime.Object,
+) (admission.Warnings, error) {
+	bucket, ok := obj.(*otcv1alpha1.Bucket)
+	if !ok {
+		return nil, fmt.Errorf("expected a Bucket object but got %T", obj)
+	}
+
+	var warnings admission.Warnings
+	var errors field.ErrorList
+
+	// Validate the resource name, which is the name of the bucket
+	if err := validateBucketName(bucket.Name); err != nil {
+		errors = append(errors, err)
+	}
+
+	// Validate ProviderConfigRef
+	if err := validateProviderConfigRefName(bucket.Spec.ProviderConfigRef); err != nil {
+		errors = append(errors, err)
+	}
+
+	// Validate the bucket configuration
+	errors = append(errors, validateBucketSpec(bucket.Spec)...)
+
+	// Validate WriteConnectionDetailsToRef
+	if err := validateConnectionDetailsRef(bucket.Spec.WriteConnectionDetailsToRef); err != nil {
+		errors = append(errors, err)
+	}
+
+	// Warn about forceDestroy if true
+	if bucket.Spec.ForceDestroy {
+		warnings = append(
+			warnings,
+			"forceDestroy is true: all objects of the bucket will be deleted when this resource is deleted",
+		)
+	}
+
+	// Warn about orphanOnDelete if true
+	if bucket.Spec.OrphanOnDelete {
+		warnings = append(
+			warnings,
+			"orphanOnDelete is true: external bucket will not be deleted when this resource is deleted",
+		)
+	}
+
+	if len(errors) == 0 {
+		return warnings, nil
+	}
+
+	return warnings, apierrors.NewInvalid(
+		bucket.GroupVersionKind().GroupKind(),
+		bucket.Name,
+		errors,
+	)
+}
+
+// ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the type Bucket.
+func (v *BucketCustomValidator) ValidateUpdate(
+	_ context.Context,
+	oldObj, newObj runtime.Object,
+) (admission.Warnings, error) {
+	oldBucket, ok := oldObj.(*otcv1alpha1.Bucket)
+	if !ok {
+		return nil, fmt.Errorf("expected a Bucket object for the oldObj but got %T", oldObj)
+	}
+	newBucket, ok := newObj.(*otcv1alpha1.Bucket)
+	if !ok {
+		return nil, fmt.Errorf("expected a Bucket object for the newObj but got %T", newObj)
+	}
+
+	var warnings admission.Warnings
+	var errors field.ErrorList
+
+	// Check immutable ProviderConfigRef
+	if !equalProviderConfigRef(
+		oldBucket.Spec.ProviderConfigRef,
+		newBucket.Spec.ProviderConfigRef,
+	) {
+		errors = append(
+			errors,
+			field.Forbidden(
+				field.NewPath("spec", "providerConfigRef"),
+				"is immutable and cannot be changed after creation",
+			),
+		)
+	}
+
+	// Validate the bucket configuration
+	errors = append(errors, validateBucketSpec(newBucket.Spec)...)
+
+	// Validate WriteConnectionDetailsToRef
+	if err := validateConnectionDetailsRef(newBucket.Spec.WriteConnectionDetailsToRef); err != nil {
+		errors = append(errors, err)
+	}
+
+	// Warn if versioning is being disabled, which only suspends it
+	if oldBucket.Spec.Versioning && !newBucket.Spec.Versioning {
+		warnings = append(
+			warnings,
+			"versioning changed to false: versioning will be suspended, existing object versions are kept",
+		)
+	}
+
+	// Warn if forceDestroy is being changed from false to true
+	if !oldBucket.Spec.ForceDestroy && newBucket.Spec.ForceDestroy {
+		warnings = append(
+			warnings,
+			"forceDestroy changed to true: all objects of the bucket will be deleted when this resource is deleted",
+		)
+	}
+
+	// Warn if orphanOnDelete is being changed from false to true
+	if !oldBucket.Spec.OrphanOnDelete && newBucket.Spec.OrphanOnDelete {
+		warnings = append(
+			warnings,
+			"orphanOnDelete changed to true: external bucket will not be deleted when this resource is deleted",
+		)
+	}
+
+	// Warn if orphanOnDelete is being changed from true to false
+	if oldBucket.Spec.OrphanOnDelete && !newBucket.Spec.OrphanOnDelete {
+		warnings = append(
+			warnings,
+			"orphanOnDelete changed to false: external bucket will be deleted when this resource is deleted",
+		)
+	}
+
+	if len(errors) == 0 {
+		return warnings, nil
+	}
+
+	return warnings, apierrors.NewInvalid(
+		oldBucket.GroupVersionKind().GroupKind(),
+		oldBucket.Name,
+		errors,
+	)
+}
+
+// ValidateDelete implements webhook.CustomValidator so a webhook will be registered for the type Bucket.
+func (v *BucketCustomValidator) ValidateDelete(
+	ctx context.Context,
+	obj runtime.Object,
+) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// validateBucketName checks the naming rules of OBS. Bucket names are used
+// as DNS labels, so they must not look like an IP address or contain
+// adjacent periods and hyphens.
+func validateBucketName(name string) *field.Error {
+	path := field.NewPath("metadata", "name")
+
+	if !validBucketName.MatchString(name) {
+		return field.Invalid(
+			path,
+			name,
+			"name must be 3 to 63 characters of lowercase letters, digits, hyphens (-) and periods (.), "+
+				"and start and end with a letter or digit",
+		)
+	}
+	if strings.Contains(name, "..") ||
+		strings.Contains(name, ".-") ||
+		strings.Contains(name, "-.") {
+		return field.Invalid(
+			path,
+			name,
+			"name must not contain adjacent periods, or periods adjacent to hyphens",
+		)
+	}
+	if net.ParseIP(name) != nil {
+		return field.Invalid(path, name, "name must not be formatted as an IP address")
+	}
+
+	return nil
+}
+
+func validateBucketSpec(spec otcv1alpha1.BucketSpec) field.ErrorList {
+	var errors field.ErrorList
+
+	rulesPath := field.NewPath("spec", "lifecycleRules")
+	for i, rule := range spec.LifecycleRules {
+		errors = append(errors, validateBucketLifecycleRule(rulesPath.Index(i), rule)...)
+	}
+
+	corsPath := field.NewPath("spec", "corsRules")
+	for i, rule := range spec.CORSRules {
+		for j, origin := range rule.AllowedOrigins {
+			if strings.Count(origin, "*") > 1 {
+				errors = append(
+					errors,
+					field.Invalid(
+						corsPath.Index(i).Child("allowedOrigins").Index(j),
+						origin,
+						"must contain at most one wildcard (*)",
+					),
+				)
+			}
+		}
+	}
+
+	if spec.Encryption != nil && spec.Encryption.KMSKey != nil {
+		if err := validateKMSKeyDependency(*spec.Encryption.KMSKey); err != nil {
+			errors = append(
+				errors,
+				field.Invalid(
+					field.NewPath("spec", "encryption", "kmsKey"),
+					spec.Encryption.KMSKey,
+					err.Error(),
+				),
+			)
+		}
+	}
+
+	if spec.Policy != "" {
+		var policy map[string]any
+		if err := json.Unmarshal([]byte(spec.Policy), &policy); err != nil {
+			errors = append(
+				errors,
+				field.Invalid(
+					field.NewPath("spec", "policy"),
+					spec.Policy,
+					fmt.Sprintf("must be a JSON object: %v", err),
+				),
+			)
+		}
+	}
+
+	return errors
+}
+
+// validateBucketLifecycleRule checks that a rule has an action and that its
+// transitions move the objects to colder storage classes over time.
+func validateBucketLifecycleRule(
+	path *field.Path,
+	rule otcv1alpha1.BucketLifecycleRule,
+) field.ErrorList {
+	var errors field.ErrorList
+
+	if rule.ExpirationDays == 0 &&
+		len(rule.Transitions) == 0 &&
+		rule.NoncurrentVersionExpirationDays == 0 {
+		errors = append(
+			errors,
+			field.Required(
+				path,
+				"at least one of expirationDays, transitions or noncurrentVersionExpirationDays must be specified",
+			),
+		)
+	}
+
+	var warmDays, coldDays int
+	for i, transition := range rule.Transitions {
+		switch transition.StorageClass {
+		case otcv1alpha1.BucketStorageClassWarm:
+			if warmDays > 0 {
+				errors = append(
+					errors,
+					field.Duplicate(path.Child("transitions").Index(i).Child("storageClass"), transition.StorageClass),
+				)
+			}
+			warmDays = transition.Days
+		case otcv1alpha1.BucketStorageClassCold:
+			if coldDays > 0 {
+				errors = append(
+					errors,
+					field.Duplicate(path.Child("transitions").Index(i).Child("storageClass"), transition.StorageClass),
+				)
+			}
+			coldDays = transition.Days
+		}
+	}
+
+	if warmDays > 0 && coldDays > 0 && coldDays <= warmDays {
+		errors = append(
+			errors,
+			field.Invalid(
+				path.Child("transitions"),
+				rule.Transitions,
+				"the transition to COLD must happen after the transition to WARM",
+			),
+		)
+	}
+
+	if rule.ExpirationDays > 0 && rule.ExpirationDays <= max(warmDays, coldDays) {
+		errors = append(
+			errors,
+			field.Invalid(
+				path.Child("expirationDays"),
+				rule.ExpirationDays,
+				"must be greater than the days of the transitions",
+			),
+		)
+	}
+
+	return errors
+}
@@ -0,0 +1,179 @@
+package v1alpha1
+
+import (
+	"context"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	otcv1alpha1 "github.com/peertech.de/otc-operator/api/v1alpha1"
+)
+
+func newTestBucket() *otcv1alpha1.Bucket {
+	return &otcv1alpha1.Bucket{
+		ObjectMeta: metav1.ObjectMeta{Name: "bucket"},
+		Spec: otcv1alpha1.BucketSpec{
+			ProviderConfigRef: otcv1alpha1.ProviderConfigReference{Name: "provider"},
+			Versioning:        true,
+			LifecycleRules: []otcv1alpha1.BucketLifecycleRule{
+				{
+					ID:             "archive",
+					Enabled:        true,
+					ExpirationDays: 365,
+					Transitions: []otcv1alpha1.BucketTransition{
+						{Days: 30, StorageClass: otcv1alpha1.BucketStorageClassWarm},
+						{Days: 90, StorageClass: otcv1alpha1.BucketStorageClassCold},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestBucketValidateCreate(t *testing.T) {
+	tests := []struct {
+		name    string
+		update  func(*otcv1alpha1.Bucket)
+		wantErr bool
+	}{
+		{
+			name:   "lifecycle rule",
+			update: func(*otcv1alpha1.Bucket) {},
+		},
+		{
+			name: "CORS rule and policy",
+			update: func(bucket *otcv1alpha1.Bucket) {
+				bucket.Spec.CORSRules = []otcv1alpha1.BucketCORSRule{
+					{AllowedOrigins: []string{"https://*.example.org"}, AllowedMethods: []otcv1alpha1.BucketCORSMethod{"GET"}},
+				}
+				bucket.Spec.Policy = `{"Statement": []}`
+			},
+		},
+		{
+			name: "name formatted as an IP address",
+			update: func(bucket *otcv1alpha1.Bucket) {
+				bucket.Name = "192.168.0.1"
+			},
+			wantErr: true,
+		},
+		{
+			name: "name with adjacent periods",
+			update: func(bucket *otcv1alpha1.Bucket) {
+				bucket.Name = "my..bucket"
+			},
+			wantErr: true,
+		},
+		{
+			name: "lifecycle rule without action",
+			update: func(bucket *otcv1alpha1.Bucket) {
+				bucket.Spec.LifecycleRules[0].ExpirationDays = 0
+				bucket.Spec.LifecycleRules[0].Transitions = nil
+			},
+			wantErr: true,
+		},
+		{
+			name: "transition to COLD before WARM",
+			update: func(bucket *otcv1alpha1.Bucket) {
+				bucket.Spec.LifecycleRules[0].Transitions[1].Days = 10
+			},
+			wantErr: true,
+		},
+		{
+			name: "expiration before transition",
+			update: func(bucket *otcv1alpha1.Bucket) {
+				bucket.Spec.LifecycleRules[0].ExpirationDays = 60
+			},
+			wantErr: true,
+		},
+		{
+			name: "CORS origin with two wildcards",
+			update: func(bucket *otcv1alpha1.Bucket) {
+				bucket.Spec.CORSRules = []otcv1alpha1.BucketCORSRule{
+					{AllowedOrigins: []string{"https://*.*.example.org"}, AllowedMethods: []otcv1alpha1.BucketCORSMethod{"GET"}},
+				}
+			},
+			wantErr: true,
+		},
+		{
+			name: "policy that is not a JSON object",
+			update: func(bucket *otcv1alpha1.Bucket) {
+				bucket.Spec.Policy = "allow all"
+			},
+			wantErr: true,
+		},
+		{
+			name: "empty KMS key ID",
+			update: func(bucket *otcv1alpha1.Bucket) {
+				kmsKeyID := ""
+				bucket.Spec.Encryption = &otcv1alpha1.BucketEncryption{
+					Algorithm: otcv1alpha1.BucketEncryptionKMS,
+					KMSKey:    &otcv1alpha1.KMSKeyDependency{KMSKeyID: &kmsKeyID},
+				}
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bucket := newTestBucket()
+			tt.update(bucket)
+
+			_, err := (&BucketCustomValidator{}).ValidateCreate(context.Background(), bucket)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Expected error %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestBucketValidateUpdate(t *testing.T) {
+	tests := []struct {
+		name     string
+		update   func(*otcv1alpha1.Bucket)
+		wantErr  bool
+		warnings int
+	}{
+		{
+			name: "lifecycle rules",
+			update: func(bucket *otcv1alpha1.Bucket) {
+				bucket.Spec.LifecycleRules[0].Enabled = false
+			},
+		},
+		{
+			name: "versioning suspended",
+			update: func(bucket *otcv1alpha1.Bucket) {
+				bucket.Spec.Versioning = false
+			},
+			warnings: 1,
+		},
+		{
+			name: "provider config",
+			update: func(bucket *otcv1alpha1.Bucket) {
+				bucket.Spec.ProviderConfigRef.Name = "other-provider"
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid lifecycle rule",
+			update: func(bucket *otcv1alpha1.Bucket) {
+				bucket.Spec.LifecycleRules[0].ExpirationDays = 60
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			oldBucket := newTestBucket()
+			newBucket := newTestBucket()
+			tt.update(newBucket)
+
+			warnings, err := (&BucketCustomValidator{}).ValidateUpdate(context.Background(), oldBucket, newBucket)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Expected error %v, got %v", tt.wantErr, err)
+			}
+			if len(warnings) != tt.warnings {
+				t.Fatalf("Expected %d warnings, got %v", tt.warnings, warnings)
+			}
+		})
+	}
+}
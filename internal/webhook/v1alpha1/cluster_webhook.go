@@ -0,0 +1,314 @@
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	otcv1alpha1 "github.com/peertech.de/otc-operator/api/v1alpha1"
+)
+
+// SetupClusterWebhookWithManager registers the webhook for Cluster in the manager.
+func SetupClusterWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).For(&otcv1alpha1.Cluster{}).
+		WithValidator(&ClusterCustomValidator{}).
+		Complete()
+}
+
+// TODO(user): change verbs to "verbs=create;update;delete" if you want to enable deletion validation.
+// +kubebuilder:webhook:path=/validate-otc-peertech-de-v1alpha1-cluster,mutating=false,failurePolicy=fail,sideEffects=None,groups=otc.peertech.de,resources=clusters,verbs=create;update,versions=v1alpha1,name=vcluster-v1alpha1.kb.io,admissionReviewVersions=v1
+
+// ClusterCustomValidator struct is responsible for validating the Cluster resource
+// when it is created, updated, or deleted.
+type ClusterCustomValidator struct{}
+
+var _ webhook.CustomValidator = &ClusterCustomValidator{}
+
+// ValidateCreate implements webhook.CustomValidator so a webhook will be registered for the type Cluster.
+func (v *ClusterCustomValidator) ValidateCreate(
+	_ context.Context,
+	obj runtime.Object,
+) (admission.Warnings, error) {
+	cluster, ok := obj.(*otcv1alpha1.Cluster)
+	if !ok {
+		return nil, fmt.Errorf("expected a Cluster object but got %T", obj)
+	}
+
+	var warnings admission.Warnings
+	var errors field.ErrorList
+
+	// Validate the resource name
+	if !validName.MatchString(cluster.Name) {
+		errors = append(errors, field.Invalid(
+			field.NewPath("metadata", "name"),
+			cluster.Name,
+			"name must contain only letters, digits, underscores (_), hyphens (-), and periods (.)",
+		))
+	}
+
+	// Validate ProviderConfigRef
+	if err := validateProviderConfigRefName(cluster.Spec.ProviderConfigRef); err != nil {
+		errors = append(errors, err)
+	}
+
+	// Validate that exactly one network dependency method is specified
+	if err := validateNetworkDependency(cluster.Spec.Network); err != nil {
+		errors = append(
+			errors,
+			field.Invalid(
+				field.NewPath("spec", "network"),
+				cluster.Spec.Network,
+				err.Error(),
+			),
+		)
+	}
+
+	// Validate that exactly one subnet dependency method is specified
+	if err := validateSubnetDependency(cluster.Spec.Subnet); err != nil {
+		errors = append(
+			errors,
+			field.Invalid(
+				field.NewPath("spec", "subnet"),
+				cluster.Spec.Subnet,
+				err.Error(),
+			),
+		)
+	}
+
+	// Validate the optional security group dependency
+	if securityGroup := cluster.Spec.SecurityGroup; securityGroup != nil {
+		if err := validateSecurityGroupDependency(*securityGroup); err != nil {
+			errors = append(
+				errors,
+				field.Invalid(
+					field.NewPath("spec", "securityGroup"),
+					securityGroup,
+					err.Error(),
+				),
+			)
+		}
+	}
+
+	// Validate the CIDRs of the cluster
+	if cidr := cluster.Spec.ContainerNetwork.CIDR; cidr != "" {
+		if err := validateCIDR(cidr); err != nil {
+			errors = append(
+				errors,
+				field.Invalid(field.NewPath("spec", "containerNetwork", "cidr"), cidr, err.Error()),
+			)
+		}
+	}
+	if cidr := cluster.Spec.ServiceCIDR; cidr != "" {
+		if err := validateCIDR(cidr); err != nil {
+			errors = append(
+				errors,
+				field.Invalid(field.NewPath("spec", "serviceCIDR"), cidr, err.Error()),
+			)
+		}
+	}
+
+	// Validate WriteKubeconfigToRef and WriteConnectionDetailsToRef
+	errors = append(errors, validateClusterOutputRefs(cluster.Spec)...)
+
+	// Warn about orphanOnDelete if true
+	if cluster.Spec.OrphanOnDelete {
+		warnings = append(
+			warnings,
+			"orphanOnDelete is true: external cluster will not be deleted when this resource is deleted",
+		)
+	}
+
+	if len(errors) == 0 {
+		return warnings, nil
+	}
+
+	return warnings, apierrors.NewInvalid(
+		cluster.GroupVersionKind().GroupKind(),
+		cluster.Name,
+		errors,
+	)
+}
+
+// ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the type Cluster.
+func (v *ClusterCustomValidator) ValidateUpdate(
+	_ context.Context,
+	oldObj, newObj runtime.Object,
+) (admission.Warnings, error) {
+	oldCluster, ok := oldObj.(*otcv1alpha1.Cluster)
+	if !ok {
+		return nil, fmt.Errorf("expected a Cluster object for the oldObj but got %T", oldObj)
+	}
+	newCluster, ok := newObj.(*otcv1alpha1.Cluster)
+	if !ok {
+		return nil, fmt.Errorf("expected a Cluster object for the newObj but got %T", newObj)
+	}
+
+	var warnings admission.Warnings
+	var errors field.ErrorList
+
+	// Check immutable ProviderConfigRef
+	if !equalProviderConfigRef(
+		oldCluster.Spec.ProviderConfigRef,
+		newCluster.Spec.ProviderConfigRef,
+	) {
+		errors = append(
+			errors,
+			field.Forbidden(
+				field.NewPath("spec", "providerConfigRef"),
+				"is immutable and cannot be changed after creation",
+			),
+		)
+	}
+
+	// Check immutable Network dependency
+	if !equalNetworkDependency(oldCluster.Spec.Network, newCluster.Spec.Network) {
+		errors = append(
+			errors,
+			field.Forbidden(
+				field.NewPath("spec", "network"),
+				"is immutable and cannot be changed after creation",
+			),
+		)
+	}
+
+	// Check immutable Subnet dependency
+	if !equalSubnetDependency(oldCluster.Spec.Subnet, newCluster.Spec.Subnet) {
+		errors = append(
+			errors,
+			field.Forbidden(
+				field.NewPath("spec", "subnet"),
+				"is immutable and cannot be changed after creation",
+			),
+		)
+	}
+
+	// Check immutable SecurityGroup dependency
+	oldSecurityGroup, newSecurityGroup := oldCluster.Spec.SecurityGroup, newCluster.Spec.SecurityGroup
+	if (oldSecurityGroup == nil) != (newSecurityGroup == nil) ||
+		(oldSecurityGroup != nil && !equalSecurityGroupDependency(*oldSecurityGroup, *newSecurityGroup)) {
+		errors = append(
+			errors,
+			field.Forbidden(
+				field.NewPath("spec", "securityGroup"),
+				"is immutable and cannot be changed after creation",
+			),
+		)
+	}
+
+	// Check immutable cluster settings
+	if oldCluster.Spec.Flavor != newCluster.Spec.Flavor {
+		errors = append(
+			errors,
+			field.Forbidden(
+				field.NewPath("spec", "flavor"),
+				"is immutable and cannot be changed after creation",
+			),
+		)
+	}
+	if oldCluster.Spec.Version != newCluster.Spec.Version {
+		errors = append(
+			errors,
+			field.Forbidden(
+				field.NewPath("spec", "version"),
+				"is immutable and cannot be changed after creation",
+			),
+		)
+	}
+	if oldCluster.Spec.ContainerNetwork != newCluster.Spec.ContainerNetwork {
+		errors = append(
+			errors,
+			field.Forbidden(
+				field.NewPath("spec", "containerNetwork"),
+				"is immutable and cannot be changed after creation",
+			),
+		)
+	}
+	if oldCluster.Spec.ServiceCIDR != newCluster.Spec.ServiceCIDR {
+		errors = append(
+			errors,
+			field.Forbidden(
+				field.NewPath("spec", "serviceCIDR"),
+				"is immutable and cannot be changed after creation",
+			),
+		)
+	}
+
+	// Validate WriteKubeconfigToRef and WriteConnectionDetailsToRef
+	errors = append(errors, validateClusterOutputRefs(newCluster.Spec)...)
+
+	// Warn if orphanOnDelete is being changed from false to true
+	if !oldCluster.Spec.OrphanOnDelete && newCluster.Spec.OrphanOnDelete {
+		warnings = append(
+			warnings,
+			"orphanOnDelete changed to true: external cluster will not be deleted when this resource is deleted",
+		)
+	}
+
+	// Warn if orphanOnDelete is being changed from true to false
+	if oldCluster.Spec.OrphanOnDelete && !newCluster.Spec.OrphanOnDelete {
+		warnings = append(
+			warnings,
+			"orphanOnDelete changed to false: external cluster will be deleted when this resource is deleted",
+		)
+	}
+
+	if len(errors) == 0 {
+		return warnings, nil
+	}
+
+	return warnings, apierrors.NewInvalid(
+		oldCluster.GroupVersionKind().GroupKind(),
+		oldCluster.Name,
+		errors,
+	)
+}
+
+// ValidateDelete implements webhook.CustomValidator so a webhook will be registered for the type Cluster.
+func (v *ClusterCustomValidator) ValidateDelete(
+	ctx context.Context,
+	obj runtime.Object,
+) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// validateClusterOutputRefs validates the references the outputs of the
+// cluster are written to. The kubeconfig is kept in a Secret of its own, so
+// the connection details must not be written to the same Secret.
+func validateClusterOutputRefs(spec otcv1alpha1.ClusterSpec) field.ErrorList {
+	var errors field.ErrorList
+
+	if ref := spec.WriteKubeconfigToRef; ref != nil {
+		if err := validateObjectRef(*ref); err != nil {
+			errors = append(
+				errors,
+				field.Invalid(field.NewPath("spec", "writeKubeconfigToRef"), ref, err.Error()),
+			)
+		}
+	}
+
+	if err := validateConnectionDetailsRef(spec.WriteConnectionDetailsToRef); err != nil {
+		errors = append(errors, err)
+	}
+
+	kubeconfigRef, detailsRef := spec.WriteKubeconfigToRef, spec.WriteConnectionDetailsToRef
+	if kubeconfigRef != nil && detailsRef != nil &&
+		detailsRef.Kind != otcv1alpha1.ConnectionDetailsConfigMap &&
+		detailsRef.Name == kubeconfigRef.Name {
+		errors = append(
+			errors,
+			field.Invalid(
+				field.NewPath("spec", "writeKubeconfigToRef", "name"),
+				kubeconfigRef.Name,
+				"must be different from the Secret in writeConnectionDetailsToRef",
+			),
+		)
+	}
+
+	return errors
+}
@@ -0,0 +1,114 @@
+package v1alpha1
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	otcv1alpha1 "github.com/peertech.de/otc-operator/api/v1alpha1"
+)
+
+func newTestCluster() *otcv1alpha1.Cluster {
+	networkID, subnetID := "network-id", "subnet-id"
+	return &otcv1alpha1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "cluster"},
+		Spec: otcv1alpha1.ClusterSpec{
+			ProviderConfigRef:    otcv1alpha1.ProviderConfigReference{Name: "provider"},
+			Flavor:               "cce.s1.small",
+			Version:              "v1.31",
+			Network:              otcv1alpha1.NetworkDependency{NetworkID: &networkID},
+			Subnet:               otcv1alpha1.SubnetDependency{SubnetID: &subnetID},
+			WriteKubeconfigToRef: &corev1.LocalObjectReference{Name: "kubeconfig"},
+		},
+	}
+}
+
+func TestClusterValidateCreate(t *testing.T) {
+	tests := []struct {
+		name    string
+		update  func(*otcv1alpha1.Cluster)
+		wantErr bool
+	}{
+		{
+			name:   "valid",
+			update: func(*otcv1alpha1.Cluster) {},
+		},
+		{
+			name: "no subnet",
+			update: func(cluster *otcv1alpha1.Cluster) {
+				cluster.Spec.Subnet = otcv1alpha1.SubnetDependency{}
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid service CIDR",
+			update: func(cluster *otcv1alpha1.Cluster) {
+				cluster.Spec.ServiceCIDR = "10.247.0.0"
+			},
+			wantErr: true,
+		},
+		{
+			name: "connection details in kubeconfig Secret",
+			update: func(cluster *otcv1alpha1.Cluster) {
+				cluster.Spec.WriteConnectionDetailsToRef = &otcv1alpha1.ConnectionDetailsReference{
+					Name: cluster.Spec.WriteKubeconfigToRef.Name,
+				}
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cluster := newTestCluster()
+			tt.update(cluster)
+
+			_, err := (&ClusterCustomValidator{}).ValidateCreate(context.Background(), cluster)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Expected error %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestClusterValidateUpdate(t *testing.T) {
+	tests := []struct {
+		name    string
+		update  func(*otcv1alpha1.Cluster)
+		wantErr bool
+	}{
+		{
+			name: "description",
+			update: func(cluster *otcv1alpha1.Cluster) {
+				cluster.Spec.Description = "production"
+			},
+		},
+		{
+			name: "version",
+			update: func(cluster *otcv1alpha1.Cluster) {
+				cluster.Spec.Version = "v1.32"
+			},
+			wantErr: true,
+		},
+		{
+			name: "service CIDR",
+			update: func(cluster *otcv1alpha1.Cluster) {
+				cluster.Spec.ServiceCIDR = "10.247.0.0/16"
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			oldCluster := newTestCluster()
+			newCluster := newTestCluster()
+			tt.update(newCluster)
+
+			_, err := (&ClusterCustomValidator{}).ValidateUpdate(context.Background(), oldCluster, newCluster)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Expected error %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
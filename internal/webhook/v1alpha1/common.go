@@ -4,9 +4,12 @@ import (
 	"fmt"
 	"net"
 	"regexp"
+	"strings"
 
 	corev1 "k8s.io/api/core/v1"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1validation "k8s.io/apimachinery/pkg/apis/meta/v1/validation"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 
 	otcv1alpha1 "github.com/peertech.de/otc-operator/api/v1alpha1"
@@ -34,13 +37,13 @@ func validateNetworkDependency(dep otcv1alpha1.NetworkDependency) error {
 	}
 	if dep.NetworkRef != nil {
 		count++
-		if err := validateObjectRef(*dep.NetworkRef); err != nil {
+		if err := validateNamespacedObjectRef(*dep.NetworkRef); err != nil {
 			return fmt.Errorf("networkRef: %w", err)
 		}
 	}
 	if dep.NetworkSelector != nil {
 		count++
-		if err := validateLabelSelector(*dep.NetworkSelector); err != nil {
+		if err := validateResourceSelector(*dep.NetworkSelector); err != nil {
 			return fmt.Errorf("networkSelector: %w", err)
 		}
 	}
@@ -67,13 +70,13 @@ func validateSubnetDependency(dep otcv1alpha1.SubnetDependency) error {
 	}
 	if dep.SubnetRef != nil {
 		count++
-		if err := validateObjectRef(*dep.SubnetRef); err != nil {
+		if err := validateNamespacedObjectRef(*dep.SubnetRef); err != nil {
 			return fmt.Errorf("subnetRef: %w", err)
 		}
 	}
 	if dep.SubnetSelector != nil {
 		count++
-		if err := validateLabelSelector(*dep.SubnetSelector); err != nil {
+		if err := validateResourceSelector(*dep.SubnetSelector); err != nil {
 			return fmt.Errorf("subnetSelector: %w", err)
 		}
 	}
@@ -98,13 +101,13 @@ func validateSecurityGroupDependency(dep otcv1alpha1.SecurityGroupDependency) er
 	}
 	if dep.SecurityGroupRef != nil {
 		count++
-		if err := validateObjectRef(*dep.SecurityGroupRef); err != nil {
+		if err := validateNamespacedObjectRef(*dep.SecurityGroupRef); err != nil {
 			return fmt.Errorf("securityGroupRef: %w", err)
 		}
 	}
 	if dep.SecurityGroupSelector != nil {
 		count++
-		if err := validateLabelSelector(*dep.SecurityGroupSelector); err != nil {
+		if err := validateResourceSelector(*dep.SecurityGroupSelector); err != nil {
 			return fmt.Errorf("securityGroupSelector: %w", err)
 		}
 	}
@@ -139,7 +142,7 @@ func validateNATGatewayDependency(dep otcv1alpha1.NATGatewayDependency) error {
 	}
 	if dep.NATGatewaySelector != nil {
 		count++
-		if err := validateLabelSelector(*dep.NATGatewaySelector); err != nil {
+		if err := validateResourceSelector(*dep.NATGatewaySelector); err != nil {
 			return fmt.Errorf("natGatewaySelector: %w", err)
 		}
 	}
@@ -174,7 +177,7 @@ func validatePublicIPDependency(dep otcv1alpha1.PublicIPDependency) error {
 	}
 	if dep.PublicIPSelector != nil {
 		count++
-		if err := validateLabelSelector(*dep.PublicIPSelector); err != nil {
+		if err := validateResourceSelector(*dep.PublicIPSelector); err != nil {
 			return fmt.Errorf("publicIPSelector: %w", err)
 		}
 	}
@@ -193,6 +196,355 @@ func validatePublicIPDependency(dep otcv1alpha1.PublicIPDependency) error {
 	return nil
 }
 
+func validateBandwidthDependency(dep otcv1alpha1.BandwidthDependency) error {
+	count := 0
+	if dep.BandwidthID != nil {
+		count++
+		if *dep.BandwidthID == "" {
+			return fmt.Errorf("bandwidthID cannot be empty")
+		}
+	}
+	if dep.BandwidthRef != nil {
+		count++
+		if err := validateObjectRef(*dep.BandwidthRef); err != nil {
+			return fmt.Errorf("bandwidthRef: %w", err)
+		}
+	}
+	if dep.BandwidthSelector != nil {
+		count++
+		if err := validateResourceSelector(*dep.BandwidthSelector); err != nil {
+			return fmt.Errorf("bandwidthSelector: %w", err)
+		}
+	}
+
+	if count == 0 {
+		return fmt.Errorf(
+			"exactly one of bandwidthID, bandwidthRef or bandwidthSelector must be specified",
+		)
+	}
+	if count > 1 {
+		return fmt.Errorf(
+			"only one of bandwidthID, bandwidthRef or bandwidthSelector can be specified",
+		)
+	}
+
+	return nil
+}
+
+func validateInstanceDependency(dep otcv1alpha1.InstanceDependency) error {
+	count := 0
+	if dep.InstanceID != nil {
+		count++
+		if *dep.InstanceID == "" {
+			return fmt.Errorf("instanceID cannot be empty")
+		}
+	}
+	if dep.InstanceRef != nil {
+		count++
+		if err := validateObjectRef(*dep.InstanceRef); err != nil {
+			return fmt.Errorf("instanceRef: %w", err)
+		}
+	}
+	if dep.InstanceSelector != nil {
+		count++
+		if err := validateResourceSelector(*dep.InstanceSelector); err != nil {
+			return fmt.Errorf("instanceSelector: %w", err)
+		}
+	}
+
+	if count == 0 {
+		return fmt.Errorf(
+			"exactly one of instanceID, instanceRef or instanceSelector must be specified",
+		)
+	}
+	if count > 1 {
+		return fmt.Errorf(
+			"only one of instanceID, instanceRef or instanceSelector can be specified",
+		)
+	}
+
+	return nil
+}
+
+func validateKeyPairDependency(dep otcv1alpha1.KeyPairDependency) error {
+	count := 0
+	if dep.KeyPairName != nil {
+		count++
+		if *dep.KeyPairName == "" {
+			return fmt.Errorf("keyPairName cannot be empty")
+		}
+	}
+	if dep.KeyPairRef != nil {
+		count++
+		if err := validateObjectRef(*dep.KeyPairRef); err != nil {
+			return fmt.Errorf("keyPairRef: %w", err)
+		}
+	}
+	if dep.KeyPairSelector != nil {
+		count++
+		if err := validateResourceSelector(*dep.KeyPairSelector); err != nil {
+			return fmt.Errorf("keyPairSelector: %w", err)
+		}
+	}
+
+	if count == 0 {
+		return fmt.Errorf(
+			"exactly one of keyPairName, keyPairRef or keyPairSelector must be specified",
+		)
+	}
+	if count > 1 {
+		return fmt.Errorf(
+			"only one of keyPairName, keyPairRef or keyPairSelector can be specified",
+		)
+	}
+
+	return nil
+}
+
+func validateDNSZoneDependency(dep otcv1alpha1.DNSZoneDependency) error {
+	count := 0
+	if dep.DNSZoneID != nil {
+		count++
+		if *dep.DNSZoneID == "" {
+			return fmt.Errorf("dnsZoneID cannot be empty")
+		}
+	}
+	if dep.DNSZoneRef != nil {
+		count++
+		if err := validateObjectRef(*dep.DNSZoneRef); err != nil {
+			return fmt.Errorf("dnsZoneRef: %w", err)
+		}
+	}
+	if dep.DNSZoneSelector != nil {
+		count++
+		if err := validateResourceSelector(*dep.DNSZoneSelector); err != nil {
+			return fmt.Errorf("dnsZoneSelector: %w", err)
+		}
+	}
+
+	if count == 0 {
+		return fmt.Errorf(
+			"exactly one of dnsZoneID, dnsZoneRef or dnsZoneSelector must be specified",
+		)
+	}
+	if count > 1 {
+		return fmt.Errorf(
+			"only one of dnsZoneID, dnsZoneRef or dnsZoneSelector can be specified",
+		)
+	}
+
+	return nil
+}
+
+func validateVPNGatewayDependency(dep otcv1alpha1.VPNGatewayDependency) error {
+	count := 0
+	if dep.VPNGatewayID != nil {
+		count++
+		if *dep.VPNGatewayID == "" {
+			return fmt.Errorf("vpnGatewayID cannot be empty")
+		}
+	}
+	if dep.VPNGatewayRef != nil {
+		count++
+		if err := validateObjectRef(*dep.VPNGatewayRef); err != nil {
+			return fmt.Errorf("vpnGatewayRef: %w", err)
+		}
+	}
+	if dep.VPNGatewaySelector != nil {
+		count++
+		if err := validateResourceSelector(*dep.VPNGatewaySelector); err != nil {
+			return fmt.Errorf("vpnGatewaySelector: %w", err)
+		}
+	}
+
+	if count == 0 {
+		return fmt.Errorf(
+			"exactly one of vpnGatewayID, vpnGatewayRef or vpnGatewaySelector must be specified",
+		)
+	}
+	if count > 1 {
+		return fmt.Errorf(
+			"only one of vpnGatewayID, vpnGatewayRef or vpnGatewaySelector can be specified",
+		)
+	}
+
+	return nil
+}
+
+func validateCustomerGatewayDependency(dep otcv1alpha1.CustomerGatewayDependency) error {
+	count := 0
+	if dep.CustomerGatewayID != nil {
+		count++
+		if *dep.CustomerGatewayID == "" {
+			return fmt.Errorf("customerGatewayID cannot be empty")
+		}
+	}
+	if dep.CustomerGatewayRef != nil {
+		count++
+		if err := validateObjectRef(*dep.CustomerGatewayRef); err != nil {
+			return fmt.Errorf("customerGatewayRef: %w", err)
+		}
+	}
+	if dep.CustomerGatewaySelector != nil {
+		count++
+		if err := validateResourceSelector(*dep.CustomerGatewaySelector); err != nil {
+			return fmt.Errorf("customerGatewaySelector: %w", err)
+		}
+	}
+
+	if count == 0 {
+		return fmt.Errorf(
+			"exactly one of customerGatewayID, customerGatewayRef or customerGatewaySelector must be specified",
+		)
+	}
+	if count > 1 {
+		return fmt.Errorf(
+			"only one of customerGatewayID, customerGatewayRef or customerGatewaySelector can be specified",
+		)
+	}
+
+	return nil
+}
+
+func validateClusterDependency(dep otcv1alpha1.ClusterDependency) error {
+	count := 0
+	if dep.ClusterID != nil {
+		count++
+		if *dep.ClusterID == "" {
+			return fmt.Errorf("clusterID cannot be empty")
+		}
+	}
+	if dep.ClusterRef != nil {
+		count++
+		if err := validateObjectRef(*dep.ClusterRef); err != nil {
+			return fmt.Errorf("clusterRef: %w", err)
+		}
+	}
+	if dep.ClusterSelector != nil {
+		count++
+		if err := validateResourceSelector(*dep.ClusterSelector); err != nil {
+			return fmt.Errorf("clusterSelector: %w", err)
+		}
+	}
+
+	if count == 0 {
+		return fmt.Errorf(
+			"exactly one of clusterID, clusterRef or clusterSelector must be specified",
+		)
+	}
+	if count > 1 {
+		return fmt.Errorf(
+			"only one of clusterID, clusterRef or clusterSelector can be specified",
+		)
+	}
+
+	return nil
+}
+
+func validateKMSKeyDependency(dep otcv1alpha1.KMSKeyDependency) error {
+	count := 0
+	if dep.KMSKeyID != nil {
+		count++
+		if *dep.KMSKeyID == "" {
+			return fmt.Errorf("kmsKeyID cannot be empty")
+		}
+	}
+	if dep.KMSKeyRef != nil {
+		count++
+		if err := validateObjectRef(*dep.KMSKeyRef); err != nil {
+			return fmt.Errorf("kmsKeyRef: %w", err)
+		}
+	}
+	if dep.KMSKeySelector != nil {
+		count++
+		if err := validateResourceSelector(*dep.KMSKeySelector); err != nil {
+			return fmt.Errorf("kmsKeySelector: %w", err)
+		}
+	}
+
+	if count == 0 {
+		return fmt.Errorf(
+			"exactly one of kmsKeyID, kmsKeyRef or kmsKeySelector must be specified",
+		)
+	}
+	if count > 1 {
+		return fmt.Errorf(
+			"only one of kmsKeyID, kmsKeyRef or kmsKeySelector can be specified",
+		)
+	}
+
+	return nil
+}
+
+func validateTopicDependency(dep otcv1alpha1.TopicDependency) error {
+	count := 0
+	if dep.TopicURN != nil {
+		count++
+		if *dep.TopicURN == "" {
+			return fmt.Errorf("topicURN cannot be empty")
+		}
+	}
+	if dep.TopicRef != nil {
+		count++
+		if err := validateObjectRef(*dep.TopicRef); err != nil {
+			return fmt.Errorf("topicRef: %w", err)
+		}
+	}
+	if dep.TopicSelector != nil {
+		count++
+		if err := validateResourceSelector(*dep.TopicSelector); err != nil {
+			return fmt.Errorf("topicSelector: %w", err)
+		}
+	}
+
+	if count == 0 {
+		return fmt.Errorf(
+			"exactly one of topicURN, topicRef or topicSelector must be specified",
+		)
+	}
+	if count > 1 {
+		return fmt.Errorf(
+			"only one of topicURN, topicRef or topicSelector can be specified",
+		)
+	}
+
+	return nil
+}
+
+func validateRecordSetValueSource(source otcv1alpha1.RecordSetValueSource) error {
+	count := 0
+	if source.PublicIPRef != nil {
+		count++
+		if err := validateObjectRef(*source.PublicIPRef); err != nil {
+			return fmt.Errorf("publicIPRef: %w", err)
+		}
+	}
+	if source.VirtualIPRef != nil {
+		count++
+		if err := validateObjectRef(*source.VirtualIPRef); err != nil {
+			return fmt.Errorf("virtualIPRef: %w", err)
+		}
+	}
+
+	if count == 0 {
+		return fmt.Errorf("exactly one of publicIPRef or virtualIPRef must be specified")
+	}
+	if count > 1 {
+		return fmt.Errorf("only one of publicIPRef or virtualIPRef can be specified")
+	}
+
+	return nil
+}
+
+// validateDomainName validates that the name is a valid domain name. A
+// trailing dot is allowed.
+func validateDomainName(name string) error {
+	if msgs := validation.IsDNS1123Subdomain(strings.TrimSuffix(name, ".")); len(msgs) > 0 {
+		return fmt.Errorf("must be a valid domain name: %s", strings.Join(msgs, ", "))
+	}
+	return nil
+}
+
 func validateObjectRef(ref corev1.LocalObjectReference) error {
 	if ref.Name == "" {
 		return fmt.Errorf("name is required")
@@ -200,9 +552,21 @@ func validateObjectRef(ref corev1.LocalObjectReference) error {
 	return nil
 }
 
-func validateLabelSelector(selector metav1.LabelSelector) error {
-	if len(selector.MatchLabels) == 0 {
-		return fmt.Errorf("matchLabels cannot be empty")
+func validateNamespacedObjectRef(ref otcv1alpha1.NamespacedObjectReference) error {
+	if ref.Name == "" {
+		return fmt.Errorf("name is required")
+	}
+	if ref.Namespace != "" {
+		if msgs := validation.IsDNS1123Label(ref.Namespace); len(msgs) > 0 {
+			return fmt.Errorf("namespace must be a valid namespace name: %s", strings.Join(msgs, ", "))
+		}
+	}
+	return nil
+}
+
+func validateResourceSelector(selector otcv1alpha1.ResourceSelector) error {
+	if len(selector.MatchLabels) == 0 && len(selector.MatchExpressions) == 0 {
+		return fmt.Errorf("matchLabels or matchExpressions must be set")
 	}
 	for key, value := range selector.MatchLabels {
 		if key == "" {
@@ -212,6 +576,14 @@ func validateLabelSelector(selector metav1.LabelSelector) error {
 			return fmt.Errorf("label value for key %s cannot be empty", key)
 		}
 	}
+	errs := metav1validation.ValidateLabelSelector(
+		&selector.LabelSelector,
+		metav1validation.LabelSelectorValidationOptions{},
+		nil,
+	)
+	if len(errs) > 0 {
+		return errs.ToAggregate()
+	}
 	return nil
 }
 
@@ -230,6 +602,44 @@ func validateCIDR(cidr string) error {
 	return nil
 }
 
+// validateIPv4 validates that the address is a valid IPv4 address
+func validateIPv4(address string) error {
+	ip := net.ParseIP(address)
+	if ip == nil || ip.To4() == nil {
+		return fmt.Errorf("'%s' must be a valid IPv4 address", address)
+	}
+
+	return nil
+}
+
+// validateConnectionDetailsRef validates the optional reference to the Secret
+// or ConfigMap the connection details are written to
+func validateConnectionDetailsRef(ref *otcv1alpha1.ConnectionDetailsReference) *field.Error {
+	if ref == nil {
+		return nil
+	}
+
+	path := field.NewPath("spec", "writeConnectionDetailsToRef")
+	switch ref.Kind {
+	case otcv1alpha1.ConnectionDetailsSecret, otcv1alpha1.ConnectionDetailsConfigMap, "":
+	default:
+		return field.NotSupported(
+			path.Child("kind"),
+			ref.Kind,
+			[]string{
+				string(otcv1alpha1.ConnectionDetailsSecret),
+				string(otcv1alpha1.ConnectionDetailsConfigMap),
+			},
+		)
+	}
+
+	if msgs := validation.IsDNS1123Subdomain(ref.Name); len(msgs) > 0 {
+		return field.Invalid(path.Child("name"), ref.Name, strings.Join(msgs, ", "))
+	}
+
+	return nil
+}
+
 func validateSecretKeySelector(selector corev1.SecretKeySelector) error {
 	if selector.Name == "" {
 		return fmt.Errorf("secret name is required")
@@ -240,38 +650,157 @@ func validateSecretKeySelector(selector corev1.SecretKeySelector) error {
 	return nil
 }
 
+func validatePublicIPBinding(binding otcv1alpha1.PublicIPBinding) error {
+	count := 0
+	if binding.PortID != nil {
+		count++
+		if *binding.PortID == "" {
+			return fmt.Errorf("portID cannot be empty")
+		}
+	}
+	if binding.VirtualIPRef != nil {
+		count++
+		if err := validateObjectRef(*binding.VirtualIPRef); err != nil {
+			return fmt.Errorf("virtualIPRef: %w", err)
+		}
+	}
+	if binding.InstanceRef != nil {
+		count++
+		if err := validateObjectRef(*binding.InstanceRef); err != nil {
+			return fmt.Errorf("instanceRef: %w", err)
+		}
+	}
+
+	if count == 0 {
+		return fmt.Errorf("exactly one of portID, virtualIPRef or instanceRef must be specified")
+	}
+	if count > 1 {
+		return fmt.Errorf("only one of portID, virtualIPRef or instanceRef can be specified")
+	}
+
+	return nil
+}
+
 func equalProviderConfigRef(a, b otcv1alpha1.ProviderConfigReference) bool {
 	return a.Name == b.Name
 }
 
 func equalNetworkDependency(a, b otcv1alpha1.NetworkDependency) bool {
 	return equalStringPtr(a.NetworkID, b.NetworkID) &&
-		equalObjectRef(a.NetworkRef, b.NetworkRef) &&
-		equalLabelSelector(a.NetworkSelector, b.NetworkSelector)
+		equalNamespacedObjectRef(a.NetworkRef, b.NetworkRef) &&
+		equalResourceSelector(a.NetworkSelector, b.NetworkSelector)
 }
 
 func equalSubnetDependency(a, b otcv1alpha1.SubnetDependency) bool {
 	return equalStringPtr(a.SubnetID, b.SubnetID) &&
-		equalObjectRef(a.SubnetRef, b.SubnetRef) &&
-		equalLabelSelector(a.SubnetSelector, b.SubnetSelector)
+		equalNamespacedObjectRef(a.SubnetRef, b.SubnetRef) &&
+		equalResourceSelector(a.SubnetSelector, b.SubnetSelector)
 }
 
 func equalNATGatewayDependency(a, b otcv1alpha1.NATGatewayDependency) bool {
 	return equalStringPtr(a.NATGatewayID, b.NATGatewayID) &&
 		equalObjectRef(a.NATGatewayRef, b.NATGatewayRef) &&
-		equalLabelSelector(a.NATGatewaySelector, b.NATGatewaySelector)
+		equalResourceSelector(a.NATGatewaySelector, b.NATGatewaySelector)
 }
 
 func equalPublicIPDependency(a, b otcv1alpha1.PublicIPDependency) bool {
 	return equalStringPtr(a.PublicIPID, b.PublicIPID) &&
 		equalObjectRef(a.PublicIPRef, b.PublicIPRef) &&
-		equalLabelSelector(a.PublicIPSelector, b.PublicIPSelector)
+		equalResourceSelector(a.PublicIPSelector, b.PublicIPSelector)
+}
+
+func equalInstanceDependency(a, b otcv1alpha1.InstanceDependency) bool {
+	return equalStringPtr(a.InstanceID, b.InstanceID) &&
+		equalObjectRef(a.InstanceRef, b.InstanceRef) &&
+		equalResourceSelector(a.InstanceSelector, b.InstanceSelector)
+}
+
+func equalBandwidthDependency(a, b *otcv1alpha1.BandwidthDependency) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return equalStringPtr(a.BandwidthID, b.BandwidthID) &&
+		equalObjectRef(a.BandwidthRef, b.BandwidthRef) &&
+		equalResourceSelector(a.BandwidthSelector, b.BandwidthSelector)
+}
+
+func equalKeyPairDependency(a, b *otcv1alpha1.KeyPairDependency) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return equalStringPtr(a.KeyPairName, b.KeyPairName) &&
+		equalObjectRef(a.KeyPairRef, b.KeyPairRef) &&
+		equalResourceSelector(a.KeyPairSelector, b.KeyPairSelector)
+}
+
+func equalDNSZoneDependency(a, b otcv1alpha1.DNSZoneDependency) bool {
+	return equalStringPtr(a.DNSZoneID, b.DNSZoneID) &&
+		equalObjectRef(a.DNSZoneRef, b.DNSZoneRef) &&
+		equalResourceSelector(a.DNSZoneSelector, b.DNSZoneSelector)
+}
+
+func equalVPNGatewayDependency(a, b otcv1alpha1.VPNGatewayDependency) bool {
+	return equalStringPtr(a.VPNGatewayID, b.VPNGatewayID) &&
+		equalObjectRef(a.VPNGatewayRef, b.VPNGatewayRef) &&
+		equalResourceSelector(a.VPNGatewaySelector, b.VPNGatewaySelector)
+}
+
+func equalCustomerGatewayDependency(a, b otcv1alpha1.CustomerGatewayDependency) bool {
+	return equalStringPtr(a.CustomerGatewayID, b.CustomerGatewayID) &&
+		equalObjectRef(a.CustomerGatewayRef, b.CustomerGatewayRef) &&
+		equalResourceSelector(a.CustomerGatewaySelector, b.CustomerGatewaySelector)
+}
+
+func equalClusterDependency(a, b otcv1alpha1.ClusterDependency) bool {
+	return equalStringPtr(a.ClusterID, b.ClusterID) &&
+		equalObjectRef(a.ClusterRef, b.ClusterRef) &&
+		equalResourceSelector(a.ClusterSelector, b.ClusterSelector)
+}
+
+func equalKMSKeyDependency(a, b *otcv1alpha1.KMSKeyDependency) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return equalStringPtr(a.KMSKeyID, b.KMSKeyID) &&
+		equalObjectRef(a.KMSKeyRef, b.KMSKeyRef) &&
+		equalResourceSelector(a.KMSKeySelector, b.KMSKeySelector)
+}
+
+func equalTopicDependency(a, b otcv1alpha1.TopicDependency) bool {
+	return equalStringPtr(a.TopicURN, b.TopicURN) &&
+		equalObjectRef(a.TopicRef, b.TopicRef) &&
+		equalResourceSelector(a.TopicSelector, b.TopicSelector)
 }
 
 func equalSecurityGroupDependency(a, b otcv1alpha1.SecurityGroupDependency) bool {
 	return equalStringPtr(a.SecurityGroupID, b.SecurityGroupID) &&
-		equalObjectRef(a.SecurityGroupRef, b.SecurityGroupRef) &&
-		equalLabelSelector(a.SecurityGroupSelector, b.SecurityGroupSelector)
+		equalNamespacedObjectRef(a.SecurityGroupRef, b.SecurityGroupRef) &&
+		equalResourceSelector(a.SecurityGroupSelector, b.SecurityGroupSelector)
+}
+
+func equalSecurityGroupDependencies(a, b []otcv1alpha1.SecurityGroupDependency) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !equalSecurityGroupDependency(a[i], b[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func equalInstanceNICs(a, b []otcv1alpha1.InstanceNIC) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !equalSubnetDependency(a[i].Subnet, b[i].Subnet) ||
+			a[i].IPAddress != b[i].IPAddress {
+			return false
+		}
+	}
+	return true
 }
 
 func equalStringPtr(a, b *string) bool {
@@ -294,22 +823,22 @@ func equalObjectRef(a, b *corev1.LocalObjectReference) bool {
 	return a.Name == b.Name
 }
 
-func equalLabelSelector(a, b *metav1.LabelSelector) bool {
-	if a == nil && b == nil {
-		return true
-	}
+func equalNamespacedObjectRef(a, b *otcv1alpha1.NamespacedObjectReference) bool {
 	if a == nil || b == nil {
-		return false
+		return a == b
 	}
-	if len(a.MatchLabels) != len(b.MatchLabels) {
-		return false
-	}
-	for k, v := range a.MatchLabels {
-		if b.MatchLabels[k] != v {
-			return false
-		}
+	return a.Name == b.Name && a.Namespace == b.Namespace
+}
+
+func equalSecretKeySelector(a, b *corev1.SecretKeySelector) bool {
+	if a == nil || b == nil {
+		return a == b
 	}
-	return true
+	return a.Name == b.Name && a.Key == b.Key
+}
+
+func equalResourceSelector(a, b *otcv1alpha1.ResourceSelector) bool {
+	return equality.Semantic.DeepEqual(a, b)
 }
 
 func equalPort(a, b *int32) bool {
@@ -0,0 +1,185 @@
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	otcv1alpha1 "github.com/peertech.de/otc-operator/api/v1alpha1"
+)
+
+// SetupCustomerGatewayWebhookWithManager registers the webhook for CustomerGateway in the manager.
+func SetupCustomerGatewayWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).For(&otcv1alpha1.CustomerGateway{}).
+		WithValidator(&CustomerGatewayCustomValidator{}).
+		Complete()
+}
+
+// TODO(user): change verbs to "verbs=create;update;delete" if you want to enable deletion validation.
+// +kubebuilder:webhook:path=/validate-otc-peertech-de-v1alpha1-customergateway,mutating=false,failurePolicy=fail,sideEffects=None,groups=otc.peertech.de,resources=customergateways,verbs=create;update,versions=v1alpha1,name=vcustomergateway-v1alpha1.kb.io,admissionReviewVersions=v1
+
+// CustomerGatewayCustomValidator struct is responsible for validating the CustomerGateway resource
+// when it is created, updated, or deleted.
+type CustomerGatewayCustomValidator struct{}
+
+var _ webhook.CustomValidator = &CustomerGatewayCustomValidator{}
+
+// ValidateCreate implements webhook.CustomValidator so a webhook will be registered for the type CustomerGateway.
+func (v *CustomerGatewayCustomValidator) ValidateCreate(
+	_ context.Context,
+	obj runtime.Object,
+) (admission.Warnings, error) {
+	customerGateway, ok := obj.(*otcv1alpha1.CustomerGateway)
+	if !ok {
+		return nil, fmt.Errorf("expected a CustomerGateway object but got %T", obj)
+	}
+
+	var warnings admission.Warnings
+	var errors field.ErrorList
+
+	// Validate the resource name
+	if !validName.MatchString(customerGateway.Name) {
+		errors = append(errors, field.Invalid(
+			field.NewPath("metadata", "name"),
+			customerGateway.Name,
+			"name must contain only letters, digits, underscores (_), hyphens (-), and periods (.)",
+		))
+	}
+
+	// Validate ProviderConfigRef
+	if err := validateProviderConfigRefName(customerGateway.Spec.ProviderConfigRef); err != nil {
+		errors = append(errors, err)
+	}
+
+	// Validate IPAddress
+	if err := validateIPv4(customerGateway.Spec.IPAddress); err != nil {
+		errors = append(
+			errors,
+			field.Invalid(
+				field.NewPath("spec", "ipAddress"),
+				customerGateway.Spec.IPAddress,
+				err.Error(),
+			),
+		)
+	}
+
+	// Validate WriteConnectionDetailsToRef
+	if err := validateConnectionDetailsRef(customerGateway.Spec.WriteConnectionDetailsToRef); err != nil {
+		errors = append(errors, err)
+	}
+
+	// Warn about orphanOnDelete if true
+	if customerGateway.Spec.OrphanOnDelete {
+		warnings = append(
+			warnings,
+			"orphanOnDelete is true: external customer gateway will not be deleted when this resource is deleted",
+		)
+	}
+
+	if len(errors) == 0 {
+		return warnings, nil
+	}
+
+	return warnings, apierrors.NewInvalid(
+		customerGateway.GroupVersionKind().GroupKind(),
+		customerGateway.Name,
+		errors,
+	)
+}
+
+// ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the type CustomerGateway.
+func (v *CustomerGatewayCustomValidator) ValidateUpdate(
+	_ context.Context,
+	oldObj, newObj runtime.Object,
+) (admission.Warnings, error) {
+	oldCustomerGateway, ok := oldObj.(*otcv1alpha1.CustomerGateway)
+	if !ok {
+		return nil, fmt.Errorf("expected a CustomerGateway object for the oldObj but got %T", oldObj)
+	}
+	newCustomerGateway, ok := newObj.(*otcv1alpha1.CustomerGateway)
+	if !ok {
+		return nil, fmt.Errorf("expected a CustomerGateway object for the newObj but got %T", newObj)
+	}
+
+	var warnings admission.Warnings
+	var errors field.ErrorList
+
+	// Check immutable ProviderConfigRef
+	if !equalProviderConfigRef(
+		oldCustomerGateway.Spec.ProviderConfigRef,
+		newCustomerGateway.Spec.ProviderConfigRef,
+	) {
+		errors = append(
+			errors,
+			field.Forbidden(
+				field.NewPath("spec", "providerConfigRef"),
+				"is immutable and cannot be changed after creation",
+			),
+		)
+	}
+
+	// Check immutable IPAddress and BGPASN
+	if oldCustomerGateway.Spec.IPAddress != newCustomerGateway.Spec.IPAddress {
+		errors = append(
+			errors,
+			field.Forbidden(
+				field.NewPath("spec", "ipAddress"),
+				"is immutable and cannot be changed after creation",
+			),
+		)
+	}
+	if oldCustomerGateway.Spec.BGPASN != newCustomerGateway.Spec.BGPASN {
+		errors = append(
+			errors,
+			field.Forbidden(
+				field.NewPath("spec", "bgpASN"),
+				"is immutable and cannot be changed after creation",
+			),
+		)
+	}
+
+	// Validate WriteConnectionDetailsToRef
+	if err := validateConnectionDetailsRef(newCustomerGateway.Spec.WriteConnectionDetailsToRef); err != nil {
+		errors = append(errors, err)
+	}
+
+	// Warn if orphanOnDelete is being changed from false to true
+	if !oldCustomerGateway.Spec.OrphanOnDelete && newCustomerGateway.Spec.OrphanOnDelete {
+		warnings = append(
+			warnings,
+			"orphanOnDelete changed to true: external customer gateway will not be deleted when this resource is deleted",
+		)
+	}
+
+	// Warn if orphanOnDelete is being changed from true to false
+	if oldCustomerGateway.Spec.OrphanOnDelete && !newCustomerGateway.Spec.OrphanOnDelete {
+		warnings = append(
+			warnings,
+			"orphanOnDelete changed to false: external customer gateway will be deleted when this resource is deleted",
+		)
+	}
+
+	if len(errors) == 0 {
+		return warnings, nil
+	}
+
+	return warnings, apierrors.NewInvalid(
+		oldCustomerGateway.GroupVersionKind().GroupKind(),
+		oldCustomerGateway.Name,
+		errors,
+	)
+}
+
+// ValidateDelete implements webhook.CustomValidator so a webhook will be registered for the type CustomerGateway.
+func (v *CustomerGatewayCustomValidator) ValidateDelete(
+	ctx context.Context,
+	obj runtime.Object,
+) (admission.Warnings, error) {
+	return nil, nil
+}
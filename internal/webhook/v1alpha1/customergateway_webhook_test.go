@@ -0,0 +1,97 @@
+package v1alpha1
+
+import (
+	"context"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	otcv1alpha1 "github.com/peertech.de/otc-operator/api/v1alpha1"
+)
+
+func newTestCustomerGateway() *otcv1alpha1.CustomerGateway {
+	return &otcv1alpha1.CustomerGateway{
+		ObjectMeta: metav1.ObjectMeta{Name: "customer-gateway"},
+		Spec: otcv1alpha1.CustomerGatewaySpec{
+			ProviderConfigRef: otcv1alpha1.ProviderConfigReference{Name: "provider"},
+			IPAddress:         "203.0.113.1",
+			BGPASN:            65000,
+		},
+	}
+}
+
+func TestCustomerGatewayValidateCreate(t *testing.T) {
+	tests := []struct {
+		name    string
+		update  func(*otcv1alpha1.CustomerGateway)
+		wantErr bool
+	}{
+		{
+			name:   "valid",
+			update: func(*otcv1alpha1.CustomerGateway) {},
+		},
+		{
+			name: "invalid IP address",
+			update: func(customerGateway *otcv1alpha1.CustomerGateway) {
+				customerGateway.Spec.IPAddress = "2001:db8::1"
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			customerGateway := newTestCustomerGateway()
+			tt.update(customerGateway)
+
+			_, err := (&CustomerGatewayCustomValidator{}).ValidateCreate(context.Background(), customerGateway)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Expected error %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestCustomerGatewayValidateUpdate(t *testing.T) {
+	tests := []struct {
+		name    string
+		update  func(*otcv1alpha1.CustomerGateway)
+		wantErr bool
+	}{
+		{
+			name: "orphanOnDelete",
+			update: func(customerGateway *otcv1alpha1.CustomerGateway) {
+				customerGateway.Spec.OrphanOnDelete = true
+			},
+		},
+		{
+			name: "IP address",
+			update: func(customerGateway *otcv1alpha1.CustomerGateway) {
+				customerGateway.Spec.IPAddress = "203.0.113.2"
+			},
+			wantErr: true,
+		},
+		{
+			name: "BGP ASN",
+			update: func(customerGateway *otcv1alpha1.CustomerGateway) {
+				customerGateway.Spec.BGPASN = 65001
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			oldCustomerGateway := newTestCustomerGateway()
+			newCustomerGateway := newTestCustomerGateway()
+			tt.update(newCustomerGateway)
+
+			_, err := (&CustomerGatewayCustomValidator{}).ValidateUpdate(
+				context.Background(),
+				oldCustomerGateway,
+				newCustomerGateway,
+			)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Expected error %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
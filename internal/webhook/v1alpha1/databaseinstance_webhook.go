@@ -0,0 +1,406 @@
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	otcv1alpha1 "github.com/peertech.de/otc-operator/api/v1alpha1"
+)
+
+// SetupDatabaseInstanceWebhookWithManager registers the webhook for DatabaseInstance in the manager.
+func SetupDatabaseInstanceWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).For(&otcv1alpha1.DatabaseInstance{}).
+		WithValidator(&DatabaseInstanceCustomValidator{}).
+		Complete()
+}
+
+// TODO(user): change verbs to "verbs=create;update;delete" if you want to enable deletion validation.
+// +kubebuilder:webhook:path=/validate-otc-peertech-de-v1alpha1-databaseinstance,mutating=false,failurePolicy=fail,sideEffects=None,groups=otc.peertech.de,resources=databaseinstances,verbs=create;update,versions=v1alpha1,name=vdatabaseinstance-v1alpha1.kb.io,admissionReviewVersions=v1
+
+// DatabaseInstanceCustomValidator struct is responsible for validating the DatabaseInstance resource
+// when it is created, updated, or deleted.
+type DatabaseInstanceCustomValidator struct{}
+
+var _ webhook.CustomValidator = &DatabaseInstanceCustomValidator{}
+
+// ValidateCreate implements webhook.CustomValidator so a webhook will be registered for the type DatabaseInstance.
+func (v *DatabaseInstanceCustomValidator) ValidateCreate(
+	_ context.Context,
+	obj runtime.Object,
+) (admission.Warnings, error) {
+	databaseInstance, ok := obj.(*otcv1alpha1.DatabaseInstance)
+	if !ok {
+		return nil, fmt.Errorf("expected a DatabaseInstance object but got %T", obj)
+	}
+
+	var warnings admission.Warnings
+	var errors field.ErrorList
+
+	// Validate the resource name
+	if !validName.MatchString(databaseInstance.Name) {
+		errors = append(errors, field.Invalid(
+			field.NewPath("metadata", "name"),
+			databaseInstance.Name,
+			"name must contain only letters, digits, underscores (_), hyphens (-), and periods (.)",
+		))
+	}
+
+	// Validate ProviderConfigRef
+	if err := validateProviderConfigRefName(databaseInstance.Spec.ProviderConfigRef); err != nil {
+		errors = append(errors, err)
+	}
+
+	// Validate that exactly one network dependency method is specified
+	if err := validateNetworkDependency(databaseInstance.Spec.Network); err != nil {
+		errors = append(
+			errors,
+			field.Invalid(
+				field.NewPath("spec", "network"),
+				databaseInstance.Spec.Network,
+				err.Error(),
+			),
+		)
+	}
+
+	// Validate that exactly one subnet dependency method is specified
+	if err := validateSubnetDependency(databaseInstance.Spec.Subnet); err != nil {
+		errors = append(
+			errors,
+			field.Invalid(
+				field.NewPath("spec", "subnet"),
+				databaseInstance.Spec.Subnet,
+				err.Error(),
+			),
+		)
+	}
+
+	// Validate that exactly one security group dependency method is specified
+	if err := validateSecurityGroupDependency(databaseInstance.Spec.SecurityGroup); err != nil {
+		errors = append(
+			errors,
+			field.Invalid(
+				field.NewPath("spec", "securityGroup"),
+				databaseInstance.Spec.SecurityGroup,
+				err.Error(),
+			),
+		)
+	}
+
+	// Validate the deployment, the password and the connection details
+	errors = append(errors, validateDatabaseInstanceSpec(databaseInstance.Spec)...)
+
+	// Warn about orphanOnDelete if true
+	if databaseInstance.Spec.OrphanOnDelete {
+		warnings = append(
+			warnings,
+			"orphanOnDelete is true: external database instance will not be deleted when this resource is deleted",
+		)
+	}
+
+	if len(errors) == 0 {
+		return warnings, nil
+	}
+
+	return warnings, apierrors.NewInvalid(
+		databaseInstance.GroupVersionKind().GroupKind(),
+		databaseInstance.Name,
+		errors,
+	)
+}
+
+// ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the type DatabaseInstance.
+func (v *DatabaseInstanceCustomValidator) ValidateUpdate(
+	_ context.Context,
+	oldObj, newObj runtime.Object,
+) (admission.Warnings, error) {
+	oldDatabaseInstance, ok := oldObj.(*otcv1alpha1.DatabaseInstance)
+	if !ok {
+		return nil, fmt.Errorf("expected a DatabaseInstance object for the oldObj but got %T", oldObj)
+	}
+	newDatabaseInstance, ok := newObj.(*otcv1alpha1.DatabaseInstance)
+	if !ok {
+		return nil, fmt.Errorf("expected a DatabaseInstance object for the newObj but got %T", newObj)
+	}
+
+	var warnings admission.Warnings
+	var errors field.ErrorList
+
+	oldSpec, newSpec := oldDatabaseInstance.Spec, newDatabaseInstance.Spec
+
+	// Check immutable ProviderConfigRef
+	if !equalProviderConfigRef(oldSpec.ProviderConfigRef, newSpec.ProviderConfigRef) {
+		errors = append(
+			errors,
+			field.Forbidden(
+				field.NewPath("spec", "providerConfigRef"),
+				"is immutable and cannot be changed after creation",
+			),
+		)
+	}
+
+	// Check immutable Network dependency
+	if !equalNetworkDependency(oldSpec.Network, newSpec.Network) {
+		errors = append(
+			errors,
+			field.Forbidden(
+				field.NewPath("spec", "network"),
+				"is immutable and cannot be changed after creation",
+			),
+		)
+	}
+
+	// Check immutable Subnet dependency
+	if !equalSubnetDependency(oldSpec.Subnet, newSpec.Subnet) {
+		errors = append(
+			errors,
+			field.Forbidden(
+				field.NewPath("spec", "subnet"),
+				"is immutable and cannot be changed after creation",
+			),
+		)
+	}
+
+	// Check immutable SecurityGroup dependency
+	if !equalSecurityGroupDependency(oldSpec.SecurityGroup, newSpec.SecurityGroup) {
+		errors = append(
+			errors,
+			field.Forbidden(
+				field.NewPath("spec", "securityGroup"),
+				"is immutable and cannot be changed after creation",
+			),
+		)
+	}
+
+	// Check immutable engine and deployment settings
+	if oldSpec.Engine != newSpec.Engine {
+		errors = append(
+			errors,
+			field.Forbidden(
+				field.NewPath("spec", "engine"),
+				"is immutable and cannot be changed after creation",
+			),
+		)
+	}
+	if oldSpec.Version != newSpec.Version {
+		errors = append(
+			errors,
+			field.Forbidden(
+				field.NewPath("spec", "version"),
+				"is immutable and cannot be changed after creation",
+			),
+		)
+	}
+	if oldSpec.HAMode != newSpec.HAMode {
+		errors = append(
+			errors,
+			field.Forbidden(
+				field.NewPath("spec", "haMode"),
+				"is immutable and cannot be changed after creation",
+			),
+		)
+	}
+	if oldSpec.ReplicationMode != newSpec.ReplicationMode {
+		errors = append(
+			errors,
+			field.Forbidden(
+				field.NewPath("spec", "replicationMode"),
+				"is immutable and cannot be changed after creation",
+			),
+		)
+	}
+	if !slices.Equal(oldSpec.AvailabilityZones, newSpec.AvailabilityZones) {
+		errors = append(
+			errors,
+			field.Forbidden(
+				field.NewPath("spec", "availabilityZones"),
+				"is immutable and cannot be changed after creation",
+			),
+		)
+	}
+	if !equalPort(oldSpec.Port, newSpec.Port) {
+		errors = append(
+			errors,
+			field.Forbidden(
+				field.NewPath("spec", "port"),
+				"is immutable and cannot be changed after creation",
+			),
+		)
+	}
+	if oldSpec.AdminPasswordSecretRef != newSpec.AdminPasswordSecretRef {
+		errors = append(
+			errors,
+			field.Forbidden(
+				field.NewPath("spec", "adminPasswordSecretRef"),
+				"is immutable and cannot be changed after creation",
+			),
+		)
+	}
+
+	// Check the volume, which can only grow
+	if oldSpec.Volume.Type != newSpec.Volume.Type {
+		errors = append(
+			errors,
+			field.Forbidden(
+				field.NewPath("spec", "volume", "type"),
+				"is immutable and cannot be changed after creation",
+			),
+		)
+	}
+	if !equalKMSKeyDependency(oldSpec.Volume.EncryptionKey, newSpec.Volume.EncryptionKey) {
+		errors = append(
+			errors,
+			field.Forbidden(
+				field.NewPath("spec", "volume", "encryptionKey"),
+				"is immutable and cannot be changed after creation",
+			),
+		)
+	}
+	if newSpec.Volume.Size < oldSpec.Volume.Size {
+		errors = append(
+			errors,
+			field.Invalid(
+				field.NewPath("spec", "volume", "size"),
+				newSpec.Volume.Size,
+				fmt.Sprintf("cannot be decreased from %d", oldSpec.Volume.Size),
+			),
+		)
+	}
+
+	// Validate the deployment, the password and the connection details
+	errors = append(errors, validateDatabaseInstanceSpec(newSpec)...)
+
+	// Warn if orphanOnDelete is being changed from false to true
+	if !oldSpec.OrphanOnDelete && newSpec.OrphanOnDelete {
+		warnings = append(
+			warnings,
+			"orphanOnDelete changed to true: external database instance will not be deleted when this resource is deleted",
+		)
+	}
+
+	// Warn if orphanOnDelete is being changed from true to false
+	if oldSpec.OrphanOnDelete && !newSpec.OrphanOnDelete {
+		warnings = append(
+			warnings,
+			"orphanOnDelete changed to false: external database instance will be deleted when this resource is deleted",
+		)
+	}
+
+	if len(errors) == 0 {
+		return warnings, nil
+	}
+
+	return warnings, apierrors.NewInvalid(
+		oldDatabaseInstance.GroupVersionKind().GroupKind(),
+		oldDatabaseInstance.Name,
+		errors,
+	)
+}
+
+// ValidateDelete implements webhook.CustomValidator so a webhook will be registered for the type DatabaseInstance.
+func (v *DatabaseInstanceCustomValidator) ValidateDelete(
+	ctx context.Context,
+	obj runtime.Object,
+) (admission.Warnings, error) {
+	return nil, nil
+}
+
+func validateDatabaseInstanceSpec(spec otcv1alpha1.DatabaseInstanceSpec) field.ErrorList {
+	var errors field.ErrorList
+
+	// HA instances run two nodes, which may share an availability zone.
+	path := field.NewPath("spec", "availabilityZones")
+	zones := 1
+	if spec.HAMode == otcv1alpha1.DatabaseHAModeHA {
+		zones = 2
+	}
+	if len(spec.AvailabilityZones) != zones {
+		errors = append(
+			errors,
+			field.Invalid(
+				path,
+				spec.AvailabilityZones,
+				fmt.Sprintf("exactly %d availability zones are required in %s mode", zones, spec.HAMode),
+			),
+		)
+	}
+	for i, zone := range spec.AvailabilityZones {
+		if zone == "" {
+			errors = append(errors, field.Required(path.Index(i), "availability zone cannot be empty"))
+		}
+	}
+
+	// The replication modes depend on the engine.
+	if mode := spec.ReplicationMode; mode != "" {
+		path := field.NewPath("spec", "replicationMode")
+		switch {
+		case spec.HAMode != otcv1alpha1.DatabaseHAModeHA:
+			errors = append(errors, field.Invalid(path, mode, "can only be set for HA instances"))
+		case spec.Engine == otcv1alpha1.DatabaseEngineMySQL &&
+			mode == otcv1alpha1.DatabaseReplicationSync:
+			errors = append(errors, field.NotSupported(path, mode, []string{
+				string(otcv1alpha1.DatabaseReplicationAsync),
+				string(otcv1alpha1.DatabaseReplicationSemiSync),
+			}))
+		case spec.Engine == otcv1alpha1.DatabaseEnginePostgreSQL &&
+			mode == otcv1alpha1.DatabaseReplicationSemiSync:
+			errors = append(errors, field.NotSupported(path, mode, []string{
+				string(otcv1alpha1.DatabaseReplicationAsync),
+				string(otcv1alpha1.DatabaseReplicationSync),
+			}))
+		}
+	}
+
+	if key := spec.Volume.EncryptionKey; key != nil {
+		if err := validateKMSKeyDependency(*key); err != nil {
+			errors = append(
+				errors,
+				field.Invalid(
+					field.NewPath("spec", "volume", "encryptionKey"),
+					key,
+					err.Error(),
+				),
+			)
+		}
+	}
+
+	ref := spec.AdminPasswordSecretRef
+	if err := validateSecretKeySelector(ref); err != nil {
+		errors = append(
+			errors,
+			field.Invalid(
+				field.NewPath("spec", "adminPasswordSecretRef"),
+				ref,
+				err.Error(),
+			),
+		)
+	}
+
+	if err := validateConnectionDetailsRef(spec.WriteConnectionDetailsToRef); err != nil {
+		errors = append(errors, err)
+	}
+
+	// The connection details replace the contents of their Secret, which
+	// would drop the admin password.
+	detailsRef := spec.WriteConnectionDetailsToRef
+	if detailsRef != nil && detailsRef.Kind != otcv1alpha1.ConnectionDetailsConfigMap &&
+		detailsRef.Name == ref.Name {
+		errors = append(
+			errors,
+			field.Invalid(
+				field.NewPath("spec", "writeConnectionDetailsToRef", "name"),
+				detailsRef.Name,
+				"must be different from the Secret in adminPasswordSecretRef",
+			),
+		)
+	}
+
+	return errors
+}
@@ -0,0 +1,150 @@
+package v1alpha1
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	otcv1alpha1 "github.com/peertech.de/otc-operator/api/v1alpha1"
+)
+
+func newTestDatabaseInstance() *otcv1alpha1.DatabaseInstance {
+	networkID, subnetID, securityGroupID := "network-id", "subnet-id", "security-group-id"
+	return &otcv1alpha1.DatabaseInstance{
+		ObjectMeta: metav1.ObjectMeta{Name: "database"},
+		Spec: otcv1alpha1.DatabaseInstanceSpec{
+			ProviderConfigRef: otcv1alpha1.ProviderConfigReference{Name: "provider"},
+			Engine:            otcv1alpha1.DatabaseEnginePostgreSQL,
+			Version:           "16",
+			Flavor:            "rds.pg.n1.large.2",
+			Volume:            otcv1alpha1.DatabaseVolume{Size: 40},
+			AvailabilityZones: []string{"eu-de-01"},
+			AdminPasswordSecretRef: corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "database-admin"},
+				Key:                  "password",
+			},
+			Network:       otcv1alpha1.NetworkDependency{NetworkID: &networkID},
+			Subnet:        otcv1alpha1.SubnetDependency{SubnetID: &subnetID},
+			SecurityGroup: otcv1alpha1.SecurityGroupDependency{SecurityGroupID: &securityGroupID},
+		},
+	}
+}
+
+func TestDatabaseInstanceValidateCreate(t *testing.T) {
+	tests := []struct {
+		name    string
+		update  func(*otcv1alpha1.DatabaseInstance)
+		wantErr bool
+	}{
+		{
+			name:   "single",
+			update: func(*otcv1alpha1.DatabaseInstance) {},
+		},
+		{
+			name: "HA with synchronous replication",
+			update: func(databaseInstance *otcv1alpha1.DatabaseInstance) {
+				databaseInstance.Spec.HAMode = otcv1alpha1.DatabaseHAModeHA
+				databaseInstance.Spec.ReplicationMode = otcv1alpha1.DatabaseReplicationSync
+				databaseInstance.Spec.AvailabilityZones = []string{"eu-de-01", "eu-de-02"}
+			},
+		},
+		{
+			name: "HA with a single availability zone",
+			update: func(databaseInstance *otcv1alpha1.DatabaseInstance) {
+				databaseInstance.Spec.HAMode = otcv1alpha1.DatabaseHAModeHA
+			},
+			wantErr: true,
+		},
+		{
+			name: "replication mode for single instance",
+			update: func(databaseInstance *otcv1alpha1.DatabaseInstance) {
+				databaseInstance.Spec.ReplicationMode = otcv1alpha1.DatabaseReplicationAsync
+			},
+			wantErr: true,
+		},
+		{
+			name: "semi-synchronous replication for PostgreSQL",
+			update: func(databaseInstance *otcv1alpha1.DatabaseInstance) {
+				databaseInstance.Spec.HAMode = otcv1alpha1.DatabaseHAModeHA
+				databaseInstance.Spec.ReplicationMode = otcv1alpha1.DatabaseReplicationSemiSync
+				databaseInstance.Spec.AvailabilityZones = []string{"eu-de-01", "eu-de-02"}
+			},
+			wantErr: true,
+		},
+		{
+			name: "connection details in admin password Secret",
+			update: func(databaseInstance *otcv1alpha1.DatabaseInstance) {
+				databaseInstance.Spec.WriteConnectionDetailsToRef = &otcv1alpha1.ConnectionDetailsReference{
+					Name: databaseInstance.Spec.AdminPasswordSecretRef.Name,
+				}
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			databaseInstance := newTestDatabaseInstance()
+			tt.update(databaseInstance)
+
+			_, err := (&DatabaseInstanceCustomValidator{}).ValidateCreate(context.Background(), databaseInstance)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Expected error %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestDatabaseInstanceValidateUpdate(t *testing.T) {
+	tests := []struct {
+		name    string
+		update  func(*otcv1alpha1.DatabaseInstance)
+		wantErr bool
+	}{
+		{
+			name: "flavor and larger volume",
+			update: func(databaseInstance *otcv1alpha1.DatabaseInstance) {
+				databaseInstance.Spec.Flavor = "rds.pg.n1.xlarge.2"
+				databaseInstance.Spec.Volume.Size = 80
+			},
+		},
+		{
+			name: "smaller volume",
+			update: func(databaseInstance *otcv1alpha1.DatabaseInstance) {
+				databaseInstance.Spec.Volume.Size = 20
+			},
+			wantErr: true,
+		},
+		{
+			name: "version",
+			update: func(databaseInstance *otcv1alpha1.DatabaseInstance) {
+				databaseInstance.Spec.Version = "17"
+			},
+			wantErr: true,
+		},
+		{
+			name: "admin password Secret",
+			update: func(databaseInstance *otcv1alpha1.DatabaseInstance) {
+				databaseInstance.Spec.AdminPasswordSecretRef.Name = "other"
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			oldDatabaseInstance := newTestDatabaseInstance()
+			newDatabaseInstance := newTestDatabaseInstance()
+			tt.update(newDatabaseInstance)
+
+			_, err := (&DatabaseInstanceCustomValidator{}).ValidateUpdate(
+				context.Background(),
+				oldDatabaseInstance,
+				newDatabaseInstance,
+			)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Expected error %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,224 @@
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	otcv1alpha1 "github.com/peertech.de/otc-operator/api/v1alpha1"
+)
+
+// SetupDNSZoneWebhookWithManager registers the webhook for DNSZone in the manager.
+func SetupDNSZoneWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).For(&otcv1alpha1.DNSZone{}).
+		WithValidator(&DNSZoneCustomValidator{}).
+		Complete()
+}
+
+// TODO(user): change verbs to "verbs=create;update;delete" if you want to enable deletion validation.
+// +kubebuilder:webhook:path=/validate-otc-peertech-de-v1alpha1-dnszone,mutating=false,failurePolicy=fail,sideEffects=None,groups=otc.peertech.de,resources=dnszones,verbs=create;update,versions=v1alpha1,name=vdnszone-v1alpha1.kb.io,admissionReviewVersions=v1
+
+// DNSZoneCustomValidator struct is responsible for validating the DNSZone resource
+// when it is created, updated, or deleted.
+type DNSZoneCustomValidator struct{}
+
+var _ webhook.CustomValidator = &DNSZoneCustomValidator{}
+
+// ValidateCreate implements webhook.CustomValidator so a webhook will be registered for the type DNSZone.
+func (v *DNSZoneCustomValidator) ValidateCreate(
+	_ context.Context,
+	obj runtime.Object,
+) (admission.Warnings, error) {
+	zone, ok := obj.(*otcv1alpha1.DNSZone)
+	if !ok {
+		return nil, fmt.Errorf("expected a DNSZone object but got %T", obj)
+	}
+
+	var warnings admission.Warnings
+	var errors field.ErrorList
+
+	// Validate the resource name
+	if !validName.MatchString(zone.Name) {
+		errors = append(errors, field.Invalid(
+			field.NewPath("metadata", "name"),
+			zone.Name,
+			"name must contain only letters, digits, underscores (_), hyphens (-), and periods (.)",
+		))
+	}
+
+	// Validate ProviderConfigRef
+	if err := validateProviderConfigRefName(zone.Spec.ProviderConfigRef); err != nil {
+		errors = append(errors, err)
+	}
+
+	// Validate the domain name
+	if err := validateDomainName(zone.Spec.DomainName); err != nil {
+		errors = append(
+			errors,
+			field.Invalid(
+				field.NewPath("spec", "domainName"),
+				zone.Spec.DomainName,
+				err.Error(),
+			),
+		)
+	}
+
+	// Validate the networks
+	errors = append(errors, validateDNSZoneNetworks(zone.Spec)...)
+
+	// Validate WriteConnectionDetailsToRef
+	if err := validateConnectionDetailsRef(zone.Spec.WriteConnectionDetailsToRef); err != nil {
+		errors = append(errors, err)
+	}
+
+	// Warn about orphanOnDelete if true
+	if zone.Spec.OrphanOnDelete {
+		warnings = append(
+			warnings,
+			"orphanOnDelete is true: external DNS zone will not be deleted when this resource is deleted",
+		)
+	}
+
+	if len(errors) == 0 {
+		return warnings, nil
+	}
+
+	return warnings, apierrors.NewInvalid(
+		zone.GroupVersionKind().GroupKind(),
+		zone.Name,
+		errors,
+	)
+}
+
+// ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the type DNSZone.
+func (v *DNSZoneCustomValidator) ValidateUpdate(
+	_ context.Context,
+	oldObj, newObj runtime.Object,
+) (admission.Warnings, error) {
+	oldZone, ok := oldObj.(*otcv1alpha1.DNSZone)
+	if !ok {
+		return nil, fmt.Errorf("expected a DNSZone object for the oldObj but got %T", oldObj)
+	}
+	newZone, ok := newObj.(*otcv1alpha1.DNSZone)
+	if !ok {
+		return nil, fmt.Errorf("expected a DNSZone object for the newObj but got %T", newObj)
+	}
+
+	var warnings admission.Warnings
+	var errors field.ErrorList
+
+	// Check immutable ProviderConfigRef
+	if !equalProviderConfigRef(
+		oldZone.Spec.ProviderConfigRef,
+		newZone.Spec.ProviderConfigRef,
+	) {
+		errors = append(
+			errors,
+			field.Forbidden(
+				field.NewPath("spec", "providerConfigRef"),
+				"is immutable and cannot be changed after creation",
+			),
+		)
+	}
+
+	// Check immutable domain name and type
+	if oldZone.Spec.DomainName != newZone.Spec.DomainName {
+		errors = append(
+			errors,
+			field.Forbidden(
+				field.NewPath("spec", "domainName"),
+				"is immutable and cannot be changed after creation",
+			),
+		)
+	}
+	if oldZone.Spec.Type != newZone.Spec.Type {
+		errors = append(
+			errors,
+			field.Forbidden(
+				field.NewPath("spec", "type"),
+				"is immutable and cannot be changed after creation",
+			),
+		)
+	}
+
+	// Validate the networks
+	errors = append(errors, validateDNSZoneNetworks(newZone.Spec)...)
+
+	// Validate WriteConnectionDetailsToRef
+	if err := validateConnectionDetailsRef(newZone.Spec.WriteConnectionDetailsToRef); err != nil {
+		errors = append(errors, err)
+	}
+
+	// Warn if orphanOnDelete is being changed from false to true
+	if !oldZone.Spec.OrphanOnDelete && newZone.Spec.OrphanOnDelete {
+		warnings = append(
+			warnings,
+			"orphanOnDelete changed to true: external DNS zone will not be deleted when this resource is deleted",
+		)
+	}
+
+	// Warn if orphanOnDelete is being changed from true to false
+	if oldZone.Spec.OrphanOnDelete && !newZone.Spec.OrphanOnDelete {
+		warnings = append(
+			warnings,
+			"orphanOnDelete changed to false: external DNS zone will be deleted when this resource is deleted",
+		)
+	}
+
+	if len(errors) == 0 {
+		return warnings, nil
+	}
+
+	return warnings, apierrors.NewInvalid(
+		oldZone.GroupVersionKind().GroupKind(),
+		oldZone.Name,
+		errors,
+	)
+}
+
+// ValidateDelete implements webhook.CustomValidator so a webhook will be registered for the type DNSZone.
+func (v *DNSZoneCustomValidator) ValidateDelete(
+	ctx context.Context,
+	obj runtime.Object,
+) (admission.Warnings, error) {
+	return nil, nil
+}
+
+func validateDNSZoneNetworks(spec otcv1alpha1.DNSZoneSpec) field.ErrorList {
+	var errors field.ErrorList
+
+	path := field.NewPath("spec", "networks")
+	switch {
+	case spec.Type == otcv1alpha1.DNSZonePrivate && len(spec.Networks) == 0:
+		errors = append(
+			errors,
+			field.Required(path, "private zones require at least one network"),
+		)
+	case spec.Type != otcv1alpha1.DNSZonePrivate && len(spec.Networks) > 0:
+		errors = append(
+			errors,
+			field.Forbidden(path, "networks can only be set for private zones"),
+		)
+	}
+
+	for i, network := range spec.Networks {
+		if err := validateNetworkDependency(network); err != nil {
+			errors = append(
+				errors,
+				field.Invalid(
+					path.Index(i),
+					network,
+					err.Error(),
+				),
+			)
+		}
+	}
+
+	return errors
+}
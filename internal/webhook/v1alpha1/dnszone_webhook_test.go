@@ -0,0 +1,119 @@
+package v1alpha1
+
+import (
+	"context"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	otcv1alpha1 "github.com/peertech.de/otc-operator/api/v1alpha1"
+)
+
+func newTestDNSZone() *otcv1alpha1.DNSZone {
+	networkID := "network-id"
+	return &otcv1alpha1.DNSZone{
+		ObjectMeta: metav1.ObjectMeta{Name: "zone"},
+		Spec: otcv1alpha1.DNSZoneSpec{
+			ProviderConfigRef: otcv1alpha1.ProviderConfigReference{Name: "provider"},
+			DomainName:        "example.internal.",
+			Type:              otcv1alpha1.DNSZonePrivate,
+			Networks:          []otcv1alpha1.NetworkDependency{{NetworkID: &networkID}},
+		},
+	}
+}
+
+func TestDNSZoneValidateCreate(t *testing.T) {
+	tests := []struct {
+		name    string
+		update  func(*otcv1alpha1.DNSZone)
+		wantErr bool
+	}{
+		{
+			name:   "private zone",
+			update: func(*otcv1alpha1.DNSZone) {},
+		},
+		{
+			name: "public zone",
+			update: func(zone *otcv1alpha1.DNSZone) {
+				zone.Spec.Type = otcv1alpha1.DNSZonePublic
+				zone.Spec.Networks = nil
+			},
+		},
+		{
+			name: "invalid domain name",
+			update: func(zone *otcv1alpha1.DNSZone) {
+				zone.Spec.DomainName = "example_internal."
+			},
+			wantErr: true,
+		},
+		{
+			name: "private zone without networks",
+			update: func(zone *otcv1alpha1.DNSZone) {
+				zone.Spec.Networks = nil
+			},
+			wantErr: true,
+		},
+		{
+			name: "public zone with networks",
+			update: func(zone *otcv1alpha1.DNSZone) {
+				zone.Spec.Type = otcv1alpha1.DNSZonePublic
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			zone := newTestDNSZone()
+			tt.update(zone)
+
+			_, err := (&DNSZoneCustomValidator{}).ValidateCreate(context.Background(), zone)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Expected error %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestDNSZoneValidateUpdate(t *testing.T) {
+	tests := []struct {
+		name    string
+		update  func(*otcv1alpha1.DNSZone)
+		wantErr bool
+	}{
+		{
+			name: "TTL and networks",
+			update: func(zone *otcv1alpha1.DNSZone) {
+				networkID := "other-network-id"
+				zone.Spec.TTL = 600
+				zone.Spec.Networks = append(zone.Spec.Networks, otcv1alpha1.NetworkDependency{NetworkID: &networkID})
+			},
+		},
+		{
+			name: "domain name",
+			update: func(zone *otcv1alpha1.DNSZone) {
+				zone.Spec.DomainName = "example.org."
+			},
+			wantErr: true,
+		},
+		{
+			name: "type",
+			update: func(zone *otcv1alpha1.DNSZone) {
+				zone.Spec.Type = otcv1alpha1.DNSZonePublic
+				zone.Spec.Networks = nil
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			oldZone := newTestDNSZone()
+			newZone := newTestDNSZone()
+			tt.update(newZone)
+
+			_, err := (&DNSZoneCustomValidator{}).ValidateUpdate(context.Background(), oldZone, newZone)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Expected error %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
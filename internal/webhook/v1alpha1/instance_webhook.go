@@ -0,0 +1,316 @@
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	otcv1alpha1 "github.com/peertech.de/otc-operator/api/v1alpha1"
+)
+
+// SetupInstanceWebhookWithManager registers the webhook for Instance in the manager.
+func SetupInstanceWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).For(&otcv1alpha1.Instance{}).
+		WithValidator(&InstanceCustomValidator{}).
+		Complete()
+}
+
+// TODO(user): change verbs to "verbs=create;update;delete" if you want to enable deletion validation.
+// +kubebuilder:webhook:path=/validate-otc-peertech-de-v1alpha1-instance,mutating=false,failurePolicy=fail,sideEffects=None,groups=otc.peertech.de,resources=instances,verbs=create;update,versions=v1alpha1,name=vinstance-v1alpha1.kb.io,admissionReviewVersions=v1
+
+// InstanceCustomValidator struct is responsible for validating the Instance resource
+// when it is created, updated, or deleted.
+type InstanceCustomValidator struct{}
+
+var _ webhook.CustomValidator = &InstanceCustomValidator{}
+
+// ValidateCreate implements webhook.CustomValidator so a webhook will be registered for the type Instance.
+func (v *InstanceCustomValidator) ValidateCreate(
+	_ context.Context,
+	obj runtime.Object,
+) (admission.Warnings, error) {
+	instance, ok := obj.(*otcv1alpha1.Instance)
+	if !ok {
+		return nil, fmt.Errorf("expected an Instance object but got %T", obj)
+	}
+
+	var warnings admission.Warnings
+	var errors field.ErrorList
+
+	// Validate the resource name
+	if !validName.MatchString(instance.Name) {
+		errors = append(errors, field.Invalid(
+			field.NewPath("metadata", "name"),
+			instance.Name,
+			"name must contain only letters, digits, underscores (_), hyphens (-), and periods (.)",
+		))
+	}
+
+	// Validate ProviderConfigRef
+	if err := validateProviderConfigRefName(instance.Spec.ProviderConfigRef); err != nil {
+		errors = append(errors, err)
+	}
+
+	// Validate the network interfaces
+	if len(instance.Spec.NICs) == 0 {
+		errors = append(
+			errors,
+			field.Required(
+				field.NewPath("spec", "nics"),
+				"at least one network interface is required",
+			),
+		)
+	}
+	for i, nic := range instance.Spec.NICs {
+		path := field.NewPath("spec", "nics").Index(i)
+
+		if err := validateSubnetDependency(nic.Subnet); err != nil {
+			errors = append(
+				errors,
+				field.Invalid(
+					path.Child("subnet"),
+					nic.Subnet,
+					err.Error(),
+				),
+			)
+		}
+
+		if nic.IPAddress != "" {
+			if err := validateIPv4(nic.IPAddress); err != nil {
+				errors = append(
+					errors,
+					field.Invalid(
+						path.Child("ipAddress"),
+						nic.IPAddress,
+						err.Error(),
+					),
+				)
+			}
+		}
+	}
+
+	// Validate that exactly one dependency method is specified per security group
+	for i, securityGroup := range instance.Spec.SecurityGroups {
+		if err := validateSecurityGroupDependency(securityGroup); err != nil {
+			errors = append(
+				errors,
+				field.Invalid(
+					field.NewPath("spec", "securityGroups").Index(i),
+					securityGroup,
+					err.Error(),
+				),
+			)
+		}
+	}
+
+	// Validate the public IP dependency
+	if publicIP := instance.Spec.PublicIP; publicIP != nil {
+		if err := validatePublicIPDependency(*publicIP); err != nil {
+			errors = append(
+				errors,
+				field.Invalid(
+					field.NewPath("spec", "publicIP"),
+					publicIP,
+					err.Error(),
+				),
+			)
+		}
+	}
+
+	// Validate the key pair dependency
+	if keyPair := instance.Spec.KeyPair; keyPair != nil {
+		if err := validateKeyPairDependency(*keyPair); err != nil {
+			errors = append(
+				errors,
+				field.Invalid(
+					field.NewPath("spec", "keyPair"),
+					keyPair,
+					err.Error(),
+				),
+			)
+		}
+	}
+
+	// Validate the user data reference
+	if ref := instance.Spec.UserDataSecretRef; ref != nil {
+		if err := validateSecretKeySelector(*ref); err != nil {
+			errors = append(
+				errors,
+				field.Invalid(
+					field.NewPath("spec", "userDataSecretRef"),
+					ref,
+					err.Error(),
+				),
+			)
+		}
+	}
+
+	// Validate WriteConnectionDetailsToRef
+	if err := validateConnectionDetailsRef(instance.Spec.WriteConnectionDetailsToRef); err != nil {
+		errors = append(errors, err)
+	}
+
+	// Warn about orphanOnDelete if true
+	if instance.Spec.OrphanOnDelete {
+		warnings = append(
+			warnings,
+			"orphanOnDelete is true: external instance will not be deleted when this resource is deleted",
+		)
+	}
+
+	if len(errors) == 0 {
+		return warnings, nil
+	}
+
+	return warnings, apierrors.NewInvalid(
+		instance.GroupVersionKind().GroupKind(),
+		instance.Name,
+		errors,
+	)
+}
+
+// ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the type Instance.
+func (v *InstanceCustomValidator) ValidateUpdate(
+	_ context.Context,
+	oldObj, newObj runtime.Object,
+) (admission.Warnings, error) {
+	oldInstance, ok := oldObj.(*otcv1alpha1.Instance)
+	if !ok {
+		return nil, fmt.Errorf("expected an Instance object for the oldObj but got %T", oldObj)
+	}
+	newInstance, ok := newObj.(*otcv1alpha1.Instance)
+	if !ok {
+		return nil, fmt.Errorf("expected an Instance object for the newObj but got %T", newObj)
+	}
+
+	var warnings admission.Warnings
+	var errors field.ErrorList
+
+	// Check immutable ProviderConfigRef
+	if !equalProviderConfigRef(
+		oldInstance.Spec.ProviderConfigRef,
+		newInstance.Spec.ProviderConfigRef,
+	) {
+		errors = append(
+			errors,
+			field.Forbidden(
+				field.NewPath("spec", "providerConfigRef"),
+				"is immutable and cannot be changed after creation",
+			),
+		)
+	}
+
+	// Check immutable flavor and image
+	if oldInstance.Spec.Flavor != newInstance.Spec.Flavor {
+		errors = append(
+			errors,
+			field.Forbidden(
+				field.NewPath("spec", "flavor"),
+				"is immutable and cannot be changed after creation",
+			),
+		)
+	}
+	if oldInstance.Spec.ImageID != newInstance.Spec.ImageID {
+		errors = append(
+			errors,
+			field.Forbidden(
+				field.NewPath("spec", "imageID"),
+				"is immutable and cannot be changed after creation",
+			),
+		)
+	}
+
+	// Check immutable network interfaces
+	if !equalInstanceNICs(oldInstance.Spec.NICs, newInstance.Spec.NICs) {
+		errors = append(
+			errors,
+			field.Forbidden(
+				field.NewPath("spec", "nics"),
+				"is immutable and cannot be changed after creation",
+			),
+		)
+	}
+
+	// Check immutable security groups
+	if !equalSecurityGroupDependencies(
+		oldInstance.Spec.SecurityGroups,
+		newInstance.Spec.SecurityGroups,
+	) {
+		errors = append(
+			errors,
+			field.Forbidden(
+				field.NewPath("spec", "securityGroups"),
+				"is immutable and cannot be changed after creation",
+			),
+		)
+	}
+
+	// Check immutable public IP dependency
+	oldPublicIP, newPublicIP := oldInstance.Spec.PublicIP, newInstance.Spec.PublicIP
+	if (oldPublicIP == nil) != (newPublicIP == nil) ||
+		(oldPublicIP != nil && !equalPublicIPDependency(*oldPublicIP, *newPublicIP)) {
+		errors = append(
+			errors,
+			field.Forbidden(
+				field.NewPath("spec", "publicIP"),
+				"is immutable and cannot be changed after creation",
+			),
+		)
+	}
+
+	// Check immutable key pair dependency
+	if !equalKeyPairDependency(oldInstance.Spec.KeyPair, newInstance.Spec.KeyPair) {
+		errors = append(
+			errors,
+			field.Forbidden(
+				field.NewPath("spec", "keyPair"),
+				"is immutable and cannot be changed after creation",
+			),
+		)
+	}
+
+	// Validate WriteConnectionDetailsToRef
+	if err := validateConnectionDetailsRef(newInstance.Spec.WriteConnectionDetailsToRef); err != nil {
+		errors = append(errors, err)
+	}
+
+	// Warn if orphanOnDelete is being changed from false to true
+	if !oldInstance.Spec.OrphanOnDelete && newInstance.Spec.OrphanOnDelete {
+		warnings = append(
+			warnings,
+			"orphanOnDelete changed to true: external instance will not be deleted when this resource is deleted",
+		)
+	}
+
+	// Warn if orphanOnDelete is being changed from true to false
+	if oldInstance.Spec.OrphanOnDelete && !newInstance.Spec.OrphanOnDelete {
+		warnings = append(
+			warnings,
+			"orphanOnDelete changed to false: external instance will be deleted when this resource is deleted",
+		)
+	}
+
+	if len(errors) == 0 {
+		return warnings, nil
+	}
+
+	return warnings, apierrors.NewInvalid(
+		oldInstance.GroupVersionKind().GroupKind(),
+		oldInstance.Name,
+		errors,
+	)
+}
+
+// ValidateDelete implements webhook.CustomValidator so a webhook will be registered for the type Instance.
+func (v *InstanceCustomValidator) ValidateDelete(
+	ctx context.Context,
+	obj runtime.Object,
+) (admission.Warnings, error) {
+	return nil, nil
+}
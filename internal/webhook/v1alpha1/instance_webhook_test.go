@@ -0,0 +1,111 @@
+package v1alpha1
+
+import (
+	"context"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	otcv1alpha1 "github.com/peertech.de/otc-operator/api/v1alpha1"
+)
+
+func newTestInstance() *otcv1alpha1.Instance {
+	subnetID := "subnet-id"
+	return &otcv1alpha1.Instance{
+		ObjectMeta: metav1.ObjectMeta{Name: "instance"},
+		Spec: otcv1alpha1.InstanceSpec{
+			ProviderConfigRef: otcv1alpha1.ProviderConfigReference{Name: "provider"},
+			Flavor:            "s3.medium.2",
+			ImageID:           "image-id",
+			NICs: []otcv1alpha1.InstanceNIC{{
+				Subnet: otcv1alpha1.SubnetDependency{SubnetID: &subnetID},
+			}},
+		},
+	}
+}
+
+func TestInstanceValidateCreate(t *testing.T) {
+	tests := []struct {
+		name    string
+		update  func(*otcv1alpha1.Instance)
+		wantErr bool
+	}{
+		{
+			name:   "valid",
+			update: func(*otcv1alpha1.Instance) {},
+		},
+		{
+			name: "no NICs",
+			update: func(instance *otcv1alpha1.Instance) {
+				instance.Spec.NICs = nil
+			},
+			wantErr: true,
+		},
+		{
+			name: "NIC without subnet",
+			update: func(instance *otcv1alpha1.Instance) {
+				instance.Spec.NICs[0].Subnet = otcv1alpha1.SubnetDependency{}
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid NIC IP address",
+			update: func(instance *otcv1alpha1.Instance) {
+				instance.Spec.NICs[0].IPAddress = "10.0.0"
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			instance := newTestInstance()
+			tt.update(instance)
+
+			_, err := (&InstanceCustomValidator{}).ValidateCreate(context.Background(), instance)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Expected error %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestInstanceValidateUpdate(t *testing.T) {
+	tests := []struct {
+		name    string
+		update  func(*otcv1alpha1.Instance)
+		wantErr bool
+	}{
+		{
+			name: "power state",
+			update: func(instance *otcv1alpha1.Instance) {
+				instance.Spec.PowerState = otcv1alpha1.InstancePowerStateStopped
+			},
+		},
+		{
+			name: "flavor",
+			update: func(instance *otcv1alpha1.Instance) {
+				instance.Spec.Flavor = "s3.large.2"
+			},
+			wantErr: true,
+		},
+		{
+			name: "NIC IP address",
+			update: func(instance *otcv1alpha1.Instance) {
+				instance.Spec.NICs[0].IPAddress = "10.0.0.10"
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			oldInstance := newTestInstance()
+			newInstance := newTestInstance()
+			tt.update(newInstance)
+
+			_, err := (&InstanceCustomValidator{}).ValidateUpdate(context.Background(), oldInstance, newInstance)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Expected error %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
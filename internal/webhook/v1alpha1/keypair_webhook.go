@@ -0,0 +1,245 @@
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	otcv1alpha1 "github.com/peertech.de/otc-operator/api/v1alpha1"
+)
+
+// SetupKeyPairWebhookWithManager registers the webhook for KeyPair in the manager.
+func SetupKeyPairWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).For(&otcv1alpha1.KeyPair{}).
+		WithValidator(&KeyPairCustomValidator{}).
+		Complete()
+}
+
+// TODO(user): change verbs to "verbs=create;update;delete" if you want to enable deletion validation.
+// +kubebuilder:webhook:path=/validate-otc-peertech-de-v1alpha1-keypair,mutating=false,failurePolicy=fail,sideEffects=None,groups=otc.peertech.de,resources=keypairs,verbs=create;update,versions=v1alpha1,name=vkeypair-v1alpha1.kb.io,admissionReviewVersions=v1
+
+// KeyPairCustomValidator struct is responsible for validating the KeyPair resource
+// when it is created, updated, or deleted.
+type KeyPairCustomValidator struct{}
+
+var _ webhook.CustomValidator = &KeyPairCustomValidator{}
+
+// ValidateCreate implements webhook.CustomValidator so a webhook will be registered for the type KeyPair.
+func (v *KeyPairCustomValidator) ValidateCreate(
+	_ context.Context,
+	obj runtime.Object,
+) (admission.Warnings, error) {
+	keyPair, ok := obj.(*otcv1alpha1.KeyPair)
+	if !ok {
+		return nil, fmt.Errorf("expected a KeyPair object but got %T", obj)
+	}
+
+	var warnings admission.Warnings
+	var errors field.ErrorList
+
+	// Validate the resource name
+	if !validName.MatchString(keyPair.Name) {
+		errors = append(errors, field.Invalid(
+			field.NewPath("metadata", "name"),
+			keyPair.Name,
+			"name must contain only letters, digits, underscores (_), hyphens (-), and periods (.)",
+		))
+	}
+
+	// Validate ProviderConfigRef
+	if err := validateProviderConfigRefName(keyPair.Spec.ProviderConfigRef); err != nil {
+		errors = append(errors, err)
+	}
+
+	// Validate the key source
+	errors = append(errors, validateKeyPairKeys(keyPair.Spec)...)
+
+	// Validate WriteConnectionDetailsToRef
+	if err := validateConnectionDetailsRef(keyPair.Spec.WriteConnectionDetailsToRef); err != nil {
+		errors = append(errors, err)
+	}
+
+	// Warn about orphanOnDelete if true
+	if keyPair.Spec.OrphanOnDelete {
+		warnings = append(
+			warnings,
+			"orphanOnDelete is true: external key pair will not be deleted when this resource is deleted",
+		)
+	}
+
+	if len(errors) == 0 {
+		return warnings, nil
+	}
+
+	return warnings, apierrors.NewInvalid(
+		keyPair.GroupVersionKind().GroupKind(),
+		keyPair.Name,
+		errors,
+	)
+}
+
+// ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the type KeyPair.
+func (v *KeyPairCustomValidator) ValidateUpdate(
+	_ context.Context,
+	oldObj, newObj runtime.Object,
+) (admission.Warnings, error) {
+	oldKeyPair, ok := oldObj.(*otcv1alpha1.KeyPair)
+	if !ok {
+		return nil, fmt.Errorf("expected a KeyPair object for the oldObj but got %T", oldObj)
+	}
+	newKeyPair, ok := newObj.(*otcv1alpha1.KeyPair)
+	if !ok {
+		return nil, fmt.Errorf("expected a KeyPair object for the newObj but got %T", newObj)
+	}
+
+	var warnings admission.Warnings
+	var errors field.ErrorList
+
+	// Check immutable ProviderConfigRef
+	if !equalProviderConfigRef(
+		oldKeyPair.Spec.ProviderConfigRef,
+		newKeyPair.Spec.ProviderConfigRef,
+	) {
+		errors = append(
+			errors,
+			field.Forbidden(
+				field.NewPath("spec", "providerConfigRef"),
+				"is immutable and cannot be changed after creation",
+			),
+		)
+	}
+
+	// Check immutable key references
+	if !equalSecretKeySelector(
+		oldKeyPair.Spec.PublicKeySecretRef,
+		newKeyPair.Spec.PublicKeySecretRef,
+	) {
+		errors = append(
+			errors,
+			field.Forbidden(
+				field.NewPath("spec", "publicKeySecretRef"),
+				"is immutable and cannot be changed after creation",
+			),
+		)
+	}
+	if !equalSecretKeySelector(
+		oldKeyPair.Spec.PrivateKeySecretRef,
+		newKeyPair.Spec.PrivateKeySecretRef,
+	) {
+		errors = append(
+			errors,
+			field.Forbidden(
+				field.NewPath("spec", "privateKeySecretRef"),
+				"is immutable and cannot be changed after creation",
+			),
+		)
+	}
+
+	// Validate the key source
+	errors = append(errors, validateKeyPairKeys(newKeyPair.Spec)...)
+
+	// Validate WriteConnectionDetailsToRef
+	if err := validateConnectionDetailsRef(newKeyPair.Spec.WriteConnectionDetailsToRef); err != nil {
+		errors = append(errors, err)
+	}
+
+	// Warn if orphanOnDelete is being changed from false to true
+	if !oldKeyPair.Spec.OrphanOnDelete && newKeyPair.Spec.OrphanOnDelete {
+		warnings = append(
+			warnings,
+			"orphanOnDelete changed to true: external key pair will not be deleted when this resource is deleted",
+		)
+	}
+
+	// Warn if orphanOnDelete is being changed from true to false
+	if oldKeyPair.Spec.OrphanOnDelete && !newKeyPair.Spec.OrphanOnDelete {
+		warnings = append(
+			warnings,
+			"orphanOnDelete changed to false: external key pair will be deleted when this resource is deleted",
+		)
+	}
+
+	if len(errors) == 0 {
+		return warnings, nil
+	}
+
+	return warnings, apierrors.NewInvalid(
+		oldKeyPair.GroupVersionKind().GroupKind(),
+		oldKeyPair.Name,
+		errors,
+	)
+}
+
+// ValidateDelete implements webhook.CustomValidator so a webhook will be registered for the type KeyPair.
+func (v *KeyPairCustomValidator) ValidateDelete(
+	ctx context.Context,
+	obj runtime.Object,
+) (admission.Warnings, error) {
+	return nil, nil
+}
+
+func validateKeyPairKeys(spec otcv1alpha1.KeyPairSpec) field.ErrorList {
+	var errors field.ErrorList
+
+	publicKeyRef, privateKeyRef := spec.PublicKeySecretRef, spec.PrivateKeySecretRef
+	if (publicKeyRef == nil) == (privateKeyRef == nil) {
+		errors = append(
+			errors,
+			field.Invalid(
+				field.NewPath("spec"),
+				spec,
+				"exactly one of publicKeySecretRef or privateKeySecretRef must be specified",
+			),
+		)
+		return errors
+	}
+
+	if publicKeyRef != nil {
+		if err := validateSecretKeySelector(*publicKeyRef); err != nil {
+			errors = append(
+				errors,
+				field.Invalid(
+					field.NewPath("spec", "publicKeySecretRef"),
+					publicKeyRef,
+					err.Error(),
+				),
+			)
+		}
+	}
+
+	if privateKeyRef != nil {
+		if err := validateSecretKeySelector(*privateKeyRef); err != nil {
+			errors = append(
+				errors,
+				field.Invalid(
+					field.NewPath("spec", "privateKeySecretRef"),
+					privateKeyRef,
+					err.Error(),
+				),
+			)
+		}
+
+		// The connection details replace the contents of their Secret, which
+		// would drop the private key.
+		ref := spec.WriteConnectionDetailsToRef
+		if ref != nil && ref.Kind != otcv1alpha1.ConnectionDetailsConfigMap &&
+			ref.Name == privateKeyRef.Name {
+			errors = append(
+				errors,
+				field.Invalid(
+					field.NewPath("spec", "writeConnectionDetailsToRef", "name"),
+					ref.Name,
+					"must not be the Secret the private key is written to",
+				),
+			)
+		}
+	}
+
+	return errors
+}
@@ -0,0 +1,154 @@
+package v1alpha1
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	otcv1alpha1 "github.com/peertech.de/otc-operator/api/v1alpha1"
+)
+
+func TestKeyPairValidateCreateName(t *testing.T) {
+	tests := []struct {
+		name      string
+		namespace string
+		keyPair   string
+		wantErr   bool
+	}{
+		{
+			name:      "short name",
+			namespace: "default",
+			keyPair:   "deploy",
+		},
+		{
+			// The name at the provider is truncated to its maximum length.
+			name:      "long name",
+			namespace: "default",
+			keyPair:   strings.Repeat("a", 253),
+		},
+		{
+			name:      "invalid characters",
+			namespace: "default",
+			keyPair:   "deploy:key",
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			keyPair := newTestKeyPair()
+			keyPair.Name, keyPair.Namespace = tt.keyPair, tt.namespace
+
+			_, err := (&KeyPairCustomValidator{}).ValidateCreate(context.Background(), keyPair)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Expected error %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func newTestKeyPair() *otcv1alpha1.KeyPair {
+	return &otcv1alpha1.KeyPair{
+		ObjectMeta: metav1.ObjectMeta{Name: "deploy", Namespace: "default"},
+		Spec: otcv1alpha1.KeyPairSpec{
+			ProviderConfigRef: otcv1alpha1.ProviderConfigReference{Name: "provider"},
+			PrivateKeySecretRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "key"},
+				Key:                  "id_ed25519",
+			},
+		},
+	}
+}
+
+func TestKeyPairValidateCreateKeys(t *testing.T) {
+	tests := []struct {
+		name    string
+		update  func(*otcv1alpha1.KeyPair)
+		wantErr bool
+	}{
+		{
+			name: "public key",
+			update: func(keyPair *otcv1alpha1.KeyPair) {
+				keyPair.Spec.PublicKeySecretRef = keyPair.Spec.PrivateKeySecretRef
+				keyPair.Spec.PrivateKeySecretRef = nil
+			},
+		},
+		{
+			name: "public and private key",
+			update: func(keyPair *otcv1alpha1.KeyPair) {
+				keyPair.Spec.PublicKeySecretRef = keyPair.Spec.PrivateKeySecretRef
+			},
+			wantErr: true,
+		},
+		{
+			name: "no key",
+			update: func(keyPair *otcv1alpha1.KeyPair) {
+				keyPair.Spec.PrivateKeySecretRef = nil
+			},
+			wantErr: true,
+		},
+		{
+			name: "connection details in private key Secret",
+			update: func(keyPair *otcv1alpha1.KeyPair) {
+				keyPair.Spec.WriteConnectionDetailsToRef = &otcv1alpha1.ConnectionDetailsReference{
+					Name: keyPair.Spec.PrivateKeySecretRef.Name,
+				}
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			keyPair := newTestKeyPair()
+			tt.update(keyPair)
+
+			_, err := (&KeyPairCustomValidator{}).ValidateCreate(context.Background(), keyPair)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Expected error %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestKeyPairValidateUpdate(t *testing.T) {
+	tests := []struct {
+		name    string
+		update  func(*otcv1alpha1.KeyPair)
+		wantErr bool
+	}{
+		{
+			name: "orphanOnDelete",
+			update: func(keyPair *otcv1alpha1.KeyPair) {
+				keyPair.Spec.OrphanOnDelete = true
+			},
+		},
+		{
+			name: "private key Secret",
+			update: func(keyPair *otcv1alpha1.KeyPair) {
+				keyPair.Spec.PrivateKeySecretRef.Name = "other"
+			},
+			wantErr: true,
+		},
+		{
+			name: "providerConfigRef",
+			update: func(keyPair *otcv1alpha1.KeyPair) {
+				keyPair.Spec.ProviderConfigRef.Name = "other"
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			oldKeyPair := newTestKeyPair()
+			newKeyPair := newTestKeyPair()
+			tt.update(newKeyPair)
+
+			_, err := (&KeyPairCustomValidator{}).ValidateUpdate(context.Background(), oldKeyPair, newKeyPair)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Expected error %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
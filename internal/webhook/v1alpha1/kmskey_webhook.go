@@ -0,0 +1,186 @@
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	otcv1alpha1 "github.com/peertech.de/otc-operator/api/v1alpha1"
+)
+
+// SetupKMSKeyWebhookWithManager registers the webhook for KMSKey in the manager.
+func SetupKMSKeyWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).For(&otcv1alpha1.KMSKey{}).
+		WithValidator(&KMSKeyCustomValidator{}).
+		Complete()
+}
+
+// TODO(user): change verbs to "verbs=create;update;delete" if you want to enable deletion validation.
+// +kubebuilder:webhook:path=/validate-otc-peertech-de-v1alpha1-kmskey,mutating=false,failurePolicy=fail,sideEffects=None,groups=otc.peertech.de,resources=kmskeys,verbs=create;update,versions=v1alpha1,name=vkmskey-v1alpha1.kb.io,admissionReviewVersions=v1
+
+// KMSKeyCustomValidator struct is responsible for validating the KMSKey resource
+// when it is created, updated, or deleted.
+type KMSKeyCustomValidator struct{}
+
+var _ webhook.CustomValidator = &KMSKeyCustomValidator{}
+
+// ValidateCreate implements webhook.CustomValidator so a webhook will be registered for the type KMSKey.
+func (v *KMSKeyCustomValidator) ValidateCreate(
+	_ context.Context,
+	obj runtime.Object,
+) (admission.Warnings, error) {
+	key, ok := obj.(*otcv1alpha1.KMSKey)
+	if !ok {
+		return nil, fmt.Errorf("expected a KMSKey object but got %T", obj)
+	}
+
+	var warnings admission.Warnings
+	var errors field.ErrorList
+
+	// Validate the resource name
+	if !validName.MatchString(key.Name) {
+		errors = append(errors, field.Invalid(
+			field.NewPath("metadata", "name"),
+			key.Name,
+			"name must contain only letters, digits, underscores (_), hyphens (-), and periods (.)",
+		))
+	}
+
+	// Validate ProviderConfigRef
+	if err := validateProviderConfigRefName(key.Spec.ProviderConfigRef); err != nil {
+		errors = append(errors, err)
+	}
+
+	// Validate the alias
+	if err := validateKMSKeyAlias(key.Spec.Alias); err != nil {
+		errors = append(errors, err)
+	}
+
+	// Validate WriteConnectionDetailsToRef
+	if err := validateConnectionDetailsRef(key.Spec.WriteConnectionDetailsToRef); err != nil {
+		errors = append(errors, err)
+	}
+
+	// Warn about orphanOnDelete if true
+	if key.Spec.OrphanOnDelete {
+		warnings = append(
+			warnings,
+			"orphanOnDelete is true: external KMS key will not be deleted when this resource is deleted",
+		)
+	}
+
+	if len(errors) == 0 {
+		return warnings, nil
+	}
+
+	return warnings, apierrors.NewInvalid(
+		key.GroupVersionKind().GroupKind(),
+		key.Name,
+		errors,
+	)
+}
+
+// ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the type KMSKey.
+func (v *KMSKeyCustomValidator) ValidateUpdate(
+	_ context.Context,
+	oldObj, newObj runtime.Object,
+) (admission.Warnings, error) {
+	oldKey, ok := oldObj.(*otcv1alpha1.KMSKey)
+	if !ok {
+		return nil, fmt.Errorf("expected a KMSKey object for the oldObj but got %T", oldObj)
+	}
+	newKey, ok := newObj.(*otcv1alpha1.KMSKey)
+	if !ok {
+		return nil, fmt.Errorf("expected a KMSKey object for the newObj but got %T", newObj)
+	}
+
+	var warnings admission.Warnings
+	var errors field.ErrorList
+
+	// Check immutable ProviderConfigRef
+	if !equalProviderConfigRef(
+		oldKey.Spec.ProviderConfigRef,
+		newKey.Spec.ProviderConfigRef,
+	) {
+		errors = append(
+			errors,
+			field.Forbidden(
+				field.NewPath("spec", "providerConfigRef"),
+				"is immutable and cannot be changed after creation",
+			),
+		)
+	}
+
+	// Validate the alias
+	if err := validateKMSKeyAlias(newKey.Spec.Alias); err != nil {
+		errors = append(errors, err)
+	}
+
+	// Validate WriteConnectionDetailsToRef
+	if err := validateConnectionDetailsRef(newKey.Spec.WriteConnectionDetailsToRef); err != nil {
+		errors = append(errors, err)
+	}
+
+	// Warn if the rotation is being disabled
+	if oldKey.Spec.RotationEnabled && !newKey.Spec.RotationEnabled {
+		warnings = append(
+			warnings,
+			"rotationEnabled changed to false: the key material will no longer be rotated",
+		)
+	}
+
+	// Warn if orphanOnDelete is being changed from false to true
+	if !oldKey.Spec.OrphanOnDelete && newKey.Spec.OrphanOnDelete {
+		warnings = append(
+			warnings,
+			"orphanOnDelete changed to true: external KMS key will not be deleted when this resource is deleted",
+		)
+	}
+
+	// Warn if orphanOnDelete is being changed from true to false
+	if oldKey.Spec.OrphanOnDelete && !newKey.Spec.OrphanOnDelete {
+		warnings = append(
+			warnings,
+			"orphanOnDelete changed to false: external KMS key will be deleted when this resource is deleted",
+		)
+	}
+
+	if len(errors) == 0 {
+		return warnings, nil
+	}
+
+	return warnings, apierrors.NewInvalid(
+		oldKey.GroupVersionKind().GroupKind(),
+		oldKey.Name,
+		errors,
+	)
+}
+
+// ValidateDelete implements webhook.CustomValidator so a webhook will be registered for the type KMSKey.
+func (v *KMSKeyCustomValidator) ValidateDelete(
+	ctx context.Context,
+	obj runtime.Object,
+) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// validateKMSKeyAlias rejects the aliases reserved for the default keys of
+// the cloud services.
+func validateKMSKeyAlias(alias string) *field.Error {
+	if strings.HasSuffix(alias, "/default") {
+		return field.Invalid(
+			field.NewPath("spec", "alias"),
+			alias,
+			"alias must not end with /default, which is reserved for the default keys of the cloud services",
+		)
+	}
+
+	return nil
+}
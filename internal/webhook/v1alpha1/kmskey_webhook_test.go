@@ -0,0 +1,119 @@
+package v1alpha1
+
+import (
+	"context"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	otcv1alpha1 "github.com/peertech.de/otc-operator/api/v1alpha1"
+)
+
+func newTestKMSKey() *otcv1alpha1.KMSKey {
+	return &otcv1alpha1.KMSKey{
+		ObjectMeta: metav1.ObjectMeta{Name: "key"},
+		Spec: otcv1alpha1.KMSKeySpec{
+			ProviderConfigRef:    otcv1alpha1.ProviderConfigReference{Name: "provider"},
+			Alias:                "app-key",
+			RotationEnabled:      true,
+			RotationIntervalDays: 90,
+		},
+	}
+}
+
+func TestKMSKeyValidateCreate(t *testing.T) {
+	tests := []struct {
+		name    string
+		update  func(*otcv1alpha1.KMSKey)
+		wantErr bool
+	}{
+		{
+			name:   "key with rotation",
+			update: func(*otcv1alpha1.KMSKey) {},
+		},
+		{
+			name: "alias with path",
+			update: func(key *otcv1alpha1.KMSKey) {
+				key.Spec.Alias = "app/key"
+			},
+		},
+		{
+			name: "alias of a default key",
+			update: func(key *otcv1alpha1.KMSKey) {
+				key.Spec.Alias = "obs/default"
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid name",
+			update: func(key *otcv1alpha1.KMSKey) {
+				key.Name = "app key"
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			key := newTestKMSKey()
+			tt.update(key)
+
+			_, err := (&KMSKeyCustomValidator{}).ValidateCreate(context.Background(), key)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Expected error %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestKMSKeyValidateUpdate(t *testing.T) {
+	tests := []struct {
+		name     string
+		update   func(*otcv1alpha1.KMSKey)
+		wantErr  bool
+		warnings int
+	}{
+		{
+			name: "alias and description",
+			update: func(key *otcv1alpha1.KMSKey) {
+				key.Spec.Alias = "other-key"
+				key.Spec.Description = "Key of the other app"
+			},
+		},
+		{
+			name: "rotation disabled",
+			update: func(key *otcv1alpha1.KMSKey) {
+				key.Spec.RotationEnabled = false
+			},
+			warnings: 1,
+		},
+		{
+			name: "provider config",
+			update: func(key *otcv1alpha1.KMSKey) {
+				key.Spec.ProviderConfigRef.Name = "other-provider"
+			},
+			wantErr: true,
+		},
+		{
+			name: "alias of a default key",
+			update: func(key *otcv1alpha1.KMSKey) {
+				key.Spec.Alias = "evs/default"
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			oldKey := newTestKMSKey()
+			newKey := newTestKMSKey()
+			tt.update(newKey)
+
+			warnings, err := (&KMSKeyCustomValidator{}).ValidateUpdate(context.Background(), oldKey, newKey)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Expected error %v, got %v", tt.wantErr, err)
+			}
+			if len(warnings) != tt.warnings {
+				t.Fatalf("Expected %d warnings, got %v", tt.warnings, warnings)
+			}
+		})
+	}
+}
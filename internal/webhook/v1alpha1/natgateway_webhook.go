@@ -98,6 +98,11 @@ func (v *NATGatewayCustomValidator) ValidateCreate(
 		)
 	}
 
+	// Validate WriteConnectionDetailsToRef
+	if err := validateConnectionDetailsRef(natGateway.Spec.WriteConnectionDetailsToRef); err != nil {
+		errors = append(errors, err)
+	}
+
 	// Warn about orphanOnDelete if true
 	if natGateway.Spec.OrphanOnDelete {
 		warnings = append(
@@ -182,6 +187,11 @@ func (v *NATGatewayCustomValidator) ValidateUpdate(
 		)
 	}
 
+	// Validate WriteConnectionDetailsToRef
+	if err := validateConnectionDetailsRef(newNATGateway.Spec.WriteConnectionDetailsToRef); err != nil {
+		errors = append(errors, err)
+	}
+
 	// Warn if orphanOnDelete is being changed from false to true
 	if !oldNATGateway.Spec.OrphanOnDelete && newNATGateway.Spec.OrphanOnDelete {
 		warnings = append(
@@ -75,6 +75,11 @@ func (v *NetworkCustomValidator) ValidateCreate(
 		)
 	}
 
+	// Validate WriteConnectionDetailsToRef
+	if err := validateConnectionDetailsRef(network.Spec.WriteConnectionDetailsToRef); err != nil {
+		errors = append(errors, err)
+	}
+
 	// Warn about orphanOnDelete if true
 	if network.Spec.OrphanOnDelete {
 		warnings = append(
@@ -136,6 +141,11 @@ func (v *NetworkCustomValidator) ValidateUpdate(
 		)
 	}
 
+	// Validate WriteConnectionDetailsToRef
+	if err := validateConnectionDetailsRef(newNetwork.Spec.WriteConnectionDetailsToRef); err != nil {
+		errors = append(errors, err)
+	}
+
 	// Warn if orphanOnDelete is being changed from false to true
 	if !oldNetwork.Spec.OrphanOnDelete && newNetwork.Spec.OrphanOnDelete {
 		warnings = append(
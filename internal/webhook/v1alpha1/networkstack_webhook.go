@@ -0,0 +1,266 @@
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	otcv1alpha1 "github.com/peertech.de/otc-operator/api/v1alpha1"
+)
+
+// SetupNetworkStackWebhookWithManager registers the webhook for NetworkStack in the manager.
+func SetupNetworkStackWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).For(&otcv1alpha1.NetworkStack{}).
+		WithValidator(&NetworkStackCustomValidator{}).
+		Complete()
+}
+
+// TODO(user): change verbs to "verbs=create;update;delete" if you want to enable deletion validation.
+// +kubebuilder:webhook:path=/validate-otc-peertech-de-v1alpha1-networkstack,mutating=false,failurePolicy=fail,sideEffects=None,groups=otc.peertech.de,resources=networkstacks,verbs=create;update,versions=v1alpha1,name=vnetworkstack-v1alpha1.kb.io,admissionReviewVersions=v1
+
+// NetworkStackCustomValidator struct is responsible for validating the NetworkStack resource
+// when it is created, updated, or deleted.
+type NetworkStackCustomValidator struct{}
+
+var _ webhook.CustomValidator = &NetworkStackCustomValidator{}
+
+// ValidateCreate implements webhook.CustomValidator so a webhook will be registered for the type NetworkStack.
+func (v *NetworkStackCustomValidator) ValidateCreate(
+	_ context.Context,
+	obj runtime.Object,
+) (admission.Warnings, error) {
+	networkStack, ok := obj.(*otcv1alpha1.NetworkStack)
+	if !ok {
+		return nil, fmt.Errorf("expected a NetworkStack object but got %T", obj)
+	}
+
+	var warnings admission.Warnings
+	var errors field.ErrorList
+
+	// Validate the resource name
+	if !validName.MatchString(networkStack.Name) {
+		errors = append(errors, field.Invalid(
+			field.NewPath("metadata", "name"),
+			networkStack.Name,
+			"name must contain only letters, digits, underscores (_), hyphens (-), and periods (.)",
+		))
+	}
+
+	// Validate ProviderConfigRef
+	if err := validateProviderConfigRefName(networkStack.Spec.ProviderConfigRef); err != nil {
+		errors = append(errors, err)
+	}
+
+	errors = append(errors, validateNetworkStackSpec(networkStack.Spec)...)
+
+	// Warn about orphanOnDelete if true
+	if networkStack.Spec.OrphanOnDelete {
+		warnings = append(
+			warnings,
+			"orphanOnDelete is true: external resources will not be deleted when this resource is deleted",
+		)
+	}
+
+	if len(errors) == 0 {
+		return warnings, nil
+	}
+
+	return warnings, apierrors.NewInvalid(
+		networkStack.GroupVersionKind().GroupKind(),
+		networkStack.Name,
+		errors,
+	)
+}
+
+// ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the type NetworkStack.
+func (v *NetworkStackCustomValidator) ValidateUpdate(
+	_ context.Context,
+	oldObj, newObj runtime.Object,
+) (admission.Warnings, error) {
+	oldNetworkStack, ok := oldObj.(*otcv1alpha1.NetworkStack)
+	if !ok {
+		return nil, fmt.Errorf("expected a NetworkStack object for the oldObj but got %T", oldObj)
+	}
+	newNetworkStack, ok := newObj.(*otcv1alpha1.NetworkStack)
+	if !ok {
+		return nil, fmt.Errorf("expected a NetworkStack object for the newObj but got %T", newObj)
+	}
+
+	var warnings admission.Warnings
+	var errors field.ErrorList
+
+	// Check immutable ProviderConfigRef
+	if !equalProviderConfigRef(
+		oldNetworkStack.Spec.ProviderConfigRef,
+		newNetworkStack.Spec.ProviderConfigRef,
+	) {
+		errors = append(
+			errors,
+			field.Forbidden(
+				field.NewPath("spec", "providerConfigRef"),
+				"is immutable and cannot be changed after creation",
+			),
+		)
+	}
+
+	// Check immutable CIDR field
+	if newNetworkStack.Spec.Cidr != oldNetworkStack.Spec.Cidr {
+		errors = append(
+			errors,
+			field.Forbidden(
+				field.NewPath("spec", "cidr"),
+				"is immutable and cannot be changed after creation",
+			),
+		)
+	}
+
+	// Subnets can be added and removed, but the CIDR and gateway IP of an
+	// existing subnet are immutable
+	oldSubnets := make(map[string]otcv1alpha1.NetworkStackSubnet)
+	for _, subnet := range oldNetworkStack.Spec.Subnets {
+		oldSubnets[subnet.Name] = subnet
+	}
+	for i, subnet := range newNetworkStack.Spec.Subnets {
+		oldSubnet, found := oldSubnets[subnet.Name]
+		if !found {
+			continue
+		}
+		if subnet.Cidr != oldSubnet.Cidr || subnet.GatewayIP != oldSubnet.GatewayIP {
+			errors = append(
+				errors,
+				field.Forbidden(
+					field.NewPath("spec", "subnets").Index(i),
+					"cidr and gatewayIP are immutable and cannot be changed after creation",
+				),
+			)
+		}
+	}
+
+	// Check immutable NAT gateway subnet and public IP type, the type of the
+	// rendered PublicIP is immutable
+	oldNAT, newNAT := oldNetworkStack.Spec.NATGateway, newNetworkStack.Spec.NATGateway
+	if oldNAT != nil && newNAT != nil && oldNAT.Subnet != newNAT.Subnet {
+		errors = append(
+			errors,
+			field.Forbidden(
+				field.NewPath("spec", "natGateway", "subnet"),
+				"is immutable and cannot be changed after creation",
+			),
+		)
+	}
+	if oldNAT != nil && newNAT != nil && oldNAT.PublicIPType != newNAT.PublicIPType {
+		errors = append(
+			errors,
+			field.Forbidden(
+				field.NewPath("spec", "natGateway", "publicIPType"),
+				"is immutable and cannot be changed after creation",
+			),
+		)
+	}
+
+	errors = append(errors, validateNetworkStackSpec(newNetworkStack.Spec)...)
+
+	// Warn if orphanOnDelete is being changed from false to true
+	if !oldNetworkStack.Spec.OrphanOnDelete && newNetworkStack.Spec.OrphanOnDelete {
+		warnings = append(
+			warnings,
+			"orphanOnDelete changed to true: external resources will not be deleted when this resource is deleted",
+		)
+	}
+
+	// Warn if orphanOnDelete is being changed from true to false
+	if oldNetworkStack.Spec.OrphanOnDelete && !newNetworkStack.Spec.OrphanOnDelete {
+		warnings = append(
+			warnings,
+			"orphanOnDelete changed to false: external resources will be deleted when this resource is deleted",
+		)
+	}
+
+	if len(errors) == 0 {
+		return warnings, nil
+	}
+
+	return warnings, apierrors.NewInvalid(
+		oldNetworkStack.GroupVersionKind().GroupKind(),
+		oldNetworkStack.Name,
+		errors,
+	)
+}
+
+// ValidateDelete implements webhook.CustomValidator so a webhook will be registered for the type NetworkStack.
+func (v *NetworkStackCustomValidator) ValidateDelete(
+	ctx context.Context,
+	obj runtime.Object,
+) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// validateNetworkStackSpec validates the network, the subnets and the NAT
+// gateway of a NetworkStack.
+func validateNetworkStackSpec(spec otcv1alpha1.NetworkStackSpec) field.ErrorList {
+	var errors field.ErrorList
+
+	// Validate CIDR format
+	if err := validateCIDR(spec.Cidr); err != nil {
+		errors = append(
+			errors,
+			field.Invalid(
+				field.NewPath("spec", "cidr"),
+				spec.Cidr,
+				err.Error(),
+			),
+		)
+	}
+
+	// Validate the subnets
+	names := make(map[string]bool)
+	snat := false
+	for i, subnet := range spec.Subnets {
+		path := field.NewPath("spec", "subnets").Index(i)
+
+		if !validName.MatchString(subnet.Name) {
+			errors = append(errors, field.Invalid(
+				path.Child("name"),
+				subnet.Name,
+				"name must contain only letters, digits, underscores (_), hyphens (-), and periods (.)",
+			))
+		}
+		if names[subnet.Name] {
+			errors = append(errors, field.Duplicate(path.Child("name"), subnet.Name))
+		}
+		names[subnet.Name] = true
+
+		if err := validateCIDR(subnet.Cidr); err != nil {
+			errors = append(errors, field.Invalid(path.Child("cidr"), subnet.Cidr, err.Error()))
+		} else if err := validateGatewayIP(subnet.GatewayIP, subnet.Cidr); err != nil {
+			errors = append(errors, field.Invalid(path.Child("gatewayIP"), subnet.GatewayIP, err.Error()))
+		}
+
+		snat = snat || subnet.SNAT
+	}
+
+	// Validate that the NAT gateway is placed in a subnet of the stack
+	if spec.NATGateway != nil && !names[spec.NATGateway.Subnet] {
+		errors = append(errors, field.Invalid(
+			field.NewPath("spec", "natGateway", "subnet"),
+			spec.NATGateway.Subnet,
+			"must be the name of a subnet of the stack",
+		))
+	}
+
+	// Validate that SNAT rules have a NAT gateway
+	if snat && spec.NATGateway == nil {
+		errors = append(errors, field.Required(
+			field.NewPath("spec", "natGateway"),
+			"is required if a subnet has snat enabled",
+		))
+	}
+
+	return errors
+}
@@ -0,0 +1,75 @@
+package v1alpha1
+
+import (
+	"context"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	otcv1alpha1 "github.com/peertech.de/otc-operator/api/v1alpha1"
+)
+
+func newTestNetworkStack() *otcv1alpha1.NetworkStack {
+	return &otcv1alpha1.NetworkStack{
+		ObjectMeta: metav1.ObjectMeta{Name: "stack"},
+		Spec: otcv1alpha1.NetworkStackSpec{
+			ProviderConfigRef: otcv1alpha1.ProviderConfigReference{Name: "provider"},
+			Cidr:              "10.0.0.0/16",
+			Subnets: []otcv1alpha1.NetworkStackSubnet{
+				{Name: "public", Cidr: "10.0.0.0/24", GatewayIP: "10.0.0.1"},
+				{Name: "private", Cidr: "10.0.1.0/24", GatewayIP: "10.0.1.1", SNAT: true},
+			},
+			NATGateway: &otcv1alpha1.NetworkStackNATGateway{
+				Subnet:        "public",
+				Type:          otcv1alpha1.TypeSmall,
+				PublicIPType:  otcv1alpha1.PublicIPBGP,
+				BandwidthSize: 10,
+			},
+		},
+	}
+}
+
+func TestNetworkStackValidateUpdate(t *testing.T) {
+	tests := []struct {
+		name    string
+		update  func(*otcv1alpha1.NetworkStack)
+		wantErr bool
+	}{
+		{
+			name: "bandwidth size",
+			update: func(networkStack *otcv1alpha1.NetworkStack) {
+				networkStack.Spec.NATGateway.BandwidthSize = 20
+			},
+		},
+		{
+			name: "public IP type",
+			update: func(networkStack *otcv1alpha1.NetworkStack) {
+				networkStack.Spec.NATGateway.PublicIPType = otcv1alpha1.PublicIPMail
+			},
+			wantErr: true,
+		},
+		{
+			name: "NAT gateway subnet",
+			update: func(networkStack *otcv1alpha1.NetworkStack) {
+				networkStack.Spec.NATGateway.Subnet = "private"
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			oldNetworkStack := newTestNetworkStack()
+			newNetworkStack := newTestNetworkStack()
+			tt.update(newNetworkStack)
+
+			validator := NetworkStackCustomValidator{}
+			_, err := validator.ValidateUpdate(context.Background(), oldNetworkStack, newNetworkStack)
+			if tt.wantErr && err == nil {
+				t.Fatal("Expected an error")
+			}
+			if !tt.wantErr && err != nil {
+				t.Fatalf("Expected no error, got %s", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,329 @@
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	otcv1alpha1 "github.com/peertech.de/otc-operator/api/v1alpha1"
+)
+
+// SetupNodePoolWebhookWithManager registers the webhook for NodePool in the manager.
+func SetupNodePoolWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).For(&otcv1alpha1.NodePool{}).
+		WithValidator(&NodePoolCustomValidator{}).
+		Complete()
+}
+
+// TODO(user): change verbs to "verbs=create;update;delete" if you want to enable deletion validation.
+// +kubebuilder:webhook:path=/validate-otc-peertech-de-v1alpha1-nodepool,mutating=false,failurePolicy=fail,sideEffects=None,groups=otc.peertech.de,resources=nodepools,verbs=create;update,versions=v1alpha1,name=vnodepool-v1alpha1.kb.io,admissionReviewVersions=v1
+
+// NodePoolCustomValidator struct is responsible for validating the NodePool resource
+// when it is created, updated, or deleted.
+type NodePoolCustomValidator struct{}
+
+var _ webhook.CustomValidator = &NodePoolCustomValidator{}
+
+// ValidateCreate implements webhook.CustomValidator so a webhook will be registered for the type NodePool.
+func (v *NodePoolCustomValidator) ValidateCreate(
+	_ context.Context,
+	obj runtime.Object,
+) (admission.Warnings, error) {
+	nodePool, ok := obj.(*otcv1alpha1.NodePool)
+	if !ok {
+		return nil, fmt.Errorf("expected a NodePool object but got %T", obj)
+	}
+
+	var warnings admission.Warnings
+	var errors field.ErrorList
+
+	// Validate the resource name
+	if !validName.MatchString(nodePool.Name) {
+		errors = append(errors, field.Invalid(
+			field.NewPath("metadata", "name"),
+			nodePool.Name,
+			"name must contain only letters, digits, underscores (_), hyphens (-), and periods (.)",
+		))
+	}
+
+	// Validate ProviderConfigRef
+	if err := validateProviderConfigRefName(nodePool.Spec.ProviderConfigRef); err != nil {
+		errors = append(errors, err)
+	}
+
+	// Validate that exactly one cluster dependency method is specified
+	if err := validateClusterDependency(nodePool.Spec.Cluster); err != nil {
+		errors = append(
+			errors,
+			field.Invalid(
+				field.NewPath("spec", "cluster"),
+				nodePool.Spec.Cluster,
+				err.Error(),
+			),
+		)
+	}
+
+	// Validate that exactly one key pair dependency method is specified
+	if err := validateKeyPairDependency(nodePool.Spec.KeyPair); err != nil {
+		errors = append(
+			errors,
+			field.Invalid(
+				field.NewPath("spec", "keyPair"),
+				nodePool.Spec.KeyPair,
+				err.Error(),
+			),
+		)
+	}
+
+	// Validate the optional subnet dependency
+	if subnet := nodePool.Spec.Subnet; subnet != nil {
+		if err := validateSubnetDependency(*subnet); err != nil {
+			errors = append(
+				errors,
+				field.Invalid(
+					field.NewPath("spec", "subnet"),
+					subnet,
+					err.Error(),
+				),
+			)
+		}
+	}
+
+	// Validate that exactly one dependency method is specified per security group
+	for i, securityGroup := range nodePool.Spec.SecurityGroups {
+		if err := validateSecurityGroupDependency(securityGroup); err != nil {
+			errors = append(
+				errors,
+				field.Invalid(
+					field.NewPath("spec", "securityGroups").Index(i),
+					securityGroup,
+					err.Error(),
+				),
+			)
+		}
+	}
+
+	// Validate the autoscaling settings
+	if err := validateNodePoolAutoscaling(nodePool.Spec.Autoscaling); err != nil {
+		errors = append(errors, err)
+	}
+
+	// Validate WriteConnectionDetailsToRef
+	if err := validateConnectionDetailsRef(nodePool.Spec.WriteConnectionDetailsToRef); err != nil {
+		errors = append(errors, err)
+	}
+
+	// Warn about orphanOnDelete if true
+	if nodePool.Spec.OrphanOnDelete {
+		warnings = append(
+			warnings,
+			"orphanOnDelete is true: external node pool will not be deleted when this resource is deleted",
+		)
+	}
+
+	if len(errors) == 0 {
+		return warnings, nil
+	}
+
+	return warnings, apierrors.NewInvalid(
+		nodePool.GroupVersionKind().GroupKind(),
+		nodePool.Name,
+		errors,
+	)
+}
+
+// ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the type NodePool.
+func (v *NodePoolCustomValidator) ValidateUpdate(
+	_ context.Context,
+	oldObj, newObj runtime.Object,
+) (admission.Warnings, error) {
+	oldNodePool, ok := oldObj.(*otcv1alpha1.NodePool)
+	if !ok {
+		return nil, fmt.Errorf("expected a NodePool object for the oldObj but got %T", oldObj)
+	}
+	newNodePool, ok := newObj.(*otcv1alpha1.NodePool)
+	if !ok {
+		return nil, fmt.Errorf("expected a NodePool object for the newObj but got %T", newObj)
+	}
+
+	var warnings admission.Warnings
+	var errors field.ErrorList
+
+	// Check immutable ProviderConfigRef
+	if !equalProviderConfigRef(
+		oldNodePool.Spec.ProviderConfigRef,
+		newNodePool.Spec.ProviderConfigRef,
+	) {
+		errors = append(
+			errors,
+			field.Forbidden(
+				field.NewPath("spec", "providerConfigRef"),
+				"is immutable and cannot be changed after creation",
+			),
+		)
+	}
+
+	// Check immutable Cluster dependency
+	if !equalClusterDependency(oldNodePool.Spec.Cluster, newNodePool.Spec.Cluster) {
+		errors = append(
+			errors,
+			field.Forbidden(
+				field.NewPath("spec", "cluster"),
+				"is immutable and cannot be changed after creation",
+			),
+		)
+	}
+
+	// Check immutable key pair dependency
+	if !equalKeyPairDependency(&oldNodePool.Spec.KeyPair, &newNodePool.Spec.KeyPair) {
+		errors = append(
+			errors,
+			field.Forbidden(
+				field.NewPath("spec", "keyPair"),
+				"is immutable and cannot be changed after creation",
+			),
+		)
+	}
+
+	// Check immutable Subnet dependency
+	oldSubnet, newSubnet := oldNodePool.Spec.Subnet, newNodePool.Spec.Subnet
+	if (oldSubnet == nil) != (newSubnet == nil) ||
+		(oldSubnet != nil && !equalSubnetDependency(*oldSubnet, *newSubnet)) {
+		errors = append(
+			errors,
+			field.Forbidden(
+				field.NewPath("spec", "subnet"),
+				"is immutable and cannot be changed after creation",
+			),
+		)
+	}
+
+	// Check immutable security groups
+	if !equalSecurityGroupDependencies(
+		oldNodePool.Spec.SecurityGroups,
+		newNodePool.Spec.SecurityGroups,
+	) {
+		errors = append(
+			errors,
+			field.Forbidden(
+				field.NewPath("spec", "securityGroups"),
+				"is immutable and cannot be changed after creation",
+			),
+		)
+	}
+
+	// Check immutable node settings. The nodes of an existing node pool cannot
+	// be replaced in place.
+	if oldNodePool.Spec.Flavor != newNodePool.Spec.Flavor {
+		errors = append(
+			errors,
+			field.Forbidden(
+				field.NewPath("spec", "flavor"),
+				"is immutable and cannot be changed after creation, create a new node pool instead",
+			),
+		)
+	}
+	if oldNodePool.Spec.AvailabilityZone != newNodePool.Spec.AvailabilityZone {
+		errors = append(
+			errors,
+			field.Forbidden(
+				field.NewPath("spec", "availabilityZone"),
+				"is immutable and cannot be changed after creation",
+			),
+		)
+	}
+	if oldNodePool.Spec.OS != newNodePool.Spec.OS {
+		errors = append(
+			errors,
+			field.Forbidden(
+				field.NewPath("spec", "os"),
+				"is immutable and cannot be changed after creation",
+			),
+		)
+	}
+	if oldNodePool.Spec.RootVolume != newNodePool.Spec.RootVolume {
+		errors = append(
+			errors,
+			field.Forbidden(
+				field.NewPath("spec", "rootVolume"),
+				"is immutable and cannot be changed after creation",
+			),
+		)
+	}
+	if !slices.Equal(oldNodePool.Spec.DataVolumes, newNodePool.Spec.DataVolumes) {
+		errors = append(
+			errors,
+			field.Forbidden(
+				field.NewPath("spec", "dataVolumes"),
+				"is immutable and cannot be changed after creation",
+			),
+		)
+	}
+
+	// Validate the autoscaling settings
+	if err := validateNodePoolAutoscaling(newNodePool.Spec.Autoscaling); err != nil {
+		errors = append(errors, err)
+	}
+
+	// Validate WriteConnectionDetailsToRef
+	if err := validateConnectionDetailsRef(newNodePool.Spec.WriteConnectionDetailsToRef); err != nil {
+		errors = append(errors, err)
+	}
+
+	// Warn if orphanOnDelete is being changed from false to true
+	if !oldNodePool.Spec.OrphanOnDelete && newNodePool.Spec.OrphanOnDelete {
+		warnings = append(
+			warnings,
+			"orphanOnDelete changed to true: external node pool will not be deleted when this resource is deleted",
+		)
+	}
+
+	// Warn if orphanOnDelete is being changed from true to false
+	if oldNodePool.Spec.OrphanOnDelete && !newNodePool.Spec.OrphanOnDelete {
+		warnings = append(
+			warnings,
+			"orphanOnDelete changed to false: external node pool will be deleted when this resource is deleted",
+		)
+	}
+
+	if len(errors) == 0 {
+		return warnings, nil
+	}
+
+	return warnings, apierrors.NewInvalid(
+		oldNodePool.GroupVersionKind().GroupKind(),
+		oldNodePool.Name,
+		errors,
+	)
+}
+
+// ValidateDelete implements webhook.CustomValidator so a webhook will be registered for the type NodePool.
+func (v *NodePoolCustomValidator) ValidateDelete(
+	ctx context.Context,
+	obj runtime.Object,
+) (admission.Warnings, error) {
+	return nil, nil
+}
+
+func validateNodePoolAutoscaling(autoscaling *otcv1alpha1.NodePoolAutoscaling) *field.Error {
+	if autoscaling == nil {
+		return nil
+	}
+
+	if autoscaling.MinNodeCount > autoscaling.MaxNodeCount {
+		return field.Invalid(
+			field.NewPath("spec", "autoscaling", "minNodeCount"),
+			autoscaling.MinNodeCount,
+			"must not be greater than maxNodeCount",
+		)
+	}
+
+	return nil
+}
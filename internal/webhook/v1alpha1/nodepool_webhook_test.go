@@ -0,0 +1,114 @@
+package v1alpha1
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	otcv1alpha1 "github.com/peertech.de/otc-operator/api/v1alpha1"
+)
+
+func newTestNodePool() *otcv1alpha1.NodePool {
+	return &otcv1alpha1.NodePool{
+		ObjectMeta: metav1.ObjectMeta{Name: "nodepool"},
+		Spec: otcv1alpha1.NodePoolSpec{
+			ProviderConfigRef: otcv1alpha1.ProviderConfigReference{Name: "provider"},
+			Cluster: otcv1alpha1.ClusterDependency{
+				ClusterRef: &corev1.LocalObjectReference{Name: "cluster"},
+			},
+			Flavor: "s3.large.2",
+			KeyPair: otcv1alpha1.KeyPairDependency{
+				KeyPairRef: &corev1.LocalObjectReference{Name: "deploy"},
+			},
+			NodeCount: 2,
+		},
+	}
+}
+
+func TestNodePoolValidateCreate(t *testing.T) {
+	tests := []struct {
+		name    string
+		update  func(*otcv1alpha1.NodePool)
+		wantErr bool
+	}{
+		{
+			name:   "valid",
+			update: func(*otcv1alpha1.NodePool) {},
+		},
+		{
+			name: "autoscaling",
+			update: func(nodePool *otcv1alpha1.NodePool) {
+				nodePool.Spec.Autoscaling = &otcv1alpha1.NodePoolAutoscaling{MinNodeCount: 1, MaxNodeCount: 5}
+			},
+		},
+		{
+			name: "autoscaling with minimum above maximum",
+			update: func(nodePool *otcv1alpha1.NodePool) {
+				nodePool.Spec.Autoscaling = &otcv1alpha1.NodePoolAutoscaling{MinNodeCount: 5, MaxNodeCount: 1}
+			},
+			wantErr: true,
+		},
+		{
+			name: "no key pair",
+			update: func(nodePool *otcv1alpha1.NodePool) {
+				nodePool.Spec.KeyPair = otcv1alpha1.KeyPairDependency{}
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			nodePool := newTestNodePool()
+			tt.update(nodePool)
+
+			_, err := (&NodePoolCustomValidator{}).ValidateCreate(context.Background(), nodePool)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Expected error %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestNodePoolValidateUpdate(t *testing.T) {
+	tests := []struct {
+		name    string
+		update  func(*otcv1alpha1.NodePool)
+		wantErr bool
+	}{
+		{
+			name: "node count and labels",
+			update: func(nodePool *otcv1alpha1.NodePool) {
+				nodePool.Spec.NodeCount = 3
+				nodePool.Spec.Labels = map[string]string{"role": "worker"}
+			},
+		},
+		{
+			name: "flavor",
+			update: func(nodePool *otcv1alpha1.NodePool) {
+				nodePool.Spec.Flavor = "s3.xlarge.2"
+			},
+			wantErr: true,
+		},
+		{
+			name: "root volume",
+			update: func(nodePool *otcv1alpha1.NodePool) {
+				nodePool.Spec.RootVolume = otcv1alpha1.NodePoolVolume{Size: 100}
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			oldNodePool := newTestNodePool()
+			newNodePool := newTestNodePool()
+			tt.update(newNodePool)
+
+			_, err := (&NodePoolCustomValidator{}).ValidateUpdate(context.Background(), oldNodePool, newNodePool)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Expected error %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
@@ -57,6 +57,37 @@ func (v *PublicIPCustomValidator) ValidateCreate(
 		errors = append(errors, err)
 	}
 
+	// Validate the bandwidth configuration
+	errors = append(errors, validatePublicIPBandwidth(publicIP.Spec)...)
+
+	// Validate that exactly one binding method is specified
+	if binding := publicIP.Spec.Binding; binding != nil {
+		if err := validatePublicIPBinding(*binding); err != nil {
+			errors = append(
+				errors,
+				field.Invalid(
+					field.NewPath("spec", "binding"),
+					binding,
+					err.Error(),
+				),
+			)
+		}
+	}
+
+	// Warn about a dedicated shared bandwidth
+	if publicIP.Spec.BandwidthShareType == otcv1alpha1.PublicIPBandwidthShared &&
+		publicIP.Spec.Bandwidth == nil {
+		warnings = append(
+			warnings,
+			"bandwidthShareType is Shared but no bandwidth is set: a shared bandwidth will be created for this public IP only",
+		)
+	}
+
+	// Validate WriteConnectionDetailsToRef
+	if err := validateConnectionDetailsRef(publicIP.Spec.WriteConnectionDetailsToRef); err != nil {
+		errors = append(errors, err)
+	}
+
 	// Warn about orphanOnDelete if true
 	if publicIP.Spec.OrphanOnDelete {
 		warnings = append(
@@ -118,29 +149,58 @@ func (v *PublicIPCustomValidator) ValidateUpdate(
 		)
 	}
 
-	// TODO: make mutable
-	// Check immutable bandwidth size field
-	if newPublicIP.Spec.BandwidthSize != oldPublicIP.Spec.BandwidthSize {
+	// Check immutable bandwidth share type field
+	if newPublicIP.Spec.BandwidthShareType != oldPublicIP.Spec.BandwidthShareType {
 		errors = append(
 			errors,
 			field.Forbidden(
-				field.NewPath("spec", "bandwidthSize"),
+				field.NewPath("spec", "bandwidthShareType"),
 				"is immutable and cannot be changed after creation",
 			),
 		)
 	}
 
-	// Check immutable bandwidth share type field
-	if newPublicIP.Spec.BandwidthShareType != oldPublicIP.Spec.BandwidthShareType {
+	// Check immutable bandwidth field
+	if !equalBandwidthDependency(oldPublicIP.Spec.Bandwidth, newPublicIP.Spec.Bandwidth) {
 		errors = append(
 			errors,
 			field.Forbidden(
-				field.NewPath("spec", "bandwidthShareType"),
+				field.NewPath("spec", "bandwidth"),
 				"is immutable and cannot be changed after creation",
 			),
 		)
 	}
 
+	// Validate the bandwidth configuration
+	errors = append(errors, validatePublicIPBandwidth(newPublicIP.Spec)...)
+
+	// Validate that exactly one binding method is specified
+	if binding := newPublicIP.Spec.Binding; binding != nil {
+		if err := validatePublicIPBinding(*binding); err != nil {
+			errors = append(
+				errors,
+				field.Invalid(
+					field.NewPath("spec", "binding"),
+					binding,
+					err.Error(),
+				),
+			)
+		}
+	}
+
+	// Warn about binding changes
+	if oldPublicIP.Spec.Binding != nil && newPublicIP.Spec.Binding == nil {
+		warnings = append(
+			warnings,
+			"binding removed: public IP will be unbound from its port",
+		)
+	}
+
+	// Validate WriteConnectionDetailsToRef
+	if err := validateConnectionDetailsRef(newPublicIP.Spec.WriteConnectionDetailsToRef); err != nil {
+		errors = append(errors, err)
+	}
+
 	// Warn if orphanOnDelete is being changed from false to true
 	if !oldPublicIP.Spec.OrphanOnDelete && newPublicIP.Spec.OrphanOnDelete {
 		warnings = append(
@@ -175,3 +235,47 @@ func (v *PublicIPCustomValidator) ValidateDelete(
 ) (admission.Warnings, error) {
 	return nil, nil
 }
+
+// validatePublicIPBandwidth validates the bandwidth related fields of a
+// PublicIP. A public IP either joins an existing shared bandwidth or gets a
+// bandwidth of its own, which requires a size.
+func validatePublicIPBandwidth(spec otcv1alpha1.PublicIPSpec) field.ErrorList {
+	var errors field.ErrorList
+
+	if spec.Bandwidth == nil {
+		if spec.BandwidthSize <= 0 {
+			errors = append(
+				errors,
+				field.Required(
+					field.NewPath("spec", "bandwidthSize"),
+					"is required unless a shared bandwidth is specified",
+				),
+			)
+		}
+		return errors
+	}
+
+	if err := validateBandwidthDependency(*spec.Bandwidth); err != nil {
+		errors = append(
+			errors,
+			field.Invalid(
+				field.NewPath("spec", "bandwidth"),
+				spec.Bandwidth,
+				err.Error(),
+			),
+		)
+	}
+
+	if spec.BandwidthShareType != otcv1alpha1.PublicIPBandwidthShared {
+		errors = append(
+			errors,
+			field.Invalid(
+				field.NewPath("spec", "bandwidthShareType"),
+				spec.BandwidthShareType,
+				"must be Shared when a bandwidth is specified",
+			),
+		)
+	}
+
+	return errors
+}
@@ -1,55 +1,174 @@
 package v1alpha1
 
 import (
-	. "github.com/onsi/ginkgo/v2"
-	. "github.com/onsi/gomega"
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	otcv1alpha1 "github.com/peertech.de/otc-operator/api/v1alpha1"
-	// TODO (user): Add any additional imports if needed
 )
 
-var _ = Describe("PublicIP Webhook", func() {
-	var (
-		obj       *otcv1alpha1.PublicIP
-		oldObj    *otcv1alpha1.PublicIP
-		validator PublicIPCustomValidator
-	)
-
-	BeforeEach(func() {
-		obj = &otcv1alpha1.PublicIP{}
-		oldObj = &otcv1alpha1.PublicIP{}
-		validator = PublicIPCustomValidator{}
-		Expect(validator).NotTo(BeNil(), "Expected validator to be initialized")
-		Expect(oldObj).NotTo(BeNil(), "Expected oldObj to be initialized")
-		Expect(obj).NotTo(BeNil(), "Expected obj to be initialized")
-		// TODO (user): Add any setup logic common to all tests
-	})
-
-	AfterEach(func() {
-		// TODO (user): Add any teardown logic common to all tests
-	})
-
-	Context("When creating or updating PublicIP under Validating Webhook", func() {
-		// TODO (user): Add logic for validating webhooks
-		// Example:
-		// It("Should deny creation if a required field is missing", func() {
-		//     By("simulating an invalid creation scenario")
-		//     obj.SomeRequiredField = ""
-		//     Expect(validator.ValidateCreate(ctx, obj)).Error().To(HaveOccurred())
-		// })
-		//
-		// It("Should admit creation if all required fields are present", func() {
-		//     By("simulating an invalid creation scenario")
-		//     obj.SomeRequiredField = "valid_value"
-		//     Expect(validator.ValidateCreate(ctx, obj)).To(BeNil())
-		// })
-		//
-		// It("Should validate updates correctly", func() {
-		//     By("simulating a valid update scenario")
-		//     oldObj.SomeRequiredField = "updated_value"
-		//     obj.SomeRequiredField = "updated_value"
-		//     Expect(validator.ValidateUpdate(ctx, oldObj, obj)).To(BeNil())
-		// })
-	})
-
-})
+func newTestPublicIP() *otcv1alpha1.PublicIP {
+	return &otcv1alpha1.PublicIP{
+		ObjectMeta: metav1.ObjectMeta{Name: "eip"},
+		Spec: otcv1alpha1.PublicIPSpec{
+			ProviderConfigRef:  otcv1alpha1.ProviderConfigReference{Name: "provider"},
+			Type:               otcv1alpha1.PublicIPBGP,
+			BandwidthSize:      10,
+			BandwidthShareType: otcv1alpha1.PublicIPBandwidthDedicated,
+		},
+	}
+}
+
+// newTestSharedPublicIP returns a public IP joining the shared bandwidth.
+func newTestSharedPublicIP() *otcv1alpha1.PublicIP {
+	publicIP := newTestPublicIP()
+	publicIP.Spec.BandwidthSize = 0
+	publicIP.Spec.BandwidthShareType = otcv1alpha1.PublicIPBandwidthShared
+	publicIP.Spec.Bandwidth = &otcv1alpha1.BandwidthDependency{
+		BandwidthRef: &corev1.LocalObjectReference{Name: "shared"},
+	}
+	return publicIP
+}
+
+func TestPublicIPValidateCreate(t *testing.T) {
+	tests := []struct {
+		name    string
+		update  func(*otcv1alpha1.PublicIP)
+		wantErr bool
+	}{
+		{
+			name:   "valid",
+			update: func(*otcv1alpha1.PublicIP) {},
+		},
+		{
+			name: "virtual IP binding",
+			update: func(publicIP *otcv1alpha1.PublicIP) {
+				publicIP.Spec.Binding = &otcv1alpha1.PublicIPBinding{
+					VirtualIPRef: &corev1.LocalObjectReference{Name: "vip"},
+				}
+			},
+		},
+		{
+			name: "empty binding",
+			update: func(publicIP *otcv1alpha1.PublicIP) {
+				publicIP.Spec.Binding = &otcv1alpha1.PublicIPBinding{}
+			},
+			wantErr: true,
+		},
+		{
+			name: "empty port binding",
+			update: func(publicIP *otcv1alpha1.PublicIP) {
+				portID := ""
+				publicIP.Spec.Binding = &otcv1alpha1.PublicIPBinding{PortID: &portID}
+			},
+			wantErr: true,
+		},
+		{
+			name: "ambiguous binding",
+			update: func(publicIP *otcv1alpha1.PublicIP) {
+				portID := "port-id"
+				publicIP.Spec.Binding = &otcv1alpha1.PublicIPBinding{
+					PortID:       &portID,
+					VirtualIPRef: &corev1.LocalObjectReference{Name: "vip"},
+				}
+			},
+			wantErr: true,
+		},
+		{
+			name: "shared bandwidth",
+			update: func(publicIP *otcv1alpha1.PublicIP) {
+				*publicIP = *newTestSharedPublicIP()
+			},
+		},
+		{
+			name: "shared bandwidth with dedicated share type",
+			update: func(publicIP *otcv1alpha1.PublicIP) {
+				publicIP.Spec.Bandwidth = &otcv1alpha1.BandwidthDependency{
+					BandwidthRef: &corev1.LocalObjectReference{Name: "shared"},
+				}
+			},
+			wantErr: true,
+		},
+		{
+			name: "no bandwidth size",
+			update: func(publicIP *otcv1alpha1.PublicIP) {
+				publicIP.Spec.BandwidthSize = 0
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			publicIP := newTestPublicIP()
+			tt.update(publicIP)
+
+			_, err := (&PublicIPCustomValidator{}).ValidateCreate(context.Background(), publicIP)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Expected error %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestPublicIPValidateUpdate(t *testing.T) {
+	tests := []struct {
+		name    string
+		shared  bool
+		update  func(*otcv1alpha1.PublicIP)
+		wantErr bool
+	}{
+		{
+			name: "bandwidth size",
+			update: func(publicIP *otcv1alpha1.PublicIP) {
+				publicIP.Spec.BandwidthSize = 20
+			},
+		},
+		{
+			name: "no bandwidth size",
+			update: func(publicIP *otcv1alpha1.PublicIP) {
+				publicIP.Spec.BandwidthSize = 0
+			},
+			wantErr: true,
+		},
+		{
+			name: "type",
+			update: func(publicIP *otcv1alpha1.PublicIP) {
+				publicIP.Spec.Type = otcv1alpha1.PublicIPMail
+			},
+			wantErr: true,
+		},
+		{
+			name: "share type",
+			update: func(publicIP *otcv1alpha1.PublicIP) {
+				publicIP.Spec.BandwidthShareType = otcv1alpha1.PublicIPBandwidthShared
+			},
+			wantErr: true,
+		},
+		{
+			name:   "bandwidth",
+			shared: true,
+			update: func(publicIP *otcv1alpha1.PublicIP) {
+				publicIP.Spec.Bandwidth.BandwidthRef.Name = "other"
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			oldPublicIP := newTestPublicIP()
+			newPublicIP := newTestPublicIP()
+			if tt.shared {
+				oldPublicIP, newPublicIP = newTestSharedPublicIP(), newTestSharedPublicIP()
+			}
+			tt.update(newPublicIP)
+
+			_, err := (&PublicIPCustomValidator{}).ValidateUpdate(context.Background(), oldPublicIP, newPublicIP)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Expected error %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,257 @@
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	otcv1alpha1 "github.com/peertech.de/otc-operator/api/v1alpha1"
+)
+
+// SetupRecordSetWebhookWithManager registers the webhook for RecordSet in the manager.
+func SetupRecordSetWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).For(&otcv1alpha1.RecordSet{}).
+		WithValidator(&RecordSetCustomValidator{}).
+		Complete()
+}
+
+// TODO(user): change verbs to "verbs=create;update;delete" if you want to enable deletion validation.
+// +kubebuilder:webhook:path=/validate-otc-peertech-de-v1alpha1-recordset,mutating=false,failurePolicy=fail,sideEffects=None,groups=otc.peertech.de,resources=recordsets,verbs=create;update,versions=v1alpha1,name=vrecordset-v1alpha1.kb.io,admissionReviewVersions=v1
+
+// RecordSetCustomValidator struct is responsible for validating the RecordSet resource
+// when it is created, updated, or deleted.
+type RecordSetCustomValidator struct{}
+
+var _ webhook.CustomValidator = &RecordSetCustomValidator{}
+
+// ValidateCreate implements webhook.CustomValidator so a webhook will be registered for the type RecordSet.
+func (v *RecordSetCustomValidator) ValidateCreate(
+	_ context.Context,
+	obj runtime.Object,
+) (admission.Warnings, error) {
+	recordSet, ok := obj.(*otcv1alpha1.RecordSet)
+	if !ok {
+		return nil, fmt.Errorf("expected a RecordSet object but got %T", obj)
+	}
+
+	var warnings admission.Warnings
+	var errors field.ErrorList
+
+	// Validate the resource name
+	if !validName.MatchString(recordSet.Name) {
+		errors = append(errors, field.Invalid(
+			field.NewPath("metadata", "name"),
+			recordSet.Name,
+			"name must contain only letters, digits, underscores (_), hyphens (-), and periods (.)",
+		))
+	}
+
+	// Validate ProviderConfigRef
+	if err := validateProviderConfigRefName(recordSet.Spec.ProviderConfigRef); err != nil {
+		errors = append(errors, err)
+	}
+
+	// Validate that exactly one dependency method is specified for the zone
+	if err := validateDNSZoneDependency(recordSet.Spec.Zone); err != nil {
+		errors = append(
+			errors,
+			field.Invalid(
+				field.NewPath("spec", "zone"),
+				recordSet.Spec.Zone,
+				err.Error(),
+			),
+		)
+	}
+
+	// Validate the records
+	errors = append(errors, validateRecordSetRecords(recordSet.Spec)...)
+
+	// Validate WriteConnectionDetailsToRef
+	if err := validateConnectionDetailsRef(recordSet.Spec.WriteConnectionDetailsToRef); err != nil {
+		errors = append(errors, err)
+	}
+
+	// Warn about orphanOnDelete if true
+	if recordSet.Spec.OrphanOnDelete {
+		warnings = append(
+			warnings,
+			"orphanOnDelete is true: external record set will not be deleted when this resource is deleted",
+		)
+	}
+
+	if len(errors) == 0 {
+		return warnings, nil
+	}
+
+	return warnings, apierrors.NewInvalid(
+		recordSet.GroupVersionKind().GroupKind(),
+		recordSet.Name,
+		errors,
+	)
+}
+
+// ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the type RecordSet.
+func (v *RecordSetCustomValidator) ValidateUpdate(
+	_ context.Context,
+	oldObj, newObj runtime.Object,
+) (admission.Warnings, error) {
+	oldRecordSet, ok := oldObj.(*otcv1alpha1.RecordSet)
+	if !ok {
+		return nil, fmt.Errorf("expected a RecordSet object for the oldObj but got %T", oldObj)
+	}
+	newRecordSet, ok := newObj.(*otcv1alpha1.RecordSet)
+	if !ok {
+		return nil, fmt.Errorf("expected a RecordSet object for the newObj but got %T", newObj)
+	}
+
+	var warnings admission.Warnings
+	var errors field.ErrorList
+
+	// Check immutable ProviderConfigRef
+	if !equalProviderConfigRef(
+		oldRecordSet.Spec.ProviderConfigRef,
+		newRecordSet.Spec.ProviderConfigRef,
+	) {
+		errors = append(
+			errors,
+			field.Forbidden(
+				field.NewPath("spec", "providerConfigRef"),
+				"is immutable and cannot be changed after creation",
+			),
+		)
+	}
+
+	// Check immutable zone dependency
+	if !equalDNSZoneDependency(oldRecordSet.Spec.Zone, newRecordSet.Spec.Zone) {
+		errors = append(
+			errors,
+			field.Forbidden(
+				field.NewPath("spec", "zone"),
+				"is immutable and cannot be changed after creation",
+			),
+		)
+	}
+
+	// Check immutable name and type
+	if oldRecordSet.Spec.Name != newRecordSet.Spec.Name {
+		errors = append(
+			errors,
+			field.Forbidden(
+				field.NewPath("spec", "name"),
+				"is immutable and cannot be changed after creation",
+			),
+		)
+	}
+	if oldRecordSet.Spec.Type != newRecordSet.Spec.Type {
+		errors = append(
+			errors,
+			field.Forbidden(
+				field.NewPath("spec", "type"),
+				"is immutable and cannot be changed after creation",
+			),
+		)
+	}
+
+	// Validate the records
+	errors = append(errors, validateRecordSetRecords(newRecordSet.Spec)...)
+
+	// Validate WriteConnectionDetailsToRef
+	if err := validateConnectionDetailsRef(newRecordSet.Spec.WriteConnectionDetailsToRef); err != nil {
+		errors = append(errors, err)
+	}
+
+	// Warn if orphanOnDelete is being changed from false to true
+	if !oldRecordSet.Spec.OrphanOnDelete && newRecordSet.Spec.OrphanOnDelete {
+		warnings = append(
+			warnings,
+			"orphanOnDelete changed to true: external record set will not be deleted when this resource is deleted",
+		)
+	}
+
+	// Warn if orphanOnDelete is being changed from true to false
+	if oldRecordSet.Spec.OrphanOnDelete && !newRecordSet.Spec.OrphanOnDelete {
+		warnings = append(
+			warnings,
+			"orphanOnDelete changed to false: external record set will be deleted when this resource is deleted",
+		)
+	}
+
+	if len(errors) == 0 {
+		return warnings, nil
+	}
+
+	return warnings, apierrors.NewInvalid(
+		oldRecordSet.GroupVersionKind().GroupKind(),
+		oldRecordSet.Name,
+		errors,
+	)
+}
+
+// ValidateDelete implements webhook.CustomValidator so a webhook will be registered for the type RecordSet.
+func (v *RecordSetCustomValidator) ValidateDelete(
+	ctx context.Context,
+	obj runtime.Object,
+) (admission.Warnings, error) {
+	return nil, nil
+}
+
+func validateRecordSetRecords(spec otcv1alpha1.RecordSetSpec) field.ErrorList {
+	var errors field.ErrorList
+
+	if len(spec.Records) == 0 && len(spec.RecordsFrom) == 0 {
+		errors = append(
+			errors,
+			field.Required(
+				field.NewPath("spec", "records"),
+				"at least one of records or recordsFrom must be specified",
+			),
+		)
+		return errors
+	}
+
+	// The values of A records are validated, other types are passed to the
+	// provider as they are.
+	if spec.Type == otcv1alpha1.RecordSetA {
+		for i, record := range spec.Records {
+			if err := validateIPv4(record); err != nil {
+				errors = append(
+					errors,
+					field.Invalid(
+						field.NewPath("spec", "records").Index(i),
+						record,
+						err.Error(),
+					),
+				)
+			}
+		}
+	}
+
+	// Referenced resources only provide IPv4 addresses.
+	path := field.NewPath("spec", "recordsFrom")
+	if len(spec.RecordsFrom) > 0 && spec.Type != otcv1alpha1.RecordSetA {
+		errors = append(
+			errors,
+			field.Forbidden(path, "can only be used with A records"),
+		)
+	}
+	for i, source := range spec.RecordsFrom {
+		if err := validateRecordSetValueSource(source); err != nil {
+			errors = append(
+				errors,
+				field.Invalid(
+					path.Index(i),
+					source,
+					err.Error(),
+				),
+			)
+		}
+	}
+
+	return errors
+}
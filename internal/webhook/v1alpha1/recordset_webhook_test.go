@@ -0,0 +1,132 @@
+package v1alpha1
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	otcv1alpha1 "github.com/peertech.de/otc-operator/api/v1alpha1"
+)
+
+func newTestRecordSet() *otcv1alpha1.RecordSet {
+	return &otcv1alpha1.RecordSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "www"},
+		Spec: otcv1alpha1.RecordSetSpec{
+			ProviderConfigRef: otcv1alpha1.ProviderConfigReference{Name: "provider"},
+			Zone: otcv1alpha1.DNSZoneDependency{
+				DNSZoneRef: &corev1.LocalObjectReference{Name: "zone"},
+			},
+			Name:    "www.example.internal.",
+			Type:    otcv1alpha1.RecordSetA,
+			Records: []string{"10.0.0.10"},
+		},
+	}
+}
+
+func TestRecordSetValidateCreate(t *testing.T) {
+	tests := []struct {
+		name    string
+		update  func(*otcv1alpha1.RecordSet)
+		wantErr bool
+	}{
+		{
+			name:   "valid",
+			update: func(*otcv1alpha1.RecordSet) {},
+		},
+		{
+			name: "records from public IP",
+			update: func(recordSet *otcv1alpha1.RecordSet) {
+				recordSet.Spec.RecordsFrom = []otcv1alpha1.RecordSetValueSource{{
+					PublicIPRef: &corev1.LocalObjectReference{Name: "public-ip"},
+				}}
+			},
+		},
+		{
+			name: "no zone",
+			update: func(recordSet *otcv1alpha1.RecordSet) {
+				recordSet.Spec.Zone = otcv1alpha1.DNSZoneDependency{}
+			},
+			wantErr: true,
+		},
+		{
+			name: "no records",
+			update: func(recordSet *otcv1alpha1.RecordSet) {
+				recordSet.Spec.Records = nil
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid A record",
+			update: func(recordSet *otcv1alpha1.RecordSet) {
+				recordSet.Spec.Records = []string{"www.example.org."}
+			},
+			wantErr: true,
+		},
+		{
+			name: "records from public IP for CNAME",
+			update: func(recordSet *otcv1alpha1.RecordSet) {
+				recordSet.Spec.Type = otcv1alpha1.RecordSetCNAME
+				recordSet.Spec.Records = nil
+				recordSet.Spec.RecordsFrom = []otcv1alpha1.RecordSetValueSource{{
+					PublicIPRef: &corev1.LocalObjectReference{Name: "public-ip"},
+				}}
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recordSet := newTestRecordSet()
+			tt.update(recordSet)
+
+			_, err := (&RecordSetCustomValidator{}).ValidateCreate(context.Background(), recordSet)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Expected error %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestRecordSetValidateUpdate(t *testing.T) {
+	tests := []struct {
+		name    string
+		update  func(*otcv1alpha1.RecordSet)
+		wantErr bool
+	}{
+		{
+			name: "records and TTL",
+			update: func(recordSet *otcv1alpha1.RecordSet) {
+				recordSet.Spec.Records = []string{"10.0.0.11"}
+				recordSet.Spec.TTL = 600
+			},
+		},
+		{
+			name: "zone",
+			update: func(recordSet *otcv1alpha1.RecordSet) {
+				recordSet.Spec.Zone.DNSZoneRef.Name = "other"
+			},
+			wantErr: true,
+		},
+		{
+			name: "name",
+			update: func(recordSet *otcv1alpha1.RecordSet) {
+				recordSet.Spec.Name = "api.example.internal."
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			oldRecordSet := newTestRecordSet()
+			newRecordSet := newTestRecordSet()
+			tt.update(newRecordSet)
+
+			_, err := (&RecordSetCustomValidator{}).ValidateUpdate(context.Background(), oldRecordSet, newRecordSet)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Expected error %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
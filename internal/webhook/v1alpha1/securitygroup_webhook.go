@@ -59,6 +59,11 @@ func (v *SecurityGroupCustomValidator) ValidateCreate(
 		errors = append(errors, err)
 	}
 
+	// Validate WriteConnectionDetailsToRef
+	if err := validateConnectionDetailsRef(securityGroup.Spec.WriteConnectionDetailsToRef); err != nil {
+		errors = append(errors, err)
+	}
+
 	// Warn about orphanOnDelete if true
 	if securityGroup.Spec.OrphanOnDelete {
 		warnings = append(
@@ -109,6 +114,11 @@ func (v *SecurityGroupCustomValidator) ValidateUpdate(
 		)
 	}
 
+	// Validate WriteConnectionDetailsToRef
+	if err := validateConnectionDetailsRef(newSecurityGroup.Spec.WriteConnectionDetailsToRef); err != nil {
+		errors = append(errors, err)
+	}
+
 	// Warn if orphanOnDelete is being changed from false to true
 	if !oldSecurityGroup.Spec.OrphanOnDelete && newSecurityGroup.Spec.OrphanOnDelete {
 		warnings = append(
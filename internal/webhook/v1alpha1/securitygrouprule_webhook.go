@@ -69,6 +69,11 @@ func (v *SecurityGroupRuleCustomValidator) ValidateCreate(
 		)
 	}
 
+	// Validate WriteConnectionDetailsToRef
+	if err := validateConnectionDetailsRef(securityGroupRule.Spec.WriteConnectionDetailsToRef); err != nil {
+		errors = append(errors, err)
+	}
+
 	// Warn about orphanOnDelete if true
 	if securityGroupRule.Spec.OrphanOnDelete {
 		warnings = append(
@@ -139,6 +144,11 @@ func (v *SecurityGroupRuleCustomValidator) ValidateUpdate(
 		)
 	}
 
+	// Validate WriteConnectionDetailsToRef
+	if err := validateConnectionDetailsRef(newSecurityGroupRule.Spec.WriteConnectionDetailsToRef); err != nil {
+		errors = append(errors, err)
+	}
+
 	// Warn if orphanOnDelete is being changed from false to true
 	if !oldSecurityGroupRule.Spec.OrphanOnDelete && newSecurityGroupRule.Spec.OrphanOnDelete {
 		warnings = append(
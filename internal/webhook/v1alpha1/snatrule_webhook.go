@@ -93,6 +93,11 @@ func (v *SNATRuleCustomValidator) ValidateCreate(
 		)
 	}
 
+	// Validate WriteConnectionDetailsToRef
+	if err := validateConnectionDetailsRef(snatRule.Spec.WriteConnectionDetailsToRef); err != nil {
+		errors = append(errors, err)
+	}
+
 	// Warn about orphanOnDelete if true
 	if snatRule.Spec.OrphanOnDelete {
 		warnings = append(
@@ -176,6 +181,11 @@ func (v *SNATRuleCustomValidator) ValidateUpdate(
 		)
 	}
 
+	// Validate WriteConnectionDetailsToRef
+	if err := validateConnectionDetailsRef(newSNATRule.Spec.WriteConnectionDetailsToRef); err != nil {
+		errors = append(errors, err)
+	}
+
 	// Warn if orphanOnDelete is being changed from false to true
 	if !oldSNATRule.Spec.OrphanOnDelete && newSNATRule.Spec.OrphanOnDelete {
 		warnings = append(
@@ -0,0 +1,213 @@
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	otcv1alpha1 "github.com/peertech.de/otc-operator/api/v1alpha1"
+)
+
+// SetupSNATRuleSetWebhookWithManager registers the webhook for SNATRuleSet in the manager.
+func SetupSNATRuleSetWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).For(&otcv1alpha1.SNATRuleSet{}).
+		WithValidator(&SNATRuleSetCustomValidator{}).
+		Complete()
+}
+
+// TODO(user): change verbs to "verbs=create;update;delete" if you want to enable deletion validation.
+// +kubebuilder:webhook:path=/validate-otc-peertech-de-v1alpha1-snatruleset,mutating=false,failurePolicy=fail,sideEffects=None,groups=otc.peertech.de,resources=snatrulesets,verbs=create;update,versions=v1alpha1,name=vsnatruleset-v1alpha1.kb.io,admissionReviewVersions=v1
+
+// SNATRuleSetCustomValidator struct is responsible for validating the SNATRuleSet resource
+// when it is created, updated, or deleted.
+type SNATRuleSetCustomValidator struct{}
+
+var _ webhook.CustomValidator = &SNATRuleSetCustomValidator{}
+
+// ValidateCreate implements webhook.CustomValidator so a webhook will be registered for the type SNATRuleSet.
+func (v *SNATRuleSetCustomValidator) ValidateCreate(
+	_ context.Context,
+	obj runtime.Object,
+) (admission.Warnings, error) {
+	snatRuleSet, ok := obj.(*otcv1alpha1.SNATRuleSet)
+	if !ok {
+		return nil, fmt.Errorf("expected a SNATRuleSet object but got %T", obj)
+	}
+
+	var warnings admission.Warnings
+	var errors field.ErrorList
+
+	// Validate the resource name
+	if !validName.MatchString(snatRuleSet.Name) {
+		errors = append(errors, field.Invalid(
+			field.NewPath("metadata", "name"),
+			snatRuleSet.Name,
+			"name must contain only letters, digits, underscores (_), hyphens (-), and periods (.)",
+		))
+	}
+
+	// Validate ProviderConfigRef
+	if err := validateProviderConfigRefName(snatRuleSet.Spec.ProviderConfigRef); err != nil {
+		errors = append(errors, err)
+	}
+
+	// Validate that exactly one NAT gateway dependency method is specified
+	if err := validateNATGatewayDependency(snatRuleSet.Spec.NATGateway); err != nil {
+		errors = append(
+			errors,
+			field.Invalid(
+				field.NewPath("spec", "natGateway"),
+				snatRuleSet.Spec.NATGateway,
+				err.Error(),
+			),
+		)
+	}
+
+	errors = append(errors, validateSNATRuleSetSpec(snatRuleSet.Spec)...)
+
+	// Warn about orphanOnDelete if true
+	if snatRuleSet.Spec.OrphanOnDelete {
+		warnings = append(
+			warnings,
+			"orphanOnDelete is true: external SNAT rules will not be deleted when this resource is deleted",
+		)
+	}
+
+	if len(errors) == 0 {
+		return warnings, nil
+	}
+
+	return warnings, apierrors.NewInvalid(
+		snatRuleSet.GroupVersionKind().GroupKind(),
+		snatRuleSet.Name,
+		errors,
+	)
+}
+
+// ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the type SNATRuleSet.
+func (v *SNATRuleSetCustomValidator) ValidateUpdate(
+	_ context.Context,
+	oldObj, newObj runtime.Object,
+) (admission.Warnings, error) {
+	oldSNATRuleSet, ok := oldObj.(*otcv1alpha1.SNATRuleSet)
+	if !ok {
+		return nil, fmt.Errorf("expected a SNATRuleSet object for the oldObj but got %T", oldObj)
+	}
+	newSNATRuleSet, ok := newObj.(*otcv1alpha1.SNATRuleSet)
+	if !ok {
+		return nil, fmt.Errorf("expected a SNATRuleSet object for the newObj but got %T", newObj)
+	}
+
+	var warnings admission.Warnings
+	var errors field.ErrorList
+
+	// Check immutable ProviderConfigRef
+	if !equalProviderConfigRef(
+		oldSNATRuleSet.Spec.ProviderConfigRef,
+		newSNATRuleSet.Spec.ProviderConfigRef,
+	) {
+		errors = append(
+			errors,
+			field.Forbidden(
+				field.NewPath("spec", "providerConfigRef"),
+				"is immutable and cannot be changed after creation",
+			),
+		)
+	}
+
+	// Check immutable NAT gateway dependency
+	if !equalNATGatewayDependency(oldSNATRuleSet.Spec.NATGateway, newSNATRuleSet.Spec.NATGateway) {
+		errors = append(
+			errors,
+			field.Forbidden(
+				field.NewPath("spec", "natGateway"),
+				"is immutable and cannot be changed after creation",
+			),
+		)
+	}
+
+	// The subnet selector and the public IP pool can be changed, the SNAT
+	// rules follow them.
+	errors = append(errors, validateSNATRuleSetSpec(newSNATRuleSet.Spec)...)
+
+	// Warn if orphanOnDelete is being changed from false to true
+	if !oldSNATRuleSet.Spec.OrphanOnDelete && newSNATRuleSet.Spec.OrphanOnDelete {
+		warnings = append(
+			warnings,
+			"orphanOnDelete changed to true: external SNAT rules will not be deleted when this resource is deleted",
+		)
+	}
+
+	// Warn if orphanOnDelete is being changed from true to false
+	if oldSNATRuleSet.Spec.OrphanOnDelete && !newSNATRuleSet.Spec.OrphanOnDelete {
+		warnings = append(
+			warnings,
+			"orphanOnDelete changed to false: external SNAT rules will be deleted when this resource is deleted",
+		)
+	}
+
+	if len(errors) == 0 {
+		return warnings, nil
+	}
+
+	return warnings, apierrors.NewInvalid(
+		oldSNATRuleSet.GroupVersionKind().GroupKind(),
+		oldSNATRuleSet.Name,
+		errors,
+	)
+}
+
+// ValidateDelete implements webhook.CustomValidator so a webhook will be registered for the type SNATRuleSet.
+func (v *SNATRuleSetCustomValidator) ValidateDelete(
+	ctx context.Context,
+	obj runtime.Object,
+) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// validateSNATRuleSetSpec validates the subnet selector and the public IP
+// pool of a SNATRuleSet.
+func validateSNATRuleSetSpec(spec otcv1alpha1.SNATRuleSetSpec) field.ErrorList {
+	var errors field.ErrorList
+
+	// Validate the subnet selector
+	selector := otcv1alpha1.ResourceSelector{LabelSelector: spec.SubnetSelector}
+	if err := validateResourceSelector(selector); err != nil {
+		errors = append(
+			errors,
+			field.Invalid(
+				field.NewPath("spec", "subnetSelector"),
+				spec.SubnetSelector,
+				err.Error(),
+			),
+		)
+	}
+
+	// Validate that exactly one dependency method is specified for each public IP
+	if len(spec.PublicIPs) == 0 {
+		errors = append(errors, field.Required(
+			field.NewPath("spec", "publicIPs"),
+			"at least one public IP must be specified",
+		))
+	}
+	for i, publicIP := range spec.PublicIPs {
+		if err := validatePublicIPDependency(publicIP); err != nil {
+			errors = append(
+				errors,
+				field.Invalid(
+					field.NewPath("spec", "publicIPs").Index(i),
+					publicIP,
+					err.Error(),
+				),
+			)
+		}
+	}
+
+	return errors
+}
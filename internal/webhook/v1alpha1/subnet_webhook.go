@@ -100,6 +100,11 @@ func (v *SubnetCustomValidator) ValidateCreate(
 		)
 	}
 
+	// Validate WriteConnectionDetailsToRef
+	if err := validateConnectionDetailsRef(subnet.Spec.WriteConnectionDetailsToRef); err != nil {
+		errors = append(errors, err)
+	}
+
 	// Warn about orphanOnDelete if true
 	if subnet.Spec.OrphanOnDelete {
 		warnings = append(
@@ -183,6 +188,11 @@ func (v *SubnetCustomValidator) ValidateUpdate(
 		)
 	}
 
+	// Validate WriteConnectionDetailsToRef
+	if err := validateConnectionDetailsRef(newSubnet.Spec.WriteConnectionDetailsToRef); err != nil {
+		errors = append(errors, err)
+	}
+
 	// Warn if orphanOnDelete is being changed from false to true
 	if !oldSubnet.Spec.OrphanOnDelete && newSubnet.Spec.OrphanOnDelete {
 		warnings = append(
@@ -0,0 +1,239 @@
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+	"net/mail"
+	"net/url"
+	"regexp"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	otcv1alpha1 "github.com/peertech.de/otc-operator/api/v1alpha1"
+)
+
+// validPhoneNumber matches phone numbers with a country code.
+var validPhoneNumber = regexp.MustCompile(`^\+[1-9][0-9]{6,14}$`)
+
+// SetupSubscriptionWebhookWithManager registers the webhook for Subscription in the manager.
+func SetupSubscriptionWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).For(&otcv1alpha1.Subscription{}).
+		WithValidator(&SubscriptionCustomValidator{}).
+		Complete()
+}
+
+// TODO(user): change verbs to "verbs=create;update;delete" if you want to enable deletion validation.
+// +kubebuilder:webhook:path=/validate-otc-peertech-de-v1alpha1-subscription,mutating=false,failurePolicy=fail,sideEffects=None,groups=otc.peertech.de,resources=subscriptions,verbs=create;update,versions=v1alpha1,name=vsubscription-v1alpha1.kb.io,admissionReviewVersions=v1
+
+// SubscriptionCustomValidator struct is responsible for validating the Subscription resource
+// when it is created, updated, or deleted.
+type SubscriptionCustomValidator struct{}
+
+var _ webhook.CustomValidator = &SubscriptionCustomValidator{}
+
+// ValidateCreate implements webhook.CustomValidator so a webhook will be registered for the type Subscription.
+func (v *SubscriptionCustomValidator) ValidateCreate(
+	_ context.Context,
+	obj runtime.Object,
+) (admission.Warnings, error) {
+	subscription, ok := obj.(*otcv1alpha1.Subscription)
+	if !ok {
+		return nil, fmt.Errorf("expected a Subscription object but got %T", obj)
+	}
+
+	var warnings admission.Warnings
+	var errors field.ErrorList
+
+	// Validate the resource name
+	if !validName.MatchString(subscription.Name) {
+		errors = append(errors, field.Invalid(
+			field.NewPath("metadata", "name"),
+			subscription.Name,
+			"name must contain only letters, digits, underscores (_), hyphens (-), and periods (.)",
+		))
+	}
+
+	// Validate ProviderConfigRef
+	if err := validateProviderConfigRefName(subscription.Spec.ProviderConfigRef); err != nil {
+		errors = append(errors, err)
+	}
+
+	// Validate that exactly one topic dependency method is specified
+	if err := validateTopicDependency(subscription.Spec.Topic); err != nil {
+		errors = append(
+			errors,
+			field.Invalid(
+				field.NewPath("spec", "topic"),
+				subscription.Spec.Topic,
+				err.Error(),
+			),
+		)
+	}
+
+	// Validate the endpoint for the protocol
+	if err := validateSubscriptionEndpoint(subscription.Spec); err != nil {
+		errors = append(errors, err)
+	}
+
+	// Validate WriteConnectionDetailsToRef
+	if err := validateConnectionDetailsRef(subscription.Spec.WriteConnectionDetailsToRef); err != nil {
+		errors = append(errors, err)
+	}
+
+	// Warn that the endpoint has to confirm the subscription
+	warnings = append(
+		warnings,
+		fmt.Sprintf(
+			"the subscription is only ready once %s confirmed it",
+			subscription.Spec.Endpoint,
+		),
+	)
+
+	// Warn about orphanOnDelete if true
+	if subscription.Spec.OrphanOnDelete {
+		warnings = append(
+			warnings,
+			"orphanOnDelete is true: external subscription will not be deleted when this resource is deleted",
+		)
+	}
+
+	if len(errors) == 0 {
+		return warnings, nil
+	}
+
+	return warnings, apierrors.NewInvalid(
+		subscription.GroupVersionKind().GroupKind(),
+		subscription.Name,
+		errors,
+	)
+}
+
+// ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the type Subscription.
+func (v *SubscriptionCustomValidator) ValidateUpdate(
+	_ context.Context,
+	oldObj, newObj runtime.Object,
+) (admission.Warnings, error) {
+	oldSubscription, ok := oldObj.(*otcv1alpha1.Subscription)
+	if !ok {
+		return nil, fmt.Errorf("expected a Subscription object for the oldObj but got %T", oldObj)
+	}
+	newSubscription, ok := newObj.(*otcv1alpha1.Subscription)
+	if !ok {
+		return nil, fmt.Errorf("expected a Subscription object for the newObj but got %T", newObj)
+	}
+
+	var warnings admission.Warnings
+	var errors field.ErrorList
+
+	oldSpec := oldSubscription.Spec
+	newSpec := newSubscription.Spec
+
+	// Check immutable ProviderConfigRef
+	if !equalProviderConfigRef(oldSpec.ProviderConfigRef, newSpec.ProviderConfigRef) {
+		errors = append(
+			errors,
+			field.Forbidden(
+				field.NewPath("spec", "providerConfigRef"),
+				"is immutable and cannot be changed after creation",
+			),
+		)
+	}
+
+	// Check the fields SMN can not update
+	immutable := []struct {
+		name  string
+		equal bool
+	}{
+		{"topic", equalTopicDependency(oldSpec.Topic, newSpec.Topic)},
+		{"protocol", oldSpec.Protocol == newSpec.Protocol},
+		{"endpoint", oldSpec.Endpoint == newSpec.Endpoint},
+		{"remark", oldSpec.Remark == newSpec.Remark},
+	}
+	for _, f := range immutable {
+		if !f.equal {
+			errors = append(
+				errors,
+				field.Forbidden(
+					field.NewPath("spec", f.name),
+					"is immutable and cannot be changed after creation",
+				),
+			)
+		}
+	}
+
+	// Validate WriteConnectionDetailsToRef
+	if err := validateConnectionDetailsRef(newSpec.WriteConnectionDetailsToRef); err != nil {
+		errors = append(errors, err)
+	}
+
+	// Warn if orphanOnDelete is being changed from false to true
+	if !oldSpec.OrphanOnDelete && newSpec.OrphanOnDelete {
+		warnings = append(
+			warnings,
+			"orphanOnDelete changed to true: external subscription will not be deleted when this resource is deleted",
+		)
+	}
+
+	// Warn if orphanOnDelete is being changed from true to false
+	if oldSpec.OrphanOnDelete && !newSpec.OrphanOnDelete {
+		warnings = append(
+			warnings,
+			"orphanOnDelete changed to false: external subscription will be deleted when this resource is deleted",
+		)
+	}
+
+	if len(errors) == 0 {
+		return warnings, nil
+	}
+
+	return warnings, apierrors.NewInvalid(
+		oldSubscription.GroupVersionKind().GroupKind(),
+		oldSubscription.Name,
+		errors,
+	)
+}
+
+// ValidateDelete implements webhook.CustomValidator so a webhook will be registered for the type Subscription.
+func (v *SubscriptionCustomValidator) ValidateDelete(
+	ctx context.Context,
+	obj runtime.Object,
+) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// validateSubscriptionEndpoint checks that the endpoint is an address of the
+// protocol.
+func validateSubscriptionEndpoint(spec otcv1alpha1.SubscriptionSpec) *field.Error {
+	path := field.NewPath("spec", "endpoint")
+
+	switch spec.Protocol {
+	case otcv1alpha1.SubscriptionProtocolEmail:
+		if _, err := mail.ParseAddress(spec.Endpoint); err != nil {
+			return field.Invalid(path, spec.Endpoint, "must be an email address")
+		}
+	case otcv1alpha1.SubscriptionProtocolHTTP, otcv1alpha1.SubscriptionProtocolHTTPS:
+		u, err := url.Parse(spec.Endpoint)
+		if err != nil || u.Scheme != string(spec.Protocol) || u.Host == "" {
+			return field.Invalid(
+				path,
+				spec.Endpoint,
+				fmt.Sprintf("must be a URL starting with %s://", spec.Protocol),
+			)
+		}
+	case otcv1alpha1.SubscriptionProtocolSMS:
+		if !validPhoneNumber.MatchString(spec.Endpoint) {
+			return field.Invalid(
+				path,
+				spec.Endpoint,
+				"must be a phone number with country code (e.g. +4915112345678)",
+			)
+		}
+	}
+
+	return nil
+}
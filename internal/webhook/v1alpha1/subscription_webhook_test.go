@@ -0,0 +1,146 @@
+package v1alpha1
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	otcv1alpha1 "github.com/peertech.de/otc-operator/api/v1alpha1"
+)
+
+func newTestSubscription() *otcv1alpha1.Subscription {
+	return &otcv1alpha1.Subscription{
+		ObjectMeta: metav1.ObjectMeta{Name: "oncall"},
+		Spec: otcv1alpha1.SubscriptionSpec{
+			ProviderConfigRef: otcv1alpha1.ProviderConfigReference{Name: "provider"},
+			Topic: otcv1alpha1.TopicDependency{
+				TopicRef: &corev1.LocalObjectReference{Name: "alerts"},
+			},
+			Protocol: otcv1alpha1.SubscriptionProtocolEmail,
+			Endpoint: "oncall@example.org",
+		},
+	}
+}
+
+func TestSubscriptionValidateCreate(t *testing.T) {
+	tests := []struct {
+		name    string
+		update  func(*otcv1alpha1.Subscription)
+		wantErr bool
+	}{
+		{
+			name:   "email endpoint",
+			update: func(*otcv1alpha1.Subscription) {},
+		},
+		{
+			name: "HTTPS endpoint",
+			update: func(subscription *otcv1alpha1.Subscription) {
+				subscription.Spec.Protocol = otcv1alpha1.SubscriptionProtocolHTTPS
+				subscription.Spec.Endpoint = "https://hooks.example.org/alerts"
+			},
+		},
+		{
+			name: "SMS endpoint",
+			update: func(subscription *otcv1alpha1.Subscription) {
+				subscription.Spec.Protocol = otcv1alpha1.SubscriptionProtocolSMS
+				subscription.Spec.Endpoint = "+4915112345678"
+			},
+		},
+		{
+			name: "invalid email endpoint",
+			update: func(subscription *otcv1alpha1.Subscription) {
+				subscription.Spec.Endpoint = "oncall"
+			},
+			wantErr: true,
+		},
+		{
+			name: "HTTP endpoint for HTTPS",
+			update: func(subscription *otcv1alpha1.Subscription) {
+				subscription.Spec.Protocol = otcv1alpha1.SubscriptionProtocolHTTPS
+				subscription.Spec.Endpoint = "http://hooks.example.org/alerts"
+			},
+			wantErr: true,
+		},
+		{
+			name: "SMS endpoint without country code",
+			update: func(subscription *otcv1alpha1.Subscription) {
+				subscription.Spec.Protocol = otcv1alpha1.SubscriptionProtocolSMS
+				subscription.Spec.Endpoint = "015112345678"
+			},
+			wantErr: true,
+		},
+		{
+			name: "topic URN and reference",
+			update: func(subscription *otcv1alpha1.Subscription) {
+				topicURN := "urn:smn:eu-de:project:alerts"
+				subscription.Spec.Topic.TopicURN = &topicURN
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			subscription := newTestSubscription()
+			tt.update(subscription)
+
+			_, err := (&SubscriptionCustomValidator{}).ValidateCreate(context.Background(), subscription)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Expected error %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestSubscriptionValidateUpdate(t *testing.T) {
+	tests := []struct {
+		name    string
+		update  func(*otcv1alpha1.Subscription)
+		wantErr bool
+	}{
+		{
+			name: "orphan on delete",
+			update: func(subscription *otcv1alpha1.Subscription) {
+				subscription.Spec.OrphanOnDelete = true
+			},
+		},
+		{
+			name: "endpoint",
+			update: func(subscription *otcv1alpha1.Subscription) {
+				subscription.Spec.Endpoint = "other@example.org"
+			},
+			wantErr: true,
+		},
+		{
+			name: "topic",
+			update: func(subscription *otcv1alpha1.Subscription) {
+				subscription.Spec.Topic.TopicRef.Name = "other-alerts"
+			},
+			wantErr: true,
+		},
+		{
+			name: "provider config",
+			update: func(subscription *otcv1alpha1.Subscription) {
+				subscription.Spec.ProviderConfigRef.Name = "other-provider"
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			oldSubscription := newTestSubscription()
+			newSubscription := newTestSubscription()
+			tt.update(newSubscription)
+
+			_, err := (&SubscriptionCustomValidator{}).ValidateUpdate(
+				context.Background(),
+				oldSubscription,
+				newSubscription,
+			)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Expected error %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,187 @@
+package v1alpha1
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	otcv1alpha1 "github.com/peertech.de/otc-operator/api/v1alpha1"
+)
+
+// validTopicName matches names of up to 255 letters, digits, hyphens and
+// underscores, which start with a letter or digit.
+var validTopicName = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9_-]{0,254}$`)
+
+// SetupTopicWebhookWithManager registers the webhook for Topic in the manager.
+func SetupTopicWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).For(&otcv1alpha1.Topic{}).
+		WithValidator(&TopicCustomValidator{}).
+		Complete()
+}
+
+// TODO(user): change verbs to "verbs=create;update;delete" if you want to enable deletion validation.
+// +kubebuilder:webhook:path=/validate-otc-peertech-de-v1alpha1-topic,mutating=false,failurePolicy=fail,sideEffects=None,groups=otc.peertech.de,resources=topics,verbs=create;update,versions=v1alpha1,name=vtopic-v1alpha1.kb.io,admissionReviewVersions=v1
+
+// TopicCustomValidator struct is responsible for validating the Topic resource
+// when it is created, updated, or deleted.
+type TopicCustomValidator struct{}
+
+var _ webhook.CustomValidator = &TopicCustomValidator{}
+
+// ValidateCreate implements webhook.CustomValidator so a webhook will be registered for the type Topic.
+func (v *TopicCustomValidator) ValidateCreate(
+	_ context.Context,
+	obj runtime.Object,
+) (admission.Warnings, error) {
+	topic, ok := obj.(*otcv1alpha1.Topic)
+	if !ok {
+		return nil, fmt.Errorf("expected a Topic object but got %T", obj)
+	}
+
+	var warnings admission.Warnings
+	var errors field.ErrorList
+
+	// Validate the resource name, which is the name of the topic
+	if !validTopicName.MatchString(topic.Name) {
+		errors = append(errors, field.Invalid(
+			field.NewPath("metadata", "name"),
+			topic.Name,
+			"name must be 1 to 255 characters of letters, digits, underscores (_) and hyphens (-), "+
+				"and start with a letter or digit",
+		))
+	}
+
+	// Validate ProviderConfigRef
+	if err := validateProviderConfigRefName(topic.Spec.ProviderConfigRef); err != nil {
+		errors = append(errors, err)
+	}
+
+	// Validate the policy
+	if err := validateTopicPolicy(topic.Spec.Policy); err != nil {
+		errors = append(errors, err)
+	}
+
+	// Validate WriteConnectionDetailsToRef
+	if err := validateConnectionDetailsRef(topic.Spec.WriteConnectionDetailsToRef); err != nil {
+		errors = append(errors, err)
+	}
+
+	// Warn about orphanOnDelete if true
+	if topic.Spec.OrphanOnDelete {
+		warnings = append(
+			warnings,
+			"orphanOnDelete is true: external topic will not be deleted when this resource is deleted",
+		)
+	}
+
+	if len(errors) == 0 {
+		return warnings, nil
+	}
+
+	return warnings, apierrors.NewInvalid(
+		topic.GroupVersionKind().GroupKind(),
+		topic.Name,
+		errors,
+	)
+}
+
+// ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the type Topic.
+func (v *TopicCustomValidator) ValidateUpdate(
+	_ context.Context,
+	oldObj, newObj runtime.Object,
+) (admission.Warnings, error) {
+	oldTopic, ok := oldObj.(*otcv1alpha1.Topic)
+	if !ok {
+		return nil, fmt.Errorf("expected a Topic object for the oldObj but got %T", oldObj)
+	}
+	newTopic, ok := newObj.(*otcv1alpha1.Topic)
+	if !ok {
+		return nil, fmt.Errorf("expected a Topic object for the newObj but got %T", newObj)
+	}
+
+	var warnings admission.Warnings
+	var errors field.ErrorList
+
+	// Check immutable ProviderConfigRef
+	if !equalProviderConfigRef(
+		oldTopic.Spec.ProviderConfigRef,
+		newTopic.Spec.ProviderConfigRef,
+	) {
+		errors = append(
+			errors,
+			field.Forbidden(
+				field.NewPath("spec", "providerConfigRef"),
+				"is immutable and cannot be changed after creation",
+			),
+		)
+	}
+
+	// Validate the policy
+	if err := validateTopicPolicy(newTopic.Spec.Policy); err != nil {
+		errors = append(errors, err)
+	}
+
+	// Validate WriteConnectionDetailsToRef
+	if err := validateConnectionDetailsRef(newTopic.Spec.WriteConnectionDetailsToRef); err != nil {
+		errors = append(errors, err)
+	}
+
+	// Warn if orphanOnDelete is being changed from false to true
+	if !oldTopic.Spec.OrphanOnDelete && newTopic.Spec.OrphanOnDelete {
+		warnings = append(
+			warnings,
+			"orphanOnDelete changed to true: external topic will not be deleted when this resource is deleted",
+		)
+	}
+
+	// Warn if orphanOnDelete is being changed from true to false
+	if oldTopic.Spec.OrphanOnDelete && !newTopic.Spec.OrphanOnDelete {
+		warnings = append(
+			warnings,
+			"orphanOnDelete changed to false: external topic will be deleted when this resource is deleted",
+		)
+	}
+
+	if len(errors) == 0 {
+		return warnings, nil
+	}
+
+	return warnings, apierrors.NewInvalid(
+		oldTopic.GroupVersionKind().GroupKind(),
+		oldTopic.Name,
+		errors,
+	)
+}
+
+// ValidateDelete implements webhook.CustomValidator so a webhook will be registered for the type Topic.
+func (v *TopicCustomValidator) ValidateDelete(
+	ctx context.Context,
+	obj runtime.Object,
+) (admission.Warnings, error) {
+	return nil, nil
+}
+
+func validateTopicPolicy(policy string) *field.Error {
+	if policy == "" {
+		return nil
+	}
+
+	var doc map[string]any
+	if err := json.Unmarshal([]byte(policy), &doc); err != nil {
+		return field.Invalid(
+			field.NewPath("spec", "policy"),
+			policy,
+			fmt.Sprintf("must be a JSON object: %v", err),
+		)
+	}
+
+	return nil
+}
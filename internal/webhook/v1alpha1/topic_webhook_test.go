@@ -0,0 +1,107 @@
+package v1alpha1
+
+import (
+	"context"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	otcv1alpha1 "github.com/peertech.de/otc-operator/api/v1alpha1"
+)
+
+func newTestTopic() *otcv1alpha1.Topic {
+	return &otcv1alpha1.Topic{
+		ObjectMeta: metav1.ObjectMeta{Name: "alerts"},
+		Spec: otcv1alpha1.TopicSpec{
+			ProviderConfigRef: otcv1alpha1.ProviderConfigReference{Name: "provider"},
+			DisplayName:       "Alerts",
+			Policy:            `{"Version": "2016-09-07", "Statement": []}`,
+		},
+	}
+}
+
+func TestTopicValidateCreate(t *testing.T) {
+	tests := []struct {
+		name    string
+		update  func(*otcv1alpha1.Topic)
+		wantErr bool
+	}{
+		{
+			name:   "topic with policy",
+			update: func(*otcv1alpha1.Topic) {},
+		},
+		{
+			name: "topic without policy",
+			update: func(topic *otcv1alpha1.Topic) {
+				topic.Spec.Policy = ""
+			},
+		},
+		{
+			name: "name starting with a hyphen",
+			update: func(topic *otcv1alpha1.Topic) {
+				topic.Name = "-alerts"
+			},
+			wantErr: true,
+		},
+		{
+			name: "policy that is not a JSON object",
+			update: func(topic *otcv1alpha1.Topic) {
+				topic.Spec.Policy = `["Statement"]`
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			topic := newTestTopic()
+			tt.update(topic)
+
+			_, err := (&TopicCustomValidator{}).ValidateCreate(context.Background(), topic)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Expected error %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestTopicValidateUpdate(t *testing.T) {
+	tests := []struct {
+		name    string
+		update  func(*otcv1alpha1.Topic)
+		wantErr bool
+	}{
+		{
+			name: "display name and policy",
+			update: func(topic *otcv1alpha1.Topic) {
+				topic.Spec.DisplayName = "Critical alerts"
+				topic.Spec.Policy = ""
+			},
+		},
+		{
+			name: "provider config",
+			update: func(topic *otcv1alpha1.Topic) {
+				topic.Spec.ProviderConfigRef.Name = "other-provider"
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid policy",
+			update: func(topic *otcv1alpha1.Topic) {
+				topic.Spec.Policy = "{"
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			oldTopic := newTestTopic()
+			newTopic := newTestTopic()
+			tt.update(newTopic)
+
+			_, err := (&TopicCustomValidator{}).ValidateUpdate(context.Background(), oldTopic, newTopic)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Expected error %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
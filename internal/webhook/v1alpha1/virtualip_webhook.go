@@ -0,0 +1,201 @@
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	otcv1alpha1 "github.com/peertech.de/otc-operator/api/v1alpha1"
+)
+
+// SetupVirtualIPWebhookWithManager registers the webhook for VirtualIP in the manager.
+func SetupVirtualIPWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).For(&otcv1alpha1.VirtualIP{}).
+		WithValidator(&VirtualIPCustomValidator{}).
+		Complete()
+}
+
+// TODO(user): change verbs to "verbs=create;update;delete" if you want to enable deletion validation.
+// +kubebuilder:webhook:path=/validate-otc-peertech-de-v1alpha1-virtualip,mutating=false,failurePolicy=fail,sideEffects=None,groups=otc.peertech.de,resources=virtualips,verbs=create;update,versions=v1alpha1,name=vvirtualip-v1alpha1.kb.io,admissionReviewVersions=v1
+
+// VirtualIPCustomValidator struct is responsible for validating the VirtualIP resource
+// when it is created, updated, or deleted.
+type VirtualIPCustomValidator struct{}
+
+var _ webhook.CustomValidator = &VirtualIPCustomValidator{}
+
+// ValidateCreate implements webhook.CustomValidator so a webhook will be registered for the type VirtualIP.
+func (v *VirtualIPCustomValidator) ValidateCreate(
+	_ context.Context,
+	obj runtime.Object,
+) (admission.Warnings, error) {
+	virtualIP, ok := obj.(*otcv1alpha1.VirtualIP)
+	if !ok {
+		return nil, fmt.Errorf("expected a VirtualIP object but got %T", obj)
+	}
+
+	var warnings admission.Warnings
+	var errors field.ErrorList
+
+	// Validate the resource name
+	if !validName.MatchString(virtualIP.Name) {
+		errors = append(errors, field.Invalid(
+			field.NewPath("metadata", "name"),
+			virtualIP.Name,
+			"name must contain only letters, digits, underscores (_), hyphens (-), and periods (.)",
+		))
+	}
+
+	// Validate ProviderConfigRef
+	if err := validateProviderConfigRefName(virtualIP.Spec.ProviderConfigRef); err != nil {
+		errors = append(errors, err)
+	}
+
+	// Validate that exactly one subnet dependency method is specified
+	if err := validateSubnetDependency(virtualIP.Spec.Subnet); err != nil {
+		errors = append(
+			errors,
+			field.Invalid(
+				field.NewPath("spec", "subnet"),
+				virtualIP.Spec.Subnet,
+				err.Error(),
+			),
+		)
+	}
+
+	// Validate IPAddress
+	if ipAddress := virtualIP.Spec.IPAddress; ipAddress != "" {
+		if err := validateIPv4(ipAddress); err != nil {
+			errors = append(
+				errors,
+				field.Invalid(
+					field.NewPath("spec", "ipAddress"),
+					ipAddress,
+					err.Error(),
+				),
+			)
+		}
+	}
+
+	// Validate WriteConnectionDetailsToRef
+	if err := validateConnectionDetailsRef(virtualIP.Spec.WriteConnectionDetailsToRef); err != nil {
+		errors = append(errors, err)
+	}
+
+	// Warn about orphanOnDelete if true
+	if virtualIP.Spec.OrphanOnDelete {
+		warnings = append(
+			warnings,
+			"orphanOnDelete is true: external virtual IP will not be deleted when this resource is deleted",
+		)
+	}
+
+	if len(errors) == 0 {
+		return warnings, nil
+	}
+
+	return warnings, apierrors.NewInvalid(
+		virtualIP.GroupVersionKind().GroupKind(),
+		virtualIP.Name,
+		errors,
+	)
+}
+
+// ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the type VirtualIP.
+func (v *VirtualIPCustomValidator) ValidateUpdate(
+	_ context.Context,
+	oldObj, newObj runtime.Object,
+) (admission.Warnings, error) {
+	oldVirtualIP, ok := oldObj.(*otcv1alpha1.VirtualIP)
+	if !ok {
+		return nil, fmt.Errorf("expected a VirtualIP object for the oldObj but got %T", newObj)
+	}
+	newVirtualIP, ok := newObj.(*otcv1alpha1.VirtualIP)
+	if !ok {
+		return nil, fmt.Errorf("expected a VirtualIP object for the newObj but got %T", newObj)
+	}
+
+	var warnings admission.Warnings
+	var errors field.ErrorList
+
+	// Check immutable ProviderConfigRef
+	if !equalProviderConfigRef(
+		oldVirtualIP.Spec.ProviderConfigRef,
+		newVirtualIP.Spec.ProviderConfigRef,
+	) {
+		errors = append(
+			errors,
+			field.Forbidden(
+				field.NewPath("spec", "providerConfigRef"),
+				"is immutable and cannot be changed after creation",
+			),
+		)
+	}
+
+	// Check immutable Subnet dependency
+	if !equalSubnetDependency(oldVirtualIP.Spec.Subnet, newVirtualIP.Spec.Subnet) {
+		errors = append(
+			errors,
+			field.Forbidden(
+				field.NewPath("spec", "subnet"),
+				"is immutable and cannot be changed after creation",
+			),
+		)
+	}
+
+	// Check immutable IPAddress
+	if oldVirtualIP.Spec.IPAddress != newVirtualIP.Spec.IPAddress {
+		errors = append(
+			errors,
+			field.Forbidden(
+				field.NewPath("spec", "ipAddress"),
+				"is immutable and cannot be changed after creation",
+			),
+		)
+	}
+
+	// Validate WriteConnectionDetailsToRef
+	if err := validateConnectionDetailsRef(newVirtualIP.Spec.WriteConnectionDetailsToRef); err != nil {
+		errors = append(errors, err)
+	}
+
+	// Warn if orphanOnDelete is being changed from false to true
+	if !oldVirtualIP.Spec.OrphanOnDelete && newVirtualIP.Spec.OrphanOnDelete {
+		warnings = append(
+			warnings,
+			"orphanOnDelete changed to true: external virtual IP will not be deleted when this resource is deleted",
+		)
+	}
+
+	// Warn if orphanOnDelete is being changed from true to false
+	if oldVirtualIP.Spec.OrphanOnDelete && !newVirtualIP.Spec.OrphanOnDelete {
+		warnings = append(
+			warnings,
+			"orphanOnDelete changed to false: external virtual IP will be deleted when this resource is deleted",
+		)
+	}
+
+	if len(errors) == 0 {
+		return warnings, nil
+	}
+
+	return warnings, apierrors.NewInvalid(
+		oldVirtualIP.GroupVersionKind().GroupKind(),
+		oldVirtualIP.Name,
+		errors,
+	)
+}
+
+// ValidateDelete implements webhook.CustomValidator so a webhook will be registered for the type VirtualIP.
+func (v *VirtualIPCustomValidator) ValidateDelete(
+	ctx context.Context,
+	obj runtime.Object,
+) (admission.Warnings, error) {
+	return nil, nil
+}
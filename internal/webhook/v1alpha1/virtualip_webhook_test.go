@@ -0,0 +1,108 @@
+package v1alpha1
+
+import (
+	"context"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	otcv1alpha1 "github.com/peertech.de/otc-operator/api/v1alpha1"
+)
+
+func newTestVirtualIP() *otcv1alpha1.VirtualIP {
+	subnetID := "subnet-id"
+	return &otcv1alpha1.VirtualIP{
+		ObjectMeta: metav1.ObjectMeta{Name: "vip"},
+		Spec: otcv1alpha1.VirtualIPSpec{
+			ProviderConfigRef: otcv1alpha1.ProviderConfigReference{Name: "provider"},
+			Subnet:            otcv1alpha1.SubnetDependency{SubnetID: &subnetID},
+			IPAddress:         "10.0.0.10",
+		},
+	}
+}
+
+func TestVirtualIPValidateCreate(t *testing.T) {
+	tests := []struct {
+		name    string
+		update  func(*otcv1alpha1.VirtualIP)
+		wantErr bool
+	}{
+		{
+			name:   "valid",
+			update: func(*otcv1alpha1.VirtualIP) {},
+		},
+		{
+			name: "assigned address",
+			update: func(virtualIP *otcv1alpha1.VirtualIP) {
+				virtualIP.Spec.IPAddress = ""
+			},
+		},
+		{
+			name: "invalid address",
+			update: func(virtualIP *otcv1alpha1.VirtualIP) {
+				virtualIP.Spec.IPAddress = "10.0.0"
+			},
+			wantErr: true,
+		},
+		{
+			name: "no subnet",
+			update: func(virtualIP *otcv1alpha1.VirtualIP) {
+				virtualIP.Spec.Subnet = otcv1alpha1.SubnetDependency{}
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			virtualIP := newTestVirtualIP()
+			tt.update(virtualIP)
+
+			_, err := (&VirtualIPCustomValidator{}).ValidateCreate(context.Background(), virtualIP)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Expected error %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestVirtualIPValidateUpdate(t *testing.T) {
+	tests := []struct {
+		name    string
+		update  func(*otcv1alpha1.VirtualIP)
+		wantErr bool
+	}{
+		{
+			name: "orphan on delete",
+			update: func(virtualIP *otcv1alpha1.VirtualIP) {
+				virtualIP.Spec.OrphanOnDelete = true
+			},
+		},
+		{
+			name: "subnet",
+			update: func(virtualIP *otcv1alpha1.VirtualIP) {
+				subnetID := "other-subnet-id"
+				virtualIP.Spec.Subnet = otcv1alpha1.SubnetDependency{SubnetID: &subnetID}
+			},
+			wantErr: true,
+		},
+		{
+			name: "address",
+			update: func(virtualIP *otcv1alpha1.VirtualIP) {
+				virtualIP.Spec.IPAddress = "10.0.0.11"
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			oldVirtualIP := newTestVirtualIP()
+			newVirtualIP := newTestVirtualIP()
+			tt.update(newVirtualIP)
+
+			_, err := (&VirtualIPCustomValidator{}).ValidateUpdate(context.Background(), oldVirtualIP, newVirtualIP)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Expected error %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
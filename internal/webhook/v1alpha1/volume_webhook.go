@@ -0,0 +1,249 @@
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	otcv1alpha1 "github.com/peertech.de/otc-operator/api/v1alpha1"
+)
+
+// SetupVolumeWebhookWithManager registers the webhook for Volume in the manager.
+func SetupVolumeWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).For(&otcv1alpha1.Volume{}).
+		WithValidator(&VolumeCustomValidator{}).
+		Complete()
+}
+
+// TODO(user): change verbs to "verbs=create;update;delete" if you want to enable deletion validation.
+// +kubebuilder:webhook:path=/validate-otc-peertech-de-v1alpha1-volume,mutating=false,failurePolicy=fail,sideEffects=None,groups=otc.peertech.de,resources=volumes,verbs=create;update,versions=v1alpha1,name=vvolume-v1alpha1.kb.io,admissionReviewVersions=v1
+
+// VolumeCustomValidator struct is responsible for validating the Volume resource
+// when it is created, updated, or deleted.
+type VolumeCustomValidator struct{}
+
+var _ webhook.CustomValidator = &VolumeCustomValidator{}
+
+// ValidateCreate implements webhook.CustomValidator so a webhook will be registered for the type Volume.
+func (v *VolumeCustomValidator) ValidateCreate(
+	_ context.Context,
+	obj runtime.Object,
+) (admission.Warnings, error) {
+	volume, ok := obj.(*otcv1alpha1.Volume)
+	if !ok {
+		return nil, fmt.Errorf("expected a Volume object but got %T", obj)
+	}
+
+	var warnings admission.Warnings
+	var errors field.ErrorList
+
+	// Validate the resource name
+	if !validName.MatchString(volume.Name) {
+		errors = append(errors, field.Invalid(
+			field.NewPath("metadata", "name"),
+			volume.Name,
+			"name must contain only letters, digits, underscores (_), hyphens (-), and periods (.)",
+		))
+	}
+
+	// Validate ProviderConfigRef
+	if err := validateProviderConfigRefName(volume.Spec.ProviderConfigRef); err != nil {
+		errors = append(errors, err)
+	}
+
+	// Validate the encryption key
+	if volume.Spec.KMSKey != nil {
+		if err := validateKMSKeyDependency(*volume.Spec.KMSKey); err != nil {
+			errors = append(
+				errors,
+				field.Invalid(
+					field.NewPath("spec", "kmsKey"),
+					volume.Spec.KMSKey,
+					err.Error(),
+				),
+			)
+		}
+	}
+
+	// Validate the attachment
+	errors = append(errors, validateVolumeAttachment(volume.Spec.Attachment)...)
+
+	// Validate WriteConnectionDetailsToRef
+	if err := validateConnectionDetailsRef(volume.Spec.WriteConnectionDetailsToRef); err != nil {
+		errors = append(errors, err)
+	}
+
+	// Warn about orphanOnDelete if true
+	if volume.Spec.OrphanOnDelete {
+		warnings = append(
+			warnings,
+			"orphanOnDelete is true: external volume will not be deleted when this resource is deleted",
+		)
+	}
+
+	if len(errors) == 0 {
+		return warnings, nil
+	}
+
+	return warnings, apierrors.NewInvalid(
+		volume.GroupVersionKind().GroupKind(),
+		volume.Name,
+		errors,
+	)
+}
+
+// ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the type Volume.
+func (v *VolumeCustomValidator) ValidateUpdate(
+	_ context.Context,
+	oldObj, newObj runtime.Object,
+) (admission.Warnings, error) {
+	oldVolume, ok := oldObj.(*otcv1alpha1.Volume)
+	if !ok {
+		return nil, fmt.Errorf("expected a Volume object for the oldObj but got %T", oldObj)
+	}
+	newVolume, ok := newObj.(*otcv1alpha1.Volume)
+	if !ok {
+		return nil, fmt.Errorf("expected a Volume object for the newObj but got %T", newObj)
+	}
+
+	var warnings admission.Warnings
+	var errors field.ErrorList
+
+	// Check immutable ProviderConfigRef
+	if !equalProviderConfigRef(
+		oldVolume.Spec.ProviderConfigRef,
+		newVolume.Spec.ProviderConfigRef,
+	) {
+		errors = append(
+			errors,
+			field.Forbidden(
+				field.NewPath("spec", "providerConfigRef"),
+				"is immutable and cannot be changed after creation",
+			),
+		)
+	}
+
+	// Check that the volume is not shrunk
+	if newVolume.Spec.Size < oldVolume.Spec.Size {
+		errors = append(
+			errors,
+			field.Invalid(
+				field.NewPath("spec", "size"),
+				newVolume.Spec.Size,
+				fmt.Sprintf("cannot be decreased from %d", oldVolume.Spec.Size),
+			),
+		)
+	}
+
+	// Check immutable type, availability zone and encryption key
+	if oldVolume.Spec.Type != newVolume.Spec.Type {
+		errors = append(
+			errors,
+			field.Forbidden(
+				field.NewPath("spec", "type"),
+				"is immutable and cannot be changed after creation",
+			),
+		)
+	}
+	if oldVolume.Spec.AvailabilityZone != newVolume.Spec.AvailabilityZone {
+		errors = append(
+			errors,
+			field.Forbidden(
+				field.NewPath("spec", "availabilityZone"),
+				"is immutable and cannot be changed after creation",
+			),
+		)
+	}
+	if !equalKMSKeyDependency(oldVolume.Spec.KMSKey, newVolume.Spec.KMSKey) {
+		errors = append(
+			errors,
+			field.Forbidden(
+				field.NewPath("spec", "kmsKey"),
+				"is immutable and cannot be changed after creation",
+			),
+		)
+	}
+
+	// Validate the attachment
+	errors = append(errors, validateVolumeAttachment(newVolume.Spec.Attachment)...)
+
+	// Validate WriteConnectionDetailsToRef
+	if err := validateConnectionDetailsRef(newVolume.Spec.WriteConnectionDetailsToRef); err != nil {
+		errors = append(errors, err)
+	}
+
+	// Warn if orphanOnDelete is being changed from false to true
+	if !oldVolume.Spec.OrphanOnDelete && newVolume.Spec.OrphanOnDelete {
+		warnings = append(
+			warnings,
+			"orphanOnDelete changed to true: external volume will not be deleted when this resource is deleted",
+		)
+	}
+
+	// Warn if orphanOnDelete is being changed from true to false
+	if oldVolume.Spec.OrphanOnDelete && !newVolume.Spec.OrphanOnDelete {
+		warnings = append(
+			warnings,
+			"orphanOnDelete changed to false: external volume will be deleted when this resource is deleted",
+		)
+	}
+
+	if len(errors) == 0 {
+		return warnings, nil
+	}
+
+	return warnings, apierrors.NewInvalid(
+		oldVolume.GroupVersionKind().GroupKind(),
+		oldVolume.Name,
+		errors,
+	)
+}
+
+// ValidateDelete implements webhook.CustomValidator so a webhook will be registered for the type Volume.
+func (v *VolumeCustomValidator) ValidateDelete(
+	ctx context.Context,
+	obj runtime.Object,
+) (admission.Warnings, error) {
+	return nil, nil
+}
+
+func validateVolumeAttachment(attachment *otcv1alpha1.VolumeAttachment) field.ErrorList {
+	var errors field.ErrorList
+	if attachment == nil {
+		return errors
+	}
+
+	path := field.NewPath("spec", "attachment")
+
+	// Validate that exactly one instance dependency method is specified
+	if err := validateInstanceDependency(attachment.Instance); err != nil {
+		errors = append(
+			errors,
+			field.Invalid(
+				path.Child("instance"),
+				attachment.Instance,
+				err.Error(),
+			),
+		)
+	}
+
+	if device := attachment.Device; device != "" && !strings.HasPrefix(device, "/dev/") {
+		errors = append(
+			errors,
+			field.Invalid(
+				path.Child("device"),
+				device,
+				"must be a device path starting with /dev/",
+			),
+		)
+	}
+
+	return errors
+}
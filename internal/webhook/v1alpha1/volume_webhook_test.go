@@ -0,0 +1,125 @@
+package v1alpha1
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	otcv1alpha1 "github.com/peertech.de/otc-operator/api/v1alpha1"
+)
+
+func newTestVolume() *otcv1alpha1.Volume {
+	return &otcv1alpha1.Volume{
+		ObjectMeta: metav1.ObjectMeta{Name: "volume"},
+		Spec: otcv1alpha1.VolumeSpec{
+			ProviderConfigRef: otcv1alpha1.ProviderConfigReference{Name: "provider"},
+			Size:              10,
+			Type:              otcv1alpha1.VolumeTypeSSD,
+			AvailabilityZone:  "eu-de-01",
+		},
+	}
+}
+
+func TestVolumeValidateCreate(t *testing.T) {
+	tests := []struct {
+		name    string
+		update  func(*otcv1alpha1.Volume)
+		wantErr bool
+	}{
+		{
+			name:   "valid",
+			update: func(*otcv1alpha1.Volume) {},
+		},
+		{
+			name: "attachment",
+			update: func(volume *otcv1alpha1.Volume) {
+				volume.Spec.Attachment = &otcv1alpha1.VolumeAttachment{
+					Instance: otcv1alpha1.InstanceDependency{
+						InstanceRef: &corev1.LocalObjectReference{Name: "instance"},
+					},
+					Device: "/dev/vdb",
+				}
+			},
+		},
+		{
+			name: "attachment without instance",
+			update: func(volume *otcv1alpha1.Volume) {
+				volume.Spec.Attachment = &otcv1alpha1.VolumeAttachment{}
+			},
+			wantErr: true,
+		},
+		{
+			name: "attachment with invalid device",
+			update: func(volume *otcv1alpha1.Volume) {
+				volume.Spec.Attachment = &otcv1alpha1.VolumeAttachment{
+					Instance: otcv1alpha1.InstanceDependency{
+						InstanceRef: &corev1.LocalObjectReference{Name: "instance"},
+					},
+					Device: "vdb",
+				}
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			volume := newTestVolume()
+			tt.update(volume)
+
+			_, err := (&VolumeCustomValidator{}).ValidateCreate(context.Background(), volume)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Expected error %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestVolumeValidateUpdate(t *testing.T) {
+	tests := []struct {
+		name    string
+		update  func(*otcv1alpha1.Volume)
+		wantErr bool
+	}{
+		{
+			name: "larger size",
+			update: func(volume *otcv1alpha1.Volume) {
+				volume.Spec.Size = 20
+			},
+		},
+		{
+			name: "smaller size",
+			update: func(volume *otcv1alpha1.Volume) {
+				volume.Spec.Size = 5
+			},
+			wantErr: true,
+		},
+		{
+			name: "type",
+			update: func(volume *otcv1alpha1.Volume) {
+				volume.Spec.Type = otcv1alpha1.VolumeTypeSAS
+			},
+			wantErr: true,
+		},
+		{
+			name: "availability zone",
+			update: func(volume *otcv1alpha1.Volume) {
+				volume.Spec.AvailabilityZone = "eu-de-02"
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			oldVolume := newTestVolume()
+			newVolume := newTestVolume()
+			tt.update(newVolume)
+
+			_, err := (&VolumeCustomValidator{}).ValidateUpdate(context.Background(), oldVolume, newVolume)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Expected error %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
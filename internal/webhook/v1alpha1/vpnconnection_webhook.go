@@ -0,0 +1,269 @@
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	otcv1alpha1 "github.com/peertech.de/otc-operator/api/v1alpha1"
+)
+
+// SetupVPNConnectionWebhookWithManager registers the webhook for VPNConnection in the manager.
+func SetupVPNConnectionWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).For(&otcv1alpha1.VPNConnection{}).
+		WithValidator(&VPNConnectionCustomValidator{}).
+		Complete()
+}
+
+// TODO(user): change verbs to "verbs=create;update;delete" if you want to enable deletion validation.
+// +kubebuilder:webhook:path=/validate-otc-peertech-de-v1alpha1-vpnconnection,mutating=false,failurePolicy=fail,sideEffects=None,groups=otc.peertech.de,resources=vpnconnections,verbs=create;update,versions=v1alpha1,name=vvpnconnection-v1alpha1.kb.io,admissionReviewVersions=v1
+
+// VPNConnectionCustomValidator struct is responsible for validating the VPNConnection resource
+// when it is created, updated, or deleted.
+type VPNConnectionCustomValidator struct{}
+
+var _ webhook.CustomValidator = &VPNConnectionCustomValidator{}
+
+// ValidateCreate implements webhook.CustomValidator so a webhook will be registered for the type VPNConnection.
+func (v *VPNConnectionCustomValidator) ValidateCreate(
+	_ context.Context,
+	obj runtime.Object,
+) (admission.Warnings, error) {
+	vpnConnection, ok := obj.(*otcv1alpha1.VPNConnection)
+	if !ok {
+		return nil, fmt.Errorf("expected a VPNConnection object but got %T", obj)
+	}
+
+	var warnings admission.Warnings
+	var errors field.ErrorList
+
+	// Validate the resource name
+	if !validName.MatchString(vpnConnection.Name) {
+		errors = append(errors, field.Invalid(
+			field.NewPath("metadata", "name"),
+			vpnConnection.Name,
+			"name must contain only letters, digits, underscores (_), hyphens (-), and periods (.)",
+		))
+	}
+
+	// Validate ProviderConfigRef
+	if err := validateProviderConfigRefName(vpnConnection.Spec.ProviderConfigRef); err != nil {
+		errors = append(errors, err)
+	}
+
+	// Validate that exactly one VPN gateway dependency method is specified
+	if err := validateVPNGatewayDependency(vpnConnection.Spec.Gateway); err != nil {
+		errors = append(
+			errors,
+			field.Invalid(
+				field.NewPath("spec", "gateway"),
+				vpnConnection.Spec.Gateway,
+				err.Error(),
+			),
+		)
+	}
+
+	// Validate that exactly one customer gateway dependency method is specified
+	if err := validateCustomerGatewayDependency(vpnConnection.Spec.CustomerGateway); err != nil {
+		errors = append(
+			errors,
+			field.Invalid(
+				field.NewPath("spec", "customerGateway"),
+				vpnConnection.Spec.CustomerGateway,
+				err.Error(),
+			),
+		)
+	}
+
+	// Validate the peer subnets and the pre-shared key
+	errors = append(errors, validateVPNConnectionSpec(vpnConnection.Spec)...)
+
+	// Validate WriteConnectionDetailsToRef
+	if err := validateConnectionDetailsRef(vpnConnection.Spec.WriteConnectionDetailsToRef); err != nil {
+		errors = append(errors, err)
+	}
+
+	// Warn about orphanOnDelete if true
+	if vpnConnection.Spec.OrphanOnDelete {
+		warnings = append(
+			warnings,
+			"orphanOnDelete is true: external VPN connection will not be deleted when this resource is deleted",
+		)
+	}
+
+	if len(errors) == 0 {
+		return warnings, nil
+	}
+
+	return warnings, apierrors.NewInvalid(
+		vpnConnection.GroupVersionKind().GroupKind(),
+		vpnConnection.Name,
+		errors,
+	)
+}
+
+// ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the type VPNConnection.
+func (v *VPNConnectionCustomValidator) ValidateUpdate(
+	_ context.Context,
+	oldObj, newObj runtime.Object,
+) (admission.Warnings, error) {
+	oldVPNConnection, ok := oldObj.(*otcv1alpha1.VPNConnection)
+	if !ok {
+		return nil, fmt.Errorf("expected a VPNConnection object for the oldObj but got %T", oldObj)
+	}
+	newVPNConnection, ok := newObj.(*otcv1alpha1.VPNConnection)
+	if !ok {
+		return nil, fmt.Errorf("expected a VPNConnection object for the newObj but got %T", newObj)
+	}
+
+	var warnings admission.Warnings
+	var errors field.ErrorList
+
+	// Check immutable ProviderConfigRef
+	if !equalProviderConfigRef(
+		oldVPNConnection.Spec.ProviderConfigRef,
+		newVPNConnection.Spec.ProviderConfigRef,
+	) {
+		errors = append(
+			errors,
+			field.Forbidden(
+				field.NewPath("spec", "providerConfigRef"),
+				"is immutable and cannot be changed after creation",
+			),
+		)
+	}
+
+	// Check immutable gateway dependencies
+	if !equalVPNGatewayDependency(oldVPNConnection.Spec.Gateway, newVPNConnection.Spec.Gateway) {
+		errors = append(
+			errors,
+			field.Forbidden(
+				field.NewPath("spec", "gateway"),
+				"is immutable and cannot be changed after creation",
+			),
+		)
+	}
+	if !equalCustomerGatewayDependency(
+		oldVPNConnection.Spec.CustomerGateway,
+		newVPNConnection.Spec.CustomerGateway,
+	) {
+		errors = append(
+			errors,
+			field.Forbidden(
+				field.NewPath("spec", "customerGateway"),
+				"is immutable and cannot be changed after creation",
+			),
+		)
+	}
+
+	// Check immutable RoutingMode
+	if oldVPNConnection.Spec.RoutingMode != newVPNConnection.Spec.RoutingMode {
+		errors = append(
+			errors,
+			field.Forbidden(
+				field.NewPath("spec", "routingMode"),
+				"is immutable and cannot be changed after creation",
+			),
+		)
+	}
+
+	// Validate the peer subnets and the pre-shared key
+	errors = append(errors, validateVPNConnectionSpec(newVPNConnection.Spec)...)
+
+	// Validate WriteConnectionDetailsToRef
+	if err := validateConnectionDetailsRef(newVPNConnection.Spec.WriteConnectionDetailsToRef); err != nil {
+		errors = append(errors, err)
+	}
+
+	// Warn about policy changes
+	if oldVPNConnection.Spec.IKEPolicy != newVPNConnection.Spec.IKEPolicy ||
+		oldVPNConnection.Spec.IPsecPolicy != newVPNConnection.Spec.IPsecPolicy {
+		warnings = append(
+			warnings,
+			"changing the IKE or IPsec policy renegotiates the tunnel and requires the same change on the customer gateway",
+		)
+	}
+
+	// Warn if orphanOnDelete is being changed from false to true
+	if !oldVPNConnection.Spec.OrphanOnDelete && newVPNConnection.Spec.OrphanOnDelete {
+		warnings = append(
+			warnings,
+			"orphanOnDelete changed to true: external VPN connection will not be deleted when this resource is deleted",
+		)
+	}
+
+	// Warn if orphanOnDelete is being changed from true to false
+	if oldVPNConnection.Spec.OrphanOnDelete && !newVPNConnection.Spec.OrphanOnDelete {
+		warnings = append(
+			warnings,
+			"orphanOnDelete changed to false: external VPN connection will be deleted when this resource is deleted",
+		)
+	}
+
+	if len(errors) == 0 {
+		return warnings, nil
+	}
+
+	return warnings, apierrors.NewInvalid(
+		oldVPNConnection.GroupVersionKind().GroupKind(),
+		oldVPNConnection.Name,
+		errors,
+	)
+}
+
+// ValidateDelete implements webhook.CustomValidator so a webhook will be registered for the type VPNConnection.
+func (v *VPNConnectionCustomValidator) ValidateDelete(
+	ctx context.Context,
+	obj runtime.Object,
+) (admission.Warnings, error) {
+	return nil, nil
+}
+
+func validateVPNConnectionSpec(spec otcv1alpha1.VPNConnectionSpec) field.ErrorList {
+	var errors field.ErrorList
+
+	path := field.NewPath("spec", "peerSubnets")
+	if len(spec.PeerSubnets) == 0 {
+		errors = append(errors, field.Required(path, "at least one peer subnet is required"))
+	}
+	for i, cidr := range spec.PeerSubnets {
+		if err := validateCIDR(cidr); err != nil {
+			errors = append(errors, field.Invalid(path.Index(i), cidr, err.Error()))
+		}
+	}
+
+	ref := spec.PreSharedKeySecretRef
+	if err := validateSecretKeySelector(ref); err != nil {
+		errors = append(
+			errors,
+			field.Invalid(
+				field.NewPath("spec", "preSharedKeySecretRef"),
+				ref,
+				err.Error(),
+			),
+		)
+	}
+
+	// The connection details replace the contents of their Secret, which
+	// would drop the pre-shared key.
+	detailsRef := spec.WriteConnectionDetailsToRef
+	if detailsRef != nil && detailsRef.Kind != otcv1alpha1.ConnectionDetailsConfigMap &&
+		detailsRef.Name == ref.Name {
+		errors = append(
+			errors,
+			field.Invalid(
+				field.NewPath("spec", "writeConnectionDetailsToRef", "name"),
+				detailsRef.Name,
+				"must not be the Secret the pre-shared key is read from",
+			),
+		)
+	}
+
+	return errors
+}
@@ -0,0 +1,123 @@
+package v1alpha1
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	otcv1alpha1 "github.com/peertech.de/otc-operator/api/v1alpha1"
+)
+
+func newTestVPNConnection() *otcv1alpha1.VPNConnection {
+	return &otcv1alpha1.VPNConnection{
+		ObjectMeta: metav1.ObjectMeta{Name: "vpn-connection"},
+		Spec: otcv1alpha1.VPNConnectionSpec{
+			ProviderConfigRef: otcv1alpha1.ProviderConfigReference{Name: "provider"},
+			Gateway: otcv1alpha1.VPNGatewayDependency{
+				VPNGatewayRef: &corev1.LocalObjectReference{Name: "vpn-gateway"},
+			},
+			CustomerGateway: otcv1alpha1.CustomerGatewayDependency{
+				CustomerGatewayRef: &corev1.LocalObjectReference{Name: "customer-gateway"},
+			},
+			PeerSubnets: []string{"192.168.0.0/24"},
+			PreSharedKeySecretRef: corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "psk"},
+				Key:                  "key",
+			},
+		},
+	}
+}
+
+func TestVPNConnectionValidateCreate(t *testing.T) {
+	tests := []struct {
+		name    string
+		update  func(*otcv1alpha1.VPNConnection)
+		wantErr bool
+	}{
+		{
+			name:   "valid",
+			update: func(*otcv1alpha1.VPNConnection) {},
+		},
+		{
+			name: "no peer subnets",
+			update: func(vpnConnection *otcv1alpha1.VPNConnection) {
+				vpnConnection.Spec.PeerSubnets = nil
+			},
+			wantErr: true,
+		},
+		{
+			name: "no pre-shared key",
+			update: func(vpnConnection *otcv1alpha1.VPNConnection) {
+				vpnConnection.Spec.PreSharedKeySecretRef = corev1.SecretKeySelector{}
+			},
+			wantErr: true,
+		},
+		{
+			name: "connection details in pre-shared key Secret",
+			update: func(vpnConnection *otcv1alpha1.VPNConnection) {
+				vpnConnection.Spec.WriteConnectionDetailsToRef = &otcv1alpha1.ConnectionDetailsReference{
+					Name: vpnConnection.Spec.PreSharedKeySecretRef.Name,
+				}
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vpnConnection := newTestVPNConnection()
+			tt.update(vpnConnection)
+
+			_, err := (&VPNConnectionCustomValidator{}).ValidateCreate(context.Background(), vpnConnection)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Expected error %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestVPNConnectionValidateUpdate(t *testing.T) {
+	tests := []struct {
+		name    string
+		update  func(*otcv1alpha1.VPNConnection)
+		wantErr bool
+	}{
+		{
+			name: "peer subnets",
+			update: func(vpnConnection *otcv1alpha1.VPNConnection) {
+				vpnConnection.Spec.PeerSubnets = []string{"192.168.1.0/24"}
+			},
+		},
+		{
+			name: "gateway",
+			update: func(vpnConnection *otcv1alpha1.VPNConnection) {
+				vpnConnection.Spec.Gateway.VPNGatewayRef.Name = "other"
+			},
+			wantErr: true,
+		},
+		{
+			name: "routing mode",
+			update: func(vpnConnection *otcv1alpha1.VPNConnection) {
+				vpnConnection.Spec.RoutingMode = otcv1alpha1.VPNConnectionPolicy
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			oldVPNConnection := newTestVPNConnection()
+			newVPNConnection := newTestVPNConnection()
+			tt.update(newVPNConnection)
+
+			_, err := (&VPNConnectionCustomValidator{}).ValidateUpdate(
+				context.Background(),
+				oldVPNConnection,
+				newVPNConnection,
+			)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Expected error %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,329 @@
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	otcv1alpha1 "github.com/peertech.de/otc-operator/api/v1alpha1"
+)
+
+// SetupVPNGatewayWebhookWithManager registers the webhook for VPNGateway in the manager.
+func SetupVPNGatewayWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).For(&otcv1alpha1.VPNGateway{}).
+		WithValidator(&VPNGatewayCustomValidator{}).
+		Complete()
+}
+
+// TODO(user): change verbs to "verbs=create;update;delete" if you want to enable deletion validation.
+// +kubebuilder:webhook:path=/validate-otc-peertech-de-v1alpha1-vpngateway,mutating=false,failurePolicy=fail,sideEffects=None,groups=otc.peertech.de,resources=vpngateways,verbs=create;update,versions=v1alpha1,name=vvpngateway-v1alpha1.kb.io,admissionReviewVersions=v1
+
+// VPNGatewayCustomValidator struct is responsible for validating the VPNGateway resource
+// when it is created, updated, or deleted.
+type VPNGatewayCustomValidator struct{}
+
+var _ webhook.CustomValidator = &VPNGatewayCustomValidator{}
+
+// ValidateCreate implements webhook.CustomValidator so a webhook will be registered for the type VPNGateway.
+func (v *VPNGatewayCustomValidator) ValidateCreate(
+	_ context.Context,
+	obj runtime.Object,
+) (admission.Warnings, error) {
+	vpnGateway, ok := obj.(*otcv1alpha1.VPNGateway)
+	if !ok {
+		return nil, fmt.Errorf("expected a VPNGateway object but got %T", obj)
+	}
+
+	var warnings admission.Warnings
+	var errors field.ErrorList
+
+	// Validate the resource name
+	if !validName.MatchString(vpnGateway.Name) {
+		errors = append(errors, field.Invalid(
+			field.NewPath("metadata", "name"),
+			vpnGateway.Name,
+			"name must contain only letters, digits, underscores (_), hyphens (-), and periods (.)",
+		))
+	}
+
+	// Validate ProviderConfigRef
+	if err := validateProviderConfigRefName(vpnGateway.Spec.ProviderConfigRef); err != nil {
+		errors = append(errors, err)
+	}
+
+	// Validate that exactly one network dependency method is specified
+	if err := validateNetworkDependency(vpnGateway.Spec.Network); err != nil {
+		errors = append(
+			errors,
+			field.Invalid(
+				field.NewPath("spec", "network"),
+				vpnGateway.Spec.Network,
+				err.Error(),
+			),
+		)
+	}
+
+	// Validate that exactly one subnet dependency method is specified
+	if err := validateSubnetDependency(vpnGateway.Spec.Subnet); err != nil {
+		errors = append(
+			errors,
+			field.Invalid(
+				field.NewPath("spec", "subnet"),
+				vpnGateway.Spec.Subnet,
+				err.Error(),
+			),
+		)
+	}
+
+	// Validate the public IPs
+	errors = append(errors, validateVPNGatewayPublicIPs(vpnGateway.Spec)...)
+
+	// Validate the local subnets
+	errors = append(errors, validateVPNGatewayLocalSubnets(vpnGateway.Spec)...)
+
+	// Validate WriteConnectionDetailsToRef
+	if err := validateConnectionDetailsRef(vpnGateway.Spec.WriteConnectionDetailsToRef); err != nil {
+		errors = append(errors, err)
+	}
+
+	// Warn about orphanOnDelete if true
+	if vpnGateway.Spec.OrphanOnDelete {
+		warnings = append(
+			warnings,
+			"orphanOnDelete is true: external VPN gateway will not be deleted when this resource is deleted",
+		)
+	}
+
+	if len(errors) == 0 {
+		return warnings, nil
+	}
+
+	return warnings, apierrors.NewInvalid(
+		vpnGateway.GroupVersionKind().GroupKind(),
+		vpnGateway.Name,
+		errors,
+	)
+}
+
+// ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the type VPNGateway.
+func (v *VPNGatewayCustomValidator) ValidateUpdate(
+	_ context.Context,
+	oldObj, newObj runtime.Object,
+) (admission.Warnings, error) {
+	oldVPNGateway, ok := oldObj.(*otcv1alpha1.VPNGateway)
+	if !ok {
+		return nil, fmt.Errorf("expected a VPNGateway object for the oldObj but got %T", oldObj)
+	}
+	newVPNGateway, ok := newObj.(*otcv1alpha1.VPNGateway)
+	if !ok {
+		return nil, fmt.Errorf("expected a VPNGateway object for the newObj but got %T", newObj)
+	}
+
+	var warnings admission.Warnings
+	var errors field.ErrorList
+
+	// Check immutable ProviderConfigRef
+	if !equalProviderConfigRef(
+		oldVPNGateway.Spec.ProviderConfigRef,
+		newVPNGateway.Spec.ProviderConfigRef,
+	) {
+		errors = append(
+			errors,
+			field.Forbidden(
+				field.NewPath("spec", "providerConfigRef"),
+				"is immutable and cannot be changed after creation",
+			),
+		)
+	}
+
+	// Check immutable Network dependency
+	if !equalNetworkDependency(oldVPNGateway.Spec.Network, newVPNGateway.Spec.Network) {
+		errors = append(
+			errors,
+			field.Forbidden(
+				field.NewPath("spec", "network"),
+				"is immutable and cannot be changed after creation",
+			),
+		)
+	}
+
+	// Check immutable Subnet dependency
+	if !equalSubnetDependency(oldVPNGateway.Spec.Subnet, newVPNGateway.Spec.Subnet) {
+		errors = append(
+			errors,
+			field.Forbidden(
+				field.NewPath("spec", "subnet"),
+				"is immutable and cannot be changed after creation",
+			),
+		)
+	}
+
+	// Check immutable public IP dependencies
+	if !equalPublicIPDependency(
+		oldVPNGateway.Spec.PrimaryPublicIP,
+		newVPNGateway.Spec.PrimaryPublicIP,
+	) {
+		errors = append(
+			errors,
+			field.Forbidden(
+				field.NewPath("spec", "primaryPublicIP"),
+				"is immutable and cannot be changed after creation",
+			),
+		)
+	}
+	if !equalPublicIPDependency(
+		oldVPNGateway.Spec.SecondaryPublicIP,
+		newVPNGateway.Spec.SecondaryPublicIP,
+	) {
+		errors = append(
+			errors,
+			field.Forbidden(
+				field.NewPath("spec", "secondaryPublicIP"),
+				"is immutable and cannot be changed after creation",
+			),
+		)
+	}
+
+	// Check immutable gateway settings
+	if oldVPNGateway.Spec.Flavor != newVPNGateway.Spec.Flavor {
+		errors = append(
+			errors,
+			field.Forbidden(
+				field.NewPath("spec", "flavor"),
+				"is immutable and cannot be changed after creation",
+			),
+		)
+	}
+	if !slices.Equal(oldVPNGateway.Spec.AvailabilityZones, newVPNGateway.Spec.AvailabilityZones) {
+		errors = append(
+			errors,
+			field.Forbidden(
+				field.NewPath("spec", "availabilityZones"),
+				"is immutable and cannot be changed after creation",
+			),
+		)
+	}
+	if oldVPNGateway.Spec.BGPASN != newVPNGateway.Spec.BGPASN {
+		errors = append(
+			errors,
+			field.Forbidden(
+				field.NewPath("spec", "bgpASN"),
+				"is immutable and cannot be changed after creation",
+			),
+		)
+	}
+	if oldVPNGateway.Spec.HAMode != newVPNGateway.Spec.HAMode {
+		errors = append(
+			errors,
+			field.Forbidden(
+				field.NewPath("spec", "haMode"),
+				"is immutable and cannot be changed after creation",
+			),
+		)
+	}
+
+	// Validate the local subnets
+	errors = append(errors, validateVPNGatewayLocalSubnets(newVPNGateway.Spec)...)
+
+	// Validate WriteConnectionDetailsToRef
+	if err := validateConnectionDetailsRef(newVPNGateway.Spec.WriteConnectionDetailsToRef); err != nil {
+		errors = append(errors, err)
+	}
+
+	// Warn if orphanOnDelete is being changed from false to true
+	if !oldVPNGateway.Spec.OrphanOnDelete && newVPNGateway.Spec.OrphanOnDelete {
+		warnings = append(
+			warnings,
+			"orphanOnDelete changed to true: external VPN gateway will not be deleted when this resource is deleted",
+		)
+	}
+
+	// Warn if orphanOnDelete is being changed from true to false
+	if oldVPNGateway.Spec.OrphanOnDelete && !newVPNGateway.Spec.OrphanOnDelete {
+		warnings = append(
+			warnings,
+			"orphanOnDelete changed to false: external VPN gateway will be deleted when this resource is deleted",
+		)
+	}
+
+	if len(errors) == 0 {
+		return warnings, nil
+	}
+
+	return warnings, apierrors.NewInvalid(
+		oldVPNGateway.GroupVersionKind().GroupKind(),
+		oldVPNGateway.Name,
+		errors,
+	)
+}
+
+// ValidateDelete implements webhook.CustomValidator so a webhook will be registered for the type VPNGateway.
+func (v *VPNGatewayCustomValidator) ValidateDelete(
+	ctx context.Context,
+	obj runtime.Object,
+) (admission.Warnings, error) {
+	return nil, nil
+}
+
+func validateVPNGatewayPublicIPs(spec otcv1alpha1.VPNGatewaySpec) field.ErrorList {
+	var errors field.ErrorList
+
+	if err := validatePublicIPDependency(spec.PrimaryPublicIP); err != nil {
+		errors = append(
+			errors,
+			field.Invalid(
+				field.NewPath("spec", "primaryPublicIP"),
+				spec.PrimaryPublicIP,
+				err.Error(),
+			),
+		)
+	}
+
+	if err := validatePublicIPDependency(spec.SecondaryPublicIP); err != nil {
+		errors = append(
+			errors,
+			field.Invalid(
+				field.NewPath("spec", "secondaryPublicIP"),
+				spec.SecondaryPublicIP,
+				err.Error(),
+			),
+		)
+	}
+
+	if len(errors) == 0 && equalPublicIPDependency(spec.PrimaryPublicIP, spec.SecondaryPublicIP) {
+		errors = append(
+			errors,
+			field.Invalid(
+				field.NewPath("spec", "secondaryPublicIP"),
+				spec.SecondaryPublicIP,
+				"must be different from primaryPublicIP",
+			),
+		)
+	}
+
+	return errors
+}
+
+func validateVPNGatewayLocalSubnets(spec otcv1alpha1.VPNGatewaySpec) field.ErrorList {
+	var errors field.ErrorList
+
+	path := field.NewPath("spec", "localSubnets")
+	if len(spec.LocalSubnets) == 0 {
+		errors = append(errors, field.Required(path, "at least one local subnet is required"))
+	}
+
+	for i, cidr := range spec.LocalSubnets {
+		if err := validateCIDR(cidr); err != nil {
+			errors = append(errors, field.Invalid(path.Index(i), cidr, err.Error()))
+		}
+	}
+
+	return errors
+}
@@ -0,0 +1,116 @@
+package v1alpha1
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	otcv1alpha1 "github.com/peertech.de/otc-operator/api/v1alpha1"
+)
+
+func newTestVPNGateway() *otcv1alpha1.VPNGateway {
+	networkID, subnetID := "network-id", "subnet-id"
+	return &otcv1alpha1.VPNGateway{
+		ObjectMeta: metav1.ObjectMeta{Name: "vpn-gateway"},
+		Spec: otcv1alpha1.VPNGatewaySpec{
+			ProviderConfigRef: otcv1alpha1.ProviderConfigReference{Name: "provider"},
+			Network:           otcv1alpha1.NetworkDependency{NetworkID: &networkID},
+			Subnet:            otcv1alpha1.SubnetDependency{SubnetID: &subnetID},
+			LocalSubnets:      []string{"10.0.0.0/24"},
+			PrimaryPublicIP: otcv1alpha1.PublicIPDependency{
+				PublicIPRef: &corev1.LocalObjectReference{Name: "public-ip-1"},
+			},
+			SecondaryPublicIP: otcv1alpha1.PublicIPDependency{
+				PublicIPRef: &corev1.LocalObjectReference{Name: "public-ip-2"},
+			},
+		},
+	}
+}
+
+func TestVPNGatewayValidateCreate(t *testing.T) {
+	tests := []struct {
+		name    string
+		update  func(*otcv1alpha1.VPNGateway)
+		wantErr bool
+	}{
+		{
+			name:   "valid",
+			update: func(*otcv1alpha1.VPNGateway) {},
+		},
+		{
+			name: "same public IPs",
+			update: func(vpnGateway *otcv1alpha1.VPNGateway) {
+				vpnGateway.Spec.SecondaryPublicIP = vpnGateway.Spec.PrimaryPublicIP
+			},
+			wantErr: true,
+		},
+		{
+			name: "no local subnets",
+			update: func(vpnGateway *otcv1alpha1.VPNGateway) {
+				vpnGateway.Spec.LocalSubnets = nil
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid local subnet",
+			update: func(vpnGateway *otcv1alpha1.VPNGateway) {
+				vpnGateway.Spec.LocalSubnets = []string{"10.0.0.0"}
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vpnGateway := newTestVPNGateway()
+			tt.update(vpnGateway)
+
+			_, err := (&VPNGatewayCustomValidator{}).ValidateCreate(context.Background(), vpnGateway)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Expected error %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestVPNGatewayValidateUpdate(t *testing.T) {
+	tests := []struct {
+		name    string
+		update  func(*otcv1alpha1.VPNGateway)
+		wantErr bool
+	}{
+		{
+			name: "local subnets",
+			update: func(vpnGateway *otcv1alpha1.VPNGateway) {
+				vpnGateway.Spec.LocalSubnets = append(vpnGateway.Spec.LocalSubnets, "10.0.1.0/24")
+			},
+		},
+		{
+			name: "primary public IP",
+			update: func(vpnGateway *otcv1alpha1.VPNGateway) {
+				vpnGateway.Spec.PrimaryPublicIP.PublicIPRef.Name = "public-ip-3"
+			},
+			wantErr: true,
+		},
+		{
+			name: "BGP ASN",
+			update: func(vpnGateway *otcv1alpha1.VPNGateway) {
+				vpnGateway.Spec.BGPASN = 65001
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			oldVPNGateway := newTestVPNGateway()
+			newVPNGateway := newTestVPNGateway()
+			tt.update(newVPNGateway)
+
+			_, err := (&VPNGatewayCustomValidator{}).ValidateUpdate(context.Background(), oldVPNGateway, newVPNGateway)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Expected error %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}